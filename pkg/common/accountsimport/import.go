@@ -0,0 +1,202 @@
+package accountsimport
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	"github.com/cyberark/idsec-sdk-golang/pkg/validation"
+)
+
+// Row import statuses.
+const (
+	StatusCreated = "created"
+	StatusFailed  = "failed"
+	StatusInvalid = "invalid"
+)
+
+// DefaultConcurrency is the number of concurrent account creations used when none is configured.
+const DefaultConcurrency = 5
+
+// Options configures an import run.
+type Options struct {
+	// ColumnMapping maps input column names to model keys, e.g. {"Host": "address"}.
+	// A target of "-" drops the column. Columns that are neither mapped nor model keys make their row invalid.
+	ColumnMapping map[string]string
+	// Concurrency bounds the number of concurrent account creations.
+	Concurrency int
+	// CreateMissingSafes creates any safe referenced by a valid row that does not exist yet.
+	CreateMissingSafes bool
+}
+
+// Target binds the import engine to a concrete accounts API.
+type Target[T any] struct {
+	// EnsureSafe makes sure the named safe exists. Only called when Options.CreateMissingSafes is set.
+	EnsureSafe func(safeName string) error
+	// Create adds the account and returns its ID.
+	Create func(account *T) (string, error)
+}
+
+// RowResult is the outcome of importing a single record.
+type RowResult struct {
+	Row       int    `json:"row" mapstructure:"row" desc:"The input row number"`
+	SafeName  string `json:"safe_name" mapstructure:"safe_name" desc:"The safe of the account"`
+	Username  string `json:"username" mapstructure:"username" desc:"The account username"`
+	Address   string `json:"address" mapstructure:"address" desc:"The account address"`
+	AccountID string `json:"account_id,omitempty" mapstructure:"account_id,omitempty" desc:"The ID of the created account"`
+	Status    string `json:"status" mapstructure:"status" desc:"The row outcome (created, failed, invalid)" choices:"created,failed,invalid"`
+	Error     string `json:"error,omitempty" mapstructure:"error,omitempty" desc:"The reason the row was not imported"`
+}
+
+// Result summarizes an import run. Rows are ordered by input row number.
+type Result struct {
+	Total   int          `json:"total" mapstructure:"total" desc:"The number of input rows"`
+	Created int          `json:"created" mapstructure:"created" desc:"The number of accounts created"`
+	Failed  int          `json:"failed" mapstructure:"failed" desc:"The number of rows that failed to import"`
+	Invalid int          `json:"invalid" mapstructure:"invalid" desc:"The number of rows that failed validation"`
+	Rows    []*RowResult `json:"rows" mapstructure:"rows" desc:"The per-row results"`
+}
+
+type pendingRow[T any] struct {
+	result  *RowResult
+	account *T
+}
+
+// Import maps, validates and creates every record using target.
+// Invalid rows are reported and skipped; a failing row never stops the others.
+func Import[T any](records []Record, target Target[T], opts Options) *Result {
+	keys := modelKeys(reflect.TypeOf((*T)(nil)))
+	result := &Result{Total: len(records), Rows: make([]*RowResult, 0, len(records))}
+	pending := make([]*pendingRow[T], 0, len(records))
+	for _, record := range records {
+		mapped, err := mapRecord(record.Values, opts.ColumnMapping, keys)
+		rowResult := &RowResult{
+			Row:      record.Row,
+			SafeName: stringValue(mapped["safe_name"]),
+			Username: stringValue(mapped["username"]),
+			Address:  stringValue(mapped["address"]),
+		}
+		result.Rows = append(result.Rows, rowResult)
+		var account *T
+		if err == nil {
+			account, err = decodeRecord[T](mapped)
+		}
+		if err == nil {
+			err = validation.ValidateStruct(account)
+		}
+		if err != nil {
+			rowResult.Status = StatusInvalid
+			rowResult.Error = err.Error()
+			continue
+		}
+		pending = append(pending, &pendingRow[T]{result: rowResult, account: account})
+	}
+
+	if opts.CreateMissingSafes && target.EnsureSafe != nil {
+		pending = ensureSafes(pending, target.EnsureSafe)
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, row := range pending {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(row *pendingRow[T]) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			accountID, err := target.Create(row.account)
+			if err != nil {
+				row.result.Status = StatusFailed
+				row.result.Error = err.Error()
+				return
+			}
+			row.result.Status = StatusCreated
+			row.result.AccountID = accountID
+		}(row)
+	}
+	wg.Wait()
+
+	sort.SliceStable(result.Rows, func(i, j int) bool { return result.Rows[i].Row < result.Rows[j].Row })
+	for _, row := range result.Rows {
+		switch row.Status {
+		case StatusCreated:
+			result.Created++
+		case StatusFailed:
+			result.Failed++
+		case StatusInvalid:
+			result.Invalid++
+		}
+	}
+	return result
+}
+
+// ensureSafes calls ensureSafe once per distinct safe and fails the rows of safes that could not be ensured.
+// It returns the rows that can still be created.
+func ensureSafes[T any](pending []*pendingRow[T], ensureSafe func(string) error) []*pendingRow[T] {
+	safeErrors := map[string]error{}
+	remaining := make([]*pendingRow[T], 0, len(pending))
+	for _, row := range pending {
+		safeName := row.result.SafeName
+		err, checked := safeErrors[safeName]
+		if !checked {
+			err = ensureSafe(safeName)
+			safeErrors[safeName] = err
+		}
+		if err != nil {
+			row.result.Status = StatusFailed
+			row.result.Error = fmt.Sprintf("failed to ensure safe [%s] - %s", safeName, err.Error())
+			continue
+		}
+		remaining = append(remaining, row)
+	}
+	return remaining
+}
+
+func stringValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
+
+// ImportFile reads records from inputFile, imports them and, when resultsFile is set,
+// writes the per-row results CSV there. The format is inferred from the extension when empty.
+func ImportFile[T any](inputFile string, format string, resultsFile string, target Target[T], opts Options) (*Result, error) {
+	input, err := os.Open(filepath.Clean(inputFile))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := input.Close(); err != nil {
+			common.GlobalLogger.Warning("Error closing import file")
+		}
+	}()
+	records, err := ReadRecords(input, DetectFormat(inputFile, format))
+	if err != nil {
+		return nil, err
+	}
+	result := Import(records, target, opts)
+	if resultsFile == "" {
+		return result, nil
+	}
+	output, err := os.Create(filepath.Clean(resultsFile))
+	if err != nil {
+		return result, err
+	}
+	if err := WriteResultsCSV(output, result); err != nil {
+		_ = output.Close()
+		return result, err
+	}
+	return result, output.Close()
+}
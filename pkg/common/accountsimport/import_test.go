@@ -0,0 +1,324 @@
+package accountsimport
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type testSecretManagement struct {
+	AutomaticManagementEnabled *bool `mapstructure:"automatic_management_enabled,omitempty"`
+}
+
+type testAddAccount struct {
+	testSecretManagement      `mapstructure:",squash"`
+	SafeName                  string                 `mapstructure:"safe_name" validate:"required"`
+	Username                  string                 `mapstructure:"username,omitempty"`
+	Address                   string                 `mapstructure:"address,omitempty"`
+	PlatformID                string                 `mapstructure:"platform_id,omitempty"`
+	RemoteMachines            []string               `mapstructure:"remote_machines,omitempty"`
+	PlatformAccountProperties map[string]interface{} `mapstructure:"platform_account_properties,omitempty"`
+}
+
+func TestReadRecords(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		format  string
+		want    []map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name:   "csv_skips_empty_cells",
+			input:  "\ufeffSafe Name,username,address\nsafe1,root,\nsafe2, admin ,10.0.0.1\n",
+			format: FormatCSV,
+			want: []map[string]interface{}{
+				{"Safe Name": "safe1", "username": "root"},
+				{"Safe Name": "safe2", "username": "admin", "address": "10.0.0.1"},
+			},
+		},
+		{
+			name:   "jsonl_skips_blank_lines",
+			input:  "{\"safe_name\":\"safe1\",\"port\":22}\n\n{\"safe_name\":\"safe2\"}\n",
+			format: FormatJSONL,
+			want: []map[string]interface{}{
+				{"safe_name": "safe1", "port": float64(22)},
+				{"safe_name": "safe2"},
+			},
+		},
+		{name: "csv_empty_file", input: "", format: FormatCSV, wantErr: true},
+		{name: "jsonl_invalid_line", input: "{\"a\":\n", format: FormatJSONL, wantErr: true},
+		{name: "unsupported_format", input: "x", format: "xlsx", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			records, err := ReadRecords(strings.NewReader(tt.input), tt.format)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(records) != len(tt.want) {
+				t.Fatalf("expected %d records, got %d", len(tt.want), len(records))
+			}
+			for i, record := range records {
+				if record.Row != i+1 {
+					t.Errorf("record %d: expected row %d, got %d", i, i+1, record.Row)
+				}
+				if fmt.Sprint(record.Values) != fmt.Sprint(tt.want[i]) {
+					t.Errorf("record %d: expected %v, got %v", i, tt.want[i], record.Values)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	t.Parallel()
+
+	if got := DetectFormat("accounts.jsonl", ""); got != FormatJSONL {
+		t.Errorf("expected jsonl, got %s", got)
+	}
+	if got := DetectFormat("accounts.txt", ""); got != FormatCSV {
+		t.Errorf("expected csv, got %s", got)
+	}
+	if got := DetectFormat("accounts.csv", "JSONL"); got != FormatJSONL {
+		t.Errorf("expected explicit format to win, got %s", got)
+	}
+}
+
+func TestImport_mapsValidatesAndCreates(t *testing.T) {
+	t.Parallel()
+
+	records := []Record{
+		{Row: 1, Values: map[string]interface{}{
+			"Safe Name":                        "safe1",
+			"User":                             "root",
+			"address":                          "10.0.0.1",
+			"automatic-management-enabled":     "false",
+			"remote_machines":                  "m1;m2",
+			"Platform_Account_Properties.Port": "22",
+			"Notes":                            "dropped",
+		}},
+		{Row: 2, Values: map[string]interface{}{"User": "missing-safe"}},
+		{Row: 3, Values: map[string]interface{}{"Safe Name": "safe1", "User": "fails"}},
+	}
+	var created []*testAddAccount
+	var mu sync.Mutex
+	target := Target[testAddAccount]{
+		Create: func(account *testAddAccount) (string, error) {
+			if account.Username == "fails" {
+				return "", errors.New("boom")
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			created = append(created, account)
+			return "acc-" + account.Username, nil
+		},
+	}
+
+	result := Import(records, target, Options{ColumnMapping: map[string]string{"User": "username", "Notes": "-"}})
+
+	if result.Total != 3 || result.Created != 1 || result.Failed != 1 || result.Invalid != 1 {
+		t.Fatalf("unexpected counts: %+v", result)
+	}
+	if result.Rows[0].Status != StatusCreated || result.Rows[0].AccountID != "acc-root" {
+		t.Errorf("unexpected row 1 result: %+v", result.Rows[0])
+	}
+	if result.Rows[1].Status != StatusInvalid || !strings.Contains(result.Rows[1].Error, "safe_name") {
+		t.Errorf("unexpected row 2 result: %+v", result.Rows[1])
+	}
+	if result.Rows[2].Status != StatusFailed || result.Rows[2].Error != "boom" {
+		t.Errorf("unexpected row 3 result: %+v", result.Rows[2])
+	}
+	account := created[0]
+	if account.SafeName != "safe1" || account.Address != "10.0.0.1" {
+		t.Errorf("unexpected account: %+v", account)
+	}
+	if account.AutomaticManagementEnabled == nil || *account.AutomaticManagementEnabled {
+		t.Errorf("expected automatic management disabled, got %v", account.AutomaticManagementEnabled)
+	}
+	if strings.Join(account.RemoteMachines, ",") != "m1,m2" {
+		t.Errorf("unexpected remote machines: %v", account.RemoteMachines)
+	}
+	if len(account.PlatformAccountProperties) != 1 || account.PlatformAccountProperties["Port"] != "22" {
+		t.Errorf("unexpected platform account properties: %v", account.PlatformAccountProperties)
+	}
+}
+
+func TestImport_rejectsUnknownColumns(t *testing.T) {
+	t.Parallel()
+
+	records := []Record{
+		{Row: 1, Values: map[string]interface{}{"safe_name": "safe1", "username": "root", "LogonDomian": "corp", "Port": "22"}},
+		{Row: 2, Values: map[string]interface{}{"safe_name": "safe1", "username": "admin", "platform_account_properties.LogonDomain": "corp"}},
+	}
+	var created []*testAddAccount
+	target := Target[testAddAccount]{
+		Create: func(account *testAddAccount) (string, error) {
+			created = append(created, account)
+			return "acc-" + account.Username, nil
+		},
+	}
+
+	result := Import(records, target, Options{})
+
+	if result.Created != 1 || result.Invalid != 1 {
+		t.Fatalf("unexpected counts: %+v", result)
+	}
+	if result.Rows[0].Status != StatusInvalid || !strings.Contains(result.Rows[0].Error, "unknown columns [LogonDomian, Port]") {
+		t.Errorf("unexpected row 1 result: %+v", result.Rows[0])
+	}
+	if result.Rows[0].SafeName != "safe1" || result.Rows[0].Username != "root" {
+		t.Errorf("expected the invalid row to still be identified, got %+v", result.Rows[0])
+	}
+	if len(created) != 1 || created[0].PlatformAccountProperties["LogonDomain"] != "corp" {
+		t.Errorf("unexpected created accounts: %+v", created)
+	}
+}
+
+func TestImport_createMissingSafes(t *testing.T) {
+	t.Parallel()
+
+	records := []Record{
+		{Row: 1, Values: map[string]interface{}{"safe_name": "good", "username": "a"}},
+		{Row: 2, Values: map[string]interface{}{"safe_name": "bad", "username": "b"}},
+		{Row: 3, Values: map[string]interface{}{"safe_name": "good", "username": "c"}},
+		{Row: 4, Values: map[string]interface{}{"safe_name": "bad", "username": "d"}},
+	}
+	ensured := map[string]int{}
+	target := Target[testAddAccount]{
+		EnsureSafe: func(safeName string) error {
+			ensured[safeName]++
+			if safeName == "bad" {
+				return errors.New("forbidden")
+			}
+			return nil
+		},
+		Create: func(account *testAddAccount) (string, error) {
+			return account.Username, nil
+		},
+	}
+
+	result := Import(records, target, Options{CreateMissingSafes: true})
+
+	if ensured["good"] != 1 || ensured["bad"] != 1 {
+		t.Errorf("expected each safe to be ensured once, got %v", ensured)
+	}
+	if result.Created != 2 || result.Failed != 2 {
+		t.Fatalf("unexpected counts: %+v", result)
+	}
+	if !strings.Contains(result.Rows[1].Error, "failed to ensure safe [bad]") {
+		t.Errorf("unexpected row 2 error: %s", result.Rows[1].Error)
+	}
+}
+
+func TestImport_boundsConcurrency(t *testing.T) {
+	t.Parallel()
+
+	records := make([]Record, 0, 20)
+	for i := 1; i <= 20; i++ {
+		records = append(records, Record{Row: i, Values: map[string]interface{}{"safe_name": "safe", "username": fmt.Sprint(i)}})
+	}
+	var inFlight, peak atomic.Int32
+	release := make(chan struct{})
+	target := Target[testAddAccount]{
+		Create: func(account *testAddAccount) (string, error) {
+			current := inFlight.Add(1)
+			for {
+				old := peak.Load()
+				if current <= old || peak.CompareAndSwap(old, current) {
+					break
+				}
+			}
+			<-release
+			inFlight.Add(-1)
+			return account.Username, nil
+		},
+	}
+	go func() {
+		for range records {
+			release <- struct{}{}
+		}
+	}()
+
+	result := Import(records, target, Options{Concurrency: 3})
+
+	if result.Created != 20 {
+		t.Fatalf("expected 20 created, got %+v", result)
+	}
+	if peak.Load() > 3 {
+		t.Errorf("expected at most 3 concurrent creates, got %d", peak.Load())
+	}
+	for i, row := range result.Rows {
+		if row.Row != i+1 {
+			t.Fatalf("expected rows ordered by row number, got %d at %d", row.Row, i)
+		}
+	}
+}
+
+func TestImportFile_writesResultsCSV(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	inputFile := filepath.Join(dir, "accounts.csv")
+	resultsFile := filepath.Join(dir, "results.csv")
+	if err := os.WriteFile(inputFile, []byte("safe_name,username\nsafe1,root\n,nosafe\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	target := Target[testAddAccount]{
+		Create: func(account *testAddAccount) (string, error) { return "42_1", nil },
+	}
+
+	result, err := ImportFile(inputFile, "", resultsFile, target, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Created != 1 || result.Invalid != 1 {
+		t.Fatalf("unexpected counts: %+v", result)
+	}
+	written, err := os.ReadFile(resultsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(written)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and 2 rows, got %q", written)
+	}
+	if lines[0] != "row,safe_name,username,address,account_id,status,error" {
+		t.Errorf("unexpected header: %s", lines[0])
+	}
+	if lines[1] != "1,safe1,root,,42_1,created," {
+		t.Errorf("unexpected created row: %s", lines[1])
+	}
+	if !strings.HasPrefix(lines[2], "2,,nosafe,,,invalid,") {
+		t.Errorf("unexpected invalid row: %s", lines[2])
+	}
+}
+
+func TestWriteResultsCSV_quotesErrors(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := WriteResultsCSV(&buf, &Result{Rows: []*RowResult{{Row: 1, Status: StatusFailed, Error: "bad, \"quoted\""}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"bad, ""quoted"""`) {
+		t.Errorf("expected quoted error, got %q", buf.String())
+	}
+}
@@ -0,0 +1,129 @@
+package accountsimport
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// PlatformAccountPropertiesKey is the model key of platform account properties. Columns prefixed with it and a dot,
+// such as "platform_account_properties.Port", set the property named after the prefix.
+const PlatformAccountPropertiesKey = "platform_account_properties"
+
+// normalizeColumn converts a spreadsheet header such as "Safe Name" or "safe-name" to a model key.
+func normalizeColumn(column string) string {
+	key := strings.ToLower(strings.TrimSpace(column))
+	key = strings.NewReplacer(" ", "_", "-", "_").Replace(key)
+	return key
+}
+
+// modelKeys returns the mapstructure keys of the struct type t, following squashed embedded structs.
+func modelKeys(t reflect.Type) map[string]bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	keys := map[string]bool{}
+	if t.Kind() != reflect.Struct {
+		return keys
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("mapstructure")
+		parts := strings.Split(tag, ",")
+		if field.Anonymous && strings.Contains(tag, "squash") {
+			for key := range modelKeys(field.Type) {
+				keys[key] = true
+			}
+			continue
+		}
+		if !field.IsExported() || parts[0] == "" || parts[0] == "-" {
+			continue
+		}
+		keys[parts[0]] = true
+	}
+	return keys
+}
+
+// mapRecord builds the nested model input for a record.
+// Explicit mappings win; otherwise the normalized column name is used when it matches a model key.
+// Dotted columns such as "platform_account_properties.Port" address nested maps, and keep the case of
+// platform account property names. Columns that match no model key are rejected rather than guessed,
+// so a misspelled header never silently turns into a platform account property.
+func mapRecord(values map[string]interface{}, columnMapping map[string]string, keys map[string]bool) (map[string]interface{}, error) {
+	mapped := map[string]interface{}{}
+	var unknownColumns []string
+	for column, value := range values {
+		target, ok := columnMapping[column]
+		if !ok {
+			target = columnTarget(column, keys)
+			if target == "" {
+				unknownColumns = append(unknownColumns, column)
+				continue
+			}
+		}
+		if target == "" || target == "-" {
+			continue
+		}
+		setNested(mapped, strings.Split(target, "."), value)
+	}
+	if len(unknownColumns) > 0 {
+		sort.Strings(unknownColumns)
+		return mapped, fmt.Errorf("unknown columns [%s], map them to account fields or prefix platform account properties with [%s.]",
+			strings.Join(unknownColumns, ", "), PlatformAccountPropertiesKey)
+	}
+	return mapped, nil
+}
+
+// columnTarget returns the model key of an unmapped column, or an empty string when it matches none.
+func columnTarget(column string, keys map[string]bool) string {
+	normalized := normalizeColumn(column)
+	if keys[normalized] {
+		return normalized
+	}
+	parts := strings.SplitN(strings.TrimSpace(column), ".", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[1]) == "" {
+		return ""
+	}
+	parent := normalizeColumn(parts[0])
+	if !keys[parent] {
+		return ""
+	}
+	if parent == PlatformAccountPropertiesKey {
+		return parent + "." + strings.TrimSpace(parts[1])
+	}
+	return normalized
+}
+
+func setNested(m map[string]interface{}, path []string, value interface{}) {
+	for _, key := range path[:len(path)-1] {
+		next, ok := m[key].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			m[key] = next
+		}
+		m = next
+	}
+	m[path[len(path)-1]] = value
+}
+
+// decodeRecord decodes mapped record values into a new T.
+// Values are weakly typed so "true" and "22" decode into bool and int fields,
+// and semicolon separated strings decode into string slices.
+func decodeRecord[T any](mapped map[string]interface{}) (*T, error) {
+	var model T
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           &model,
+		WeaklyTypedInput: true,
+		DecodeHook:       mapstructure.StringToSliceHookFunc(";"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := decoder.Decode(mapped); err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
@@ -0,0 +1,117 @@
+// Package accountsimport implements the vault-agnostic part of bulk account
+// onboarding: reading spreadsheet-style records, mapping their columns onto
+// an add-account model, validating each row and running the creates with
+// bounded concurrency. Services plug in their own safe and account calls.
+package accountsimport
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Supported input formats.
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// Record is a single input row keyed by its column name.
+// Row is the 1-based data row number, excluding the CSV header.
+type Record struct {
+	Row    int
+	Values map[string]interface{}
+}
+
+// DetectFormat returns format when set, otherwise infers it from the file extension.
+// Anything other than .jsonl / .ndjson is treated as CSV.
+func DetectFormat(path string, format string) string {
+	if format != "" {
+		return strings.ToLower(format)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jsonl", ".ndjson":
+		return FormatJSONL
+	default:
+		return FormatCSV
+	}
+}
+
+// ReadRecords reads all records from r in the given format.
+// Empty CSV cells are omitted from the record so they do not override model defaults.
+func ReadRecords(r io.Reader, format string) ([]Record, error) {
+	switch format {
+	case FormatCSV:
+		return readCSVRecords(r)
+	case FormatJSONL:
+		return readJSONLRecords(r)
+	default:
+		return nil, fmt.Errorf("unsupported import format [%s]", format)
+	}
+}
+
+func readCSVRecords(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("import file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header - %w", err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))
+	}
+	records := make([]Record, 0)
+	for row := 1; ; row++ {
+		line, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv row [%d] - %w", row, err)
+		}
+		values := make(map[string]interface{}, len(header))
+		for i, column := range header {
+			if i >= len(line) || column == "" {
+				continue
+			}
+			value := strings.TrimSpace(line[i])
+			if value == "" {
+				continue
+			}
+			values[column] = value
+		}
+		records = append(records, Record{Row: row, Values: values})
+	}
+	return records, nil
+}
+
+func readJSONLRecords(r io.Reader) ([]Record, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	records := make([]Record, 0)
+	row := 0
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		row++
+		values := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &values); err != nil {
+			return nil, fmt.Errorf("failed to parse jsonl row [%d] - %w", row, err)
+		}
+		records = append(records, Record{Row: row, Values: values})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read jsonl - %w", err)
+	}
+	return records, nil
+}
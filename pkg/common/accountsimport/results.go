@@ -0,0 +1,32 @@
+package accountsimport
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+var resultsHeader = []string{"row", "safe_name", "username", "address", "account_id", "status", "error"}
+
+// WriteResultsCSV writes one line per row result, including the created account ID or the error.
+func WriteResultsCSV(w io.Writer, result *Result) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(resultsHeader); err != nil {
+		return err
+	}
+	for _, row := range result.Rows {
+		if err := writer.Write([]string{
+			strconv.Itoa(row.Row),
+			row.SafeName,
+			row.Username,
+			row.Address,
+			row.AccountID,
+			row.Status,
+			row.Error,
+		}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
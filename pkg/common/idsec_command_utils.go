@@ -73,3 +73,32 @@ func ExecuteCommandArgs(name string, args ...string) error {
 	cmd.Stdin = os.Stdin
 	return cmd.Run()
 }
+
+// ExecuteCommandArgsWithEnv executes a binary directly (no shell) like
+// ExecuteCommandArgs, adding the given environment variables to the child
+// process only. The environment of the current process is left untouched, so
+// concurrent callers never observe each other's variables.
+//
+// Parameters:
+//   - env: Additional environment variables in "KEY=value" form. They take
+//     precedence over variables of the same name in the current environment.
+//   - name: The executable name or absolute path.
+//   - args: The arguments to pass to the executable.
+//
+// Returns an error if the command execution fails or if the command returns a
+// non-zero exit code.
+//
+// Example:
+//
+//	err := ExecuteCommandArgsWithEnv([]string{"TNS_ADMIN=/path/to/wallet"}, "sqlplus", "/nolog")
+//	if err != nil {
+//		// handle error
+//	}
+func ExecuteCommandArgsWithEnv(env []string, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
@@ -231,3 +231,17 @@ func getEchoEnvCommand() string {
 	}
 	return "echo $" + testVar
 }
+
+func TestExecuteCommandArgsWithEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping Unix-specific test")
+	}
+	t.Setenv("IDSEC_TEST_ENV", "parent")
+	err := ExecuteCommandArgsWithEnv([]string{"IDSEC_TEST_ENV=child"}, "sh", "-c", `test "$IDSEC_TEST_ENV" = child`)
+	if err != nil {
+		t.Fatalf("expected the child to see its own environment, got error: %v", err)
+	}
+	if value := os.Getenv("IDSEC_TEST_ENV"); value != "parent" {
+		t.Fatalf("expected the current environment to be unchanged, got %q", value)
+	}
+}
@@ -0,0 +1,43 @@
+package common
+
+import (
+	"io"
+	"net"
+	"sync"
+)
+
+// PipeConnections copies data between two connections in both directions until either side is done.
+//
+// PipeConnections blocks until both copy directions finish. As soon as one direction ends, both
+// connections are closed so the other direction unblocks as well, which makes it suitable for
+// forwarding a local connection to a remote one in tunnels and port forwards.
+//
+// Parameters:
+//   - a: The first connection
+//   - b: The second connection
+//
+// Example:
+//
+//	go func() {
+//		PipeConnections(localConnection, remoteConnection)
+//	}()
+func PipeConnections(a net.Conn, b net.Conn) {
+	var once sync.Once
+	closeBoth := func() {
+		_ = a.Close()
+		_ = b.Close()
+	}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(a, b)
+		once.Do(closeBoth)
+	}()
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(b, a)
+		once.Do(closeBoth)
+	}()
+	wg.Wait()
+}
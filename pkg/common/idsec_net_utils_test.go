@@ -0,0 +1,42 @@
+package common
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestPipeConnections(t *testing.T) {
+	local, localPeer := net.Pipe()
+	remote, remotePeer := net.Pipe()
+	done := make(chan struct{})
+	go func() {
+		PipeConnections(localPeer, remotePeer)
+		close(done)
+	}()
+
+	go func() {
+		_, _ = local.Write([]byte("ping"))
+	}()
+	buffer := make([]byte, 4)
+	if _, err := io.ReadFull(remote, buffer); err != nil || string(buffer) != "ping" {
+		t.Fatalf("expected ping to reach the remote side, got %q, %v", buffer, err)
+	}
+	go func() {
+		_, _ = remote.Write([]byte("pong"))
+	}()
+	if _, err := io.ReadFull(local, buffer); err != nil || string(buffer) != "pong" {
+		t.Fatalf("expected pong to reach the local side, got %q, %v", buffer, err)
+	}
+
+	_ = local.Close()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected PipeConnections to return once one side closed")
+	}
+	if _, err := remote.Read(buffer); err == nil {
+		t.Fatal("expected the remote side to be closed")
+	}
+}
@@ -0,0 +1,41 @@
+// Package k8s provides kubectl exec credential helpers shared by the Kubernetes access services of the IDSEC SDK.
+package k8s
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	k8scommonmodels "github.com/cyberark/idsec-sdk-golang/pkg/models/common/k8s"
+)
+
+// BuildClientCertificateExecCredential builds a kubectl ExecCredential of the given client authentication
+// API version from a PEM client certificate and key. expirationTimestamp is written verbatim as
+// status.expirationTimestamp (RFC3339 UTC) when non-zero, so callers own any early-refresh buffer.
+func BuildClientCertificateExecCredential(apiVersion string, certPEM string, keyPEM string, expirationTimestamp time.Time) *k8scommonmodels.IdsecK8sExecCredential {
+	credential := &k8scommonmodels.IdsecK8sExecCredential{
+		APIVersion: apiVersion,
+		Kind:       k8scommonmodels.IdsecK8sExecCredentialKind,
+		Status: k8scommonmodels.IdsecK8sExecCredentialStatus{
+			ClientCertificateData: certPEM,
+			ClientKeyData:         keyPEM,
+		},
+	}
+	if !expirationTimestamp.IsZero() {
+		credential.Status.ExpirationTimestamp = expirationTimestamp.UTC().Format(time.RFC3339)
+	}
+	return credential
+}
+
+// ExecCredentialExpiresAt parses the status.expirationTimestamp of an ExecCredential. It is the exact
+// inverse of BuildClientCertificateExecCredential, no buffer arithmetic is applied.
+func ExecCredentialExpiresAt(credential *k8scommonmodels.IdsecK8sExecCredential) (time.Time, error) {
+	if credential == nil || strings.TrimSpace(credential.Status.ExpirationTimestamp) == "" {
+		return time.Time{}, fmt.Errorf("ExecCredential missing expirationTimestamp")
+	}
+	expiresAt, err := time.Parse(time.RFC3339, credential.Status.ExpirationTimestamp)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse expirationTimestamp: %w", err)
+	}
+	return expiresAt.UTC(), nil
+}
@@ -0,0 +1,46 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildClientCertificateExecCredential(t *testing.T) {
+	t.Parallel()
+	expirationTimestamp := time.Date(2026, 5, 31, 13, 34, 16, 0, time.FixedZone("IDT", 3*60*60))
+	credential := BuildClientCertificateExecCredential("client.authentication.k8s.io/v1", "CERT", "KEY", expirationTimestamp)
+	require.Equal(t, "client.authentication.k8s.io/v1", credential.APIVersion)
+	require.Equal(t, "ExecCredential", credential.Kind)
+	require.Equal(t, "CERT", credential.Status.ClientCertificateData)
+	require.Equal(t, "KEY", credential.Status.ClientKeyData)
+	require.Equal(t, "2026-05-31T10:34:16Z", credential.Status.ExpirationTimestamp)
+}
+
+func TestBuildClientCertificateExecCredential_NoExpiration(t *testing.T) {
+	t.Parallel()
+	credential := BuildClientCertificateExecCredential("client.authentication.k8s.io/v1beta1", "CERT", "KEY", time.Time{})
+	require.Empty(t, credential.Status.ExpirationTimestamp)
+	_, err := ExecCredentialExpiresAt(credential)
+	require.Error(t, err)
+}
+
+func TestExecCredentialExpiresAt_RoundTrip(t *testing.T) {
+	t.Parallel()
+	expirationTimestamp := time.Date(2026, 5, 31, 13, 34, 16, 0, time.UTC)
+	credential := BuildClientCertificateExecCredential("client.authentication.k8s.io/v1", "CERT", "KEY", expirationTimestamp)
+	expiresAt, err := ExecCredentialExpiresAt(credential)
+	require.NoError(t, err)
+	require.True(t, expiresAt.Equal(expirationTimestamp))
+}
+
+func TestExecCredentialExpiresAt_Invalid(t *testing.T) {
+	t.Parallel()
+	_, err := ExecCredentialExpiresAt(nil)
+	require.Error(t, err)
+	credential := BuildClientCertificateExecCredential("client.authentication.k8s.io/v1", "CERT", "KEY", time.Time{})
+	credential.Status.ExpirationTimestamp = "tomorrow"
+	_, err = ExecCredentialExpiresAt(credential)
+	require.ErrorContains(t, err, "failed to parse expirationTimestamp")
+}
@@ -58,6 +58,7 @@ import (
 
 	aws "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/aws"
 	azure "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/azure"
+	gcp "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/gcp"
 	networks "github.com/cyberark/idsec-sdk-golang/pkg/services/cmgr/networks"
 	poolcomponents "github.com/cyberark/idsec-sdk-golang/pkg/services/cmgr/poolcomponents"
 	poolidentifiers "github.com/cyberark/idsec-sdk-golang/pkg/services/cmgr/poolidentifiers"
@@ -72,8 +73,14 @@ import (
 	pamshsafes "github.com/cyberark/idsec-sdk-golang/pkg/services/pamsh/pamshsafes"
 	accounts "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts"
 	applications "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/applications"
+	credentialprovider "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/credentialprovider"
+	discoveredaccounts "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/discoveredaccounts"
+	effectivepermissions "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/effectivepermissions"
+	inventory "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/inventory"
 	platforms "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/platforms"
+	pcloudrequests "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/requests"
 	safes "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/safes"
+	secretrotation "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/secretrotation"
 	targetplatforms "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/targetplatforms"
 	policy "github.com/cyberark/idsec-sdk-golang/pkg/services/policy"
 	cloudaccess "github.com/cyberark/idsec-sdk-golang/pkg/services/policy/cloudaccess"
@@ -85,6 +92,7 @@ import (
 	discovery "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/discovery"
 	groupaccess2 "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/groupaccess"
 	k8s2 "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/k8s"
+	sessions "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/sessions"
 	configurations "github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/configurations"
 	filters "github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/filters"
 	scans "github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/scans"
@@ -107,7 +115,7 @@ import (
 	db3 "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/workspacesdb"
 	targetsets "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/workspacestargetsets"
 	sessionactivities "github.com/cyberark/idsec-sdk-golang/pkg/services/sm/sessionactivities"
-	sessions "github.com/cyberark/idsec-sdk-golang/pkg/services/sm/sessions"
+	sessions2 "github.com/cyberark/idsec-sdk-golang/pkg/services/sm/sessions"
 )
 
 // IdsecAPI wraps different API functionality of Idsec Services.
@@ -288,6 +296,19 @@ func (api *IdsecAPI) CceAzure() (*azure.IdsecCCEAzureService, error) {
 	return service, nil
 }
 
+func (api *IdsecAPI) CceGcp() (*gcp.IdsecCCEGCPService, error) {
+	if serviceIfs, ok := api.services[gcp.ServiceConfig.ServiceName]; ok {
+		return (*serviceIfs).(*gcp.IdsecCCEGCPService), nil
+	}
+	service, err := gcp.ServiceGenerator(api.loadServiceAuthenticators(gcp.ServiceConfig)...)
+	if err != nil {
+		return nil, err
+	}
+	var baseService services.IdsecService = service
+	api.services[gcp.ServiceConfig.ServiceName] = &baseService
+	return service, nil
+}
+
 func (api *IdsecAPI) CmgrNetworks() (*networks.IdsecCmgrNetworksService, error) {
 	if serviceIfs, ok := api.services[networks.ServiceConfig.ServiceName]; ok {
 		return (*serviceIfs).(*networks.IdsecCmgrNetworksService), nil
@@ -470,6 +491,58 @@ func (api *IdsecAPI) PcloudApplications() (*applications.IdsecPCloudApplications
 	return service, nil
 }
 
+func (api *IdsecAPI) PcloudCredentialProvider() (*credentialprovider.IdsecPCloudCredentialProviderService, error) {
+	if serviceIfs, ok := api.services[credentialprovider.ServiceConfig.ServiceName]; ok {
+		return (*serviceIfs).(*credentialprovider.IdsecPCloudCredentialProviderService), nil
+	}
+	service, err := credentialprovider.ServiceGenerator(api.loadServiceAuthenticators(credentialprovider.ServiceConfig)...)
+	if err != nil {
+		return nil, err
+	}
+	var baseService services.IdsecService = service
+	api.services[credentialprovider.ServiceConfig.ServiceName] = &baseService
+	return service, nil
+}
+
+func (api *IdsecAPI) PcloudDiscoveredaccounts() (*discoveredaccounts.IdsecPCloudDiscoveredAccountsService, error) {
+	if serviceIfs, ok := api.services[discoveredaccounts.ServiceConfig.ServiceName]; ok {
+		return (*serviceIfs).(*discoveredaccounts.IdsecPCloudDiscoveredAccountsService), nil
+	}
+	service, err := discoveredaccounts.ServiceGenerator(api.loadServiceAuthenticators(discoveredaccounts.ServiceConfig)...)
+	if err != nil {
+		return nil, err
+	}
+	var baseService services.IdsecService = service
+	api.services[discoveredaccounts.ServiceConfig.ServiceName] = &baseService
+	return service, nil
+}
+
+func (api *IdsecAPI) PcloudEffectivePermissions() (*effectivepermissions.IdsecPCloudEffectivePermissionsService, error) {
+	if serviceIfs, ok := api.services[effectivepermissions.ServiceConfig.ServiceName]; ok {
+		return (*serviceIfs).(*effectivepermissions.IdsecPCloudEffectivePermissionsService), nil
+	}
+	service, err := effectivepermissions.ServiceGenerator(api.loadServiceAuthenticators(effectivepermissions.ServiceConfig)...)
+	if err != nil {
+		return nil, err
+	}
+	var baseService services.IdsecService = service
+	api.services[effectivepermissions.ServiceConfig.ServiceName] = &baseService
+	return service, nil
+}
+
+func (api *IdsecAPI) PcloudInventory() (*inventory.IdsecPCloudInventoryService, error) {
+	if serviceIfs, ok := api.services[inventory.ServiceConfig.ServiceName]; ok {
+		return (*serviceIfs).(*inventory.IdsecPCloudInventoryService), nil
+	}
+	service, err := inventory.ServiceGenerator(api.loadServiceAuthenticators(inventory.ServiceConfig)...)
+	if err != nil {
+		return nil, err
+	}
+	var baseService services.IdsecService = service
+	api.services[inventory.ServiceConfig.ServiceName] = &baseService
+	return service, nil
+}
+
 func (api *IdsecAPI) PcloudPlatforms() (*platforms.IdsecPCloudPlatformsService, error) {
 	if serviceIfs, ok := api.services[platforms.ServiceConfig.ServiceName]; ok {
 		return (*serviceIfs).(*platforms.IdsecPCloudPlatformsService), nil
@@ -483,6 +556,19 @@ func (api *IdsecAPI) PcloudPlatforms() (*platforms.IdsecPCloudPlatformsService,
 	return service, nil
 }
 
+func (api *IdsecAPI) PcloudRequests() (*pcloudrequests.IdsecPCloudRequestsService, error) {
+	if serviceIfs, ok := api.services[pcloudrequests.ServiceConfig.ServiceName]; ok {
+		return (*serviceIfs).(*pcloudrequests.IdsecPCloudRequestsService), nil
+	}
+	service, err := pcloudrequests.ServiceGenerator(api.loadServiceAuthenticators(pcloudrequests.ServiceConfig)...)
+	if err != nil {
+		return nil, err
+	}
+	var baseService services.IdsecService = service
+	api.services[pcloudrequests.ServiceConfig.ServiceName] = &baseService
+	return service, nil
+}
+
 func (api *IdsecAPI) PcloudSafes() (*safes.IdsecPCloudSafesService, error) {
 	if serviceIfs, ok := api.services[safes.ServiceConfig.ServiceName]; ok {
 		return (*serviceIfs).(*safes.IdsecPCloudSafesService), nil
@@ -496,6 +582,19 @@ func (api *IdsecAPI) PcloudSafes() (*safes.IdsecPCloudSafesService, error) {
 	return service, nil
 }
 
+func (api *IdsecAPI) PcloudSecretRotation() (*secretrotation.IdsecPCloudSecretRotationService, error) {
+	if serviceIfs, ok := api.services[secretrotation.ServiceConfig.ServiceName]; ok {
+		return (*serviceIfs).(*secretrotation.IdsecPCloudSecretRotationService), nil
+	}
+	service, err := secretrotation.ServiceGenerator(api.loadServiceAuthenticators(secretrotation.ServiceConfig)...)
+	if err != nil {
+		return nil, err
+	}
+	var baseService services.IdsecService = service
+	api.services[secretrotation.ServiceConfig.ServiceName] = &baseService
+	return service, nil
+}
+
 func (api *IdsecAPI) PcloudTargetplatforms() (*targetplatforms.IdsecPCloudTargetPlatformsService, error) {
 	if serviceIfs, ok := api.services[targetplatforms.ServiceConfig.ServiceName]; ok {
 		return (*serviceIfs).(*targetplatforms.IdsecPCloudTargetPlatformsService), nil
@@ -639,6 +738,19 @@ func (api *IdsecAPI) ScaK8s() (*k8s2.IdsecSCAK8sService, error) {
 	return service, nil
 }
 
+func (api *IdsecAPI) ScaSessions() (*sessions.IdsecSCASessionsService, error) {
+	if serviceIfs, ok := api.services[sessions.ServiceConfig.ServiceName]; ok {
+		return (*serviceIfs).(*sessions.IdsecSCASessionsService), nil
+	}
+	service, err := sessions.ServiceGenerator(api.loadServiceAuthenticators(sessions.ServiceConfig)...)
+	if err != nil {
+		return nil, err
+	}
+	var baseService services.IdsecService = service
+	api.services[sessions.ServiceConfig.ServiceName] = &baseService
+	return service, nil
+}
+
 func (api *IdsecAPI) SechubConfigurations() (*configurations.IdsecSecHubConfigurationService, error) {
 	if serviceIfs, ok := api.services[configurations.ServiceConfig.ServiceName]; ok {
 		return (*serviceIfs).(*configurations.IdsecSecHubConfigurationService), nil
@@ -925,15 +1037,15 @@ func (api *IdsecAPI) SmSessionactivities() (*sessionactivities.IdsecSMSessionAct
 	return service, nil
 }
 
-func (api *IdsecAPI) SmSessions() (*sessions.IdsecSMSessionsService, error) {
-	if serviceIfs, ok := api.services[sessions.ServiceConfig.ServiceName]; ok {
-		return (*serviceIfs).(*sessions.IdsecSMSessionsService), nil
+func (api *IdsecAPI) SmSessions() (*sessions2.IdsecSMSessionsService, error) {
+	if serviceIfs, ok := api.services[sessions2.ServiceConfig.ServiceName]; ok {
+		return (*serviceIfs).(*sessions2.IdsecSMSessionsService), nil
 	}
-	service, err := sessions.ServiceGenerator(api.loadServiceAuthenticators(sessions.ServiceConfig)...)
+	service, err := sessions2.ServiceGenerator(api.loadServiceAuthenticators(sessions2.ServiceConfig)...)
 	if err != nil {
 		return nil, err
 	}
 	var baseService services.IdsecService = service
-	api.services[sessions.ServiceConfig.ServiceName] = &baseService
+	api.services[sessions2.ServiceConfig.ServiceName] = &baseService
 	return service, nil
 }
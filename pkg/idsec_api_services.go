@@ -8,6 +8,7 @@ import (
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/cce"
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/aws"
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/azure"
+	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/gcp"
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/cmgr"
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/cmgr/networks"
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/cmgr/poolcomponents"
@@ -28,8 +29,14 @@ import (
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts"
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/applications"
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/common"
+	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/credentialprovider"
+	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/discoveredaccounts"
+	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/effectivepermissions"
+	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/inventory"
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/platforms"
+	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/requests"
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/safes"
+	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/secretrotation"
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/targetplatforms"
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/policy"
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/policy/cloudaccess"
@@ -43,6 +50,7 @@ import (
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/discovery"
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/groupaccess"
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/k8s"
+	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/sessions"
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/sechub"
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/configurations"
 	_ "github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/filters"
@@ -0,0 +1,26 @@
+// Package k8s provides data structures shared by the Kubernetes access services of the IDSEC SDK.
+package k8s
+
+// IdsecK8sExecCredentialKind is the kind of the object returned by a kubectl exec credential plugin.
+const IdsecK8sExecCredentialKind = "ExecCredential"
+
+// IdsecK8sExecCredentialStatus holds the authentication credentials injected into kubectl, either a
+// bearer token or a client certificate and key.
+//
+// ExpirationTimestamp, when set (RFC3339), lets client-go cache the credential in-process and skip
+// re-invoking the plugin until that time.
+type IdsecK8sExecCredentialStatus struct {
+	Token                 string `json:"token,omitempty"`
+	ExpirationTimestamp   string `json:"expirationTimestamp,omitempty"`
+	ClientCertificateData string `json:"clientCertificateData,omitempty"`
+	ClientKeyData         string `json:"clientKeyData,omitempty"`
+}
+
+// IdsecK8sExecCredential is the JSON object written to stdout for kubectl's exec credential plugin protocol.
+//
+// Reference: https://kubernetes.io/docs/reference/config-api/client-authentication.v1/
+type IdsecK8sExecCredential struct {
+	APIVersion string                       `json:"apiVersion"`
+	Kind       string                       `json:"kind"`
+	Status     IdsecK8sExecCredentialStatus `json:"status"`
+}
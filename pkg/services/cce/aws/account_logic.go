@@ -0,0 +1,221 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	awsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/aws/models"
+	ccemodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/common/models"
+	cceinternal "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/internal"
+)
+
+// account retrieves AWS account details by account onboarding ID.
+// API: GET /api/aws/programmatic/account/{id}
+func (s *IdsecCCEAWSService) account(accountID string) (*awsmodels.IdsecCCEAWSAccount, error) {
+	s.Logger.Info("Getting AWS Account details for ID [%s]", accountID)
+	url := fmt.Sprintf(pathAccountGetOrDeleteURL, accountID)
+	response, err := s.ISPClient().Get(context.Background(), url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer cceinternal.CloseResponseBody(response.Body)
+
+	// Handle non-2xx status codes
+	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
+		return nil, cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, "failed to get account details")
+	}
+
+	accountJSON, err := common.DeserializeJSONSnake(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var account awsmodels.IdsecCCEAWSAccount
+	err = mapstructure.Decode(accountJSON, &account)
+	if err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// accountWithRetry wraps account retrieval with retry logic for transient failures.
+// API: GET /api/aws/programmatic/account/{id}
+func (s *IdsecCCEAWSService) accountWithRetry(accountID string) (*awsmodels.IdsecCCEAWSAccount, error) {
+	s.Logger.Info("Getting AWS account details with retry for ID [%s]", accountID)
+
+	maxRetries := cceinternal.DefaultMaxRequestRetries
+	retryDelay := cceinternal.DefaultRequestRetryDelay
+
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			s.Logger.Info("Retry attempt %d/%d after %v", attempt, maxRetries, retryDelay)
+			time.Sleep(retryDelay)
+		}
+
+		account, err := s.account(accountID)
+		if err == nil {
+			return account, nil
+		}
+
+		lastErr = err
+
+		// Check if error is retryable
+		if !cceinternal.IsRetryableError(0, err) {
+			s.Logger.Info("Non-retryable error, not retrying: %v", err)
+			break
+		}
+
+		s.Logger.Info("Retryable error on attempt %d: %v", attempt+1, err)
+	}
+
+	return nil, fmt.Errorf("failed to get account details after %d attempts: %w", maxRetries+1, lastErr)
+}
+
+// addAccount adds an AWS account programmatically and returns the onboarding ID of the added account.
+// API: POST /api/aws/programmatic/account
+func (s *IdsecCCEAWSService) addAccount(input *awsmodels.IdsecCCEAWSAddAccount, onboardingType string) (string, error) {
+	s.Logger.Info("Adding AWS account [%s]", input.AccountID)
+
+	// Convert input to map using JSON marshal/unmarshal
+	inputJSON, err := json.Marshal(input)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal input: %w", err)
+	}
+
+	var requestBody map[string]interface{}
+	if err := json.Unmarshal(inputJSON, &requestBody); err != nil {
+		return "", fmt.Errorf("failed to unmarshal input to map: %w", err)
+	}
+	requestBody["onboardingType"] = onboardingType
+
+	response, err := s.ISPClient().Post(context.Background(), pathAccountAddURL, requestBody)
+	if err != nil {
+		return "", err
+	}
+	defer cceinternal.CloseResponseBody(response.Body)
+
+	// Handle non-2xx status codes
+	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
+		return "", cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, "failed to add account")
+	}
+
+	responseJSON, err := common.DeserializeJSONSnake(response.Body)
+	if err != nil {
+		return "", err
+	}
+
+	var addedAccount awsmodels.TfIdsecCCEAWSAddedAccount
+	err = mapstructure.Decode(responseJSON, &addedAccount)
+	if err != nil {
+		return "", err
+	}
+
+	return addedAccount.ID, nil
+}
+
+// updateAccount updates an AWS account programmatically by reconciling service changes.
+// Compares the desired services in the input with the current services on the account,
+// then adds new services and removes services that are no longer desired.
+func (s *IdsecCCEAWSService) updateAccount(input *awsmodels.IdsecCCEAWSUpdateAccount) (*awsmodels.IdsecCCEAWSAccount, error) {
+	s.Logger.Info("Updating AWS account [%s]", input.ID)
+
+	// Step 1: Get current account details to determine existing services
+	currentAccount, err := s.account(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	currentServiceNames := currentAccount.ServiceNames
+
+	// Step 2: Compare services to determine what to add and what to remove
+	// Build maps for efficient lookup
+	desiredServicesMap := make(map[string]ccemodels.IdsecCCEServiceInput)
+	for _, service := range input.Services {
+		desiredServicesMap[service.ServiceName] = service
+	}
+
+	currentServices := make(map[string]bool)
+	for _, serviceName := range currentServiceNames {
+		currentServices[serviceName] = true
+	}
+
+	s.Logger.Info("Current account services: %v", currentServiceNames)
+
+	// Determine services to add (in desired but not in current)
+	var servicesToAdd []ccemodels.IdsecCCEServiceInput
+	for serviceName, service := range desiredServicesMap {
+		if !currentServices[serviceName] {
+			servicesToAdd = append(servicesToAdd, service)
+			s.Logger.Info("Service '%s' will be ADDED", serviceName)
+		}
+	}
+
+	// Determine services to remove (in current but not in desired)
+	var servicesToRemove []string
+	for serviceName := range currentServices {
+		if _, exists := desiredServicesMap[serviceName]; !exists {
+			servicesToRemove = append(servicesToRemove, serviceName)
+			s.Logger.Info("Service '%s' will be REMOVED", serviceName)
+		}
+	}
+
+	// Step 3: Add new services if any
+	s.Logger.Info("Services to add: %d, Services to remove: %d\n", len(servicesToAdd), len(servicesToRemove))
+	if len(servicesToAdd) > 0 {
+		s.Logger.Info("Adding %d services to account [%s]", len(servicesToAdd), input.ID)
+		err = s.TfAddAccountServices(&awsmodels.TfIdsecCCEAWSAddAccountServices{
+			ID:       input.ID,
+			Services: servicesToAdd,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to add services: %w", err)
+		}
+	}
+
+	// Step 4: Remove services that are no longer desired
+	if len(servicesToRemove) > 0 {
+		s.Logger.Info("Removing %d services from account [%s]", len(servicesToRemove), input.ID)
+		err = s.DeleteAccountServices(&awsmodels.TfIdsecCCEAWSDeleteAccountServices{
+			ID:           input.ID,
+			ServiceNames: servicesToRemove,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove services: %w", err)
+		}
+	}
+
+	// Step 5: Fetch and return updated account details
+	s.Logger.Info("Fetching full details for account [%s]", input.ID)
+	fullAccount, err := s.accountWithRetry(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("account updated with ID %s, but failed to fetch details: %w", input.ID, err)
+	}
+
+	return fullAccount, nil
+}
+
+// deleteAccount deletes an AWS account programmatically.
+// API: DELETE /api/aws/programmatic/account/{id}
+func (s *IdsecCCEAWSService) deleteAccount(accountID string) error {
+	s.Logger.Info("Deleting AWS account with ID [%s]", accountID)
+
+	url := fmt.Sprintf(pathAccountGetOrDeleteURL, accountID)
+	response, err := s.ISPClient().Delete(context.Background(), url, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete account: %w", err)
+	}
+	defer cceinternal.CloseResponseBody(response.Body)
+
+	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
+		bodyBytes, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("failed to delete account: status code %d, body: %s", response.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
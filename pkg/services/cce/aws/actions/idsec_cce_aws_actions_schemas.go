@@ -6,7 +6,23 @@ import (
 
 // ActionToSchemaMap is a map that defines the mapping between CCE AWS action names and their corresponding schema types.
 var ActionToSchemaMap = map[string]interface{}{
+	// Organization actions
+	"list-organizations":    nil,
+	"list-organizations-by": &awsmodels.IdsecCCEAWSWorkspacesFilter{},
+	"organization":          &awsmodels.IdsecCCEAWSGetOrganization{},
+	"add-organization":      &awsmodels.IdsecCCEAWSAddOrganization{},
+	"update-organization":   &awsmodels.IdsecCCEAWSUpdateOrganization{},
+	"delete-organization":   &awsmodels.IdsecCCEAWSDeleteOrganization{},
 
+	// Account actions
+	"list-accounts":    nil,
+	"list-accounts-by": &awsmodels.IdsecCCEAWSWorkspacesFilter{},
+	"account":          &awsmodels.IdsecCCEAWSGetAccount{},
+	"add-account":      &awsmodels.IdsecCCEAWSAddAccount{},
+	"update-account":   &awsmodels.IdsecCCEAWSUpdateAccount{},
+	"delete-account":   &awsmodels.IdsecCCEAWSDeleteAccount{},
+
+	// Terraform provider actions
 	"tf-organization":                  &awsmodels.TfIdsecCCEAWSGetOrganization{},
 	"tf-organization-datasource":       &awsmodels.TfIdsecCCEAWSGetOrganization{},
 	"tf-add-organization":              &awsmodels.TfIdsecCCEAWSAddOrganization{},
@@ -1,10 +1,13 @@
 package aws
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 	"unsafe"
 
 	"github.com/stretchr/testify/require"
@@ -794,3 +797,83 @@ func TestUpdateAccount_EmptyServicesArray_Returns400(t *testing.T) {
 	require.Contains(t, err.Error(), "400")
 	require.Contains(t, err.Error(), "Bad Request")
 }
+
+func TestAddAccount_ReturnsOperation(t *testing.T) {
+	var capturedBody map[string]interface{}
+	readCount := 0
+	client, cleanup := internal.SetupMockCCEService(t, []internal.MockEndpointConfig{
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "POST" && r.URL.Path == "/api/aws/programmatic/account"
+			},
+			StatusCode:   http.StatusCreated,
+			ResponseBody: `{"id": "1111aaaa2222bbbb3333cccc"}`,
+			OnRequest: func(r *http.Request) {
+				_ = json.NewDecoder(r.Body).Decode(&capturedBody)
+			},
+		},
+		{
+			Matcher: func(r *http.Request) bool {
+				if r.Method != "GET" || !strings.Contains(r.URL.Path, "1111aaaa2222bbbb3333cccc") {
+					return false
+				}
+				readCount++
+				return readCount == 1
+			},
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{"id": "1111aaaa2222bbbb3333cccc", "accountId": "123456789012", "services": ["sca"], "status": "Waiting for deployment"}`,
+		},
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "GET" && strings.Contains(r.URL.Path, "1111aaaa2222bbbb3333cccc")
+			},
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{"id": "1111aaaa2222bbbb3333cccc", "accountId": "123456789012", "services": ["sca"], "status": "Completely added"}`,
+		},
+	})
+	defer cleanup()
+
+	service := setupAWSService(client)
+	operation, err := service.AddAccount(&awsmodels.IdsecCCEAWSAddAccount{
+		AccountID: "123456789012",
+		Services:  []ccemodels.IdsecCCEServiceInput{{ServiceName: ccemodels.SCA}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, ccemodels.Programmatic, capturedBody["onboardingType"])
+	require.Equal(t, "1111aaaa2222bbbb3333cccc", operation.ID)
+	require.Equal(t, awsmodels.WorkspaceTypeAccount, operation.ResourceType)
+	require.Equal(t, ccemodels.WaitingForDeployment, operation.Status)
+	require.False(t, operation.Done())
+
+	status, err := operation.Wait(context.Background(), time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, ccemodels.CompletelyAdded, status)
+}
+
+func TestAccountDetails_Success(t *testing.T) {
+	client, cleanup := internal.SetupMockCCEService(t, []internal.MockEndpointConfig{
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "GET" && r.URL.Path == "/api/aws/programmatic/account/1111aaaa2222bbbb3333cccc"
+			},
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{"id": "1111aaaa2222bbbb3333cccc", "accountId": "123456789012", "onboardingType": "programmatic", "services": ["sca", "dpa"], "status": "Completely added"}`,
+		},
+	})
+	defer cleanup()
+
+	service := setupAWSService(client)
+	account, err := service.Account(&awsmodels.IdsecCCEAWSGetAccount{ID: "1111aaaa2222bbbb3333cccc"})
+	require.NoError(t, err)
+	require.Equal(t, "123456789012", account.AccountID)
+	require.Equal(t, ccemodels.Programmatic, account.OnboardingType)
+	require.Equal(t, []string{"sca", "dpa"}, account.ServiceNames)
+}
+
+func TestAccountDetails_ErrorPropagation(t *testing.T) {
+	internal.TestServiceErrorPropagation(t, func(client *isp.IdsecISPServiceClient) error {
+		service := setupAWSService(client)
+		_, err := service.Account(&awsmodels.IdsecCCEAWSGetAccount{ID: "1111aaaa2222bbbb3333cccc"})
+		return err
+	})
+}
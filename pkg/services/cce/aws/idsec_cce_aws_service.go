@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io"
 	"strings"
-	"time"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/cyberark/idsec-sdk-golang/pkg/auth"
@@ -31,6 +30,9 @@ const (
 	pathTenantServiceDetailsURL    = "/api/aws/tenant/service-details"
 )
 
+// IdsecCCEAWSWorkspacesPage is a page of onboarded AWS workspaces.
+type IdsecCCEAWSWorkspacesPage = common.IdsecPage[ccemodels.IdsecCCEWorkspace]
+
 // IdsecCCEAWSService is the implementation of the CCE AWS service.
 type IdsecCCEAWSService struct {
 	*services.IdsecBaseService
@@ -68,12 +70,172 @@ func (s *IdsecCCEAWSService) refreshCCEAWSAuth(client *common.IdsecClient) error
 	return nil
 }
 
+// ListOrganizations lists the AWS organizations onboarded to CCE.
+// On failure during pagination, the channel emits a final page with Err set.
+// API: GET /api/aws/workspaces
+func (s *IdsecCCEAWSService) ListOrganizations() (<-chan *IdsecCCEAWSWorkspacesPage, error) {
+	return s.ListOrganizationsBy(&awsmodels.IdsecCCEAWSWorkspacesFilter{})
+}
+
+// ListOrganizationsBy lists the AWS organizations onboarded to CCE which match the given filter.
+// On failure during pagination, the channel emits a final page with Err set.
+// API: GET /api/aws/workspaces
+func (s *IdsecCCEAWSService) ListOrganizationsBy(filter *awsmodels.IdsecCCEAWSWorkspacesFilter) (<-chan *IdsecCCEAWSWorkspacesPage, error) {
+	return s.listWorkspaces(awsmodels.WorkspaceTypeOrganization, filter), nil
+}
+
+// Organization retrieves an onboarded AWS organization by its onboarding ID.
+// API: GET /api/aws/programmatic/organization/{id}
+func (s *IdsecCCEAWSService) Organization(getOrganization *awsmodels.IdsecCCEAWSGetOrganization) (*awsmodels.IdsecCCEAWSOrganization, error) {
+	return s.organization(getOrganization.ID)
+}
+
+// AddOrganization adds an AWS organization programmatically using the organization's management account.
+// The returned operation tracks the onboarding, which completes once the CCE resources are deployed.
+// API: POST /api/aws/programmatic/organization
+func (s *IdsecCCEAWSService) AddOrganization(addOrganization *awsmodels.IdsecCCEAWSAddOrganization) (*ccemodels.IdsecCCEOnboardingOperation, error) {
+	organizationID, err := s.addOrganization(addOrganization, ccemodels.Programmatic)
+	if err != nil {
+		return nil, err
+	}
+	return s.newOnboardingOperation(organizationID, awsmodels.WorkspaceTypeOrganization, func() (string, error) {
+		organization, err := s.organization(organizationID)
+		if err != nil {
+			return "", err
+		}
+		return organization.Status, nil
+	}), nil
+}
+
+// UpdateOrganization updates the services of an onboarded AWS organization.
+// Services missing from the input are removed and new services are added.
+// API: POST/DELETE /api/aws/programmatic/organization/{id}/services
+func (s *IdsecCCEAWSService) UpdateOrganization(updateOrganization *awsmodels.IdsecCCEAWSUpdateOrganization) (*awsmodels.IdsecCCEAWSOrganization, error) {
+	return s.updateOrganization(updateOrganization)
+}
+
+// DeleteOrganization deletes an onboarded AWS organization.
+// API: DELETE /api/aws/programmatic/organization/{id}
+func (s *IdsecCCEAWSService) DeleteOrganization(deleteOrganization *awsmodels.IdsecCCEAWSDeleteOrganization) error {
+	return s.deleteOrganization(deleteOrganization.ID)
+}
+
+// ListAccounts lists the AWS accounts onboarded to CCE.
+// On failure during pagination, the channel emits a final page with Err set.
+// API: GET /api/aws/workspaces
+func (s *IdsecCCEAWSService) ListAccounts() (<-chan *IdsecCCEAWSWorkspacesPage, error) {
+	return s.ListAccountsBy(&awsmodels.IdsecCCEAWSWorkspacesFilter{})
+}
+
+// ListAccountsBy lists the AWS accounts onboarded to CCE which match the given filter.
+// On failure during pagination, the channel emits a final page with Err set.
+// API: GET /api/aws/workspaces
+func (s *IdsecCCEAWSService) ListAccountsBy(filter *awsmodels.IdsecCCEAWSWorkspacesFilter) (<-chan *IdsecCCEAWSWorkspacesPage, error) {
+	return s.listWorkspaces(awsmodels.WorkspaceTypeAccount, filter), nil
+}
+
+// Account retrieves an onboarded AWS account by its onboarding ID.
+// API: GET /api/aws/programmatic/account/{id}
+func (s *IdsecCCEAWSService) Account(getAccount *awsmodels.IdsecCCEAWSGetAccount) (*awsmodels.IdsecCCEAWSAccount, error) {
+	return s.account(getAccount.ID)
+}
+
+// AddAccount adds an AWS account programmatically.
+// The returned operation tracks the onboarding, which completes once the CCE resources are deployed.
+// API: POST /api/aws/programmatic/account
+func (s *IdsecCCEAWSService) AddAccount(addAccount *awsmodels.IdsecCCEAWSAddAccount) (*ccemodels.IdsecCCEOnboardingOperation, error) {
+	accountID, err := s.addAccount(addAccount, ccemodels.Programmatic)
+	if err != nil {
+		return nil, err
+	}
+	return s.newOnboardingOperation(accountID, awsmodels.WorkspaceTypeAccount, func() (string, error) {
+		account, err := s.account(accountID)
+		if err != nil {
+			return "", err
+		}
+		return account.Status, nil
+	}), nil
+}
+
+// UpdateAccount updates the services of an onboarded AWS account.
+// Services missing from the input are removed and new services are added.
+// API: POST/DELETE /api/aws/programmatic/account/{id}/services
+func (s *IdsecCCEAWSService) UpdateAccount(updateAccount *awsmodels.IdsecCCEAWSUpdateAccount) (*awsmodels.IdsecCCEAWSAccount, error) {
+	return s.updateAccount(updateAccount)
+}
+
+// DeleteAccount deletes an onboarded AWS account.
+// API: DELETE /api/aws/programmatic/account/{id}
+func (s *IdsecCCEAWSService) DeleteAccount(deleteAccount *awsmodels.IdsecCCEAWSDeleteAccount) error {
+	return s.deleteAccount(deleteAccount.ID)
+}
+
+// newOnboardingOperation creates an onboarding operation handle and populates its initial status.
+// A failure to retrieve the initial status is logged, as the resource was already added.
+func (s *IdsecCCEAWSService) newOnboardingOperation(id string, resourceType string, statusFunc ccemodels.IdsecCCEOnboardingStatusFunc) *ccemodels.IdsecCCEOnboardingOperation {
+	operation := ccemodels.NewIdsecCCEOnboardingOperation(id, resourceType, statusFunc)
+	if _, err := operation.Refresh(); err != nil {
+		s.Logger.Warning("Failed to get initial onboarding status of %s [%s]: %v", resourceType, id, err)
+	}
+	return operation
+}
+
+// listWorkspaces streams the AWS workspaces of the given type as pages.
+func (s *IdsecCCEAWSService) listWorkspaces(workspaceType string, filter *awsmodels.IdsecCCEAWSWorkspacesFilter) <-chan *IdsecCCEAWSWorkspacesPage {
+	if filter == nil {
+		filter = &awsmodels.IdsecCCEAWSWorkspacesFilter{}
+	}
+	results := make(chan *IdsecCCEAWSWorkspacesPage)
+	go func() {
+		defer close(results)
+		pageChannel, errorChannel := s.tfWorkspacesStream(&awsmodels.TfIdsecCCEAWSGetWorkspacesTerraform{
+			IncludeSuspended:       filter.IncludeSuspended,
+			IncludeEmptyWorkspaces: filter.IncludeEmptyWorkspaces,
+			ParentID:               filter.ParentID,
+			Services:               filter.Services,
+			WorkspaceStatus:        filter.WorkspaceStatus,
+			WorkspaceType:          workspaceType,
+		})
+		for page := range pageChannel {
+			items := make([]*ccemodels.IdsecCCEWorkspace, len(page.Workspaces))
+			for i := range page.Workspaces {
+				items[i] = &page.Workspaces[i]
+			}
+			results <- &IdsecCCEAWSWorkspacesPage{Items: items}
+		}
+		if err := <-errorChannel; err != nil {
+			results <- &IdsecCCEAWSWorkspacesPage{Err: err}
+		}
+	}()
+	return results
+}
+
+// toTfOrganization converts an organization to its Terraform provider representation.
+func toTfOrganization(organization *awsmodels.IdsecCCEAWSOrganization) *awsmodels.TfIdsecCCEAWSOrganization {
+	return &awsmodels.TfIdsecCCEAWSOrganization{
+		ID:                  organization.ID,
+		OrganizationRootID:  organization.OrganizationRootID,
+		ManagementAccountID: organization.ManagementAccountID,
+		OrganizationID:      organization.OrganizationID,
+		OnboardingType:      organization.OnboardingType,
+		Region:              organization.Region,
+		DisplayName:         organization.DisplayName,
+		Parameters:          organization.Parameters,
+		Status:              organization.Status,
+		LastSuccessfulScan:  organization.LastSuccessfulScan,
+	}
+}
+
 // TfOrganization retrieves AWS organization details by management account ID.
 // ⚠️  DEPRECATED: This function is deprecated and should not be used.
 // ⚠️  It exists only for compatibility with Terraform provider.
 // API: GET /api/aws/programmatic/organization/{id}
 func (s *IdsecCCEAWSService) TfOrganization(input *awsmodels.TfIdsecCCEAWSGetOrganization) (*awsmodels.TfIdsecCCEAWSOrganization, error) {
-	return s.tfOrganization(input)
+	organization, err := s.organization(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	return toTfOrganization(organization), nil
 }
 
 // TfOrganizationDatasource retrieves AWS organization details with services information by management account ID.
@@ -81,7 +243,15 @@ func (s *IdsecCCEAWSService) TfOrganization(input *awsmodels.TfIdsecCCEAWSGetOrg
 // ⚠️  It exists only for compatibility with Terraform provider.
 // API: GET /api/aws/programmatic/organization/{id}
 func (s *IdsecCCEAWSService) TfOrganizationDatasource(input *awsmodels.TfIdsecCCEAWSGetOrganization) (*awsmodels.TfIdsecCCEAWSOrganizationDatasource, error) {
-	return s.tfOrganizationDatasource(input)
+	organization, err := s.organization(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &awsmodels.TfIdsecCCEAWSOrganizationDatasource{
+		TfIdsecCCEAWSOrganization: *toTfOrganization(organization),
+		Services:                  organization.Services,
+		ServicesData:              organization.ServicesData,
+	}, nil
 }
 
 // TfAddOrganization adds an AWS organization programmatically using the organization's management account.
@@ -90,7 +260,18 @@ func (s *IdsecCCEAWSService) TfOrganizationDatasource(input *awsmodels.TfIdsecCC
 // ⚠️  It exists only for compatibility with Terraform provider.
 // API: POST /api/aws/programmatic/organization
 func (s *IdsecCCEAWSService) TfAddOrganization(input *awsmodels.TfIdsecCCEAWSAddOrganization) (*awsmodels.TfIdsecCCEAWSOrganization, error) {
-	return s.tfAddOrganization(input)
+	organizationID, err := s.addOrganization((*awsmodels.IdsecCCEAWSAddOrganization)(input), ccemodels.TerraformProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Retrieve the full organization details with retry logic
+	s.Logger.Info("Retrieving organization details for ID [%s]", organizationID)
+	organization, err := s.getOrganizationWithRetry(organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve organization after creation: %w", err)
+	}
+	return toTfOrganization(organization), nil
 }
 
 // TfDeleteOrganization deletes an AWS organization by its onboarding ID.
@@ -98,7 +279,7 @@ func (s *IdsecCCEAWSService) TfAddOrganization(input *awsmodels.TfIdsecCCEAWSAdd
 // ⚠️  It exists only for compatibility with Terraform provider.
 // API: DELETE /api/aws/programmatic/organization/{id}
 func (s *IdsecCCEAWSService) TfDeleteOrganization(input *awsmodels.TfIdsecCCEAWSGetOrganization) error {
-	return s.tfDeleteOrganization(input)
+	return s.deleteOrganization(input.ID)
 }
 
 // TfUpdateOrganization updates an AWS organization programmatically by reconciling service changes.
@@ -107,7 +288,11 @@ func (s *IdsecCCEAWSService) TfDeleteOrganization(input *awsmodels.TfIdsecCCEAWS
 // ⚠️  DEPRECATED: This function is deprecated and should not be used.
 // ⚠️  It exists only for compatibility with Terraform provider.
 func (s *IdsecCCEAWSService) TfUpdateOrganization(input *awsmodels.TfIdsecCCEAWSUpdateOrganization) (*awsmodels.TfIdsecCCEAWSOrganization, error) {
-	return s.tfUpdateOrganization(input)
+	organization, err := s.updateOrganization((*awsmodels.IdsecCCEAWSUpdateOrganization)(input))
+	if err != nil {
+		return nil, err
+	}
+	return toTfOrganization(organization), nil
 }
 
 // Workspaces retrieves AWS organizations and single accounts with optional filtering.
@@ -311,156 +496,43 @@ func (s *IdsecCCEAWSService) TfTenantServiceDetails(input *awsmodels.TfIdsecCCEA
 // ⚠️  It exists only for compatibility with Terraform provider.
 // API: POST /api/aws/programmatic/account
 func (s *IdsecCCEAWSService) TfAddAccount(input *awsmodels.TfIdsecCCEAWSAddAccount) (*awsmodels.TfIdsecCCEAWSAccount, error) {
-	s.Logger.Info("Adding AWS account [%s]", input.AccountID)
-
 	// Explicitly set the onboarding type to terraform_provider if not defined
 	if input.OnboardingType == nil {
 		defaultOnboardingType := ccemodels.TerraformProvider
 		input.OnboardingType = &defaultOnboardingType
 	}
 
-	// POST to add the account
-	response, err := s.ISPClient().Post(context.Background(), pathAccountAddURL, input)
-	if err != nil {
-		return nil, err
-	}
-	defer cceinternal.CloseResponseBody(response.Body)
-
-	// Handle non-2xx status codes
-	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
-		return nil, cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, "failed to add account")
-	}
-
-	responseJSON, err := common.DeserializeJSONSnake(response.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	var addedAccount awsmodels.TfIdsecCCEAWSAddedAccount
-	err = mapstructure.Decode(responseJSON, &addedAccount)
+	accountID, err := s.addAccount(&awsmodels.IdsecCCEAWSAddAccount{
+		AccountID:          input.AccountID,
+		Services:           input.Services,
+		AccountDisplayName: input.AccountDisplayName,
+		DeploymentRegion:   input.DeploymentRegion,
+	}, *input.OnboardingType)
 	if err != nil {
 		return nil, err
 	}
 
 	// Retrieve the full account details
-	s.Logger.Info("Retrieving account details for ID [%s]", addedAccount.ID)
-	account, err := s.accountWithRetry(&awsmodels.TfIdsecCCEAWSGetAccount{
-		ID: addedAccount.ID, // the onboarding ID of the added account
-	})
+	s.Logger.Info("Retrieving account details for ID [%s]", accountID)
+	account, err := s.accountWithRetry(accountID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve account after creation: %w", err)
 	}
 
-	return account, nil
+	return (*awsmodels.TfIdsecCCEAWSAccount)(account), nil
 }
 
-// UpdateAccount updates an AWS account programmatically by reconciling service changes.
+// TfUpdateAccount updates an AWS account programmatically by reconciling service changes.
 // Compares the desired services in the input with the current services on the account,
 // then adds new services and removes services that are no longer desired.
 // ⚠️  DEPRECATED: This function is deprecated and should not be used.
 // ⚠️  It exists only for compatibility with Terraform provider.
 func (s *IdsecCCEAWSService) TfUpdateAccount(input *awsmodels.TfIdsecCCEAWSUpdateAccount) (*awsmodels.TfIdsecCCEAWSAccount, error) {
-	s.Logger.Info("Updating AWS account [%s]", input.ID)
-	// Step 1: Get current account details to determine existing services
-	s.Logger.Info("Getting AWS Account details for ID [%s]", input.ID)
-	url := fmt.Sprintf(pathAccountGetOrDeleteURL, input.ID)
-	response, err := s.ISPClient().Get(context.Background(), url, nil)
+	account, err := s.updateAccount((*awsmodels.IdsecCCEAWSUpdateAccount)(input))
 	if err != nil {
 		return nil, err
 	}
-	defer cceinternal.CloseResponseBody(response.Body)
-
-	// Handle non-2xx status codes
-	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
-		return nil, cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, "failed to get account details")
-	}
-
-	accountJSON, err := common.DeserializeJSONSnake(response.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	// Extract current services from raw JSON
-	var currentServiceNames []string
-	if orgMap, ok := accountJSON.(map[string]interface{}); ok {
-		if servicesRaw, exists := orgMap["services"]; exists {
-			if servicesList, ok := servicesRaw.([]interface{}); ok {
-				for _, svc := range servicesList {
-					if svcStr, ok := svc.(string); ok {
-						currentServiceNames = append(currentServiceNames, svcStr)
-					}
-				}
-			}
-		}
-	}
-
-	// Step 2: Compare services to determine what to add and what to remove
-	// Build maps for efficient lookup
-	desiredServicesMap := make(map[string]ccemodels.IdsecCCEServiceInput)
-	for _, service := range input.Services {
-		desiredServicesMap[service.ServiceName] = service
-	}
-
-	currentServices := make(map[string]bool)
-	for _, serviceName := range currentServiceNames {
-		currentServices[serviceName] = true
-	}
-
-	s.Logger.Info("Current account services: %v", currentServiceNames)
-
-	// Determine services to add (in desired but not in current)
-	var servicesToAdd []ccemodels.IdsecCCEServiceInput
-	for serviceName, service := range desiredServicesMap {
-		if !currentServices[serviceName] {
-			servicesToAdd = append(servicesToAdd, service)
-			s.Logger.Info("Service '%s' will be ADDED", serviceName)
-		}
-	}
-
-	// Determine services to remove (in current but not in desired)
-	var servicesToRemove []string
-	for serviceName := range currentServices {
-		if _, exists := desiredServicesMap[serviceName]; !exists {
-			servicesToRemove = append(servicesToRemove, serviceName)
-			s.Logger.Info("Service '%s' will be REMOVED", serviceName)
-		}
-	}
-
-	// Step 3: Add new services if any
-	s.Logger.Info("Services to add: %d, Services to remove: %d\n", len(servicesToAdd), len(servicesToRemove))
-	if len(servicesToAdd) > 0 {
-		s.Logger.Info("Adding %d services to account [%s]", len(servicesToAdd), input.ID)
-		err = s.TfAddAccountServices(&awsmodels.TfIdsecCCEAWSAddAccountServices{
-			ID:       input.ID,
-			Services: servicesToAdd,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to add services: %w", err)
-		}
-	}
-
-	// Step 4: Remove services that are no longer desired
-	if len(servicesToRemove) > 0 {
-		s.Logger.Info("Removing %d services from account [%s]", len(servicesToRemove), input.ID)
-		err = s.DeleteAccountServices(&awsmodels.TfIdsecCCEAWSDeleteAccountServices{
-			ID:           input.ID,
-			ServiceNames: servicesToRemove,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to remove services: %w", err)
-		}
-	}
-
-	// Step 5: Fetch and return updated account details
-	s.Logger.Info("Fetching full details for account [%s]", input.ID)
-	fullAccount, err := s.accountWithRetry(&awsmodels.TfIdsecCCEAWSGetAccount{
-		ID: input.ID,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("account created with ID %s, but failed to fetch details: %w", input.ID, err)
-	}
-
-	return fullAccount, nil
+	return (*awsmodels.TfIdsecCCEAWSAccount)(account), nil
 }
 
 // accountDetailsForUpdate contains the extracted account information needed for updating organization accounts.
@@ -691,9 +763,11 @@ func (s *IdsecCCEAWSService) TfUpdateOrganizationAccount(input *awsmodels.TfIdse
 	if len(servicesToAdd) == 0 {
 		s.Logger.Info("No new services to add, account is already up to date")
 		// Still fetch and return current account details for consistency
-		return s.accountWithRetry(&awsmodels.TfIdsecCCEAWSGetAccount{
-			ID: input.ID,
-		})
+		account, err := s.accountWithRetry(input.ID)
+		if err != nil {
+			return nil, err
+		}
+		return (*awsmodels.TfIdsecCCEAWSAccount)(account), nil
 	}
 
 	// Step 4: Add only the NEW services using the organization API endpoint
@@ -710,119 +784,33 @@ func (s *IdsecCCEAWSService) TfUpdateOrganizationAccount(input *awsmodels.TfIdse
 
 	// Step 5: Fetch and return updated account details using existing retry logic
 	s.Logger.Info("Fetching full account details for account ID [%s]", input.ID)
-	fullAccount, err := s.accountWithRetry(&awsmodels.TfIdsecCCEAWSGetAccount{
-		ID: input.ID,
-	})
+	fullAccount, err := s.accountWithRetry(input.ID)
 	if err != nil {
 		return nil, fmt.Errorf("services added successfully but failed to fetch updated account details: %w", err)
 	}
 
 	s.Logger.Info("Successfully updated organization account [%s]", input.ID)
-	return fullAccount, nil
+	return (*awsmodels.TfIdsecCCEAWSAccount)(fullAccount), nil
 }
 
-// accountWithRetry wraps the Account() method with retry logic for transient failures.
-// ⚠️  DEPRECATED: This function is deprecated and should not be used.
-// ⚠️  It exists only for compatibility with Terraform provider.
-// API: GET /api/aws/programmatic/account/{id}
-func (s *IdsecCCEAWSService) accountWithRetry(input *awsmodels.TfIdsecCCEAWSGetAccount) (*awsmodels.TfIdsecCCEAWSAccount, error) {
-	s.Logger.Info("Getting AWS account details with retry for ID [%s]", input.ID)
-
-	maxRetries := cceinternal.DefaultMaxRequestRetries
-	retryDelay := cceinternal.DefaultRequestRetryDelay
-
-	var lastErr error
-	for attempt := 0; attempt <= maxRetries; attempt++ {
-		if attempt > 0 {
-			s.Logger.Info("Retry attempt %d/%d after %v", attempt, maxRetries, retryDelay)
-			time.Sleep(retryDelay)
-		}
-
-		account, err := s.TfAccount(input)
-		if err == nil {
-			return account, nil
-		}
-
-		lastErr = err
-
-		// Check if error is retryable
-		if !cceinternal.IsRetryableError(0, err) {
-			s.Logger.Info("Non-retryable error, not retrying: %v", err)
-			break
-		}
-
-		s.Logger.Info("Retryable error on attempt %d: %v", attempt+1, err)
-	}
-
-	return nil, fmt.Errorf("failed to get account details after %d attempts: %w", maxRetries+1, lastErr)
-}
-
-// Account retrieves AWS account details by account ID and deserializes union types.
+// TfAccount retrieves AWS account details by account onboarding ID.
 // ⚠️  DEPRECATED: This function is deprecated and should not be used.
 // ⚠️  It exists only for compatibility with Terraform provider.
 // API: GET /api/aws/programmatic/account/{id}
 func (s *IdsecCCEAWSService) TfAccount(input *awsmodels.TfIdsecCCEAWSGetAccount) (*awsmodels.TfIdsecCCEAWSAccount, error) {
-	s.Logger.Info("Getting AWS Account details for ID [%s]", input.ID)
-	url := fmt.Sprintf(pathAccountGetOrDeleteURL, input.ID)
-	response, err := s.ISPClient().Get(context.Background(), url, nil)
+	account, err := s.account(input.ID)
 	if err != nil {
 		return nil, err
 	}
-	defer cceinternal.CloseResponseBody(response.Body)
-
-	// Handle non-2xx status codes
-	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
-		return nil, cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, "failed to get account details")
-	}
-
-	accountJSON, err := common.DeserializeJSONSnake(response.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	var account awsmodels.TfIdsecCCEAWSAccount
-	err = mapstructure.Decode(accountJSON, &account)
-	if err != nil {
-		return nil, err
-	}
-
-	// Extract ServiceNames from raw JSON since it has mapstructure:"-" tag
-	if accountMap, ok := accountJSON.(map[string]interface{}); ok {
-		if servicesRaw, exists := accountMap["services"]; exists {
-			if servicesList, ok := servicesRaw.([]interface{}); ok {
-				account.ServiceNames = make([]string, 0, len(servicesList))
-				for _, svc := range servicesList {
-					if svcStr, ok := svc.(string); ok {
-						account.ServiceNames = append(account.ServiceNames, svcStr)
-					}
-				}
-			}
-		}
-	}
-
-	return &account, nil
+	return (*awsmodels.TfIdsecCCEAWSAccount)(account), nil
 }
 
-// DeleteAccount deletes an AWS account programmatically.
+// TfDeleteAccount deletes an AWS account programmatically.
 // ⚠️  DEPRECATED: This function is deprecated and should not be used.
 // ⚠️  It exists only for compatibility with Terraform provider.
 // API: DELETE /api/aws/programmatic/account/{id}
 func (s *IdsecCCEAWSService) TfDeleteAccount(input *awsmodels.TfIdsecCCEAWSDeleteAccount) error {
-	s.Logger.Info("Deleting AWS account with ID [%s]", input.ID)
-
-	url := fmt.Sprintf(pathAccountGetOrDeleteURL, input.ID)
-	response, err := s.ISPClient().Delete(context.Background(), url, nil, nil)
-	if err != nil {
-		return fmt.Errorf("failed to delete account: %w", err)
-	}
-	defer cceinternal.CloseResponseBody(response.Body)
-
-	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
-		bodyBytes, _ := io.ReadAll(response.Body)
-		return fmt.Errorf("failed to delete account: status code %d, body: %s", response.StatusCode, string(bodyBytes))
-	}
-
-	return nil
+	return s.deleteAccount(input.ID)
 }
 
 // AddAccountServices adds services to an AWS account programmatically
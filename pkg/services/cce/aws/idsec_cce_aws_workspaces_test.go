@@ -600,3 +600,74 @@ func TestWorkspaces_ErrorPropagation(t *testing.T) {
 		return err
 	})
 }
+
+func TestListOrganizationsBy_StreamsPages(t *testing.T) {
+	var capturedQueries []string
+	client, cleanup := internal.SetupMockCCEService(t, []internal.MockEndpointConfig{
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "GET" && r.URL.Path == "/api/aws/workspaces" && r.URL.Query().Get("page") == "1"
+			},
+			StatusCode: http.StatusOK,
+			ResponseBody: `{
+				"workspaces": [{"key": "org-1", "data": {"id": "org-1", "type": "aws_organization", "status": "Completely added"}}],
+				"page": {"page_number": 1, "page_size": 100, "is_last_page": false, "total_records": 2}
+			}`,
+			OnRequest: func(r *http.Request) {
+				capturedQueries = append(capturedQueries, r.URL.RawQuery)
+			},
+		},
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "GET" && r.URL.Path == "/api/aws/workspaces"
+			},
+			StatusCode: http.StatusOK,
+			ResponseBody: `{
+				"workspaces": [{"key": "org-2", "data": {"id": "org-2", "type": "aws_organization", "status": "Failed to add"}}],
+				"page": {"page_number": 2, "page_size": 100, "is_last_page": true, "total_records": 2}
+			}`,
+		},
+	})
+	defer cleanup()
+
+	service := setupAWSService(client)
+	filter := &awsmodels.IdsecCCEAWSWorkspacesFilter{IncludeSuspended: true}
+	filter.Services = "sca"
+	pages, err := service.ListOrganizationsBy(filter)
+	require.NoError(t, err)
+
+	var keys []string
+	for page := range pages {
+		require.NoError(t, page.Err)
+		for _, workspace := range page.Items {
+			keys = append(keys, workspace.Key)
+		}
+	}
+	require.Equal(t, []string{"org-1", "org-2"}, keys)
+	require.Len(t, capturedQueries, 1)
+	require.Contains(t, capturedQueries[0], "workspace_type=aws_organization")
+	require.Contains(t, capturedQueries[0], "include_suspended=true")
+	require.Contains(t, capturedQueries[0], "services=sca")
+}
+
+func TestListAccounts_PaginationError(t *testing.T) {
+	client, cleanup := internal.SetupMockCCEService(t, []internal.MockEndpointConfig{
+		{
+			Matcher:      func(r *http.Request) bool { return true },
+			StatusCode:   http.StatusInternalServerError,
+			ResponseBody: `{"error": "test error"}`,
+		},
+	})
+	defer cleanup()
+
+	service := setupAWSService(client)
+	pages, err := service.ListAccounts()
+	require.NoError(t, err)
+
+	var lastErr error
+	for page := range pages {
+		require.Empty(t, page.Items)
+		lastErr = page.Err
+	}
+	require.Error(t, lastErr)
+}
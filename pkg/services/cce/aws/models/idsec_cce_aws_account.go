@@ -107,3 +107,66 @@ type TfIdsecCCEAWSDeleteAccountServices struct {
 	// ServiceNames is the list of service names to remove (e.g., ["dpa", "sca"]).
 	ServiceNames []string `json:"servicesNames" mapstructure:"services_names" validate:"required,min=1" desc:"List of services to remove from the account (SIA, SCA, SecretsHub, CDS)."`
 }
+
+// IdsecCCEAWSGetAccount is the input for getting an onboarded AWS account.
+// OPENAPI-CORRELATION: Input for GET /api/aws/programmatic/account/{id}
+type IdsecCCEAWSGetAccount struct {
+	// ID is the account's onboarding ID.
+	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE account onboarding ID."`
+}
+
+// IdsecCCEAWSAccount represents an AWS account onboarded to CCE.
+// OPENAPI-CORRELATION: GetAccountDetailsOutput
+type IdsecCCEAWSAccount struct {
+	// ID is the CCE onboarding ID for the account.
+	ID string `json:"id" mapstructure:"id" desc:"CCE account onboarding ID."`
+	// AccountID is the AWS account ID (12 digits).
+	AccountID string `json:"account_id" mapstructure:"account_id" desc:"AWS account ID (12 digits)"`
+	// OnboardingType indicates how the account was onboarded: "standard" (UI), "programmatic" (API), or "terraform_provider".
+	OnboardingType string `json:"onboarding_type" mapstructure:"onboarding_type" choices:"standard,programmatic,terraform_provider" desc:"The method used to deploy resources in AWS."`
+	// Region is the AWS deployment region where CCE resources were created.
+	Region string `json:"region,omitempty" mapstructure:"region" desc:"AWS region where CCE resources were created."`
+	// ServiceNames is the list of onboarded service names (e.g., ["dpa", "sca"]).
+	ServiceNames []string `json:"services" mapstructure:"services" desc:"List of services (SIA, SCA, SecretsHub, CDS)."`
+	// DisplayName is the display name shown in the CCE UI.
+	DisplayName string `json:"display_name,omitempty" mapstructure:"display_name" desc:"Display name shown in the CCE UI."`
+	// Parameters contains service-specific configuration parameters, keyed by service name.
+	Parameters map[string]map[string]interface{} `json:"parameters,omitempty" mapstructure:"parameters" desc:"A key-value map of service-specific configuration parameters, keyed by service name."`
+	// Status is the overall onboarding status of the account.
+	Status string `json:"status,omitempty" mapstructure:"status" desc:"Onboarding status: Completely added, Partially added, Failed to add."`
+	// OrganizationID is the CCE onboarding ID of the parent AWS organization if this account belongs to one.
+	OrganizationID string `json:"organization_id,omitempty" mapstructure:"organization_id" desc:"CCE onboarding ID of the parent AWS organization."`
+	// OrganizationName is the display name of the parent AWS organization if this account belongs to one.
+	OrganizationName string `json:"organization_name,omitempty" mapstructure:"organization_name" desc:"Display name of the parent AWS organization shown in the CCE UI."`
+	// DuplicatedServices lists services that are deployed both in this account and in a parent organization.
+	DuplicatedServices *[]string `json:"duplicated_services,omitempty" mapstructure:"duplicated_services" desc:"Service resources deployed to this account and to the parent organization."`
+}
+
+// IdsecCCEAWSAddAccount is the input for adding an AWS account programmatically.
+// OPENAPI-CORRELATION: AwsProgrammaticCreateAccountInput
+type IdsecCCEAWSAddAccount struct {
+	// AccountID is the AWS account ID (12 digits).
+	AccountID string `json:"accountId" mapstructure:"account_id" validate:"required,len=12,numeric" desc:"AWS account ID (12 digits) found in the upper right corner of the AWS console."`
+	// Services is the list of services to onboard with their resource configurations.
+	Services []ccemodels.IdsecCCEServiceInput `json:"services" mapstructure:"services" validate:"required,min=1,dive" desc:"List of services to add to the account (SIA, SCA, SecretsHub, CDS) and their associated resources."`
+	// AccountDisplayName is the optional display name for the account that will appear in the CCE UI.
+	AccountDisplayName string `json:"accountDisplayName,omitempty" mapstructure:"account_display_name" desc:"Optional name for the account shown in the CCE UI."`
+	// DeploymentRegion is the AWS region where resources will be created. If not specified, the tenant region will be used.
+	DeploymentRegion string `json:"deploymentRegion,omitempty" mapstructure:"deployment_region" desc:"AWS region where the account is deployed, for example, us-east-1. If not specified, the tenant region is used."`
+}
+
+// IdsecCCEAWSUpdateAccount is the input for updating the services of an AWS account.
+// OPENAPI-CORRELATION: Custom input combining multiple endpoints
+type IdsecCCEAWSUpdateAccount struct {
+	// ID is the account's onboarding ID.
+	ID string `json:"id,omitempty" mapstructure:"id" validate:"required" desc:"GUID of the added account without hyphens. For example, ef858a2d8f8f4f1781578089bb4ea010."`
+	// Services is the desired list of services with their resource configurations.
+	Services []ccemodels.IdsecCCEServiceInput `json:"services" mapstructure:"services" validate:"required,min=1,dive" desc:"List of services to keep on the account (SIA, SCA, SecretsHub, CDS) and their associated resources."`
+}
+
+// IdsecCCEAWSDeleteAccount is the input for deleting an onboarded AWS account.
+// OPENAPI-CORRELATION: Input for DELETE /api/aws/programmatic/account/{id}
+type IdsecCCEAWSDeleteAccount struct {
+	// ID is the account's onboarding ID.
+	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE account onboarding ID."`
+}
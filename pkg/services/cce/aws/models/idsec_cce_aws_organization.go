@@ -102,3 +102,71 @@ type TfIdsecCCEAWSOrganizationDatasource struct {
 	ServicesData []ccemodels.IdsecCCEOnboardedService `json:"servicesData" mapstructure:"services_data" desc:"Detailed information about each service."`
 	// Note: Parameters field is inherited from TfIdsecCCEAWSOrganization (embedded with squash)
 }
+
+// IdsecCCEAWSGetOrganization is the input for getting an onboarded AWS organization.
+// OPENAPI-CORRELATION: Input for GET /api/aws/programmatic/organization/{id}
+type IdsecCCEAWSGetOrganization struct {
+	// ID is the organization's onboarding ID.
+	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE organization onboarding ID."`
+}
+
+// IdsecCCEAWSOrganization represents an AWS organization onboarded to CCE.
+// OPENAPI-CORRELATION: GetAwsOrganizationDetailsOutput
+type IdsecCCEAWSOrganization struct {
+	// ID is the CCE onboarding ID of the organization.
+	ID string `json:"id" mapstructure:"id" desc:"CCE organization onboarding ID."`
+	// OrganizationRootID is the AWS organization's root ID.
+	OrganizationRootID string `json:"organization_root_id" mapstructure:"organization_root_id" desc:"Organization's root ID."`
+	// ManagementAccountID is the AWS organization's management account ID.
+	ManagementAccountID string `json:"management_account_id" mapstructure:"management_account_id" desc:"Organization's management account ID."`
+	// OrganizationID is the AWS organization ID.
+	OrganizationID string `json:"organization_id" mapstructure:"organization_id" desc:"AWS organization ID."`
+	// OnboardingType indicates how the organization was onboarded.
+	OnboardingType string `json:"onboarding_type" mapstructure:"onboarding_type" choices:"standard,programmatic,terraform_provider" desc:"The method used to deploy resources in AWS."`
+	// Region is the AWS region where CCE resources were created.
+	Region string `json:"region,omitempty" mapstructure:"region,omitempty" desc:"AWS region where the organization is located."`
+	// DisplayName is the display name shown in the CCE UI.
+	DisplayName string `json:"display_name,omitempty" mapstructure:"display_name,omitempty" desc:"Display name shown in the CCE UI."`
+	// Parameters contains service-specific configuration parameters, keyed by service name.
+	Parameters map[string]map[string]interface{} `json:"parameters,omitempty" mapstructure:"parameters" desc:"A key-value map of service-specific configuration parameters, keyed by service name."`
+	// Status is the overall onboarding status of the organization.
+	Status string `json:"status,omitempty" mapstructure:"status,omitempty" choices:"Removing,Deploying resources,Waiting for deployment,Partially added,Failed to add,Service Error,Completely added" desc:"Onboarding status of the organization."`
+	// LastSuccessfulScan is the timestamp of the last successful organization scan.
+	LastSuccessfulScan string `json:"last_successful_scan,omitempty" mapstructure:"last_successful_scan,omitempty" desc:"Timestamp of the last successful organization scan (RFC3339 format)."`
+	// Services is the list of onboarded service names (e.g., ["dpa", "sca"]).
+	Services []string `json:"services" mapstructure:"services" desc:"List of services (SIA, SCA, SecretsHub, CDS)."`
+	// ServicesData contains detailed information about each onboarded service.
+	ServicesData []ccemodels.IdsecCCEOnboardedService `json:"services_data" mapstructure:"services_data" desc:"Detailed information about each service."`
+}
+
+// IdsecCCEAWSAddOrganization is the input for adding an AWS organization programmatically.
+// OPENAPI-CORRELATION: AwsProgrammaticCreateOrganizationInput
+type IdsecCCEAWSAddOrganization struct {
+	OrganizationRootID         string                            `json:"organizationRootId" mapstructure:"organization_root_id" validate:"required,min=6,max=34,regexp=^r-[0-9a-z]+$" desc:"Organization's root ID."`
+	ManagementAccountID        string                            `json:"managementAccountId" mapstructure:"management_account_id" validate:"required,pattern=^\\d{12}$" desc:"Organization's management account ID."`
+	OrganizationID             string                            `json:"organizationId" mapstructure:"organization_id" validate:"required,min=12,max=34,regexp=^o-[a-z0-9]+$" desc:"AWS organization ID."`
+	Services                   []ccemodels.IdsecCCEServiceInput  `json:"services" mapstructure:"services" validate:"required" desc:"List of services to add (SIA, SCA, SecretsHub, CDS) and their associated resources."`
+	ServiceParameters          map[string]map[string]interface{} `json:"serviceParameters,omitempty" mapstructure:"service_parameters,omitempty" desc:"A key-value map of service-specific configuration parameters, keyed by service name."`
+	OrganizationDisplayName    string                            `json:"organizationDisplayName,omitempty" mapstructure:"organization_display_name,omitempty" desc:"Optional name for the organization shown in the CCE UI."`
+	ScanOrganizationRoleArn    string                            `json:"scanOrganizationRoleArn" mapstructure:"scan_organization_role_arn" validate:"required,pattern=arn:aws:iam::\\d{12}:role/.+" desc:"ARN of the role used by CCE to scan the organization."`
+	CrossAccountRoleExternalID string                            `json:"crossAccountRoleExternalId" mapstructure:"cross_account_role_external_id" validate:"required,pattern=^(cyberark0x7CIdira0x7CCCE)-[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$" desc:"External ID of the cross account role."`
+	DeploymentRegion           string                            `json:"deploymentRegion,omitempty" mapstructure:"region,omitempty" desc:"AWS region where CCE resources are deployed. If not specified, the tenant region is used."`
+}
+
+// IdsecCCEAWSUpdateOrganization is the input for updating the services of an AWS organization.
+// OPENAPI-CORRELATION: Custom input combining multiple endpoints
+type IdsecCCEAWSUpdateOrganization struct {
+	// ID is the organization's onboarding ID.
+	ID string `json:"id,omitempty" mapstructure:"id" validate:"required" desc:"CCE organization onboarding ID (for example, ef858a2d8f8f4f1781578089bb4ea010)"`
+	// Services is the desired list of services with their resource configurations.
+	Services []ccemodels.IdsecCCEServiceInput `json:"services" mapstructure:"services" validate:"required,min=1,dive" desc:"List of services to keep on the organization (SIA, SCA, SecretsHub, CDS) and their associated resources."`
+	// ServiceParameters contains service-specific parameters, keyed by service name.
+	ServiceParameters map[string]map[string]interface{} `json:"serviceParameters,omitempty" mapstructure:"service_parameters,omitempty" desc:"A key-value map of service-specific configuration parameters, keyed by service name."`
+}
+
+// IdsecCCEAWSDeleteOrganization is the input for deleting an onboarded AWS organization.
+// OPENAPI-CORRELATION: Input for DELETE /api/aws/programmatic/organization/{id}
+type IdsecCCEAWSDeleteOrganization struct {
+	// ID is the organization's onboarding ID.
+	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE organization onboarding ID."`
+}
@@ -4,6 +4,24 @@ import (
 	ccemodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/common/models"
 )
 
+// Possible AWS workspace types.
+const (
+	WorkspaceTypeOrganization = "aws_organization"
+	WorkspaceTypeRoot         = "aws_root"
+	WorkspaceTypeOU           = "aws_ou"
+	WorkspaceTypeAccount      = "aws_account"
+)
+
+// IdsecCCEAWSWorkspacesFilter is the filter for listing onboarded AWS organizations or accounts.
+// OPENAPI-CORRELATION: Input for GET /api/aws/workspaces
+type IdsecCCEAWSWorkspacesFilter struct {
+	ccemodels.IdsecCCEWorkspacesFilter `mapstructure:",squash"`
+	// IncludeSuspended indicates whether to include suspended accounts in the results (accounts with suspended services).
+	IncludeSuspended bool `json:"include_suspended,omitempty" mapstructure:"include_suspended,omitempty" desc:"Include suspended accounts in results"`
+	// IncludeEmptyWorkspaces indicates whether to include empty workspaces in the results (workspaces with no services deployed).
+	IncludeEmptyWorkspaces bool `json:"include_empty_workspaces,omitempty" mapstructure:"include_empty_workspaces,omitempty" desc:"Include empty workspaces in results"`
+}
+
 // TfIdsecCCEAWSGetWorkspaces is the input for retrieving AWS workspaces.
 // OPENAPI-CORRELATION: Input for GET /api/aws/workspaces
 type TfIdsecCCEAWSGetWorkspacesTerraform struct {
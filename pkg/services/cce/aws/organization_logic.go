@@ -16,12 +16,12 @@ import (
 	cceinternal "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/internal"
 )
 
-// tfOrganization retrieves AWS organization details by Organization onboarding ID.
+// organization retrieves AWS organization details by Organization onboarding ID.
 // API: GET /api/aws/programmatic/organization/{id}
-func (s *IdsecCCEAWSService) tfOrganization(input *awsmodels.TfIdsecCCEAWSGetOrganization) (*awsmodels.TfIdsecCCEAWSOrganization, error) {
-	s.Logger.Info("Getting AWS organization details for ID [%s]", input.ID)
+func (s *IdsecCCEAWSService) organization(organizationID string) (*awsmodels.IdsecCCEAWSOrganization, error) {
+	s.Logger.Info("Getting AWS organization details for ID [%s]", organizationID)
 
-	url := fmt.Sprintf(pathOrganizationGetOrDeleteURL, input.ID)
+	url := fmt.Sprintf(pathOrganizationGetOrDeleteURL, organizationID)
 	response, err := s.ISPClient().Get(context.Background(), url, nil)
 	if err != nil {
 		return nil, err
@@ -38,38 +38,7 @@ func (s *IdsecCCEAWSService) tfOrganization(input *awsmodels.TfIdsecCCEAWSGetOrg
 		return nil, err
 	}
 
-	var organization awsmodels.TfIdsecCCEAWSOrganization
-	err = mapstructure.Decode(organizationJSON, &organization)
-	if err != nil {
-		return nil, err
-	}
-
-	return &organization, nil
-}
-
-// tfOrganizationDatasource retrieves AWS organization details with services information by Organization onboarding ID.
-// API: GET /api/aws/programmatic/organization/{id}
-func (s *IdsecCCEAWSService) tfOrganizationDatasource(input *awsmodels.TfIdsecCCEAWSGetOrganization) (*awsmodels.TfIdsecCCEAWSOrganizationDatasource, error) {
-	s.Logger.Info("Getting AWS organization details for ID [%s]", input.ID)
-
-	url := fmt.Sprintf(pathOrganizationGetOrDeleteURL, input.ID)
-	response, err := s.ISPClient().Get(context.Background(), url, nil)
-	if err != nil {
-		return nil, err
-	}
-	defer cceinternal.CloseResponseBody(response.Body)
-
-	// Handle non-2xx status codes
-	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
-		return nil, cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, "failed to get organization details")
-	}
-
-	organizationJSON, err := common.DeserializeJSONSnake(response.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	var organization awsmodels.TfIdsecCCEAWSOrganizationDatasource
+	var organization awsmodels.IdsecCCEAWSOrganization
 	err = mapstructure.Decode(organizationJSON, &organization)
 	if err != nil {
 		return nil, err
@@ -80,10 +49,10 @@ func (s *IdsecCCEAWSService) tfOrganizationDatasource(input *awsmodels.TfIdsecCC
 
 // getOrganizationWithRetry retrieves an organization with retry logic.
 // It attempts to fetch the organization up to 3 times with 1 second delay between attempts.
-func (s *IdsecCCEAWSService) getOrganizationWithRetry(organizationID string) (*awsmodels.TfIdsecCCEAWSOrganization, error) {
-	var organization *awsmodels.TfIdsecCCEAWSOrganization
+func (s *IdsecCCEAWSService) getOrganizationWithRetry(organizationID string) (*awsmodels.IdsecCCEAWSOrganization, error) {
+	var organization *awsmodels.IdsecCCEAWSOrganization
 	err := common.RetryCall(func() error {
-		org, getErr := s.tfOrganization(&awsmodels.TfIdsecCCEAWSGetOrganization{ID: organizationID})
+		org, getErr := s.organization(organizationID)
 		if getErr != nil {
 			return getErr
 		}
@@ -100,25 +69,24 @@ func (s *IdsecCCEAWSService) getOrganizationWithRetry(organizationID string) (*a
 	return organization, nil
 }
 
-// tfAddOrganization adds an AWS organization programmatically using the organization's management account.
-// After creation, it retrieves the full organization details with retry logic (3 attempts, 1 second delay).
+// addOrganization adds an AWS organization programmatically using the organization's management account
+// and returns the onboarding ID of the added organization.
 // API: POST /api/aws/programmatic/organization
-func (s *IdsecCCEAWSService) tfAddOrganization(input *awsmodels.TfIdsecCCEAWSAddOrganization) (*awsmodels.TfIdsecCCEAWSOrganization, error) {
+func (s *IdsecCCEAWSService) addOrganization(input *awsmodels.IdsecCCEAWSAddOrganization, onboardingType string) (string, error) {
 	s.Logger.Info("Adding AWS organization with management account ID [%s]", input.ManagementAccountID)
 
 	// Convert input to map using JSON marshal/unmarshal
 	inputJSON, err := json.Marshal(input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal input: %w", err)
+		return "", fmt.Errorf("failed to marshal input: %w", err)
 	}
 
 	var requestBody map[string]interface{}
 	if err := json.Unmarshal(inputJSON, &requestBody); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal input to map: %w", err)
+		return "", fmt.Errorf("failed to unmarshal input to map: %w", err)
 	}
 
-	// Add hardcoded onboarding type
-	requestBody["onboardingType"] = ccemodels.TerraformProvider
+	requestBody["onboardingType"] = onboardingType
 
 	// Add serviceParameters if provided
 	if len(input.ServiceParameters) > 0 {
@@ -127,42 +95,35 @@ func (s *IdsecCCEAWSService) tfAddOrganization(input *awsmodels.TfIdsecCCEAWSAdd
 
 	response, err := s.ISPClient().Post(context.Background(), pathOrganizationAddURL, requestBody)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	defer cceinternal.CloseResponseBody(response.Body)
 
 	// Handle non-2xx status codes
 	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
-		return nil, cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, "failed to add organization")
+		return "", cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, "failed to add organization")
 	}
 
 	outputJSON, err := common.DeserializeJSONSnake(response.Body)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	var output awsmodels.TfIdsecCCEAWSAddOrganizationOutput
 	err = mapstructure.Decode(outputJSON, &output)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
-	// Retrieve the full organization details with retry logic
-	s.Logger.Info("Retrieving organization details for ID [%s]", output.ID)
-	organization, err := s.getOrganizationWithRetry(output.ID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve organization after creation: %w", err)
-	}
-
-	return organization, nil
+	return output.ID, nil
 }
 
-// tfDeleteOrganization deletes an AWS organization by its onboarding ID.
+// deleteOrganization deletes an AWS organization by its onboarding ID.
 // API: DELETE /api/aws/programmatic/organization/{id}
-func (s *IdsecCCEAWSService) tfDeleteOrganization(input *awsmodels.TfIdsecCCEAWSGetOrganization) error {
-	s.Logger.Info("Deleting AWS organization with ID [%s]", input.ID)
+func (s *IdsecCCEAWSService) deleteOrganization(organizationID string) error {
+	s.Logger.Info("Deleting AWS organization with ID [%s]", organizationID)
 
-	url := fmt.Sprintf(pathOrganizationGetOrDeleteURL, input.ID)
+	url := fmt.Sprintf(pathOrganizationGetOrDeleteURL, organizationID)
 	response, err := s.ISPClient().Delete(context.Background(), url, nil, nil)
 	if err != nil {
 		return err
@@ -177,10 +138,10 @@ func (s *IdsecCCEAWSService) tfDeleteOrganization(input *awsmodels.TfIdsecCCEAWS
 	return nil
 }
 
-// tfUpdateOrganization updates an AWS organization programmatically by reconciling service changes.
+// updateOrganization updates an AWS organization programmatically by reconciling service changes.
 // Compares the desired services in the input with the current services on the organization,
 // then adds new services and removes services that are no longer desired.
-func (s *IdsecCCEAWSService) tfUpdateOrganization(input *awsmodels.TfIdsecCCEAWSUpdateOrganization) (*awsmodels.TfIdsecCCEAWSOrganization, error) {
+func (s *IdsecCCEAWSService) updateOrganization(input *awsmodels.IdsecCCEAWSUpdateOrganization) (*awsmodels.IdsecCCEAWSOrganization, error) {
 	s.Logger.Info("Updating AWS organization [%s]", input.ID)
 	// Step 1: Get current organization details to determine existing services
 	// We need to extract services from the raw API response since it's not in the struct
@@ -430,9 +391,7 @@ func (s *IdsecCCEAWSService) triggerOrganizationScanIfNeeded(isNotFound bool, or
 	if isNotFound {
 		// Fetch the organization to get the AWS organization ID for triggering scan
 		s.Logger.Info("Fetching organization details for onboarding ID [%s] to trigger scan", organizationOnboardingID)
-		org, err := s.tfOrganization(&awsmodels.TfIdsecCCEAWSGetOrganization{
-			ID: organizationOnboardingID,
-		})
+		org, err := s.organization(organizationOnboardingID)
 		if err != nil {
 			return fmt.Errorf("failed to get organization details: %w", err)
 		}
@@ -473,9 +432,7 @@ func (s *IdsecCCEAWSService) waitForOrganizationScanCompletion(organizationID st
 		s.Logger.Info("Poll attempt %d/%d: Checking organization scan status", i+1, maxRetries)
 
 		// Get organization details to check last successful scan
-		org, orgErr := s.tfOrganization(&awsmodels.TfIdsecCCEAWSGetOrganization{
-			ID: organizationID,
-		})
+		org, orgErr := s.organization(organizationID)
 
 		if orgErr != nil {
 			s.Logger.Warning("Failed to get organization details: %v, will retry", orgErr)
@@ -562,12 +519,10 @@ func (s *IdsecCCEAWSService) tfAddOrganizationAccountSync(input *awsmodels.Idsec
 
 	// Fetch full account details using existing retry logic
 	s.Logger.Info("Fetching full account details for account ID [%s]", result.ID)
-	account, err := s.accountWithRetry(&awsmodels.TfIdsecCCEAWSGetAccount{
-		ID: result.ID,
-	})
+	account, err := s.accountWithRetry(result.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch account details after adding to organization: %w", err)
 	}
 
-	return account, nil
+	return (*awsmodels.TfIdsecCCEAWSAccount)(account), nil
 }
@@ -2,23 +2,48 @@ package actions
 
 import (
 	azuremodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/azure/models"
+	ccemodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/common/models"
 )
 
 // ActionToSchemaMap is a map that defines the mapping between CCE Azure action names and their corresponding schema types.
 var ActionToSchemaMap = map[string]interface{}{
 	// Entra actions
+	"list-entras":    nil,
+	"list-entras-by": &ccemodels.IdsecCCEWorkspacesFilter{},
+	"entra":          &azuremodels.IdsecCCEAzureGetEntra{},
+	"add-entra":      &azuremodels.IdsecCCEAzureAddEntra{},
+	"update-entra":   &azuremodels.IdsecCCEAzureUpdateEntra{},
+	"delete-entra":   &azuremodels.IdsecCCEAzureDeleteEntra{},
+
+	// Management Group actions
+	"list-management-groups":    nil,
+	"list-management-groups-by": &ccemodels.IdsecCCEWorkspacesFilter{},
+	"management-group":          &azuremodels.IdsecCCEAzureGetManagementGroup{},
+	"add-management-group":      &azuremodels.IdsecCCEAzureAddManagementGroup{},
+	"update-management-group":   &azuremodels.IdsecCCEAzureUpdateManagementGroup{},
+	"delete-management-group":   &azuremodels.IdsecCCEAzureDeleteManagementGroup{},
+
+	// Subscription actions
+	"list-subscriptions":    nil,
+	"list-subscriptions-by": &ccemodels.IdsecCCEWorkspacesFilter{},
+	"subscription":          &azuremodels.IdsecCCEAzureGetSubscription{},
+	"add-subscription":      &azuremodels.IdsecCCEAzureAddSubscription{},
+	"update-subscription":   &azuremodels.IdsecCCEAzureUpdateSubscription{},
+	"delete-subscription":   &azuremodels.IdsecCCEAzureDeleteSubscription{},
+
+	// Terraform provider Entra actions
 	"tf-add-entra":    &azuremodels.TfIdsecCCEAzureAddEntra{},
 	"tf-entra":        &azuremodels.TfIdsecCCEAzureGetEntra{},
 	"tf-update-entra": &azuremodels.TfIdsecCCEAzureUpdateEntra{},
 	"tf-delete-entra": &azuremodels.TfIdsecCCEAzureDeleteEntra{},
 
-	// Management Group actions
+	// Terraform provider Management Group actions
 	"tf-add-management-group":    &azuremodels.TfIdsecCCEAzureAddManagementGroup{},
 	"tf-management-group":        &azuremodels.TfIdsecCCEAzureGetManagementGroup{},
 	"tf-update-management-group": &azuremodels.TfIdsecCCEAzureUpdateManagementGroup{},
 	"tf-delete-management-group": &azuremodels.TfIdsecCCEAzureDeleteManagementGroup{},
 
-	// Subscription actions
+	// Terraform provider Subscription actions
 	"tf-add-subscription":    &azuremodels.TfIdsecCCEAzureAddSubscription{},
 	"tf-subscription":        &azuremodels.TfIdsecCCEAzureGetSubscription{},
 	"tf-update-subscription": &azuremodels.TfIdsecCCEAzureUpdateSubscription{},
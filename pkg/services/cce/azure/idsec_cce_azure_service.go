@@ -30,6 +30,9 @@ type azureWorkspacesAPIResponse struct {
 	Page       ccemodels.IdsecCCEPageOutput    `json:"page" mapstructure:"page"`
 }
 
+// IdsecCCEAzureWorkspacesPage is a page of onboarded Azure workspaces.
+type IdsecCCEAzureWorkspacesPage = common.IdsecPage[ccemodels.IdsecCCEWorkspace]
+
 // IdsecCCEAzureService is the implementation of the CCE Azure service.
 type IdsecCCEAzureService struct {
 	*services.IdsecBaseService
@@ -67,12 +70,204 @@ func (s *IdsecCCEAzureService) refreshCCEAzureAuth(client *common.IdsecClient) e
 	return nil
 }
 
+// ListEntras lists the Azure Entra tenants onboarded to CCE.
+// On failure during pagination, the channel emits a final page with Err set.
+// API: GET /api/azure/workspaces
+func (s *IdsecCCEAzureService) ListEntras() (<-chan *IdsecCCEAzureWorkspacesPage, error) {
+	return s.ListEntrasBy(&ccemodels.IdsecCCEWorkspacesFilter{})
+}
+
+// ListEntrasBy lists the Azure Entra tenants onboarded to CCE which match the given filter.
+// On failure during pagination, the channel emits a final page with Err set.
+// API: GET /api/azure/workspaces
+func (s *IdsecCCEAzureService) ListEntrasBy(filter *ccemodels.IdsecCCEWorkspacesFilter) (<-chan *IdsecCCEAzureWorkspacesPage, error) {
+	return s.listWorkspaces(azuremodels.WorkspaceTypeEntra, filter), nil
+}
+
+// Entra retrieves an onboarded Azure Entra tenant by its onboarding ID.
+// API: GET /api/azure/manual/entra/{id}
+func (s *IdsecCCEAzureService) Entra(getEntra *azuremodels.IdsecCCEAzureGetEntra) (*azuremodels.IdsecCCEAzureEntra, error) {
+	return s.entra(getEntra)
+}
+
+// AddEntra onboards an Azure Entra tenant programmatically.
+// The returned operation tracks the onboarding, which completes once the CCE resources are deployed.
+// API: POST /api/azure/manual
+func (s *IdsecCCEAzureService) AddEntra(addEntra *azuremodels.IdsecCCEAzureAddEntra) (*ccemodels.IdsecCCEOnboardingOperation, error) {
+	entraID, err := s.addEntra(addEntra, ccemodels.Programmatic)
+	if err != nil {
+		return nil, err
+	}
+	return s.newOnboardingOperation(entraID, azuremodels.WorkspaceTypeEntra, func() (string, error) {
+		entra, err := s.entra(&azuremodels.IdsecCCEAzureGetEntra{ID: entraID})
+		if err != nil {
+			return "", err
+		}
+		return entra.Status, nil
+	}), nil
+}
+
+// UpdateEntra updates the services of an onboarded Azure Entra tenant.
+// Services missing from the input are removed and new services are added.
+// API: POST/DELETE /api/azure/manual/{id}/services
+func (s *IdsecCCEAzureService) UpdateEntra(updateEntra *azuremodels.IdsecCCEAzureUpdateEntra) (*azuremodels.IdsecCCEAzureEntra, error) {
+	return s.updateEntra(updateEntra)
+}
+
+// DeleteEntra deletes an onboarded Azure Entra tenant.
+// API: DELETE /api/azure/manual/{id}
+func (s *IdsecCCEAzureService) DeleteEntra(deleteEntra *azuremodels.IdsecCCEAzureDeleteEntra) error {
+	return s.deleteEntra(deleteEntra)
+}
+
+// ListManagementGroups lists the Azure Management Groups onboarded to CCE.
+// On failure during pagination, the channel emits a final page with Err set.
+// API: GET /api/azure/workspaces
+func (s *IdsecCCEAzureService) ListManagementGroups() (<-chan *IdsecCCEAzureWorkspacesPage, error) {
+	return s.ListManagementGroupsBy(&ccemodels.IdsecCCEWorkspacesFilter{})
+}
+
+// ListManagementGroupsBy lists the Azure Management Groups onboarded to CCE which match the given filter.
+// On failure during pagination, the channel emits a final page with Err set.
+// API: GET /api/azure/workspaces
+func (s *IdsecCCEAzureService) ListManagementGroupsBy(filter *ccemodels.IdsecCCEWorkspacesFilter) (<-chan *IdsecCCEAzureWorkspacesPage, error) {
+	return s.listWorkspaces(azuremodels.WorkspaceTypeManagementGroup, filter), nil
+}
+
+// ManagementGroup retrieves an onboarded Azure Management Group by its onboarding ID.
+// API: GET /api/azure/manual/mgmtgroup/{id}
+func (s *IdsecCCEAzureService) ManagementGroup(getManagementGroup *azuremodels.IdsecCCEAzureGetManagementGroup) (*azuremodels.IdsecCCEAzureManagementGroup, error) {
+	return s.managementGroup(getManagementGroup)
+}
+
+// AddManagementGroup onboards an Azure Management Group programmatically.
+// The returned operation tracks the onboarding, which completes once the CCE resources are deployed.
+// API: POST /api/azure/manual
+func (s *IdsecCCEAzureService) AddManagementGroup(addManagementGroup *azuremodels.IdsecCCEAzureAddManagementGroup) (*ccemodels.IdsecCCEOnboardingOperation, error) {
+	managementGroupID, err := s.addManagementGroup(addManagementGroup, ccemodels.Programmatic)
+	if err != nil {
+		return nil, err
+	}
+	return s.newOnboardingOperation(managementGroupID, azuremodels.WorkspaceTypeManagementGroup, func() (string, error) {
+		managementGroup, err := s.managementGroup(&azuremodels.IdsecCCEAzureGetManagementGroup{ID: managementGroupID})
+		if err != nil {
+			return "", err
+		}
+		return managementGroup.Status, nil
+	}), nil
+}
+
+// UpdateManagementGroup updates the services of an onboarded Azure Management Group.
+// Services missing from the input are removed and new services are added.
+// API: POST/DELETE /api/azure/manual/{id}/services
+func (s *IdsecCCEAzureService) UpdateManagementGroup(updateManagementGroup *azuremodels.IdsecCCEAzureUpdateManagementGroup) (*azuremodels.IdsecCCEAzureManagementGroup, error) {
+	return s.updateManagementGroup(updateManagementGroup)
+}
+
+// DeleteManagementGroup deletes an onboarded Azure Management Group.
+// API: DELETE /api/azure/manual/{id}
+func (s *IdsecCCEAzureService) DeleteManagementGroup(deleteManagementGroup *azuremodels.IdsecCCEAzureDeleteManagementGroup) error {
+	return s.deleteManagementGroup(deleteManagementGroup)
+}
+
+// ListSubscriptions lists the Azure Subscriptions onboarded to CCE.
+// On failure during pagination, the channel emits a final page with Err set.
+// API: GET /api/azure/workspaces
+func (s *IdsecCCEAzureService) ListSubscriptions() (<-chan *IdsecCCEAzureWorkspacesPage, error) {
+	return s.ListSubscriptionsBy(&ccemodels.IdsecCCEWorkspacesFilter{})
+}
+
+// ListSubscriptionsBy lists the Azure Subscriptions onboarded to CCE which match the given filter.
+// On failure during pagination, the channel emits a final page with Err set.
+// API: GET /api/azure/workspaces
+func (s *IdsecCCEAzureService) ListSubscriptionsBy(filter *ccemodels.IdsecCCEWorkspacesFilter) (<-chan *IdsecCCEAzureWorkspacesPage, error) {
+	return s.listWorkspaces(azuremodels.WorkspaceTypeSubscription, filter), nil
+}
+
+// Subscription retrieves an onboarded Azure Subscription by its onboarding ID.
+// API: GET /api/azure/manual/subscription/{id}
+func (s *IdsecCCEAzureService) Subscription(getSubscription *azuremodels.IdsecCCEAzureGetSubscription) (*azuremodels.IdsecCCEAzureSubscription, error) {
+	return s.subscription(getSubscription)
+}
+
+// AddSubscription onboards an Azure Subscription programmatically.
+// The returned operation tracks the onboarding, which completes once the CCE resources are deployed.
+// API: POST /api/azure/manual
+func (s *IdsecCCEAzureService) AddSubscription(addSubscription *azuremodels.IdsecCCEAzureAddSubscription) (*ccemodels.IdsecCCEOnboardingOperation, error) {
+	subscriptionID, err := s.addSubscription(addSubscription, ccemodels.Programmatic)
+	if err != nil {
+		return nil, err
+	}
+	return s.newOnboardingOperation(subscriptionID, azuremodels.WorkspaceTypeSubscription, func() (string, error) {
+		subscription, err := s.subscription(&azuremodels.IdsecCCEAzureGetSubscription{ID: subscriptionID})
+		if err != nil {
+			return "", err
+		}
+		return subscription.Status, nil
+	}), nil
+}
+
+// UpdateSubscription updates the services of an onboarded Azure Subscription.
+// Services missing from the input are removed and new services are added.
+// API: POST/DELETE /api/azure/manual/{id}/services
+func (s *IdsecCCEAzureService) UpdateSubscription(updateSubscription *azuremodels.IdsecCCEAzureUpdateSubscription) (*azuremodels.IdsecCCEAzureSubscription, error) {
+	return s.updateSubscription(updateSubscription)
+}
+
+// DeleteSubscription deletes an onboarded Azure Subscription.
+// API: DELETE /api/azure/manual/{id}
+func (s *IdsecCCEAzureService) DeleteSubscription(deleteSubscription *azuremodels.IdsecCCEAzureDeleteSubscription) error {
+	return s.deleteSubscription(deleteSubscription)
+}
+
+// newOnboardingOperation creates an onboarding operation handle and populates its initial status.
+// A failure to retrieve the initial status is logged, as the resource was already added.
+func (s *IdsecCCEAzureService) newOnboardingOperation(id string, resourceType string, statusFunc ccemodels.IdsecCCEOnboardingStatusFunc) *ccemodels.IdsecCCEOnboardingOperation {
+	operation := ccemodels.NewIdsecCCEOnboardingOperation(id, resourceType, statusFunc)
+	if _, err := operation.Refresh(); err != nil {
+		s.Logger.Warning("Failed to get initial onboarding status of %s [%s]: %v", resourceType, id, err)
+	}
+	return operation
+}
+
+// listWorkspaces streams the Azure workspaces of the given type as pages.
+func (s *IdsecCCEAzureService) listWorkspaces(workspaceType string, filter *ccemodels.IdsecCCEWorkspacesFilter) <-chan *IdsecCCEAzureWorkspacesPage {
+	if filter == nil {
+		filter = &ccemodels.IdsecCCEWorkspacesFilter{}
+	}
+	results := make(chan *IdsecCCEAzureWorkspacesPage)
+	go func() {
+		defer close(results)
+		pageChannel, errorChannel := s.tfWorkspacesStream(&azuremodels.TfIdsecCCEAzureGetWorkspacesTerraform{
+			ParentID:        filter.ParentID,
+			Services:        filter.Services,
+			WorkspaceStatus: filter.WorkspaceStatus,
+			WorkspaceType:   workspaceType,
+		})
+		for page := range pageChannel {
+			items := make([]*ccemodels.IdsecCCEWorkspace, len(page.Workspaces))
+			for i := range page.Workspaces {
+				items[i] = &page.Workspaces[i]
+			}
+			results <- &IdsecCCEAzureWorkspacesPage{Items: items}
+		}
+		if err := <-errorChannel; err != nil {
+			results <- &IdsecCCEAzureWorkspacesPage{Err: err}
+		}
+	}()
+	return results
+}
+
 // TfEntra retrieves Azure Entra tenant details by onboarding ID.
 // ⚠️  DEPRECATED: This function is deprecated and should not be used.
 // ⚠️  It exists only for compatibility with Terraform provider.
 // API: GET /api/azure/manual/entra/{id}
 func (s *IdsecCCEAzureService) TfEntra(input *azuremodels.TfIdsecCCEAzureGetEntra) (*azuremodels.TfIdsecCCEAzureEntra, error) {
-	return s.tfEntra(input)
+	entra, err := s.entra((*azuremodels.IdsecCCEAzureGetEntra)(input))
+	if err != nil {
+		return nil, err
+	}
+	return (*azuremodels.TfIdsecCCEAzureEntra)(entra), nil
 }
 
 // TfAddEntra adds an Azure Entra tenant manually.
@@ -81,7 +276,18 @@ func (s *IdsecCCEAzureService) TfEntra(input *azuremodels.TfIdsecCCEAzureGetEntr
 // ⚠️  It exists only for compatibility with Terraform provider.
 // API: POST /api/azure/manual
 func (s *IdsecCCEAzureService) TfAddEntra(input *azuremodels.TfIdsecCCEAzureAddEntra) (*azuremodels.TfIdsecCCEAzureEntra, error) {
-	return s.tfAddEntra(input)
+	entraID, err := s.addEntra((*azuremodels.IdsecCCEAzureAddEntra)(input), ccemodels.TerraformProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Retrieve the full Entra tenant details with retry
+	s.Logger.Info("Retrieving Entra tenant details for ID [%s]", entraID)
+	entra, err := s.entraWithRetry(entraID)
+	if err != nil {
+		return nil, fmt.Errorf("entra tenant created with ID %s, but failed to fetch details: %w", entraID, err)
+	}
+	return (*azuremodels.TfIdsecCCEAzureEntra)(entra), nil
 }
 
 // TfUpdateEntra updates an Azure Entra tenant's services.
@@ -91,7 +297,11 @@ func (s *IdsecCCEAzureService) TfAddEntra(input *azuremodels.TfIdsecCCEAzureAddE
 // ⚠️  It exists only for compatibility with Terraform provider.
 // API: POST/DELETE /api/azure/manual/{id}/services
 func (s *IdsecCCEAzureService) TfUpdateEntra(input *azuremodels.TfIdsecCCEAzureUpdateEntra) (*azuremodels.TfIdsecCCEAzureEntra, error) {
-	return s.tfUpdateEntra(input)
+	entra, err := s.updateEntra((*azuremodels.IdsecCCEAzureUpdateEntra)(input))
+	if err != nil {
+		return nil, err
+	}
+	return (*azuremodels.TfIdsecCCEAzureEntra)(entra), nil
 }
 
 // TfDeleteEntra deletes an Azure Entra tenant.
@@ -99,7 +309,7 @@ func (s *IdsecCCEAzureService) TfUpdateEntra(input *azuremodels.TfIdsecCCEAzureU
 // ⚠️  It exists only for compatibility with Terraform provider.
 // API: DELETE /api/azure/manual/{id}
 func (s *IdsecCCEAzureService) TfDeleteEntra(input *azuremodels.TfIdsecCCEAzureDeleteEntra) error {
-	return s.tfDeleteEntra(input)
+	return s.deleteEntra((*azuremodels.IdsecCCEAzureDeleteEntra)(input))
 }
 
 // TfManagementGroup retrieves Azure Management Group details by onboarding ID.
@@ -107,7 +317,11 @@ func (s *IdsecCCEAzureService) TfDeleteEntra(input *azuremodels.TfIdsecCCEAzureD
 // ⚠️  It exists only for compatibility with Terraform provider.
 // API: GET /api/azure/manual/mgmtgroup/{id}
 func (s *IdsecCCEAzureService) TfManagementGroup(input *azuremodels.TfIdsecCCEAzureGetManagementGroup) (*azuremodels.TfIdsecCCEAzureManagementGroup, error) {
-	return s.tfManagementGroup(input)
+	managementGroup, err := s.managementGroup((*azuremodels.IdsecCCEAzureGetManagementGroup)(input))
+	if err != nil {
+		return nil, err
+	}
+	return (*azuremodels.TfIdsecCCEAzureManagementGroup)(managementGroup), nil
 }
 
 // TfAddManagementGroup adds an Azure Management Group manually.
@@ -116,7 +330,18 @@ func (s *IdsecCCEAzureService) TfManagementGroup(input *azuremodels.TfIdsecCCEAz
 // ⚠️  It exists only for compatibility with Terraform provider.
 // API: POST /api/azure/manual
 func (s *IdsecCCEAzureService) TfAddManagementGroup(input *azuremodels.TfIdsecCCEAzureAddManagementGroup) (*azuremodels.TfIdsecCCEAzureManagementGroup, error) {
-	return s.tfAddManagementGroup(input)
+	managementGroupID, err := s.addManagementGroup((*azuremodels.IdsecCCEAzureAddManagementGroup)(input), ccemodels.TerraformProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Retrieve the full Management Group details with retry
+	s.Logger.Info("Retrieving Management Group details for ID [%s]", managementGroupID)
+	managementGroup, err := s.managementGroupWithRetry(managementGroupID)
+	if err != nil {
+		return nil, fmt.Errorf("management group created with ID %s, but failed to fetch details: %w", managementGroupID, err)
+	}
+	return (*azuremodels.TfIdsecCCEAzureManagementGroup)(managementGroup), nil
 }
 
 // TfUpdateManagementGroup updates an Azure Management Group's services.
@@ -126,7 +351,11 @@ func (s *IdsecCCEAzureService) TfAddManagementGroup(input *azuremodels.TfIdsecCC
 // ⚠️  It exists only for compatibility with Terraform provider.
 // API: POST/DELETE /api/azure/manual/{id}/services
 func (s *IdsecCCEAzureService) TfUpdateManagementGroup(input *azuremodels.TfIdsecCCEAzureUpdateManagementGroup) (*azuremodels.TfIdsecCCEAzureManagementGroup, error) {
-	return s.tfUpdateManagementGroup(input)
+	managementGroup, err := s.updateManagementGroup((*azuremodels.IdsecCCEAzureUpdateManagementGroup)(input))
+	if err != nil {
+		return nil, err
+	}
+	return (*azuremodels.TfIdsecCCEAzureManagementGroup)(managementGroup), nil
 }
 
 // TfDeleteManagementGroup deletes an Azure Management Group.
@@ -134,7 +363,7 @@ func (s *IdsecCCEAzureService) TfUpdateManagementGroup(input *azuremodels.TfIdse
 // ⚠️  It exists only for compatibility with Terraform provider.
 // API: DELETE /api/azure/manual/{id}
 func (s *IdsecCCEAzureService) TfDeleteManagementGroup(input *azuremodels.TfIdsecCCEAzureDeleteManagementGroup) error {
-	return s.tfDeleteManagementGroup(input)
+	return s.deleteManagementGroup((*azuremodels.IdsecCCEAzureDeleteManagementGroup)(input))
 }
 
 // TfSubscription retrieves Azure Subscription details by onboarding ID.
@@ -142,7 +371,11 @@ func (s *IdsecCCEAzureService) TfDeleteManagementGroup(input *azuremodels.TfIdse
 // ⚠️  It exists only for compatibility with Terraform provider.
 // API: GET /api/azure/manual/subscription/{id}
 func (s *IdsecCCEAzureService) TfSubscription(input *azuremodels.TfIdsecCCEAzureGetSubscription) (*azuremodels.TfIdsecCCEAzureSubscription, error) {
-	return s.tfSubscription(input)
+	subscription, err := s.subscription((*azuremodels.IdsecCCEAzureGetSubscription)(input))
+	if err != nil {
+		return nil, err
+	}
+	return (*azuremodels.TfIdsecCCEAzureSubscription)(subscription), nil
 }
 
 // TfAddSubscription adds an Azure Subscription manually.
@@ -151,7 +384,18 @@ func (s *IdsecCCEAzureService) TfSubscription(input *azuremodels.TfIdsecCCEAzure
 // ⚠️  It exists only for compatibility with Terraform provider.
 // API: POST /api/azure/manual
 func (s *IdsecCCEAzureService) TfAddSubscription(input *azuremodels.TfIdsecCCEAzureAddSubscription) (*azuremodels.TfIdsecCCEAzureSubscription, error) {
-	return s.tfAddSubscription(input)
+	subscriptionID, err := s.addSubscription((*azuremodels.IdsecCCEAzureAddSubscription)(input), ccemodels.TerraformProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	// Retrieve the full Subscription details with retry
+	s.Logger.Info("Retrieving Subscription details for ID [%s]", subscriptionID)
+	subscription, err := s.subscriptionWithRetry(subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("subscription created with ID %s, but failed to fetch details: %w", subscriptionID, err)
+	}
+	return (*azuremodels.TfIdsecCCEAzureSubscription)(subscription), nil
 }
 
 // TfUpdateSubscription updates an Azure Subscription's services.
@@ -161,7 +405,11 @@ func (s *IdsecCCEAzureService) TfAddSubscription(input *azuremodels.TfIdsecCCEAz
 // ⚠️  It exists only for compatibility with Terraform provider.
 // API: POST/DELETE /api/azure/manual/{id}/services
 func (s *IdsecCCEAzureService) TfUpdateSubscription(input *azuremodels.TfIdsecCCEAzureUpdateSubscription) (*azuremodels.TfIdsecCCEAzureSubscription, error) {
-	return s.tfUpdateSubscription(input)
+	subscription, err := s.updateSubscription((*azuremodels.IdsecCCEAzureUpdateSubscription)(input))
+	if err != nil {
+		return nil, err
+	}
+	return (*azuremodels.TfIdsecCCEAzureSubscription)(subscription), nil
 }
 
 // TfDeleteSubscription deletes an Azure Subscription.
@@ -169,7 +417,7 @@ func (s *IdsecCCEAzureService) TfUpdateSubscription(input *azuremodels.TfIdsecCC
 // ⚠️  It exists only for compatibility with Terraform provider.
 // API: DELETE /api/azure/manual/{id}
 func (s *IdsecCCEAzureService) TfDeleteSubscription(input *azuremodels.TfIdsecCCEAzureDeleteSubscription) error {
-	return s.tfDeleteSubscription(input)
+	return s.deleteSubscription((*azuremodels.IdsecCCEAzureDeleteSubscription)(input))
 }
 
 // tfInternalWorkspaces retrieves Azure workspaces with pagination support.
@@ -1,6 +1,7 @@
 package azure
 
 import (
+	"encoding/json"
 	"net/http"
 	"testing"
 
@@ -231,3 +232,75 @@ func TestSubscription_ErrorPropagation(t *testing.T) {
 		return err
 	})
 }
+
+func TestAddSubscription_ReturnsOperation(t *testing.T) {
+	var capturedBody map[string]interface{}
+	client, cleanup := internal.SetupMockCCEService(t, []internal.MockEndpointConfig{
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "POST" && r.URL.Path == "/api/azure/manual"
+			},
+			StatusCode:   http.StatusCreated,
+			ResponseBody: `{"id": "subscription-123"}`,
+			OnRequest: func(r *http.Request) {
+				_ = json.NewDecoder(r.Body).Decode(&capturedBody)
+			},
+		},
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "GET" && r.URL.Path == "/api/azure/manual/subscription/subscription-123"
+			},
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{"id": "subscription-123", "onboardingType": "programmatic", "status": "Waiting for consent"}`,
+		},
+	})
+	defer cleanup()
+
+	service := setupAzureService(client)
+	operation, err := service.AddSubscription(&azuremodels.IdsecCCEAzureAddSubscription{
+		EntraID:          "12345678-1234-1234-1234-123456789012",
+		EntraTenantName:  "TestTenant",
+		SubscriptionID:   "sub-12345678-1234-1234-1234-123456789012",
+		SubscriptionName: "Test Subscription",
+		Services:         []ccemodels.IdsecCCEServiceInput{{ServiceName: ccemodels.DPA}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, ccemodels.Programmatic, capturedBody["onboardingType"])
+	require.Equal(t, "standalone", capturedBody["deploymentType"])
+	require.Equal(t, "subscription-123", operation.ID)
+	require.Equal(t, azuremodels.WorkspaceTypeSubscription, operation.ResourceType)
+	require.Equal(t, ccemodels.WaitingForConsent, operation.Status)
+	require.False(t, operation.Done())
+}
+
+func TestUpdateSubscription_ReconcilesServices(t *testing.T) {
+	var deletedServices []string
+	client, cleanup := internal.SetupMockCCEService(t, []internal.MockEndpointConfig{
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "GET" && r.URL.Path == "/api/azure/manual/subscription/subscription-123"
+			},
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{"id": "subscription-123", "services": ["dpa", "sca"], "status": "Completely added"}`,
+		},
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "DELETE" && r.URL.Path == "/api/azure/manual/subscription-123/services"
+			},
+			StatusCode: http.StatusOK,
+			OnRequest: func(r *http.Request) {
+				deletedServices = r.URL.Query()["services_names"]
+			},
+		},
+	})
+	defer cleanup()
+
+	service := setupAzureService(client)
+	subscription, err := service.UpdateSubscription(&azuremodels.IdsecCCEAzureUpdateSubscription{
+		ID:       "subscription-123",
+		Services: []ccemodels.IdsecCCEServiceInput{{ServiceName: ccemodels.DPA}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "subscription-123", subscription.ID)
+	require.Equal(t, []string{"sca"}, deletedServices)
+}
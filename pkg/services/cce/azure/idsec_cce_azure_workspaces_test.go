@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
 	azuremodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/azure/models"
+	ccemodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/common/models"
 	"github.com/cyberark/idsec-sdk-golang/pkg/services/cce/internal"
 )
 
@@ -275,3 +276,38 @@ func TestTfWorkspaces_ErrorPropagation(t *testing.T) {
 		return err
 	})
 }
+
+func TestListEntrasBy_FiltersByType(t *testing.T) {
+	var capturedQuery string
+	client, cleanup := internal.SetupMockCCEService(t, []internal.MockEndpointConfig{
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "GET" && r.URL.Path == "/api/azure/workspaces"
+			},
+			StatusCode: http.StatusOK,
+			ResponseBody: `{
+				"workspaces": [{"key": "entra-123", "data": {"id": "entra-123", "type": "azure_entra"}}],
+				"page": {"page_number": 1, "page_size": 100, "is_last_page": true, "total_records": 1}
+			}`,
+			OnRequest: func(r *http.Request) {
+				capturedQuery = r.URL.RawQuery
+			},
+		},
+	})
+	defer cleanup()
+
+	service := setupAzureService(client)
+	pages, err := service.ListEntrasBy(&ccemodels.IdsecCCEWorkspacesFilter{WorkspaceStatus: "Completely added"})
+	require.NoError(t, err)
+
+	var keys []string
+	for page := range pages {
+		require.NoError(t, page.Err)
+		for _, workspace := range page.Items {
+			keys = append(keys, workspace.Key)
+		}
+	}
+	require.Equal(t, []string{"entra-123"}, keys)
+	require.Contains(t, capturedQuery, "workspace_type=azure_entra")
+	require.Contains(t, capturedQuery, "workspace_status=Completely+added")
+}
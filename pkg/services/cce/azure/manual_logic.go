@@ -65,50 +65,25 @@ func structToMap(v interface{}) (map[string]interface{}, error) {
 	return result, nil
 }
 
-// tfAddEntra adds an Azure Entra tenant manually.
-// After creation, it retrieves the full Entra tenant details with retry logic (3 attempts, 1 second delay).
+// addEntra adds an Azure Entra tenant manually and returns the onboarding ID of the added Entra tenant.
 // API: POST /api/azure/manual
-func (s *IdsecCCEAzureService) tfAddEntra(input *azuremodels.TfIdsecCCEAzureAddEntra) (*azuremodels.TfIdsecCCEAzureEntra, error) {
+func (s *IdsecCCEAzureService) addEntra(input *azuremodels.IdsecCCEAzureAddEntra, onboardingType string) (string, error) {
 	s.Logger.Info("Adding Azure Entra tenant with Entra ID [%s]", input.EntraID)
 
 	// Convert input to map and add hardcoded deploymentType
 	requestBody, err := structToMap(input)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	requestBody[requestKeyDeploymentType] = deploymentTypeOrganization
-	requestBody[requestKeyOnboardingType] = ccemodels.TerraformProvider
-
-	response, err := s.ISPClient().Post(context.Background(), pathManualAddURL, requestBody)
-	if err != nil {
-		return nil, err
-	}
-	defer cceinternal.CloseResponseBody(response.Body)
-
-	// Handle non-2xx status codes
-	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
-		return nil, cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, "failed to add Entra tenant")
-	}
-
-	var addOutput azuremodels.IdsecCCEAzureAddOutput
-	err = json.NewDecoder(response.Body).Decode(&addOutput)
-	if err != nil {
-		return nil, err
-	}
-
-	// Retrieve the full Entra tenant details with retry
-	s.Logger.Info("Retrieving Entra tenant details for ID [%s]", addOutput.ID)
-	entra, err := s.tfEntraWithRetry(addOutput.ID)
-	if err != nil {
-		return nil, fmt.Errorf("entra tenant created with ID %s, but failed to fetch details: %w", addOutput.ID, err)
-	}
+	requestBody[requestKeyOnboardingType] = onboardingType
 
-	return entra, nil
+	return s.addManual(requestBody, "failed to add Entra tenant")
 }
 
-// tfEntra retrieves Azure Entra tenant details by onboarding ID.
+// entra retrieves Azure Entra tenant details by onboarding ID.
 // API: GET /api/azure/manual/entra/{id}
-func (s *IdsecCCEAzureService) tfEntra(input *azuremodels.TfIdsecCCEAzureGetEntra) (*azuremodels.TfIdsecCCEAzureEntra, error) {
+func (s *IdsecCCEAzureService) entra(input *azuremodels.IdsecCCEAzureGetEntra) (*azuremodels.IdsecCCEAzureEntra, error) {
 	s.Logger.Info("Getting Azure Entra tenant details for ID [%s]", input.ID)
 
 	url := fmt.Sprintf(pathManualEntraGetURL, input.ID)
@@ -123,7 +98,7 @@ func (s *IdsecCCEAzureService) tfEntra(input *azuremodels.TfIdsecCCEAzureGetEntr
 		return nil, cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, "failed to get Entra tenant details")
 	}
 
-	var entra azuremodels.TfIdsecCCEAzureEntra
+	var entra azuremodels.IdsecCCEAzureEntra
 	err = json.NewDecoder(response.Body).Decode(&entra)
 	if err != nil {
 		return nil, err
@@ -132,12 +107,12 @@ func (s *IdsecCCEAzureService) tfEntra(input *azuremodels.TfIdsecCCEAzureGetEntr
 	return &entra, nil
 }
 
-// tfEntraWithRetry retrieves an Entra tenant with retry logic.
+// entraWithRetry retrieves an Entra tenant with retry logic.
 // It attempts to fetch the Entra tenant up to 3 times with 1 second delay between attempts.
-func (s *IdsecCCEAzureService) tfEntraWithRetry(entraID string) (*azuremodels.TfIdsecCCEAzureEntra, error) {
-	var entra *azuremodels.TfIdsecCCEAzureEntra
+func (s *IdsecCCEAzureService) entraWithRetry(entraID string) (*azuremodels.IdsecCCEAzureEntra, error) {
+	var entra *azuremodels.IdsecCCEAzureEntra
 	err := common.RetryCall(func() error {
-		ent, getErr := s.tfEntra(&azuremodels.TfIdsecCCEAzureGetEntra{ID: entraID})
+		ent, getErr := s.entra(&azuremodels.IdsecCCEAzureGetEntra{ID: entraID})
 		if getErr != nil {
 			return getErr
 		}
@@ -154,11 +129,11 @@ func (s *IdsecCCEAzureService) tfEntraWithRetry(entraID string) (*azuremodels.Tf
 	return entra, nil
 }
 
-// tfUpdateEntra updates an Azure Entra tenant's services.
+// updateEntra updates an Azure Entra tenant's services.
 // Compares the desired services in the input with the current services on the Entra tenant,
 // then adds new services and removes services that are no longer desired.
 // API: POST/DELETE /api/azure/manual/{id}/services
-func (s *IdsecCCEAzureService) tfUpdateEntra(input *azuremodels.TfIdsecCCEAzureUpdateEntra) (*azuremodels.TfIdsecCCEAzureEntra, error) {
+func (s *IdsecCCEAzureService) updateEntra(input *azuremodels.IdsecCCEAzureUpdateEntra) (*azuremodels.IdsecCCEAzureEntra, error) {
 	s.Logger.Info("Updating Azure Entra tenant [%s]", input.ID)
 
 	// Step 1: Get current Entra tenant details to determine existing services
@@ -189,7 +164,7 @@ func (s *IdsecCCEAzureService) tfUpdateEntra(input *azuremodels.TfIdsecCCEAzureU
 
 	// Step 3: Fetch and return updated Entra tenant details
 	s.Logger.Info("Fetching full details for Entra tenant [%s]", input.ID)
-	fullEntra, err := s.tfEntraWithRetry(input.ID)
+	fullEntra, err := s.entraWithRetry(input.ID)
 	if err != nil {
 		return nil, fmt.Errorf("entra tenant updated with ID %s, but failed to fetch details: %w", input.ID, err)
 	}
@@ -197,57 +172,32 @@ func (s *IdsecCCEAzureService) tfUpdateEntra(input *azuremodels.TfIdsecCCEAzureU
 	return fullEntra, nil
 }
 
-// tfDeleteEntra deletes an Azure Entra tenant.
+// deleteEntra deletes an Azure Entra tenant.
 // API: DELETE /api/azure/manual/{id}
-func (s *IdsecCCEAzureService) tfDeleteEntra(input *azuremodels.TfIdsecCCEAzureDeleteEntra) error {
+func (s *IdsecCCEAzureService) deleteEntra(input *azuremodels.IdsecCCEAzureDeleteEntra) error {
 	s.Logger.Info("Deleting Azure Entra tenant [%s]", input.ID)
 	return s.deleteManual(input.ID)
 }
 
-// tfAddManagementGroup adds an Azure Management Group manually.
-// After creation, it retrieves the full Management Group details with retry logic (3 attempts, 1 second delay).
+// addManagementGroup adds an Azure Management Group manually and returns the onboarding ID of the added Management Group.
 // API: POST /api/azure/manual
-func (s *IdsecCCEAzureService) tfAddManagementGroup(input *azuremodels.TfIdsecCCEAzureAddManagementGroup) (*azuremodels.TfIdsecCCEAzureManagementGroup, error) {
+func (s *IdsecCCEAzureService) addManagementGroup(input *azuremodels.IdsecCCEAzureAddManagementGroup, onboardingType string) (string, error) {
 	s.Logger.Info("Adding Azure Management Group with ID [%s]", input.ManagementGroupID)
 
 	// Convert input to map and add hardcoded deploymentType
 	requestBody, err := structToMap(input)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	requestBody[requestKeyDeploymentType] = deploymentTypeFolder
-	requestBody[requestKeyOnboardingType] = ccemodels.TerraformProvider
-
-	response, err := s.ISPClient().Post(context.Background(), pathManualAddURL, requestBody)
-	if err != nil {
-		return nil, err
-	}
-	defer cceinternal.CloseResponseBody(response.Body)
-
-	// Handle non-2xx status codes
-	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
-		return nil, cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, "failed to add Management Group")
-	}
-
-	var addOutput azuremodels.IdsecCCEAzureAddOutput
-	err = json.NewDecoder(response.Body).Decode(&addOutput)
-	if err != nil {
-		return nil, err
-	}
-
-	// Retrieve the full Management Group details with retry
-	s.Logger.Info("Retrieving Management Group details for ID [%s]", addOutput.ID)
-	mgmtGroup, err := s.tfManagementGroupWithRetry(addOutput.ID)
-	if err != nil {
-		return nil, fmt.Errorf("management group created with ID %s, but failed to fetch details: %w", addOutput.ID, err)
-	}
+	requestBody[requestKeyOnboardingType] = onboardingType
 
-	return mgmtGroup, nil
+	return s.addManual(requestBody, "failed to add Management Group")
 }
 
-// tfManagementGroup retrieves Azure Management Group details by onboarding ID.
+// managementGroup retrieves Azure Management Group details by onboarding ID.
 // API: GET /api/azure/manual/mgmtgroup/{id}
-func (s *IdsecCCEAzureService) tfManagementGroup(input *azuremodels.TfIdsecCCEAzureGetManagementGroup) (*azuremodels.TfIdsecCCEAzureManagementGroup, error) {
+func (s *IdsecCCEAzureService) managementGroup(input *azuremodels.IdsecCCEAzureGetManagementGroup) (*azuremodels.IdsecCCEAzureManagementGroup, error) {
 	s.Logger.Info("Getting Azure Management Group details for ID [%s]", input.ID)
 
 	url := fmt.Sprintf(pathManualMgmtGroupGetURL, input.ID)
@@ -262,7 +212,7 @@ func (s *IdsecCCEAzureService) tfManagementGroup(input *azuremodels.TfIdsecCCEAz
 		return nil, cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, "failed to get Management Group details")
 	}
 
-	var mgmtGroup azuremodels.TfIdsecCCEAzureManagementGroup
+	var mgmtGroup azuremodels.IdsecCCEAzureManagementGroup
 	err = json.NewDecoder(response.Body).Decode(&mgmtGroup)
 	if err != nil {
 		return nil, err
@@ -271,12 +221,12 @@ func (s *IdsecCCEAzureService) tfManagementGroup(input *azuremodels.TfIdsecCCEAz
 	return &mgmtGroup, nil
 }
 
-// tfManagementGroupWithRetry retrieves a Management Group with retry logic.
+// managementGroupWithRetry retrieves a Management Group with retry logic.
 // It attempts to fetch the Management Group up to 3 times with 1 second delay between attempts.
-func (s *IdsecCCEAzureService) tfManagementGroupWithRetry(mgmtGroupID string) (*azuremodels.TfIdsecCCEAzureManagementGroup, error) {
-	var mgmtGroup *azuremodels.TfIdsecCCEAzureManagementGroup
+func (s *IdsecCCEAzureService) managementGroupWithRetry(mgmtGroupID string) (*azuremodels.IdsecCCEAzureManagementGroup, error) {
+	var mgmtGroup *azuremodels.IdsecCCEAzureManagementGroup
 	err := common.RetryCall(func() error {
-		mg, getErr := s.tfManagementGroup(&azuremodels.TfIdsecCCEAzureGetManagementGroup{ID: mgmtGroupID})
+		mg, getErr := s.managementGroup(&azuremodels.IdsecCCEAzureGetManagementGroup{ID: mgmtGroupID})
 		if getErr != nil {
 			return getErr
 		}
@@ -293,11 +243,11 @@ func (s *IdsecCCEAzureService) tfManagementGroupWithRetry(mgmtGroupID string) (*
 	return mgmtGroup, nil
 }
 
-// tfUpdateManagementGroup updates an Azure Management Group's services.
+// updateManagementGroup updates an Azure Management Group's services.
 // Compares the desired services in the input with the current services on the Management Group,
 // then adds new services and removes services that are no longer desired.
 // API: POST/DELETE /api/azure/manual/{id}/services
-func (s *IdsecCCEAzureService) tfUpdateManagementGroup(input *azuremodels.TfIdsecCCEAzureUpdateManagementGroup) (*azuremodels.TfIdsecCCEAzureManagementGroup, error) {
+func (s *IdsecCCEAzureService) updateManagementGroup(input *azuremodels.IdsecCCEAzureUpdateManagementGroup) (*azuremodels.IdsecCCEAzureManagementGroup, error) {
 	s.Logger.Info("Updating Azure Management Group [%s]", input.ID)
 
 	// Step 1: Get current Management Group details to determine existing services
@@ -328,7 +278,7 @@ func (s *IdsecCCEAzureService) tfUpdateManagementGroup(input *azuremodels.TfIdse
 
 	// Step 3: Fetch and return updated Management Group details
 	s.Logger.Info("Fetching full details for Management Group [%s]", input.ID)
-	fullMgmtGroup, err := s.tfManagementGroupWithRetry(input.ID)
+	fullMgmtGroup, err := s.managementGroupWithRetry(input.ID)
 	if err != nil {
 		return nil, fmt.Errorf("management group updated with ID %s, but failed to fetch details: %w", input.ID, err)
 	}
@@ -336,57 +286,32 @@ func (s *IdsecCCEAzureService) tfUpdateManagementGroup(input *azuremodels.TfIdse
 	return fullMgmtGroup, nil
 }
 
-// tfDeleteManagementGroup deletes an Azure Management Group.
+// deleteManagementGroup deletes an Azure Management Group.
 // API: DELETE /api/azure/manual/{id}
-func (s *IdsecCCEAzureService) tfDeleteManagementGroup(input *azuremodels.TfIdsecCCEAzureDeleteManagementGroup) error {
+func (s *IdsecCCEAzureService) deleteManagementGroup(input *azuremodels.IdsecCCEAzureDeleteManagementGroup) error {
 	s.Logger.Info("Deleting Azure Management Group [%s]", input.ID)
 	return s.deleteManual(input.ID)
 }
 
-// tfAddSubscription adds an Azure Subscription manually.
-// After creation, it retrieves the full Subscription details with retry logic (3 attempts, 1 second delay).
+// addSubscription adds an Azure Subscription manually and returns the onboarding ID of the added Subscription.
 // API: POST /api/azure/manual
-func (s *IdsecCCEAzureService) tfAddSubscription(input *azuremodels.TfIdsecCCEAzureAddSubscription) (*azuremodels.TfIdsecCCEAzureSubscription, error) {
+func (s *IdsecCCEAzureService) addSubscription(input *azuremodels.IdsecCCEAzureAddSubscription, onboardingType string) (string, error) {
 	s.Logger.Info("Adding Azure Subscription with ID [%s]", input.SubscriptionID)
 
 	// Convert input to map and add hardcoded deploymentType
 	requestBody, err := structToMap(input)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	requestBody[requestKeyDeploymentType] = deploymentTypeStandalone
-	requestBody[requestKeyOnboardingType] = ccemodels.TerraformProvider
-
-	response, err := s.ISPClient().Post(context.Background(), pathManualAddURL, requestBody)
-	if err != nil {
-		return nil, err
-	}
-	defer cceinternal.CloseResponseBody(response.Body)
-
-	// Handle non-2xx status codes
-	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
-		return nil, cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, "failed to add Subscription")
-	}
-
-	var addOutput azuremodels.IdsecCCEAzureAddOutput
-	err = json.NewDecoder(response.Body).Decode(&addOutput)
-	if err != nil {
-		return nil, err
-	}
-
-	// Retrieve the full Subscription details with retry
-	s.Logger.Info("Retrieving Subscription details for ID [%s]", addOutput.ID)
-	subscription, err := s.tfSubscriptionWithRetry(addOutput.ID)
-	if err != nil {
-		return nil, fmt.Errorf("subscription created with ID %s, but failed to fetch details: %w", addOutput.ID, err)
-	}
+	requestBody[requestKeyOnboardingType] = onboardingType
 
-	return subscription, nil
+	return s.addManual(requestBody, "failed to add Subscription")
 }
 
-// tfSubscription retrieves Azure Subscription details by onboarding ID.
+// subscription retrieves Azure Subscription details by onboarding ID.
 // API: GET /api/azure/manual/subscription/{id}
-func (s *IdsecCCEAzureService) tfSubscription(input *azuremodels.TfIdsecCCEAzureGetSubscription) (*azuremodels.TfIdsecCCEAzureSubscription, error) {
+func (s *IdsecCCEAzureService) subscription(input *azuremodels.IdsecCCEAzureGetSubscription) (*azuremodels.IdsecCCEAzureSubscription, error) {
 	s.Logger.Info("Getting Azure Subscription details for ID [%s]", input.ID)
 
 	url := fmt.Sprintf(pathManualSubscriptionGetURL, input.ID)
@@ -401,7 +326,7 @@ func (s *IdsecCCEAzureService) tfSubscription(input *azuremodels.TfIdsecCCEAzure
 		return nil, cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, "failed to get Subscription details")
 	}
 
-	var subscription azuremodels.TfIdsecCCEAzureSubscription
+	var subscription azuremodels.IdsecCCEAzureSubscription
 	err = json.NewDecoder(response.Body).Decode(&subscription)
 	if err != nil {
 		return nil, err
@@ -410,12 +335,12 @@ func (s *IdsecCCEAzureService) tfSubscription(input *azuremodels.TfIdsecCCEAzure
 	return &subscription, nil
 }
 
-// tfSubscriptionWithRetry retrieves a Subscription with retry logic.
+// subscriptionWithRetry retrieves a Subscription with retry logic.
 // It attempts to fetch the Subscription up to 3 times with 1 second delay between attempts.
-func (s *IdsecCCEAzureService) tfSubscriptionWithRetry(subscriptionID string) (*azuremodels.TfIdsecCCEAzureSubscription, error) {
-	var subscription *azuremodels.TfIdsecCCEAzureSubscription
+func (s *IdsecCCEAzureService) subscriptionWithRetry(subscriptionID string) (*azuremodels.IdsecCCEAzureSubscription, error) {
+	var subscription *azuremodels.IdsecCCEAzureSubscription
 	err := common.RetryCall(func() error {
-		sub, getErr := s.tfSubscription(&azuremodels.TfIdsecCCEAzureGetSubscription{ID: subscriptionID})
+		sub, getErr := s.subscription(&azuremodels.IdsecCCEAzureGetSubscription{ID: subscriptionID})
 		if getErr != nil {
 			return getErr
 		}
@@ -432,11 +357,11 @@ func (s *IdsecCCEAzureService) tfSubscriptionWithRetry(subscriptionID string) (*
 	return subscription, nil
 }
 
-// tfUpdateSubscription updates an Azure Subscription's services.
+// updateSubscription updates an Azure Subscription's services.
 // Compares the desired services in the input with the current services on the Subscription,
 // then adds new services and removes services that are no longer desired.
 // API: POST/DELETE /api/azure/manual/{id}/services
-func (s *IdsecCCEAzureService) tfUpdateSubscription(input *azuremodels.TfIdsecCCEAzureUpdateSubscription) (*azuremodels.TfIdsecCCEAzureSubscription, error) {
+func (s *IdsecCCEAzureService) updateSubscription(input *azuremodels.IdsecCCEAzureUpdateSubscription) (*azuremodels.IdsecCCEAzureSubscription, error) {
 	s.Logger.Info("Updating Azure Subscription [%s]", input.ID)
 
 	// Step 1: Get current Subscription details to determine existing services
@@ -467,7 +392,7 @@ func (s *IdsecCCEAzureService) tfUpdateSubscription(input *azuremodels.TfIdsecCC
 
 	// Step 3: Fetch and return updated Subscription details
 	s.Logger.Info("Fetching full details for Subscription [%s]", input.ID)
-	fullSubscription, err := s.tfSubscriptionWithRetry(input.ID)
+	fullSubscription, err := s.subscriptionWithRetry(input.ID)
 	if err != nil {
 		return nil, fmt.Errorf("subscription updated with ID %s, but failed to fetch details: %w", input.ID, err)
 	}
@@ -475,9 +400,9 @@ func (s *IdsecCCEAzureService) tfUpdateSubscription(input *azuremodels.TfIdsecCC
 	return fullSubscription, nil
 }
 
-// tfDeleteSubscription deletes an Azure Subscription.
+// deleteSubscription deletes an Azure Subscription.
 // API: DELETE /api/azure/manual/{id}
-func (s *IdsecCCEAzureService) tfDeleteSubscription(input *azuremodels.TfIdsecCCEAzureDeleteSubscription) error {
+func (s *IdsecCCEAzureService) deleteSubscription(input *azuremodels.IdsecCCEAzureDeleteSubscription) error {
 	s.Logger.Info("Deleting Azure Subscription [%s]", input.ID)
 	return s.deleteManual(input.ID)
 }
@@ -603,6 +528,29 @@ func (s *IdsecCCEAzureService) deleteManualServices(id string, serviceNames []st
 	return nil
 }
 
+// addManual adds an Azure manual onboarding and returns its onboarding ID.
+// API: POST /api/azure/manual
+func (s *IdsecCCEAzureService) addManual(requestBody map[string]interface{}, errorContext string) (string, error) {
+	response, err := s.ISPClient().Post(context.Background(), pathManualAddURL, requestBody)
+	if err != nil {
+		return "", err
+	}
+	defer cceinternal.CloseResponseBody(response.Body)
+
+	// Handle non-2xx status codes
+	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
+		return "", cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, errorContext)
+	}
+
+	var addOutput azuremodels.IdsecCCEAzureAddOutput
+	err = json.NewDecoder(response.Body).Decode(&addOutput)
+	if err != nil {
+		return "", err
+	}
+
+	return addOutput.ID, nil
+}
+
 // deleteManual deletes an Azure manual onboarding.
 // API: DELETE /api/azure/manual/{id}
 func (s *IdsecCCEAzureService) deleteManual(id string) error {
@@ -52,3 +52,47 @@ type TfIdsecCCEAzureDeleteEntra struct {
 	// ID is the Entra tenant's onboarding ID.
 	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE Microsoft Entra tenant onboarding ID."`
 }
+
+// IdsecCCEAzureAddEntra is the input for onboarding an Azure Entra tenant manually.
+// OPENAPI-CORRELATION: AzureProgrammaticGeneralOnboardInput
+type IdsecCCEAzureAddEntra struct {
+	EntraID      string                           `json:"entraId" mapstructure:"entra_id" validate:"required,uuid" desc:"Microsoft Entra tenant ID (UUID format)."`
+	Services     []ccemodels.IdsecCCEServiceInput `json:"services" mapstructure:"services" validate:"required,min=1,dive" desc:"List of services to add (SIA, SCA, SecretsHub, CDS) and their associated resources."`
+	CCEResources map[string]interface{}           `json:"cceResources" mapstructure:"cce_resources" validate:"required" desc:"CCE resources."`
+}
+
+// IdsecCCEAzureEntra represents the details of an Azure Entra tenant.
+// OPENAPI-CORRELATION: AzureGetEntraDetailsOutput
+type IdsecCCEAzureEntra struct {
+	ID             string                            `json:"id" mapstructure:"id" desc:"CCE Microsoft Entra tenant onboarding ID"`
+	OnboardingType string                            `json:"onboardingType" mapstructure:"onboarding_type" desc:"Onboarding type: standard (UI), programmatic (API), or terraform_provider." possible_values:"standard,programmatic,terraform_provider."`
+	Region         string                            `json:"region" mapstructure:"region" desc:"The region where CCE resources are deployed."`
+	DisplayName    string                            `json:"displayName,omitempty" mapstructure:"display_name,omitempty" desc:"Display name shown in the CCE UI."`
+	Parameters     map[string]map[string]interface{} `json:"parameters,omitempty" mapstructure:"parameters,omitempty" desc:"A key-value map of service-specific configuration parameters, keyed by service name."`
+	Status         string                            `json:"status" mapstructure:"status" desc:"Onboarding status (For example, Completely added, Partially added, Failed to add)."`
+	ConsentData    []map[string]interface{}          `json:"consentData,omitempty" mapstructure:"consent_data,omitempty" desc:"Consent data for service applications."`
+	EntraID        string                            `json:"entraId" mapstructure:"entra_id" desc:"Microsoft Entra tenant ID."`
+}
+
+// IdsecCCEAzureGetEntra is the input for getting Azure Entra tenant details.
+// OPENAPI-CORRELATION: Input for GET /api/azure/manual/entra/{id}
+type IdsecCCEAzureGetEntra struct {
+	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE Microsoft Entra tenant onboarding ID."`
+}
+
+// IdsecCCEAzureUpdateEntra is the input for updating an Azure Entra tenant's services.
+// Services missing from the input are removed from the onboarded resource.
+// OPENAPI-CORRELATION: Custom input combining multiple endpoints
+type IdsecCCEAzureUpdateEntra struct {
+	// ID is the Entra tenant's onboarding ID.
+	ID string `json:"id,omitempty" mapstructure:"id,omitempty" validate:"required" desc:"CCE Microsoft Entra tenant onboarding ID."`
+	// Services is the list of services to onboard (e.g., DPA, SCA, SecretsHub, CDS) with their resource configurations.
+	Services []ccemodels.IdsecCCEServiceInput `json:"services" mapstructure:"services" validate:"required,min=1,dive" desc:"List of services to add to the Microsoft Entra tenant (SIA, SCA, SecretsHub, CDS) and their associated resources."`
+}
+
+// IdsecCCEAzureDeleteEntra is the input for deleting an Azure Entra tenant.
+// OPENAPI-CORRELATION: Input for DELETE /api/azure/manual/{id}
+type IdsecCCEAzureDeleteEntra struct {
+	// ID is the Entra tenant's onboarding ID.
+	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE Microsoft Entra tenant onboarding ID."`
+}
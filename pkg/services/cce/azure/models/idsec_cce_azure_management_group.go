@@ -54,3 +54,49 @@ type TfIdsecCCEAzureDeleteManagementGroup struct {
 	// ID is the Management Group's onboarding ID.
 	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE management group onboarding ID."`
 }
+
+// IdsecCCEAzureAddManagementGroup is the input for onboarding an Azure Management Group manually.
+// OPENAPI-CORRELATION: AzureProgrammaticGeneralOnboardInput
+type IdsecCCEAzureAddManagementGroup struct {
+	EntraID           string                           `json:"entraId" mapstructure:"entra_id" validate:"required,uuid" desc:"Microsoft Entra tenant ID (UUID format)."`
+	ManagementGroupID string                           `json:"id" mapstructure:"management_group_id" validate:"required" desc:"Management group ID."`
+	Services          []ccemodels.IdsecCCEServiceInput `json:"services" mapstructure:"services" validate:"required,min=1,dive" desc:"List of services to add (SIA, SCA, SecretsHub, CDS) and their associated resources."`
+	CCEResources      map[string]interface{}           `json:"cceResources" mapstructure:"cce_resources" validate:"required" desc:"CCE resources."`
+}
+
+// IdsecCCEAzureManagementGroup represents the details of an Azure Management Group.
+// OPENAPI-CORRELATION: AzureGetMgmtGroupDetailsOutput
+type IdsecCCEAzureManagementGroup struct {
+	ID                string                            `json:"id" mapstructure:"id" desc:"CCE management group onboarding ID"`
+	OnboardingType    string                            `json:"onboardingType" mapstructure:"onboarding_type" desc:"Onboarding type: standard (UI), programmatic (API), or terraform_provider." possible_values:"standard,programmatic,terraform_provider."`
+	Region            string                            `json:"region" mapstructure:"region" desc:"The region where CCE resources are deployed."`
+	DisplayName       string                            `json:"displayName,omitempty" mapstructure:"display_name,omitempty" desc:"Display name shown in CCE UI."`
+	Parameters        map[string]map[string]interface{} `json:"parameters,omitempty" mapstructure:"parameters,omitempty" desc:"A key-value map of service-specific configuration parameters, keyed by service name."`
+	Status            string                            `json:"status" mapstructure:"status" desc:"Onboarding status (for example, Completely added, Partially added, Failed to add)."`
+	ConsentData       []map[string]interface{}          `json:"consentData,omitempty" mapstructure:"consent_data,omitempty" desc:"Consent data for service applications."`
+	EntraID           string                            `json:"entraId" mapstructure:"entra_id" desc:"Microsoft Entra tenant ID."`
+	ManagementGroupID string                            `json:"managementGroupId" mapstructure:"management_group_id" desc:"Azure management group ID."`
+}
+
+// IdsecCCEAzureGetManagementGroup is the input for getting Azure Management Group details.
+// OPENAPI-CORRELATION: Input for GET /api/azure/manual/mgmtgroup/{id}
+type IdsecCCEAzureGetManagementGroup struct {
+	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE management group onboarding ID."`
+}
+
+// IdsecCCEAzureUpdateManagementGroup is the input for updating an Azure Management Group's services.
+// Services missing from the input are removed from the onboarded resource.
+// OPENAPI-CORRELATION: Custom input combining multiple endpoints
+type IdsecCCEAzureUpdateManagementGroup struct {
+	// ID is the Management Group's onboarding ID.
+	ID string `json:"id,omitempty" mapstructure:"id,omitempty" validate:"required" desc:"CCE management group onboarding ID."`
+	// Services is the list of services to onboard (e.g., DPA, SCA, SecretsHub, CDS) with their resource configurations.
+	Services []ccemodels.IdsecCCEServiceInput `json:"services" mapstructure:"services" validate:"required,min=1,dive" desc:"List of services to add (SIA, SCA, SecretsHub, CDS) and their associated resources."`
+}
+
+// IdsecCCEAzureDeleteManagementGroup is the input for deleting an Azure Management Group.
+// OPENAPI-CORRELATION: Input for DELETE /api/azure/manual/{id}
+type IdsecCCEAzureDeleteManagementGroup struct {
+	// ID is the Management Group's onboarding ID.
+	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE management group onboarding ID."`
+}
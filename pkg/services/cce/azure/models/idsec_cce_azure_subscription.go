@@ -58,3 +58,53 @@ type TfIdsecCCEAzureDeleteSubscription struct {
 	// ID is the Subscription's onboarding ID.
 	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE subscription onboarding ID."`
 }
+
+// IdsecCCEAzureAddSubscription is the input for onboarding an Azure Subscription manually.
+// OPENAPI-CORRELATION: AzureProgrammaticGeneralOnboardInput
+type IdsecCCEAzureAddSubscription struct {
+	EntraID          string                           `json:"entraId" mapstructure:"entra_id" validate:"required,uuid" desc:"Microsoft Entra tenant ID (UUID format)."`
+	EntraTenantName  string                           `json:"entraTenantName" mapstructure:"entra_tenant_name" validate:"required" desc:"Microsoft Entra tenant name."`
+	SubscriptionID   string                           `json:"id" mapstructure:"subscription_id" validate:"required" desc:"Azure subscription ID."`
+	SubscriptionName string                           `json:"subscriptionName" mapstructure:"subscription_name" validate:"required" desc:"Azure subscription name."`
+	Services         []ccemodels.IdsecCCEServiceInput `json:"services" mapstructure:"services" validate:"required,min=1,dive" desc:"List of services to add (SIA, SCA, SecretsHub, CDS) and their associated resources."`
+}
+
+// IdsecCCEAzureSubscription represents the details of an Azure Subscription.
+// OPENAPI-CORRELATION: AzureGetSubscriptionDetailsOutput
+type IdsecCCEAzureSubscription struct {
+	ID                  string                            `json:"id" mapstructure:"id" desc:"CCE subscription onboarding ID."`
+	OnboardingType      string                            `json:"onboardingType" mapstructure:"onboarding_type" desc:"Onboarding type: standard (UI), programmatic (API), or terraform_provider." possible_values:"standard,programmatic,terraform_provider."`
+	Region              string                            `json:"region" mapstructure:"region" desc:"The region where CCE resources are deployed."`
+	DisplayName         string                            `json:"displayName,omitempty" mapstructure:"display_name,omitempty" desc:"Display name shown in the CCE UI."`
+	Parameters          map[string]map[string]interface{} `json:"parameters,omitempty" mapstructure:"parameters,omitempty" desc:"A key-value map of service-specific configuration parameters, keyed by service name."`
+	Status              string                            `json:"status" mapstructure:"status" desc:"Onboarding status (for example, Completely added, Partially added, Failed to add)."`
+	ConsentData         []map[string]interface{}          `json:"consentData,omitempty" mapstructure:"consent_data,omitempty" desc:"Consent data for service applications."`
+	SubscriptionID      string                            `json:"subscriptionId" mapstructure:"subscription_id" desc:"Azure subscription ID."`
+	EntraID             string                            `json:"entraId,omitempty" mapstructure:"entra_id,omitempty" desc:"Microsoft Entra tenant ID."`
+	EntraName           string                            `json:"entraName,omitempty" mapstructure:"entra_name,omitempty" desc:"Microsoft Entra tenant name."`
+	ManagementGroupId   string                            `json:"managementGroupId,omitempty" mapstructure:"management_group_id,omitempty" desc:"Azure management group ID."`
+	ManagementGroupName string                            `json:"managementGroupName,omitempty" mapstructure:"management_group_name,omitempty" desc:"Azure management group name."`
+}
+
+// IdsecCCEAzureGetSubscription is the input for getting Azure Subscription details.
+// OPENAPI-CORRELATION: Input for GET /api/azure/manual/subscription/{id}
+type IdsecCCEAzureGetSubscription struct {
+	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE subscription onboarding ID."`
+}
+
+// IdsecCCEAzureUpdateSubscription is the input for updating an Azure Subscription's services.
+// Services missing from the input are removed from the onboarded resource.
+// OPENAPI-CORRELATION: Custom input combining multiple endpoints
+type IdsecCCEAzureUpdateSubscription struct {
+	// ID is the Subscription's onboarding ID.
+	ID string `json:"id,omitempty" mapstructure:"id,omitempty" validate:"required" desc:"CCE subscription onboarding ID."`
+	// Services is the list of services to onboard (e.g., DPA, SCA, SecretsHub, CDS) with their resource configurations.
+	Services []ccemodels.IdsecCCEServiceInput `json:"services" mapstructure:"services" validate:"required,min=1,dive" desc:"List of services to add (SIA, SCA, SecretsHub, CDS) and their associated resources."`
+}
+
+// IdsecCCEAzureDeleteSubscription is the input for deleting an Azure Subscription.
+// OPENAPI-CORRELATION: Input for DELETE /api/azure/manual/{id}
+type IdsecCCEAzureDeleteSubscription struct {
+	// ID is the Subscription's onboarding ID.
+	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE subscription onboarding ID."`
+}
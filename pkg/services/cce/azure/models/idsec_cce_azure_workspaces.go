@@ -4,6 +4,14 @@ import (
 	ccemodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/common/models"
 )
 
+// Possible Azure workspace types.
+const (
+	WorkspaceTypeOrganization    = "azure_organization"
+	WorkspaceTypeEntra           = "azure_entra"
+	WorkspaceTypeManagementGroup = "azure_management_group"
+	WorkspaceTypeSubscription    = "azure_subscription"
+)
+
 // TfIdsecCCEAzureGetWorkspacesTerraform is the input for retrieving Azure workspaces for Terraform.
 // This struct does not include pagination parameters as pagination is handled automatically.
 // ⚠️  DEPRECATED: This struct is deprecated and should not be used.
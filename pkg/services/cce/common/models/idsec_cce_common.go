@@ -148,12 +148,22 @@ type IdsecCCEServiceDto struct {
 }
 
 // TfIdsecCCEWorkspace represents a workspace in the CCE system.
+// ⚠️  DEPRECATED: Use IdsecCCEWorkspace instead.
+// ⚠️  It exists only for compatibility with Terraform provider.
+type TfIdsecCCEWorkspace = IdsecCCEWorkspace
+
+// TfIdsecCCEWorkspaceData represents the data associated with a workspace.
+// ⚠️  DEPRECATED: Use IdsecCCEWorkspaceData instead.
+// ⚠️  It exists only for compatibility with Terraform provider.
+type TfIdsecCCEWorkspaceData = IdsecCCEWorkspaceData
+
+// IdsecCCEWorkspace represents a workspace in the CCE system.
 // OPENAPI-CORRELATION: WorkspaceOutput
-type TfIdsecCCEWorkspace struct {
+type IdsecCCEWorkspace struct {
 	// Key is the unique CCE onboarding ID for the workspace.
 	Key string `json:"key" mapstructure:"key" desc:"Unique CCE onboarding ID for the workspace"`
 	// Data contains the detailed workspace information including IDs, names, services, and status.
-	Data TfIdsecCCEWorkspaceData `json:"data" mapstructure:"data" desc:"Detailed workspace information (IDs, names, services, status)"`
+	Data IdsecCCEWorkspaceData `json:"data" mapstructure:"data" desc:"Detailed workspace information (IDs, names, services, status)"`
 	// Leaf indicates whether this workspace is a leaf node (e.g., account) or can have children (e.g., organization/organization unit).
 	Leaf bool `json:"leaf" mapstructure:"leaf" desc:"Indicates if workspace is leaf node (account) or can have children (organization/organization unit)"`
 	// Path is the hierarchical path showing the workspace's location in the organizational structure.
@@ -162,9 +172,9 @@ type TfIdsecCCEWorkspace struct {
 	ParentID string `json:"parent_id" mapstructure:"parent_id" desc:"CCE onboarding ID of parent workspace (Organization or Organization Unit)"`
 }
 
-// TfIdsecCCEWorkspaceData represents the data associated with a workspace.
+// IdsecCCEWorkspaceData represents the data associated with a workspace.
 // OPENAPI-CORRELATION: WorkspaceDataOutput
-type TfIdsecCCEWorkspaceData struct {
+type IdsecCCEWorkspaceData struct {
 	// ID is the CCE onboarding ID for the workspace.
 	ID string `json:"id" mapstructure:"id" desc:"CCE onboarding ID for the workspace"`
 	// PlatformID is the cloud provider's native identifier (e.g., AWS account ID "123456789012").
@@ -187,6 +197,16 @@ type TfIdsecCCEWorkspaceData struct {
 	OrganizationName string `json:"organization_name,omitempty" mapstructure:"organization_name" desc:"Display name of parent organization"`
 }
 
+// IdsecCCEWorkspacesFilter is the filter for listing onboarded workspaces of a specific type.
+type IdsecCCEWorkspacesFilter struct {
+	// ParentID filters workspaces to only those under the specified parent CCE onboarding ID.
+	ParentID string `json:"parent_id,omitempty" mapstructure:"parent_id,omitempty" desc:"Filter by parent CCE onboarding ID"`
+	// Services filters workspaces to only those deployed with the specified services, comma-separated (e.g., "dpa,sca").
+	Services string `json:"services,omitempty" mapstructure:"services,omitempty" desc:"Filter by services, comma-separated (e.g., dpa,sca)"`
+	// WorkspaceStatus filters workspaces by their onboarding status, comma-separated (e.g., "Completely added,Failed to add").
+	WorkspaceStatus string `json:"workspace_status,omitempty" mapstructure:"workspace_status,omitempty" desc:"Filter by status, comma-separated (e.g., Completely added,Failed to add)"`
+}
+
 // IdsecCCEPageOutput represents pagination information.
 // OPENAPI-CORRELATION: PageOutput
 type IdsecCCEPageOutput struct {
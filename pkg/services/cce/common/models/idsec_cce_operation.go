@@ -0,0 +1,92 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultOnboardingPollInterval is the default interval between onboarding status checks.
+const DefaultOnboardingPollInterval = 10 * time.Second
+
+// IdsecCCEOnboardingStatusFunc retrieves the current onboarding status of an onboarded resource.
+type IdsecCCEOnboardingStatusFunc func() (string, error)
+
+// IdsecCCEOnboardingOperation is a handle to an asynchronous CCE onboarding.
+// Adding a resource returns as soon as CCE accepted the request, while the resources
+// required by the onboarded services are still being deployed. The handle can be
+// refreshed or waited upon until the onboarding reaches a final status.
+type IdsecCCEOnboardingOperation struct {
+	// ID is the CCE onboarding ID of the added resource.
+	ID string `json:"id" mapstructure:"id" desc:"CCE onboarding ID of the added resource"`
+	// ResourceType is the type of the added resource (e.g., aws_organization, azure_subscription).
+	ResourceType string `json:"resource_type" mapstructure:"resource_type" desc:"Type of the added resource"`
+	// Status is the last known onboarding status of the resource.
+	Status string `json:"status,omitempty" mapstructure:"status,omitempty" choices:"Removing,Deploying resources,Waiting for deployment,Waiting for consent,Partially added,Failed to add,Service Error,Completely added" desc:"Last known onboarding status of the resource"`
+
+	statusFunc IdsecCCEOnboardingStatusFunc
+}
+
+// NewIdsecCCEOnboardingOperation creates a new onboarding operation handle for the given resource.
+func NewIdsecCCEOnboardingOperation(id string, resourceType string, statusFunc IdsecCCEOnboardingStatusFunc) *IdsecCCEOnboardingOperation {
+	return &IdsecCCEOnboardingOperation{
+		ID:           id,
+		ResourceType: resourceType,
+		statusFunc:   statusFunc,
+	}
+}
+
+// Refresh retrieves the current onboarding status and stores it on the operation.
+func (o *IdsecCCEOnboardingOperation) Refresh() (string, error) {
+	if o.statusFunc == nil {
+		return "", fmt.Errorf("onboarding operation for %s [%s] cannot be refreshed", o.ResourceType, o.ID)
+	}
+	status, err := o.statusFunc()
+	if err != nil {
+		return "", err
+	}
+	o.Status = status
+	return status, nil
+}
+
+// Done returns whether the last known status is a final onboarding status.
+func (o *IdsecCCEOnboardingOperation) Done() bool {
+	switch o.Status {
+	case CompletelyAdded, PartiallyAdded, FailedToAdd, ServiceError:
+		return true
+	}
+	return false
+}
+
+// Failed returns whether the last known status indicates a failed onboarding.
+func (o *IdsecCCEOnboardingOperation) Failed() bool {
+	return o.Status == FailedToAdd || o.Status == ServiceError
+}
+
+// Wait polls the onboarding status until it reaches a final status or the context is done.
+// Resources awaiting a manual deployment or consent keep the operation pending, so callers
+// should bound the wait with a context deadline. A non-positive poll interval uses
+// DefaultOnboardingPollInterval. An error is returned if the onboarding failed.
+func (o *IdsecCCEOnboardingOperation) Wait(ctx context.Context, pollInterval time.Duration) (string, error) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultOnboardingPollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := o.Refresh(); err != nil {
+			return o.Status, err
+		}
+		if o.Done() {
+			if o.Failed() {
+				return o.Status, fmt.Errorf("onboarding of %s [%s] ended with status [%s]", o.ResourceType, o.ID, o.Status)
+			}
+			return o.Status, nil
+		}
+		select {
+		case <-ctx.Done():
+			return o.Status, fmt.Errorf("stopped waiting for onboarding of %s [%s] with status [%s]: %w", o.ResourceType, o.ID, o.Status, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnboardingOperation_Wait(t *testing.T) {
+	tests := []struct {
+		name           string
+		statuses       []string
+		statusErr      error
+		timeout        time.Duration
+		expectedStatus string
+		expectedError  string
+	}{
+		{
+			name:           "completes_after_deployment",
+			statuses:       []string{WaitingForDeployment, DeployingResources, CompletelyAdded},
+			expectedStatus: CompletelyAdded,
+		},
+		{
+			name:           "partially_added_is_final",
+			statuses:       []string{PartiallyAdded},
+			expectedStatus: PartiallyAdded,
+		},
+		{
+			name:           "failed_onboarding",
+			statuses:       []string{DeployingResources, FailedToAdd},
+			expectedStatus: FailedToAdd,
+			expectedError:  "ended with status [Failed to add]",
+		},
+		{
+			name:          "status_error",
+			statusErr:     errors.New("status unavailable"),
+			expectedError: "status unavailable",
+		},
+		{
+			name:           "context_deadline",
+			statuses:       []string{WaitingForConsent},
+			timeout:        20 * time.Millisecond,
+			expectedStatus: WaitingForConsent,
+			expectedError:  "stopped waiting for onboarding",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			operation := NewIdsecCCEOnboardingOperation("onboarding-1", "azure_subscription", func() (string, error) {
+				if tt.statusErr != nil {
+					return "", tt.statusErr
+				}
+				status := tt.statuses[min(calls, len(tt.statuses)-1)]
+				calls++
+				return status, nil
+			})
+
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+			status, err := operation.Wait(ctx, time.Millisecond)
+			require.Equal(t, tt.expectedStatus, status)
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, operation.Done())
+		})
+	}
+}
+
+func TestOnboardingOperation_RefreshWithoutStatusFunc(t *testing.T) {
+	operation := &IdsecCCEOnboardingOperation{ID: "onboarding-1", ResourceType: "aws_account"}
+	_, err := operation.Refresh()
+	require.Error(t, err)
+}
@@ -0,0 +1,37 @@
+package actions
+
+import (
+	ccemodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/common/models"
+	gcpmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/gcp/models"
+)
+
+// ActionToSchemaMap is a map that defines the mapping between CCE GCP action names and their corresponding schema types.
+var ActionToSchemaMap = map[string]interface{}{
+	// Organization actions
+	"list-organizations":    nil,
+	"list-organizations-by": &ccemodels.IdsecCCEWorkspacesFilter{},
+	"organization":          &gcpmodels.IdsecCCEGCPGetOrganization{},
+	"add-organization":      &gcpmodels.IdsecCCEGCPAddOrganization{},
+	"update-organization":   &gcpmodels.IdsecCCEGCPUpdateOrganization{},
+	"delete-organization":   &gcpmodels.IdsecCCEGCPDeleteOrganization{},
+
+	// Folder actions
+	"list-folders":    nil,
+	"list-folders-by": &ccemodels.IdsecCCEWorkspacesFilter{},
+	"folder":          &gcpmodels.IdsecCCEGCPGetFolder{},
+	"add-folder":      &gcpmodels.IdsecCCEGCPAddFolder{},
+	"update-folder":   &gcpmodels.IdsecCCEGCPUpdateFolder{},
+	"delete-folder":   &gcpmodels.IdsecCCEGCPDeleteFolder{},
+
+	// Project actions
+	"list-projects":    nil,
+	"list-projects-by": &ccemodels.IdsecCCEWorkspacesFilter{},
+	"project":          &gcpmodels.IdsecCCEGCPGetProject{},
+	"add-project":      &gcpmodels.IdsecCCEGCPAddProject{},
+	"update-project":   &gcpmodels.IdsecCCEGCPUpdateProject{},
+	"delete-project":   &gcpmodels.IdsecCCEGCPDeleteProject{},
+
+	// Services actions
+	"add-services":    &gcpmodels.IdsecCCEGCPAddManualServices{},
+	"delete-services": &gcpmodels.IdsecCCEGCPDeleteManualServices{},
+}
@@ -0,0 +1,166 @@
+package gcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services"
+	ccemodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/common/models"
+	gcpmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/gcp/models"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/cce/internal"
+	"github.com/stretchr/testify/require"
+)
+
+// setupGCPService creates an IdsecCCEGCPService with the given mock ISP client.
+func setupGCPService(client *isp.IdsecISPServiceClient) *IdsecCCEGCPService {
+	ispBase := &services.IdsecISPBaseService{}
+	// Use reflection to set the private client field for testing
+	v := reflect.ValueOf(ispBase).Elem()
+	clientField := v.FieldByName("client")
+	clientField = reflect.NewAt(clientField.Type(), unsafe.Pointer(clientField.UnsafeAddr())).Elem()
+	clientField.Set(reflect.ValueOf(client))
+
+	return &IdsecCCEGCPService{
+		IdsecBaseService: &services.IdsecBaseService{
+			Logger: common.GlobalLogger,
+		},
+		IdsecISPBaseService: ispBase,
+	}
+}
+
+func TestOrganization_Success(t *testing.T) {
+	client, cleanup := internal.SetupMockCCEService(t, []internal.MockEndpointConfig{
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "GET" && r.URL.Path == "/api/gcp/manual/organization/org-onboarding-123"
+			},
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{"id": "org-onboarding-123", "organizationId": "123456789012", "organizationName": "Test Organization"}`,
+		},
+	})
+	defer cleanup()
+
+	service := setupGCPService(client)
+	result, err := service.Organization(&gcpmodels.IdsecCCEGCPGetOrganization{ID: "org-onboarding-123"})
+
+	require.NoError(t, err)
+	require.Equal(t, "org-onboarding-123", result.ID)
+	require.Equal(t, "Test Organization", result.OrganizationName)
+}
+
+func TestUpdateOrganization_Success(t *testing.T) {
+	var addedServices map[string]interface{}
+	var removedServices []string
+	client, cleanup := internal.SetupMockCCEService(t, []internal.MockEndpointConfig{
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "GET" && r.URL.Path == "/api/gcp/manual/organization/org-onboarding-123"
+			},
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{"id": "org-onboarding-123", "services": ["dpa", "cds"]}`,
+		},
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "POST" && r.URL.Path == "/api/gcp/manual/org-onboarding-123/services"
+			},
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{}`,
+			OnRequest: func(r *http.Request) {
+				_ = json.NewDecoder(r.Body).Decode(&addedServices)
+			},
+		},
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "DELETE" && r.URL.Path == "/api/gcp/manual/org-onboarding-123/services"
+			},
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{}`,
+			OnRequest: func(r *http.Request) {
+				removedServices = r.URL.Query()["services_names"]
+			},
+		},
+	})
+	defer cleanup()
+
+	service := setupGCPService(client)
+	result, err := service.UpdateOrganization(&gcpmodels.IdsecCCEGCPUpdateOrganization{
+		ID: "org-onboarding-123",
+		Services: []ccemodels.IdsecCCEServiceInput{
+			{ServiceName: ccemodels.DPA, Resources: map[string]interface{}{}},
+			{ServiceName: ccemodels.SCA, Resources: map[string]interface{}{}},
+		},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "org-onboarding-123", result.ID)
+	require.Len(t, addedServices["services"], 1)
+	require.Equal(t, []string{"cds"}, removedServices)
+}
+
+func TestDeleteOrganization_Success(t *testing.T) {
+	client, cleanup := internal.SetupMockCCEService(t, []internal.MockEndpointConfig{
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "DELETE" && r.URL.Path == "/api/gcp/manual/org-onboarding-123"
+			},
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{}`,
+		},
+	})
+	defer cleanup()
+
+	service := setupGCPService(client)
+	err := service.DeleteOrganization(&gcpmodels.IdsecCCEGCPDeleteOrganization{ID: "org-onboarding-123"})
+	require.NoError(t, err)
+}
+
+func TestOrganization_ErrorPropagation(t *testing.T) {
+	internal.TestServiceErrorPropagation(t, func(client *isp.IdsecISPServiceClient) error {
+		service := setupGCPService(client)
+		_, err := service.Organization(&gcpmodels.IdsecCCEGCPGetOrganization{ID: "org-onboarding-123"})
+		return err
+	})
+}
+
+func TestAddOrganization_ReturnsOperation(t *testing.T) {
+	var capturedBody map[string]interface{}
+	client, cleanup := internal.SetupMockCCEService(t, []internal.MockEndpointConfig{
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "POST" && r.URL.Path == "/api/gcp/manual"
+			},
+			StatusCode:   http.StatusCreated,
+			ResponseBody: `{"id": "org-onboarding-123"}`,
+			OnRequest: func(r *http.Request) {
+				_ = json.NewDecoder(r.Body).Decode(&capturedBody)
+			},
+		},
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "GET" && r.URL.Path == "/api/gcp/manual/organization/org-onboarding-123"
+			},
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{"id": "org-onboarding-123", "onboardingType": "programmatic", "status": "Waiting for consent"}`,
+		},
+	})
+	defer cleanup()
+
+	service := setupGCPService(client)
+	operation, err := service.AddOrganization(&gcpmodels.IdsecCCEGCPAddOrganization{
+		OrganizationID:   "123456789012",
+		OrganizationName: "Test Organization",
+		Services:         []ccemodels.IdsecCCEServiceInput{{ServiceName: ccemodels.SCA}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, ccemodels.Programmatic, capturedBody["onboardingType"])
+	require.Equal(t, "organization", capturedBody["deploymentType"])
+	require.Equal(t, "org-onboarding-123", operation.ID)
+	require.Equal(t, gcpmodels.WorkspaceTypeOrganization, operation.ResourceType)
+	require.Equal(t, ccemodels.WaitingForConsent, operation.Status)
+	require.False(t, operation.Done())
+}
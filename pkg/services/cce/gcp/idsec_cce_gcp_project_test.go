@@ -0,0 +1,135 @@
+package gcp
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
+	ccemodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/common/models"
+	gcpmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/gcp/models"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/cce/internal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddProject_Success(t *testing.T) {
+	var capturedBody map[string]interface{}
+	client, cleanup := internal.SetupMockCCEService(t, []internal.MockEndpointConfig{
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "POST" && r.URL.Path == "/api/gcp/manual"
+			},
+			StatusCode:   http.StatusCreated,
+			ResponseBody: `{"id": "project-onboarding-123"}`,
+			OnRequest: func(r *http.Request) {
+				_ = json.NewDecoder(r.Body).Decode(&capturedBody)
+			},
+		},
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "GET" && r.URL.Path == "/api/gcp/manual/project/project-onboarding-123"
+			},
+			StatusCode: http.StatusOK,
+			ResponseBody: `{
+				"id": "project-onboarding-123",
+				"status": "Completely added",
+				"projectId": "my-project",
+				"projectNumber": "987654321",
+				"folderId": "folders/111"
+			}`,
+		},
+	})
+	defer cleanup()
+
+	service := setupGCPService(client)
+	operation, err := service.AddProject(&gcpmodels.IdsecCCEGCPAddProject{
+		ProjectID:     "my-project",
+		ProjectNumber: "987654321",
+		ProjectName:   "My Project",
+		Services: []ccemodels.IdsecCCEServiceInput{
+			{ServiceName: ccemodels.SecretsHub, Resources: map[string]interface{}{}},
+		},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "project-onboarding-123", operation.ID)
+	require.Equal(t, gcpmodels.WorkspaceTypeProject, operation.ResourceType)
+	require.Equal(t, ccemodels.CompletelyAdded, operation.Status)
+	require.True(t, operation.Done())
+	require.Equal(t, "standalone", capturedBody["deploymentType"])
+	require.Equal(t, ccemodels.Programmatic, capturedBody["onboardingType"])
+	require.NotContains(t, capturedBody, "organizationId")
+}
+
+func TestAddFolder_Success(t *testing.T) {
+	var capturedBody map[string]interface{}
+	client, cleanup := internal.SetupMockCCEService(t, []internal.MockEndpointConfig{
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "POST" && r.URL.Path == "/api/gcp/manual"
+			},
+			StatusCode:   http.StatusCreated,
+			ResponseBody: `{"id": "folder-onboarding-123"}`,
+			OnRequest: func(r *http.Request) {
+				_ = json.NewDecoder(r.Body).Decode(&capturedBody)
+			},
+		},
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "GET" && r.URL.Path == "/api/gcp/manual/folder/folder-onboarding-123"
+			},
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{"id": "folder-onboarding-123", "folderId": "111", "organizationId": "123456789012"}`,
+		},
+	})
+	defer cleanup()
+
+	service := setupGCPService(client)
+	operation, err := service.AddFolder(&gcpmodels.IdsecCCEGCPAddFolder{
+		OrganizationID: "123456789012",
+		FolderID:       "111",
+		FolderName:     "Engineering",
+		Services: []ccemodels.IdsecCCEServiceInput{
+			{ServiceName: ccemodels.DPA, Resources: map[string]interface{}{}},
+		},
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "folder-onboarding-123", operation.ID)
+	require.Equal(t, gcpmodels.WorkspaceTypeFolder, operation.ResourceType)
+	require.Equal(t, "folder", capturedBody["deploymentType"])
+	require.Equal(t, "123456789012", capturedBody["organizationId"])
+}
+
+func TestDeleteServices_Success(t *testing.T) {
+	var removedServices []string
+	client, cleanup := internal.SetupMockCCEService(t, []internal.MockEndpointConfig{
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "DELETE" && r.URL.Path == "/api/gcp/manual/project-onboarding-123/services"
+			},
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{}`,
+			OnRequest: func(r *http.Request) {
+				removedServices = r.URL.Query()["services_names"]
+			},
+		},
+	})
+	defer cleanup()
+
+	service := setupGCPService(client)
+	err := service.DeleteServices(&gcpmodels.IdsecCCEGCPDeleteManualServices{
+		ID:           "project-onboarding-123",
+		ServiceNames: []string{"dpa", "sca"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"dpa", "sca"}, removedServices)
+}
+
+func TestProject_ErrorPropagation(t *testing.T) {
+	internal.TestServiceErrorPropagation(t, func(client *isp.IdsecISPServiceClient) error {
+		service := setupGCPService(client)
+		_, err := service.Project(&gcpmodels.IdsecCCEGCPGetProject{ID: "project-onboarding-123"})
+		return err
+	})
+}
@@ -0,0 +1,324 @@
+package gcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/auth"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services"
+	ccemodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/common/models"
+	gcpmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/gcp/models"
+	cceinternal "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/internal"
+	"github.com/mitchellh/mapstructure"
+)
+
+// API path constants for GCP general operations
+const (
+	pathWorkspacesURL = "/api/gcp/workspaces"
+)
+
+// workspacesPageSize is the number of workspaces fetched per page when listing workspaces.
+const workspacesPageSize = 100
+
+// gcpWorkspacesAPIResponse is an internal struct to capture the API response
+// which includes pagination information.
+type gcpWorkspacesAPIResponse struct {
+	Workspaces []ccemodels.IdsecCCEWorkspace `json:"workspaces" mapstructure:"workspaces"`
+	Page       ccemodels.IdsecCCEPageOutput  `json:"page" mapstructure:"page"`
+}
+
+// IdsecCCEGCPWorkspacesPage is a page of onboarded GCP workspaces.
+type IdsecCCEGCPWorkspacesPage = common.IdsecPage[ccemodels.IdsecCCEWorkspace]
+
+// IdsecCCEGCPService is the implementation of the CCE GCP service.
+type IdsecCCEGCPService struct {
+	*services.IdsecBaseService
+	*services.IdsecISPBaseService
+}
+
+// NewIdsecCCEGCPService creates a new instance of IdsecCCEGCPService.
+func NewIdsecCCEGCPService(authenticators ...auth.IdsecAuth) (*IdsecCCEGCPService, error) {
+	cceGCPService := &IdsecCCEGCPService{}
+	var cceGCPServiceInterface services.IdsecService = cceGCPService
+	baseService, err := services.NewIdsecBaseService(cceGCPServiceInterface, authenticators...)
+	if err != nil {
+		return nil, err
+	}
+	ispBaseAuth, err := baseService.Authenticator("isp")
+	if err != nil {
+		return nil, err
+	}
+	ispAuth := ispBaseAuth.(*auth.IdsecISPAuth)
+
+	ispBaseService, err := services.NewIdsecISPBaseService(ispAuth, cceinternal.IspServiceName, cceinternal.IspVersion, cceinternal.IspAPIVersion, cceGCPService.refreshCCEGCPAuth)
+	if err != nil {
+		return nil, err
+	}
+	cceGCPService.IdsecBaseService = baseService
+	cceGCPService.IdsecISPBaseService = ispBaseService
+	return cceGCPService, nil
+}
+
+func (s *IdsecCCEGCPService) refreshCCEGCPAuth(client *common.IdsecClient) error {
+	err := isp.RefreshClient(client, s.ISPAuth())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ListOrganizations lists the GCP Organizations onboarded to CCE.
+// On failure during pagination, the channel emits a final page with Err set.
+// API: GET /api/gcp/workspaces
+func (s *IdsecCCEGCPService) ListOrganizations() (<-chan *IdsecCCEGCPWorkspacesPage, error) {
+	return s.listWorkspaces(gcpmodels.WorkspaceTypeOrganization, nil), nil
+}
+
+// ListOrganizationsBy lists the GCP Organizations onboarded to CCE which match the given filter.
+// On failure during pagination, the channel emits a final page with Err set.
+// API: GET /api/gcp/workspaces
+func (s *IdsecCCEGCPService) ListOrganizationsBy(filter *ccemodels.IdsecCCEWorkspacesFilter) (<-chan *IdsecCCEGCPWorkspacesPage, error) {
+	return s.listWorkspaces(gcpmodels.WorkspaceTypeOrganization, filter), nil
+}
+
+// Organization retrieves an onboarded GCP Organization by its onboarding ID.
+// API: GET /api/gcp/manual/organization/{id}
+func (s *IdsecCCEGCPService) Organization(getOrganization *gcpmodels.IdsecCCEGCPGetOrganization) (*gcpmodels.IdsecCCEGCPOrganization, error) {
+	return s.organization(getOrganization)
+}
+
+// AddOrganization onboards a GCP Organization programmatically.
+// The returned operation tracks the onboarding, which completes once the CCE resources are deployed.
+// API: POST /api/gcp/manual
+func (s *IdsecCCEGCPService) AddOrganization(addOrganization *gcpmodels.IdsecCCEGCPAddOrganization) (*ccemodels.IdsecCCEOnboardingOperation, error) {
+	organizationID, err := s.addOrganization(addOrganization, ccemodels.Programmatic)
+	if err != nil {
+		return nil, err
+	}
+	return s.newOnboardingOperation(organizationID, gcpmodels.WorkspaceTypeOrganization, func() (string, error) {
+		organization, err := s.organization(&gcpmodels.IdsecCCEGCPGetOrganization{ID: organizationID})
+		if err != nil {
+			return "", err
+		}
+		return organization.Status, nil
+	}), nil
+}
+
+// UpdateOrganization updates the services of an onboarded GCP Organization.
+// Services missing from the input are removed and new services are added.
+// API: POST/DELETE /api/gcp/manual/{id}/services
+func (s *IdsecCCEGCPService) UpdateOrganization(updateOrganization *gcpmodels.IdsecCCEGCPUpdateOrganization) (*gcpmodels.IdsecCCEGCPOrganization, error) {
+	return s.updateOrganization(updateOrganization)
+}
+
+// DeleteOrganization deletes an onboarded GCP Organization.
+// API: DELETE /api/gcp/manual/{id}
+func (s *IdsecCCEGCPService) DeleteOrganization(deleteOrganization *gcpmodels.IdsecCCEGCPDeleteOrganization) error {
+	return s.deleteOrganization(deleteOrganization)
+}
+
+// ListFolders lists the GCP Folders onboarded to CCE.
+// On failure during pagination, the channel emits a final page with Err set.
+// API: GET /api/gcp/workspaces
+func (s *IdsecCCEGCPService) ListFolders() (<-chan *IdsecCCEGCPWorkspacesPage, error) {
+	return s.listWorkspaces(gcpmodels.WorkspaceTypeFolder, nil), nil
+}
+
+// ListFoldersBy lists the GCP Folders onboarded to CCE which match the given filter.
+// On failure during pagination, the channel emits a final page with Err set.
+// API: GET /api/gcp/workspaces
+func (s *IdsecCCEGCPService) ListFoldersBy(filter *ccemodels.IdsecCCEWorkspacesFilter) (<-chan *IdsecCCEGCPWorkspacesPage, error) {
+	return s.listWorkspaces(gcpmodels.WorkspaceTypeFolder, filter), nil
+}
+
+// Folder retrieves a onboarded GCP Folder by its onboarding ID.
+// API: GET /api/gcp/manual/folder/{id}
+func (s *IdsecCCEGCPService) Folder(getFolder *gcpmodels.IdsecCCEGCPGetFolder) (*gcpmodels.IdsecCCEGCPFolder, error) {
+	return s.folder(getFolder)
+}
+
+// AddFolder onboards a GCP Folder programmatically.
+// The returned operation tracks the onboarding, which completes once the CCE resources are deployed.
+// API: POST /api/gcp/manual
+func (s *IdsecCCEGCPService) AddFolder(addFolder *gcpmodels.IdsecCCEGCPAddFolder) (*ccemodels.IdsecCCEOnboardingOperation, error) {
+	folderID, err := s.addFolder(addFolder, ccemodels.Programmatic)
+	if err != nil {
+		return nil, err
+	}
+	return s.newOnboardingOperation(folderID, gcpmodels.WorkspaceTypeFolder, func() (string, error) {
+		folder, err := s.folder(&gcpmodels.IdsecCCEGCPGetFolder{ID: folderID})
+		if err != nil {
+			return "", err
+		}
+		return folder.Status, nil
+	}), nil
+}
+
+// UpdateFolder updates the services of an onboarded GCP Folder.
+// Services missing from the input are removed and new services are added.
+// API: POST/DELETE /api/gcp/manual/{id}/services
+func (s *IdsecCCEGCPService) UpdateFolder(updateFolder *gcpmodels.IdsecCCEGCPUpdateFolder) (*gcpmodels.IdsecCCEGCPFolder, error) {
+	return s.updateFolder(updateFolder)
+}
+
+// DeleteFolder deletes an onboarded GCP Folder.
+// API: DELETE /api/gcp/manual/{id}
+func (s *IdsecCCEGCPService) DeleteFolder(deleteFolder *gcpmodels.IdsecCCEGCPDeleteFolder) error {
+	return s.deleteFolder(deleteFolder)
+}
+
+// ListProjects lists the GCP Projects onboarded to CCE.
+// On failure during pagination, the channel emits a final page with Err set.
+// API: GET /api/gcp/workspaces
+func (s *IdsecCCEGCPService) ListProjects() (<-chan *IdsecCCEGCPWorkspacesPage, error) {
+	return s.listWorkspaces(gcpmodels.WorkspaceTypeProject, nil), nil
+}
+
+// ListProjectsBy lists the GCP Projects onboarded to CCE which match the given filter.
+// On failure during pagination, the channel emits a final page with Err set.
+// API: GET /api/gcp/workspaces
+func (s *IdsecCCEGCPService) ListProjectsBy(filter *ccemodels.IdsecCCEWorkspacesFilter) (<-chan *IdsecCCEGCPWorkspacesPage, error) {
+	return s.listWorkspaces(gcpmodels.WorkspaceTypeProject, filter), nil
+}
+
+// Project retrieves a onboarded GCP Project by its onboarding ID.
+// API: GET /api/gcp/manual/project/{id}
+func (s *IdsecCCEGCPService) Project(getProject *gcpmodels.IdsecCCEGCPGetProject) (*gcpmodels.IdsecCCEGCPProject, error) {
+	return s.project(getProject)
+}
+
+// AddProject onboards a GCP Project programmatically.
+// The returned operation tracks the onboarding, which completes once the CCE resources are deployed.
+// API: POST /api/gcp/manual
+func (s *IdsecCCEGCPService) AddProject(addProject *gcpmodels.IdsecCCEGCPAddProject) (*ccemodels.IdsecCCEOnboardingOperation, error) {
+	projectID, err := s.addProject(addProject, ccemodels.Programmatic)
+	if err != nil {
+		return nil, err
+	}
+	return s.newOnboardingOperation(projectID, gcpmodels.WorkspaceTypeProject, func() (string, error) {
+		project, err := s.project(&gcpmodels.IdsecCCEGCPGetProject{ID: projectID})
+		if err != nil {
+			return "", err
+		}
+		return project.Status, nil
+	}), nil
+}
+
+// UpdateProject updates the services of an onboarded GCP Project.
+// Services missing from the input are removed and new services are added.
+// API: POST/DELETE /api/gcp/manual/{id}/services
+func (s *IdsecCCEGCPService) UpdateProject(updateProject *gcpmodels.IdsecCCEGCPUpdateProject) (*gcpmodels.IdsecCCEGCPProject, error) {
+	return s.updateProject(updateProject)
+}
+
+// DeleteProject deletes an onboarded GCP Project.
+// API: DELETE /api/gcp/manual/{id}
+func (s *IdsecCCEGCPService) DeleteProject(deleteProject *gcpmodels.IdsecCCEGCPDeleteProject) error {
+	return s.deleteProject(deleteProject)
+}
+
+// newOnboardingOperation creates an onboarding operation handle and populates its initial status.
+// A failure to retrieve the initial status is logged, as the resource was already added.
+func (s *IdsecCCEGCPService) newOnboardingOperation(id string, resourceType string, statusFunc ccemodels.IdsecCCEOnboardingStatusFunc) *ccemodels.IdsecCCEOnboardingOperation {
+	operation := ccemodels.NewIdsecCCEOnboardingOperation(id, resourceType, statusFunc)
+	if _, err := operation.Refresh(); err != nil {
+		s.Logger.Warning("Failed to get initial onboarding status of %s [%s]: %v", resourceType, id, err)
+	}
+	return operation
+}
+
+// AddServices adds services to an onboarded GCP Organization, Folder or Project.
+// API: POST /api/gcp/manual/{id}/services
+func (s *IdsecCCEGCPService) AddServices(addServices *gcpmodels.IdsecCCEGCPAddManualServices) error {
+	return s.addManualServices(addServices.ID, addServices.Services)
+}
+
+// DeleteServices removes services from an onboarded GCP Organization, Folder or Project.
+// API: DELETE /api/gcp/manual/{id}/services
+func (s *IdsecCCEGCPService) DeleteServices(deleteServices *gcpmodels.IdsecCCEGCPDeleteManualServices) error {
+	return s.deleteManualServices(deleteServices.ID, deleteServices.ServiceNames)
+}
+
+// listWorkspaces streams the GCP workspaces of the given type as pages, fetching
+// pages of workspacesPageSize items until the last page is reached.
+func (s *IdsecCCEGCPService) listWorkspaces(workspaceType string, filter *ccemodels.IdsecCCEWorkspacesFilter) <-chan *IdsecCCEGCPWorkspacesPage {
+	if filter == nil {
+		filter = &ccemodels.IdsecCCEWorkspacesFilter{}
+	}
+	results := make(chan *IdsecCCEGCPWorkspacesPage)
+	go func() {
+		defer close(results)
+		for pageNumber := 1; ; pageNumber++ {
+			s.Logger.Info("Fetching workspaces page %d", pageNumber)
+			page, err := s.workspacesPage(workspaceType, filter, pageNumber)
+			if err != nil {
+				s.Logger.Error("Failed to fetch workspaces page %d: %v", pageNumber, err)
+				results <- &IdsecCCEGCPWorkspacesPage{Err: fmt.Errorf("failed to fetch workspaces page %d: %w", pageNumber, err)}
+				return
+			}
+			items := make([]*ccemodels.IdsecCCEWorkspace, len(page.Workspaces))
+			for i := range page.Workspaces {
+				items[i] = &page.Workspaces[i]
+			}
+			results <- &IdsecCCEGCPWorkspacesPage{Items: items}
+			if page.Page.IsLastPage {
+				s.Logger.Info("Retrieved all workspaces across %d page(s)", pageNumber)
+				return
+			}
+		}
+	}()
+	return results
+}
+
+// workspacesPage retrieves a single page of GCP workspaces of the given type which match the given filter.
+// API: GET /api/gcp/workspaces
+func (s *IdsecCCEGCPService) workspacesPage(workspaceType string, filter *ccemodels.IdsecCCEWorkspacesFilter, pageNumber int) (*gcpWorkspacesAPIResponse, error) {
+	params := map[string][]string{
+		"page":           {fmt.Sprintf("%d", pageNumber)},
+		"page_size":      {fmt.Sprintf("%d", workspacesPageSize)},
+		"workspace_type": {workspaceType},
+	}
+	if filter.ParentID != "" {
+		params["parent_id"] = []string{filter.ParentID}
+	}
+	if filter.WorkspaceStatus != "" {
+		params["workspace_status"] = []string{filter.WorkspaceStatus}
+	}
+	if filter.Services != "" {
+		// Split comma-separated services into multiple query params: services=dpa&services=sca
+		services := strings.Split(filter.Services, ",")
+		for i, service := range services {
+			services[i] = strings.TrimSpace(service)
+		}
+		params["services"] = services
+	}
+
+	response, err := s.ISPClient().Get(context.Background(), pathWorkspacesURL, params)
+	if err != nil {
+		return nil, err
+	}
+	defer cceinternal.CloseResponseBody(response.Body)
+
+	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
+		return nil, cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, "Failed to get workspaces details")
+	}
+	workspacesJSON, err := common.DeserializeJSONSnake(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var workspaces gcpWorkspacesAPIResponse
+	err = mapstructure.Decode(workspacesJSON, &workspaces)
+	if err != nil {
+		return nil, err
+	}
+	return &workspaces, nil
+}
+
+// ServiceConfig returns the service configuration for the IdsecCCEGCPService.
+func (s *IdsecCCEGCPService) ServiceConfig() services.IdsecServiceConfig {
+	return ServiceConfig
+}
@@ -0,0 +1,116 @@
+package gcp
+
+import (
+	"net/http"
+	"testing"
+
+	ccemodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/common/models"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/cce/internal"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListOrganizationsBy_Pagination(t *testing.T) {
+	var requestedServices []string
+	client, cleanup := internal.SetupMockCCEService(t, []internal.MockEndpointConfig{
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "GET" && r.URL.Path == "/api/gcp/workspaces" && r.URL.Query().Get("page") == "1"
+			},
+			StatusCode: http.StatusOK,
+			ResponseBody: `{
+				"workspaces": [{"key": "org-1", "data": {"id": "org-1", "type": "gcp_organization", "platform_type": "GCP"}, "leaf": false}],
+				"page": {"page_number": 1, "page_size": 100, "is_last_page": false, "total_records": 2}
+			}`,
+			OnRequest: func(r *http.Request) {
+				requestedServices = r.URL.Query()["services"]
+			},
+		},
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "GET" && r.URL.Path == "/api/gcp/workspaces" && r.URL.Query().Get("page") == "2"
+			},
+			StatusCode: http.StatusOK,
+			ResponseBody: `{
+				"workspaces": [{"key": "project-1", "data": {"id": "project-1", "type": "gcp_project", "platform_type": "GCP"}, "leaf": true, "parent_id": "org-1"}],
+				"page": {"page_number": 2, "page_size": 100, "is_last_page": true, "total_records": 2}
+			}`,
+		},
+	})
+	defer cleanup()
+
+	service := setupGCPService(client)
+	pages, err := service.ListOrganizationsBy(&ccemodels.IdsecCCEWorkspacesFilter{Services: "dpa, sca"})
+	require.NoError(t, err)
+
+	var workspaces []*ccemodels.IdsecCCEWorkspace
+	pageCount := 0
+	for page := range pages {
+		require.NoError(t, page.Err)
+		workspaces = append(workspaces, page.Items...)
+		pageCount++
+	}
+	require.Equal(t, 2, pageCount)
+	require.Len(t, workspaces, 2)
+	require.Equal(t, "gcp_project", workspaces[1].Data.Type)
+	require.Equal(t, "org-1", workspaces[1].ParentID)
+	require.Equal(t, []string{"dpa", "sca"}, requestedServices)
+}
+
+func TestListOrganizations_Error(t *testing.T) {
+	client, cleanup := internal.SetupMockCCEService(t, []internal.MockEndpointConfig{
+		{
+			Matcher:      func(r *http.Request) bool { return true },
+			StatusCode:   http.StatusInternalServerError,
+			ResponseBody: `{"error": "test error"}`,
+		},
+	})
+	defer cleanup()
+
+	service := setupGCPService(client)
+	pages, err := service.ListOrganizations()
+	require.NoError(t, err)
+
+	var pageErrs []error
+	for page := range pages {
+		require.Empty(t, page.Items)
+		pageErrs = append(pageErrs, page.Err)
+	}
+	require.Len(t, pageErrs, 1)
+	require.Error(t, pageErrs[0])
+	require.Contains(t, pageErrs[0].Error(), "failed to fetch workspaces page 1")
+}
+
+func TestListProjectsBy_FiltersByType(t *testing.T) {
+	var capturedQuery string
+	client, cleanup := internal.SetupMockCCEService(t, []internal.MockEndpointConfig{
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == "GET" && r.URL.Path == "/api/gcp/workspaces"
+			},
+			StatusCode: http.StatusOK,
+			ResponseBody: `{
+				"workspaces": [{"key": "project-123", "data": {"id": "project-123", "type": "gcp_project"}}],
+				"page": {"page_number": 1, "page_size": 100, "is_last_page": true, "total_records": 1}
+			}`,
+			OnRequest: func(r *http.Request) {
+				capturedQuery = r.URL.RawQuery
+			},
+		},
+	})
+	defer cleanup()
+
+	service := setupGCPService(client)
+	pages, err := service.ListProjectsBy(&ccemodels.IdsecCCEWorkspacesFilter{ParentID: "org-onboarding-123"})
+	require.NoError(t, err)
+
+	var keys []string
+	for page := range pages {
+		require.NoError(t, page.Err)
+		for _, workspace := range page.Items {
+			keys = append(keys, workspace.Key)
+		}
+	}
+	require.Equal(t, []string{"project-123"}, keys)
+	require.Contains(t, capturedQuery, "workspace_type=gcp_project")
+	require.Contains(t, capturedQuery, "parent_id=org-onboarding-123")
+}
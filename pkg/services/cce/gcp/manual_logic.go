@@ -0,0 +1,404 @@
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	ccemodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/common/models"
+	gcpmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/gcp/models"
+	cceinternal "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/internal"
+)
+
+// API path constants for GCP manual onboarding
+const (
+	pathManualAddURL             = "/api/gcp/manual"
+	pathManualDeleteURL          = "/api/gcp/manual/%s"
+	pathManualOrganizationGetURL = "/api/gcp/manual/organization/%s"
+	pathManualFolderGetURL       = "/api/gcp/manual/folder/%s"
+	pathManualProjectGetURL      = "/api/gcp/manual/project/%s"
+	pathManualServicesURL        = "/api/gcp/manual/%s/services"
+)
+
+const (
+	requestKeyDeploymentType   = "deploymentType"
+	requestKeyOnboardingType   = "onboardingType"
+	deploymentTypeOrganization = "organization"
+	deploymentTypeFolder       = "folder"
+	deploymentTypeStandalone   = "standalone"
+)
+
+// extractServiceNames extracts the list of service names from an entity JSON response.
+// It assumes all GCP manual entities have a "services" field containing an array of service name strings.
+func extractServiceNames(entityJSON interface{}) []string {
+	var serviceNames []string
+	if entityMap, ok := entityJSON.(map[string]interface{}); ok {
+		if servicesRaw, exists := entityMap["services"]; exists {
+			if servicesList, ok := servicesRaw.([]interface{}); ok {
+				for _, svc := range servicesList {
+					if svcStr, ok := svc.(string); ok {
+						serviceNames = append(serviceNames, svcStr)
+					}
+				}
+			}
+		}
+	}
+	return serviceNames
+}
+
+// structToMap converts a struct to map[string]interface{} using JSON marshaling.
+func structToMap(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal struct: %w", err)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal to map: %w", err)
+	}
+
+	return result, nil
+}
+
+// getManual retrieves a GCP manual onboarding entity from the given URL into a new T.
+func getManual[T any](s *IdsecCCEGCPService, url string, errContext string) (*T, error) {
+	response, err := s.ISPClient().Get(context.Background(), url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer cceinternal.CloseResponseBody(response.Body)
+
+	// Handle non-2xx status codes
+	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
+		return nil, cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, errContext)
+	}
+
+	var entity T
+	err = json.NewDecoder(response.Body).Decode(&entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity, nil
+}
+
+// getManualWithRetry retrieves a GCP manual onboarding entity with retry logic.
+// It attempts to fetch the entity up to 3 times with a delay between attempts.
+func getManualWithRetry[T any](s *IdsecCCEGCPService, url string, resourceType string) (*T, error) {
+	var entity *T
+	err := common.RetryCall(func() error {
+		ent, getErr := getManual[T](s, url, fmt.Sprintf("failed to get %s details", resourceType))
+		if getErr != nil {
+			return getErr
+		}
+		entity = ent
+		return nil
+	}, cceinternal.DefaultMaxRequestRetries, cceinternal.DefaultRetryDelaySeconds, nil, cceinternal.DefaultRetryBackoffMultiplier, 0, func(err error, delay int) {
+		s.Logger.Info("Retrying to get %s in %d seconds: %v", resourceType, delay, err)
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve %s: %w", resourceType, err)
+	}
+
+	return entity, nil
+}
+
+// addManual onboards a GCP entity manually with the given onboarding type and returns its onboarding ID.
+// API: POST /api/gcp/manual
+func (s *IdsecCCEGCPService) addManual(input interface{}, deploymentType string, onboardingType string, resourceType string) (string, error) {
+	// Convert input to map and add the deployment and onboarding types
+	requestBody, err := structToMap(input)
+	if err != nil {
+		return "", err
+	}
+	requestBody[requestKeyDeploymentType] = deploymentType
+	requestBody[requestKeyOnboardingType] = onboardingType
+
+	response, err := s.ISPClient().Post(context.Background(), pathManualAddURL, requestBody)
+	if err != nil {
+		return "", err
+	}
+	defer cceinternal.CloseResponseBody(response.Body)
+
+	// Handle non-2xx status codes
+	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
+		return "", cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, fmt.Sprintf("failed to add %s", resourceType))
+	}
+
+	var addOutput gcpmodels.IdsecCCEGCPAddOutput
+	err = json.NewDecoder(response.Body).Decode(&addOutput)
+	if err != nil {
+		return "", err
+	}
+	return addOutput.ID, nil
+}
+
+// currentManualServices returns the names of the services currently onboarded on a GCP manual entity.
+func (s *IdsecCCEGCPService) currentManualServices(url string, resourceType string) ([]string, error) {
+	response, err := s.ISPClient().Get(context.Background(), url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current %s details: %w", resourceType, err)
+	}
+	defer cceinternal.CloseResponseBody(response.Body)
+
+	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
+		return nil, cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, fmt.Sprintf("failed to get %s details", resourceType))
+	}
+
+	entityJSON, err := common.DeserializeJSONSnake(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize %s response: %w", resourceType, err)
+	}
+	return extractServiceNames(entityJSON), nil
+}
+
+// addOrganization onboards a GCP Organization manually with the given onboarding type and returns its onboarding ID.
+// API: POST /api/gcp/manual
+func (s *IdsecCCEGCPService) addOrganization(input *gcpmodels.IdsecCCEGCPAddOrganization, onboardingType string) (string, error) {
+	s.Logger.Info("Adding GCP Organization with ID [%s]", input.OrganizationID)
+	return s.addManual(input, deploymentTypeOrganization, onboardingType, "Organization")
+}
+
+// organizationWithRetry retrieves GCP Organization details by onboarding ID with retry logic.
+func (s *IdsecCCEGCPService) organizationWithRetry(id string) (*gcpmodels.IdsecCCEGCPOrganization, error) {
+	return getManualWithRetry[gcpmodels.IdsecCCEGCPOrganization](s, fmt.Sprintf(pathManualOrganizationGetURL, id), "Organization")
+}
+
+// organization retrieves GCP Organization details by onboarding ID.
+// API: GET /api/gcp/manual/organization/{id}
+func (s *IdsecCCEGCPService) organization(input *gcpmodels.IdsecCCEGCPGetOrganization) (*gcpmodels.IdsecCCEGCPOrganization, error) {
+	s.Logger.Info("Getting GCP Organization details for ID [%s]", input.ID)
+	return getManual[gcpmodels.IdsecCCEGCPOrganization](s, fmt.Sprintf(pathManualOrganizationGetURL, input.ID), "failed to get Organization details")
+}
+
+// updateOrganization reconciles the services of a GCP Organization with the desired services.
+// API: POST/DELETE /api/gcp/manual/{id}/services
+func (s *IdsecCCEGCPService) updateOrganization(input *gcpmodels.IdsecCCEGCPUpdateOrganization) (*gcpmodels.IdsecCCEGCPOrganization, error) {
+	s.Logger.Info("Updating GCP Organization [%s]", input.ID)
+	url := fmt.Sprintf(pathManualOrganizationGetURL, input.ID)
+	currentServiceNames, err := s.currentManualServices(url, "Organization")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.updateManualServices(input.ID, currentServiceNames, input.Services, "organization"); err != nil {
+		return nil, err
+	}
+	organization, err := s.organizationWithRetry(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("organization updated with ID %s, but failed to fetch details: %w", input.ID, err)
+	}
+	return organization, nil
+}
+
+// deleteOrganization deletes a GCP Organization.
+// API: DELETE /api/gcp/manual/{id}
+func (s *IdsecCCEGCPService) deleteOrganization(input *gcpmodels.IdsecCCEGCPDeleteOrganization) error {
+	s.Logger.Info("Deleting GCP Organization [%s]", input.ID)
+	return s.deleteManual(input.ID)
+}
+
+// addFolder onboards a GCP Folder manually with the given onboarding type and returns its onboarding ID.
+// API: POST /api/gcp/manual
+func (s *IdsecCCEGCPService) addFolder(input *gcpmodels.IdsecCCEGCPAddFolder, onboardingType string) (string, error) {
+	s.Logger.Info("Adding GCP Folder with ID [%s]", input.FolderID)
+	return s.addManual(input, deploymentTypeFolder, onboardingType, "Folder")
+}
+
+// folderWithRetry retrieves GCP Folder details by onboarding ID with retry logic.
+func (s *IdsecCCEGCPService) folderWithRetry(id string) (*gcpmodels.IdsecCCEGCPFolder, error) {
+	return getManualWithRetry[gcpmodels.IdsecCCEGCPFolder](s, fmt.Sprintf(pathManualFolderGetURL, id), "Folder")
+}
+
+// folder retrieves GCP Folder details by onboarding ID.
+// API: GET /api/gcp/manual/folder/{id}
+func (s *IdsecCCEGCPService) folder(input *gcpmodels.IdsecCCEGCPGetFolder) (*gcpmodels.IdsecCCEGCPFolder, error) {
+	s.Logger.Info("Getting GCP Folder details for ID [%s]", input.ID)
+	return getManual[gcpmodels.IdsecCCEGCPFolder](s, fmt.Sprintf(pathManualFolderGetURL, input.ID), "failed to get Folder details")
+}
+
+// updateFolder reconciles the services of a GCP Folder with the desired services.
+// API: POST/DELETE /api/gcp/manual/{id}/services
+func (s *IdsecCCEGCPService) updateFolder(input *gcpmodels.IdsecCCEGCPUpdateFolder) (*gcpmodels.IdsecCCEGCPFolder, error) {
+	s.Logger.Info("Updating GCP Folder [%s]", input.ID)
+	url := fmt.Sprintf(pathManualFolderGetURL, input.ID)
+	currentServiceNames, err := s.currentManualServices(url, "Folder")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.updateManualServices(input.ID, currentServiceNames, input.Services, "folder"); err != nil {
+		return nil, err
+	}
+	folder, err := s.folderWithRetry(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("folder updated with ID %s, but failed to fetch details: %w", input.ID, err)
+	}
+	return folder, nil
+}
+
+// deleteFolder deletes a GCP Folder.
+// API: DELETE /api/gcp/manual/{id}
+func (s *IdsecCCEGCPService) deleteFolder(input *gcpmodels.IdsecCCEGCPDeleteFolder) error {
+	s.Logger.Info("Deleting GCP Folder [%s]", input.ID)
+	return s.deleteManual(input.ID)
+}
+
+// addProject onboards a GCP Project manually with the given onboarding type and returns its onboarding ID.
+// API: POST /api/gcp/manual
+func (s *IdsecCCEGCPService) addProject(input *gcpmodels.IdsecCCEGCPAddProject, onboardingType string) (string, error) {
+	s.Logger.Info("Adding GCP Project with ID [%s]", input.ProjectID)
+	return s.addManual(input, deploymentTypeStandalone, onboardingType, "Project")
+}
+
+// projectWithRetry retrieves GCP Project details by onboarding ID with retry logic.
+func (s *IdsecCCEGCPService) projectWithRetry(id string) (*gcpmodels.IdsecCCEGCPProject, error) {
+	return getManualWithRetry[gcpmodels.IdsecCCEGCPProject](s, fmt.Sprintf(pathManualProjectGetURL, id), "Project")
+}
+
+// project retrieves GCP Project details by onboarding ID.
+// API: GET /api/gcp/manual/project/{id}
+func (s *IdsecCCEGCPService) project(input *gcpmodels.IdsecCCEGCPGetProject) (*gcpmodels.IdsecCCEGCPProject, error) {
+	s.Logger.Info("Getting GCP Project details for ID [%s]", input.ID)
+	return getManual[gcpmodels.IdsecCCEGCPProject](s, fmt.Sprintf(pathManualProjectGetURL, input.ID), "failed to get Project details")
+}
+
+// updateProject reconciles the services of a GCP Project with the desired services.
+// API: POST/DELETE /api/gcp/manual/{id}/services
+func (s *IdsecCCEGCPService) updateProject(input *gcpmodels.IdsecCCEGCPUpdateProject) (*gcpmodels.IdsecCCEGCPProject, error) {
+	s.Logger.Info("Updating GCP Project [%s]", input.ID)
+	url := fmt.Sprintf(pathManualProjectGetURL, input.ID)
+	currentServiceNames, err := s.currentManualServices(url, "Project")
+	if err != nil {
+		return nil, err
+	}
+	if err := s.updateManualServices(input.ID, currentServiceNames, input.Services, "project"); err != nil {
+		return nil, err
+	}
+	project, err := s.projectWithRetry(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("project updated with ID %s, but failed to fetch details: %w", input.ID, err)
+	}
+	return project, nil
+}
+
+// deleteProject deletes a GCP Project.
+// API: DELETE /api/gcp/manual/{id}
+func (s *IdsecCCEGCPService) deleteProject(input *gcpmodels.IdsecCCEGCPDeleteProject) error {
+	s.Logger.Info("Deleting GCP Project [%s]", input.ID)
+	return s.deleteManual(input.ID)
+}
+
+// updateManualServices updates services for a GCP manual onboarding by reconciling service changes.
+// Services in desiredServices that are not currently onboarded are added, and
+// currently onboarded services that are not in desiredServices are removed.
+func (s *IdsecCCEGCPService) updateManualServices(id string, currentServiceNames []string, desiredServices []ccemodels.IdsecCCEServiceInput, resourceType string) error {
+	s.Logger.Info("Updating services for GCP %s [%s]", resourceType, id)
+
+	desiredServicesMap := make(map[string]ccemodels.IdsecCCEServiceInput)
+	for _, service := range desiredServices {
+		desiredServicesMap[service.ServiceName] = service
+	}
+	currentServices := make(map[string]bool)
+	for _, serviceName := range currentServiceNames {
+		currentServices[serviceName] = true
+	}
+
+	var servicesToAdd []ccemodels.IdsecCCEServiceInput
+	for _, service := range desiredServices {
+		if !currentServices[service.ServiceName] {
+			servicesToAdd = append(servicesToAdd, service)
+			s.Logger.Info("Service '%s' will be ADDED", service.ServiceName)
+		}
+	}
+	var servicesToRemove []string
+	for _, serviceName := range currentServiceNames {
+		if _, exists := desiredServicesMap[serviceName]; !exists {
+			servicesToRemove = append(servicesToRemove, serviceName)
+			s.Logger.Info("Service '%s' will be REMOVED", serviceName)
+		}
+	}
+
+	if len(servicesToAdd) > 0 {
+		s.Logger.Info("Adding %d services to %s [%s]", len(servicesToAdd), resourceType, id)
+		if err := s.addManualServices(id, servicesToAdd); err != nil {
+			return fmt.Errorf("failed to add services: %w", err)
+		}
+	}
+	if len(servicesToRemove) > 0 {
+		s.Logger.Info("Removing %d services from %s [%s]", len(servicesToRemove), resourceType, id)
+		if err := s.deleteManualServices(id, servicesToRemove); err != nil {
+			return fmt.Errorf("failed to remove services: %w", err)
+		}
+	}
+	return nil
+}
+
+// addManualServices adds services to a GCP manual onboarding.
+// API: POST /api/gcp/manual/{id}/services
+func (s *IdsecCCEGCPService) addManualServices(id string, services []ccemodels.IdsecCCEServiceInput) error {
+	s.Logger.Info("Adding services to GCP manual onboarding [%s]", id)
+
+	url := fmt.Sprintf(pathManualServicesURL, id)
+	requestBody := map[string]interface{}{
+		"services": services,
+	}
+
+	response, err := s.ISPClient().Post(context.Background(), url, requestBody)
+	if err != nil {
+		return err
+	}
+	defer cceinternal.CloseResponseBody(response.Body)
+
+	// Handle non-2xx status codes
+	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
+		return cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, "failed to add services")
+	}
+
+	return nil
+}
+
+// deleteManualServices removes services from a GCP manual onboarding.
+// API: DELETE /api/gcp/manual/{id}/services
+func (s *IdsecCCEGCPService) deleteManualServices(id string, serviceNames []string) error {
+	s.Logger.Info("Deleting services: %v from GCP manual onboarding [%s]", serviceNames, id)
+
+	// The API expects multiple services_names query params like: services_names=dpa&services_names=sca
+	params := map[string][]string{
+		"services_names": serviceNames,
+	}
+
+	response, err := s.ISPClient().Delete(context.Background(), fmt.Sprintf(pathManualServicesURL, id), nil, params)
+	if err != nil {
+		return fmt.Errorf("failed to delete services from GCP manual onboarding: %w", err)
+	}
+	defer cceinternal.CloseResponseBody(response.Body)
+
+	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
+		bodyBytes, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("failed to delete services from GCP manual onboarding: status code %d, body: %s", response.StatusCode, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// deleteManual deletes a GCP manual onboarding.
+// API: DELETE /api/gcp/manual/{id}
+func (s *IdsecCCEGCPService) deleteManual(id string) error {
+	s.Logger.Info("Deleting GCP manual onboarding [%s]", id)
+
+	response, err := s.ISPClient().Delete(context.Background(), fmt.Sprintf(pathManualDeleteURL, id), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer cceinternal.CloseResponseBody(response.Body)
+
+	// Handle non-2xx status codes
+	if !cceinternal.IsHTTPSuccess(response.StatusCode) {
+		return cceinternal.HandleNon2xxResponse(s.Logger, response.StatusCode, response.Body, "failed to delete manual onboarding")
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	ccemodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/common/models"
+)
+
+// IdsecCCEGCPAddOutput is the output returned after adding a GCP manual onboarding.
+// OPENAPI-CORRELATION: GcpProgrammaticGeneralOnboardOutput
+type IdsecCCEGCPAddOutput struct {
+	// ID is the onboarding ID for the created resource.
+	ID string `json:"id" mapstructure:"id" desc:"CCE onboarding ID for the created resource."`
+}
+
+// IdsecCCEGCPAddManualServices is the input for adding services to a GCP manual onboarding.
+// OPENAPI-CORRELATION: GcpProgrammaticAddServicesBodyInput + path parameter
+type IdsecCCEGCPAddManualServices struct {
+	// ID is the onboarding ID.
+	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE onboarding ID."`
+	// Services is the list of services to add with their resource configurations.
+	Services []ccemodels.IdsecCCEServiceInput `json:"services" mapstructure:"services" validate:"required,min=1,dive" desc:"List of services to add (SIA, SCA, SecretsHub, CDS) and their associated resources."`
+}
+
+// IdsecCCEGCPDeleteManualServices is the input for deleting services from a GCP manual onboarding.
+// OPENAPI-CORRELATION: Input for DELETE /api/gcp/manual/{id}/services
+type IdsecCCEGCPDeleteManualServices struct {
+	// ID is the onboarding ID.
+	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE onboarding ID"`
+	// ServiceNames is the list of service names to remove (e.g., ["dpa", "sca"]).
+	ServiceNames []string `json:"serviceNames" mapstructure:"service_names" validate:"required,min=1" desc:"List of services to remove (SIA, SCA, SecretsHub, CDS)."`
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	ccemodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/common/models"
+)
+
+// IdsecCCEGCPAddFolder is the input for onboarding a GCP Folder manually.
+// OPENAPI-CORRELATION: GcpProgrammaticGeneralOnboardInput
+type IdsecCCEGCPAddFolder struct {
+	OrganizationID string                           `json:"organizationId" mapstructure:"organization_id" validate:"required" desc:"GCP organization ID the folder belongs to."`
+	FolderID       string                           `json:"id" mapstructure:"folder_id" validate:"required" desc:"GCP folder ID."`
+	FolderName     string                           `json:"folderName" mapstructure:"folder_name" validate:"required" desc:"GCP folder display name."`
+	Services       []ccemodels.IdsecCCEServiceInput `json:"services" mapstructure:"services" validate:"required,min=1,dive" desc:"List of services to add (SIA, SCA, SecretsHub, CDS) and their associated resources."`
+	CCEResources   map[string]interface{}           `json:"cceResources,omitempty" mapstructure:"cce_resources,omitempty" desc:"CCE resources."`
+}
+
+// IdsecCCEGCPFolder represents the details of a GCP Folder.
+// OPENAPI-CORRELATION: GcpGetFolderDetailsOutput
+type IdsecCCEGCPFolder struct {
+	ID             string                            `json:"id" mapstructure:"id" desc:"CCE folder onboarding ID."`
+	OnboardingType string                            `json:"onboardingType" mapstructure:"onboarding_type" desc:"Onboarding type: standard (UI), programmatic (API), or terraform_provider." possible_values:"standard,programmatic,terraform_provider."`
+	Region         string                            `json:"region" mapstructure:"region" desc:"The region where CCE resources are deployed."`
+	DisplayName    string                            `json:"displayName,omitempty" mapstructure:"display_name,omitempty" desc:"Display name shown in the CCE UI."`
+	Parameters     map[string]map[string]interface{} `json:"parameters,omitempty" mapstructure:"parameters,omitempty" desc:"A key-value map of service-specific configuration parameters, keyed by service name."`
+	Status         string                            `json:"status" mapstructure:"status" desc:"Onboarding status (for example, Completely added, Partially added, Failed to add)."`
+	OrganizationID string                            `json:"organizationId,omitempty" mapstructure:"organization_id,omitempty" desc:"GCP organization ID."`
+	FolderID       string                            `json:"folderId" mapstructure:"folder_id" desc:"GCP folder ID."`
+	FolderName     string                            `json:"folderName,omitempty" mapstructure:"folder_name,omitempty" desc:"GCP folder display name."`
+}
+
+// IdsecCCEGCPGetFolder is the input for getting GCP Folder details.
+// OPENAPI-CORRELATION: Input for GET /api/gcp/manual/folder/{id}
+type IdsecCCEGCPGetFolder struct {
+	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE folder onboarding ID."`
+}
+
+// IdsecCCEGCPUpdateFolder is the input for updating a GCP Folder's services.
+// Services missing from the input are removed from the onboarded resource.
+// OPENAPI-CORRELATION: Custom input combining multiple endpoints
+type IdsecCCEGCPUpdateFolder struct {
+	// ID is the Folder's onboarding ID.
+	ID string `json:"id,omitempty" mapstructure:"id,omitempty" validate:"required" desc:"CCE folder onboarding ID."`
+	// Services is the list of services to onboard (e.g., DPA, SCA, SecretsHub, CDS) with their resource configurations.
+	Services []ccemodels.IdsecCCEServiceInput `json:"services" mapstructure:"services" validate:"required,min=1,dive" desc:"List of services to add (SIA, SCA, SecretsHub, CDS) and their associated resources."`
+}
+
+// IdsecCCEGCPDeleteFolder is the input for deleting a GCP Folder.
+// OPENAPI-CORRELATION: Input for DELETE /api/gcp/manual/{id}
+type IdsecCCEGCPDeleteFolder struct {
+	// ID is the Folder's onboarding ID.
+	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE folder onboarding ID."`
+}
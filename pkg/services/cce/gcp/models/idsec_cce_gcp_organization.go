@@ -0,0 +1,50 @@
+package models
+
+import (
+	ccemodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/common/models"
+)
+
+// IdsecCCEGCPAddOrganization is the input for onboarding a GCP Organization manually.
+// OPENAPI-CORRELATION: GcpProgrammaticGeneralOnboardInput
+type IdsecCCEGCPAddOrganization struct {
+	OrganizationID   string                           `json:"id" mapstructure:"organization_id" validate:"required" desc:"GCP organization ID."`
+	OrganizationName string                           `json:"organizationName" mapstructure:"organization_name" validate:"required" desc:"GCP organization display name."`
+	Services         []ccemodels.IdsecCCEServiceInput `json:"services" mapstructure:"services" validate:"required,min=1,dive" desc:"List of services to add (SIA, SCA, SecretsHub, CDS) and their associated resources."`
+	CCEResources     map[string]interface{}           `json:"cceResources,omitempty" mapstructure:"cce_resources,omitempty" desc:"CCE resources."`
+}
+
+// IdsecCCEGCPOrganization represents the details of a GCP Organization.
+// OPENAPI-CORRELATION: GcpGetOrganizationDetailsOutput
+type IdsecCCEGCPOrganization struct {
+	ID               string                            `json:"id" mapstructure:"id" desc:"CCE organization onboarding ID."`
+	OnboardingType   string                            `json:"onboardingType" mapstructure:"onboarding_type" desc:"Onboarding type: standard (UI), programmatic (API), or terraform_provider." possible_values:"standard,programmatic,terraform_provider."`
+	Region           string                            `json:"region" mapstructure:"region" desc:"The region where CCE resources are deployed."`
+	DisplayName      string                            `json:"displayName,omitempty" mapstructure:"display_name,omitempty" desc:"Display name shown in the CCE UI."`
+	Parameters       map[string]map[string]interface{} `json:"parameters,omitempty" mapstructure:"parameters,omitempty" desc:"A key-value map of service-specific configuration parameters, keyed by service name."`
+	Status           string                            `json:"status" mapstructure:"status" desc:"Onboarding status (for example, Completely added, Partially added, Failed to add)."`
+	OrganizationID   string                            `json:"organizationId" mapstructure:"organization_id" desc:"GCP organization ID."`
+	OrganizationName string                            `json:"organizationName,omitempty" mapstructure:"organization_name,omitempty" desc:"GCP organization display name."`
+}
+
+// IdsecCCEGCPGetOrganization is the input for getting GCP Organization details.
+// OPENAPI-CORRELATION: Input for GET /api/gcp/manual/organization/{id}
+type IdsecCCEGCPGetOrganization struct {
+	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE organization onboarding ID."`
+}
+
+// IdsecCCEGCPUpdateOrganization is the input for updating a GCP Organization's services.
+// Services missing from the input are removed from the onboarded resource.
+// OPENAPI-CORRELATION: Custom input combining multiple endpoints
+type IdsecCCEGCPUpdateOrganization struct {
+	// ID is the Organization's onboarding ID.
+	ID string `json:"id,omitempty" mapstructure:"id,omitempty" validate:"required" desc:"CCE organization onboarding ID."`
+	// Services is the list of services to onboard (e.g., DPA, SCA, SecretsHub, CDS) with their resource configurations.
+	Services []ccemodels.IdsecCCEServiceInput `json:"services" mapstructure:"services" validate:"required,min=1,dive" desc:"List of services to add (SIA, SCA, SecretsHub, CDS) and their associated resources."`
+}
+
+// IdsecCCEGCPDeleteOrganization is the input for deleting a GCP Organization.
+// OPENAPI-CORRELATION: Input for DELETE /api/gcp/manual/{id}
+type IdsecCCEGCPDeleteOrganization struct {
+	// ID is the Organization's onboarding ID.
+	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE organization onboarding ID."`
+}
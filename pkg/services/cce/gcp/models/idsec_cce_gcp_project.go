@@ -0,0 +1,54 @@
+package models
+
+import (
+	ccemodels "github.com/cyberark/idsec-sdk-golang/pkg/services/cce/common/models"
+)
+
+// IdsecCCEGCPAddProject is the input for onboarding a GCP Project manually.
+// OPENAPI-CORRELATION: GcpProgrammaticGeneralOnboardInput
+type IdsecCCEGCPAddProject struct {
+	ProjectID      string                           `json:"id" mapstructure:"project_id" validate:"required" desc:"GCP project ID."`
+	ProjectNumber  string                           `json:"projectNumber" mapstructure:"project_number" validate:"required" desc:"GCP project number."`
+	ProjectName    string                           `json:"projectName" mapstructure:"project_name" validate:"required" desc:"GCP project display name."`
+	OrganizationID string                           `json:"organizationId,omitempty" mapstructure:"organization_id,omitempty" desc:"GCP organization ID the project belongs to, if any."`
+	Services       []ccemodels.IdsecCCEServiceInput `json:"services" mapstructure:"services" validate:"required,min=1,dive" desc:"List of services to add (SIA, SCA, SecretsHub, CDS) and their associated resources."`
+}
+
+// IdsecCCEGCPProject represents the details of a GCP Project.
+// OPENAPI-CORRELATION: GcpGetProjectDetailsOutput
+type IdsecCCEGCPProject struct {
+	ID             string                            `json:"id" mapstructure:"id" desc:"CCE project onboarding ID."`
+	OnboardingType string                            `json:"onboardingType" mapstructure:"onboarding_type" desc:"Onboarding type: standard (UI), programmatic (API), or terraform_provider." possible_values:"standard,programmatic,terraform_provider."`
+	Region         string                            `json:"region" mapstructure:"region" desc:"The region where CCE resources are deployed."`
+	DisplayName    string                            `json:"displayName,omitempty" mapstructure:"display_name,omitempty" desc:"Display name shown in the CCE UI."`
+	Parameters     map[string]map[string]interface{} `json:"parameters,omitempty" mapstructure:"parameters,omitempty" desc:"A key-value map of service-specific configuration parameters, keyed by service name."`
+	Status         string                            `json:"status" mapstructure:"status" desc:"Onboarding status (for example, Completely added, Partially added, Failed to add)."`
+	ProjectID      string                            `json:"projectId" mapstructure:"project_id" desc:"GCP project ID."`
+	ProjectNumber  string                            `json:"projectNumber,omitempty" mapstructure:"project_number,omitempty" desc:"GCP project number."`
+	ProjectName    string                            `json:"projectName,omitempty" mapstructure:"project_name,omitempty" desc:"GCP project display name."`
+	OrganizationID string                            `json:"organizationId,omitempty" mapstructure:"organization_id,omitempty" desc:"GCP organization ID."`
+	FolderID       string                            `json:"folderId,omitempty" mapstructure:"folder_id,omitempty" desc:"GCP folder ID."`
+}
+
+// IdsecCCEGCPGetProject is the input for getting GCP Project details.
+// OPENAPI-CORRELATION: Input for GET /api/gcp/manual/project/{id}
+type IdsecCCEGCPGetProject struct {
+	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE project onboarding ID."`
+}
+
+// IdsecCCEGCPUpdateProject is the input for updating a GCP Project's services.
+// Services missing from the input are removed from the onboarded resource.
+// OPENAPI-CORRELATION: Custom input combining multiple endpoints
+type IdsecCCEGCPUpdateProject struct {
+	// ID is the Project's onboarding ID.
+	ID string `json:"id,omitempty" mapstructure:"id,omitempty" validate:"required" desc:"CCE project onboarding ID."`
+	// Services is the list of services to onboard (e.g., DPA, SCA, SecretsHub, CDS) with their resource configurations.
+	Services []ccemodels.IdsecCCEServiceInput `json:"services" mapstructure:"services" validate:"required,min=1,dive" desc:"List of services to add (SIA, SCA, SecretsHub, CDS) and their associated resources."`
+}
+
+// IdsecCCEGCPDeleteProject is the input for deleting a GCP Project.
+// OPENAPI-CORRELATION: Input for DELETE /api/gcp/manual/{id}
+type IdsecCCEGCPDeleteProject struct {
+	// ID is the Project's onboarding ID.
+	ID string `json:"id" mapstructure:"id" validate:"required" desc:"CCE project onboarding ID."`
+}
@@ -0,0 +1,8 @@
+package models
+
+// Possible GCP workspace types.
+const (
+	WorkspaceTypeOrganization = "gcp_organization"
+	WorkspaceTypeFolder       = "gcp_folder"
+	WorkspaceTypeProject      = "gcp_project"
+)
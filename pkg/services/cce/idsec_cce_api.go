@@ -4,12 +4,14 @@ import (
 	"github.com/cyberark/idsec-sdk-golang/pkg/auth"
 	"github.com/cyberark/idsec-sdk-golang/pkg/services/cce/aws"
 	"github.com/cyberark/idsec-sdk-golang/pkg/services/cce/azure"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/cce/gcp"
 )
 
 // IdsecCCEAPI is a struct that provides access to the CCE API as a wrapped set of services.
 type IdsecCCEAPI struct {
 	awsService   *aws.IdsecCCEAWSService
 	azureService *azure.IdsecCCEAzureService
+	gcpService   *gcp.IdsecCCEGCPService
 }
 
 // NewIdsecCCEAPI creates a new instance of IdsecCCEAPI with the provided IdsecISPAuth.
@@ -23,9 +25,14 @@ func NewIdsecCCEAPI(ispAuth *auth.IdsecISPAuth) (*IdsecCCEAPI, error) {
 	if err != nil {
 		return nil, err
 	}
+	gcpService, err := gcp.NewIdsecCCEGCPService(baseIspAuth)
+	if err != nil {
+		return nil, err
+	}
 	return &IdsecCCEAPI{
 		awsService:   awsService,
 		azureService: azureService,
+		gcpService:   gcpService,
 	}, nil
 }
 
@@ -38,3 +45,8 @@ func (api *IdsecCCEAPI) AWS() *aws.IdsecCCEAWSService {
 func (api *IdsecCCEAPI) Azure() *azure.IdsecCCEAzureService {
 	return api.azureService
 }
+
+// GCP returns the GCP service of the IdsecCCEAPI instance.
+func (api *IdsecCCEAPI) GCP() *gcp.IdsecCCEGCPService {
+	return api.gcpService
+}
@@ -8,4 +8,5 @@ var ActionToSchemaMap = map[string]interface{}{
 	"update": &accountsmodels.IdsecPamshUpdateAccount{},
 	"delete": &accountsmodels.IdsecPamshDeleteAccount{},
 	"get":    &accountsmodels.IdsecPamshGetAccount{},
+	"import": &accountsmodels.IdsecPamshImportAccounts{},
 }
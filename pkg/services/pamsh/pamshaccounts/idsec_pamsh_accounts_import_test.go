@@ -0,0 +1,134 @@
+package pamshaccounts
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/accountsimport"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pamsh/pamshaccounts/internal"
+	accountsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pamsh/pamshaccounts/models"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pamsh/pamshsafes"
+)
+
+func TestImport_creates_missing_safe_and_accounts(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var safeCreates int
+	var accountBodies []map[string]interface{}
+	parts, cleanup := internal.SetupMockPVWAServiceParts(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/PasswordVault/API/Safes":
+			_, _ = fmt.Fprint(w, `{"value":[],"count":0}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/PasswordVault/API/Safes":
+			mu.Lock()
+			safeCreates++
+			mu.Unlock()
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprint(w, `{"safeUrlId":"linux-safe","safeName":"linux-safe"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/PasswordVault/API/Accounts":
+			body := map[string]interface{}{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			mu.Lock()
+			accountBodies = append(accountBodies, body)
+			mu.Unlock()
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"id":"id-%s","name":%q,"safeName":"linux-safe"}`, body["username"], fmt.Sprint(body["name"]))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(cleanup)
+	svc := newTestPamshAccountsService(parts)
+	svc.safesService = &pamshsafes.IdsecPamshSafesService{
+		IdsecBaseService:     parts.BaseService,
+		IdsecPVWABaseService: parts.PVWABase,
+	}
+
+	dir := t.TempDir()
+	inputFile := filepath.Join(dir, "accounts.csv")
+	resultsFile := filepath.Join(dir, "results.csv")
+	require.NoError(t, os.WriteFile(inputFile, []byte(
+		"Safe,username,address,platform_id,platform_account_properties.LogonDomain\n"+
+			"linux-safe,root,10.0.0.1,UnixSSH,corp\n"+
+			"linux-safe,admin,10.0.0.2,UnixSSH,\n"+
+			",orphan,10.0.0.3,UnixSSH,\n"), 0o600))
+
+	result, err := svc.Import(&accountsmodels.IdsecPamshImportAccounts{
+		InputFile:          inputFile,
+		ResultsFile:        resultsFile,
+		ColumnMapping:      map[string]string{"Safe": "safe_name"},
+		CreateMissingSafes: true,
+		Concurrency:        2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, result.Total)
+	require.Equal(t, 2, result.Created)
+	require.Equal(t, 1, result.Invalid)
+	require.Equal(t, 1, safeCreates, "the missing safe is created once")
+	require.Len(t, accountBodies, 2)
+	for _, body := range accountBodies {
+		if body["address"] == "10.0.0.1" {
+			require.Equal(t, map[string]interface{}{"logonDomain": "corp"}, body["platformAccountProperties"])
+		}
+	}
+	require.Equal(t, accountsimport.StatusCreated, result.Rows[0].Status)
+	require.Equal(t, "id-root", result.Rows[0].AccountID)
+	require.Equal(t, accountsimport.StatusInvalid, result.Rows[2].Status)
+
+	written, err := os.ReadFile(resultsFile)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(written), "row,safe_name,username,address,account_id,status,error\n1,linux-safe,root,10.0.0.1,id-root,created,\n"))
+}
+
+func TestImport_does_not_create_safe_when_lookup_fails(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var safeCreates, accountCreates int
+	parts, cleanup := internal.SetupMockPVWAServiceParts(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/PasswordVault/API/Safes":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = fmt.Fprint(w, `{"ErrorCode":"PASWS013E","ErrorMessage":"Access denied"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/PasswordVault/API/Safes":
+			safeCreates++
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPost && r.URL.Path == "/PasswordVault/API/Accounts":
+			accountCreates++
+			w.WriteHeader(http.StatusCreated)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(cleanup)
+	svc := newTestPamshAccountsService(parts)
+	svc.safesService = &pamshsafes.IdsecPamshSafesService{
+		IdsecBaseService:     parts.BaseService,
+		IdsecPVWABaseService: parts.PVWABase,
+	}
+
+	inputFile := filepath.Join(t.TempDir(), "accounts.csv")
+	require.NoError(t, os.WriteFile(inputFile, []byte("safe_name,username,address,platform_id\nlinux-safe,root,10.0.0.1,UnixSSH\n"), 0o600))
+
+	result, err := svc.Import(&accountsmodels.IdsecPamshImportAccounts{
+		InputFile:          inputFile,
+		CreateMissingSafes: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Failed)
+	require.Contains(t, result.Rows[0].Error, "failed to ensure safe [linux-safe]")
+	require.Contains(t, result.Rows[0].Error, "403")
+	require.Zero(t, safeCreates)
+	require.Zero(t, accountCreates)
+}
@@ -2,15 +2,19 @@ package pamshaccounts
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 
 	"github.com/cyberark/idsec-sdk-golang/pkg/auth"
 	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/accountsimport"
 	"github.com/cyberark/idsec-sdk-golang/pkg/services"
 	pamshinternal "github.com/cyberark/idsec-sdk-golang/pkg/services/pamsh/internal"
 	accountsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pamsh/pamshaccounts/models"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pamsh/pamshsafes"
+	safesmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pamsh/pamshsafes/models"
 )
 
 // API endpoint paths for account-related operations
@@ -26,6 +30,8 @@ type pamshAccountsPage = common.IdsecPage[accountsmodels.IdsecPamshAccount]
 type IdsecPamshAccountsService struct {
 	*services.IdsecBaseService
 	*services.IdsecPVWABaseService
+
+	safesService *pamshsafes.IdsecPamshSafesService
 }
 
 // NewIdsecPamshAccountsService creates a new IdsecPamshAccountsService.
@@ -238,6 +244,54 @@ func (s *IdsecPamshAccountsService) Create(addAccount *accountsmodels.IdsecPamsh
 	return account, nil
 }
 
+// Import bulk onboards accounts from a CSV or JSON Lines file.
+// Each row is mapped onto IdsecPamshAddAccount, validated and created with bounded concurrency.
+// Rows that fail do not stop the import; their errors are reported in the result and the optional results file.
+func (s *IdsecPamshAccountsService) Import(importAccounts *accountsmodels.IdsecPamshImportAccounts) (*accountsimport.Result, error) {
+	s.Logger.Info("Importing accounts from [%s]", importAccounts.InputFile)
+	target := accountsimport.Target[accountsmodels.IdsecPamshAddAccount]{
+		EnsureSafe: s.ensureSafe,
+		Create: func(addAccount *accountsmodels.IdsecPamshAddAccount) (string, error) {
+			account, err := s.Create(addAccount)
+			if err != nil {
+				return "", err
+			}
+			return account.AccountID, nil
+		},
+	}
+	result, err := accountsimport.ImportFile(importAccounts.InputFile, importAccounts.Format, importAccounts.ResultsFile, target, accountsimport.Options{
+		ColumnMapping:      importAccounts.ColumnMapping,
+		Concurrency:        importAccounts.Concurrency,
+		CreateMissingSafes: importAccounts.CreateMissingSafes,
+	})
+	if err != nil {
+		return result, err
+	}
+	s.Logger.Info("Imported accounts - [%d] created, [%d] failed, [%d] invalid", result.Created, result.Failed, result.Invalid)
+	return result, nil
+}
+
+// ensureSafe creates the safe when it does not exist. Any other failure to retrieve it is returned.
+func (s *IdsecPamshAccountsService) ensureSafe(safeName string) error {
+	if s.safesService == nil {
+		safesService, err := pamshsafes.NewIdsecPamshSafesService(s.PVWAAuth())
+		if err != nil {
+			return err
+		}
+		s.safesService = safesService
+	}
+	_, err := s.safesService.Get(&safesmodels.IdsecPamshGetSafe{SafeName: safeName})
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, pamshsafes.ErrSafeNotFound) {
+		return err
+	}
+	s.Logger.Info("Safe [%s] not found, creating it", safeName)
+	_, err = s.safesService.Create(&safesmodels.IdsecPamshAddSafe{SafeName: safeName})
+	return err
+}
+
 // Update updates an existing IdsecPamshAccount.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/SDK/UpdateAccount%20v10.htm
 func (s *IdsecPamshAccountsService) Update(updateAccount *accountsmodels.IdsecPamshUpdateAccount) (*accountsmodels.IdsecPamshAccount, error) {
@@ -0,0 +1,11 @@
+package models
+
+// IdsecPamshImportAccounts represents the details required to bulk onboard accounts from a CSV or JSON Lines file.
+type IdsecPamshImportAccounts struct {
+	InputFile          string            `json:"input_file" mapstructure:"input_file" desc:"The CSV or JSON Lines file containing one account per row" flag:"input-file" validate:"required"`
+	Format             string            `json:"format,omitempty" mapstructure:"format,omitempty" desc:"The input format, inferred from the file extension when empty (csv,jsonl)" flag:"format" choices:"csv,jsonl"`
+	ColumnMapping      map[string]string `json:"column_mapping,omitempty" mapstructure:"column_mapping,omitempty" desc:"Maps input columns to account fields, e.g. Host=address or Port=platform_account_properties.Port. Other columns must be account fields or platform account properties prefixed with platform_account_properties., e.g. platform_account_properties.Port" flag:"column-mapping"`
+	ResultsFile        string            `json:"results_file,omitempty" mapstructure:"results_file,omitempty" desc:"The CSV file to write the per-row results to" flag:"results-file"`
+	CreateMissingSafes bool              `json:"create_missing_safes,omitempty" mapstructure:"create_missing_safes,omitempty" desc:"Whether to create safes referenced by the input that do not exist" flag:"create-missing-safes" default:"false"`
+	Concurrency        int               `json:"concurrency,omitempty" mapstructure:"concurrency,omitempty" desc:"The maximum number of accounts created concurrently" flag:"concurrency" default:"5" validate:"omitempty,min=1"`
+}
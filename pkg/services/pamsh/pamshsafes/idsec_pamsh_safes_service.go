@@ -2,6 +2,7 @@ package pamshsafes
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -22,6 +23,9 @@ const (
 	safeMemberURL  = "/PasswordVault/API/Safes/%s/Members/%s/"
 )
 
+// ErrSafeNotFound is returned, wrapped, by Get when the requested safe does not exist.
+var ErrSafeNotFound = errors.New("safe not found")
+
 type pamshSafesPage = common.IdsecPage[safesmodels.IdsecPamshSafe]
 
 // IdsecPamshSafesService manages PAM self-hosted safes using PVWA-authenticated REST.
@@ -124,7 +128,7 @@ func (s *IdsecPamshSafesService) Get(getSafe *safesmodels.IdsecPamshGetSafe) (*s
 			}
 		}
 		if getSafe.SafeID == "" {
-			return nil, fmt.Errorf("safe with name '%s' not found", getSafe.SafeName)
+			return nil, fmt.Errorf("%w: no safe is named '%s'", ErrSafeNotFound, getSafe.SafeName)
 		}
 	}
 	response, err := s.PVWAClient().Get(context.Background(), fmt.Sprintf(safeURL, getSafe.SafeID), nil)
@@ -137,6 +141,9 @@ func (s *IdsecPamshSafesService) Get(getSafe *safesmodels.IdsecPamshGetSafe) (*s
 			common.GlobalLogger.Warning("Error closing response body")
 		}
 	}(response.Body)
+	if response.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w - [%d] - [%s]", ErrSafeNotFound, response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	}
 	if response.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("failed to retrieve safe - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
 	}
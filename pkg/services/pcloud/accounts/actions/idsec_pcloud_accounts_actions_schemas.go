@@ -20,7 +20,10 @@ var ActionToSchemaMap = map[string]interface{}{
 	"set-next-credentials":        &accountsmodels.IdsecPCloudSetAccountNextCredentials{},
 	"update-credentials-in-vault": &accountsmodels.IdsecPCloudUpdateAccountCredentialsInVault{},
 	"reconcile-credentials":       &accountsmodels.IdsecPCloudReconcileAccountCredentials{},
+	"check-in":                    &accountsmodels.IdsecPCloudCheckInAccount{},
+	"unlock":                      &accountsmodels.IdsecPCloudUnlockAccount{},
 	"link":                        &accountsmodels.IdsecPCloudLinkAccount{},
 	"unlink":                      &accountsmodels.IdsecPCloudUnlinkAccount{},
+	"import":                      &accountsmodels.IdsecPCloudImportAccounts{},
 	"stats":                       nil,
 }
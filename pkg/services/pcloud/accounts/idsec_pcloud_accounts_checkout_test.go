@@ -0,0 +1,159 @@
+package accounts_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	accountsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts/models"
+	pcloudint "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/internal"
+)
+
+// checkoutServer serves password retrieval and check in of account 12_3, counting check ins.
+type checkoutServer struct {
+	mu            sync.Mutex
+	checkIns      int
+	checkInStatus int
+}
+
+func (c *checkoutServer) checkInCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.checkIns
+}
+
+func (c *checkoutServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/accounts/12_3/password/retrieve":
+		_, _ = fmt.Fprint(w, `"secret"`)
+	case r.Method == http.MethodPost && r.URL.Path == "/api/accounts/12_3/checkin":
+		c.mu.Lock()
+		c.checkIns++
+		c.mu.Unlock()
+		w.WriteHeader(c.checkInStatus)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestAccountsCheckout(t *testing.T) {
+	t.Parallel()
+	fnErr := errors.New("fn failed")
+	tests := []struct {
+		name             string
+		checkInStatus    int
+		fnErr            error
+		expectedErrors   []string
+		expectedCheckIns int
+	}{
+		{name: "success", checkInStatus: http.StatusOK, expectedCheckIns: 1},
+		{name: "fn_error", checkInStatus: http.StatusOK, fnErr: fnErr, expectedErrors: []string{"fn failed"}, expectedCheckIns: 1},
+		{name: "check_in_error", checkInStatus: http.StatusConflict, fnErr: fnErr, expectedErrors: []string{"fn failed", "failed to check in account - [409]"}, expectedCheckIns: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := &checkoutServer{checkInStatus: tt.checkInStatus}
+			parts, cleanup := pcloudint.SetupMockISPServiceParts(t, server)
+			t.Cleanup(cleanup)
+
+			svc := newTestPCloudAccountsService(parts)
+			err := svc.Checkout(context.Background(), &accountsmodels.IdsecPCloudGetAccountCredentials{AccountID: "12_3"},
+				func(ctx context.Context, credentials *accountsmodels.IdsecPCloudAccountCredentials) error {
+					require.Equal(t, "secret", credentials.Password)
+					require.Equal(t, 0, server.checkInCount(), "the account is checked in only after fn returns")
+					return tt.fnErr
+				})
+			if len(tt.expectedErrors) == 0 {
+				require.NoError(t, err)
+			}
+			for _, expectedError := range tt.expectedErrors {
+				require.ErrorContains(t, err, expectedError)
+			}
+			if tt.fnErr != nil {
+				require.ErrorIs(t, err, tt.fnErr)
+			}
+			require.Equal(t, tt.expectedCheckIns, server.checkInCount())
+		})
+	}
+}
+
+func TestAccountsCheckout_checksInOnCancel(t *testing.T) {
+	t.Parallel()
+	server := &checkoutServer{checkInStatus: http.StatusOK}
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, server)
+	t.Cleanup(cleanup)
+
+	svc := newTestPCloudAccountsService(parts)
+	ctx, cancel := context.WithCancel(context.Background())
+	err := svc.Checkout(ctx, &accountsmodels.IdsecPCloudGetAccountCredentials{AccountID: "12_3"},
+		func(ctx context.Context, _ *accountsmodels.IdsecPCloudAccountCredentials) error {
+			cancel()
+			require.Eventually(t, func() bool { return server.checkInCount() == 1 }, 5*time.Second, 10*time.Millisecond,
+				"the account is checked in as soon as the context is cancelled")
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 1, server.checkInCount())
+}
+
+func TestAccountsCheckout_retrieveErrorSkipsCheckIn(t *testing.T) {
+	t.Parallel()
+	server := &checkoutServer{checkInStatus: http.StatusOK}
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, server)
+	t.Cleanup(cleanup)
+
+	svc := newTestPCloudAccountsService(parts)
+	called := false
+	err := svc.Checkout(context.Background(), &accountsmodels.IdsecPCloudGetAccountCredentials{AccountID: "missing"},
+		func(context.Context, *accountsmodels.IdsecPCloudAccountCredentials) error {
+			called = true
+			return nil
+		})
+	require.Error(t, err)
+	require.False(t, called)
+	require.Equal(t, 0, server.checkInCount())
+}
+
+func TestAccountsUnlock(t *testing.T) {
+	t.Parallel()
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/accounts/12_3/unlock" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, h)
+	t.Cleanup(cleanup)
+
+	svc := newTestPCloudAccountsService(parts)
+	require.NoError(t, svc.Unlock(&accountsmodels.IdsecPCloudUnlockAccount{AccountID: "12_3"}))
+	require.Error(t, svc.Unlock(&accountsmodels.IdsecPCloudUnlockAccount{AccountID: "missing"}))
+}
+
+func TestAccountsGet_lockState(t *testing.T) {
+	t.Parallel()
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/accounts/12_3/" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"id": "12_3", "name": "exclusive", "safeName": "Safe", "locked": true, "lockedBy": "user@example.com"}`)
+	})
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, h)
+	t.Cleanup(cleanup)
+
+	svc := newTestPCloudAccountsService(parts)
+	account, err := svc.Get(&accountsmodels.IdsecPCloudGetAccount{AccountID: "12_3"})
+	require.NoError(t, err)
+	require.True(t, account.Locked)
+	require.Equal(t, "user@example.com", account.LockedBy)
+}
@@ -0,0 +1,66 @@
+package accounts_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/accountsimport"
+	accountsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts/models"
+	pcloudint "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/internal"
+)
+
+func TestAccountsImport_jsonl(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	var bodies []map[string]interface{}
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/accounts" {
+			http.NotFound(w, r)
+			return
+		}
+		body := map[string]interface{}{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if body["username"] == "rejected" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"ErrorCode":"PASWS027E","ErrorMessage":"Platform not found"}`)
+			return
+		}
+		mu.Lock()
+		bodies = append(bodies, body)
+		mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"id":"12_%s","name":%q,"safeName":"safe1"}`, fmt.Sprint(body["username"]), fmt.Sprint(body["name"]))
+	}))
+	t.Cleanup(cleanup)
+
+	inputFile := filepath.Join(t.TempDir(), "accounts.jsonl")
+	require.NoError(t, os.WriteFile(inputFile, []byte(
+		`{"safe_name":"safe1","username":"1","address":"db1","platform_id":"Oracle","platform_account_properties.Port":1521,"remote_machines":"m1;m2"}`+"\n"+
+			`{"safe_name":"safe1","username":"rejected","platform_id":"Missing"}`+"\n"+
+			`{"username":"2"}`+"\n"), 0o600))
+
+	svc := newTestPCloudAccountsService(parts)
+	result, err := svc.Import(&accountsmodels.IdsecPCloudImportAccounts{InputFile: inputFile})
+	require.NoError(t, err)
+	require.Equal(t, 3, result.Total)
+	require.Equal(t, 1, result.Created)
+	require.Equal(t, 1, result.Failed)
+	require.Equal(t, 1, result.Invalid)
+
+	require.Equal(t, accountsimport.StatusCreated, result.Rows[0].Status)
+	require.Equal(t, "12_1", result.Rows[0].AccountID)
+	require.Equal(t, accountsimport.StatusFailed, result.Rows[1].Status)
+	require.Contains(t, result.Rows[1].Error, "failed to add account - [400]")
+	require.Equal(t, accountsimport.StatusInvalid, result.Rows[2].Status)
+
+	require.Len(t, bodies, 1)
+	require.Equal(t, map[string]interface{}{"port": float64(1521)}, bodies[0]["platformAccountProperties"])
+	require.Equal(t, map[string]interface{}{"remoteMachines": "m1;m2"}, bodies[0]["remoteMachinesAccess"])
+}
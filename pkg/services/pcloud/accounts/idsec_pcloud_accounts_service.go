@@ -2,21 +2,26 @@ package accounts
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/mitchellh/mapstructure"
 	"github.com/cyberark/idsec-sdk-golang/pkg/auth"
 	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/accountsimport"
 	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
 	"github.com/cyberark/idsec-sdk-golang/pkg/services"
 	accountsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts/models"
 	commonpcloud "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/common"
 	pcloudinternal "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/internal"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/safes"
+	safesmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/safes/models"
 
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -35,6 +40,8 @@ const (
 	updateAccountCredentialsInVaultURL = "/api/accounts/%s/password/update"   // #nosec G101
 	retrieveAccountCredentialsURL      = "/api/accounts/%s/password/retrieve" // #nosec G101
 	reconcileAccountCredentialsURL     = "/api/accounts/%s/reconcile"         // #nosec G101
+	checkInAccountURL                  = "/api/accounts/%s/checkin"
+	unlockAccountURL                   = "/api/accounts/%s/unlock"
 	linkAccountURL                     = "/api/accounts/%s/linkaccount"
 	unlinkAccountURL                   = "/api/accounts/%s/linkaccount/%s/"
 	accountActivitiesURL               = "/api/accounts/%s/activities"
@@ -47,6 +54,8 @@ type IdsecPCloudAccountsPage = common.IdsecPage[accountsmodels.IdsecPCloudAccoun
 type IdsecPCloudAccountsService struct {
 	*services.IdsecBaseService
 	*services.IdsecISPBaseService
+
+	safesService *safes.IdsecPCloudSafesService
 }
 
 // NewIdsecPCloudAccountsService creates a new instance of IdsecPCloudAccountsService.
@@ -110,6 +119,15 @@ func normalizeAccountItemMap(accountMap map[string]interface{}) error {
 		if lastModifiedTime, ok := secretManagementMap["last_modified_time"]; ok {
 			accountMap["last_modified_time"] = lastModifiedTime
 		}
+		if secretStatus, ok := secretManagementMap["status"]; ok {
+			accountMap["secret_status"] = secretStatus
+		}
+		if lastVerifiedTime, ok := secretManagementMap["last_verified_time"]; ok {
+			accountMap["last_verified_time"] = lastVerifiedTime
+		}
+		if lastReconciledTime, ok := secretManagementMap["last_reconciled_time"]; ok {
+			accountMap["last_reconciled_time"] = lastReconciledTime
+		}
 	}
 	if remoteMachinesAccess, ok := accountMap["remote_machines_access"]; ok {
 		remoteMachinesAccessMap, ok := remoteMachinesAccess.(map[string]interface{})
@@ -568,6 +586,80 @@ func (s *IdsecPCloudAccountsService) ReconcileCredentials(reconcileAccountCreden
 	return nil
 }
 
+// CheckIn checks in an exclusive account that was checked out by the current user, releasing its lock.
+// Accounts on one-time password platforms have their password changed by CPM after the check in.
+// https://docs.cyberark.com/privilege-cloud-standard/latest/en/content/webservices/check-in.htm
+func (s *IdsecPCloudAccountsService) CheckIn(checkInAccount *accountsmodels.IdsecPCloudCheckInAccount) error {
+	s.Logger.Info("Checking in account [%s]", checkInAccount.AccountID)
+	response, err := s.ISPClient().Post(context.Background(), fmt.Sprintf(checkInAccountURL, checkInAccount.AccountID), nil)
+	if err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to check in account - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	}
+	return nil
+}
+
+// Unlock unlocks an account locked by the current user.
+// https://docs.cyberark.com/privilege-cloud-standard/latest/en/content/webservices/unlock-account.htm
+func (s *IdsecPCloudAccountsService) Unlock(unlockAccount *accountsmodels.IdsecPCloudUnlockAccount) error {
+	s.Logger.Info("Unlocking account [%s]", unlockAccount.AccountID)
+	response, err := s.ISPClient().Post(context.Background(), fmt.Sprintf(unlockAccountURL, unlockAccount.AccountID), nil)
+	if err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to unlock account - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	}
+	return nil
+}
+
+// Checkout retrieves the credentials of an exclusive account, which checks it out, and calls fn with them.
+// The account is checked in exactly once, when fn returns or panics, or as soon as ctx is cancelled,
+// whichever happens first. fn should stop using the credentials once ctx is done.
+// The returned error joins the error of fn and the error of the check in.
+func (s *IdsecPCloudAccountsService) Checkout(
+	ctx context.Context,
+	getAccountCredentials *accountsmodels.IdsecPCloudGetAccountCredentials,
+	fn func(ctx context.Context, credentials *accountsmodels.IdsecPCloudAccountCredentials) error,
+) (err error) {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	credentials, err := s.GetCredentials(getAccountCredentials)
+	if err != nil {
+		return err
+	}
+	var checkInOnce sync.Once
+	var checkInErr error
+	checkIn := func() {
+		checkInOnce.Do(func() {
+			checkInErr = s.CheckIn(&accountsmodels.IdsecPCloudCheckInAccount{AccountID: getAccountCredentials.AccountID})
+		})
+	}
+	stopCheckInOnCancel := context.AfterFunc(ctx, checkIn)
+	defer func() {
+		stopCheckInOnCancel()
+		// Waits for a check in that already started on cancellation
+		checkIn()
+		err = errors.Join(err, checkInErr)
+	}()
+	return fn(ctx, credentials)
+}
+
 func (s *IdsecPCloudAccountsService) parseAccountResponse(responseBody io.ReadCloser) (*accountsmodels.IdsecPCloudAccount, error) {
 	accountJSON, err := common.DeserializeJSONSnake(responseBody)
 	if err != nil {
@@ -761,6 +853,54 @@ func (s *IdsecPCloudAccountsService) Create(addAccount *accountsmodels.IdsecPClo
 	return s.parseAccountResponse(response.Body)
 }
 
+// Import bulk onboards accounts from a CSV or JSON Lines file.
+// Each row is mapped onto IdsecPCloudAddAccount, validated and created with bounded concurrency.
+// Rows that fail do not stop the import; their errors are reported in the result and the optional results file.
+func (s *IdsecPCloudAccountsService) Import(importAccounts *accountsmodels.IdsecPCloudImportAccounts) (*accountsimport.Result, error) {
+	s.Logger.Info("Importing accounts from [%s]", importAccounts.InputFile)
+	target := accountsimport.Target[accountsmodels.IdsecPCloudAddAccount]{
+		EnsureSafe: s.ensureSafe,
+		Create: func(addAccount *accountsmodels.IdsecPCloudAddAccount) (string, error) {
+			account, err := s.Create(addAccount)
+			if err != nil {
+				return "", err
+			}
+			return account.AccountID, nil
+		},
+	}
+	result, err := accountsimport.ImportFile(importAccounts.InputFile, importAccounts.Format, importAccounts.ResultsFile, target, accountsimport.Options{
+		ColumnMapping:      importAccounts.ColumnMapping,
+		Concurrency:        importAccounts.Concurrency,
+		CreateMissingSafes: importAccounts.CreateMissingSafes,
+	})
+	if err != nil {
+		return result, err
+	}
+	s.Logger.Info("Imported accounts - [%d] created, [%d] failed, [%d] invalid", result.Created, result.Failed, result.Invalid)
+	return result, nil
+}
+
+// ensureSafe creates the safe when it does not exist. Any other failure to retrieve it is returned.
+func (s *IdsecPCloudAccountsService) ensureSafe(safeName string) error {
+	if s.safesService == nil {
+		safesService, err := safes.NewIdsecPCloudSafesService(s.ISPAuth())
+		if err != nil {
+			return err
+		}
+		s.safesService = safesService
+	}
+	_, err := s.safesService.Get(&safesmodels.IdsecPCloudGetSafe{SafeName: safeName})
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, safes.ErrSafeNotFound) {
+		return err
+	}
+	s.Logger.Info("Safe [%s] not found, creating it", safeName)
+	_, err = s.safesService.Create(&safesmodels.IdsecPCloudAddSafe{SafeName: safeName})
+	return err
+}
+
 // Update updates an existing IdsecPCloudAccount.
 // https://docs.cyberark.com/Product-Doc/OnlineHelp/PAS/Latest/en/Content/SDK/UpdateAccount%20v10.htm
 func (s *IdsecPCloudAccountsService) Update(updateAccount *accountsmodels.IdsecPCloudUpdateAccount) (*accountsmodels.IdsecPCloudAccount, error) {
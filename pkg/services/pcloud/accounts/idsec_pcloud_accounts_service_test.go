@@ -17,7 +17,9 @@ func TestParseAccountResponseNormalizesRemoteMachines(t *testing.T) {
 		"secret_management": {
 			"automatic_management_enabled": true,
 			"manual_management_reason": "manual",
-			"last_modified_time": 123
+			"last_modified_time": 123,
+			"status": "success",
+			"last_verified_time": 124
 		},
 		"remote_machines_access": {
 			"access_restricted_to_remote_machines": true,
@@ -36,6 +38,9 @@ func TestParseAccountResponseNormalizesRemoteMachines(t *testing.T) {
 	if account.Username != "test-user" {
 		t.Fatalf("Username = %q, want %q", account.Username, "test-user")
 	}
+	if account.SecretStatus != "success" || account.LastModifiedTime != 123 || account.LastVerifiedTime != 124 {
+		t.Fatalf("secret management = %q/%d/%d, want %q/%d/%d", account.SecretStatus, account.LastModifiedTime, account.LastVerifiedTime, "success", 123, 124)
+	}
 	if !slices.Equal(account.RemoteMachines, []string{"host1", "host2"}) {
 		t.Fatalf("RemoteMachines = %v, want %v", account.RemoteMachines, []string{"host1", "host2"})
 	}
@@ -27,11 +27,16 @@ type IdsecPCloudAccount struct {
 	Status                                 string                 `json:"status,omitempty" mapstructure:"status,omitempty" desc:"The account's management status" flag:"status"`
 	CreatedTime                            int                    `json:"created_time,omitempty" mapstructure:"created_time,omitempty" desc:"The date and time the account was created" flag:"created-time"`
 	CategoryModificationTime               int                    `json:"category_modification_time,omitempty" mapstructure:"category_modification_time,omitempty" desc:"The last time the account or one of its file categories was created or changed" flag:"category-modification-time"`
+	SecretStatus                           string                 `json:"secret_status,omitempty" mapstructure:"secret_status,omitempty" desc:"The result of the last CPM operation on the account secret (success,failure)" flag:"secret-status"`
+	LastVerifiedTime                       int                    `json:"last_verified_time,omitempty" mapstructure:"last_verified_time,omitempty" desc:"The last time CPM verified the account secret" flag:"last-verified-time"`
+	LastReconciledTime                     int                    `json:"last_reconciled_time,omitempty" mapstructure:"last_reconciled_time,omitempty" desc:"The last time CPM reconciled the account secret" flag:"last-reconciled-time"`
 	Name                                   string                 `json:"name" mapstructure:"name" desc:"The name of the account" flag:"name" validate:"required" maxlength:"170"`
 	SafeName                               string                 `json:"safe_name" mapstructure:"safe_name" desc:"The name of the Safe where the account is stored" flag:"safe-name" validate:"required"`
 	PlatformID                             string                 `json:"platform_id,omitempty" mapstructure:"platform_id,omitempty" desc:"The ID of the platform assigned to the account" flag:"platform-id"`
 	Username                               string                 `json:"username,omitempty" mapstructure:"username,omitempty" desc:"The account username" flag:"username"`
 	Address                                string                 `json:"address,omitempty" mapstructure:"address,omitempty" desc:"The name or address of the machine where the account is used" flag:"address"`
 	SecretType                             string                 `json:"secret_type,omitempty" mapstructure:"secret_type,omitempty" desc:"The type of secret (password,key)" flag:"secret-type" choices:"password,key"`
+	Locked                                 bool                   `json:"locked,omitempty" mapstructure:"locked,omitempty" desc:"Whether the account is checked out or locked by a user" flag:"locked"`
+	LockedBy                               string                 `json:"locked_by,omitempty" mapstructure:"locked_by,omitempty" desc:"The user who checked out or locked the account" flag:"locked-by"`
 	PlatformAccountProperties              map[string]interface{} `json:"platform_account_properties,omitempty" mapstructure:"platform_account_properties,omitempty" desc:"The object containing key-value pairs to associate with the account, as defined by the account platform. Optional properties that do not exist or internal properties are not returned" flag:"platform-account-properties"`
 }
@@ -0,0 +1,6 @@
+package models
+
+// IdsecPCloudCheckInAccount represents the details required to check in an exclusive account.
+type IdsecPCloudCheckInAccount struct {
+	AccountID string `json:"account_id" mapstructure:"account_id" desc:"The ID of the account to check in" flag:"account-id" validate:"required"`
+}
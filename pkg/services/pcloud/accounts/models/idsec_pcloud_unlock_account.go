@@ -0,0 +1,6 @@
+package models
+
+// IdsecPCloudUnlockAccount represents the details required to unlock an account locked by the current user.
+type IdsecPCloudUnlockAccount struct {
+	AccountID string `json:"account_id" mapstructure:"account_id" desc:"The ID of the account to unlock" flag:"account-id" validate:"required"`
+}
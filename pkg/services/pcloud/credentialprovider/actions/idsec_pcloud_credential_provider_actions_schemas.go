@@ -0,0 +1,8 @@
+package actions
+
+import credentialprovidermodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/credentialprovider/models"
+
+// ActionToSchemaMap maps action names to their corresponding schema structures.
+var ActionToSchemaMap = map[string]interface{}{
+	"agent": &credentialprovidermodels.IdsecPCloudCredentialProviderAgentExecution{},
+}
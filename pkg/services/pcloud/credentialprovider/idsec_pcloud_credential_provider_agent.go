@@ -0,0 +1,437 @@
+package credentialprovider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	applicationsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/applications/models"
+	credentialprovidermodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/credentialprovider/models"
+)
+
+const (
+	credentialsPath        = "/credentials"
+	agentReadHeaderTimeout = 10 * time.Second
+)
+
+// errCredentialsNotFound is returned by a credentials retriever when no account matches the request.
+var errCredentialsNotFound = errors.New("no account matches the request")
+
+// credentialsRetriever fetches the secret of the account named object in the given safe.
+type credentialsRetriever func(safe string, object string) (*credentialprovidermodels.IdsecPCloudCredentialProviderCredentials, error)
+
+// callerProcess describes the process on the other end of a Unix socket connection, as identified
+// when the connection was accepted.
+//
+// The executable path and hash are only as strong as the OS user check: a process running as the
+// same user can ptrace or inject code into an allowed executable, so path and hash auth methods
+// should be combined with an osUser auth method dedicated to the application.
+type callerProcess struct {
+	PID            int
+	UID            string
+	Executable     string
+	ExecutableHash string
+	// startTime pins the identity to the process, so that a pid reused by another process
+	// between accepting the connection and serving a request is rejected.
+	startTime string
+}
+
+// callerIdentity is attached to the context of every connection to the agent.
+type callerIdentity struct {
+	process *callerProcess
+	err     error
+}
+
+type callerContextKey struct{}
+
+type cachedCredentials struct {
+	credentials *credentialprovidermodels.IdsecPCloudCredentialProviderCredentials
+	expiresAt   time.Time
+}
+
+// IdsecPCloudCredentialProviderAgent is a local HTTP endpoint serving pCloud secrets to the applications
+// of its allow-list. It is created by IdsecPCloudCredentialProviderService.StartAgent and runs until Close
+// is called.
+//
+// Retrieved secrets are kept in memory for the cache TTL, so repeated requests for the same account
+// do not reach the vault. The cache is dropped when the agent is closed.
+type IdsecPCloudCredentialProviderAgent struct {
+	listener     net.Listener
+	server       *http.Server
+	applications map[string]*credentialprovidermodels.IdsecPCloudCredentialProviderApplication
+	retrieve     credentialsRetriever
+	info         credentialprovidermodels.IdsecPCloudCredentialProviderAgentInfo
+	logger       *common.IdsecLogger
+	now          func() time.Time
+
+	cacheTTL  time.Duration
+	cacheLock sync.Mutex
+	cache     map[string]*cachedCredentials
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// validateApplications checks that every application uses supported auth methods the agent can check on the
+// given network, and indexes them by app id.
+func validateApplications(network string, applications []credentialprovidermodels.IdsecPCloudCredentialProviderApplication) (map[string]*credentialprovidermodels.IdsecPCloudCredentialProviderApplication, error) {
+	indexed := make(map[string]*credentialprovidermodels.IdsecPCloudCredentialProviderApplication, len(applications))
+	for i := range applications {
+		application := &applications[i]
+		if application.AppID == "" {
+			return nil, fmt.Errorf("application app id is required")
+		}
+		if len(application.AllowedSafes) == 0 {
+			return nil, fmt.Errorf("application [%s] must allow at least one safe", application.AppID)
+		}
+		if len(application.AuthMethods) == 0 && !application.InsecureAppIDOnly {
+			return nil, fmt.Errorf("application [%s] has no auth methods, add auth methods or set insecure app id only to identify it by its app id alone", application.AppID)
+		}
+		if len(application.AuthMethods) > 0 && application.InsecureAppIDOnly {
+			return nil, fmt.Errorf("application [%s] cannot both have auth methods and be insecure app id only", application.AppID)
+		}
+		for _, authMethod := range application.AuthMethods {
+			switch authMethod.AuthType {
+			case applicationsmodels.ApplicationAuthMethodPath, applicationsmodels.ApplicationAuthMethodHash, applicationsmodels.ApplicationAuthMethodOsUser:
+				if authMethod.AuthValue == "" {
+					return nil, fmt.Errorf("auth method [%s] of application [%s] has no value", authMethod.AuthType, application.AppID)
+				}
+			default:
+				return nil, fmt.Errorf("unsupported auth method [%s] for application [%s], expected one of [path,hash,osUser]", authMethod.AuthType, application.AppID)
+			}
+		}
+		if len(application.AuthMethods) > 0 && network != "unix" {
+			return nil, fmt.Errorf("auth methods of application [%s] can only be checked over a Unix socket, set a socket path", application.AppID)
+		}
+		key := strings.ToLower(application.AppID)
+		if _, ok := indexed[key]; ok {
+			return nil, fmt.Errorf("application [%s] is defined more than once", application.AppID)
+		}
+		indexed[key] = application
+	}
+	return indexed, nil
+}
+
+func newCredentialProviderAgent(
+	network string,
+	address string,
+	applications []credentialprovidermodels.IdsecPCloudCredentialProviderApplication,
+	cacheTTL time.Duration,
+	retrieve credentialsRetriever,
+	logger *common.IdsecLogger,
+) (*IdsecPCloudCredentialProviderAgent, error) {
+	indexedApplications, err := validateApplications(network, applications)
+	if err != nil {
+		return nil, err
+	}
+	if network != "unix" {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid local address [%s]: %w", address, err)
+		}
+		if !isLoopbackHost(host) {
+			return nil, fmt.Errorf("local address [%s] is not a loopback address, the agent only listens on loopback addresses", host)
+		}
+	}
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on [%s]: %w", address, err)
+	}
+	agent := &IdsecPCloudCredentialProviderAgent{
+		listener:     listener,
+		applications: indexedApplications,
+		retrieve:     retrieve,
+		logger:       logger,
+		now:          time.Now,
+		cacheTTL:     cacheTTL,
+		cache:        make(map[string]*cachedCredentials),
+		done:         make(chan struct{}),
+		info: credentialprovidermodels.IdsecPCloudCredentialProviderAgentInfo{
+			Network:      network,
+			Address:      listener.Addr().String(),
+			Applications: len(indexedApplications),
+		},
+	}
+	for _, application := range indexedApplications {
+		if application.InsecureAppIDOnly {
+			logger.Warning("Application [%s] is insecure app id only, any local process presenting its app id can retrieve its secrets", application.AppID)
+		}
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(credentialsPath, agent.serveCredentials)
+	var handler http.Handler = mux
+	if network != "unix" {
+		handler = requireLoopbackHost(mux)
+	}
+	agent.server = &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: agentReadHeaderTimeout,
+		ConnContext:       identifyCaller,
+	}
+	go agent.serve()
+	return agent, nil
+}
+
+// Info returns the details clients need to connect to the agent.
+func (a *IdsecPCloudCredentialProviderAgent) Info() *credentialprovidermodels.IdsecPCloudCredentialProviderAgentInfo {
+	info := a.info
+	return &info
+}
+
+// Done returns a channel that is closed once the agent stops serving requests.
+func (a *IdsecPCloudCredentialProviderAgent) Done() <-chan struct{} {
+	return a.done
+}
+
+// Close stops serving requests and drops every cached secret.
+func (a *IdsecPCloudCredentialProviderAgent) Close() error {
+	var err error
+	a.closeOnce.Do(func() {
+		err = a.server.Close()
+		a.cacheLock.Lock()
+		a.cache = make(map[string]*cachedCredentials)
+		a.cacheLock.Unlock()
+	})
+	<-a.done
+	return err
+}
+
+func (a *IdsecPCloudCredentialProviderAgent) serve() {
+	defer close(a.done)
+	err := a.server.Serve(a.listener)
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		a.logger.Warning("Stopped serving credential provider requests on [%s]: %v", a.info.Address, err)
+	}
+}
+
+// identifyCaller attaches the identity of the calling process to the context of Unix socket connections.
+func identifyCaller(ctx context.Context, connection net.Conn) context.Context {
+	unixConnection, ok := connection.(*net.UnixConn)
+	if !ok {
+		return ctx
+	}
+	process, err := unixPeerProcess(unixConnection)
+	return context.WithValue(ctx, callerContextKey{}, &callerIdentity{process: process, err: err})
+}
+
+// isLoopbackHost reports whether the host name or IP address refers to the local machine.
+func isLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	return ip != nil && ip.IsLoopback()
+}
+
+// requireLoopbackHost rejects requests whose Host header does not name a loopback host. A browser
+// tricked into resolving an attacker's domain to the loopback address still sends that domain as the
+// host, so this keeps web pages from reading secrets off a TCP agent through DNS rebinding.
+func requireLoopbackHost(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if splitHost, _, err := net.SplitHostPort(host); err == nil {
+			host = splitHost
+		}
+		if !isLoopbackHost(host) {
+			writeAgentError(w, http.StatusForbidden, fmt.Sprintf("host [%s] is not a loopback host", r.Host))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeAgentResponse(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeAgentError(w http.ResponseWriter, statusCode int, message string) {
+	writeAgentResponse(w, statusCode, &credentialprovidermodels.IdsecPCloudCredentialProviderError{ErrorMsg: message})
+}
+
+func (a *IdsecPCloudCredentialProviderAgent) serveCredentials(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeAgentError(w, http.StatusMethodNotAllowed, "only GET is supported")
+		return
+	}
+	query := r.URL.Query()
+	appID, safe, object := query.Get("app_id"), query.Get("safe"), query.Get("object")
+	if appID == "" || safe == "" || object == "" {
+		writeAgentError(w, http.StatusBadRequest, "app_id, safe and object are required")
+		return
+	}
+	application, ok := a.applications[strings.ToLower(appID)]
+	if !ok {
+		a.logger.Warning("Denied request of unknown application [%s] for [%s/%s]", appID, safe, object)
+		writeAgentError(w, http.StatusForbidden, fmt.Sprintf("application [%s] is not allowed", appID))
+		return
+	}
+	if !allowsObject(application, safe, object) {
+		a.logger.Warning("Denied request of application [%s] for [%s/%s] outside its allow-list", appID, safe, object)
+		writeAgentError(w, http.StatusForbidden, fmt.Sprintf("application [%s] is not allowed to retrieve [%s] from safe [%s]", appID, object, safe))
+		return
+	}
+	if len(application.AuthMethods) > 0 {
+		if err := authenticateCaller(application, r.Context()); err != nil {
+			a.logger.Warning("Denied request of application [%s] for [%s/%s]: %v", appID, safe, object, err)
+			writeAgentError(w, http.StatusForbidden, fmt.Sprintf("application [%s] authentication failed: %v", appID, err))
+			return
+		}
+	}
+	credentials, err := a.credentials(safe, object)
+	if errors.Is(err, errCredentialsNotFound) {
+		writeAgentError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		a.logger.Warning("Failed to retrieve [%s/%s] for application [%s]: %v", safe, object, appID, err)
+		writeAgentError(w, http.StatusBadGateway, fmt.Sprintf("failed to retrieve the secret: %v", err))
+		return
+	}
+	a.logger.Info("Served [%s/%s] to application [%s]", safe, object, appID)
+	writeAgentResponse(w, http.StatusOK, credentials)
+}
+
+// credentials returns the cached secret of the account, retrieving it from the vault when it is missing or expired.
+func (a *IdsecPCloudCredentialProviderAgent) credentials(safe string, object string) (*credentialprovidermodels.IdsecPCloudCredentialProviderCredentials, error) {
+	key := strings.ToLower(safe) + "/" + strings.ToLower(object)
+	if a.cacheTTL > 0 {
+		a.cacheLock.Lock()
+		cached, ok := a.cache[key]
+		if ok && a.now().Before(cached.expiresAt) {
+			a.cacheLock.Unlock()
+			credentials := *cached.credentials
+			return &credentials, nil
+		}
+		delete(a.cache, key)
+		a.cacheLock.Unlock()
+	}
+	credentials, err := a.retrieve(safe, object)
+	if err != nil {
+		return nil, err
+	}
+	if a.cacheTTL > 0 {
+		cachedCopy := *credentials
+		a.cacheLock.Lock()
+		a.cache[key] = &cachedCredentials{credentials: &cachedCopy, expiresAt: a.now().Add(a.cacheTTL)}
+		a.cacheLock.Unlock()
+	}
+	return credentials, nil
+}
+
+func allowsObject(application *credentialprovidermodels.IdsecPCloudCredentialProviderApplication, safe string, object string) bool {
+	safeAllowed := false
+	for _, allowedSafe := range application.AllowedSafes {
+		if strings.EqualFold(allowedSafe, safe) {
+			safeAllowed = true
+			break
+		}
+	}
+	if !safeAllowed {
+		return false
+	}
+	if len(application.AllowedObjects) == 0 {
+		return true
+	}
+	for _, allowedObject := range application.AllowedObjects {
+		if strings.EqualFold(allowedObject, object) {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticateCaller matches the calling process against the auth methods of the application.
+// Every auth method type must be matched by at least one of its values.
+func authenticateCaller(application *credentialprovidermodels.IdsecPCloudCredentialProviderApplication, ctx context.Context) error {
+	identity, ok := ctx.Value(callerContextKey{}).(*callerIdentity)
+	if !ok {
+		return fmt.Errorf("the calling process can only be identified over the Unix socket")
+	}
+	if identity.err != nil {
+		return fmt.Errorf("the calling process could not be identified: %v", identity.err)
+	}
+	if err := verifyProcessStartTime(identity.process.PID, identity.process.startTime); err != nil {
+		return err
+	}
+	methodsByType := make(map[string][]applicationsmodels.IdsecPCloudApplicationAuthMethod)
+	for _, authMethod := range application.AuthMethods {
+		methodsByType[authMethod.AuthType] = append(methodsByType[authMethod.AuthType], authMethod)
+	}
+	var osUser string
+	for authType, authMethods := range methodsByType {
+		matched := false
+		for _, authMethod := range authMethods {
+			switch authType {
+			case applicationsmodels.ApplicationAuthMethodPath:
+				matched = matchesPath(authMethod, identity.process.Executable)
+			case applicationsmodels.ApplicationAuthMethodHash:
+				matched = strings.EqualFold(authMethod.AuthValue, identity.process.ExecutableHash)
+			case applicationsmodels.ApplicationAuthMethodOsUser:
+				if osUser == "" {
+					osUser = identity.process.UID
+					if lookedUp, err := user.LookupId(identity.process.UID); err == nil {
+						osUser = lookedUp.Username
+					}
+				}
+				matched = strings.EqualFold(authMethod.AuthValue, osUser)
+			}
+			if matched {
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("process [%d] does not match the [%s] auth methods", identity.process.PID, authType)
+		}
+	}
+	return nil
+}
+
+// verifyProcessStartTime checks that the pid still refers to the process which started at startTime.
+func verifyProcessStartTime(pid int, startTime string) error {
+	currentStartTime, err := processStartTime(pid)
+	if err != nil {
+		return err
+	}
+	if currentStartTime != startTime {
+		return fmt.Errorf("process [%d] was replaced after the connection was accepted", pid)
+	}
+	return nil
+}
+
+func matchesPath(authMethod applicationsmodels.IdsecPCloudApplicationAuthMethod, executable string) bool {
+	allowedPath := filepath.Clean(authMethod.AuthValue)
+	if authMethod.IsFolder != nil && *authMethod.IsFolder {
+		return strings.HasPrefix(executable, allowedPath+string(filepath.Separator))
+	}
+	return executable == allowedPath
+}
+
+// hashExecutable returns the hex encoded SHA-256 of the file.
+func hashExecutable(path string) (string, error) {
+	file, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return "", fmt.Errorf("failed to read the executable of the calling process: %v", err)
+	}
+	defer file.Close() //nolint:errcheck
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to read the executable of the calling process: %v", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,77 @@
+package credentialprovider
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// procStatStartTimeField is the index of the start time among the fields of /proc/<pid>/stat following the command name.
+const procStatStartTimeField = 19
+
+// unixPeerProcess identifies the process on the other end of the connection from its peer credentials.
+// The executable path and hash are resolved once, when the connection is accepted, and pinned to the
+// start time of the process so that a reused pid is detected.
+func unixPeerProcess(connection *net.UnixConn) (*callerProcess, error) {
+	rawConnection, err := connection.SyscallConn()
+	if err != nil {
+		return nil, err
+	}
+	var credentials *syscall.Ucred
+	var credentialsErr error
+	err = rawConnection.Control(func(fd uintptr) {
+		credentials, credentialsErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if credentialsErr != nil {
+		return nil, fmt.Errorf("failed to read peer credentials: %v", credentialsErr)
+	}
+	pid := int(credentials.Pid)
+	startTime, err := processStartTime(pid)
+	if err != nil {
+		return nil, err
+	}
+	exeLink := fmt.Sprintf("/proc/%d/exe", pid)
+	executable, err := os.Readlink(exeLink)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the executable of process [%d]: %v", pid, err)
+	}
+	// The running image is hashed rather than the path, so replacing the file on disk does not change the hash.
+	executableHash, err := hashExecutable(exeLink)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyProcessStartTime(pid, startTime); err != nil {
+		return nil, err
+	}
+	return &callerProcess{
+		PID:            pid,
+		UID:            strconv.FormatUint(uint64(credentials.Uid), 10),
+		Executable:     executable,
+		ExecutableHash: executableHash,
+		startTime:      startTime,
+	}, nil
+}
+
+// processStartTime returns the start time of the process, in clock ticks since boot, from /proc/<pid>/stat.
+func processStartTime(pid int) (string, error) {
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the status of process [%d]: %v", pid, err)
+	}
+	// The command name is enclosed in parentheses and may itself contain spaces and parentheses.
+	commandEnd := strings.LastIndexByte(string(stat), ')')
+	if commandEnd < 0 {
+		return "", fmt.Errorf("failed to parse the status of process [%d]", pid)
+	}
+	fields := strings.Fields(string(stat[commandEnd+1:]))
+	if len(fields) <= procStatStartTimeField {
+		return "", fmt.Errorf("failed to parse the status of process [%d]", pid)
+	}
+	return fields[procStatStartTimeField], nil
+}
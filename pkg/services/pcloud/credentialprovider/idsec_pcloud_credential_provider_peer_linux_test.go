@@ -0,0 +1,105 @@
+package credentialprovider
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	applicationsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/applications/models"
+	credentialprovidermodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/credentialprovider/models"
+	pcloudint "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/internal"
+)
+
+// TestStartAgent_unixSocketAuthMethods calls the agent from the test process itself, so the
+// caller is identified as the running test binary and the current user.
+func TestStartAgent_unixSocketAuthMethods(t *testing.T) {
+	t.Parallel()
+	var retrievals int32
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, fakeVault(&retrievals))
+	defer cleanup()
+	svc := newTestPCloudCredentialProviderService(parts)
+	executable, err := os.Readlink("/proc/self/exe")
+	require.NoError(t, err)
+	executableHash, err := hashExecutable(executable)
+	require.NoError(t, err)
+	currentUser, err := user.Current()
+	require.NoError(t, err)
+	isFolder := true
+
+	socketPath := filepath.Join(t.TempDir(), "credentials.sock")
+	agent, err := svc.StartAgent(&credentialprovidermodels.IdsecPCloudCredentialProviderAgentExecution{
+		SocketPath: socketPath,
+		Applications: []credentialprovidermodels.IdsecPCloudCredentialProviderApplication{
+			{
+				AppID:        "self",
+				AllowedSafes: []string{"Finance"},
+				AuthMethods: []applicationsmodels.IdsecPCloudApplicationAuthMethod{
+					{AuthType: applicationsmodels.ApplicationAuthMethodPath, AuthValue: "/opt/other"},
+					{AuthType: applicationsmodels.ApplicationAuthMethodPath, AuthValue: filepath.Dir(executable), IsFolder: &isFolder},
+					{AuthType: applicationsmodels.ApplicationAuthMethodHash, AuthValue: executableHash},
+					{AuthType: applicationsmodels.ApplicationAuthMethodOsUser, AuthValue: currentUser.Username},
+				},
+			},
+			{
+				AppID:        "other-user",
+				AllowedSafes: []string{"Finance"},
+				AuthMethods: []applicationsmodels.IdsecPCloudApplicationAuthMethod{
+					{AuthType: applicationsmodels.ApplicationAuthMethodPath, AuthValue: executable},
+					{AuthType: applicationsmodels.ApplicationAuthMethodOsUser, AuthValue: "someone-else"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer agent.Close() //nolint:errcheck
+	require.Equal(t, "unix", agent.Info().Network)
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+		},
+	}}
+
+	status, body := getCredentials(t, client, "http://agent", "self", "Finance", "db-admin")
+	require.Equal(t, http.StatusOK, status, body["ErrorMsg"])
+	require.Equal(t, "s3cret", body["Content"])
+
+	status, body = getCredentials(t, client, "http://agent", "other-user", "Finance", "db-admin")
+	require.Equal(t, http.StatusForbidden, status)
+	require.Contains(t, body["ErrorMsg"], "does not match the [osUser] auth methods")
+	require.Equal(t, int32(1), atomic.LoadInt32(&retrievals))
+
+	require.NoError(t, agent.Close())
+	_, err = os.Stat(socketPath)
+	require.True(t, os.IsNotExist(err), "the socket is removed when the agent is closed")
+}
+
+func TestAuthenticateCaller_replacedProcess(t *testing.T) {
+	t.Parallel()
+	executable, err := os.Readlink("/proc/self/exe")
+	require.NoError(t, err)
+	startTime, err := processStartTime(os.Getpid())
+	require.NoError(t, err)
+	application := &credentialprovidermodels.IdsecPCloudCredentialProviderApplication{
+		AppID: "self",
+		AuthMethods: []applicationsmodels.IdsecPCloudApplicationAuthMethod{
+			{AuthType: applicationsmodels.ApplicationAuthMethodPath, AuthValue: executable},
+		},
+	}
+	process := &callerProcess{PID: os.Getpid(), Executable: executable, startTime: startTime}
+	ctx := context.WithValue(context.Background(), callerContextKey{}, &callerIdentity{process: process})
+	require.NoError(t, authenticateCaller(application, ctx))
+
+	replaced := *process
+	replaced.startTime = "0"
+	ctx = context.WithValue(context.Background(), callerContextKey{}, &callerIdentity{process: &replaced})
+	err = authenticateCaller(application, ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "was replaced after the connection was accepted")
+}
@@ -0,0 +1,18 @@
+//go:build !linux
+
+package credentialprovider
+
+import (
+	"fmt"
+	"net"
+)
+
+// unixPeerProcess is only implemented on Linux, elsewhere applications with auth methods are always denied.
+func unixPeerProcess(*net.UnixConn) (*callerProcess, error) {
+	return nil, fmt.Errorf("identifying the calling process is only supported on linux")
+}
+
+// processStartTime is only implemented on Linux.
+func processStartTime(int) (string, error) {
+	return "", fmt.Errorf("identifying the calling process is only supported on linux")
+}
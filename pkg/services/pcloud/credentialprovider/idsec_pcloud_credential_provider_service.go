@@ -0,0 +1,222 @@
+package credentialprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/auth"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts"
+	accountsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts/models"
+	commonpcloud "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/common"
+	credentialprovidermodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/credentialprovider/models"
+)
+
+const (
+	defaultAgentLocalAddress = "127.0.0.1"
+	defaultAgentCacheTTL     = 300 * time.Second
+	defaultAgentReason       = "Credential provider agent"
+)
+
+// IdsecPCloudCredentialProviderService is the service for serving pCloud secrets to local applications.
+type IdsecPCloudCredentialProviderService struct {
+	*services.IdsecBaseService
+	*services.IdsecISPBaseService
+
+	accountsService *accounts.IdsecPCloudAccountsService
+}
+
+// NewIdsecPCloudCredentialProviderService creates a new instance of IdsecPCloudCredentialProviderService.
+func NewIdsecPCloudCredentialProviderService(authenticators ...auth.IdsecAuth) (*IdsecPCloudCredentialProviderService, error) {
+	pcloudCredentialProviderService := &IdsecPCloudCredentialProviderService{}
+	var pcloudCredentialProviderServiceInterface services.IdsecService = pcloudCredentialProviderService
+	baseService, err := services.NewIdsecBaseService(pcloudCredentialProviderServiceInterface, authenticators...)
+	if err != nil {
+		return nil, err
+	}
+	ispBaseAuth, err := baseService.Authenticator("isp")
+	if err != nil {
+		return nil, err
+	}
+	ispAuth := ispBaseAuth.(*auth.IdsecISPAuth)
+
+	ispBaseService, err := services.NewIdsecISPBaseServiceWithRetry(
+		ispAuth,
+		"privilegecloud",
+		".",
+		"passwordvault",
+		pcloudCredentialProviderService.refreshPCloudCredentialProviderAuth,
+		commonpcloud.DefaultPCloudRetryStrategy(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	accountsService, err := accounts.NewIdsecPCloudAccountsService(authenticators...)
+	if err != nil {
+		return nil, err
+	}
+
+	pcloudCredentialProviderService.IdsecBaseService = baseService
+	pcloudCredentialProviderService.IdsecISPBaseService = ispBaseService
+	pcloudCredentialProviderService.accountsService = accountsService
+	return pcloudCredentialProviderService, nil
+}
+
+func (s *IdsecPCloudCredentialProviderService) refreshPCloudCredentialProviderAuth(client *common.IdsecClient) error {
+	err := isp.RefreshClient(client, s.ISPAuth())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func loadAgentApplications(agentExecution *credentialprovidermodels.IdsecPCloudCredentialProviderAgentExecution) ([]credentialprovidermodels.IdsecPCloudCredentialProviderApplication, error) {
+	applications := append([]credentialprovidermodels.IdsecPCloudCredentialProviderApplication{}, agentExecution.Applications...)
+	if agentExecution.ApplicationsFile != "" {
+		applicationsFile := strings.TrimSuffix(common.ExpandFolder(agentExecution.ApplicationsFile), "/")
+		data, err := os.ReadFile(applicationsFile) // #nosec G304
+		if err != nil {
+			return nil, fmt.Errorf("failed to read applications file: %v", err)
+		}
+		var fileApplications []credentialprovidermodels.IdsecPCloudCredentialProviderApplication
+		if err := json.Unmarshal(data, &fileApplications); err != nil {
+			return nil, fmt.Errorf("failed to parse applications file: %v", err)
+		}
+		applications = append(applications, fileApplications...)
+	}
+	if len(applications) == 0 {
+		return nil, fmt.Errorf("at least one application is required")
+	}
+	return applications, nil
+}
+
+// retrieveCredentials finds the account named object in the safe and retrieves its secret.
+func (s *IdsecPCloudCredentialProviderService) retrieveCredentials(safe string, object string, reason string) (*credentialprovidermodels.IdsecPCloudCredentialProviderCredentials, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	accountsPages, err := s.accountsService.ListByContext(ctx, &accountsmodels.IdsecPCloudAccountsFilter{
+		SafeName: safe,
+		Search:   object,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var account *accountsmodels.IdsecPCloudAccount
+	for accountsPage := range accountsPages {
+		if accountsPage.Err != nil {
+			return nil, accountsPage.Err
+		}
+		for _, pageAccount := range accountsPage.Items {
+			// The search matches partially and on every account property, keep the exact name only
+			if strings.EqualFold(pageAccount.SafeName, safe) && strings.EqualFold(pageAccount.Name, object) {
+				account = pageAccount
+				break
+			}
+		}
+		if account != nil {
+			break
+		}
+	}
+	if account == nil {
+		return nil, fmt.Errorf("%w: no account named [%s] in safe [%s]", errCredentialsNotFound, object, safe)
+	}
+	secret, err := s.accountsService.GetCredentials(&accountsmodels.IdsecPCloudGetAccountCredentials{
+		AccountID:  account.AccountID,
+		Reason:     reason,
+		ActionType: accountsmodels.Show,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &credentialprovidermodels.IdsecPCloudCredentialProviderCredentials{
+		Content:   secret.Password,
+		UserName:  account.Username,
+		Address:   account.Address,
+		Safe:      account.SafeName,
+		Name:      account.Name,
+		PolicyID:  account.PlatformID,
+		AccountID: account.AccountID,
+	}, nil
+}
+
+// StartAgent starts a local credential provider agent serving the secrets of the vault to the allowed applications.
+// The agent retrieves secrets as the authenticated user, which is expected to be a service user with the
+// Retrieve Accounts permission on the allowed safes. It keeps running in the background until it is closed.
+//
+// Example:
+//
+//	agent, err := credentialProviderService.StartAgent(&credentialprovidermodels.IdsecPCloudCredentialProviderAgentExecution{
+//		SocketPath:       "/run/idsec/credentials.sock",
+//		ApplicationsFile: "/etc/idsec/applications.json",
+//	})
+//	if err != nil { /* handle */ }
+//	defer agent.Close()
+//	// curl --unix-socket /run/idsec/credentials.sock "http://localhost/credentials?app_id=billing&safe=Finance&object=db-admin"
+func (s *IdsecPCloudCredentialProviderService) StartAgent(agentExecution *credentialprovidermodels.IdsecPCloudCredentialProviderAgentExecution) (*IdsecPCloudCredentialProviderAgent, error) {
+	applications, err := loadAgentApplications(agentExecution)
+	if err != nil {
+		return nil, err
+	}
+	network := "unix"
+	address := agentExecution.SocketPath
+	if address == "" {
+		network = "tcp"
+		localAddress := agentExecution.LocalAddress
+		if localAddress == "" {
+			localAddress = defaultAgentLocalAddress
+		}
+		address = net.JoinHostPort(localAddress, strconv.Itoa(agentExecution.LocalPort))
+	}
+	cacheTTL := time.Duration(agentExecution.CacheTTLSeconds) * time.Second
+	if agentExecution.CacheTTLSeconds == 0 {
+		cacheTTL = defaultAgentCacheTTL
+	}
+	reason := agentExecution.Reason
+	if reason == "" {
+		reason = defaultAgentReason
+	}
+	s.Logger.Info("Starting credential provider agent on %s [%s] for [%d] applications", network, address, len(applications))
+	return newCredentialProviderAgent(
+		network,
+		address,
+		applications,
+		cacheTTL,
+		func(safe string, object string) (*credentialprovidermodels.IdsecPCloudCredentialProviderCredentials, error) {
+			return s.retrieveCredentials(safe, object, reason)
+		},
+		s.Logger,
+	)
+}
+
+// Agent runs a local credential provider agent until interrupted, logging the address applications should call.
+func (s *IdsecPCloudCredentialProviderService) Agent(agentExecution *credentialprovidermodels.IdsecPCloudCredentialProviderAgentExecution) error {
+	agent, err := s.StartAgent(agentExecution)
+	if err != nil {
+		return err
+	}
+	info := agent.Info()
+	s.Logger.Info("Serving credentials to [%d] applications on %s [%s]", info.Applications, info.Network, info.Address)
+	s.Logger.Info("Request GET /credentials?app_id=<app id>&safe=<safe>&object=<account name>. Press Ctrl+C to stop.")
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	select {
+	case <-ctx.Done():
+	case <-agent.Done():
+	}
+	return agent.Close()
+}
+
+// ServiceConfig returns the service configuration for the IdsecPCloudCredentialProviderService.
+func (s *IdsecPCloudCredentialProviderService) ServiceConfig() services.IdsecServiceConfig {
+	return ServiceConfig
+}
@@ -0,0 +1,27 @@
+package credentialprovider
+
+import (
+	"github.com/cyberark/idsec-sdk-golang/pkg/models/actions"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services"
+	svcactions "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/credentialprovider/actions"
+)
+
+// ServiceConfig is the configuration for the pcloud credential provider service.
+var ServiceConfig = services.IdsecServiceConfig{
+	ServiceName:                "pcloud-credential-provider",
+	RequiredAuthenticatorNames: []string{},
+	OptionalAuthenticatorNames: []string{"isp"},
+	ActionsConfigurations:      map[actions.IdsecServiceActionType][]actions.IdsecServiceActionDefinition{},
+	ActionSchemas:              svcactions.ActionToSchemaMap,
+}
+
+// ServiceGenerator is the function that generates a new instance of the IdsecPCloudCredentialProviderService.
+var ServiceGenerator = NewIdsecPCloudCredentialProviderService
+
+// Module init, registers the service configuration.
+func init() {
+	err := services.Register(ServiceConfig, false)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,197 @@
+package credentialprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts"
+	applicationsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/applications/models"
+	credentialprovidermodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/credentialprovider/models"
+	pcloudint "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/internal"
+)
+
+func newTestPCloudCredentialProviderService(parts *pcloudint.MockISPServiceParts) *IdsecPCloudCredentialProviderService {
+	return &IdsecPCloudCredentialProviderService{
+		IdsecBaseService:    parts.BaseService,
+		IdsecISPBaseService: parts.ISPBase,
+		accountsService: &accounts.IdsecPCloudAccountsService{
+			IdsecBaseService:    parts.BaseService,
+			IdsecISPBaseService: parts.ISPBase,
+		},
+	}
+}
+
+// fakeVault serves a single account of the Finance safe, among partial search matches,
+// and counts how many times its secret is retrieved.
+func fakeVault(retrievals *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/accounts":
+			_, _ = fmt.Fprint(w, `{"value": [
+				{"id": "12_4", "name": "db-admin-old", "safeName": "Finance", "userName": "olddba"},
+				{"id": "12_3", "name": "db-admin", "safeName": "Finance", "userName": "dba", "address": "db01.corp", "platformId": "MySQL"}
+			]}`)
+		case "/api/accounts/12_3/password/retrieve":
+			atomic.AddInt32(retrievals, 1)
+			_, _ = fmt.Fprint(w, `"s3cret"`)
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func getCredentials(t *testing.T, client *http.Client, baseURL string, appID string, safe string, object string) (int, map[string]string) {
+	t.Helper()
+	response, err := client.Get(fmt.Sprintf("%s/credentials?app_id=%s&safe=%s&object=%s", baseURL, appID, safe, object))
+	require.NoError(t, err)
+	defer response.Body.Close() //nolint:errcheck
+	body := make(map[string]string)
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&body))
+	return response.StatusCode, body
+}
+
+func TestStartAgent(t *testing.T) {
+	t.Parallel()
+	var retrievals int32
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, fakeVault(&retrievals))
+	defer cleanup()
+	svc := newTestPCloudCredentialProviderService(parts)
+
+	agent, err := svc.StartAgent(&credentialprovidermodels.IdsecPCloudCredentialProviderAgentExecution{
+		Applications: []credentialprovidermodels.IdsecPCloudCredentialProviderApplication{
+			{AppID: "billing", AllowedSafes: []string{"finance"}, AllowedObjects: []string{"db-admin", "missing"}, InsecureAppIDOnly: true},
+			{AppID: "payroll", AllowedSafes: []string{"Operations"}, InsecureAppIDOnly: true},
+		},
+	})
+	require.NoError(t, err)
+	defer agent.Close() //nolint:errcheck
+	require.Equal(t, "tcp", agent.Info().Network)
+	require.Equal(t, 2, agent.Info().Applications)
+	baseURL := "http://" + agent.Info().Address
+	client := &http.Client{}
+
+	status, body := getCredentials(t, client, baseURL, "billing", "Finance", "db-admin")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, "s3cret", body["Content"])
+	require.Equal(t, "dba", body["UserName"])
+	require.Equal(t, "db01.corp", body["Address"])
+	require.Equal(t, "MySQL", body["PolicyID"])
+	require.Equal(t, "12_3", body["AccountID"])
+
+	status, _ = getCredentials(t, client, baseURL, "BILLING", "finance", "DB-ADMIN")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, int32(1), atomic.LoadInt32(&retrievals), "the secret is served from the cache")
+
+	agent.now = func() time.Time { return time.Now().Add(defaultAgentCacheTTL + time.Second) }
+	status, _ = getCredentials(t, client, baseURL, "billing", "Finance", "db-admin")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, int32(2), atomic.LoadInt32(&retrievals), "an expired secret is retrieved again")
+
+	status, body = getCredentials(t, client, baseURL, "billing", "Finance", "missing")
+	require.Equal(t, http.StatusNotFound, status)
+	require.Contains(t, body["ErrorMsg"], "no account named [missing] in safe [Finance]")
+
+	status, _ = getCredentials(t, client, baseURL, "billing", "Finance", "db-admin-old")
+	require.Equal(t, http.StatusForbidden, status, "the object is outside the allow-list")
+	status, _ = getCredentials(t, client, baseURL, "billing", "Operations", "db-admin")
+	require.Equal(t, http.StatusForbidden, status, "the safe is outside the allow-list")
+	status, _ = getCredentials(t, client, baseURL, "unknown", "Finance", "db-admin")
+	require.Equal(t, http.StatusForbidden, status)
+	status, _ = getCredentials(t, client, baseURL, "payroll", "Finance", "db-admin")
+	require.Equal(t, http.StatusForbidden, status)
+	status, _ = getCredentials(t, client, baseURL, "billing", "Finance", "")
+	require.Equal(t, http.StatusBadRequest, status)
+
+	request, err := http.NewRequest(http.MethodGet, baseURL+"/credentials?app_id=billing&safe=Finance&object=db-admin", nil)
+	require.NoError(t, err)
+	request.Host = "attacker.example:8080"
+	response, err := client.Do(request)
+	require.NoError(t, err)
+	_ = response.Body.Close()
+	require.Equal(t, http.StatusForbidden, response.StatusCode, "a non loopback host is rejected against DNS rebinding")
+	require.Equal(t, int32(2), atomic.LoadInt32(&retrievals))
+
+	require.NoError(t, agent.Close())
+	<-agent.Done()
+	require.Empty(t, agent.cache)
+}
+
+func TestStartAgent_tcp(t *testing.T) {
+	t.Parallel()
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, http.NotFoundHandler())
+	defer cleanup()
+	svc := newTestPCloudCredentialProviderService(parts)
+
+	_, err := svc.StartAgent(&credentialprovidermodels.IdsecPCloudCredentialProviderAgentExecution{
+		Applications: []credentialprovidermodels.IdsecPCloudCredentialProviderApplication{{
+			AppID:        "payroll",
+			AllowedSafes: []string{"Finance"},
+			AuthMethods: []applicationsmodels.IdsecPCloudApplicationAuthMethod{
+				{AuthType: applicationsmodels.ApplicationAuthMethodPath, AuthValue: "/opt/payroll/bin/payroll"},
+			},
+		}},
+	})
+	require.ErrorContains(t, err, "auth methods of application [payroll] can only be checked over a Unix socket")
+
+	for _, localAddress := range []string{"0.0.0.0", "::", "10.0.0.1", "example.com"} {
+		_, err = svc.StartAgent(&credentialprovidermodels.IdsecPCloudCredentialProviderAgentExecution{
+			LocalAddress: localAddress,
+			Applications: []credentialprovidermodels.IdsecPCloudCredentialProviderApplication{{AppID: "billing", AllowedSafes: []string{"Finance"}, InsecureAppIDOnly: true}},
+		})
+		require.ErrorContains(t, err, "is not a loopback address", localAddress)
+	}
+
+	agent, err := svc.StartAgent(&credentialprovidermodels.IdsecPCloudCredentialProviderAgentExecution{
+		LocalAddress: "localhost",
+		Applications: []credentialprovidermodels.IdsecPCloudCredentialProviderApplication{{AppID: "billing", AllowedSafes: []string{"Finance"}, InsecureAppIDOnly: true}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, agent.Close())
+}
+
+func TestStartAgent_applications(t *testing.T) {
+	t.Parallel()
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, http.NotFoundHandler())
+	defer cleanup()
+	svc := newTestPCloudCredentialProviderService(parts)
+
+	_, err := svc.StartAgent(&credentialprovidermodels.IdsecPCloudCredentialProviderAgentExecution{})
+	require.ErrorContains(t, err, "at least one application is required")
+
+	_, err = svc.StartAgent(&credentialprovidermodels.IdsecPCloudCredentialProviderAgentExecution{
+		Applications: []credentialprovidermodels.IdsecPCloudCredentialProviderApplication{{
+			AppID:        "billing",
+			AllowedSafes: []string{"Finance"},
+			AuthMethods: []applicationsmodels.IdsecPCloudApplicationAuthMethod{
+				{AuthType: applicationsmodels.ApplicationAuthMethodCertificateSerialNumber, AuthValue: "0A1B"},
+			},
+		}},
+	})
+	require.ErrorContains(t, err, "unsupported auth method [certificateSerialNumber] for application [billing]")
+
+	_, err = svc.StartAgent(&credentialprovidermodels.IdsecPCloudCredentialProviderAgentExecution{
+		Applications: []credentialprovidermodels.IdsecPCloudCredentialProviderApplication{{AppID: "billing", AllowedSafes: []string{"Finance"}}},
+	})
+	require.ErrorContains(t, err, "application [billing] has no auth methods")
+
+	applicationsFile := filepath.Join(t.TempDir(), "applications.json")
+	require.NoError(t, os.WriteFile(applicationsFile, []byte(`[{"app_id": "billing", "allowed_safes": ["Finance"], "insecure_app_id_only": true}]`), 0600))
+	_, err = svc.StartAgent(&credentialprovidermodels.IdsecPCloudCredentialProviderAgentExecution{
+		Applications:     []credentialprovidermodels.IdsecPCloudCredentialProviderApplication{{AppID: "Billing", AllowedSafes: []string{"Finance"}, InsecureAppIDOnly: true}},
+		ApplicationsFile: applicationsFile,
+	})
+	require.ErrorContains(t, err, "application [billing] is defined more than once")
+
+	agent, err := svc.StartAgent(&credentialprovidermodels.IdsecPCloudCredentialProviderAgentExecution{ApplicationsFile: applicationsFile})
+	require.NoError(t, err)
+	require.Equal(t, 1, agent.Info().Applications)
+	require.NoError(t, agent.Close())
+}
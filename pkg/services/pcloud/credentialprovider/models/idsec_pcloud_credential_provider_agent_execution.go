@@ -0,0 +1,24 @@
+package models
+
+// IdsecPCloudCredentialProviderAgentExecution defines the structure for running a local credential provider agent.
+//
+// The agent serves GET /credentials?app_id=&safe=&object= on a Unix socket, or on a loopback TCP
+// address when no socket path is given. Callers on a Unix socket are identified by the peer
+// credentials of the connection, which is required for applications with auth methods. Over TCP
+// only applications marked insecure can be served, and requests must name a loopback host.
+type IdsecPCloudCredentialProviderAgentExecution struct {
+	SocketPath       string                                     `json:"socket_path,omitempty" mapstructure:"socket_path,omitempty" flag:"socket-path" desc:"The Unix socket to listen on. Takes precedence over the local address and port."`
+	LocalAddress     string                                     `json:"local_address,omitempty" mapstructure:"local_address,omitempty" flag:"local-address" desc:"The loopback address to listen on." default:"127.0.0.1"`
+	LocalPort        int                                        `json:"local_port,omitempty" mapstructure:"local_port,omitempty" flag:"local-port" desc:"The local port to listen on. Leave empty to pick a free port."`
+	Applications     []IdsecPCloudCredentialProviderApplication `json:"applications,omitempty" mapstructure:"applications,omitempty" flag:"applications" desc:"The applications allowed to retrieve secrets."`
+	ApplicationsFile string                                     `json:"applications_file,omitempty" mapstructure:"applications_file,omitempty" flag:"applications-file" desc:"A JSON file holding a list of applications allowed to retrieve secrets, added to the applications."`
+	CacheTTLSeconds  int                                        `json:"cache_ttl_seconds,omitempty" mapstructure:"cache_ttl_seconds,omitempty" flag:"cache-ttl-seconds" desc:"How long retrieved secrets are kept in memory. Set a negative value to disable caching." default:"300"`
+	Reason           string                                     `json:"reason,omitempty" mapstructure:"reason,omitempty" flag:"reason" desc:"The reason recorded in the vault audit for every retrieval." default:"Credential provider agent"`
+}
+
+// IdsecPCloudCredentialProviderAgentInfo describes a running credential provider agent.
+type IdsecPCloudCredentialProviderAgentInfo struct {
+	Network      string `json:"network" mapstructure:"network" desc:"The network the agent listens on (unix,tcp)."`
+	Address      string `json:"address" mapstructure:"address" desc:"The socket path or local address the agent listens on."`
+	Applications int    `json:"applications" mapstructure:"applications" desc:"The number of applications allowed to retrieve secrets."`
+}
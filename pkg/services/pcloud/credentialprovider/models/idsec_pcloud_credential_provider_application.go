@@ -0,0 +1,17 @@
+package models
+
+import applicationsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/applications/models"
+
+// IdsecPCloudCredentialProviderApplication represents an application allowed to retrieve secrets from the credential provider agent.
+//
+// The auth methods follow pCloud applications: path, hash and osUser methods are supported.
+// Methods of different types must all match the calling process, while any value of the same
+// type is enough. Auth methods can only be checked over a Unix socket, so an application
+// without them, which is identified by its app id alone, must be explicitly marked insecure.
+type IdsecPCloudCredentialProviderApplication struct {
+	AppID             string                                                `json:"app_id" mapstructure:"app_id" desc:"The application ID callers present in the app_id query parameter" flag:"app-id" validate:"required"`
+	AuthMethods       []applicationsmodels.IdsecPCloudApplicationAuthMethod `json:"auth_methods,omitempty" mapstructure:"auth_methods,omitempty" desc:"The path, hash and osUser auth methods the calling process must match" flag:"auth-methods"`
+	AllowedSafes      []string                                              `json:"allowed_safes" mapstructure:"allowed_safes" desc:"The safes the application may retrieve secrets from" flag:"allowed-safes" validate:"required"`
+	AllowedObjects    []string                                              `json:"allowed_objects,omitempty" mapstructure:"allowed_objects,omitempty" desc:"The account names the application may retrieve, all accounts of the allowed safes when empty" flag:"allowed-objects"`
+	InsecureAppIDOnly bool                                                  `json:"insecure_app_id_only,omitempty" mapstructure:"insecure_app_id_only,omitempty" desc:"Allow any local process presenting the app id to retrieve the secrets of an application without auth methods" flag:"insecure-app-id-only"`
+}
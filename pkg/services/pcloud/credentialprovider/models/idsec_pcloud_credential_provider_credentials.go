@@ -0,0 +1,18 @@
+package models
+
+// IdsecPCloudCredentialProviderCredentials represents the response of the credential provider agent.
+// The field names follow the Central Credential Provider REST API so existing clients can parse it.
+type IdsecPCloudCredentialProviderCredentials struct {
+	Content   string `json:"Content" mapstructure:"Content" desc:"The secret of the account"`
+	UserName  string `json:"UserName,omitempty" mapstructure:"UserName" desc:"The user name of the account"`
+	Address   string `json:"Address,omitempty" mapstructure:"Address" desc:"The address of the account"`
+	Safe      string `json:"Safe" mapstructure:"Safe" desc:"The safe holding the account"`
+	Name      string `json:"Name" mapstructure:"Name" desc:"The name of the account"`
+	PolicyID  string `json:"PolicyID,omitempty" mapstructure:"PolicyID" desc:"The platform of the account"`
+	AccountID string `json:"AccountID" mapstructure:"AccountID" desc:"The unique ID of the account"`
+}
+
+// IdsecPCloudCredentialProviderError represents an error response of the credential provider agent.
+type IdsecPCloudCredentialProviderError struct {
+	ErrorMsg string `json:"ErrorMsg" mapstructure:"ErrorMsg" desc:"The reason the request failed"`
+}
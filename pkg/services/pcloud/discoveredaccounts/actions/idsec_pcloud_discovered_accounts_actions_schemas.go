@@ -0,0 +1,13 @@
+package actions
+
+import discoveredaccountsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/discoveredaccounts/models"
+
+// ActionToSchemaMap maps action names to their corresponding schema structures.
+var ActionToSchemaMap = map[string]interface{}{
+	"list":         nil,
+	"list-by":      &discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsFilter{},
+	"get":          &discoveredaccountsmodels.IdsecPCloudGetDiscoveredAccount{},
+	"delete":       &discoveredaccountsmodels.IdsecPCloudDeleteDiscoveredAccount{},
+	"onboard":      &discoveredaccountsmodels.IdsecPCloudOnboardDiscoveredAccount{},
+	"auto-onboard": &discoveredaccountsmodels.IdsecPCloudAutoOnboardDiscoveredAccounts{},
+}
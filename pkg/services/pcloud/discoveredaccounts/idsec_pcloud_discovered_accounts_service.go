@@ -0,0 +1,611 @@
+package discoveredaccounts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/cyberark/idsec-sdk-golang/pkg/auth"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts"
+	accountsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts/models"
+	commonpcloud "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/common"
+	discoveredaccountsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/discoveredaccounts/models"
+	pcloudinternal "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/internal"
+)
+
+// API endpoint paths for discovered account operations
+const (
+	discoveredAccountsURL = "/api/DiscoveredAccounts"
+	discoveredAccountURL  = "/api/DiscoveredAccounts/%s"
+)
+
+// IdsecPCloudDiscoveredAccountsPage is a paginated type for IdsecPCloudDiscoveredAccount
+type IdsecPCloudDiscoveredAccountsPage = common.IdsecPage[discoveredaccountsmodels.IdsecPCloudDiscoveredAccount]
+
+// IdsecPCloudDiscoveredAccountsService is the service for reviewing and onboarding pCloud discovered accounts.
+type IdsecPCloudDiscoveredAccountsService struct {
+	*services.IdsecBaseService
+	*services.IdsecISPBaseService
+
+	accountsService *accounts.IdsecPCloudAccountsService
+}
+
+// NewIdsecPCloudDiscoveredAccountsService creates a new instance of IdsecPCloudDiscoveredAccountsService.
+func NewIdsecPCloudDiscoveredAccountsService(authenticators ...auth.IdsecAuth) (*IdsecPCloudDiscoveredAccountsService, error) {
+	pcloudDiscoveredAccountsService := &IdsecPCloudDiscoveredAccountsService{}
+	var pcloudDiscoveredAccountsServiceInterface services.IdsecService = pcloudDiscoveredAccountsService
+	baseService, err := services.NewIdsecBaseService(pcloudDiscoveredAccountsServiceInterface, authenticators...)
+	if err != nil {
+		return nil, err
+	}
+	ispBaseAuth, err := baseService.Authenticator("isp")
+	if err != nil {
+		return nil, err
+	}
+	ispAuth := ispBaseAuth.(*auth.IdsecISPAuth)
+
+	ispBaseService, err := services.NewIdsecISPBaseServiceWithRetry(
+		ispAuth,
+		"privilegecloud",
+		".",
+		"passwordvault",
+		pcloudDiscoveredAccountsService.refreshPCloudDiscoveredAccountsAuth,
+		commonpcloud.DefaultPCloudRetryStrategy(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	accountsService, err := accounts.NewIdsecPCloudAccountsService(authenticators...)
+	if err != nil {
+		return nil, err
+	}
+
+	pcloudDiscoveredAccountsService.IdsecBaseService = baseService
+	pcloudDiscoveredAccountsService.IdsecISPBaseService = ispBaseService
+	pcloudDiscoveredAccountsService.accountsService = accountsService
+	return pcloudDiscoveredAccountsService, nil
+}
+
+func (s *IdsecPCloudDiscoveredAccountsService) refreshPCloudDiscoveredAccountsAuth(client *common.IdsecClient) error {
+	err := isp.RefreshClient(client, s.ISPAuth())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// normalizeDiscoveredAccountMap maps API discovered account JSON fields to IdsecPCloudDiscoveredAccount mapstructure keys.
+func normalizeDiscoveredAccountMap(discoveredAccountMap map[string]interface{}) {
+	if discoveredAccountID, ok := discoveredAccountMap["id"]; ok {
+		discoveredAccountMap["discovered_account_id"] = discoveredAccountID
+	}
+	if userName, ok := discoveredAccountMap["user_name"]; ok {
+		discoveredAccountMap["username"] = userName
+	}
+}
+
+func decodeDiscoveredAccount(discoveredAccountJSON interface{}) (*discoveredaccountsmodels.IdsecPCloudDiscoveredAccount, error) {
+	discoveredAccountMap, ok := discoveredAccountJSON.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid discovered account format")
+	}
+	normalizeDiscoveredAccountMap(discoveredAccountMap)
+	var discoveredAccount discoveredaccountsmodels.IdsecPCloudDiscoveredAccount
+	if err := mapstructure.Decode(discoveredAccountMap, &discoveredAccount); err != nil {
+		return nil, err
+	}
+	return &discoveredAccount, nil
+}
+
+// fetchDiscoveredAccountsListPage performs a single list request and decodes one OData page.
+// A nil nextQuery means there are no further pages.
+func (s *IdsecPCloudDiscoveredAccountsService) fetchDiscoveredAccountsListPage(ctx context.Context, query map[string]string) ([]*discoveredaccountsmodels.IdsecPCloudDiscoveredAccount, map[string]string, error) {
+	response, err := s.ISPClient().Get(ctx, discoveredAccountsURL, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("failed to list discovered accounts - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	}
+	result, err := common.DeserializeJSONSnake(response.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("failed to list discovered accounts: %w", pcloudinternal.ErrUnexpectedListResult)
+	}
+	discoveredAccountsJSON, ok := resultMap["value"].([]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("failed to list discovered accounts: %w", pcloudinternal.ErrUnexpectedListResult)
+	}
+	discoveredAccounts := make([]*discoveredaccountsmodels.IdsecPCloudDiscoveredAccount, 0, len(discoveredAccountsJSON))
+	for _, discoveredAccountJSON := range discoveredAccountsJSON {
+		discoveredAccount, err := decodeDiscoveredAccount(discoveredAccountJSON)
+		if err != nil {
+			return nil, nil, err
+		}
+		discoveredAccounts = append(discoveredAccounts, discoveredAccount)
+	}
+	if nextLink, ok := pcloudinternal.NextLinkFromResultMap(resultMap); ok {
+		nextQuery, err := pcloudinternal.QueryFromNextLink(nextLink)
+		if err != nil {
+			return nil, nil, err
+		}
+		return discoveredAccounts, nextQuery, nil
+	}
+	return discoveredAccounts, nil, nil
+}
+
+// discoveredAccountsQuery builds the list query parameters of a discovered accounts filter.
+func discoveredAccountsQuery(filter *discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsFilter) map[string]string {
+	query := map[string]string{}
+	if filter.Search != "" {
+		query["search"] = filter.Search
+	}
+	if filter.SearchType != "" {
+		query["searchType"] = filter.SearchType
+	}
+	if filter.Sort != "" {
+		query["sort"] = filter.Sort
+	}
+	if filter.Offset > 0 {
+		query["offset"] = fmt.Sprintf("%d", filter.Offset)
+	}
+	if filter.Limit > 0 {
+		query["limit"] = fmt.Sprintf("%d", filter.Limit)
+	}
+	var conditions []string
+	if filter.PlatformType != "" {
+		conditions = append(conditions, fmt.Sprintf("platformType eq %s", filter.PlatformType))
+	}
+	if filter.Privileged != nil {
+		conditions = append(conditions, fmt.Sprintf("privileged eq %t", *filter.Privileged))
+	}
+	if filter.AccountEnabled != nil {
+		conditions = append(conditions, fmt.Sprintf("accountEnabled eq %t", *filter.AccountEnabled))
+	}
+	if len(conditions) > 0 {
+		query["filter"] = strings.Join(conditions, " AND ")
+	}
+	return query
+}
+
+func (s *IdsecPCloudDiscoveredAccountsService) listDiscoveredAccountsWithFilters(ctx context.Context, query map[string]string) <-chan *IdsecPCloudDiscoveredAccountsPage {
+	results := make(chan *IdsecPCloudDiscoveredAccountsPage)
+	go func() {
+		defer close(results)
+		for {
+			items, nextQuery, err := s.fetchDiscoveredAccountsListPage(ctx, query)
+			if err != nil {
+				select {
+				case results <- &IdsecPCloudDiscoveredAccountsPage{Err: err}:
+				case <-ctx.Done():
+				}
+				return
+			}
+			select {
+			case results <- &IdsecPCloudDiscoveredAccountsPage{Items: items}:
+			case <-ctx.Done():
+				return
+			}
+			if nextQuery == nil {
+				return
+			}
+			query = nextQuery
+		}
+	}()
+	return results
+}
+
+// List retrieves a list of IdsecPCloudDiscoveredAccount pages.
+// On failure during pagination, the channel emits a final page with Err set; otherwise Err is nil on every page.
+// https://docs.cyberark.com/privilege-cloud-standard/latest/en/content/webservices/getdiscoveredaccounts.htm
+func (s *IdsecPCloudDiscoveredAccountsService) List() (<-chan *IdsecPCloudDiscoveredAccountsPage, error) {
+	return s.ListContext(context.Background())
+}
+
+// ListContext is like List but accepts a context.Context. Callers that stop iterating the
+// returned channel early must cancel the context to release the producer goroutine.
+func (s *IdsecPCloudDiscoveredAccountsService) ListContext(ctx context.Context) (<-chan *IdsecPCloudDiscoveredAccountsPage, error) {
+	return s.ListByContext(ctx, &discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsFilter{})
+}
+
+// ListBy retrieves a list of IdsecPCloudDiscoveredAccount pages with filters.
+// On failure during pagination, the channel emits a final page with Err set; otherwise Err is nil on every page.
+// https://docs.cyberark.com/privilege-cloud-standard/latest/en/content/webservices/getdiscoveredaccounts.htm
+func (s *IdsecPCloudDiscoveredAccountsService) ListBy(discoveredAccountsFilter *discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsFilter) (<-chan *IdsecPCloudDiscoveredAccountsPage, error) {
+	return s.ListByContext(context.Background(), discoveredAccountsFilter)
+}
+
+// ListByContext is like ListBy but accepts a context.Context. Callers that stop iterating the
+// returned channel early must cancel the context to release the producer goroutine.
+func (s *IdsecPCloudDiscoveredAccountsService) ListByContext(ctx context.Context, discoveredAccountsFilter *discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsFilter) (<-chan *IdsecPCloudDiscoveredAccountsPage, error) {
+	s.Logger.Info("Listing discovered accounts")
+	return s.listDiscoveredAccountsWithFilters(ctx, discoveredAccountsQuery(discoveredAccountsFilter)), nil
+}
+
+// Get retrieves a discovered account by its ID.
+// https://docs.cyberark.com/privilege-cloud-standard/latest/en/content/webservices/getdiscoveredaccountdetails.htm
+func (s *IdsecPCloudDiscoveredAccountsService) Get(getDiscoveredAccount *discoveredaccountsmodels.IdsecPCloudGetDiscoveredAccount) (*discoveredaccountsmodels.IdsecPCloudDiscoveredAccount, error) {
+	s.Logger.Info("Retrieving discovered account [%s]", getDiscoveredAccount.DiscoveredAccountID)
+	response, err := s.ISPClient().Get(context.Background(), fmt.Sprintf(discoveredAccountURL, getDiscoveredAccount.DiscoveredAccountID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to retrieve discovered account - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	}
+	discoveredAccountJSON, err := common.DeserializeJSONSnake(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeDiscoveredAccount(discoveredAccountJSON)
+}
+
+// Delete deletes a discovered account from the pending list.
+// https://docs.cyberark.com/privilege-cloud-standard/latest/en/content/webservices/deletediscoveredaccounts.htm
+func (s *IdsecPCloudDiscoveredAccountsService) Delete(deleteDiscoveredAccount *discoveredaccountsmodels.IdsecPCloudDeleteDiscoveredAccount) error {
+	s.Logger.Info("Deleting discovered account [%s]", deleteDiscoveredAccount.DiscoveredAccountID)
+	response, err := s.ISPClient().Delete(context.Background(), fmt.Sprintf(discoveredAccountURL, deleteDiscoveredAccount.DiscoveredAccountID), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to delete discovered account - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	}
+	return nil
+}
+
+// Onboard onboards a discovered account into a safe with a platform, and removes it from the pending list
+// unless KeepDiscoveredAccount is set.
+//
+// Discovered accounts carry no secret, so unless a secret is given the onboarded account is marked for
+// reconciliation by CPM, or for the secret action of the request. When a later step fails the error names
+// the created account, and onboarding again with its AccountID retries only the remaining steps.
+func (s *IdsecPCloudDiscoveredAccountsService) Onboard(onboardDiscoveredAccount *discoveredaccountsmodels.IdsecPCloudOnboardDiscoveredAccount) (*accountsmodels.IdsecPCloudAccount, error) {
+	discoveredAccount, err := s.Get(&discoveredaccountsmodels.IdsecPCloudGetDiscoveredAccount{DiscoveredAccountID: onboardDiscoveredAccount.DiscoveredAccountID})
+	if err != nil {
+		return nil, err
+	}
+	progress := &discoveredaccountsmodels.IdsecPCloudDiscoveredAccountOnboardingProgress{AccountID: onboardDiscoveredAccount.AccountID}
+	return s.onboard(discoveredAccount, onboardDiscoveredAccount, progress, func() error { return nil })
+}
+
+// onboard runs the onboarding steps progress does not record as done yet, calling saveProgress after each of them.
+func (s *IdsecPCloudDiscoveredAccountsService) onboard(
+	discoveredAccount *discoveredaccountsmodels.IdsecPCloudDiscoveredAccount,
+	onboardDiscoveredAccount *discoveredaccountsmodels.IdsecPCloudOnboardDiscoveredAccount,
+	progress *discoveredaccountsmodels.IdsecPCloudDiscoveredAccountOnboardingProgress,
+	saveProgress func() error,
+) (*accountsmodels.IdsecPCloudAccount, error) {
+	var account *accountsmodels.IdsecPCloudAccount
+	var err error
+	if progress.AccountID != "" {
+		s.Logger.Info("Resuming onboarding of discovered account [%s] as account [%s]", discoveredAccount.DiscoveredAccountID, progress.AccountID)
+		account, err = s.accountsService.Get(&accountsmodels.IdsecPCloudGetAccount{AccountID: progress.AccountID})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		s.Logger.Info("Onboarding discovered account [%s] into safe [%s]", discoveredAccount.DiscoveredAccountID, onboardDiscoveredAccount.SafeName)
+		addAccount := &accountsmodels.IdsecPCloudAddAccount{
+			Name:       onboardDiscoveredAccount.Name,
+			SafeName:   onboardDiscoveredAccount.SafeName,
+			PlatformID: onboardDiscoveredAccount.PlatformID,
+			Username:   discoveredAccount.Username,
+			Address:    discoveredAccount.Address,
+			Secret:     onboardDiscoveredAccount.Secret,
+			SecretType: onboardDiscoveredAccount.SecretType,
+		}
+		addAccount.AutomaticManagementEnabled = onboardDiscoveredAccount.AutomaticManagementEnabled
+		addAccount.ManualManagementReason = onboardDiscoveredAccount.ManualManagementReason
+		account, err = s.accountsService.Create(addAccount)
+		if err != nil {
+			return nil, err
+		}
+		progress.AccountID = account.AccountID
+		if err := saveProgress(); err != nil {
+			return account, err
+		}
+	}
+	if !progress.SecretManaged {
+		if err := s.manageOnboardedSecret(account.AccountID, onboardingSecretAction(onboardDiscoveredAccount)); err != nil {
+			return account, fmt.Errorf("account [%s] was onboarded but its secret was not managed: %w", account.AccountID, err)
+		}
+		progress.SecretManaged = true
+		if err := saveProgress(); err != nil {
+			return account, err
+		}
+	}
+	if !onboardDiscoveredAccount.KeepDiscoveredAccount {
+		err = s.Delete(&discoveredaccountsmodels.IdsecPCloudDeleteDiscoveredAccount{DiscoveredAccountID: discoveredAccount.DiscoveredAccountID})
+		if err != nil {
+			return account, fmt.Errorf("account [%s] was onboarded but its discovered account was not deleted: %w", account.AccountID, err)
+		}
+	}
+	return account, nil
+}
+
+// onboardingSecretAction returns the secret action of an onboarding, which defaults to reconciliation unless a secret is given.
+func onboardingSecretAction(onboardDiscoveredAccount *discoveredaccountsmodels.IdsecPCloudOnboardDiscoveredAccount) string {
+	if onboardDiscoveredAccount.SecretAction != "" {
+		return onboardDiscoveredAccount.SecretAction
+	}
+	if onboardDiscoveredAccount.Secret != "" {
+		return discoveredaccountsmodels.OnboardingSecretActionNone
+	}
+	return discoveredaccountsmodels.OnboardingSecretActionReconcile
+}
+
+func (s *IdsecPCloudDiscoveredAccountsService) manageOnboardedSecret(accountID string, secretAction string) error {
+	switch secretAction {
+	case discoveredaccountsmodels.OnboardingSecretActionReconcile:
+		return s.accountsService.ReconcileCredentials(&accountsmodels.IdsecPCloudReconcileAccountCredentials{AccountID: accountID})
+	case discoveredaccountsmodels.OnboardingSecretActionChange:
+		return s.accountsService.ChangeCredentials(&accountsmodels.IdsecPCloudChangeAccountCredentials{AccountID: accountID})
+	case discoveredaccountsmodels.OnboardingSecretActionNone:
+		return nil
+	default:
+		return fmt.Errorf("unsupported secret action [%s]", secretAction)
+	}
+}
+
+// onboardingRule is an onboarding rule with its compiled patterns.
+type onboardingRule struct {
+	*discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsOnboardingRule
+	address  *regexp.Regexp
+	username *regexp.Regexp
+	os       *regexp.Regexp
+}
+
+func compileOnboardingRules(rules []discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsOnboardingRule) ([]onboardingRule, error) {
+	compiledRules := make([]onboardingRule, 0, len(rules))
+	for i := range rules {
+		rule := onboardingRule{IdsecPCloudDiscoveredAccountsOnboardingRule: &rules[i]}
+		if rule.SafeName == "" || rule.PlatformID == "" {
+			return nil, fmt.Errorf("onboarding rule [%s] must set a safe name and a platform ID", rule.Name)
+		}
+		switch rule.SecretAction {
+		case "", discoveredaccountsmodels.OnboardingSecretActionReconcile, discoveredaccountsmodels.OnboardingSecretActionChange, discoveredaccountsmodels.OnboardingSecretActionNone:
+		default:
+			return nil, fmt.Errorf("onboarding rule [%s] has an unsupported secret action [%s]", rule.Name, rule.SecretAction)
+		}
+		for _, pattern := range []struct {
+			value    string
+			compiled **regexp.Regexp
+		}{
+			{rule.AddressPattern, &rule.address},
+			{rule.UsernamePattern, &rule.username},
+			{rule.OSPattern, &rule.os},
+		} {
+			if pattern.value == "" {
+				continue
+			}
+			compiled, err := regexp.Compile(pattern.value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern in onboarding rule [%s]: %w", rule.Name, err)
+			}
+			*pattern.compiled = compiled
+		}
+		compiledRules = append(compiledRules, rule)
+	}
+	return compiledRules, nil
+}
+
+func (r *onboardingRule) matches(discoveredAccount *discoveredaccountsmodels.IdsecPCloudDiscoveredAccount) bool {
+	if r.PrivilegedOnly && !discoveredAccount.Privileged {
+		return false
+	}
+	if r.address != nil && !r.address.MatchString(discoveredAccount.Address) {
+		return false
+	}
+	if r.username != nil && !r.username.MatchString(discoveredAccount.Username) {
+		return false
+	}
+	if r.os != nil && !r.os.MatchString(discoveredAccount.OSFamily) && !r.os.MatchString(discoveredAccount.OSVersion) {
+		return false
+	}
+	return true
+}
+
+// AutoOnboard onboards the discovered accounts matched by onboarding rules.
+//
+// The discovered accounts selected by the filter are listed first, then every account is onboarded with the
+// safe and platform of the first rule that matches it. Rules are evaluated client-side. A dry run only
+// reports the planned onboarding. The result holds the outcome of every discovered account, and an error
+// is returned alongside it when any account failed to onboard.
+//
+// Discovered accounts carry no secret, so every onboarded account is marked for the secret action of its
+// rule, reconciliation by default. With a state file, the ID of every created account is recorded as soon
+// as it is created, and a rerun with the same state file skips creating it again and only retries the
+// steps that failed, such as deleting the discovered account.
+func (s *IdsecPCloudDiscoveredAccountsService) AutoOnboard(autoOnboard *discoveredaccountsmodels.IdsecPCloudAutoOnboardDiscoveredAccounts) (*discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsOnboardingResult, error) {
+	rules, err := compileOnboardingRules(autoOnboard.Rules)
+	if err != nil {
+		return nil, err
+	}
+	state, err := loadOrCreateOnboardingState(autoOnboard.StateFile)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	pages, err := s.ListByContext(ctx, &autoOnboard.IdsecPCloudDiscoveredAccountsFilter)
+	if err != nil {
+		return nil, err
+	}
+	// Onboarding deletes discovered accounts, which would shift the pages still to be listed
+	var discoveredAccounts []*discoveredaccountsmodels.IdsecPCloudDiscoveredAccount
+	for page := range pages {
+		if page.Err != nil {
+			return nil, page.Err
+		}
+		discoveredAccounts = append(discoveredAccounts, page.Items...)
+	}
+	result := &discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsOnboardingResult{
+		Items: make([]discoveredaccountsmodels.IdsecPCloudDiscoveredAccountOnboarding, 0, len(discoveredAccounts)),
+	}
+	var failures []string
+	for _, discoveredAccount := range discoveredAccounts {
+		item := discoveredaccountsmodels.IdsecPCloudDiscoveredAccountOnboarding{
+			DiscoveredAccountID: discoveredAccount.DiscoveredAccountID,
+			Username:            discoveredAccount.Username,
+			Address:             discoveredAccount.Address,
+			Status:              discoveredaccountsmodels.OnboardingUnmatched,
+		}
+		for i := range rules {
+			if !rules[i].matches(discoveredAccount) {
+				continue
+			}
+			item.Rule = rules[i].Name
+			item.SafeName = rules[i].SafeName
+			item.PlatformID = rules[i].PlatformID
+			if autoOnboard.DryRun {
+				item.Status = discoveredaccountsmodels.OnboardingPlanned
+				break
+			}
+			progress, ok := state.Accounts[discoveredAccount.DiscoveredAccountID]
+			if !ok {
+				progress = &discoveredaccountsmodels.IdsecPCloudDiscoveredAccountOnboardingProgress{}
+			}
+			saveProgress := func() error {
+				state.Accounts[discoveredAccount.DiscoveredAccountID] = progress
+				return saveOnboardingState(autoOnboard.StateFile, state)
+			}
+			account, err := s.onboard(discoveredAccount, &discoveredaccountsmodels.IdsecPCloudOnboardDiscoveredAccount{
+				DiscoveredAccountID:   discoveredAccount.DiscoveredAccountID,
+				SafeName:              rules[i].SafeName,
+				PlatformID:            rules[i].PlatformID,
+				SecretAction:          rules[i].SecretAction,
+				KeepDiscoveredAccount: autoOnboard.KeepDiscoveredAccounts,
+			}, progress, saveProgress)
+			if account != nil {
+				item.AccountID = account.AccountID
+			}
+			if err != nil {
+				item.Status = discoveredaccountsmodels.OnboardingFailed
+				item.Error = err.Error()
+				failures = append(failures, discoveredAccount.DiscoveredAccountID)
+			} else {
+				item.Status = discoveredaccountsmodels.OnboardingOnboarded
+			}
+			break
+		}
+		addOnboardingItem(result, item)
+		if item.Status == discoveredaccountsmodels.OnboardingFailed && autoOnboard.StopOnFailure {
+			break
+		}
+	}
+	if len(failures) > 0 {
+		return result, fmt.Errorf("failed to onboard discovered accounts [%s]", strings.Join(failures, ", "))
+	}
+	return result, nil
+}
+
+func addOnboardingItem(result *discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsOnboardingResult, item discoveredaccountsmodels.IdsecPCloudDiscoveredAccountOnboarding) {
+	result.Items = append(result.Items, item)
+	switch item.Status {
+	case discoveredaccountsmodels.OnboardingOnboarded:
+		result.Onboarded++
+	case discoveredaccountsmodels.OnboardingPlanned:
+		result.Planned++
+	case discoveredaccountsmodels.OnboardingFailed:
+		result.Failed++
+	case discoveredaccountsmodels.OnboardingUnmatched:
+		result.Unmatched++
+	}
+}
+
+// loadOrCreateOnboardingState loads an auto-onboarding state file, or returns an empty state when it does not exist yet.
+func loadOrCreateOnboardingState(path string) (*discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsOnboardingState, error) {
+	state := &discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsOnboardingState{
+		Version:  discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsOnboardingStateVersion,
+		Accounts: map[string]*discoveredaccountsmodels.IdsecPCloudDiscoveredAccountOnboardingProgress{},
+	}
+	if path == "" {
+		return state, nil
+	}
+	content, err := os.ReadFile(path) // #nosec G304
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read onboarding state [%s]: %w", path, err)
+	}
+	if err := json.Unmarshal(content, state); err != nil {
+		return nil, fmt.Errorf("failed to parse onboarding state [%s]: %w", path, err)
+	}
+	if state.Version != discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsOnboardingStateVersion {
+		return nil, fmt.Errorf("unsupported onboarding state version [%d], expected [%d]", state.Version, discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsOnboardingStateVersion)
+	}
+	if state.Accounts == nil {
+		state.Accounts = map[string]*discoveredaccountsmodels.IdsecPCloudDiscoveredAccountOnboardingProgress{}
+	}
+	return state, nil
+}
+
+// saveOnboardingState atomically replaces an auto-onboarding state file. Nothing is saved without a path.
+func saveOnboardingState(path string, state *discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsOnboardingState) error {
+	if path == "" {
+		return nil
+	}
+	state.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tempFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write onboarding state [%s]: %w", path, err)
+	}
+	defer func() {
+		_ = os.Remove(tempFile.Name())
+	}()
+	if _, err := tempFile.Write(content); err != nil {
+		_ = tempFile.Close()
+		return fmt.Errorf("failed to write onboarding state [%s]: %w", path, err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("failed to write onboarding state [%s]: %w", path, err)
+	}
+	if err := os.Rename(tempFile.Name(), path); err != nil {
+		return fmt.Errorf("failed to write onboarding state [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// ServiceConfig returns the service configuration for the IdsecPCloudDiscoveredAccountsService.
+func (s *IdsecPCloudDiscoveredAccountsService) ServiceConfig() services.IdsecServiceConfig {
+	return ServiceConfig
+}
@@ -0,0 +1,309 @@
+package discoveredaccounts
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts"
+	discoveredaccountsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/discoveredaccounts/models"
+	pcloudint "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/internal"
+)
+
+func newTestPCloudDiscoveredAccountsService(parts *pcloudint.MockISPServiceParts) *IdsecPCloudDiscoveredAccountsService {
+	return &IdsecPCloudDiscoveredAccountsService{
+		IdsecBaseService:    parts.BaseService,
+		IdsecISPBaseService: parts.ISPBase,
+		accountsService: &accounts.IdsecPCloudAccountsService{
+			IdsecBaseService:    parts.BaseService,
+			IdsecISPBaseService: parts.ISPBase,
+		},
+	}
+}
+
+// fakeDiscovery serves two pages of discovered accounts, account creation, retrieval and secret actions,
+// and discovered account deletion.
+type fakeDiscovery struct {
+	mu            sync.Mutex
+	created       []map[string]interface{}
+	deleted       []string
+	secretActions []string
+	failAddresses map[string]bool
+	failDeletes   map[string]bool
+	listQueries   []string
+}
+
+func (f *fakeDiscovery) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/api/DiscoveredAccounts":
+		f.listQueries = append(f.listQueries, r.URL.RawQuery)
+		if r.URL.Query().Get("offset") == "" {
+			_, _ = fmt.Fprint(w, `{"value": [
+				{"id": "d1", "userName": "root", "address": "db01.example.com", "osFamily": "Linux", "privileged": true},
+				{"id": "d2", "userName": "Administrator", "address": "win01.example.com", "osFamily": "Windows", "osVersion": "Windows Server 2022", "privileged": true}
+			], "nextLink": "api/DiscoveredAccounts?offset=2&limit=2"}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"value": [
+			{"id": "d3", "userName": "svc_backup", "address": "db02.example.com", "osFamily": "Linux", "privileged": false},
+			{"id": "d4", "userName": "guest", "address": "kiosk.example.com", "osFamily": "Windows", "privileged": true}
+		]}`)
+	case r.Method == http.MethodGet && r.URL.Path == "/api/DiscoveredAccounts/d1":
+		_, _ = fmt.Fprint(w, `{"id": "d1", "userName": "root", "address": "db01.example.com", "osFamily": "Linux", "privileged": true}`)
+	case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/DiscoveredAccounts/"):
+		discoveredAccountID := strings.TrimPrefix(r.URL.Path, "/api/DiscoveredAccounts/")
+		if f.failDeletes[discoveredAccountID] {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		f.deleted = append(f.deleted, discoveredAccountID)
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPost && (strings.HasSuffix(r.URL.Path, "/reconcile") || strings.HasSuffix(r.URL.Path, "/change")):
+		f.secretActions = append(f.secretActions, strings.TrimPrefix(r.URL.Path, "/api/accounts/"))
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/accounts/"):
+		_, _ = fmt.Fprintf(w, `{"id": %q, "name": "account", "safeName": "LinuxRoot", "platformId": "UnixSSH", "userName": "root", "address": "db01.example.com"}`,
+			strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/accounts/"), "/"))
+	case r.Method == http.MethodPost && r.URL.Path == "/api/accounts":
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if f.failAddresses[fmt.Sprint(body["address"])] {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"ErrorMessage": "platform not found"}`)
+			return
+		}
+		f.created = append(f.created, body)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"id": "acc-%d", "name": "account", "safeName": %q, "platformId": %q, "userName": %q, "address": %q}`,
+			len(f.created), fmt.Sprint(body["safeName"]), fmt.Sprint(body["platformId"]), fmt.Sprint(body["username"]), fmt.Sprint(body["address"]))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestListBy(t *testing.T) {
+	t.Parallel()
+	fake := &fakeDiscovery{}
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, fake)
+	t.Cleanup(cleanup)
+
+	svc := newTestPCloudDiscoveredAccountsService(parts)
+	privileged := true
+	pages, err := svc.ListBy(&discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsFilter{PlatformType: "Unix", Privileged: &privileged})
+	require.NoError(t, err)
+	var discoveredAccounts []*discoveredaccountsmodels.IdsecPCloudDiscoveredAccount
+	for page := range pages {
+		require.NoError(t, page.Err)
+		discoveredAccounts = append(discoveredAccounts, page.Items...)
+	}
+	require.Len(t, discoveredAccounts, 4)
+	require.Equal(t, "d1", discoveredAccounts[0].DiscoveredAccountID)
+	require.Equal(t, "root", discoveredAccounts[0].Username)
+	require.Equal(t, "Windows Server 2022", discoveredAccounts[1].OSVersion)
+	require.Contains(t, fake.listQueries[0], "filter=platformType+eq+Unix+AND+privileged+eq+true")
+}
+
+func TestOnboard(t *testing.T) {
+	t.Parallel()
+	fake := &fakeDiscovery{}
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, fake)
+	t.Cleanup(cleanup)
+
+	svc := newTestPCloudDiscoveredAccountsService(parts)
+	account, err := svc.Onboard(&discoveredaccountsmodels.IdsecPCloudOnboardDiscoveredAccount{
+		DiscoveredAccountID: "d1",
+		SafeName:            "LinuxRoot",
+		PlatformID:          "UnixSSH",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "acc-1", account.AccountID)
+	require.Len(t, fake.created, 1)
+	require.Equal(t, "root", fake.created[0]["username"])
+	require.Equal(t, "db01.example.com", fake.created[0]["address"])
+	require.Equal(t, "LinuxRoot", fake.created[0]["safeName"])
+	require.Equal(t, []string{"acc-1/reconcile"}, fake.secretActions)
+	require.Equal(t, []string{"d1"}, fake.deleted)
+}
+
+func TestOnboard_secretGiven(t *testing.T) {
+	t.Parallel()
+	fake := &fakeDiscovery{}
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, fake)
+	t.Cleanup(cleanup)
+
+	svc := newTestPCloudDiscoveredAccountsService(parts)
+	_, err := svc.Onboard(&discoveredaccountsmodels.IdsecPCloudOnboardDiscoveredAccount{
+		DiscoveredAccountID: "d1",
+		SafeName:            "LinuxRoot",
+		PlatformID:          "UnixSSH",
+		Secret:              "secret",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "secret", fake.created[0]["secret"])
+	require.Empty(t, fake.secretActions)
+}
+
+func TestOnboard_resumesCreatedAccount(t *testing.T) {
+	t.Parallel()
+	fake := &fakeDiscovery{}
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, fake)
+	t.Cleanup(cleanup)
+
+	svc := newTestPCloudDiscoveredAccountsService(parts)
+	account, err := svc.Onboard(&discoveredaccountsmodels.IdsecPCloudOnboardDiscoveredAccount{
+		DiscoveredAccountID: "d1",
+		SafeName:            "LinuxRoot",
+		PlatformID:          "UnixSSH",
+		SecretAction:        "change",
+		AccountID:           "acc-7",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "acc-7", account.AccountID)
+	require.Empty(t, fake.created)
+	require.Equal(t, []string{"acc-7/change"}, fake.secretActions)
+	require.Equal(t, []string{"d1"}, fake.deleted)
+}
+
+func TestAutoOnboard(t *testing.T) {
+	t.Parallel()
+	rules := []discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsOnboardingRule{
+		{Name: "linux-root", UsernamePattern: "^root$", OSPattern: "(?i)linux", SafeName: "LinuxRoot", PlatformID: "UnixSSH"},
+		{Name: "windows-admins", OSPattern: "Windows Server", PrivilegedOnly: true, SafeName: "WinAdmins", PlatformID: "WinServerLocal"},
+		{Name: "db-services", AddressPattern: `^db\d+\.`, SafeName: "DBServices", PlatformID: "UnixSSH"},
+	}
+	tests := []struct {
+		name             string
+		dryRun           bool
+		stopOnFailure    bool
+		failAddresses    map[string]bool
+		expectedStatuses []string
+		expectedSafes    []string
+		expectedDeleted  []string
+		expectedError    string
+	}{
+		{
+			name:             "onboards_by_first_matching_rule",
+			expectedStatuses: []string{"onboarded", "onboarded", "onboarded", "unmatched"},
+			expectedSafes:    []string{"LinuxRoot", "WinAdmins", "DBServices", ""},
+			expectedDeleted:  []string{"d1", "d2", "d3"},
+		},
+		{
+			name:             "dry_run",
+			dryRun:           true,
+			expectedStatuses: []string{"planned", "planned", "planned", "unmatched"},
+			expectedSafes:    []string{"LinuxRoot", "WinAdmins", "DBServices", ""},
+		},
+		{
+			name:             "continues_after_failure",
+			failAddresses:    map[string]bool{"win01.example.com": true},
+			expectedStatuses: []string{"onboarded", "failed", "onboarded", "unmatched"},
+			expectedSafes:    []string{"LinuxRoot", "WinAdmins", "DBServices", ""},
+			expectedDeleted:  []string{"d1", "d3"},
+			expectedError:    "failed to onboard discovered accounts [d2]",
+		},
+		{
+			name:             "stops_on_failure",
+			stopOnFailure:    true,
+			failAddresses:    map[string]bool{"win01.example.com": true},
+			expectedStatuses: []string{"onboarded", "failed"},
+			expectedSafes:    []string{"LinuxRoot", "WinAdmins"},
+			expectedDeleted:  []string{"d1"},
+			expectedError:    "failed to onboard discovered accounts [d2]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			fake := &fakeDiscovery{failAddresses: tt.failAddresses}
+			parts, cleanup := pcloudint.SetupMockISPServiceParts(t, fake)
+			t.Cleanup(cleanup)
+
+			svc := newTestPCloudDiscoveredAccountsService(parts)
+			result, err := svc.AutoOnboard(&discoveredaccountsmodels.IdsecPCloudAutoOnboardDiscoveredAccounts{
+				Rules:         rules,
+				DryRun:        tt.dryRun,
+				StopOnFailure: tt.stopOnFailure,
+			})
+			if tt.expectedError != "" {
+				require.ErrorContains(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NotNil(t, result)
+			var statuses, safes []string
+			for _, item := range result.Items {
+				statuses = append(statuses, item.Status)
+				safes = append(safes, item.SafeName)
+			}
+			require.Equal(t, tt.expectedStatuses, statuses)
+			require.Equal(t, tt.expectedSafes, safes)
+			require.Equal(t, tt.expectedDeleted, fake.deleted)
+			require.Equal(t, len(tt.expectedDeleted), result.Onboarded)
+		})
+	}
+}
+
+func TestAutoOnboard_invalidRule(t *testing.T) {
+	t.Parallel()
+	svc := &IdsecPCloudDiscoveredAccountsService{}
+	_, err := svc.AutoOnboard(&discoveredaccountsmodels.IdsecPCloudAutoOnboardDiscoveredAccounts{
+		Rules: []discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsOnboardingRule{{Name: "bad", AddressPattern: "(", SafeName: "Safe", PlatformID: "Platform"}},
+	})
+	require.ErrorContains(t, err, "invalid pattern in onboarding rule [bad]")
+	_, err = svc.AutoOnboard(&discoveredaccountsmodels.IdsecPCloudAutoOnboardDiscoveredAccounts{
+		Rules: []discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsOnboardingRule{{Name: "no-safe", PlatformID: "Platform"}},
+	})
+	require.ErrorContains(t, err, "must set a safe name and a platform ID")
+	_, err = svc.AutoOnboard(&discoveredaccountsmodels.IdsecPCloudAutoOnboardDiscoveredAccounts{
+		Rules: []discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsOnboardingRule{{Name: "bad-action", SafeName: "Safe", PlatformID: "Platform", SecretAction: "rotate"}},
+	})
+	require.ErrorContains(t, err, "unsupported secret action [rotate]")
+}
+
+func TestAutoOnboard_stateFileSkipsCreatedAccounts(t *testing.T) {
+	t.Parallel()
+	fake := &fakeDiscovery{failDeletes: map[string]bool{"d2": true}}
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, fake)
+	t.Cleanup(cleanup)
+
+	svc := newTestPCloudDiscoveredAccountsService(parts)
+	autoOnboard := &discoveredaccountsmodels.IdsecPCloudAutoOnboardDiscoveredAccounts{
+		Rules: []discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsOnboardingRule{
+			{Name: "windows-admins", OSPattern: "Windows Server", SafeName: "WinAdmins", PlatformID: "WinServerLocal", SecretAction: "change"},
+			{Name: "linux", OSPattern: "Linux", SafeName: "Linux", PlatformID: "UnixSSH"},
+		},
+		StateFile: filepath.Join(t.TempDir(), "onboarding.json"),
+	}
+	result, err := svc.AutoOnboard(autoOnboard)
+	require.ErrorContains(t, err, "failed to onboard discovered accounts [d2]")
+	require.Equal(t, "acc-2", result.Items[1].AccountID)
+	require.Contains(t, result.Items[1].Error, "account [acc-2] was onboarded but its discovered account was not deleted")
+	require.Len(t, fake.created, 3)
+	require.Equal(t, []string{"acc-1/reconcile", "acc-2/change", "acc-3/reconcile"}, fake.secretActions)
+	require.Equal(t, []string{"d1", "d3"}, fake.deleted)
+
+	content, err := os.ReadFile(autoOnboard.StateFile)
+	require.NoError(t, err)
+	var state discoveredaccountsmodels.IdsecPCloudDiscoveredAccountsOnboardingState
+	require.NoError(t, json.Unmarshal(content, &state))
+	require.Equal(t, "acc-2", state.Accounts["d2"].AccountID)
+	require.True(t, state.Accounts["d2"].SecretManaged)
+
+	fake.failDeletes = nil
+	fake.deleted = nil
+	result, err = svc.AutoOnboard(autoOnboard)
+	require.NoError(t, err)
+	require.Equal(t, "acc-2", result.Items[1].AccountID)
+	require.Len(t, fake.created, 3)
+	require.Len(t, fake.secretActions, 3)
+	require.Equal(t, []string{"d1", "d2", "d3"}, fake.deleted)
+}
@@ -0,0 +1,66 @@
+package models
+
+// Possible outcomes of onboarding a single discovered account.
+const (
+	OnboardingOnboarded = "onboarded"
+	OnboardingFailed    = "failed"
+	OnboardingUnmatched = "unmatched"
+	OnboardingPlanned   = "planned"
+)
+
+// Possible CPM actions that set the secret of an onboarded account.
+const (
+	OnboardingSecretActionReconcile = "reconcile"
+	OnboardingSecretActionChange    = "change"
+	OnboardingSecretActionNone      = "none"
+)
+
+// IdsecPCloudDiscoveredAccountsOnboardingStateVersion is the version of the auto-onboarding state file format.
+const IdsecPCloudDiscoveredAccountsOnboardingStateVersion = 1
+
+// IdsecPCloudAutoOnboardDiscoveredAccounts represents the request to onboard the discovered accounts matched by onboarding rules.
+//
+// Rules are evaluated client-side in order, and the first rule that matches a discovered account decides its safe and platform.
+type IdsecPCloudAutoOnboardDiscoveredAccounts struct {
+	IdsecPCloudDiscoveredAccountsFilter `mapstructure:",squash"`
+	Rules                               []IdsecPCloudDiscoveredAccountsOnboardingRule `json:"rules" mapstructure:"rules" desc:"The onboarding rules, evaluated in order" flag:"rules" validate:"required,min=1"`
+	DryRun                              bool                                          `json:"dry_run" mapstructure:"dry_run" desc:"Whether to only report which accounts would be onboarded" flag:"dry-run" default:"false"`
+	KeepDiscoveredAccounts              bool                                          `json:"keep_discovered_accounts" mapstructure:"keep_discovered_accounts" desc:"Whether to keep onboarded accounts in the pending list" flag:"keep-discovered-accounts" default:"false"`
+	StopOnFailure                       bool                                          `json:"stop_on_failure" mapstructure:"stop_on_failure" desc:"Whether to stop onboarding after the first account that failed to onboard" flag:"stop-on-failure" default:"false"`
+	StateFile                           string                                        `json:"state_file,omitempty" mapstructure:"state_file,omitempty" desc:"The onboarding state file. Created accounts are recorded in it, so a rerun with the same file retries only the remaining steps instead of creating them again" flag:"state-file"`
+}
+
+// IdsecPCloudDiscoveredAccountOnboardingProgress represents the onboarding steps completed for a single discovered account.
+type IdsecPCloudDiscoveredAccountOnboardingProgress struct {
+	AccountID     string `json:"account_id" mapstructure:"account_id" desc:"The ID of the account created for the discovered account"`
+	SecretManaged bool   `json:"secret_managed" mapstructure:"secret_managed" desc:"Whether the secret action of the account was triggered"`
+}
+
+// IdsecPCloudDiscoveredAccountsOnboardingState represents the auto-onboarding state persisted between runs.
+type IdsecPCloudDiscoveredAccountsOnboardingState struct {
+	Version   int                                                        `json:"version" mapstructure:"version" desc:"The version of the state file format"`
+	UpdatedAt string                                                     `json:"updated_at" mapstructure:"updated_at" desc:"The time the state was last saved (RFC3339)"`
+	Accounts  map[string]*IdsecPCloudDiscoveredAccountOnboardingProgress `json:"accounts" mapstructure:"accounts" desc:"The onboarding progress by discovered account ID"`
+}
+
+// IdsecPCloudDiscoveredAccountOnboarding represents the outcome of onboarding a single discovered account.
+type IdsecPCloudDiscoveredAccountOnboarding struct {
+	DiscoveredAccountID string `json:"discovered_account_id" mapstructure:"discovered_account_id" desc:"The ID of the discovered account"`
+	Username            string `json:"username" mapstructure:"username" desc:"The username of the discovered account"`
+	Address             string `json:"address" mapstructure:"address" desc:"The address of the discovered account"`
+	Rule                string `json:"rule,omitempty" mapstructure:"rule,omitempty" desc:"The name of the rule that matched the discovered account"`
+	SafeName            string `json:"safe_name,omitempty" mapstructure:"safe_name,omitempty" desc:"The Safe the account is onboarded into"`
+	PlatformID          string `json:"platform_id,omitempty" mapstructure:"platform_id,omitempty" desc:"The platform assigned to the account"`
+	AccountID           string `json:"account_id,omitempty" mapstructure:"account_id,omitempty" desc:"The ID of the onboarded account"`
+	Status              string `json:"status" mapstructure:"status" desc:"The outcome of the onboarding (onboarded, failed, unmatched, planned)"`
+	Error               string `json:"error,omitempty" mapstructure:"error,omitempty" desc:"The reason the onboarding failed"`
+}
+
+// IdsecPCloudDiscoveredAccountsOnboardingResult represents the outcome of onboarding discovered accounts by rules.
+type IdsecPCloudDiscoveredAccountsOnboardingResult struct {
+	Items     []IdsecPCloudDiscoveredAccountOnboarding `json:"items" mapstructure:"items" desc:"The outcome per discovered account, in listing order"`
+	Onboarded int                                      `json:"onboarded" mapstructure:"onboarded" desc:"The number of onboarded accounts"`
+	Planned   int                                      `json:"planned" mapstructure:"planned" desc:"The number of accounts that would be onboarded, on a dry run"`
+	Failed    int                                      `json:"failed" mapstructure:"failed" desc:"The number of accounts that failed to onboard"`
+	Unmatched int                                      `json:"unmatched" mapstructure:"unmatched" desc:"The number of accounts no rule matched"`
+}
@@ -0,0 +1,6 @@
+package models
+
+// IdsecPCloudDeleteDiscoveredAccount represents the details required to delete a discovered account.
+type IdsecPCloudDeleteDiscoveredAccount struct {
+	DiscoveredAccountID string `json:"discovered_account_id" mapstructure:"discovered_account_id" desc:"The ID of the discovered account to delete" flag:"discovered-account-id" validate:"required"`
+}
@@ -0,0 +1,26 @@
+package models
+
+// IdsecPCloudDiscoveredAccount represents a privileged account found by a discovery scan that is pending onboarding.
+type IdsecPCloudDiscoveredAccount struct {
+	DiscoveredAccountID        string                 `json:"discovered_account_id" mapstructure:"discovered_account_id" desc:"The unique ID of the discovered account" flag:"discovered-account-id"`
+	Username                   string                 `json:"username" mapstructure:"username" desc:"The username of the discovered account" flag:"username"`
+	Address                    string                 `json:"address" mapstructure:"address" desc:"The address of the machine the account was discovered on" flag:"address"`
+	DiscoveryDate              int                    `json:"discovery_date,omitempty" mapstructure:"discovery_date,omitempty" desc:"The time the account was discovered" flag:"discovery-date"`
+	AccountEnabled             bool                   `json:"account_enabled" mapstructure:"account_enabled" desc:"Whether the account is enabled on its machine" flag:"account-enabled"`
+	PlatformType               string                 `json:"platform_type,omitempty" mapstructure:"platform_type,omitempty" desc:"The platform type of the discovered account" flag:"platform-type"`
+	Domain                     string                 `json:"domain,omitempty" mapstructure:"domain,omitempty" desc:"The domain of the discovered account" flag:"domain"`
+	OSGroups                   string                 `json:"os_groups,omitempty" mapstructure:"os_groups,omitempty" desc:"The operating system groups of the discovered account" flag:"os-groups"`
+	OSVersion                  string                 `json:"os_version,omitempty" mapstructure:"os_version,omitempty" desc:"The operating system version of the machine" flag:"os-version"`
+	OSFamily                   string                 `json:"os_family,omitempty" mapstructure:"os_family,omitempty" desc:"The operating system family of the machine" flag:"os-family"`
+	Privileged                 bool                   `json:"privileged" mapstructure:"privileged" desc:"Whether the account is privileged" flag:"privileged"`
+	PrivilegedCriteria         string                 `json:"privileged_criteria,omitempty" mapstructure:"privileged_criteria,omitempty" desc:"The criteria the account was found privileged by" flag:"privileged-criteria"`
+	UserDisplayName            string                 `json:"user_display_name,omitempty" mapstructure:"user_display_name,omitempty" desc:"The display name of the account user" flag:"user-display-name"`
+	Description                string                 `json:"description,omitempty" mapstructure:"description,omitempty" desc:"The description of the discovered account" flag:"description"`
+	OrganizationalUnit         string                 `json:"organizational_unit,omitempty" mapstructure:"organizational_unit,omitempty" desc:"The organizational unit of the discovered account" flag:"organizational-unit"`
+	LastLogonDateTime          int                    `json:"last_logon_date_time,omitempty" mapstructure:"last_logon_date_time,omitempty" desc:"The last time the account logged on" flag:"last-logon-date-time"`
+	LastPasswordSetDateTime    int                    `json:"last_password_set_date_time,omitempty" mapstructure:"last_password_set_date_time,omitempty" desc:"The last time the account password was set" flag:"last-password-set-date-time"`
+	PasswordNeverExpires       bool                   `json:"password_never_expires" mapstructure:"password_never_expires" desc:"Whether the account password never expires" flag:"password-never-expires"`
+	PasswordExpirationDateTime int                    `json:"password_expiration_date_time,omitempty" mapstructure:"password_expiration_date_time,omitempty" desc:"The time the account password expires" flag:"password-expiration-date-time"`
+	NumberOfDependencies       int                    `json:"number_of_dependencies,omitempty" mapstructure:"number_of_dependencies,omitempty" desc:"The number of dependencies of the discovered account" flag:"number-of-dependencies"`
+	AdditionalProperties       map[string]interface{} `json:"additional_properties,omitempty" mapstructure:"additional_properties,omitempty" desc:"Additional properties reported by the discovery" flag:"additional-properties"`
+}
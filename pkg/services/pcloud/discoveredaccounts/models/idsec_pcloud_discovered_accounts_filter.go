@@ -0,0 +1,13 @@
+package models
+
+// IdsecPCloudDiscoveredAccountsFilter represents the filter options for discovered accounts.
+type IdsecPCloudDiscoveredAccountsFilter struct {
+	Search         string `json:"search,omitempty" mapstructure:"search,omitempty" desc:"A list of keywords to search for in discovered accounts, separated by a space" flag:"search"`
+	SearchType     string `json:"search_type,omitempty" mapstructure:"search_type,omitempty" desc:"Get discovered accounts with the value specified in the Search parameter (contains or startswith)" flag:"search-type"`
+	Sort           string `json:"sort,omitempty" mapstructure:"sort,omitempty" desc:"Sort results by given key. Sort direction by asc (default) or desc" flag:"sort"`
+	PlatformType   string `json:"platform_type,omitempty" mapstructure:"platform_type,omitempty" desc:"The platform type to use as filter" flag:"platform-type"`
+	Privileged     *bool  `json:"privileged,omitempty" mapstructure:"privileged,omitempty" desc:"Whether to get only privileged or only non privileged discovered accounts" flag:"privileged"`
+	AccountEnabled *bool  `json:"account_enabled,omitempty" mapstructure:"account_enabled,omitempty" desc:"Whether to get only enabled or only disabled discovered accounts" flag:"account-enabled"`
+	Offset         int    `json:"offset,omitempty" mapstructure:"offset,omitempty" desc:"Offset of the first discovered account that is returned in the collection of results" flag:"offset"`
+	Limit          int    `json:"limit,omitempty" mapstructure:"limit,omitempty" desc:"The maximum number of returned discovered accounts (up to 1000)" flag:"limit"`
+}
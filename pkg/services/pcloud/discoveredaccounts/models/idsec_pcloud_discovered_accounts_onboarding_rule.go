@@ -0,0 +1,15 @@
+package models
+
+// IdsecPCloudDiscoveredAccountsOnboardingRule represents a rule that assigns a safe and a platform to the discovered accounts it matches.
+//
+// The patterns are regular expressions matched against the discovered account. An empty pattern matches every account.
+type IdsecPCloudDiscoveredAccountsOnboardingRule struct {
+	Name            string `json:"name" mapstructure:"name" desc:"The name of the rule" flag:"name"`
+	AddressPattern  string `json:"address_pattern,omitempty" mapstructure:"address_pattern,omitempty" desc:"A regular expression the address of the account must match" flag:"address-pattern"`
+	UsernamePattern string `json:"username_pattern,omitempty" mapstructure:"username_pattern,omitempty" desc:"A regular expression the username of the account must match" flag:"username-pattern"`
+	OSPattern       string `json:"os_pattern,omitempty" mapstructure:"os_pattern,omitempty" desc:"A regular expression the operating system family or version of the machine must match" flag:"os-pattern"`
+	PrivilegedOnly  bool   `json:"privileged_only" mapstructure:"privileged_only" desc:"Whether the rule matches only privileged accounts" flag:"privileged-only" default:"false"`
+	SafeName        string `json:"safe_name" mapstructure:"safe_name" desc:"The Safe matching accounts are onboarded into" flag:"safe-name" validate:"required"`
+	PlatformID      string `json:"platform_id" mapstructure:"platform_id" desc:"The platform assigned to matching accounts" flag:"platform-id" validate:"required"`
+	SecretAction    string `json:"secret_action,omitempty" mapstructure:"secret_action,omitempty" desc:"The CPM action that sets the secret of matching accounts once onboarded (reconcile,change,none)" flag:"secret-action" default:"reconcile" choices:"reconcile,change,none"`
+}
@@ -0,0 +1,6 @@
+package models
+
+// IdsecPCloudGetDiscoveredAccount represents the details required to retrieve a discovered account.
+type IdsecPCloudGetDiscoveredAccount struct {
+	DiscoveredAccountID string `json:"discovered_account_id" mapstructure:"discovered_account_id" desc:"The ID of the discovered account to retrieve" flag:"discovered-account-id" validate:"required"`
+}
@@ -0,0 +1,16 @@
+package models
+
+// IdsecPCloudOnboardDiscoveredAccount represents the details required to onboard a discovered account into a safe.
+type IdsecPCloudOnboardDiscoveredAccount struct {
+	DiscoveredAccountID        string `json:"discovered_account_id" mapstructure:"discovered_account_id" desc:"The ID of the discovered account to onboard" flag:"discovered-account-id" validate:"required"`
+	SafeName                   string `json:"safe_name" mapstructure:"safe_name" desc:"The Safe the account is onboarded into" flag:"safe-name" validate:"required"`
+	PlatformID                 string `json:"platform_id" mapstructure:"platform_id" desc:"The platform assigned to the onboarded account" flag:"platform-id" validate:"required"`
+	Name                       string `json:"name,omitempty" mapstructure:"name,omitempty" desc:"The name of the onboarded account. Generated from the safe, platform and address when not set" flag:"name" maxlength:"170"`
+	Secret                     string `json:"secret,omitempty" mapstructure:"secret,omitempty" desc:"The secret of the onboarded account" flag:"secret"`
+	SecretType                 string `json:"secret_type,omitempty" mapstructure:"secret_type,omitempty" desc:"The type of secret for the account (password,key)" flag:"secret-type" choices:"password,key"`
+	AutomaticManagementEnabled *bool  `json:"automatic_management_enabled,omitempty" mapstructure:"automatic_management_enabled,omitempty" desc:"Whether the account secret is managed automatically" flag:"automatic-management-enabled"`
+	ManualManagementReason     string `json:"manual_management_reason,omitempty" mapstructure:"manual_management_reason,omitempty" desc:"The reason for disabling automatic management" flag:"manual-management-reason"`
+	SecretAction               string `json:"secret_action,omitempty" mapstructure:"secret_action,omitempty" desc:"The CPM action that sets the secret of the onboarded account (reconcile,change,none). Defaults to reconcile when no secret is given, as discovered accounts carry no secret" flag:"secret-action" choices:"reconcile,change,none"`
+	AccountID                  string `json:"account_id,omitempty" mapstructure:"account_id,omitempty" desc:"The ID of an account already created for the discovered account. Creation is skipped and only the remaining onboarding steps are retried" flag:"account-id"`
+	KeepDiscoveredAccount      bool   `json:"keep_discovered_account" mapstructure:"keep_discovered_account" desc:"Whether to keep the discovered account in the pending list after it is onboarded" flag:"keep-discovered-account" default:"false"`
+}
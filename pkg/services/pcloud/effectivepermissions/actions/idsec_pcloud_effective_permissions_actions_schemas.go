@@ -0,0 +1,10 @@
+package actions
+
+import effectivepermissionsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/effectivepermissions/models"
+
+// ActionToSchemaMap maps action names to their corresponding schema structures.
+var ActionToSchemaMap = map[string]interface{}{
+	"analyze-safe":    &effectivepermissionsmodels.IdsecPCloudAnalyzeSafePermissions{},
+	"analyze-account": &effectivepermissionsmodels.IdsecPCloudAnalyzeAccountPermissions{},
+	"who-can":         &effectivepermissionsmodels.IdsecPCloudWhoCan{},
+}
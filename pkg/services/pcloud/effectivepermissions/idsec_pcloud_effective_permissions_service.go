@@ -0,0 +1,503 @@
+package effectivepermissions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/auth"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/identity/directories"
+	directoriesmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/identity/directories/models"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/identity/roles"
+	rolesmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/identity/roles/models"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts"
+	accountsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts/models"
+	commonpcloud "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/common"
+	effectivepermissionsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/effectivepermissions/models"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/safes"
+	safesmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/safes/models"
+)
+
+const (
+	userGroupsURL = "/api/UserGroups"
+)
+
+// riskRule is a combination of permissions that is risky when held by a single principal.
+type riskRule struct {
+	risk        string
+	description string
+	permissions []string
+}
+
+var riskRules = []riskRule{
+	{
+		risk:        effectivepermissionsmodels.RiskRetrieveWithoutConfirmation,
+		description: "Can retrieve secrets without the dual control confirmation of the safe",
+		permissions: []string{"retrieve_accounts", "access_without_confirmation"},
+	},
+	{
+		risk:        effectivepermissionsmodels.RiskGrantAndRetrieve,
+		description: "Can retrieve secrets and grant the same access to other members",
+		permissions: []string{"manage_safe_members", "retrieve_accounts"},
+	},
+}
+
+// IdsecPCloudEffectivePermissionsService is the service for analyzing who can do what on pCloud safes and accounts.
+type IdsecPCloudEffectivePermissionsService struct {
+	*services.IdsecBaseService
+	*services.IdsecISPBaseService
+
+	safesService       *safes.IdsecPCloudSafesService
+	accountsService    *accounts.IdsecPCloudAccountsService
+	rolesService       *roles.IdsecIdentityRolesService
+	directoriesService *directories.IdsecIdentityDirectoriesService
+}
+
+// NewIdsecPCloudEffectivePermissionsService creates a new instance of IdsecPCloudEffectivePermissionsService.
+func NewIdsecPCloudEffectivePermissionsService(authenticators ...auth.IdsecAuth) (*IdsecPCloudEffectivePermissionsService, error) {
+	pcloudEffectivePermissionsService := &IdsecPCloudEffectivePermissionsService{}
+	var pcloudEffectivePermissionsServiceInterface services.IdsecService = pcloudEffectivePermissionsService
+	baseService, err := services.NewIdsecBaseService(pcloudEffectivePermissionsServiceInterface, authenticators...)
+	if err != nil {
+		return nil, err
+	}
+	ispBaseAuth, err := baseService.Authenticator("isp")
+	if err != nil {
+		return nil, err
+	}
+	ispAuth := ispBaseAuth.(*auth.IdsecISPAuth)
+
+	ispBaseService, err := services.NewIdsecISPBaseServiceWithRetry(
+		ispAuth,
+		"privilegecloud",
+		".",
+		"passwordvault",
+		pcloudEffectivePermissionsService.refreshPCloudEffectivePermissionsAuth,
+		commonpcloud.DefaultPCloudRetryStrategy(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	safesService, err := safes.NewIdsecPCloudSafesService(authenticators...)
+	if err != nil {
+		return nil, err
+	}
+	accountsService, err := accounts.NewIdsecPCloudAccountsService(authenticators...)
+	if err != nil {
+		return nil, err
+	}
+
+	pcloudEffectivePermissionsService.IdsecBaseService = baseService
+	pcloudEffectivePermissionsService.IdsecISPBaseService = ispBaseService
+	pcloudEffectivePermissionsService.safesService = safesService
+	pcloudEffectivePermissionsService.accountsService = accountsService
+	pcloudEffectivePermissionsService.rolesService = safesService.RolesService
+	pcloudEffectivePermissionsService.directoriesService = safesService.RolesService.DirectoriesService
+	return pcloudEffectivePermissionsService, nil
+}
+
+func (s *IdsecPCloudEffectivePermissionsService) refreshPCloudEffectivePermissionsAuth(client *common.IdsecClient) error {
+	err := isp.RefreshClient(client, s.ISPAuth())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// permissionNames returns the json names of all safe member permissions.
+func permissionNames() []string {
+	permissionsType := reflect.TypeOf(safesmodels.IdsecPCloudSafeMemberPermissions{})
+	names := make([]string, 0, permissionsType.NumField())
+	for i := 0; i < permissionsType.NumField(); i++ {
+		names = append(names, strings.Split(permissionsType.Field(i).Tag.Get("json"), ",")[0])
+	}
+	return names
+}
+
+// grantedPermissions returns the json names of the permissions set in the given permissions.
+func grantedPermissions(permissions safesmodels.IdsecPCloudSafeMemberPermissions) []string {
+	granted := make([]string, 0)
+	permissionsValue := reflect.ValueOf(permissions)
+	for i, name := range permissionNames() {
+		if permissionsValue.Field(i).Bool() {
+			granted = append(granted, name)
+		}
+	}
+	return granted
+}
+
+// mergePermissions sets in target every permission set in source.
+func mergePermissions(target *safesmodels.IdsecPCloudSafeMemberPermissions, source safesmodels.IdsecPCloudSafeMemberPermissions) {
+	targetValue := reflect.ValueOf(target).Elem()
+	sourceValue := reflect.ValueOf(source)
+	for i := 0; i < targetValue.NumField(); i++ {
+		if sourceValue.Field(i).Bool() {
+			targetValue.Field(i).SetBool(true)
+		}
+	}
+}
+
+func hasPermissions(granted []string, required []string) bool {
+	for _, permission := range required {
+		found := false
+		for _, grantedPermission := range granted {
+			if grantedPermission == permission {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// principalRisks evaluates the risk rules against the effective permissions of the principal.
+// A risk is marked as combined when none of the principal's sources grants it on its own.
+func principalRisks(principal *effectivepermissionsmodels.IdsecPCloudEffectivePrincipal) []*effectivepermissionsmodels.IdsecPCloudPermissionRisk {
+	effective := grantedPermissions(principal.Permissions)
+	risks := make([]*effectivepermissionsmodels.IdsecPCloudPermissionRisk, 0)
+	for _, rule := range riskRules {
+		if !hasPermissions(effective, rule.permissions) {
+			continue
+		}
+		combined := true
+		for _, source := range principal.Sources {
+			if hasPermissions(source.Permissions, rule.permissions) {
+				combined = false
+				break
+			}
+		}
+		risks = append(risks, &effectivepermissionsmodels.IdsecPCloudPermissionRisk{
+			Risk:        rule.risk,
+			Description: rule.description,
+			Permissions: rule.permissions,
+			Combined:    combined,
+		})
+	}
+	return risks
+}
+
+// permissionsAnalysis accumulates the principals and warnings of a single analysis.
+type permissionsAnalysis struct {
+	principals map[string]*effectivepermissionsmodels.IdsecPCloudEffectivePrincipal
+	warnings   []string
+}
+
+func principalKey(principalType string, principalName string) string {
+	return principalType + "/" + strings.ToLower(principalName)
+}
+
+func (a *permissionsAnalysis) addPrincipal(principalType string, principalName string, principalID string, member *safesmodels.IdsecPCloudSafeMember, via []string) {
+	key := principalKey(principalType, principalName)
+	principal, ok := a.principals[key]
+	if !ok {
+		principal = &effectivepermissionsmodels.IdsecPCloudEffectivePrincipal{
+			PrincipalName: principalName,
+			PrincipalType: principalType,
+			Sources:       make([]*effectivepermissionsmodels.IdsecPCloudPermissionSource, 0),
+		}
+		a.principals[key] = principal
+	}
+	if principal.PrincipalID == "" {
+		principal.PrincipalID = principalID
+	}
+	mergePermissions(&principal.Permissions, member.Permissions)
+	principal.Sources = append(principal.Sources, &effectivepermissionsmodels.IdsecPCloudPermissionSource{
+		MemberName:  member.MemberName,
+		MemberType:  member.MemberType,
+		Via:         via,
+		Permissions: grantedPermissions(member.Permissions),
+	})
+}
+
+// expandRole adds the members of the identity role to the analysis, descending into nested roles.
+// A role that cannot be expanded is kept as a principal of its own so its permissions are not lost.
+func (s *IdsecPCloudEffectivePermissionsService) expandRole(analysis *permissionsAnalysis, member *safesmodels.IdsecPCloudSafeMember, listRoleMembers *rolesmodels.IdsecIdentityListRoleMembers, roleName string, via []string, visited map[string]bool) {
+	roleKey := strings.ToLower(roleName)
+	if visited[roleKey] {
+		return
+	}
+	visited[roleKey] = true
+	defer delete(visited, roleKey)
+	roleMembers, err := s.rolesService.ListMembers(listRoleMembers)
+	if err != nil {
+		s.Logger.Warning("Failed to list members of role [%s]: %v", roleName, err)
+		analysis.warnings = append(analysis.warnings, fmt.Sprintf("members of role [%s] could not be listed: %v", roleName, err))
+		analysis.addPrincipal(safesmodels.Role, roleName, listRoleMembers.RoleID, member, via)
+		return
+	}
+	nestedVia := append(append(make([]string, 0, len(via)+1), via...), roleName)
+	for _, roleMember := range roleMembers {
+		switch roleMember.MemberType {
+		case directoriesmodels.EntityTypeUser:
+			analysis.addPrincipal(safesmodels.User, roleMember.MemberName, roleMember.MemberID, member, nestedVia)
+		case directoriesmodels.EntityTypeGroup:
+			s.expandGroup(analysis, member, roleMember.MemberName, roleMember.MemberID, nestedVia)
+		case directoriesmodels.EntityTypeRole:
+			s.expandRole(analysis, member, &rolesmodels.IdsecIdentityListRoleMembers{RoleID: roleMember.MemberID}, roleMember.MemberName, nestedVia, visited)
+		}
+	}
+}
+
+// groupMembers returns the user names of the members of the Privilege Cloud group with the given name.
+// The boolean result is false when no group has that name.
+func (s *IdsecPCloudEffectivePermissionsService) groupMembers(groupName string) ([]string, bool, error) {
+	response, err := s.ISPClient().Get(context.Background(), userGroupsURL, map[string]string{
+		"filter":         fmt.Sprintf("groupName eq %s", groupName),
+		"includeMembers": "true",
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	defer func() {
+		if closeErr := response.Body.Close(); closeErr != nil {
+			common.GlobalLogger.Warning("Error closing response body")
+		}
+	}()
+	if response.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("failed to list members of group - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	}
+	result, err := common.DeserializeJSONSnake(response.Body)
+	if err != nil {
+		return nil, false, err
+	}
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, false, fmt.Errorf("failed to list members of group, unexpected result")
+	}
+	groups, ok := resultMap["value"].([]interface{})
+	if !ok {
+		return nil, false, fmt.Errorf("failed to list members of group, unexpected result")
+	}
+	for _, group := range groups {
+		groupMap, ok := group.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		// The filter is not guaranteed to be an exact match, keep only the group with the exact name
+		if name, _ := groupMap["group_name"].(string); !strings.EqualFold(name, groupName) {
+			continue
+		}
+		members := make([]string, 0)
+		groupMembersList, _ := groupMap["members"].([]interface{})
+		for _, groupMember := range groupMembersList {
+			groupMemberMap, ok := groupMember.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if username, _ := groupMemberMap["username"].(string); username != "" {
+				members = append(members, username)
+			}
+		}
+		return members, true, nil
+	}
+	return nil, false, nil
+}
+
+// expandGroup adds the users of the Privilege Cloud group to the analysis.
+// A group that cannot be expanded is kept as a principal of its own so its permissions are not lost.
+func (s *IdsecPCloudEffectivePermissionsService) expandGroup(analysis *permissionsAnalysis, member *safesmodels.IdsecPCloudSafeMember, groupName string, groupID string, via []string) {
+	members, found, err := s.groupMembers(groupName)
+	if err != nil {
+		s.Logger.Warning("Failed to list members of group [%s]: %v", groupName, err)
+		analysis.warnings = append(analysis.warnings, fmt.Sprintf("members of group [%s] could not be listed: %v", groupName, err))
+		analysis.addPrincipal(safesmodels.Group, groupName, groupID, member, via)
+		return
+	}
+	if !found {
+		analysis.warnings = append(analysis.warnings, fmt.Sprintf("group [%s] was not found in Privilege Cloud and was not expanded", groupName))
+		analysis.addPrincipal(safesmodels.Group, groupName, groupID, member, via)
+		return
+	}
+	nestedVia := append(append(make([]string, 0, len(via)+1), via...), groupName)
+	for _, username := range members {
+		analysis.addPrincipal(safesmodels.User, username, "", member, nestedVia)
+	}
+}
+
+// resolvePrincipal fills in the identity ID and directory of the principal from the identity directories.
+// A principal that cannot be found is reported as is, an error is returned when the lookup itself failed.
+func (s *IdsecPCloudEffectivePermissionsService) resolvePrincipal(principal *effectivepermissionsmodels.IdsecPCloudEffectivePrincipal) error {
+	if s.directoriesService == nil || principal.PrincipalType == safesmodels.Role {
+		return nil
+	}
+	entityType := directoriesmodels.EntityTypeUser
+	if principal.PrincipalType == safesmodels.Group {
+		entityType = directoriesmodels.EntityTypeGroup
+	}
+	entitiesPages, err := s.directoriesService.ListEntities(&directoriesmodels.IdsecIdentityListDirectoriesEntities{
+		Search:      principal.PrincipalName,
+		EntityTypes: []string{entityType},
+	})
+	if err != nil {
+		return err
+	}
+	for entitiesPage := range entitiesPages {
+		if entitiesPage.Err != nil {
+			return entitiesPage.Err
+		}
+		for _, entity := range entitiesPage.Items {
+			var baseEntity *directoriesmodels.IdsecIdentityBaseEntity
+			switch typedEntity := (*entity).(type) {
+			case *directoriesmodels.IdsecIdentityUserEntity:
+				baseEntity = &typedEntity.IdsecIdentityBaseEntity
+			case *directoriesmodels.IdsecIdentityGroupEntity:
+				baseEntity = &typedEntity.IdsecIdentityBaseEntity
+			}
+			if baseEntity == nil || baseEntity.EntityType != entityType || principal.DirectoryServiceType != "" {
+				continue
+			}
+			// The search is a partial match, keep only the entity with the exact name
+			if strings.EqualFold(baseEntity.Name, principal.PrincipalName) || strings.EqualFold(baseEntity.DisplayName, principal.PrincipalName) {
+				if principal.PrincipalID == "" {
+					principal.PrincipalID = baseEntity.ID
+				}
+				principal.DirectoryServiceType = baseEntity.DirectoryServiceType
+			}
+		}
+	}
+	return nil
+}
+
+func (s *IdsecPCloudEffectivePermissionsService) analyzeSafe(safeID string) (*effectivepermissionsmodels.IdsecPCloudEffectivePermissions, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	membersPages, err := s.safesService.ListMembersContext(ctx, &safesmodels.IdsecPCloudListSafeMembers{SafeID: safeID})
+	if err != nil {
+		return nil, err
+	}
+	analysis := &permissionsAnalysis{
+		principals: make(map[string]*effectivepermissionsmodels.IdsecPCloudEffectivePrincipal),
+		warnings:   make([]string, 0),
+	}
+	for membersPage := range membersPages {
+		if membersPage.Err != nil {
+			return nil, membersPage.Err
+		}
+		for _, member := range membersPage.Items {
+			if member.IsExpiredMembershipEnabled {
+				analysis.warnings = append(analysis.warnings, fmt.Sprintf("membership of [%s] has expired and was skipped", member.MemberName))
+				continue
+			}
+			switch member.MemberType {
+			case safesmodels.Role:
+				if s.rolesService == nil {
+					analysis.addPrincipal(safesmodels.Role, member.MemberName, "", member, nil)
+					continue
+				}
+				s.expandRole(analysis, member, &rolesmodels.IdsecIdentityListRoleMembers{RoleName: member.MemberName}, member.MemberName, nil, make(map[string]bool))
+			case safesmodels.Group:
+				s.expandGroup(analysis, member, member.MemberName, "", nil)
+			default:
+				analysis.addPrincipal(safesmodels.User, member.MemberName, "", member, nil)
+			}
+		}
+	}
+	result := &effectivepermissionsmodels.IdsecPCloudEffectivePermissions{
+		SafeID:     safeID,
+		Principals: make([]*effectivepermissionsmodels.IdsecPCloudEffectivePrincipal, 0, len(analysis.principals)),
+		Warnings:   analysis.warnings,
+	}
+	for _, principal := range analysis.principals {
+		if err := s.resolvePrincipal(principal); err != nil {
+			s.Logger.Warning("Failed to look up [%s] in the identity directories: %v", principal.PrincipalName, err)
+			result.Warnings = append(result.Warnings, fmt.Sprintf("identity of [%s] could not be resolved: %v", principal.PrincipalName, err))
+		}
+		principal.Risks = principalRisks(principal)
+		if len(principal.Risks) > 0 {
+			result.RiskyPrincipals++
+		}
+		result.Principals = append(result.Principals, principal)
+	}
+	sort.Slice(result.Principals, func(i, j int) bool {
+		return principalKey(result.Principals[i].PrincipalType, result.Principals[i].PrincipalName) <
+			principalKey(result.Principals[j].PrincipalType, result.Principals[j].PrincipalName)
+	})
+	s.Logger.Info("Safe [%s] grants permissions to [%d] principals, [%d] of them risky", safeID, len(result.Principals), result.RiskyPrincipals)
+	return result, nil
+}
+
+// AnalyzeSafe computes the effective permissions of every principal on the given safe.
+//
+// Safe members that are identity roles are expanded to their users and groups, including
+// nested roles, groups are expanded to their users, and each principal holds the union of the permissions of all the members
+// it is reached through. Principals holding a risky combination of permissions are flagged.
+func (s *IdsecPCloudEffectivePermissionsService) AnalyzeSafe(analyzeSafe *effectivepermissionsmodels.IdsecPCloudAnalyzeSafePermissions) (*effectivepermissionsmodels.IdsecPCloudEffectivePermissions, error) {
+	if analyzeSafe.SafeID == "" {
+		return nil, fmt.Errorf("safe id is required")
+	}
+	s.Logger.Info("Analyzing effective permissions of safe [%s]", analyzeSafe.SafeID)
+	return s.analyzeSafe(analyzeSafe.SafeID)
+}
+
+// AnalyzeAccount computes the effective permissions of every principal on the given account,
+// which are the effective permissions on the safe holding it.
+func (s *IdsecPCloudEffectivePermissionsService) AnalyzeAccount(analyzeAccount *effectivepermissionsmodels.IdsecPCloudAnalyzeAccountPermissions) (*effectivepermissionsmodels.IdsecPCloudEffectivePermissions, error) {
+	if analyzeAccount.AccountID == "" {
+		return nil, fmt.Errorf("account id is required")
+	}
+	s.Logger.Info("Analyzing effective permissions of account [%s]", analyzeAccount.AccountID)
+	account, err := s.accountsService.Get(&accountsmodels.IdsecPCloudGetAccount{AccountID: analyzeAccount.AccountID})
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.analyzeSafe(account.SafeName)
+	if err != nil {
+		return nil, err
+	}
+	result.AccountID = account.AccountID
+	result.AccountName = account.Name
+	return result, nil
+}
+
+// WhoCan returns the principals holding all the given permissions on a safe or account.
+func (s *IdsecPCloudEffectivePermissionsService) WhoCan(whoCan *effectivepermissionsmodels.IdsecPCloudWhoCan) (*effectivepermissionsmodels.IdsecPCloudEffectivePermissions, error) {
+	if (whoCan.SafeID == "") == (whoCan.AccountID == "") {
+		return nil, fmt.Errorf("exactly one of safe id or account id is required")
+	}
+	if len(whoCan.Permissions) == 0 {
+		return nil, fmt.Errorf("at least one permission is required")
+	}
+	knownPermissions := permissionNames()
+	for _, permission := range whoCan.Permissions {
+		if !hasPermissions(knownPermissions, []string{permission}) {
+			return nil, fmt.Errorf("unknown permission [%s], expected one of [%s]", permission, strings.Join(knownPermissions, ","))
+		}
+	}
+	var result *effectivepermissionsmodels.IdsecPCloudEffectivePermissions
+	var err error
+	if whoCan.SafeID != "" {
+		result, err = s.AnalyzeSafe(&effectivepermissionsmodels.IdsecPCloudAnalyzeSafePermissions{SafeID: whoCan.SafeID})
+	} else {
+		result, err = s.AnalyzeAccount(&effectivepermissionsmodels.IdsecPCloudAnalyzeAccountPermissions{AccountID: whoCan.AccountID})
+	}
+	if err != nil {
+		return nil, err
+	}
+	principals := make([]*effectivepermissionsmodels.IdsecPCloudEffectivePrincipal, 0)
+	result.RiskyPrincipals = 0
+	for _, principal := range result.Principals {
+		if !hasPermissions(grantedPermissions(principal.Permissions), whoCan.Permissions) {
+			continue
+		}
+		principals = append(principals, principal)
+		if len(principal.Risks) > 0 {
+			result.RiskyPrincipals++
+		}
+	}
+	result.Principals = principals
+	return result, nil
+}
+
+// ServiceConfig returns the service configuration for the IdsecPCloudEffectivePermissionsService.
+func (s *IdsecPCloudEffectivePermissionsService) ServiceConfig() services.IdsecServiceConfig {
+	return ServiceConfig
+}
@@ -0,0 +1,272 @@
+package effectivepermissions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/identity/directories"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/identity/roles"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts"
+	effectivepermissionsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/effectivepermissions/models"
+	pcloudint "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/internal"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/safes"
+	safesmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/safes/models"
+)
+
+func mockResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+		Header:     make(http.Header),
+	}
+}
+
+// fakeRoleMembers maps the identity role IDs to their members. The nested role points
+// back at its parent to make sure cycles are not followed.
+var fakeRoleMembers = map[string]string{
+	"role-ops": `[{"Row": {"Guid": "u-alice", "Name": "alice@corp", "Type": "User"}},
+		{"Row": {"Guid": "g-db", "Name": "db-team", "Type": "Group"}},
+		{"Row": {"Guid": "role-nested", "Name": "Nested", "Type": "Role"}}]`,
+	"role-nested": `[{"Row": {"Guid": "u-bob", "Name": "bob@corp", "Type": "User"}},
+		{"Row": {"Guid": "role-ops", "Name": "Ops Admins", "Type": "Role"}}]`,
+}
+
+// newFakeIdentityRolesService returns a roles service where only "Ops Admins" is an identity role,
+// and whose directories know the "db-team" group.
+func newFakeIdentityRolesService(parts *pcloudint.MockISPServiceParts) *roles.IdsecIdentityRolesService {
+	directoriesService := &directories.IdsecIdentityDirectoriesService{
+		IdsecBaseService: parts.BaseService,
+		DoGet: func(ctx context.Context, path string, params interface{}) (*http.Response, error) {
+			return mockResponse(`{"success": true, "Result": {"Results": [{"Row": {"Service": "CDS", "directoryServiceUuid": "dir-1"}}]}}`), nil
+		},
+		DoPost: func(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+			return mockResponse(`{"success": true, "Result": {"Group": {"Results": [{"Row": {"SystemName": "db-team", "InternalName": "g-db", "ServiceType": "AdProxy"}}]}}}`), nil
+		},
+	}
+	return &roles.IdsecIdentityRolesService{
+		IdsecBaseService:   parts.BaseService,
+		DirectoriesService: directoriesService,
+		DoDirectoryServiceQueryPost: func(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+			query, _ := json.Marshal(body)
+			if strings.Contains(string(query), "Ops Admins") {
+				return mockResponse(`{"success": true, "Result": {"Roles": {"Results": [{"Row": {"_ID": "role-ops", "Name": "Ops Admins"}}]}}}`), nil
+			}
+			return mockResponse(`{"success": true, "Result": {"Roles": {"Results": []}}}`), nil
+		},
+		DoGet: func(ctx context.Context, path string, params interface{}) (*http.Response, error) {
+			return mockResponse(`[]`), nil
+		},
+		DoPost: func(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+			if path != "Roles/GetRoleMembers" {
+				return mockResponse(`{"success": false}`), nil
+			}
+			members, ok := fakeRoleMembers[body.(map[string]interface{})["Name"].(string)]
+			if !ok {
+				return mockResponse(`{"success": false}`), nil
+			}
+			return mockResponse(fmt.Sprintf(`{"success": true, "Result": {"Results": %s}}`, members)), nil
+		},
+	}
+}
+
+func newTestPCloudEffectivePermissionsService(parts *pcloudint.MockISPServiceParts) *IdsecPCloudEffectivePermissionsService {
+	rolesService := newFakeIdentityRolesService(parts)
+	return &IdsecPCloudEffectivePermissionsService{
+		IdsecBaseService:    parts.BaseService,
+		IdsecISPBaseService: parts.ISPBase,
+		safesService: &safes.IdsecPCloudSafesService{
+			IdsecBaseService:    parts.BaseService,
+			IdsecISPBaseService: parts.ISPBase,
+			RolesService:        rolesService,
+		},
+		accountsService: &accounts.IdsecPCloudAccountsService{
+			IdsecBaseService:    parts.BaseService,
+			IdsecISPBaseService: parts.ISPBase,
+		},
+		rolesService:       rolesService,
+		directoriesService: rolesService.DirectoriesService,
+	}
+}
+
+// fakeVault serves the members of the Finance safe, a single account stored in it and the
+// "Vault Admins" group. pCloud reports the "Ops Admins" identity role as a group.
+func fakeVault(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/api/safes/Finance/members":
+		_, _ = fmt.Fprint(w, `{"value": [
+			{"safeName": "Finance", "memberName": "alice@corp", "memberType": "User", "permissions": {"listAccounts": true, "retrieveAccounts": true}},
+			{"safeName": "Finance", "memberName": "Ops Admins", "memberType": "Group", "permissions": {"listAccounts": true, "accessWithoutConfirmation": true}},
+			{"safeName": "Finance", "memberName": "Vault Admins", "memberType": "Group", "permissions": {"retrieveAccounts": true, "accessWithoutConfirmation": true, "manageSafeMembers": true}},
+			{"safeName": "Finance", "memberName": "old@corp", "memberType": "User", "isExpiredMembershipEnabled": true, "permissions": {"retrieveAccounts": true}}
+		]}`)
+	case r.URL.Path == "/api/UserGroups":
+		if r.URL.Query().Get("filter") != "groupName eq Vault Admins" || r.URL.Query().Get("includeMembers") != "true" {
+			_, _ = fmt.Fprint(w, `{"value": []}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"value": [{"id": 7, "groupName": "Vault Admins", "members": [{"id": "12", "username": "carol@corp"}]}]}`)
+	case strings.HasPrefix(r.URL.Path, "/api/accounts/12_3"):
+		_, _ = fmt.Fprint(w, `{"id": "12_3", "name": "root-db01", "safeName": "Finance", "platformId": "UnixSSH", "userName": "root"}`)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func findPrincipal(t *testing.T, result *effectivepermissionsmodels.IdsecPCloudEffectivePermissions, principalType string, principalName string) *effectivepermissionsmodels.IdsecPCloudEffectivePrincipal {
+	t.Helper()
+	for _, principal := range result.Principals {
+		if principal.PrincipalType == principalType && principal.PrincipalName == principalName {
+			return principal
+		}
+	}
+	require.Failf(t, "principal not found", "%s [%s]", principalType, principalName)
+	return nil
+}
+
+func TestAnalyzeSafe(t *testing.T) {
+	t.Parallel()
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, http.HandlerFunc(fakeVault))
+	defer cleanup()
+	svc := newTestPCloudEffectivePermissionsService(parts)
+
+	result, err := svc.AnalyzeSafe(&effectivepermissionsmodels.IdsecPCloudAnalyzeSafePermissions{SafeID: "Finance"})
+	require.NoError(t, err)
+	require.Len(t, result.Principals, 4, "the role and group are expanded and the expired member is skipped")
+	require.Equal(t, 2, result.RiskyPrincipals)
+	require.Len(t, result.Warnings, 2)
+	require.Contains(t, result.Warnings[0], "group [db-team] was not found")
+	require.Contains(t, result.Warnings[1], "old@corp")
+
+	alice := findPrincipal(t, result, safesmodels.User, "alice@corp")
+	require.True(t, alice.Permissions.RetrieveAccounts)
+	require.True(t, alice.Permissions.AccessWithoutConfirmation)
+	require.Len(t, alice.Sources, 2)
+	require.Len(t, alice.Risks, 1)
+	require.Equal(t, effectivepermissionsmodels.RiskRetrieveWithoutConfirmation, alice.Risks[0].Risk)
+	require.True(t, alice.Risks[0].Combined, "the risk only arises from the direct and role memberships together")
+
+	bob := findPrincipal(t, result, safesmodels.User, "bob@corp")
+	require.Equal(t, "u-bob", bob.PrincipalID)
+	require.Equal(t, []string{"Ops Admins", "Nested"}, bob.Sources[0].Via)
+	require.Equal(t, safesmodels.Role, bob.Sources[0].MemberType)
+	require.False(t, bob.Permissions.RetrieveAccounts)
+	require.Empty(t, bob.Risks)
+
+	dbTeam := findPrincipal(t, result, safesmodels.Group, "db-team")
+	require.Equal(t, []string{"Ops Admins"}, dbTeam.Sources[0].Via)
+	require.Equal(t, "g-db", dbTeam.PrincipalID)
+	require.Equal(t, "AdProxy", dbTeam.DirectoryServiceType)
+
+	carol := findPrincipal(t, result, safesmodels.User, "carol@corp")
+	require.Equal(t, []string{"Vault Admins"}, carol.Sources[0].Via)
+	require.Equal(t, safesmodels.Group, carol.Sources[0].MemberType)
+	require.Len(t, carol.Risks, 2)
+	for _, risk := range carol.Risks {
+		require.False(t, risk.Combined)
+	}
+}
+
+func TestAnalyzeSafe_unexpandableGroup(t *testing.T) {
+	t.Parallel()
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/UserGroups" {
+			http.Error(w, `{"ErrorCode": "PASWS013E"}`, http.StatusForbidden)
+			return
+		}
+		fakeVault(w, r)
+	}))
+	defer cleanup()
+	svc := newTestPCloudEffectivePermissionsService(parts)
+
+	result, err := svc.AnalyzeSafe(&effectivepermissionsmodels.IdsecPCloudAnalyzeSafePermissions{SafeID: "Finance"})
+	require.NoError(t, err)
+	vaultAdmins := findPrincipal(t, result, safesmodels.Group, "Vault Admins")
+	require.True(t, vaultAdmins.Permissions.RetrieveAccounts, "the group keeps its permissions when it cannot be expanded")
+	require.Len(t, vaultAdmins.Risks, 2)
+	require.Len(t, result.Warnings, 3)
+	require.Contains(t, result.Warnings[1], "members of group [Vault Admins] could not be listed")
+}
+
+func TestAnalyzeSafe_directoryLookupFailure(t *testing.T) {
+	t.Parallel()
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, http.HandlerFunc(fakeVault))
+	defer cleanup()
+	svc := newTestPCloudEffectivePermissionsService(parts)
+	svc.directoriesService.DoPost = func(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+		return mockResponse(`{"success": false}`), nil
+	}
+
+	result, err := svc.AnalyzeSafe(&effectivepermissionsmodels.IdsecPCloudAnalyzeSafePermissions{SafeID: "Finance"})
+	require.NoError(t, err)
+	require.Len(t, result.Principals, 4)
+	unresolved := 0
+	for _, warning := range result.Warnings {
+		if strings.Contains(warning, "could not be resolved") {
+			unresolved++
+		}
+	}
+	require.Equal(t, 4, unresolved, "every failed directory lookup is reported")
+	require.Empty(t, findPrincipal(t, result, safesmodels.Group, "db-team").DirectoryServiceType)
+}
+
+func TestAnalyzeSafe_unexpandableRole(t *testing.T) {
+	t.Parallel()
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, http.HandlerFunc(fakeVault))
+	defer cleanup()
+	svc := newTestPCloudEffectivePermissionsService(parts)
+	svc.rolesService.DoPost = func(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusForbidden, Body: io.NopCloser(bytes.NewBufferString(`{}`)), Header: make(http.Header)}, nil
+	}
+
+	result, err := svc.AnalyzeSafe(&effectivepermissionsmodels.IdsecPCloudAnalyzeSafePermissions{SafeID: "Finance"})
+	require.NoError(t, err)
+	opsAdmins := findPrincipal(t, result, safesmodels.Role, "Ops Admins")
+	require.True(t, opsAdmins.Permissions.AccessWithoutConfirmation, "the role keeps its permissions when it cannot be expanded")
+	require.Len(t, result.Warnings, 2)
+}
+
+func TestAnalyzeAccount(t *testing.T) {
+	t.Parallel()
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, http.HandlerFunc(fakeVault))
+	defer cleanup()
+	svc := newTestPCloudEffectivePermissionsService(parts)
+
+	result, err := svc.AnalyzeAccount(&effectivepermissionsmodels.IdsecPCloudAnalyzeAccountPermissions{AccountID: "12_3"})
+	require.NoError(t, err)
+	require.Equal(t, "Finance", result.SafeID)
+	require.Equal(t, "12_3", result.AccountID)
+	require.Equal(t, "root-db01", result.AccountName)
+	require.Len(t, result.Principals, 4)
+}
+
+func TestWhoCan(t *testing.T) {
+	t.Parallel()
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, http.HandlerFunc(fakeVault))
+	defer cleanup()
+	svc := newTestPCloudEffectivePermissionsService(parts)
+
+	result, err := svc.WhoCan(&effectivepermissionsmodels.IdsecPCloudWhoCan{AccountID: "12_3", Permissions: []string{"retrieve_accounts"}})
+	require.NoError(t, err)
+	require.Len(t, result.Principals, 2)
+	require.Equal(t, "alice@corp", result.Principals[0].PrincipalName)
+	require.Equal(t, "carol@corp", result.Principals[1].PrincipalName)
+	require.Equal(t, 2, result.RiskyPrincipals)
+
+	result, err = svc.WhoCan(&effectivepermissionsmodels.IdsecPCloudWhoCan{SafeID: "Finance", Permissions: []string{"list_accounts", "access_without_confirmation"}})
+	require.NoError(t, err)
+	require.Len(t, result.Principals, 3)
+	require.Equal(t, 1, result.RiskyPrincipals)
+
+	_, err = svc.WhoCan(&effectivepermissionsmodels.IdsecPCloudWhoCan{SafeID: "Finance", Permissions: []string{"retrieve"}})
+	require.ErrorContains(t, err, "unknown permission [retrieve]")
+	_, err = svc.WhoCan(&effectivepermissionsmodels.IdsecPCloudWhoCan{SafeID: "Finance", AccountID: "12_3", Permissions: []string{"retrieve_accounts"}})
+	require.ErrorContains(t, err, "exactly one of safe id or account id is required")
+}
@@ -0,0 +1,6 @@
+package models
+
+// IdsecPCloudAnalyzeAccountPermissions represents the details required to analyze the effective permissions on an account.
+type IdsecPCloudAnalyzeAccountPermissions struct {
+	AccountID string `json:"account_id" mapstructure:"account_id" desc:"The unique ID of the account to analyze" flag:"account-id" validate:"required"`
+}
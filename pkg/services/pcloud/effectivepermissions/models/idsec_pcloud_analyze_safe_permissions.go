@@ -0,0 +1,6 @@
+package models
+
+// IdsecPCloudAnalyzeSafePermissions represents the details required to analyze the effective permissions on a safe.
+type IdsecPCloudAnalyzeSafePermissions struct {
+	SafeID string `json:"safe_id" mapstructure:"safe_id" desc:"The url id of the safe to analyze" flag:"safe-id" validate:"required"`
+}
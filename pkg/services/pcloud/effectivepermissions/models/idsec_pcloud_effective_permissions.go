@@ -0,0 +1,48 @@
+package models
+
+import safesmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/safes/models"
+
+// Possible risky permission combinations
+const (
+	RiskRetrieveWithoutConfirmation = "retrieve_without_confirmation"
+	RiskGrantAndRetrieve            = "grant_and_retrieve"
+)
+
+// IdsecPCloudPermissionSource represents a safe member through which a principal receives permissions.
+type IdsecPCloudPermissionSource struct {
+	MemberName  string   `json:"member_name" mapstructure:"member_name" desc:"The name of the safe member granting the permissions"`
+	MemberType  string   `json:"member_type" mapstructure:"member_type" desc:"The type of the safe member (User,Group,Role)"`
+	Via         []string `json:"via,omitempty" mapstructure:"via,omitempty" desc:"The nested roles and groups between the safe member and the principal, outermost first"`
+	Permissions []string `json:"permissions" mapstructure:"permissions" desc:"The permissions granted by the safe member"`
+}
+
+// IdsecPCloudPermissionRisk represents a risky combination of permissions held by a principal.
+type IdsecPCloudPermissionRisk struct {
+	Risk        string   `json:"risk" mapstructure:"risk" desc:"The name of the risk" choices:"retrieve_without_confirmation,grant_and_retrieve"`
+	Description string   `json:"description" mapstructure:"description" desc:"What the combination allows"`
+	Permissions []string `json:"permissions" mapstructure:"permissions" desc:"The permissions forming the combination"`
+	Combined    bool     `json:"combined" mapstructure:"combined" desc:"Whether the combination only arises from several safe members together"`
+}
+
+// IdsecPCloudEffectivePrincipal represents the effective permissions of a single principal.
+// Users are reached directly or through identity roles and groups. A group is only reported
+// as a principal itself when its members could not be listed.
+type IdsecPCloudEffectivePrincipal struct {
+	PrincipalName        string                                       `json:"principal_name" mapstructure:"principal_name" desc:"The name of the principal"`
+	PrincipalType        string                                       `json:"principal_type" mapstructure:"principal_type" desc:"The type of the principal (User,Group,Role)" choices:"User,Group,Role"`
+	PrincipalID          string                                       `json:"principal_id,omitempty" mapstructure:"principal_id,omitempty" desc:"The identity ID of the principal, when it could be resolved"`
+	DirectoryServiceType string                                       `json:"directory_service_type,omitempty" mapstructure:"directory_service_type,omitempty" desc:"The directory of the principal, when it could be resolved"`
+	Permissions          safesmodels.IdsecPCloudSafeMemberPermissions `json:"permissions" mapstructure:"permissions" desc:"The union of the permissions granted to the principal"`
+	Sources              []*IdsecPCloudPermissionSource               `json:"sources" mapstructure:"sources" desc:"The safe members the permissions come from"`
+	Risks                []*IdsecPCloudPermissionRisk                 `json:"risks,omitempty" mapstructure:"risks,omitempty" desc:"The risky permission combinations held by the principal"`
+}
+
+// IdsecPCloudEffectivePermissions represents the effective permissions of all principals on a safe or account.
+type IdsecPCloudEffectivePermissions struct {
+	SafeID          string                           `json:"safe_id" mapstructure:"safe_id" desc:"The url id of the analyzed safe"`
+	AccountID       string                           `json:"account_id,omitempty" mapstructure:"account_id,omitempty" desc:"The unique ID of the analyzed account"`
+	AccountName     string                           `json:"account_name,omitempty" mapstructure:"account_name,omitempty" desc:"The name of the analyzed account"`
+	Principals      []*IdsecPCloudEffectivePrincipal `json:"principals" mapstructure:"principals" desc:"The principals holding permissions"`
+	RiskyPrincipals int                              `json:"risky_principals" mapstructure:"risky_principals" desc:"The number of principals holding a risky permission combination"`
+	Warnings        []string                         `json:"warnings,omitempty" mapstructure:"warnings,omitempty" desc:"Members that were skipped or could not be fully expanded"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// IdsecPCloudWhoCan represents the details required to find the principals holding permissions on a safe or account.
+type IdsecPCloudWhoCan struct {
+	SafeID      string   `json:"safe_id,omitempty" mapstructure:"safe_id,omitempty" desc:"The url id of the safe to query, either this or account id is required" flag:"safe-id"`
+	AccountID   string   `json:"account_id,omitempty" mapstructure:"account_id,omitempty" desc:"The unique ID of the account to query, either this or safe id is required" flag:"account-id"`
+	Permissions []string `json:"permissions" mapstructure:"permissions" desc:"The safe member permissions the principals must all hold, for example retrieve_accounts" flag:"permissions" validate:"required"`
+}
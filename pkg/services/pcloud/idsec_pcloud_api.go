@@ -4,18 +4,30 @@ import (
 	"github.com/cyberark/idsec-sdk-golang/pkg/auth"
 	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts"
 	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/applications"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/credentialprovider"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/discoveredaccounts"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/effectivepermissions"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/inventory"
 	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/platforms"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/requests"
 	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/safes"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/secretrotation"
 	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/targetplatforms"
 )
 
 // IdsecPCloudAPI is a struct that provides access to the Idsec PCloud API as a wrapped set of services.
 type IdsecPCloudAPI struct {
-	safesService           *safes.IdsecPCloudSafesService
-	accountsService        *accounts.IdsecPCloudAccountsService
-	platformsService       *platforms.IdsecPCloudPlatformsService
-	targetPlatformsService *targetplatforms.IdsecPCloudTargetPlatformsService
-	applicationsService    *applications.IdsecPCloudApplicationsService
+	safesService                *safes.IdsecPCloudSafesService
+	accountsService             *accounts.IdsecPCloudAccountsService
+	platformsService            *platforms.IdsecPCloudPlatformsService
+	targetPlatformsService      *targetplatforms.IdsecPCloudTargetPlatformsService
+	applicationsService         *applications.IdsecPCloudApplicationsService
+	requestsService             *requests.IdsecPCloudRequestsService
+	discoveredAccountsService   *discoveredaccounts.IdsecPCloudDiscoveredAccountsService
+	inventoryService            *inventory.IdsecPCloudInventoryService
+	effectivePermissionsService *effectivepermissions.IdsecPCloudEffectivePermissionsService
+	credentialProviderService   *credentialprovider.IdsecPCloudCredentialProviderService
+	secretRotationService       *secretrotation.IdsecPCloudSecretRotationService
 }
 
 // NewIdsecPCloudAPI creates a new instance of IdsecPCloudAPI with the provided IdsecISPAuth.
@@ -41,12 +53,42 @@ func NewIdsecPCloudAPI(ispAuth *auth.IdsecISPAuth) (*IdsecPCloudAPI, error) {
 	if err != nil {
 		return nil, err
 	}
+	requestsService, err := requests.NewIdsecPCloudRequestsService(baseIspAuth)
+	if err != nil {
+		return nil, err
+	}
+	discoveredAccountsService, err := discoveredaccounts.NewIdsecPCloudDiscoveredAccountsService(baseIspAuth)
+	if err != nil {
+		return nil, err
+	}
+	inventoryService, err := inventory.NewIdsecPCloudInventoryService(baseIspAuth)
+	if err != nil {
+		return nil, err
+	}
+	effectivePermissionsService, err := effectivepermissions.NewIdsecPCloudEffectivePermissionsService(baseIspAuth)
+	if err != nil {
+		return nil, err
+	}
+	credentialProviderService, err := credentialprovider.NewIdsecPCloudCredentialProviderService(baseIspAuth)
+	if err != nil {
+		return nil, err
+	}
+	secretRotationService, err := secretrotation.NewIdsecPCloudSecretRotationService(baseIspAuth)
+	if err != nil {
+		return nil, err
+	}
 	return &IdsecPCloudAPI{
-		safesService:           safesService,
-		accountsService:        accountsService,
-		platformsService:       platformsService,
-		targetPlatformsService: targetPlatformsService,
-		applicationsService:    applicationsService,
+		safesService:                safesService,
+		accountsService:             accountsService,
+		platformsService:            platformsService,
+		targetPlatformsService:      targetPlatformsService,
+		applicationsService:         applicationsService,
+		requestsService:             requestsService,
+		discoveredAccountsService:   discoveredAccountsService,
+		inventoryService:            inventoryService,
+		effectivePermissionsService: effectivePermissionsService,
+		credentialProviderService:   credentialProviderService,
+		secretRotationService:       secretRotationService,
 	}, nil
 }
 
@@ -74,3 +116,33 @@ func (api *IdsecPCloudAPI) TargetPlatforms() *targetplatforms.IdsecPCloudTargetP
 func (api *IdsecPCloudAPI) Applications() *applications.IdsecPCloudApplicationsService {
 	return api.applicationsService
 }
+
+// Requests returns the Requests service of the IdsecPCloudAPI instance.
+func (api *IdsecPCloudAPI) Requests() *requests.IdsecPCloudRequestsService {
+	return api.requestsService
+}
+
+// DiscoveredAccounts returns the Discovered Accounts service of the IdsecPCloudAPI instance.
+func (api *IdsecPCloudAPI) DiscoveredAccounts() *discoveredaccounts.IdsecPCloudDiscoveredAccountsService {
+	return api.discoveredAccountsService
+}
+
+// Inventory returns the Inventory service of the IdsecPCloudAPI instance.
+func (api *IdsecPCloudAPI) Inventory() *inventory.IdsecPCloudInventoryService {
+	return api.inventoryService
+}
+
+// EffectivePermissions returns the EffectivePermissions service of the IdsecPCloudAPI instance.
+func (api *IdsecPCloudAPI) EffectivePermissions() *effectivepermissions.IdsecPCloudEffectivePermissionsService {
+	return api.effectivePermissionsService
+}
+
+// CredentialProvider returns the CredentialProvider service of the IdsecPCloudAPI instance.
+func (api *IdsecPCloudAPI) CredentialProvider() *credentialprovider.IdsecPCloudCredentialProviderService {
+	return api.credentialProviderService
+}
+
+// SecretRotation returns the SecretRotation service of the IdsecPCloudAPI instance.
+func (api *IdsecPCloudAPI) SecretRotation() *secretrotation.IdsecPCloudSecretRotationService {
+	return api.secretRotationService
+}
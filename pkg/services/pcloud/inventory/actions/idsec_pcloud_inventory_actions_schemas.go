@@ -0,0 +1,9 @@
+package actions
+
+import inventorymodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/inventory/models"
+
+// ActionToSchemaMap maps action names to their corresponding schema structures.
+var ActionToSchemaMap = map[string]interface{}{
+	"snapshot": &inventorymodels.IdsecPCloudCreateInventorySnapshot{},
+	"diff":     &inventorymodels.IdsecPCloudDiffInventorySnapshots{},
+}
@@ -0,0 +1,249 @@
+package inventory
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	accountsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts/models"
+	inventorymodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/inventory/models"
+	safesmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/safes/models"
+)
+
+// comparedField is a field compared between two snapshots.
+type comparedField[T any] struct {
+	name  string
+	value func(*T) interface{}
+}
+
+// comparedSafeFields are the safe properties reported when they change.
+var comparedSafeFields = []comparedField[safesmodels.IdsecPCloudSafe]{
+	{"description", func(safe *safesmodels.IdsecPCloudSafe) interface{} { return safe.Description }},
+	{"location", func(safe *safesmodels.IdsecPCloudSafe) interface{} { return safe.Location }},
+	{"managing_cpm", func(safe *safesmodels.IdsecPCloudSafe) interface{} { return safe.ManagingCPM }},
+	{"number_of_days_retention", func(safe *safesmodels.IdsecPCloudSafe) interface{} { return safe.NumberOfDaysRetention }},
+	{"number_of_versions_retention", func(safe *safesmodels.IdsecPCloudSafe) interface{} { return safe.NumberOfVersionsRetention }},
+	{"olac_enabled", func(safe *safesmodels.IdsecPCloudSafe) interface{} { return safe.OlacEnabled }},
+	{"auto_purge_enabled", func(safe *safesmodels.IdsecPCloudSafe) interface{} { return safe.AutoPurgeEnabled }},
+}
+
+// comparedAccountFields are the account platform and management fields reported when they change.
+var comparedAccountFields = []comparedField[accountsmodels.IdsecPCloudAccount]{
+	{"platform_id", func(account *accountsmodels.IdsecPCloudAccount) interface{} { return account.PlatformID }},
+	{"status", func(account *accountsmodels.IdsecPCloudAccount) interface{} { return account.Status }},
+	{"automatic_management_enabled", func(account *accountsmodels.IdsecPCloudAccount) interface{} {
+		if account.AutomaticManagementEnabled == nil {
+			return nil
+		}
+		return *account.AutomaticManagementEnabled
+	}},
+	{"manual_management_reason", func(account *accountsmodels.IdsecPCloudAccount) interface{} { return account.ManualManagementReason }},
+}
+
+func diffFields[T any](fields []comparedField[T], base *T, target *T) []*inventorymodels.IdsecPCloudInventoryFieldChange {
+	changes := make([]*inventorymodels.IdsecPCloudInventoryFieldChange, 0)
+	for _, field := range fields {
+		oldValue, newValue := field.value(base), field.value(target)
+		if oldValue != newValue {
+			changes = append(changes, &inventorymodels.IdsecPCloudInventoryFieldChange{Field: field.name, Old: oldValue, New: newValue})
+		}
+	}
+	return changes
+}
+
+// grantedPermissions returns the set of permission names, as in the json tags, that a member has.
+func grantedPermissions(permissions *safesmodels.IdsecPCloudSafeMemberPermissions) map[string]bool {
+	granted := map[string]bool{}
+	value := reflect.ValueOf(permissions).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		if value.Field(i).Kind() == reflect.Bool && value.Field(i).Bool() {
+			name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("json"), ",")
+			granted[name] = true
+		}
+	}
+	return granted
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// setDelta returns the sorted keys only in target and the sorted keys only in base.
+func setDelta(base map[string]bool, target map[string]bool) ([]string, []string) {
+	onlyTarget, onlyBase := map[string]bool{}, map[string]bool{}
+	for name := range target {
+		if !base[name] {
+			onlyTarget[name] = true
+		}
+	}
+	for name := range base {
+		if !target[name] {
+			onlyBase[name] = true
+		}
+	}
+	return sortedKeys(onlyTarget), sortedKeys(onlyBase)
+}
+
+// Vault object names are case insensitive.
+func safeKey(safeName string) string {
+	return strings.ToLower(safeName)
+}
+
+func memberKey(member *safesmodels.IdsecPCloudSafeMember) string {
+	return strings.ToLower(member.MemberType + "/" + member.MemberName)
+}
+
+func accountRef(account *accountsmodels.IdsecPCloudAccount) *inventorymodels.IdsecPCloudInventoryAccountRef {
+	return &inventorymodels.IdsecPCloudInventoryAccountRef{AccountID: account.AccountID, SafeName: account.SafeName, Name: account.Name}
+}
+
+// DiffSnapshots compares two inventory snapshots and reports what changed from base to target.
+// Results are sorted by safe, member and account so diffs of the same snapshots are stable.
+func DiffSnapshots(base *inventorymodels.IdsecPCloudInventorySnapshot, target *inventorymodels.IdsecPCloudInventorySnapshot) *inventorymodels.IdsecPCloudInventoryDiff {
+	diff := &inventorymodels.IdsecPCloudInventoryDiff{
+		BaseCreatedAt:         base.CreatedAt,
+		TargetCreatedAt:       target.CreatedAt,
+		SafesAdded:            make([]string, 0),
+		SafesRemoved:          make([]string, 0),
+		SafesChanged:          make([]*inventorymodels.IdsecPCloudInventorySafeChange, 0),
+		PermissionEscalations: make([]*inventorymodels.IdsecPCloudInventoryPermissionChange, 0),
+		PermissionReductions:  make([]*inventorymodels.IdsecPCloudInventoryPermissionChange, 0),
+		AccountsAdded:         make([]*inventorymodels.IdsecPCloudInventoryAccountRef, 0),
+		AccountsRemoved:       make([]*inventorymodels.IdsecPCloudInventoryAccountRef, 0),
+		AccountsChanged:       make([]*inventorymodels.IdsecPCloudInventoryAccountChange, 0),
+		PlatformsAdded:        make([]string, 0),
+		PlatformsRemoved:      make([]string, 0),
+	}
+	diffSafes(diff, base.Safes, target.Safes)
+	diffAccounts(diff, base.Accounts, target.Accounts)
+
+	basePlatforms, targetPlatforms := map[string]bool{}, map[string]bool{}
+	for _, platform := range base.Platforms {
+		basePlatforms[platform.General.ID] = true
+	}
+	for _, platform := range target.Platforms {
+		targetPlatforms[platform.General.ID] = true
+	}
+	diff.PlatformsAdded, diff.PlatformsRemoved = setDelta(basePlatforms, targetPlatforms)
+	return diff
+}
+
+func diffSafes(diff *inventorymodels.IdsecPCloudInventoryDiff, baseSafes []*inventorymodels.IdsecPCloudInventorySafe, targetSafes []*inventorymodels.IdsecPCloudInventorySafe) {
+	baseByName := map[string]*inventorymodels.IdsecPCloudInventorySafe{}
+	for _, safe := range baseSafes {
+		baseByName[safeKey(safe.SafeName)] = safe
+	}
+	targetByName := map[string]*inventorymodels.IdsecPCloudInventorySafe{}
+	for _, safe := range targetSafes {
+		targetByName[safeKey(safe.SafeName)] = safe
+	}
+	for _, safe := range baseSafes {
+		if _, ok := targetByName[safeKey(safe.SafeName)]; !ok {
+			diff.SafesRemoved = append(diff.SafesRemoved, safe.SafeName)
+		}
+	}
+	for _, targetSafe := range targetSafes {
+		baseSafe, ok := baseByName[safeKey(targetSafe.SafeName)]
+		if !ok {
+			diff.SafesAdded = append(diff.SafesAdded, targetSafe.SafeName)
+			continue
+		}
+		if changes := diffFields(comparedSafeFields, &baseSafe.IdsecPCloudSafe, &targetSafe.IdsecPCloudSafe); len(changes) > 0 {
+			diff.SafesChanged = append(diff.SafesChanged, &inventorymodels.IdsecPCloudInventorySafeChange{SafeName: targetSafe.SafeName, Changes: changes})
+		}
+		if baseSafe.MembersError != "" || targetSafe.MembersError != "" {
+			continue
+		}
+		diffMembers(diff, targetSafe.SafeName, baseSafe.Members, targetSafe.Members)
+	}
+	sort.Strings(diff.SafesAdded)
+	sort.Strings(diff.SafesRemoved)
+	sort.Slice(diff.SafesChanged, func(i, j int) bool { return diff.SafesChanged[i].SafeName < diff.SafesChanged[j].SafeName })
+	for _, changes := range [][]*inventorymodels.IdsecPCloudInventoryPermissionChange{diff.PermissionEscalations, diff.PermissionReductions} {
+		sort.SliceStable(changes, func(i, j int) bool {
+			if changes[i].SafeName != changes[j].SafeName {
+				return changes[i].SafeName < changes[j].SafeName
+			}
+			return changes[i].MemberName < changes[j].MemberName
+		})
+	}
+}
+
+func diffMembers(diff *inventorymodels.IdsecPCloudInventoryDiff, safeName string, baseMembers []*safesmodels.IdsecPCloudSafeMember, targetMembers []*safesmodels.IdsecPCloudSafeMember) {
+	baseByKey := map[string]*safesmodels.IdsecPCloudSafeMember{}
+	for _, member := range baseMembers {
+		baseByKey[memberKey(member)] = member
+	}
+	targetKeys := map[string]bool{}
+	for _, targetMember := range targetMembers {
+		targetKeys[memberKey(targetMember)] = true
+		change := &inventorymodels.IdsecPCloudInventoryPermissionChange{
+			SafeName:   safeName,
+			MemberName: targetMember.MemberName,
+			MemberType: targetMember.MemberType,
+			Change:     inventorymodels.MemberChanged,
+		}
+		basePermissions := map[string]bool{}
+		if baseMember, ok := baseByKey[memberKey(targetMember)]; ok {
+			basePermissions = grantedPermissions(&baseMember.Permissions)
+		} else {
+			change.Change = inventorymodels.MemberAdded
+		}
+		change.Granted, change.Revoked = setDelta(basePermissions, grantedPermissions(&targetMember.Permissions))
+		switch {
+		case len(change.Granted) > 0:
+			diff.PermissionEscalations = append(diff.PermissionEscalations, change)
+		case len(change.Revoked) > 0:
+			diff.PermissionReductions = append(diff.PermissionReductions, change)
+		}
+	}
+	for _, baseMember := range baseMembers {
+		if targetKeys[memberKey(baseMember)] {
+			continue
+		}
+		_, revoked := setDelta(grantedPermissions(&baseMember.Permissions), map[string]bool{})
+		diff.PermissionReductions = append(diff.PermissionReductions, &inventorymodels.IdsecPCloudInventoryPermissionChange{
+			SafeName:   safeName,
+			MemberName: baseMember.MemberName,
+			MemberType: baseMember.MemberType,
+			Change:     inventorymodels.MemberRemoved,
+			Revoked:    revoked,
+		})
+	}
+}
+
+func diffAccounts(diff *inventorymodels.IdsecPCloudInventoryDiff, baseAccounts []*accountsmodels.IdsecPCloudAccount, targetAccounts []*accountsmodels.IdsecPCloudAccount) {
+	baseByID := map[string]*accountsmodels.IdsecPCloudAccount{}
+	for _, account := range baseAccounts {
+		baseByID[account.AccountID] = account
+	}
+	targetIDs := map[string]bool{}
+	for _, targetAccount := range targetAccounts {
+		targetIDs[targetAccount.AccountID] = true
+		baseAccount, ok := baseByID[targetAccount.AccountID]
+		if !ok {
+			diff.AccountsAdded = append(diff.AccountsAdded, accountRef(targetAccount))
+			continue
+		}
+		if changes := diffFields(comparedAccountFields, baseAccount, targetAccount); len(changes) > 0 {
+			diff.AccountsChanged = append(diff.AccountsChanged, &inventorymodels.IdsecPCloudInventoryAccountChange{
+				IdsecPCloudInventoryAccountRef: *accountRef(targetAccount),
+				Changes:                        changes,
+			})
+		}
+	}
+	for _, baseAccount := range baseAccounts {
+		if !targetIDs[baseAccount.AccountID] {
+			diff.AccountsRemoved = append(diff.AccountsRemoved, accountRef(baseAccount))
+		}
+	}
+	for _, refs := range [][]*inventorymodels.IdsecPCloudInventoryAccountRef{diff.AccountsAdded, diff.AccountsRemoved} {
+		sort.Slice(refs, func(i, j int) bool { return refs[i].AccountID < refs[j].AccountID })
+	}
+	sort.Slice(diff.AccountsChanged, func(i, j int) bool { return diff.AccountsChanged[i].AccountID < diff.AccountsChanged[j].AccountID })
+}
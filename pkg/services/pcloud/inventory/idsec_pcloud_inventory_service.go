@@ -0,0 +1,318 @@
+package inventory
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/auth"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts"
+	commonpcloud "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/common"
+	inventorymodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/inventory/models"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/platforms"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/safes"
+	safesmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/safes/models"
+)
+
+// IdsecPCloudInventoryService is the service for taking and comparing point-in-time inventories of the pCloud vault.
+type IdsecPCloudInventoryService struct {
+	*services.IdsecBaseService
+	*services.IdsecISPBaseService
+
+	safesService     *safes.IdsecPCloudSafesService
+	accountsService  *accounts.IdsecPCloudAccountsService
+	platformsService *platforms.IdsecPCloudPlatformsService
+}
+
+// NewIdsecPCloudInventoryService creates a new instance of IdsecPCloudInventoryService.
+func NewIdsecPCloudInventoryService(authenticators ...auth.IdsecAuth) (*IdsecPCloudInventoryService, error) {
+	pcloudInventoryService := &IdsecPCloudInventoryService{}
+	var pcloudInventoryServiceInterface services.IdsecService = pcloudInventoryService
+	baseService, err := services.NewIdsecBaseService(pcloudInventoryServiceInterface, authenticators...)
+	if err != nil {
+		return nil, err
+	}
+	ispBaseAuth, err := baseService.Authenticator("isp")
+	if err != nil {
+		return nil, err
+	}
+	ispAuth := ispBaseAuth.(*auth.IdsecISPAuth)
+
+	ispBaseService, err := services.NewIdsecISPBaseServiceWithRetry(
+		ispAuth,
+		"privilegecloud",
+		".",
+		"passwordvault",
+		pcloudInventoryService.refreshPCloudInventoryAuth,
+		commonpcloud.DefaultPCloudRetryStrategy(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	safesService, err := safes.NewIdsecPCloudSafesService(authenticators...)
+	if err != nil {
+		return nil, err
+	}
+	accountsService, err := accounts.NewIdsecPCloudAccountsService(authenticators...)
+	if err != nil {
+		return nil, err
+	}
+	platformsService, err := platforms.NewIdsecPCloudPlatformsService(authenticators...)
+	if err != nil {
+		return nil, err
+	}
+
+	pcloudInventoryService.IdsecBaseService = baseService
+	pcloudInventoryService.IdsecISPBaseService = ispBaseService
+	pcloudInventoryService.safesService = safesService
+	pcloudInventoryService.accountsService = accountsService
+	pcloudInventoryService.platformsService = platformsService
+	return pcloudInventoryService, nil
+}
+
+func (s *IdsecPCloudInventoryService) refreshPCloudInventoryAuth(client *common.IdsecClient) error {
+	err := isp.RefreshClient(client, s.ISPAuth())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// snapshotWriter streams the snapshot JSON document one array item at a time,
+// so large vaults are never held in memory. The first write error is kept in err.
+type snapshotWriter struct {
+	w     io.Writer
+	items int
+	err   error
+}
+
+func (sw *snapshotWriter) writeRaw(raw string) {
+	if sw.err == nil {
+		_, sw.err = io.WriteString(sw.w, raw)
+	}
+}
+
+func (sw *snapshotWriter) startArray(name string) {
+	sw.writeRaw(fmt.Sprintf(",%q:[", name))
+	sw.items = 0
+}
+
+func (sw *snapshotWriter) writeItem(item interface{}) {
+	if sw.err != nil {
+		return
+	}
+	data, err := json.Marshal(item)
+	if err != nil {
+		sw.err = err
+		return
+	}
+	if sw.items > 0 {
+		sw.writeRaw(",")
+	}
+	sw.writeRaw(string(data))
+	sw.items++
+}
+
+func (sw *snapshotWriter) endArray() {
+	sw.writeRaw("]")
+}
+
+// Snapshot takes an inventory snapshot of the vault and writes it to the given file as gzip compressed JSON.
+func (s *IdsecPCloudInventoryService) Snapshot(createSnapshot *inventorymodels.IdsecPCloudCreateInventorySnapshot) (*inventorymodels.IdsecPCloudInventorySnapshotSummary, error) {
+	if createSnapshot.OutputFile == "" {
+		return nil, fmt.Errorf("output file is required")
+	}
+	outputFile := strings.TrimSuffix(common.ExpandFolder(createSnapshot.OutputFile), "/")
+	if err := os.MkdirAll(filepath.Dir(outputFile), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output folder: %v", err)
+	}
+	file, err := os.OpenFile(outputFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot file: %v", err)
+	}
+	summary, err := s.SnapshotTo(file)
+	closeErr := file.Close()
+	if err != nil {
+		_ = os.Remove(outputFile)
+		return nil, err
+	}
+	if closeErr != nil {
+		return nil, fmt.Errorf("failed to write snapshot file: %v", closeErr)
+	}
+	summary.OutputFile = outputFile
+	return summary, nil
+}
+
+// SnapshotTo takes an inventory snapshot of the vault and streams it to w as gzip compressed JSON.
+//
+// Safes, their members and accounts are written page by page as they are listed. A safe whose
+// members cannot be listed, for example for lack of the View Safe Members permission, is recorded
+// with members_error instead of failing the snapshot.
+func (s *IdsecPCloudInventoryService) SnapshotTo(w io.Writer) (*inventorymodels.IdsecPCloudInventorySnapshotSummary, error) {
+	s.Logger.Info("Taking vault inventory snapshot")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	summary := &inventorymodels.IdsecPCloudInventorySnapshotSummary{
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	gzipWriter := gzip.NewWriter(w)
+	sw := &snapshotWriter{w: gzipWriter}
+	sw.writeRaw(fmt.Sprintf(`{"version":%d,"created_at":%q`, inventorymodels.IdsecPCloudInventorySnapshotVersion, summary.CreatedAt))
+
+	platformsList, err := s.platformsService.List()
+	if err != nil {
+		return nil, err
+	}
+	sw.startArray("platforms")
+	for _, platform := range platformsList {
+		sw.writeItem(platform)
+	}
+	sw.endArray()
+	summary.PlatformsCount = len(platformsList)
+
+	safesPages, err := s.safesService.ListContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sw.startArray("safes")
+	for safesPage := range safesPages {
+		if safesPage.Err != nil {
+			return nil, safesPage.Err
+		}
+		for _, safe := range safesPage.Items {
+			inventorySafe := &inventorymodels.IdsecPCloudInventorySafe{
+				IdsecPCloudSafe: *safe,
+				Members:         make([]*safesmodels.IdsecPCloudSafeMember, 0),
+			}
+			members, err := s.listSafeMembers(ctx, safe.SafeID)
+			if err != nil {
+				s.Logger.Warning("Failed to list members of safe [%s]: %v", safe.SafeName, err)
+				inventorySafe.MembersError = err.Error()
+			} else {
+				inventorySafe.Members = members
+			}
+			sw.writeItem(inventorySafe)
+			summary.SafesCount++
+			summary.MembersCount += len(inventorySafe.Members)
+		}
+		if sw.err != nil {
+			return nil, fmt.Errorf("failed to write snapshot: %v", sw.err)
+		}
+	}
+	sw.endArray()
+
+	accountsPages, err := s.accountsService.ListContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sw.startArray("accounts")
+	for accountsPage := range accountsPages {
+		if accountsPage.Err != nil {
+			return nil, accountsPage.Err
+		}
+		for _, account := range accountsPage.Items {
+			sw.writeItem(account)
+			summary.AccountsCount++
+		}
+		if sw.err != nil {
+			return nil, fmt.Errorf("failed to write snapshot: %v", sw.err)
+		}
+	}
+	sw.endArray()
+	sw.writeRaw("}\n")
+	if sw.err != nil {
+		return nil, fmt.Errorf("failed to write snapshot: %v", sw.err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write snapshot: %v", err)
+	}
+	s.Logger.Info("Vault inventory snapshot holds [%d] safes, [%d] members, [%d] accounts and [%d] platforms",
+		summary.SafesCount, summary.MembersCount, summary.AccountsCount, summary.PlatformsCount)
+	return summary, nil
+}
+
+func (s *IdsecPCloudInventoryService) listSafeMembers(ctx context.Context, safeID string) ([]*safesmodels.IdsecPCloudSafeMember, error) {
+	membersCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	membersPages, err := s.safesService.ListMembersContext(membersCtx, &safesmodels.IdsecPCloudListSafeMembers{SafeID: safeID})
+	if err != nil {
+		return nil, err
+	}
+	members := make([]*safesmodels.IdsecPCloudSafeMember, 0)
+	for membersPage := range membersPages {
+		if membersPage.Err != nil {
+			return nil, membersPage.Err
+		}
+		members = append(members, membersPage.Items...)
+	}
+	return members, nil
+}
+
+// ReadSnapshot reads an inventory snapshot written by SnapshotTo. Both gzip compressed and plain JSON are accepted.
+func ReadSnapshot(r io.Reader) (*inventorymodels.IdsecPCloudInventorySnapshot, error) {
+	bufferedReader := bufio.NewReader(r)
+	var reader io.Reader = bufferedReader
+	if magic, err := bufferedReader.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gzipReader, err := gzip.NewReader(bufferedReader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot: %v", err)
+		}
+		defer gzipReader.Close() //nolint:errcheck
+		reader = gzipReader
+	}
+	var snapshot inventorymodels.IdsecPCloudInventorySnapshot
+	if err := json.NewDecoder(reader).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %v", err)
+	}
+	if snapshot.Version != inventorymodels.IdsecPCloudInventorySnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version [%d]", snapshot.Version)
+	}
+	return &snapshot, nil
+}
+
+func readSnapshotFile(path string) (*inventorymodels.IdsecPCloudInventorySnapshot, error) {
+	file, err := os.Open(strings.TrimSuffix(common.ExpandFolder(path), "/")) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot file: %v", err)
+	}
+	defer func(file *os.File) {
+		err := file.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing snapshot file")
+		}
+	}(file)
+	snapshot, err := ReadSnapshot(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return snapshot, nil
+}
+
+// Diff compares two inventory snapshot files and reports what changed from the base to the target snapshot.
+func (s *IdsecPCloudInventoryService) Diff(diffSnapshots *inventorymodels.IdsecPCloudDiffInventorySnapshots) (*inventorymodels.IdsecPCloudInventoryDiff, error) {
+	s.Logger.Info("Comparing vault inventory snapshots [%s] and [%s]", diffSnapshots.BaseFile, diffSnapshots.TargetFile)
+	base, err := readSnapshotFile(diffSnapshots.BaseFile)
+	if err != nil {
+		return nil, err
+	}
+	target, err := readSnapshotFile(diffSnapshots.TargetFile)
+	if err != nil {
+		return nil, err
+	}
+	return DiffSnapshots(base, target), nil
+}
+
+// ServiceConfig returns the service configuration for the IdsecPCloudInventoryService.
+func (s *IdsecPCloudInventoryService) ServiceConfig() services.IdsecServiceConfig {
+	return ServiceConfig
+}
@@ -0,0 +1,27 @@
+package inventory
+
+import (
+	"github.com/cyberark/idsec-sdk-golang/pkg/models/actions"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services"
+	svcactions "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/inventory/actions"
+)
+
+// ServiceConfig is the configuration for the pcloud inventory service.
+var ServiceConfig = services.IdsecServiceConfig{
+	ServiceName:                "pcloud-inventory",
+	RequiredAuthenticatorNames: []string{},
+	OptionalAuthenticatorNames: []string{"isp"},
+	ActionsConfigurations:      map[actions.IdsecServiceActionType][]actions.IdsecServiceActionDefinition{},
+	ActionSchemas:              svcactions.ActionToSchemaMap,
+}
+
+// ServiceGenerator is the function that generates a new instance of the IdsecPCloudInventoryService.
+var ServiceGenerator = NewIdsecPCloudInventoryService
+
+// Module init, registers the service configuration.
+func init() {
+	err := services.Register(ServiceConfig, false)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,240 @@
+package inventory
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts"
+	accountsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts/models"
+	pcloudint "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/internal"
+	inventorymodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/inventory/models"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/platforms"
+	platformsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/platforms/models"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/safes"
+	safesmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/safes/models"
+)
+
+func newTestPCloudInventoryService(parts *pcloudint.MockISPServiceParts) *IdsecPCloudInventoryService {
+	return &IdsecPCloudInventoryService{
+		IdsecBaseService:    parts.BaseService,
+		IdsecISPBaseService: parts.ISPBase,
+		safesService: &safes.IdsecPCloudSafesService{
+			IdsecBaseService:    parts.BaseService,
+			IdsecISPBaseService: parts.ISPBase,
+		},
+		accountsService: &accounts.IdsecPCloudAccountsService{
+			IdsecBaseService:    parts.BaseService,
+			IdsecISPBaseService: parts.ISPBase,
+		},
+		platformsService: &platforms.IdsecPCloudPlatformsService{
+			IdsecBaseService:    parts.BaseService,
+			IdsecISPBaseService: parts.ISPBase,
+		},
+	}
+}
+
+// fakeVault serves platforms, two pages of safes, safe members and accounts.
+func fakeVault(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/api/platforms":
+		_, _ = fmt.Fprint(w, `{"platforms": [{"general": {"id": "UnixSSH", "name": "Unix via SSH", "active": true}}]}`)
+	case "/api/safes":
+		if r.URL.Query().Get("offset") == "" {
+			_, _ = fmt.Fprint(w, `{"value": [{"safeUrlId": "Finance", "safeName": "Finance", "managingCPM": "PasswordManager"}], "nextLink": "api/safes?offset=1"}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"value": [{"safeUrlId": "Restricted", "safeName": "Restricted"}]}`)
+	case "/api/safes/Finance/members":
+		_, _ = fmt.Fprint(w, `{"value": [{"safeName": "Finance", "memberName": "auditors", "memberType": "Group", "permissions": {"listAccounts": true, "viewAuditLog": true}}]}`)
+	case "/api/safes/Restricted/members":
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = fmt.Fprint(w, `{"ErrorMessage": "missing View Safe Members permission"}`)
+	case "/api/accounts":
+		_, _ = fmt.Fprint(w, `{"value": [{"id": "12_3", "name": "root-db01", "safeName": "Finance", "platformId": "UnixSSH", "userName": "root", "secretManagement": {"automaticManagementEnabled": true}}]}`)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, http.HandlerFunc(fakeVault))
+	defer cleanup()
+	svc := newTestPCloudInventoryService(parts)
+	outputFile := filepath.Join(t.TempDir(), "snapshots", "vault.json.gz")
+
+	summary, err := svc.Snapshot(&inventorymodels.IdsecPCloudCreateInventorySnapshot{OutputFile: outputFile})
+	require.NoError(t, err)
+	require.Equal(t, outputFile, summary.OutputFile)
+	require.Equal(t, 1, summary.PlatformsCount)
+	require.Equal(t, 2, summary.SafesCount)
+	require.Equal(t, 1, summary.MembersCount)
+	require.Equal(t, 1, summary.AccountsCount)
+
+	file, err := os.Open(outputFile)
+	require.NoError(t, err)
+	defer file.Close() //nolint:errcheck
+	gzipReader, err := gzip.NewReader(file)
+	require.NoError(t, err)
+	var document map[string]interface{}
+	require.NoError(t, json.NewDecoder(gzipReader).Decode(&document), "the snapshot is gzip compressed JSON")
+
+	_, err = file.Seek(0, 0)
+	require.NoError(t, err)
+	snapshot, err := ReadSnapshot(file)
+	require.NoError(t, err)
+	require.Equal(t, summary.CreatedAt, snapshot.CreatedAt)
+	require.Equal(t, "UnixSSH", snapshot.Platforms[0].General.ID)
+	require.Len(t, snapshot.Safes, 2)
+	require.Equal(t, "PasswordManager", snapshot.Safes[0].ManagingCPM)
+	require.True(t, snapshot.Safes[0].Members[0].Permissions.ViewAuditLog)
+	require.Empty(t, snapshot.Safes[0].MembersError)
+	require.Contains(t, snapshot.Safes[1].MembersError, "403")
+	require.Equal(t, "UnixSSH", snapshot.Accounts[0].PlatformID)
+	require.True(t, *snapshot.Accounts[0].AutomaticManagementEnabled)
+}
+
+func TestSnapshot_failedListRemovesFile(t *testing.T) {
+	t.Parallel()
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/accounts" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fakeVault(w, r)
+	}))
+	defer cleanup()
+	svc := newTestPCloudInventoryService(parts)
+	outputFile := filepath.Join(t.TempDir(), "vault.json.gz")
+
+	_, err := svc.Snapshot(&inventorymodels.IdsecPCloudCreateInventorySnapshot{OutputFile: outputFile})
+	require.Error(t, err)
+	_, statErr := os.Stat(outputFile)
+	require.True(t, os.IsNotExist(statErr), "a partial snapshot is not left behind")
+}
+
+func TestReadSnapshot_errors(t *testing.T) {
+	t.Parallel()
+	_, err := ReadSnapshot(bytes.NewBufferString(`{"version": 2}`))
+	require.ErrorContains(t, err, "unsupported snapshot version [2]")
+	_, err = ReadSnapshot(bytes.NewBufferString(`not json`))
+	require.ErrorContains(t, err, "failed to read snapshot")
+}
+
+func boolPtr(value bool) *bool {
+	return &value
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	base := &inventorymodels.IdsecPCloudInventorySnapshot{
+		Version:   inventorymodels.IdsecPCloudInventorySnapshotVersion,
+		CreatedAt: "2026-01-01T00:00:00Z",
+		Platforms: []*platformsmodels.IdsecPCloudPlatform{{General: platformsmodels.IdsecPCloudPlatformGeneralDetails{ID: "UnixSSH"}}},
+		Safes: []*inventorymodels.IdsecPCloudInventorySafe{
+			{
+				IdsecPCloudSafe: safesmodels.IdsecPCloudSafe{SafeName: "Finance", ManagingCPM: "PasswordManager"},
+				Members: []*safesmodels.IdsecPCloudSafeMember{
+					{MemberName: "auditors", MemberType: safesmodels.Group, Permissions: safesmodels.IdsecPCloudSafeMemberPermissions{ListAccounts: true, ViewAuditLog: true}},
+					{MemberName: "ops", MemberType: safesmodels.Group, Permissions: safesmodels.IdsecPCloudSafeMemberPermissions{ListAccounts: true, UseAccounts: true}},
+					{MemberName: "john", MemberType: safesmodels.User, Permissions: safesmodels.IdsecPCloudSafeMemberPermissions{ListAccounts: true}},
+				},
+			},
+			{IdsecPCloudSafe: safesmodels.IdsecPCloudSafe{SafeName: "Legacy"}},
+			{IdsecPCloudSafe: safesmodels.IdsecPCloudSafe{SafeName: "Restricted"}, MembersError: "forbidden"},
+		},
+		Accounts: []*accountsmodels.IdsecPCloudAccount{
+			{AccountID: "1_1", SafeName: "Finance", Name: "root-db01", PlatformID: "UnixSSH",
+				IdsecPCloudAccountSecretManagement: accountsmodels.IdsecPCloudAccountSecretManagement{AutomaticManagementEnabled: boolPtr(true)}},
+			{AccountID: "1_2", SafeName: "Finance", Name: "root-db02", PlatformID: "UnixSSH"},
+			{AccountID: "1_3", SafeName: "Legacy", Name: "old"},
+		},
+	}
+	target := &inventorymodels.IdsecPCloudInventorySnapshot{
+		Version:   inventorymodels.IdsecPCloudInventorySnapshotVersion,
+		CreatedAt: "2026-02-01T00:00:00Z",
+		Platforms: []*platformsmodels.IdsecPCloudPlatform{
+			{General: platformsmodels.IdsecPCloudPlatformGeneralDetails{ID: "UnixSSH"}},
+			{General: platformsmodels.IdsecPCloudPlatformGeneralDetails{ID: "WinDomain"}},
+		},
+		Safes: []*inventorymodels.IdsecPCloudInventorySafe{
+			{
+				IdsecPCloudSafe: safesmodels.IdsecPCloudSafe{SafeName: "FINANCE", ManagingCPM: "", OlacEnabled: true},
+				Members: []*safesmodels.IdsecPCloudSafeMember{
+					{MemberName: "auditors", MemberType: safesmodels.Group, Permissions: safesmodels.IdsecPCloudSafeMemberPermissions{ListAccounts: true, ViewAuditLog: true}},
+					{MemberName: "ops", MemberType: safesmodels.Group, Permissions: safesmodels.IdsecPCloudSafeMemberPermissions{ListAccounts: true, RetrieveAccounts: true}},
+					{MemberName: "contractor", MemberType: safesmodels.User, Permissions: safesmodels.IdsecPCloudSafeMemberPermissions{ManageSafe: true}},
+				},
+			},
+			{IdsecPCloudSafe: safesmodels.IdsecPCloudSafe{SafeName: "Restricted"}, Members: []*safesmodels.IdsecPCloudSafeMember{
+				{MemberName: "unknown", MemberType: safesmodels.User, Permissions: safesmodels.IdsecPCloudSafeMemberPermissions{ManageSafe: true}},
+			}},
+			{IdsecPCloudSafe: safesmodels.IdsecPCloudSafe{SafeName: "Payroll"}},
+		},
+		Accounts: []*accountsmodels.IdsecPCloudAccount{
+			{AccountID: "1_1", SafeName: "Finance", Name: "root-db01", PlatformID: "UnixSSH",
+				IdsecPCloudAccountSecretManagement: accountsmodels.IdsecPCloudAccountSecretManagement{AutomaticManagementEnabled: boolPtr(false), ManualManagementReason: "migration"}},
+			{AccountID: "1_2", SafeName: "Finance", Name: "root-db02", PlatformID: "UnixSSHKeys"},
+			{AccountID: "2_1", SafeName: "Payroll", Name: "sa"},
+		},
+	}
+
+	diff := DiffSnapshots(base, target)
+
+	require.Equal(t, []string{"Payroll"}, diff.SafesAdded)
+	require.Equal(t, []string{"Legacy"}, diff.SafesRemoved)
+	require.Len(t, diff.SafesChanged, 1)
+	require.Equal(t, []*inventorymodels.IdsecPCloudInventoryFieldChange{
+		{Field: "managing_cpm", Old: "PasswordManager", New: ""},
+		{Field: "olac_enabled", Old: false, New: true},
+	}, diff.SafesChanged[0].Changes)
+
+	require.Equal(t, []*inventorymodels.IdsecPCloudInventoryPermissionChange{
+		{SafeName: "FINANCE", MemberName: "contractor", MemberType: safesmodels.User, Change: inventorymodels.MemberAdded, Granted: []string{"manage_safe"}, Revoked: []string{}},
+		{SafeName: "FINANCE", MemberName: "ops", MemberType: safesmodels.Group, Change: inventorymodels.MemberChanged, Granted: []string{"retrieve_accounts"}, Revoked: []string{"use_accounts"}},
+	}, diff.PermissionEscalations, "safes whose members could not be listed are not compared")
+	require.Equal(t, []*inventorymodels.IdsecPCloudInventoryPermissionChange{
+		{SafeName: "FINANCE", MemberName: "john", MemberType: safesmodels.User, Change: inventorymodels.MemberRemoved, Revoked: []string{"list_accounts"}},
+	}, diff.PermissionReductions)
+
+	require.Equal(t, []*inventorymodels.IdsecPCloudInventoryAccountRef{{AccountID: "2_1", SafeName: "Payroll", Name: "sa"}}, diff.AccountsAdded)
+	require.Equal(t, []*inventorymodels.IdsecPCloudInventoryAccountRef{{AccountID: "1_3", SafeName: "Legacy", Name: "old"}}, diff.AccountsRemoved)
+	require.Len(t, diff.AccountsChanged, 2)
+	require.Equal(t, []*inventorymodels.IdsecPCloudInventoryFieldChange{
+		{Field: "automatic_management_enabled", Old: true, New: false},
+		{Field: "manual_management_reason", Old: "", New: "migration"},
+	}, diff.AccountsChanged[0].Changes)
+	require.Equal(t, []*inventorymodels.IdsecPCloudInventoryFieldChange{
+		{Field: "platform_id", Old: "UnixSSH", New: "UnixSSHKeys"},
+	}, diff.AccountsChanged[1].Changes)
+	require.Equal(t, []string{"WinDomain"}, diff.PlatformsAdded)
+	require.Empty(t, diff.PlatformsRemoved)
+
+	// Snapshot files, compressed or not, compare the same way
+	dir := t.TempDir()
+	baseFile, targetFile := filepath.Join(dir, "base.json.gz"), filepath.Join(dir, "target.json")
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	require.NoError(t, json.NewEncoder(gzipWriter).Encode(base))
+	require.NoError(t, gzipWriter.Close())
+	require.NoError(t, os.WriteFile(baseFile, compressed.Bytes(), 0600))
+	targetJSON, err := json.Marshal(target)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(targetFile, targetJSON, 0600))
+
+	svc := newTestPCloudInventoryService(pcloudint.NewMockISPServiceParts(""))
+	fileDiff, err := svc.Diff(&inventorymodels.IdsecPCloudDiffInventorySnapshots{BaseFile: baseFile, TargetFile: targetFile})
+	require.NoError(t, err)
+	require.Equal(t, diff, fileDiff)
+
+	_, err = svc.Diff(&inventorymodels.IdsecPCloudDiffInventorySnapshots{BaseFile: filepath.Join(dir, "missing.json"), TargetFile: targetFile})
+	require.ErrorContains(t, err, "failed to open snapshot file")
+}
@@ -0,0 +1,6 @@
+package models
+
+// IdsecPCloudCreateInventorySnapshot represents the details required to take a vault inventory snapshot.
+type IdsecPCloudCreateInventorySnapshot struct {
+	OutputFile string `json:"output_file" mapstructure:"output_file" desc:"The path of the gzip compressed JSON snapshot to write" flag:"output-file" validate:"required"`
+}
@@ -0,0 +1,7 @@
+package models
+
+// IdsecPCloudDiffInventorySnapshots represents the details required to compare two vault inventory snapshots.
+type IdsecPCloudDiffInventorySnapshots struct {
+	BaseFile   string `json:"base_file" mapstructure:"base_file" desc:"The path of the older snapshot" flag:"base-file" validate:"required"`
+	TargetFile string `json:"target_file" mapstructure:"target_file" desc:"The path of the newer snapshot" flag:"target-file" validate:"required"`
+}
@@ -0,0 +1,62 @@
+package models
+
+// Possible safe member changes
+const (
+	MemberAdded   = "added"
+	MemberRemoved = "removed"
+	MemberChanged = "changed"
+)
+
+// IdsecPCloudInventoryFieldChange represents a field whose value differs between two snapshots.
+type IdsecPCloudInventoryFieldChange struct {
+	Field string      `json:"field" mapstructure:"field" desc:"The name of the field"`
+	Old   interface{} `json:"old" mapstructure:"old" desc:"The value in the base snapshot"`
+	New   interface{} `json:"new" mapstructure:"new" desc:"The value in the target snapshot"`
+}
+
+// IdsecPCloudInventorySafeChange represents a safe whose properties changed between two snapshots.
+type IdsecPCloudInventorySafeChange struct {
+	SafeName string                             `json:"safe_name" mapstructure:"safe_name" desc:"The name of the safe"`
+	Changes  []*IdsecPCloudInventoryFieldChange `json:"changes" mapstructure:"changes" desc:"The changed properties"`
+}
+
+// IdsecPCloudInventoryPermissionChange represents a safe member whose permissions changed between two snapshots.
+type IdsecPCloudInventoryPermissionChange struct {
+	SafeName   string   `json:"safe_name" mapstructure:"safe_name" desc:"The name of the safe"`
+	MemberName string   `json:"member_name" mapstructure:"member_name" desc:"The name of the member"`
+	MemberType string   `json:"member_type" mapstructure:"member_type" desc:"The type of the member (User,Group,Role)"`
+	Change     string   `json:"change" mapstructure:"change" desc:"Whether the member was added, removed or had its permissions changed" choices:"added,removed,changed"`
+	Granted    []string `json:"granted,omitempty" mapstructure:"granted,omitempty" desc:"The permissions the member gained"`
+	Revoked    []string `json:"revoked,omitempty" mapstructure:"revoked,omitempty" desc:"The permissions the member lost"`
+}
+
+// IdsecPCloudInventoryAccountRef identifies an account of a snapshot.
+type IdsecPCloudInventoryAccountRef struct {
+	AccountID string `json:"account_id" mapstructure:"account_id" desc:"The unique ID of the account"`
+	SafeName  string `json:"safe_name" mapstructure:"safe_name" desc:"The name of the safe of the account"`
+	Name      string `json:"name" mapstructure:"name" desc:"The name of the account"`
+}
+
+// IdsecPCloudInventoryAccountChange represents an account whose platform or management status changed between two snapshots.
+type IdsecPCloudInventoryAccountChange struct {
+	IdsecPCloudInventoryAccountRef `mapstructure:",squash"`
+	Changes                        []*IdsecPCloudInventoryFieldChange `json:"changes" mapstructure:"changes" desc:"The changed fields"`
+}
+
+// IdsecPCloudInventoryDiff represents the differences between two vault inventory snapshots.
+// Permission changes are reported for safes present in both snapshots; members granted any
+// permission are escalations, members that only lost permissions are reductions.
+type IdsecPCloudInventoryDiff struct {
+	BaseCreatedAt         string                                  `json:"base_created_at" mapstructure:"base_created_at" desc:"The time the base snapshot was taken"`
+	TargetCreatedAt       string                                  `json:"target_created_at" mapstructure:"target_created_at" desc:"The time the target snapshot was taken"`
+	SafesAdded            []string                                `json:"safes_added" mapstructure:"safes_added" desc:"The safes only in the target snapshot"`
+	SafesRemoved          []string                                `json:"safes_removed" mapstructure:"safes_removed" desc:"The safes only in the base snapshot"`
+	SafesChanged          []*IdsecPCloudInventorySafeChange       `json:"safes_changed" mapstructure:"safes_changed" desc:"The safes whose properties changed"`
+	PermissionEscalations []*IdsecPCloudInventoryPermissionChange `json:"permission_escalations" mapstructure:"permission_escalations" desc:"The safe members that gained permissions"`
+	PermissionReductions  []*IdsecPCloudInventoryPermissionChange `json:"permission_reductions" mapstructure:"permission_reductions" desc:"The safe members that only lost permissions"`
+	AccountsAdded         []*IdsecPCloudInventoryAccountRef       `json:"accounts_added" mapstructure:"accounts_added" desc:"The accounts only in the target snapshot"`
+	AccountsRemoved       []*IdsecPCloudInventoryAccountRef       `json:"accounts_removed" mapstructure:"accounts_removed" desc:"The accounts only in the base snapshot"`
+	AccountsChanged       []*IdsecPCloudInventoryAccountChange    `json:"accounts_changed" mapstructure:"accounts_changed" desc:"The accounts whose platform or management status changed"`
+	PlatformsAdded        []string                                `json:"platforms_added" mapstructure:"platforms_added" desc:"The platforms only in the target snapshot"`
+	PlatformsRemoved      []string                                `json:"platforms_removed" mapstructure:"platforms_removed" desc:"The platforms only in the base snapshot"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	accountsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts/models"
+	platformsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/platforms/models"
+	safesmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/safes/models"
+)
+
+// IdsecPCloudInventorySnapshotVersion is the version of the snapshot document format.
+const IdsecPCloudInventorySnapshotVersion = 1
+
+// IdsecPCloudInventorySafe represents a safe of an inventory snapshot together with its members.
+type IdsecPCloudInventorySafe struct {
+	safesmodels.IdsecPCloudSafe `mapstructure:",squash"`
+	Members                     []*safesmodels.IdsecPCloudSafeMember `json:"members" mapstructure:"members" desc:"The members of the safe and their permissions"`
+	MembersError                string                               `json:"members_error,omitempty" mapstructure:"members_error,omitempty" desc:"The error returned when listing the members of the safe, in which case members are not compared"`
+}
+
+// IdsecPCloudInventorySnapshot represents a point-in-time inventory of the vault.
+// Accounts hold metadata only, secrets are never part of a snapshot.
+type IdsecPCloudInventorySnapshot struct {
+	Version   int                                    `json:"version" mapstructure:"version" desc:"The version of the snapshot document format"`
+	CreatedAt string                                 `json:"created_at" mapstructure:"created_at" desc:"The time the snapshot was taken (RFC3339)"`
+	Platforms []*platformsmodels.IdsecPCloudPlatform `json:"platforms" mapstructure:"platforms" desc:"The platforms of the vault"`
+	Safes     []*IdsecPCloudInventorySafe            `json:"safes" mapstructure:"safes" desc:"The safes of the vault and their members"`
+	Accounts  []*accountsmodels.IdsecPCloudAccount   `json:"accounts" mapstructure:"accounts" desc:"The accounts of the vault"`
+}
+
+// IdsecPCloudInventorySnapshotSummary represents the outcome of taking an inventory snapshot.
+type IdsecPCloudInventorySnapshotSummary struct {
+	OutputFile     string `json:"output_file,omitempty" mapstructure:"output_file,omitempty" desc:"The file the snapshot was written to"`
+	CreatedAt      string `json:"created_at" mapstructure:"created_at" desc:"The time the snapshot was taken (RFC3339)"`
+	PlatformsCount int    `json:"platforms_count" mapstructure:"platforms_count" desc:"The number of platforms in the snapshot"`
+	SafesCount     int    `json:"safes_count" mapstructure:"safes_count" desc:"The number of safes in the snapshot"`
+	MembersCount   int    `json:"members_count" mapstructure:"members_count" desc:"The number of safe members in the snapshot"`
+	AccountsCount  int    `json:"accounts_count" mapstructure:"accounts_count" desc:"The number of accounts in the snapshot"`
+}
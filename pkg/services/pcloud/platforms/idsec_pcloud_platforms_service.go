@@ -1,6 +1,7 @@
 package platforms
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
@@ -222,7 +223,24 @@ func (s *IdsecPCloudPlatformsService) Import(importPlatform *platformsmodels.Ids
 		return nil, fmt.Errorf("given path [%s] does not exist or is invalid", importPlatform.PlatformZipPath)
 	}
 
-	zipData, err := os.ReadFile(filePath)
+	zipFile, err := os.Open(filePath) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("failed to read platform zip file: %v", err)
+	}
+	defer func(zipFile *os.File) {
+		err := zipFile.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing platform zip file")
+		}
+	}(zipFile)
+	return s.ImportFrom(zipFile)
+}
+
+// ImportFrom imports a platform from zip data read from platformZip.
+//
+// Same as Import, without requiring the zip to be stored on disk.
+func (s *IdsecPCloudPlatformsService) ImportFrom(platformZip io.Reader) (*platformsmodels.IdsecPCloudPlatformDetails, error) {
+	zipData, err := io.ReadAll(platformZip)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read platform zip file: %v", err)
 	}
@@ -272,7 +290,24 @@ func (s *IdsecPCloudPlatformsService) Export(exportPlatform *platformsmodels.Ids
 		return fmt.Errorf("failed to create output folder: %v", err)
 	}
 
-	response, err := s.postOperation()(context.Background(), fmt.Sprintf(exportPlatformURL, exportPlatform.PlatformID), nil)
+	var data bytes.Buffer
+	if err := s.ExportTo(exportPlatform.PlatformID, &data); err != nil {
+		return err
+	}
+
+	outputPath := filepath.Join(exportPlatform.OutputFolder, exportPlatform.PlatformID)
+	if err := os.WriteFile(fmt.Sprintf("%s.zip", outputPath), data.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write export file: %v", err)
+	}
+
+	return nil
+}
+
+// ExportTo exports a platform by id and writes its zip data to w.
+//
+// Same as Export, without storing the zip on disk.
+func (s *IdsecPCloudPlatformsService) ExportTo(platformID string, w io.Writer) error {
+	response, err := s.postOperation()(context.Background(), fmt.Sprintf(exportPlatformURL, platformID), nil)
 	if err != nil {
 		return err
 	}
@@ -287,16 +322,9 @@ func (s *IdsecPCloudPlatformsService) Export(exportPlatform *platformsmodels.Ids
 		return fmt.Errorf("failed to export platform - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
 	}
 
-	data, err := io.ReadAll(response.Body)
-	if err != nil {
+	if _, err := io.Copy(w, response.Body); err != nil {
 		return fmt.Errorf("failed to read export data: %v", err)
 	}
-
-	outputPath := filepath.Join(exportPlatform.OutputFolder, exportPlatform.PlatformID)
-	if err := os.WriteFile(fmt.Sprintf("%s.zip", outputPath), data, 0644); err != nil {
-		return fmt.Errorf("failed to write export file: %v", err)
-	}
-
 	return nil
 }
 
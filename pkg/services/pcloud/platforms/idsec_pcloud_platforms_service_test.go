@@ -3,10 +3,12 @@ package platforms
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/cyberark/idsec-sdk-golang/pkg/common"
@@ -609,6 +611,63 @@ func TestExport(t *testing.T) {
 	}
 }
 
+// TestImportFrom tests the ImportFrom method.
+//
+// This test validates that zip data read from a reader is sent base64 encoded.
+func TestImportFrom(t *testing.T) {
+	t.Parallel()
+
+	service := createTestService()
+	var importFile interface{}
+	service.doPost = func(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+		importFile = body.(map[string]interface{})["ImportFile"]
+		return NewMockResponse(http.StatusCreated, `{"platform_id": "platform-123"}`), nil
+	}
+	service.doGet = func(ctx context.Context, path string, params interface{}) (*http.Response, error) {
+		return NewMockResponse(http.StatusOK, `{"general": {"id": "platform-123"}}`), nil
+	}
+
+	_, err := service.ImportFrom(strings.NewReader("fake zip content"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if importFile != base64.StdEncoding.EncodeToString([]byte("fake zip content")) {
+		t.Errorf("Expected base64 encoded zip content, got %v", importFile)
+	}
+}
+
+// TestExportTo tests the ExportTo method.
+//
+// This test validates that the exported zip data is written to the writer.
+func TestExportTo(t *testing.T) {
+	t.Parallel()
+
+	service := createTestService()
+	var exportPath string
+	service.doPost = func(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+		exportPath = path
+		return NewMockResponse(http.StatusOK, "fake zip content"), nil
+	}
+
+	var buf bytes.Buffer
+	if err := service.ExportTo("platform-123", &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if buf.String() != "fake zip content" {
+		t.Errorf("Expected exported zip content, got %q", buf.String())
+	}
+	if exportPath != "api/platforms/platform-123/export" {
+		t.Errorf("Expected export path, got %s", exportPath)
+	}
+
+	service.doPost = func(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+		return NewMockResponse(http.StatusNotFound, `{"error": "platform not found"}`), nil
+	}
+	if err := service.ExportTo("nonexistent", &bytes.Buffer{}); err == nil || !strings.HasPrefix(err.Error(), "failed to export platform") {
+		t.Errorf("Expected export error, got %v", err)
+	}
+}
+
 // TestStats tests the Stats method.
 //
 // This test validates platform statistics calculation.
@@ -0,0 +1,353 @@
+// Package pkg edits platform packages offline. A platform package is the zip
+// returned by the platforms Export API and accepted by the platforms and target
+// platforms Import APIs. It holds the CPM policy (Policy-<PlatformID>.ini),
+// the PVWA settings (Policy-<PlatformID>.xml) and any plugin files.
+package pkg
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+)
+
+// Well known top level CPM parameters of the policy INI file.
+const (
+	CPMParameterPolicyID   = "PolicyID"
+	CPMParameterPolicyName = "PolicyName"
+)
+
+var (
+	platformIDPattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+	// numericCPMParameters must hold integers. Some accept -1, e.g. MinSpecial=-1 excludes special characters.
+	numericCPMParameters = []string{
+		"ImmediateInterval", "Interval", "MinValidityPeriod", "HeadStartInterval",
+		"MinLength", "MaxLength", "MinUpperCase", "MinLowerCase", "MinDigit", "MinSpecial",
+	}
+
+	// yesNoCPMParameters must hold Yes or No.
+	yesNoCPMParameters = []string{
+		"AllowManualChange", "PerformPeriodicChange", "AllowManualVerification", "PerformPeriodicVerification",
+		"AllowManualReconciliation", "AutomaticReconcileWhenUnsynched", "SearchForUsages",
+	}
+)
+
+type packageFile struct {
+	name     string
+	modified time.Time
+	data     []byte
+}
+
+// IdsecPCloudPlatformPackage is an opened platform zip.
+// The policy INI and XML files are parsed and can be edited in place; every other file is kept as is.
+type IdsecPCloudPlatformPackage struct {
+	files         []*packageFile
+	policyININame string
+	policyXMLName string
+	xmlFiles      map[string]*IdsecPCloudPlatformPolicyXML
+
+	// PolicyINI is the parsed CPM policy file.
+	PolicyINI *IdsecPCloudPlatformPolicyINI
+	// PolicyXML is the parsed PVWA settings file, or nil when the package has none.
+	PolicyXML *IdsecPCloudPlatformPolicyXML
+}
+
+// OpenPlatformPackage reads a platform zip from disk.
+func OpenPlatformPackage(platformZipPath string) (*IdsecPCloudPlatformPackage, error) {
+	filePath := strings.TrimSuffix(common.ExpandFolder(platformZipPath), "/")
+	data, err := os.ReadFile(filePath) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("failed to read platform zip file: %v", err)
+	}
+	return ReadPlatformPackage(data)
+}
+
+// ReadPlatformPackage parses a platform zip held in memory.
+func ReadPlatformPackage(data []byte) (*IdsecPCloudPlatformPackage, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open platform zip: %v", err)
+	}
+	platformPackage := &IdsecPCloudPlatformPackage{xmlFiles: map[string]*IdsecPCloudPlatformPolicyXML{}}
+	for _, entry := range reader.File {
+		if entry.FileInfo().IsDir() {
+			continue
+		}
+		content, err := readZipEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		platformPackage.files = append(platformPackage.files, &packageFile{name: entry.Name, modified: entry.Modified, data: content})
+		base := path.Base(entry.Name)
+		lower := strings.ToLower(base)
+		switch {
+		case strings.HasPrefix(lower, "policy-") && strings.HasSuffix(lower, ".ini"):
+			if platformPackage.PolicyINI != nil {
+				return nil, fmt.Errorf("platform zip contains more than one policy ini file")
+			}
+			platformPackage.policyININame = entry.Name
+			platformPackage.PolicyINI = ParsePolicyINI(content)
+		case strings.HasSuffix(lower, ".xml"):
+			policyXML, err := ParsePolicyXML(content)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse [%s]: %w", entry.Name, err)
+			}
+			platformPackage.xmlFiles[entry.Name] = policyXML
+			if strings.HasPrefix(lower, "policy-") {
+				platformPackage.policyXMLName = entry.Name
+				platformPackage.PolicyXML = policyXML
+			}
+		}
+	}
+	if platformPackage.PolicyINI == nil {
+		return nil, fmt.Errorf("platform zip does not contain a Policy-*.ini file")
+	}
+	return platformPackage, nil
+}
+
+func readZipEntry(entry *zip.File) ([]byte, error) {
+	reader, err := entry.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open [%s]: %v", entry.Name, err)
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			common.GlobalLogger.Warning("Error closing platform zip entry")
+		}
+	}()
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read [%s]: %v", entry.Name, err)
+	}
+	return content, nil
+}
+
+// PlatformID returns the platform ID declared in the policy INI file.
+func (p *IdsecPCloudPlatformPackage) PlatformID() string {
+	platformID, _ := p.PolicyINI.Get("", CPMParameterPolicyID)
+	return platformID
+}
+
+// SetPlatformID changes the platform ID in the policy INI and XML files and renames both files to match.
+func (p *IdsecPCloudPlatformPackage) SetPlatformID(platformID string) error {
+	if !platformIDPattern.MatchString(platformID) {
+		return fmt.Errorf("invalid platform id [%s] - only letters, digits and underscores are allowed", platformID)
+	}
+	p.PolicyINI.Set("", CPMParameterPolicyID, platformID)
+	p.policyININame = p.renameFile(p.policyININame, "Policy-"+platformID+".ini")
+	if p.PolicyXML != nil {
+		if err := p.PolicyXML.SetPolicyID(platformID); err != nil {
+			return err
+		}
+		newName := p.renameFile(p.policyXMLName, "Policy-"+platformID+".xml")
+		delete(p.xmlFiles, p.policyXMLName)
+		p.xmlFiles[newName] = p.PolicyXML
+		p.policyXMLName = newName
+	}
+	return nil
+}
+
+// Name returns the platform display name declared in the policy INI file.
+func (p *IdsecPCloudPlatformPackage) Name() string {
+	name, _ := p.PolicyINI.Get("", CPMParameterPolicyName)
+	return name
+}
+
+// SetName changes the platform display name.
+func (p *IdsecPCloudPlatformPackage) SetName(name string) {
+	p.PolicyINI.Set("", CPMParameterPolicyName, name)
+}
+
+// CPMParameter returns a top level CPM parameter of the policy INI file.
+func (p *IdsecPCloudPlatformPackage) CPMParameter(key string) (string, bool) {
+	return p.PolicyINI.Get("", key)
+}
+
+// SetCPMParameter sets a top level CPM parameter of the policy INI file.
+func (p *IdsecPCloudPlatformPackage) SetCPMParameter(key string, value string) {
+	p.PolicyINI.Set("", key, value)
+}
+
+// CPMParameters returns all top level CPM parameters of the policy INI file.
+func (p *IdsecPCloudPlatformPackage) CPMParameters() map[string]string {
+	parameters := map[string]string{}
+	for _, key := range p.PolicyINI.Keys("") {
+		parameters[key], _ = p.PolicyINI.Get("", key)
+	}
+	return parameters
+}
+
+// FileNames returns the names of all files in the package in zip order.
+func (p *IdsecPCloudPlatformPackage) FileNames() []string {
+	names := make([]string, 0, len(p.files))
+	for _, file := range p.files {
+		names = append(names, file.name)
+	}
+	return names
+}
+
+// XMLFile returns a parsed XML file of the package by name.
+func (p *IdsecPCloudPlatformPackage) XMLFile(name string) (*IdsecPCloudPlatformPolicyXML, bool) {
+	xmlFile, ok := p.xmlFiles[name]
+	return xmlFile, ok
+}
+
+// File returns the raw content of a file of the package.
+// Parsed INI and XML files reflect any edits made so far.
+func (p *IdsecPCloudPlatformPackage) File(name string) ([]byte, bool) {
+	for _, file := range p.files {
+		if file.name == name {
+			content, err := p.fileContent(file)
+			if err != nil {
+				return nil, false
+			}
+			return content, true
+		}
+	}
+	return nil, false
+}
+
+// SetFile adds or replaces a raw file of the package. The policy files must be edited through PolicyINI and PolicyXML.
+func (p *IdsecPCloudPlatformPackage) SetFile(name string, data []byte) error {
+	if name == p.policyININame || name == p.policyXMLName {
+		return fmt.Errorf("file [%s] is a parsed policy file and cannot be replaced", name)
+	}
+	if strings.HasSuffix(strings.ToLower(name), ".xml") {
+		xmlFile, err := ParsePolicyXML(data)
+		if err != nil {
+			return err
+		}
+		p.xmlFiles[name] = xmlFile
+	}
+	for _, file := range p.files {
+		if file.name == name {
+			file.data = data
+			file.modified = time.Now()
+			return nil
+		}
+	}
+	p.files = append(p.files, &packageFile{name: name, modified: time.Now(), data: data})
+	return nil
+}
+
+// Validate checks that the package is consistent and can be imported.
+// All problems found are returned joined together.
+func (p *IdsecPCloudPlatformPackage) Validate() error {
+	var errs []error
+	platformID := p.PlatformID()
+	switch {
+	case platformID == "":
+		errs = append(errs, fmt.Errorf("policy ini is missing %s", CPMParameterPolicyID))
+	case !platformIDPattern.MatchString(platformID):
+		errs = append(errs, fmt.Errorf("invalid platform id [%s] - only letters, digits and underscores are allowed", platformID))
+	case !strings.EqualFold(path.Base(p.policyININame), "Policy-"+platformID+".ini"):
+		errs = append(errs, fmt.Errorf("policy ini file [%s] does not match platform id [%s]", p.policyININame, platformID))
+	}
+	if p.Name() == "" {
+		errs = append(errs, fmt.Errorf("policy ini is missing %s", CPMParameterPolicyName))
+	}
+	if p.PolicyXML != nil && platformID != "" {
+		if xmlPlatformID := p.PolicyXML.PolicyID(); xmlPlatformID != platformID {
+			errs = append(errs, fmt.Errorf("policy xml id [%s] does not match platform id [%s]", xmlPlatformID, platformID))
+		}
+		if !strings.EqualFold(path.Base(p.policyXMLName), "Policy-"+platformID+".xml") {
+			errs = append(errs, fmt.Errorf("policy xml file [%s] does not match platform id [%s]", p.policyXMLName, platformID))
+		}
+	}
+	for _, key := range numericCPMParameters {
+		if value, ok := p.CPMParameter(key); ok && value != "" {
+			if _, err := strconv.Atoi(value); err != nil {
+				errs = append(errs, fmt.Errorf("cpm parameter %s must be an integer, got [%s]", key, value))
+			}
+		}
+	}
+	for _, key := range yesNoCPMParameters {
+		if value, ok := p.CPMParameter(key); ok && value != "" && !strings.EqualFold(value, "yes") && !strings.EqualFold(value, "no") {
+			errs = append(errs, fmt.Errorf("cpm parameter %s must be Yes or No, got [%s]", key, value))
+		}
+	}
+	minLength, minErr := strconv.Atoi(p.cpmParameterOrEmpty("MinLength"))
+	maxLength, maxErr := strconv.Atoi(p.cpmParameterOrEmpty("MaxLength"))
+	if minErr == nil && maxErr == nil && minLength > maxLength {
+		errs = append(errs, fmt.Errorf("cpm parameter MinLength [%d] is greater than MaxLength [%d]", minLength, maxLength))
+	}
+	return errors.Join(errs...)
+}
+
+// Bytes validates the package and re-packs it as a zip suitable for Import.
+func (p *IdsecPCloudPlatformPackage) Bytes() ([]byte, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, file := range p.files {
+		content, err := p.fileContent(file)
+		if err != nil {
+			return nil, err
+		}
+		entry, err := writer.CreateHeader(&zip.FileHeader{Name: file.name, Method: zip.Deflate, Modified: file.modified})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := entry.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Save validates the package and writes the re-packed zip to platformZipPath, ready to be passed to Import.
+func (p *IdsecPCloudPlatformPackage) Save(platformZipPath string) error {
+	data, err := p.Bytes()
+	if err != nil {
+		return err
+	}
+	filePath := strings.TrimSuffix(common.ExpandFolder(platformZipPath), "/")
+	if err := os.WriteFile(filePath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write platform zip file: %v", err)
+	}
+	return nil
+}
+
+func (p *IdsecPCloudPlatformPackage) fileContent(file *packageFile) ([]byte, error) {
+	if file.name == p.policyININame {
+		return p.PolicyINI.Bytes(), nil
+	}
+	if xmlFile, ok := p.xmlFiles[file.name]; ok {
+		return xmlFile.Bytes()
+	}
+	return file.data, nil
+}
+
+func (p *IdsecPCloudPlatformPackage) cpmParameterOrEmpty(key string) string {
+	value, _ := p.CPMParameter(key)
+	return value
+}
+
+// renameFile renames a package file within its folder and returns the new full name.
+func (p *IdsecPCloudPlatformPackage) renameFile(oldName string, newBase string) string {
+	newName := newBase
+	if dir := path.Dir(oldName); dir != "." {
+		newName = path.Join(dir, newBase)
+	}
+	for _, file := range p.files {
+		if file.name == oldName {
+			file.name = newName
+		}
+	}
+	return newName
+}
@@ -0,0 +1,230 @@
+package pkg
+
+import (
+	"archive/zip"
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicyINI = "PolicyID=UnixSSH\t\t;Mandatory, Do not change\r\n" +
+	"PolicyName=Unix via SSH\r\n" +
+	"; CPM parameters\r\n" +
+	"ImmediateInterval=5\r\n" +
+	"Interval=1440\r\n" +
+	"MinLength=12\r\n" +
+	"MaxLength=39\r\n" +
+	"PasswordForbiddenChars=;=\t;inline comment\r\n" +
+	"[ExtraInfo]\r\n" +
+	"PromptsFilename=Bin\\Unix-Prompts.ini\r\n"
+
+const testPolicyXML = `<?xml version="1.0" encoding="utf-8"?>
+<Device Name="Operating System">
+  <Policies>
+    <Policy ID="UnixSSH" PlatformBaseID="Unix" AutoChangeOnAdd="No">
+      <!-- account properties -->
+      <Properties>
+        <Required>
+          <Property Name="Username" />
+          <Property Name="Address" />
+        </Required>
+        <Optional>
+          <Property Name="Port" />
+        </Optional>
+      </Properties>
+    </Policy>
+  </Policies>
+</Device>
+`
+
+func buildTestZip(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for _, name := range []string{"Policy-UnixSSH.ini", "Policy-UnixSSH.xml", "Bin/Unix-Prompts.ini"} {
+		content, ok := files[name]
+		if !ok {
+			continue
+		}
+		entry, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = entry.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+func defaultTestFiles() map[string]string {
+	return map[string]string{
+		"Policy-UnixSSH.ini":   testPolicyINI,
+		"Policy-UnixSSH.xml":   testPolicyXML,
+		"Bin/Unix-Prompts.ini": "conditions=raw\n",
+	}
+}
+
+func TestParsePolicyINI_roundTripAndEdit(t *testing.T) {
+	t.Parallel()
+	ini := ParsePolicyINI([]byte(testPolicyINI))
+	require.Equal(t, testPolicyINI, string(ini.Bytes()), "untouched files round-trip byte for byte")
+
+	value, ok := ini.Get("", "policyid")
+	require.True(t, ok)
+	require.Equal(t, "UnixSSH", value)
+	value, _ = ini.Get("", "PasswordForbiddenChars")
+	require.Equal(t, ";=", value, "a semicolon not preceded by whitespace is part of the value")
+	value, _ = ini.Get("ExtraInfo", "PromptsFilename")
+	require.Equal(t, `Bin\Unix-Prompts.ini`, value)
+	require.Equal(t, []string{"ExtraInfo"}, ini.Sections())
+
+	ini.Set("", "PolicyID", "UnixSSHCopy")
+	ini.Set("", "MinValidityPeriod", "60")
+	ini.Set("ExtraInfo", "Port", "22")
+	ini.Set("Debug", "Level", "1")
+	require.True(t, ini.Delete("", "Interval"))
+
+	expected := "PolicyID=UnixSSHCopy\t\t;Mandatory, Do not change\r\n" +
+		"PolicyName=Unix via SSH\r\n" +
+		"; CPM parameters\r\n" +
+		"ImmediateInterval=5\r\n" +
+		"MinLength=12\r\n" +
+		"MaxLength=39\r\n" +
+		"PasswordForbiddenChars=;=\t;inline comment\r\n" +
+		"MinValidityPeriod=60\r\n" +
+		"[ExtraInfo]\r\n" +
+		"PromptsFilename=Bin\\Unix-Prompts.ini\r\n" +
+		"Port=22\r\n" +
+		"[Debug]\r\n" +
+		"Level=1\r\n"
+	require.Equal(t, expected, string(ini.Bytes()))
+}
+
+func TestParsePolicyXML_properties(t *testing.T) {
+	t.Parallel()
+	policyXML, err := ParsePolicyXML([]byte(testPolicyXML))
+	require.NoError(t, err)
+	require.Equal(t, "UnixSSH", policyXML.PolicyID())
+	require.Equal(t, []string{"Username", "Address"}, policyXML.Properties(PropertiesRequired))
+	require.Equal(t, []string{"Port"}, policyXML.Properties(PropertiesOptional))
+
+	require.NoError(t, policyXML.AddProperty(PropertiesRequired, "Port"))
+	require.NoError(t, policyXML.AddProperty(PropertiesOptional, "LogonDomain"))
+	require.True(t, policyXML.RemoveProperty("Address"))
+	require.False(t, policyXML.RemoveProperty("Missing"))
+	require.Error(t, policyXML.AddProperty("Other", "X"))
+
+	data, err := policyXML.Bytes()
+	require.NoError(t, err)
+	reparsed, err := ParsePolicyXML(data)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Username", "Port"}, reparsed.Properties(PropertiesRequired))
+	require.Equal(t, []string{"LogonDomain"}, reparsed.Properties(PropertiesOptional))
+	require.True(t, strings.HasPrefix(string(data), `<?xml version="1.0" encoding="utf-8"?>`))
+	require.Contains(t, string(data), "<!-- account properties -->")
+	autoChange, _ := reparsed.Policy().Attr("AutoChangeOnAdd")
+	require.Equal(t, "No", autoChange)
+
+	_, err = ParsePolicyXML([]byte("not xml"))
+	require.Error(t, err)
+}
+
+func TestPlatformPackage_editAndRepack(t *testing.T) {
+	t.Parallel()
+	platformPackage, err := ReadPlatformPackage(buildTestZip(t, defaultTestFiles()))
+	require.NoError(t, err)
+	require.Equal(t, "UnixSSH", platformPackage.PlatformID())
+	require.Equal(t, "Unix via SSH", platformPackage.Name())
+	require.Equal(t, "1440", platformPackage.CPMParameters()["Interval"])
+	require.NoError(t, platformPackage.Validate())
+
+	require.NoError(t, platformPackage.SetPlatformID("UnixSSH_Prod"))
+	platformPackage.SetName("Unix via SSH (Prod)")
+	platformPackage.SetCPMParameter("Interval", "720")
+	require.NoError(t, platformPackage.PolicyXML.AddProperty(PropertiesOptional, "LogonDomain"))
+
+	zipPath := filepath.Join(t.TempDir(), "UnixSSH_Prod.zip")
+	require.NoError(t, platformPackage.Save(zipPath))
+
+	reopened, err := OpenPlatformPackage(zipPath)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Policy-UnixSSH_Prod.ini", "Policy-UnixSSH_Prod.xml", "Bin/Unix-Prompts.ini"}, reopened.FileNames())
+	require.Equal(t, "UnixSSH_Prod", reopened.PlatformID())
+	require.Equal(t, "Unix via SSH (Prod)", reopened.Name())
+	interval, _ := reopened.CPMParameter("Interval")
+	require.Equal(t, "720", interval)
+	require.Equal(t, "UnixSSH_Prod", reopened.PolicyXML.PolicyID())
+	require.Equal(t, []string{"Port", "LogonDomain"}, reopened.PolicyXML.Properties(PropertiesOptional))
+	prompts, ok := reopened.File("Bin/Unix-Prompts.ini")
+	require.True(t, ok)
+	require.Equal(t, "conditions=raw\n", string(prompts))
+	require.NoError(t, reopened.Validate())
+}
+
+func TestPlatformPackage_validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		edit           func(p *IdsecPCloudPlatformPackage)
+		expectedErrors []string
+	}{
+		{
+			name: "invalid_parameters",
+			edit: func(p *IdsecPCloudPlatformPackage) {
+				p.SetCPMParameter("Interval", "daily")
+				p.SetCPMParameter("AllowManualChange", "maybe")
+				p.SetCPMParameter("MinLength", "40")
+			},
+			expectedErrors: []string{
+				"cpm parameter Interval must be an integer, got [daily]",
+				"cpm parameter AllowManualChange must be Yes or No, got [maybe]",
+				"cpm parameter MinLength [40] is greater than MaxLength [39]",
+			},
+		},
+		{
+			name: "ids_out_of_sync",
+			edit: func(p *IdsecPCloudPlatformPackage) {
+				p.PolicyINI.Set("", CPMParameterPolicyID, "Other")
+				p.SetName("")
+			},
+			expectedErrors: []string{
+				"policy ini file [Policy-UnixSSH.ini] does not match platform id [Other]",
+				"policy ini is missing PolicyName",
+				"policy xml id [UnixSSH] does not match platform id [Other]",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			platformPackage, err := ReadPlatformPackage(buildTestZip(t, defaultTestFiles()))
+			require.NoError(t, err)
+			tt.edit(platformPackage)
+			err = platformPackage.Validate()
+			require.Error(t, err)
+			for _, expected := range tt.expectedErrors {
+				require.Contains(t, err.Error(), expected)
+			}
+			_, err = platformPackage.Bytes()
+			require.Error(t, err, "invalid packages are not re-packed")
+		})
+	}
+}
+
+func TestPlatformPackage_errors(t *testing.T) {
+	t.Parallel()
+	_, err := ReadPlatformPackage([]byte("not a zip"))
+	require.ErrorContains(t, err, "failed to open platform zip")
+
+	_, err = ReadPlatformPackage(buildTestZip(t, map[string]string{"Bin/Unix-Prompts.ini": "x"}))
+	require.ErrorContains(t, err, "does not contain a Policy-*.ini file")
+
+	platformPackage, err := ReadPlatformPackage(buildTestZip(t, defaultTestFiles()))
+	require.NoError(t, err)
+	require.ErrorContains(t, platformPackage.SetPlatformID("Unix SSH"), "invalid platform id")
+	require.ErrorContains(t, platformPackage.SetFile("Policy-UnixSSH.ini", nil), "cannot be replaced")
+	require.NoError(t, platformPackage.SetFile("Bin/Unix-Process.ini", []byte("[states]\n")))
+	require.Contains(t, platformPackage.FileNames(), "Bin/Unix-Process.ini")
+}
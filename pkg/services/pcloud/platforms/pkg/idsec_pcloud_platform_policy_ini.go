@@ -0,0 +1,180 @@
+package pkg
+
+import (
+	"bytes"
+	"strings"
+)
+
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// iniLine is a single line of a platform INI file.
+// Key lines keep their original suffix (whitespace and inline comment) so that edits round-trip cleanly.
+type iniLine struct {
+	raw     string
+	section string
+	key     string
+	value   string
+	suffix  string
+	isKey   bool
+}
+
+// IdsecPCloudPlatformPolicyINI represents a parsed CPM policy INI file (Policy-<PlatformID>.ini).
+// Keys outside any section are the CPM parameters of the platform; comments, ordering
+// and line endings are preserved when the file is written back.
+type IdsecPCloudPlatformPolicyINI struct {
+	lines   []*iniLine
+	newline string
+	bom     bool
+}
+
+// ParsePolicyINI parses the contents of a platform INI file.
+func ParsePolicyINI(data []byte) *IdsecPCloudPlatformPolicyINI {
+	bom := bytes.HasPrefix(data, utf8BOM)
+	content := string(bytes.TrimPrefix(data, utf8BOM))
+	newline := "\n"
+	if strings.Contains(content, "\r\n") {
+		newline = "\r\n"
+	}
+	content = strings.TrimSuffix(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	ini := &IdsecPCloudPlatformPolicyINI{newline: newline, bom: bom}
+	if content == "" {
+		return ini
+	}
+	section := ""
+	for _, raw := range strings.Split(content, "\n") {
+		line := &iniLine{raw: raw, section: section}
+		trimmed := strings.TrimSpace(raw)
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, ";") || strings.HasPrefix(trimmed, "#"):
+		case strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]"):
+			section = strings.TrimSpace(trimmed[1 : len(trimmed)-1])
+			line.section = section
+		default:
+			if idx := strings.Index(raw, "="); idx > 0 {
+				line.isKey = true
+				line.key = strings.TrimSpace(raw[:idx])
+				line.value, line.suffix = splitINIValue(raw[idx+1:])
+			}
+		}
+		ini.lines = append(ini.lines, line)
+	}
+	return ini
+}
+
+// splitINIValue splits the text after "=" into the value and its trailing whitespace and inline comment.
+// An inline comment starts at a ";" preceded by whitespace, so values may still contain ";".
+func splitINIValue(rest string) (string, string) {
+	end := len(rest)
+	for i := 1; i < len(rest); i++ {
+		if rest[i] == ';' && (rest[i-1] == ' ' || rest[i-1] == '\t') {
+			end = i
+			break
+		}
+	}
+	valuePart := strings.TrimRight(rest[:end], " \t")
+	return strings.TrimLeft(valuePart, " \t"), rest[len(valuePart):]
+}
+
+// Get returns the value of key in section. Use an empty section for the top level CPM parameters.
+// Keys are matched case-insensitively, like the CPM does.
+func (i *IdsecPCloudPlatformPolicyINI) Get(section string, key string) (string, bool) {
+	if line := i.find(section, key); line != nil {
+		return line.value, true
+	}
+	return "", false
+}
+
+// Set sets the value of key in section, adding the key at the end of the section when missing.
+func (i *IdsecPCloudPlatformPolicyINI) Set(section string, key string, value string) {
+	if line := i.find(section, key); line != nil {
+		line.value = value
+		line.raw = ""
+		return
+	}
+	line := &iniLine{section: section, key: key, value: value, isKey: true}
+	insertAfter := -1
+	for idx, existing := range i.lines {
+		if !strings.EqualFold(existing.section, section) {
+			continue
+		}
+		if existing.isKey || (section != "" && insertAfter == -1) {
+			insertAfter = idx
+		}
+	}
+	if insertAfter == -1 && section == "" {
+		// Top level keys go before the first section header.
+		insertAfter = len(i.lines) - 1
+		for idx, existing := range i.lines {
+			if existing.section != "" {
+				insertAfter = idx - 1
+				break
+			}
+		}
+	}
+	if insertAfter == -1 && section != "" {
+		i.lines = append(i.lines, &iniLine{raw: "[" + section + "]", section: section}, line)
+		return
+	}
+	i.lines = append(i.lines[:insertAfter+1], append([]*iniLine{line}, i.lines[insertAfter+1:]...)...)
+}
+
+// Delete removes key from section and reports whether it existed.
+func (i *IdsecPCloudPlatformPolicyINI) Delete(section string, key string) bool {
+	for idx, line := range i.lines {
+		if line.isKey && strings.EqualFold(line.section, section) && strings.EqualFold(line.key, key) {
+			i.lines = append(i.lines[:idx], i.lines[idx+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Keys returns the keys of section in file order.
+func (i *IdsecPCloudPlatformPolicyINI) Keys(section string) []string {
+	keys := make([]string, 0)
+	for _, line := range i.lines {
+		if line.isKey && strings.EqualFold(line.section, section) {
+			keys = append(keys, line.key)
+		}
+	}
+	return keys
+}
+
+// Sections returns the named sections in file order.
+func (i *IdsecPCloudPlatformPolicyINI) Sections() []string {
+	sections := make([]string, 0)
+	seen := map[string]bool{}
+	for _, line := range i.lines {
+		if line.section != "" && !seen[strings.ToLower(line.section)] {
+			seen[strings.ToLower(line.section)] = true
+			sections = append(sections, line.section)
+		}
+	}
+	return sections
+}
+
+// Bytes serializes the INI file, keeping untouched lines byte for byte.
+func (i *IdsecPCloudPlatformPolicyINI) Bytes() []byte {
+	var buf bytes.Buffer
+	if i.bom {
+		buf.Write(utf8BOM)
+	}
+	for _, line := range i.lines {
+		if line.isKey && line.raw == "" {
+			buf.WriteString(line.key + "=" + line.value + line.suffix)
+		} else {
+			buf.WriteString(line.raw)
+		}
+		buf.WriteString(i.newline)
+	}
+	return buf.Bytes()
+}
+
+func (i *IdsecPCloudPlatformPolicyINI) find(section string, key string) *iniLine {
+	for _, line := range i.lines {
+		if line.isKey && strings.EqualFold(line.section, section) && strings.EqualFold(line.key, key) {
+			return line
+		}
+	}
+	return nil
+}
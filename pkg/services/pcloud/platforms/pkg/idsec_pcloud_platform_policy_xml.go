@@ -0,0 +1,273 @@
+package pkg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Property kinds of a platform policy XML.
+const (
+	PropertiesRequired = "Required"
+	PropertiesOptional = "Optional"
+)
+
+// IdsecPCloudPlatformXMLNode is an element (or comment) of a platform XML file.
+// Element order, attribute order and comments are kept so files round-trip with only whitespace changes.
+type IdsecPCloudPlatformXMLNode struct {
+	Name     string
+	Attrs    []xml.Attr
+	Text     string
+	Comment  string
+	Children []*IdsecPCloudPlatformXMLNode
+}
+
+// Attr returns the value of the named attribute.
+func (n *IdsecPCloudPlatformXMLNode) Attr(name string) (string, bool) {
+	for _, attr := range n.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// SetAttr sets the named attribute, appending it when missing.
+func (n *IdsecPCloudPlatformXMLNode) SetAttr(name string, value string) {
+	for i := range n.Attrs {
+		if n.Attrs[i].Name.Local == name {
+			n.Attrs[i].Value = value
+			return
+		}
+	}
+	n.Attrs = append(n.Attrs, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+}
+
+// Child returns the first direct child element with the given name.
+func (n *IdsecPCloudPlatformXMLNode) Child(name string) *IdsecPCloudPlatformXMLNode {
+	for _, child := range n.Children {
+		if child.Name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// Find returns the first descendant element, in document order, with the given name.
+func (n *IdsecPCloudPlatformXMLNode) Find(name string) *IdsecPCloudPlatformXMLNode {
+	for _, child := range n.Children {
+		if child.Name == name {
+			return child
+		}
+		if found := child.Find(name); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// IdsecPCloudPlatformPolicyXML represents a parsed platform XML file, such as the PVWA settings in Policy-<PlatformID>.xml.
+type IdsecPCloudPlatformPolicyXML struct {
+	Root   *IdsecPCloudPlatformXMLNode
+	header string
+}
+
+// ParsePolicyXML parses the contents of a platform XML file.
+func ParsePolicyXML(data []byte) (*IdsecPCloudPlatformPolicyXML, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(bytes.TrimPrefix(data, utf8BOM)))
+	decoder.Strict = false
+	policyXML := &IdsecPCloudPlatformPolicyXML{}
+	var stack []*IdsecPCloudPlatformXMLNode
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse platform xml - %w", err)
+		}
+		switch t := token.(type) {
+		case xml.ProcInst:
+			if t.Target == "xml" && len(stack) == 0 {
+				policyXML.header = fmt.Sprintf("<?xml %s?>", string(t.Inst))
+			}
+		case xml.StartElement:
+			node := &IdsecPCloudPlatformXMLNode{Name: t.Name.Local}
+			for _, attr := range t.Attr {
+				node.Attrs = append(node.Attrs, xml.Attr{Name: xml.Name{Local: attr.Name.Local}, Value: attr.Value})
+			}
+			if len(stack) == 0 {
+				if policyXML.Root != nil {
+					return nil, fmt.Errorf("failed to parse platform xml - multiple root elements")
+				}
+				policyXML.Root = node
+			} else {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, node)
+			}
+			stack = append(stack, node)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].Text += strings.TrimSpace(string(t))
+			}
+		case xml.Comment:
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, &IdsecPCloudPlatformXMLNode{Comment: string(t)})
+			}
+		}
+	}
+	if policyXML.Root == nil {
+		return nil, fmt.Errorf("failed to parse platform xml - no root element")
+	}
+	return policyXML, nil
+}
+
+// Bytes serializes the XML file with two-space indentation.
+func (x *IdsecPCloudPlatformPolicyXML) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	if x.header != "" {
+		buf.WriteString(x.header + "\n")
+	}
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encodeXMLNode(encoder, x.Root); err != nil {
+		return nil, err
+	}
+	if err := encoder.Flush(); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+	return buf.Bytes(), nil
+}
+
+func encodeXMLNode(encoder *xml.Encoder, node *IdsecPCloudPlatformXMLNode) error {
+	if node.Name == "" {
+		return encoder.EncodeToken(xml.Comment(node.Comment))
+	}
+	start := xml.StartElement{Name: xml.Name{Local: node.Name}, Attr: node.Attrs}
+	if err := encoder.EncodeToken(start); err != nil {
+		return err
+	}
+	if node.Text != "" {
+		if err := encoder.EncodeToken(xml.CharData(node.Text)); err != nil {
+			return err
+		}
+	}
+	for _, child := range node.Children {
+		if err := encodeXMLNode(encoder, child); err != nil {
+			return err
+		}
+	}
+	return encoder.EncodeToken(start.End())
+}
+
+// Policy returns the Policy element describing the platform, or nil when the file has none.
+func (x *IdsecPCloudPlatformPolicyXML) Policy() *IdsecPCloudPlatformXMLNode {
+	if x.Root.Name == "Policy" {
+		return x.Root
+	}
+	return x.Root.Find("Policy")
+}
+
+// PolicyID returns the ID attribute of the Policy element.
+func (x *IdsecPCloudPlatformPolicyXML) PolicyID() string {
+	policy := x.Policy()
+	if policy == nil {
+		return ""
+	}
+	id, _ := policy.Attr("ID")
+	return id
+}
+
+// SetPolicyID sets the ID attribute of the Policy element.
+func (x *IdsecPCloudPlatformPolicyXML) SetPolicyID(policyID string) error {
+	policy := x.Policy()
+	if policy == nil {
+		return fmt.Errorf("platform xml has no Policy element")
+	}
+	policy.SetAttr("ID", policyID)
+	return nil
+}
+
+// Properties returns the names of the Required or Optional account properties of the platform.
+func (x *IdsecPCloudPlatformPolicyXML) Properties(kind string) []string {
+	names := make([]string, 0)
+	group := x.propertiesGroup(kind, false)
+	if group == nil {
+		return names
+	}
+	for _, property := range group.Children {
+		if property.Name != "Property" {
+			continue
+		}
+		if name, ok := property.Attr("Name"); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// AddProperty adds an account property of the given kind, moving it if it is declared with the other kind.
+func (x *IdsecPCloudPlatformPolicyXML) AddProperty(kind string, name string) error {
+	if kind != PropertiesRequired && kind != PropertiesOptional {
+		return fmt.Errorf("invalid property kind [%s]", kind)
+	}
+	x.RemoveProperty(name)
+	group := x.propertiesGroup(kind, true)
+	if group == nil {
+		return fmt.Errorf("platform xml has no Policy element")
+	}
+	group.Children = append(group.Children, &IdsecPCloudPlatformXMLNode{
+		Name:  "Property",
+		Attrs: []xml.Attr{{Name: xml.Name{Local: "Name"}, Value: name}},
+	})
+	return nil
+}
+
+// RemoveProperty removes an account property from both the Required and Optional lists and reports whether it existed.
+func (x *IdsecPCloudPlatformPolicyXML) RemoveProperty(name string) bool {
+	removed := false
+	for _, kind := range []string{PropertiesRequired, PropertiesOptional} {
+		group := x.propertiesGroup(kind, false)
+		if group == nil {
+			continue
+		}
+		children := group.Children[:0]
+		for _, property := range group.Children {
+			if propertyName, _ := property.Attr("Name"); property.Name == "Property" && propertyName == name {
+				removed = true
+				continue
+			}
+			children = append(children, property)
+		}
+		group.Children = children
+	}
+	return removed
+}
+
+func (x *IdsecPCloudPlatformPolicyXML) propertiesGroup(kind string, create bool) *IdsecPCloudPlatformXMLNode {
+	policy := x.Policy()
+	if policy == nil {
+		return nil
+	}
+	properties := policy.Child("Properties")
+	if properties == nil {
+		if !create {
+			return nil
+		}
+		properties = &IdsecPCloudPlatformXMLNode{Name: "Properties"}
+		policy.Children = append(policy.Children, properties)
+	}
+	group := properties.Child(kind)
+	if group == nil && create {
+		group = &IdsecPCloudPlatformXMLNode{Name: kind}
+		properties.Children = append(properties.Children, group)
+	}
+	return group
+}
@@ -0,0 +1,19 @@
+package actions
+
+import (
+	requestsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/requests/models"
+)
+
+// ActionToSchemaMap maps action names to their corresponding schema structures.
+var ActionToSchemaMap = map[string]interface{}{
+	"create":                &requestsmodels.IdsecPCloudCreateRequest{},
+	"list-outgoing":         &requestsmodels.IdsecPCloudRequestsFilter{},
+	"get-outgoing":          &requestsmodels.IdsecPCloudGetRequest{},
+	"cancel":                &requestsmodels.IdsecPCloudCancelRequest{},
+	"list-incoming":         &requestsmodels.IdsecPCloudRequestsFilter{},
+	"get-incoming":          &requestsmodels.IdsecPCloudGetRequest{},
+	"confirm":               &requestsmodels.IdsecPCloudConfirmRequest{},
+	"reject":                &requestsmodels.IdsecPCloudRejectRequest{},
+	"wait-for-confirmation": &requestsmodels.IdsecPCloudWaitForRequest{},
+	"request-credentials":   &requestsmodels.IdsecPCloudRequestAccountCredentials{},
+}
@@ -0,0 +1,382 @@
+package requests
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/auth"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services"
+	"github.com/go-viper/mapstructure/v2"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts"
+	accountsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts/models"
+	commonpcloud "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/common"
+	requestsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/requests/models"
+)
+
+const (
+	myRequestsURL             = "/api/MyRequests"
+	myRequestURL              = "/api/MyRequests/%s/"
+	incomingRequestsURL       = "/api/IncomingRequests"
+	incomingRequestURL        = "/api/IncomingRequests/%s/"
+	confirmIncomingRequestURL = "/api/IncomingRequests/%s/Confirm"
+	rejectIncomingRequestURL  = "/api/IncomingRequests/%s/Reject"
+
+	defaultConfirmationPollInterval = 15 * time.Second
+	defaultConfirmationTimeout      = 3600 * time.Second
+)
+
+// ErrRequestConfirmationTimeout is returned when an access request is still waiting for confirmation after the timeout.
+var ErrRequestConfirmationTimeout = errors.New("request was not confirmed in time")
+
+// IdsecPCloudRequestsService is the service for managing pCloud access requests of safes that require dual control confirmation.
+type IdsecPCloudRequestsService struct {
+	*services.IdsecBaseService
+	*services.IdsecISPBaseService
+
+	accountsService *accounts.IdsecPCloudAccountsService
+	sleep           func(time.Duration)
+}
+
+// NewIdsecPCloudRequestsService creates a new instance of IdsecPCloudRequestsService.
+func NewIdsecPCloudRequestsService(authenticators ...auth.IdsecAuth) (*IdsecPCloudRequestsService, error) {
+	pcloudRequestsService := &IdsecPCloudRequestsService{}
+	var pcloudRequestsServiceInterface services.IdsecService = pcloudRequestsService
+	baseService, err := services.NewIdsecBaseService(pcloudRequestsServiceInterface, authenticators...)
+	if err != nil {
+		return nil, err
+	}
+	ispBaseAuth, err := baseService.Authenticator("isp")
+	if err != nil {
+		return nil, err
+	}
+	ispAuth := ispBaseAuth.(*auth.IdsecISPAuth)
+
+	ispBaseService, err := services.NewIdsecISPBaseServiceWithRetry(
+		ispAuth,
+		"privilegecloud",
+		".",
+		"passwordvault",
+		pcloudRequestsService.refreshPCloudRequestsAuth,
+		commonpcloud.DefaultPCloudRetryStrategy(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	accountsService, err := accounts.NewIdsecPCloudAccountsService(authenticators...)
+	if err != nil {
+		return nil, err
+	}
+
+	pcloudRequestsService.IdsecBaseService = baseService
+	pcloudRequestsService.IdsecISPBaseService = ispBaseService
+	pcloudRequestsService.accountsService = accountsService
+	pcloudRequestsService.sleep = time.Sleep
+	return pcloudRequestsService, nil
+}
+
+func (s *IdsecPCloudRequestsService) refreshPCloudRequestsAuth(client *common.IdsecClient) error {
+	err := isp.RefreshClient(client, s.ISPAuth())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// normalizeRequestMap maps API request JSON fields to IdsecPCloudRequest mapstructure keys.
+func normalizeRequestMap(requestMap map[string]interface{}) {
+	if requestID, ok := requestMap["request_i_d"]; ok {
+		requestMap["request_id"] = requestID
+	}
+	if accountDetails, ok := requestMap["account_details"].(map[string]interface{}); ok {
+		if accountID, ok := accountDetails["account_i_d"]; ok {
+			accountDetails["account_id"] = accountID
+		}
+	}
+}
+
+func decodeRequest(requestJSON interface{}) (*requestsmodels.IdsecPCloudRequest, error) {
+	requestJSONMap, ok := requestJSON.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid request response format")
+	}
+	normalizeRequestMap(requestJSONMap)
+	var request requestsmodels.IdsecPCloudRequest
+	err := mapstructure.Decode(requestJSONMap, &request)
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+func (s *IdsecPCloudRequestsService) getRequest(requestURL string, requestID string) (*requestsmodels.IdsecPCloudRequest, error) {
+	response, err := s.ISPClient().Get(context.Background(), fmt.Sprintf(requestURL, requestID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to retrieve request - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	}
+	requestJSON, err := common.DeserializeJSONSnake(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeRequest(requestJSON)
+}
+
+func (s *IdsecPCloudRequestsService) listRequests(requestsURL string, requestsKey string, filter *requestsmodels.IdsecPCloudRequestsFilter) ([]*requestsmodels.IdsecPCloudRequest, error) {
+	query := map[string]string{
+		"onlywaiting": strconv.FormatBool(filter.OnlyWaiting),
+		"expired":     strconv.FormatBool(filter.Expired),
+	}
+	response, err := s.ISPClient().Get(context.Background(), requestsURL, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list requests - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	}
+	requestsJSON, err := common.DeserializeJSONSnake(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	requestsJSONMap, ok := requestsJSON.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid requests response format")
+	}
+	requestsJSONArray, ok := requestsJSONMap[requestsKey].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid requests response format")
+	}
+	requests := make([]*requestsmodels.IdsecPCloudRequest, 0, len(requestsJSONArray))
+	for _, requestJSON := range requestsJSONArray {
+		request, err := decodeRequest(requestJSON)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+	}
+	return requests, nil
+}
+
+// Create creates an access request to an account of a safe that requires confirmation.
+// https://docs.cyberark.com/privilege-cloud-standard/latest/en/content/webservices/createrequest.htm
+func (s *IdsecPCloudRequestsService) Create(createRequest *requestsmodels.IdsecPCloudCreateRequest) (*requestsmodels.IdsecPCloudRequest, error) {
+	s.Logger.Info("Creating pCloud access request for account [%s]", createRequest.AccountID)
+	createRequestJSON := map[string]interface{}{
+		"AccountId":              createRequest.AccountID,
+		"Reason":                 createRequest.Reason,
+		"TicketingSystemName":    createRequest.TicketingSystemName,
+		"TicketId":               createRequest.TicketID,
+		"MultipleAccessRequired": createRequest.MultipleAccessRequired,
+		"UseConnect":             createRequest.UseConnect,
+	}
+	if createRequest.FromDate != 0 {
+		createRequestJSON["FromDate"] = createRequest.FromDate
+	}
+	if createRequest.ToDate != 0 {
+		createRequestJSON["ToDate"] = createRequest.ToDate
+	}
+	if createRequest.AdditionalInfo != "" {
+		createRequestJSON["AdditionalInfo"] = createRequest.AdditionalInfo
+	}
+	if createRequest.ConnectionComponent != "" {
+		createRequestJSON["ConnectionComponent"] = createRequest.ConnectionComponent
+	}
+	response, err := s.ISPClient().Post(context.Background(), myRequestsURL, createRequestJSON)
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("failed to create request - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	}
+	requestJSON, err := common.DeserializeJSONSnake(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeRequest(requestJSON)
+}
+
+// ListOutgoing lists the access requests created by the current user.
+// https://docs.cyberark.com/privilege-cloud-standard/latest/en/content/webservices/getmyrequests.htm
+func (s *IdsecPCloudRequestsService) ListOutgoing(filter *requestsmodels.IdsecPCloudRequestsFilter) ([]*requestsmodels.IdsecPCloudRequest, error) {
+	s.Logger.Info("Listing outgoing pCloud access requests")
+	return s.listRequests(myRequestsURL, "my_requests", filter)
+}
+
+// GetOutgoing retrieves an access request created by the current user.
+// https://docs.cyberark.com/privilege-cloud-standard/latest/en/content/webservices/getmyrequestdetails.htm
+func (s *IdsecPCloudRequestsService) GetOutgoing(getRequest *requestsmodels.IdsecPCloudGetRequest) (*requestsmodels.IdsecPCloudRequest, error) {
+	s.Logger.Info("Retrieving outgoing pCloud access request [%s]", getRequest.RequestID)
+	return s.getRequest(myRequestURL, getRequest.RequestID)
+}
+
+// Cancel cancels an access request created by the current user.
+// https://docs.cyberark.com/privilege-cloud-standard/latest/en/content/webservices/deletemyrequest.htm
+func (s *IdsecPCloudRequestsService) Cancel(cancelRequest *requestsmodels.IdsecPCloudCancelRequest) error {
+	s.Logger.Info("Cancelling pCloud access request [%s]", cancelRequest.RequestID)
+	response, err := s.ISPClient().Delete(context.Background(), fmt.Sprintf(myRequestURL, cancelRequest.RequestID), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to cancel request - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	}
+	return nil
+}
+
+// ListIncoming lists the access requests the current user can confirm or reject.
+// https://docs.cyberark.com/privilege-cloud-standard/latest/en/content/webservices/getincomingrequests.htm
+func (s *IdsecPCloudRequestsService) ListIncoming(filter *requestsmodels.IdsecPCloudRequestsFilter) ([]*requestsmodels.IdsecPCloudRequest, error) {
+	s.Logger.Info("Listing incoming pCloud access requests")
+	return s.listRequests(incomingRequestsURL, "incoming_requests", filter)
+}
+
+// GetIncoming retrieves an access request the current user can confirm or reject.
+// https://docs.cyberark.com/privilege-cloud-standard/latest/en/content/webservices/getincomingrequestdetails.htm
+func (s *IdsecPCloudRequestsService) GetIncoming(getRequest *requestsmodels.IdsecPCloudGetRequest) (*requestsmodels.IdsecPCloudRequest, error) {
+	s.Logger.Info("Retrieving incoming pCloud access request [%s]", getRequest.RequestID)
+	return s.getRequest(incomingRequestURL, getRequest.RequestID)
+}
+
+// Confirm confirms an incoming access request.
+// https://docs.cyberark.com/privilege-cloud-standard/latest/en/content/webservices/confirmrequest.htm
+func (s *IdsecPCloudRequestsService) Confirm(confirmRequest *requestsmodels.IdsecPCloudConfirmRequest) error {
+	s.Logger.Info("Confirming pCloud access request [%s]", confirmRequest.RequestID)
+	return s.answerIncoming(confirmIncomingRequestURL, confirmRequest.RequestID, confirmRequest.Reason, "confirm")
+}
+
+// Reject rejects an incoming access request.
+// https://docs.cyberark.com/privilege-cloud-standard/latest/en/content/webservices/rejectrequest.htm
+func (s *IdsecPCloudRequestsService) Reject(rejectRequest *requestsmodels.IdsecPCloudRejectRequest) error {
+	s.Logger.Info("Rejecting pCloud access request [%s]", rejectRequest.RequestID)
+	return s.answerIncoming(rejectIncomingRequestURL, rejectRequest.RequestID, rejectRequest.Reason, "reject")
+}
+
+func (s *IdsecPCloudRequestsService) answerIncoming(answerURL string, requestID string, reason string, answer string) error {
+	response, err := s.ISPClient().Post(context.Background(), fmt.Sprintf(answerURL, requestID), map[string]interface{}{"Reason": reason})
+	if err != nil {
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to %s request - [%d] - [%s]", answer, response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	}
+	return nil
+}
+
+// WaitForConfirmation polls an outgoing access request until it is confirmed, and returns it.
+// It fails as soon as the request is rejected, deleted, expired or invalid, or when the timeout elapses.
+// A non-positive poll interval or timeout falls back to 15 seconds and one hour respectively.
+func (s *IdsecPCloudRequestsService) WaitForConfirmation(waitForRequest *requestsmodels.IdsecPCloudWaitForRequest) (*requestsmodels.IdsecPCloudRequest, error) {
+	s.Logger.Info("Waiting for pCloud access request [%s] to be confirmed", waitForRequest.RequestID)
+	pollInterval := time.Duration(waitForRequest.PollInterval) * time.Second
+	if pollInterval <= 0 {
+		pollInterval = defaultConfirmationPollInterval
+	}
+	timeout := time.Duration(waitForRequest.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultConfirmationTimeout
+	}
+	for waited := time.Duration(0); ; waited += pollInterval {
+		request, err := s.GetOutgoing(&requestsmodels.IdsecPCloudGetRequest{RequestID: waitForRequest.RequestID})
+		if err != nil {
+			return nil, err
+		}
+		switch request.StatusTitle {
+		case requestsmodels.RequestStatusConfirmed:
+			return request, nil
+		case requestsmodels.RequestStatusRejected, requestsmodels.RequestStatusDeleted, requestsmodels.RequestStatusExpired, requestsmodels.RequestStatusInvalid:
+			reason := ""
+			if request.UserReason != "" {
+				reason = fmt.Sprintf(" - [%s]", request.UserReason)
+			}
+			return nil, fmt.Errorf("request [%s] is %s%s", request.RequestID, strings.ToLower(request.StatusTitle), reason)
+		}
+		if waited >= timeout {
+			return nil, fmt.Errorf("request [%s] after [%s]: %w", request.RequestID, timeout, ErrRequestConfirmationTimeout)
+		}
+		s.sleep(pollInterval)
+	}
+}
+
+// RequestCredentials requests access to an account of a safe that requires confirmation, waits until the
+// request is confirmed and retrieves the account credentials with the same reason and ticket.
+// A request that is not confirmed in time is cancelled.
+func (s *IdsecPCloudRequestsService) RequestCredentials(requestCredentials *requestsmodels.IdsecPCloudRequestAccountCredentials) (*accountsmodels.IdsecPCloudAccountCredentials, error) {
+	request, err := s.Create(&requestsmodels.IdsecPCloudCreateRequest{
+		AccountID:              requestCredentials.AccountID,
+		Reason:                 requestCredentials.Reason,
+		TicketingSystemName:    requestCredentials.TicketingSystemName,
+		TicketID:               requestCredentials.TicketID,
+		MultipleAccessRequired: requestCredentials.MultipleAccessRequired,
+		FromDate:               requestCredentials.FromDate,
+		ToDate:                 requestCredentials.ToDate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.WaitForConfirmation(&requestsmodels.IdsecPCloudWaitForRequest{
+		RequestID:    request.RequestID,
+		Timeout:      requestCredentials.Timeout,
+		PollInterval: requestCredentials.PollInterval,
+	})
+	if errors.Is(err, ErrRequestConfirmationTimeout) {
+		if cancelErr := s.Cancel(&requestsmodels.IdsecPCloudCancelRequest{RequestID: request.RequestID}); cancelErr != nil {
+			s.Logger.Warning("Failed to cancel pCloud access request [%s]: %v", request.RequestID, cancelErr)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.accountsService.GetCredentials(&accountsmodels.IdsecPCloudGetAccountCredentials{
+		AccountID:           requestCredentials.AccountID,
+		Reason:              requestCredentials.Reason,
+		TicketingSystemName: requestCredentials.TicketingSystemName,
+		TicketID:            requestCredentials.TicketID,
+		ActionType:          requestCredentials.ActionType,
+		Machine:             requestCredentials.Machine,
+	})
+}
+
+// ServiceConfig returns the service configuration for the IdsecPCloudRequestsService.
+func (s *IdsecPCloudRequestsService) ServiceConfig() services.IdsecServiceConfig {
+	return ServiceConfig
+}
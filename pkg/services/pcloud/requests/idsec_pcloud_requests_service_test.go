@@ -0,0 +1,216 @@
+package requests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts"
+	pcloudint "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/internal"
+	requestsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/requests/models"
+)
+
+func newTestPCloudRequestsService(parts *pcloudint.MockISPServiceParts) *IdsecPCloudRequestsService {
+	return &IdsecPCloudRequestsService{
+		IdsecBaseService:    parts.BaseService,
+		IdsecISPBaseService: parts.ISPBase,
+		accountsService: &accounts.IdsecPCloudAccountsService{
+			IdsecBaseService:    parts.BaseService,
+			IdsecISPBaseService: parts.ISPBase,
+		},
+		sleep: func(time.Duration) {},
+	}
+}
+
+func requestJSON(requestID string, statusTitle string) string {
+	return fmt.Sprintf(`{
+		"RequestID": %q,
+		"SafeName": "DualControl",
+		"RequestorUserName": "requestor@example.com",
+		"RequestorReason": "maintenance",
+		"UserReason": "not today",
+		"Operation": "Retrieve",
+		"AccessType": "OneTime",
+		"CreationDate": 1698947376,
+		"ExpirationDate": 1699033776,
+		"Status": 1,
+		"StatusTitle": %q,
+		"ConfirmationsLeft": 1,
+		"RequiredConfirmersCount": 1,
+		"AccountDetails": {"AccountID": "12_3", "Properties": {"Address": "host"}}
+	}`, requestID, statusTitle)
+}
+
+func TestListIncoming(t *testing.T) {
+	t.Parallel()
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/IncomingRequests" {
+			http.NotFound(w, r)
+			return
+		}
+		require.Equal(t, "true", r.URL.Query().Get("onlywaiting"))
+		require.Equal(t, "false", r.URL.Query().Get("expired"))
+		_, _ = fmt.Fprintf(w, `{"IncomingRequests": [%s]}`, requestJSON("req-1", requestsmodels.RequestStatusWaiting))
+	})
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, h)
+	t.Cleanup(cleanup)
+
+	svc := newTestPCloudRequestsService(parts)
+	requests, err := svc.ListIncoming(&requestsmodels.IdsecPCloudRequestsFilter{OnlyWaiting: true})
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	require.Equal(t, "req-1", requests[0].RequestID)
+	require.Equal(t, "DualControl", requests[0].SafeName)
+	require.Equal(t, requestsmodels.RequestStatusWaiting, requests[0].StatusTitle)
+	require.Equal(t, int64(1698947376), requests[0].CreationDate)
+	require.Equal(t, "12_3", requests[0].AccountDetails.AccountID)
+}
+
+func TestConfirmAndReject(t *testing.T) {
+	t.Parallel()
+	var mu sync.Mutex
+	answers := map[string]string{}
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.NotFound(w, r)
+			return
+		}
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		mu.Lock()
+		answers[r.URL.Path] = body["Reason"]
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	})
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, h)
+	t.Cleanup(cleanup)
+
+	svc := newTestPCloudRequestsService(parts)
+	require.NoError(t, svc.Confirm(&requestsmodels.IdsecPCloudConfirmRequest{RequestID: "req-1", Reason: "approved"}))
+	require.NoError(t, svc.Reject(&requestsmodels.IdsecPCloudRejectRequest{RequestID: "req-2", Reason: "denied"}))
+	require.Equal(t, map[string]string{
+		"/api/IncomingRequests/req-1/Confirm": "approved",
+		"/api/IncomingRequests/req-2/Reject":  "denied",
+	}, answers)
+}
+
+func TestRequestCredentials(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name              string
+		statuses          []string
+		timeout           int
+		expectedError     string
+		expectedPassword  string
+		expectedCancelled bool
+	}{
+		{
+			name:             "confirmed_after_waiting",
+			statuses:         []string{requestsmodels.RequestStatusWaiting, requestsmodels.RequestStatusConfirmed},
+			timeout:          60,
+			expectedPassword: "secret",
+		},
+		{
+			name:          "rejected",
+			statuses:      []string{requestsmodels.RequestStatusRejected},
+			timeout:       60,
+			expectedError: "request [req-1] is rejected - [not today]",
+		},
+		{
+			name:              "timeout_cancels_request",
+			statuses:          []string{requestsmodels.RequestStatusWaiting},
+			timeout:           30,
+			expectedError:     "was not confirmed in time",
+			expectedCancelled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var mu sync.Mutex
+			polls := 0
+			cancelled := false
+			var retrieveBody map[string]interface{}
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				switch {
+				case r.Method == http.MethodPost && r.URL.Path == "/api/MyRequests":
+					var body map[string]interface{}
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					require.Equal(t, "12_3", body["AccountId"])
+					require.Equal(t, "maintenance", body["Reason"])
+					require.Equal(t, "INC-1", body["TicketId"])
+					w.WriteHeader(http.StatusCreated)
+					_, _ = fmt.Fprint(w, requestJSON("req-1", requestsmodels.RequestStatusWaiting))
+				case r.Method == http.MethodGet && r.URL.Path == "/api/MyRequests/req-1/":
+					status := tt.statuses[min(polls, len(tt.statuses)-1)]
+					polls++
+					_, _ = fmt.Fprint(w, requestJSON("req-1", status))
+				case r.Method == http.MethodDelete && r.URL.Path == "/api/MyRequests/req-1/":
+					cancelled = true
+					w.WriteHeader(http.StatusOK)
+				case r.Method == http.MethodPost && r.URL.Path == "/api/accounts/12_3/password/retrieve":
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&retrieveBody))
+					_, _ = fmt.Fprint(w, `"secret"`)
+				default:
+					http.NotFound(w, r)
+				}
+			})
+			parts, cleanup := pcloudint.SetupMockISPServiceParts(t, h)
+			t.Cleanup(cleanup)
+
+			svc := newTestPCloudRequestsService(parts)
+			credentials, err := svc.RequestCredentials(&requestsmodels.IdsecPCloudRequestAccountCredentials{
+				AccountID:    "12_3",
+				Reason:       "maintenance",
+				TicketID:     "INC-1",
+				ActionType:   "show",
+				Timeout:      tt.timeout,
+				PollInterval: 0,
+			})
+			mu.Lock()
+			defer mu.Unlock()
+			require.Equal(t, tt.expectedCancelled, cancelled)
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectedError)
+				require.Nil(t, retrieveBody)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedPassword, credentials.Password)
+			require.Equal(t, "maintenance", retrieveBody["Reason"])
+			require.Equal(t, "INC-1", retrieveBody["Ticketid"])
+		})
+	}
+}
+
+func TestWaitForConfirmation_defaults(t *testing.T) {
+	t.Parallel()
+	polls := 0
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/MyRequests/req-1/", r.URL.Path)
+		polls++
+		_, _ = fmt.Fprint(w, requestJSON("req-1", requestsmodels.RequestStatusWaiting))
+	})
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, h)
+	t.Cleanup(cleanup)
+
+	svc := newTestPCloudRequestsService(parts)
+	var slept []time.Duration
+	svc.sleep = func(d time.Duration) { slept = append(slept, d) }
+	_, err := svc.WaitForConfirmation(&requestsmodels.IdsecPCloudWaitForRequest{RequestID: "req-1"})
+	require.ErrorIs(t, err, ErrRequestConfirmationTimeout)
+	require.Contains(t, err.Error(), "after [1h0m0s]")
+	require.Len(t, slept, 240, "an hour of polls every 15 seconds")
+	for _, d := range slept {
+		require.Equal(t, 15*time.Second, d)
+	}
+	require.Equal(t, 241, polls)
+}
@@ -0,0 +1,6 @@
+package models
+
+// IdsecPCloudCancelRequest represents the details required to cancel an outgoing pCloud access request.
+type IdsecPCloudCancelRequest struct {
+	RequestID string `json:"request_id" mapstructure:"request_id" flag:"request-id" desc:"The ID of the request to cancel" validate:"required"`
+}
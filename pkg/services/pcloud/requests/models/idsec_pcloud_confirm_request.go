@@ -0,0 +1,7 @@
+package models
+
+// IdsecPCloudConfirmRequest represents the details required to confirm an incoming pCloud access request.
+type IdsecPCloudConfirmRequest struct {
+	RequestID string `json:"request_id" mapstructure:"request_id" flag:"request-id" desc:"The ID of the request to confirm" validate:"required"`
+	Reason    string `json:"reason,omitempty" mapstructure:"reason,omitempty" flag:"reason" desc:"The reason for confirming the request"`
+}
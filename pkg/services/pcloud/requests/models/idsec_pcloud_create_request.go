@@ -0,0 +1,15 @@
+package models
+
+// IdsecPCloudCreateRequest represents the details required to request access to an account of a safe that requires confirmation.
+type IdsecPCloudCreateRequest struct {
+	AccountID              string `json:"account_id" mapstructure:"account_id" flag:"account-id" desc:"The ID of the account to request access to" validate:"required"`
+	Reason                 string `json:"reason,omitempty" mapstructure:"reason,omitempty" flag:"reason" desc:"The reason for the request"`
+	TicketingSystemName    string `json:"ticketing_system_name,omitempty" mapstructure:"ticketing_system_name,omitempty" flag:"ticketing-system-name" desc:"The ticketing system the ticket belongs to"`
+	TicketID               string `json:"ticket_id,omitempty" mapstructure:"ticket_id,omitempty" flag:"ticket-id" desc:"The ticket ID justifying the request"`
+	MultipleAccessRequired bool   `json:"multiple_access_required" mapstructure:"multiple_access_required" flag:"multiple-access-required" desc:"Whether the account is accessed multiple times during the request period" default:"false"`
+	FromDate               int64  `json:"from_date,omitempty" mapstructure:"from_date,omitempty" flag:"from-date" desc:"The start of the requested access period, in Unix seconds"`
+	ToDate                 int64  `json:"to_date,omitempty" mapstructure:"to_date,omitempty" flag:"to-date" desc:"The end of the requested access period, in Unix seconds"`
+	AdditionalInfo         string `json:"additional_info,omitempty" mapstructure:"additional_info,omitempty" flag:"additional-info" desc:"Additional information for the confirmers"`
+	UseConnect             bool   `json:"use_connect" mapstructure:"use_connect" flag:"use-connect" desc:"Whether the request is for connecting through a connection component" default:"false"`
+	ConnectionComponent    string `json:"connection_component,omitempty" mapstructure:"connection_component,omitempty" flag:"connection-component" desc:"The connection component to connect with, when use-connect is set"`
+}
@@ -0,0 +1,6 @@
+package models
+
+// IdsecPCloudGetRequest represents the details required to retrieve a pCloud access request.
+type IdsecPCloudGetRequest struct {
+	RequestID string `json:"request_id" mapstructure:"request_id" flag:"request-id" desc:"The ID of the request" validate:"required"`
+}
@@ -0,0 +1,7 @@
+package models
+
+// IdsecPCloudRejectRequest represents the details required to reject an incoming pCloud access request.
+type IdsecPCloudRejectRequest struct {
+	RequestID string `json:"request_id" mapstructure:"request_id" flag:"request-id" desc:"The ID of the request to reject" validate:"required"`
+	Reason    string `json:"reason,omitempty" mapstructure:"reason,omitempty" flag:"reason" desc:"The reason for rejecting the request"`
+}
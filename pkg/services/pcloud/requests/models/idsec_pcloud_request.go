@@ -0,0 +1,40 @@
+package models
+
+// Possible status titles of a pCloud access request.
+const (
+	RequestStatusWaiting   = "Waiting"
+	RequestStatusConfirmed = "Confirmed"
+	RequestStatusRejected  = "Rejected"
+	RequestStatusDeleted   = "Deleted"
+	RequestStatusExpired   = "Expired"
+	RequestStatusInvalid   = "Invalid"
+)
+
+// IdsecPCloudRequestAccountDetails represents the account an access request was made for.
+type IdsecPCloudRequestAccountDetails struct {
+	AccountID  string                 `json:"account_id" mapstructure:"account_id" desc:"The ID of the requested account"`
+	Properties map[string]interface{} `json:"properties,omitempty" mapstructure:"properties,omitempty" desc:"The properties of the requested account"`
+}
+
+// IdsecPCloudRequest represents an access request to an account of a safe that requires dual control confirmation.
+type IdsecPCloudRequest struct {
+	RequestID                string                           `json:"request_id" mapstructure:"request_id" desc:"The ID of the request"`
+	SafeName                 string                           `json:"safe_name" mapstructure:"safe_name" desc:"The safe of the requested account"`
+	RequestorUserName        string                           `json:"requestor_user_name" mapstructure:"requestor_user_name" desc:"The user who created the request"`
+	RequestorReason          string                           `json:"requestor_reason" mapstructure:"requestor_reason" desc:"The reason given by the requestor"`
+	UserReason               string                           `json:"user_reason,omitempty" mapstructure:"user_reason,omitempty" desc:"The reason given by the confirmer when confirming or rejecting the request"`
+	Operation                string                           `json:"operation" mapstructure:"operation" desc:"The operation the request was made for"`
+	AccessType               string                           `json:"access_type" mapstructure:"access_type" desc:"Whether the request is for a single or multiple accesses"`
+	CreationDate             int64                            `json:"creation_date" mapstructure:"creation_date" desc:"The creation time of the request, in Unix seconds"`
+	ExpirationDate           int64                            `json:"expiration_date" mapstructure:"expiration_date" desc:"The expiration time of the request, in Unix seconds"`
+	AccessFrom               int64                            `json:"access_from,omitempty" mapstructure:"access_from,omitempty" desc:"The start of the requested access period, in Unix seconds"`
+	AccessTo                 int64                            `json:"access_to,omitempty" mapstructure:"access_to,omitempty" desc:"The end of the requested access period, in Unix seconds"`
+	Status                   int                              `json:"status" mapstructure:"status" desc:"The status code of the request"`
+	StatusTitle              string                           `json:"status_title" mapstructure:"status_title" desc:"The status of the request (Waiting, Confirmed, Rejected, Deleted, Expired, Invalid)"`
+	ConfirmationsLeft        int                              `json:"confirmations_left" mapstructure:"confirmations_left" desc:"The number of confirmations still needed"`
+	CurrentConfirmationLevel int                              `json:"current_confirmation_level" mapstructure:"current_confirmation_level" desc:"The confirmation level the request is waiting for"`
+	RequiredConfirmersCount  int                              `json:"required_confirmers_count" mapstructure:"required_confirmers_count" desc:"The number of confirmers required for the request"`
+	InvalidRequestReason     int                              `json:"invalid_request_reason,omitempty" mapstructure:"invalid_request_reason,omitempty" desc:"The reason code when the request is invalid"`
+	AdditionalInfo           string                           `json:"additional_info,omitempty" mapstructure:"additional_info,omitempty" desc:"Additional information about the request"`
+	AccountDetails           IdsecPCloudRequestAccountDetails `json:"account_details" mapstructure:"account_details" desc:"The requested account"`
+}
@@ -0,0 +1,17 @@
+package models
+
+// IdsecPCloudRequestAccountCredentials represents the details required to request access to an account,
+// wait for the request to be confirmed and retrieve the account credentials.
+type IdsecPCloudRequestAccountCredentials struct {
+	AccountID              string `json:"account_id" mapstructure:"account_id" flag:"account-id" desc:"The ID of the account to retrieve the credentials of" validate:"required"`
+	Reason                 string `json:"reason,omitempty" mapstructure:"reason,omitempty" flag:"reason" desc:"The reason for the request and the retrieval"`
+	TicketingSystemName    string `json:"ticketing_system_name,omitempty" mapstructure:"ticketing_system_name,omitempty" flag:"ticketing-system-name" desc:"The ticketing system the ticket belongs to"`
+	TicketID               string `json:"ticket_id,omitempty" mapstructure:"ticket_id,omitempty" flag:"ticket-id" desc:"The ticket ID justifying the request"`
+	MultipleAccessRequired bool   `json:"multiple_access_required" mapstructure:"multiple_access_required" flag:"multiple-access-required" desc:"Whether the account is accessed multiple times during the request period" default:"false"`
+	FromDate               int64  `json:"from_date,omitempty" mapstructure:"from_date,omitempty" flag:"from-date" desc:"The start of the requested access period, in Unix seconds"`
+	ToDate                 int64  `json:"to_date,omitempty" mapstructure:"to_date,omitempty" flag:"to-date" desc:"The end of the requested access period, in Unix seconds"`
+	ActionType             string `json:"action_type" mapstructure:"action_type" flag:"action-type" desc:"The action the secret will be used for (show,copy,connect)" default:"show" choices:"show,copy,connect"`
+	Machine                string `json:"machine,omitempty" mapstructure:"machine,omitempty" flag:"machine" desc:"The address of the remote machine to which the account will connect"`
+	Timeout                int    `json:"timeout" mapstructure:"timeout" flag:"timeout" desc:"The number of seconds to wait for the request to be confirmed. The request is cancelled when it is not confirmed in time" default:"3600"`
+	PollInterval           int    `json:"poll_interval" mapstructure:"poll_interval" flag:"poll-interval" desc:"The number of seconds to wait between status checks" default:"15"`
+}
@@ -0,0 +1,7 @@
+package models
+
+// IdsecPCloudRequestsFilter represents the filter for listing incoming or outgoing pCloud access requests.
+type IdsecPCloudRequestsFilter struct {
+	OnlyWaiting bool `json:"only_waiting" mapstructure:"only_waiting" flag:"only-waiting" desc:"Whether to list only requests that are waiting for confirmation" default:"false"`
+	Expired     bool `json:"expired" mapstructure:"expired" flag:"expired" desc:"Whether to include expired requests" default:"false"`
+}
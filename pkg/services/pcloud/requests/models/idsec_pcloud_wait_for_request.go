@@ -0,0 +1,8 @@
+package models
+
+// IdsecPCloudWaitForRequest represents the details required to wait until an outgoing pCloud access request is confirmed.
+type IdsecPCloudWaitForRequest struct {
+	RequestID    string `json:"request_id" mapstructure:"request_id" flag:"request-id" desc:"The ID of the request to wait for" validate:"required"`
+	Timeout      int    `json:"timeout" mapstructure:"timeout" flag:"timeout" desc:"The number of seconds to wait for the request to be confirmed" default:"3600"`
+	PollInterval int    `json:"poll_interval" mapstructure:"poll_interval" flag:"poll-interval" desc:"The number of seconds to wait between status checks" default:"15"`
+}
@@ -30,6 +30,9 @@ const (
 	safeMemberURL  = "/api/safes/%s/members/%s/"
 )
 
+// ErrSafeNotFound is returned, wrapped, by Get when the requested safe does not exist.
+var ErrSafeNotFound = errors.New("safe not found")
+
 // SafeMembersPermissionsSets maps permission sets to their corresponding permissions
 var SafeMembersPermissionsSets = map[string]safesmodels.IdsecPCloudSafeMemberPermissions{
 	safesmodels.ConnectOnly: {
@@ -558,6 +561,9 @@ func (s *IdsecPCloudSafesService) Get(getSafe *safesmodels.IdsecPCloudGetSafe) (
 			return nil, err
 		}
 		for safesPage := range safesPages {
+			if safesPage.Err != nil {
+				return nil, safesPage.Err
+			}
 			for _, safe := range safesPage.Items {
 				if safe.SafeName == getSafe.SafeName {
 					getSafe.SafeID = safe.SafeID
@@ -566,7 +572,7 @@ func (s *IdsecPCloudSafesService) Get(getSafe *safesmodels.IdsecPCloudGetSafe) (
 			}
 		}
 		if getSafe.SafeID == "" {
-			return nil, fmt.Errorf("safe with name '%s' not found", getSafe.SafeName)
+			return nil, fmt.Errorf("%w: no safe is named '%s'", ErrSafeNotFound, getSafe.SafeName)
 		}
 	}
 	response, err := s.ISPClient().Get(context.Background(), fmt.Sprintf(safeURL, getSafe.SafeID), nil)
@@ -579,6 +585,9 @@ func (s *IdsecPCloudSafesService) Get(getSafe *safesmodels.IdsecPCloudGetSafe) (
 			common.GlobalLogger.Warning("Error closing response body")
 		}
 	}(response.Body)
+	if response.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w - [%d] - [%s]", ErrSafeNotFound, response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	}
 	if response.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("failed to retrieve safe - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
 	}
@@ -1,9 +1,14 @@
 package safes
 
 import (
+	"errors"
 	"io"
+	"net/http"
 	"strings"
 	"testing"
+
+	pcloudinternal "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/internal"
+	safesmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/safes/models"
 )
 
 func TestParseSafeResponse(t *testing.T) {
@@ -63,3 +68,34 @@ func TestSafesListPageFromResultMapInvalidShapes(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSafeByName_notFoundOnlyWhenListingSucceeds(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		listStatus     int
+		expectNotFound bool
+	}{
+		{name: "no_matching_safe", listStatus: http.StatusOK, expectNotFound: true},
+		{name: "listing_fails", listStatus: http.StatusForbidden, expectNotFound: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			parts, cleanup := pcloudinternal.SetupMockISPServiceParts(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.listStatus)
+				_, _ = w.Write([]byte(`{"value":[{"safe_url_id":"other","safe_name":"Other"}]}`))
+			}))
+			t.Cleanup(cleanup)
+			service := &IdsecPCloudSafesService{IdsecBaseService: parts.BaseService, IdsecISPBaseService: parts.ISPBase}
+
+			_, err := service.Get(&safesmodels.IdsecPCloudGetSafe{SafeName: "Missing"})
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if errors.Is(err, ErrSafeNotFound) != tt.expectNotFound {
+				t.Errorf("expected not found [%t], got error: %v", tt.expectNotFound, err)
+			}
+		})
+	}
+}
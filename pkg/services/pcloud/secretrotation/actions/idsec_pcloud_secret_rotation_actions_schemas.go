@@ -0,0 +1,8 @@
+package actions
+
+import secretrotationmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/secretrotation/models"
+
+// ActionToSchemaMap maps action names to their corresponding schema structures.
+var ActionToSchemaMap = map[string]interface{}{
+	"rotate": &secretrotationmodels.IdsecPCloudRotateSecrets{},
+}
@@ -0,0 +1,404 @@
+package secretrotation
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/auth"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts"
+	accountsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts/models"
+	commonpcloud "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/common"
+	secretrotationmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/secretrotation/models"
+)
+
+const (
+	defaultRotationConcurrency  = 5
+	defaultRotationPollInterval = 10 * time.Second
+	defaultRotationTimeout      = 600 * time.Second
+
+	cpmSecretStatusFailure = "failure"
+)
+
+// cpmOperation is a CPM operation triggered on an account, along with the activities CPM
+// records on the account once the operation completes and the secret management time it
+// updates when it succeeds.
+type cpmOperation struct {
+	name          string
+	successAction string
+	failureAction string
+	completedAt   func(account *accountsmodels.IdsecPCloudAccount) int
+	trigger       func(accountsService *accounts.IdsecPCloudAccountsService, accountID string) error
+}
+
+var (
+	cpmChange = cpmOperation{
+		name:          "change",
+		successAction: "CPM Change Password",
+		failureAction: "CPM Change Password Failed",
+		completedAt:   func(account *accountsmodels.IdsecPCloudAccount) int { return account.LastModifiedTime },
+		trigger: func(accountsService *accounts.IdsecPCloudAccountsService, accountID string) error {
+			return accountsService.ChangeCredentials(&accountsmodels.IdsecPCloudChangeAccountCredentials{AccountID: accountID})
+		},
+	}
+	cpmVerify = cpmOperation{
+		name:          "verify",
+		successAction: "CPM Verify Password",
+		failureAction: "CPM Verify Password Failed",
+		completedAt:   func(account *accountsmodels.IdsecPCloudAccount) int { return account.LastVerifiedTime },
+		trigger: func(accountsService *accounts.IdsecPCloudAccountsService, accountID string) error {
+			return accountsService.VerifyCredentials(&accountsmodels.IdsecPCloudVerifyAccountCredentials{AccountID: accountID})
+		},
+	}
+	cpmReconcile = cpmOperation{
+		name:          "reconcile",
+		successAction: "CPM Reconcile Password",
+		failureAction: "CPM Reconcile Password Failed",
+		completedAt:   func(account *accountsmodels.IdsecPCloudAccount) int { return account.LastReconciledTime },
+		trigger: func(accountsService *accounts.IdsecPCloudAccountsService, accountID string) error {
+			return accountsService.ReconcileCredentials(&accountsmodels.IdsecPCloudReconcileAccountCredentials{AccountID: accountID})
+		},
+	}
+)
+
+// IdsecPCloudSecretRotationService is the service for orchestrating CPM secret rotations of pCloud accounts.
+type IdsecPCloudSecretRotationService struct {
+	*services.IdsecBaseService
+	*services.IdsecISPBaseService
+
+	accountsService *accounts.IdsecPCloudAccountsService
+	sleep           func(time.Duration)
+}
+
+// NewIdsecPCloudSecretRotationService creates a new instance of IdsecPCloudSecretRotationService.
+func NewIdsecPCloudSecretRotationService(authenticators ...auth.IdsecAuth) (*IdsecPCloudSecretRotationService, error) {
+	pcloudSecretRotationService := &IdsecPCloudSecretRotationService{}
+	var pcloudSecretRotationServiceInterface services.IdsecService = pcloudSecretRotationService
+	baseService, err := services.NewIdsecBaseService(pcloudSecretRotationServiceInterface, authenticators...)
+	if err != nil {
+		return nil, err
+	}
+	ispBaseAuth, err := baseService.Authenticator("isp")
+	if err != nil {
+		return nil, err
+	}
+	ispAuth := ispBaseAuth.(*auth.IdsecISPAuth)
+
+	ispBaseService, err := services.NewIdsecISPBaseServiceWithRetry(
+		ispAuth,
+		"privilegecloud",
+		".",
+		"passwordvault",
+		pcloudSecretRotationService.refreshPCloudSecretRotationAuth,
+		commonpcloud.DefaultPCloudRetryStrategy(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	accountsService, err := accounts.NewIdsecPCloudAccountsService(authenticators...)
+	if err != nil {
+		return nil, err
+	}
+
+	pcloudSecretRotationService.IdsecBaseService = baseService
+	pcloudSecretRotationService.IdsecISPBaseService = ispBaseService
+	pcloudSecretRotationService.accountsService = accountsService
+	pcloudSecretRotationService.sleep = time.Sleep
+	return pcloudSecretRotationService, nil
+}
+
+func (s *IdsecPCloudSecretRotationService) refreshPCloudSecretRotationAuth(client *common.IdsecClient) error {
+	err := isp.RefreshClient(client, s.ISPAuth())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Rotate has CPM change the secret of a set of accounts and reports the outcome of every account.
+//
+// Change, verify and reconcile requests are only queued by the vault, so every operation is
+// followed by polling the account and its activities until CPM records its success or failure. A failed
+// change is retried, then optionally reconciled. At most Concurrency accounts are handled at once
+// so CPM is not flooded. Accounts whose automatic management is disabled are skipped, as CPM
+// would never process them.
+//
+// Example:
+//
+//	report, err := secretRotationService.Rotate(&secretrotationmodels.IdsecPCloudRotateSecrets{
+//		SafeName:           "Finance",
+//		Verify:             true,
+//		RetryCount:         1,
+//		ReconcileOnFailure: true,
+//	})
+//	if err != nil { /* handle */ }
+//	for _, account := range report.Accounts { fmt.Println(account.Name, account.Status, account.Message) }
+func (s *IdsecPCloudSecretRotationService) Rotate(rotateSecrets *secretrotationmodels.IdsecPCloudRotateSecrets) (*secretrotationmodels.IdsecPCloudSecretRotationReport, error) {
+	if len(rotateSecrets.AccountIDs) == 0 && rotateSecrets.Search == "" && rotateSecrets.SafeName == "" {
+		return nil, fmt.Errorf("at least one of account ids, search or safe name is required")
+	}
+	startedAt := time.Now().UTC()
+	rotationAccounts, err := s.listRotationAccounts(rotateSecrets)
+	if err != nil {
+		return nil, err
+	}
+	concurrency := rotateSecrets.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultRotationConcurrency
+	}
+	pollInterval := time.Duration(rotateSecrets.PollIntervalSeconds) * time.Second
+	if pollInterval <= 0 {
+		pollInterval = defaultRotationPollInterval
+	}
+	timeout := time.Duration(rotateSecrets.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultRotationTimeout
+	}
+	s.Logger.Info("Rotating the secrets of [%d] accounts with concurrency [%d]", len(rotationAccounts), concurrency)
+
+	report := &secretrotationmodels.IdsecPCloudSecretRotationReport{
+		StartedAt: startedAt.Format(time.RFC3339),
+		Accounts:  make([]secretrotationmodels.IdsecPCloudSecretRotationAccountReport, len(rotationAccounts)),
+	}
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i, account := range rotationAccounts {
+		wg.Add(1)
+		go func(accountReport *secretrotationmodels.IdsecPCloudSecretRotationAccountReport, account *accountsmodels.IdsecPCloudAccount) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			*accountReport = s.rotateAccount(rotateSecrets, account, pollInterval, timeout)
+		}(&report.Accounts[i], account)
+	}
+	wg.Wait()
+
+	for _, accountReport := range report.Accounts {
+		switch accountReport.Status {
+		case secretrotationmodels.RotationAccountStatusRotated:
+			report.Rotated++
+		case secretrotationmodels.RotationAccountStatusReconciled:
+			report.Reconciled++
+		case secretrotationmodels.RotationAccountStatusSkipped:
+			report.Skipped++
+		default:
+			report.Failed++
+		}
+	}
+	report.CompletedAt = time.Now().UTC().Format(time.RFC3339)
+	s.Logger.Info("Secret rotation completed, [%d] rotated, [%d] reconciled, [%d] failed and [%d] skipped", report.Rotated, report.Reconciled, report.Failed, report.Skipped)
+	return report, nil
+}
+
+// listRotationAccounts returns the accounts given by ID and those matching the filter, without duplicates.
+func (s *IdsecPCloudSecretRotationService) listRotationAccounts(rotateSecrets *secretrotationmodels.IdsecPCloudRotateSecrets) ([]*accountsmodels.IdsecPCloudAccount, error) {
+	var rotationAccounts []*accountsmodels.IdsecPCloudAccount
+	known := map[string]bool{}
+	for _, accountID := range rotateSecrets.AccountIDs {
+		if known[accountID] {
+			continue
+		}
+		account, err := s.accountsService.Get(&accountsmodels.IdsecPCloudGetAccount{AccountID: accountID})
+		if err != nil {
+			return nil, err
+		}
+		known[account.AccountID] = true
+		rotationAccounts = append(rotationAccounts, account)
+	}
+	if rotateSecrets.Search == "" && rotateSecrets.SafeName == "" {
+		return rotationAccounts, nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	accountsPages, err := s.accountsService.ListByContext(ctx, &accountsmodels.IdsecPCloudAccountsFilter{
+		Search:   rotateSecrets.Search,
+		SafeName: rotateSecrets.SafeName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for accountsPage := range accountsPages {
+		if accountsPage.Err != nil {
+			return nil, accountsPage.Err
+		}
+		for _, account := range accountsPage.Items {
+			if known[account.AccountID] {
+				continue
+			}
+			known[account.AccountID] = true
+			rotationAccounts = append(rotationAccounts, account)
+		}
+	}
+	return rotationAccounts, nil
+}
+
+func (s *IdsecPCloudSecretRotationService) rotateAccount(rotateSecrets *secretrotationmodels.IdsecPCloudRotateSecrets, account *accountsmodels.IdsecPCloudAccount, pollInterval time.Duration, timeout time.Duration) secretrotationmodels.IdsecPCloudSecretRotationAccountReport {
+	accountReport := secretrotationmodels.IdsecPCloudSecretRotationAccountReport{
+		AccountID: account.AccountID,
+		Name:      account.Name,
+		SafeName:  account.SafeName,
+	}
+	if account.AutomaticManagementEnabled != nil && !*account.AutomaticManagementEnabled {
+		accountReport.Status = secretrotationmodels.RotationAccountStatusSkipped
+		accountReport.Message = "automatic management is disabled"
+		if account.ManualManagementReason != "" {
+			accountReport.Message += ": " + account.ManualManagementReason
+		}
+		return accountReport
+	}
+
+	var err error
+	for attempt := 0; attempt <= rotateSecrets.RetryCount; attempt++ {
+		accountReport.Attempts++
+		if err = s.runCPMOperation(account.AccountID, cpmChange, pollInterval, timeout); err == nil {
+			break
+		}
+		s.Logger.Warning("Secret change of account [%s] failed on attempt [%d]: %v", account.AccountID, accountReport.Attempts, err)
+	}
+	if err == nil {
+		accountReport.Message = "secret changed"
+		if rotateSecrets.Verify {
+			if err = s.runCPMOperation(account.AccountID, cpmVerify, pollInterval, timeout); err == nil {
+				accountReport.Verified = true
+				accountReport.Message = "secret changed and verified"
+			}
+		}
+	}
+	if err == nil {
+		accountReport.Status = secretrotationmodels.RotationAccountStatusRotated
+		return accountReport
+	}
+	if !rotateSecrets.ReconcileOnFailure {
+		accountReport.Status = secretrotationmodels.RotationAccountStatusFailed
+		accountReport.Message = err.Error()
+		return accountReport
+	}
+	s.Logger.Info("Reconciling the secret of account [%s]", account.AccountID)
+	if reconcileErr := s.runCPMOperation(account.AccountID, cpmReconcile, pollInterval, timeout); reconcileErr != nil {
+		accountReport.Status = secretrotationmodels.RotationAccountStatusFailed
+		accountReport.Message = fmt.Sprintf("%v, then %v", err, reconcileErr)
+		return accountReport
+	}
+	accountReport.Status = secretrotationmodels.RotationAccountStatusReconciled
+	accountReport.Reconciled = true
+	accountReport.Message = fmt.Sprintf("%v, secret reconciled", err)
+	return accountReport
+}
+
+// runCPMOperation triggers the operation on the account and waits for CPM to record its result.
+//
+// Every poll reads the secret management of the account, whose time of the operation moves
+// forward once CPM completes it successfully, and the account activities, which also record
+// failures and their reason. Activity dates have a one-second resolution, so activities are
+// told apart from those listed before the trigger by their content rather than by their date.
+// Only vault times are compared, the local clock is never relied on.
+func (s *IdsecPCloudSecretRotationService) runCPMOperation(accountID string, operation cpmOperation, pollInterval time.Duration, timeout time.Duration) error {
+	account, err := s.accountsService.Get(&accountsmodels.IdsecPCloudGetAccount{AccountID: accountID})
+	if err != nil {
+		return err
+	}
+	completedBefore := operation.completedAt(account)
+	activities, err := s.accountsService.ListActivities(&accountsmodels.IdsecPCloudListAccountActivities{AccountID: accountID})
+	if err != nil {
+		return err
+	}
+	previousActivities := newActivitiesSnapshot(activities)
+	if err := operation.trigger(s.accountsService, accountID); err != nil {
+		return err
+	}
+	for waited := time.Duration(0); waited < timeout; waited += pollInterval {
+		s.sleep(pollInterval)
+		activities, err := s.accountsService.ListActivities(&accountsmodels.IdsecPCloudListAccountActivities{AccountID: accountID})
+		if err != nil {
+			return err
+		}
+		result := cpmResult(activities, operation, previousActivities)
+		if result != nil {
+			if strings.EqualFold(result.Action, operation.failureAction) {
+				if result.MoreInfo != "" {
+					return fmt.Errorf("CPM %s failed: %s", operation.name, result.MoreInfo)
+				}
+				return fmt.Errorf("CPM %s failed", operation.name)
+			}
+			return nil
+		}
+		account, err := s.accountsService.Get(&accountsmodels.IdsecPCloudGetAccount{AccountID: accountID})
+		if err != nil {
+			return err
+		}
+		if operation.completedAt(account) > completedBefore && !strings.EqualFold(account.SecretStatus, cpmSecretStatusFailure) {
+			return nil
+		}
+	}
+	return fmt.Errorf("timed out after %s waiting for CPM to %s the secret", timeout, operation.name)
+}
+
+// activitiesSnapshot holds the activities of an account listed before an operation was triggered.
+type activitiesSnapshot struct {
+	latest int
+	seen   map[string]int
+}
+
+func activityKey(activity *accountsmodels.IdsecPCloudAccountActivity) string {
+	return fmt.Sprintf("%d|%d|%s|%s|%s|%s|%s", activity.Date, activity.ActionID, activity.Action, activity.User, activity.ClientID, activity.MoreInfo, activity.Reason)
+}
+
+func newActivitiesSnapshot(activities []*accountsmodels.IdsecPCloudAccountActivity) *activitiesSnapshot {
+	snapshot := &activitiesSnapshot{seen: make(map[string]int, len(activities))}
+	for _, activity := range activities {
+		if activity.Date > snapshot.latest {
+			snapshot.latest = activity.Date
+		}
+		snapshot.seen[activityKey(activity)]++
+	}
+	return snapshot
+}
+
+// newActivities returns the activities missing from the snapshot. An activity is new when it is not
+// older than the latest one of the snapshot and is listed more times than in the snapshot, so an
+// activity recorded in the same second as an earlier one is still found.
+func (a *activitiesSnapshot) newActivities(activities []*accountsmodels.IdsecPCloudAccountActivity) []*accountsmodels.IdsecPCloudAccountActivity {
+	seen := make(map[string]int, len(a.seen))
+	for key, count := range a.seen {
+		seen[key] = count
+	}
+	added := make([]*accountsmodels.IdsecPCloudAccountActivity, 0)
+	for _, activity := range activities {
+		if activity.Date < a.latest {
+			continue
+		}
+		key := activityKey(activity)
+		if seen[key] > 0 {
+			seen[key]--
+			continue
+		}
+		added = append(added, activity)
+	}
+	return added
+}
+
+// cpmResult returns the earliest success or failure activity of the operation recorded since the snapshot.
+func cpmResult(activities []*accountsmodels.IdsecPCloudAccountActivity, operation cpmOperation, previousActivities *activitiesSnapshot) *accountsmodels.IdsecPCloudAccountActivity {
+	var result *accountsmodels.IdsecPCloudAccountActivity
+	for _, activity := range previousActivities.newActivities(activities) {
+		if !strings.EqualFold(activity.Action, operation.successAction) && !strings.EqualFold(activity.Action, operation.failureAction) {
+			continue
+		}
+		if result == nil || activity.Date < result.Date {
+			result = activity
+		}
+	}
+	return result
+}
+
+// ServiceConfig returns the service configuration for the IdsecPCloudSecretRotationService.
+func (s *IdsecPCloudSecretRotationService) ServiceConfig() services.IdsecServiceConfig {
+	return ServiceConfig
+}
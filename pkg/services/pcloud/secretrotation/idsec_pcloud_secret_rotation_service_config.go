@@ -0,0 +1,27 @@
+package secretrotation
+
+import (
+	"github.com/cyberark/idsec-sdk-golang/pkg/models/actions"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services"
+	svcactions "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/secretrotation/actions"
+)
+
+// ServiceConfig is the configuration for the pcloud secret rotation service.
+var ServiceConfig = services.IdsecServiceConfig{
+	ServiceName:                "pcloud-secret-rotation",
+	RequiredAuthenticatorNames: []string{},
+	OptionalAuthenticatorNames: []string{"isp"},
+	ActionsConfigurations:      map[actions.IdsecServiceActionType][]actions.IdsecServiceActionDefinition{},
+	ActionSchemas:              svcactions.ActionToSchemaMap,
+}
+
+// ServiceGenerator is the function that generates a new instance of the IdsecPCloudSecretRotationService.
+var ServiceGenerator = NewIdsecPCloudSecretRotationService
+
+// Module init, registers the service configuration.
+func init() {
+	err := services.Register(ServiceConfig, false)
+	if err != nil {
+		panic(err)
+	}
+}
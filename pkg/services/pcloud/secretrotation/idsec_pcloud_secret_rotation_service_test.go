@@ -0,0 +1,235 @@
+package secretrotation
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/accounts"
+	pcloudint "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/internal"
+	secretrotationmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/pcloud/secretrotation/models"
+)
+
+func newTestPCloudSecretRotationService(parts *pcloudint.MockISPServiceParts, sleep func(time.Duration)) *IdsecPCloudSecretRotationService {
+	return &IdsecPCloudSecretRotationService{
+		IdsecBaseService:    parts.BaseService,
+		IdsecISPBaseService: parts.ISPBase,
+		accountsService: &accounts.IdsecPCloudAccountsService{
+			IdsecBaseService:    parts.BaseService,
+			IdsecISPBaseService: parts.ISPBase,
+		},
+		sleep: sleep,
+	}
+}
+
+// fakeCPM serves the accounts of the Finance safe and records a CPM activity every time an
+// operation is triggered, taking its outcome from the scripted outcomes of the account. A
+// successful operation also moves its secret management time forward. An operation without
+// a scripted outcome is never processed by CPM.
+type fakeCPM struct {
+	mu         sync.Mutex
+	date       int
+	sameSecond bool
+	noActivity bool
+	accounts   map[string]map[string]interface{}
+	outcomes   map[string][]bool
+	activities map[string][]map[string]interface{}
+	triggered  []string
+}
+
+func newFakeCPM(outcomes map[string][]bool) *fakeCPM {
+	accounts := map[string]map[string]interface{}{}
+	for accountID, account := range map[string]string{
+		"12_1": `{"id": "12_1", "name": "db-admin", "safeName": "Finance", "secretManagement": {"automaticManagementEnabled": true, "status": "failure", "lastModifiedTime": 1600000000}}`,
+		"12_2": `{"id": "12_2", "name": "web-admin", "safeName": "Finance", "secretManagement": {"automaticManagementEnabled": true}}`,
+		"12_3": `{"id": "12_3", "name": "legacy-admin", "safeName": "Operations", "secretManagement": {"automaticManagementEnabled": true}}`,
+		"12_4": `{"id": "12_4", "name": "break-glass", "safeName": "Operations", "secretManagement": {"automaticManagementEnabled": false, "manualManagementReason": "Managed by hand"}}`,
+		"12_5": `{"id": "12_5", "name": "app-admin", "safeName": "Finance", "secretManagement": {"automaticManagementEnabled": true}}`,
+	} {
+		accountMap := map[string]interface{}{}
+		_ = json.Unmarshal([]byte(account), &accountMap)
+		accounts[accountID] = accountMap
+	}
+	return &fakeCPM{
+		date:     1700000000,
+		accounts: accounts,
+		outcomes: outcomes,
+		activities: map[string][]map[string]interface{}{
+			"12_1": {{"date": 1600000000, "action": "CPM Change Password Failed", "moreInfo": "An old failure"}},
+		},
+	}
+}
+
+func (f *fakeCPM) record(accountID string, operation string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.triggered = append(f.triggered, accountID+"/"+operation)
+	key := accountID + "/" + operation
+	if len(f.outcomes[key]) == 0 {
+		return
+	}
+	succeeded := f.outcomes[key][0]
+	f.outcomes[key] = f.outcomes[key][1:]
+	action := map[string]string{"change": "CPM Change Password", "verify": "CPM Verify Password", "reconcile": "CPM Reconcile Password"}[operation]
+	activity := map[string]interface{}{"action": action}
+	if !f.sameSecond {
+		f.date++
+	}
+	secretManagement := f.accounts[accountID]["secretManagement"].(map[string]interface{})
+	secretManagement["status"] = "success"
+	if succeeded {
+		timeField := map[string]string{"change": "lastModifiedTime", "verify": "lastVerifiedTime", "reconcile": "lastReconciledTime"}[operation]
+		secretManagement[timeField] = f.date
+	} else {
+		secretManagement["status"] = "failure"
+		activity["action"] = action + " Failed"
+		activity["moreInfo"] = "Invalid current password"
+	}
+	if f.noActivity {
+		return
+	}
+	activity["date"] = f.date
+	f.activities[accountID] = append(f.activities[accountID], activity)
+}
+
+func (f *fakeCPM) writeAccount(w http.ResponseWriter, accountID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_ = json.NewEncoder(w).Encode(f.accounts[accountID])
+}
+
+func (f *fakeCPM) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/accounts"), "/")
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	switch {
+	case path == "":
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"value": []interface{}{f.accounts["12_1"], f.accounts["12_2"], f.accounts["12_5"]}})
+	case len(segments) == 1 && f.accounts[segments[0]] != nil:
+		f.writeAccount(w, segments[0])
+	case len(segments) == 2 && segments[1] == "activities":
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"Activities": f.activities[segments[0]]})
+	case len(segments) == 2 && r.Method == http.MethodPost:
+		f.record(segments[0], segments[1])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	t.Parallel()
+	cpm := newFakeCPM(map[string][]bool{
+		"12_1/change":    {true},
+		"12_1/verify":    {true},
+		"12_2/change":    {false, true},
+		"12_2/verify":    {true},
+		"12_3/change":    {false, false},
+		"12_3/reconcile": {true},
+		"12_5/change":    {true},
+		"12_5/verify":    {false},
+		"12_5/reconcile": {false},
+	})
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, cpm)
+	defer cleanup()
+	svc := newTestPCloudSecretRotationService(parts, func(time.Duration) {})
+
+	report, err := svc.Rotate(&secretrotationmodels.IdsecPCloudRotateSecrets{
+		AccountIDs:         []string{"12_3", "12_4", "12_1"},
+		SafeName:           "Finance",
+		Concurrency:        2,
+		RetryCount:         1,
+		Verify:             true,
+		ReconcileOnFailure: true,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, report.StartedAt)
+	require.NotEmpty(t, report.CompletedAt)
+	require.Equal(t, 2, report.Rotated)
+	require.Equal(t, 1, report.Reconciled)
+	require.Equal(t, 1, report.Failed)
+	require.Equal(t, 1, report.Skipped)
+	require.Equal(t, []secretrotationmodels.IdsecPCloudSecretRotationAccountReport{
+		{AccountID: "12_3", Name: "legacy-admin", SafeName: "Operations", Status: "reconciled", Message: "CPM change failed: Invalid current password, secret reconciled", Attempts: 2, Reconciled: true},
+		{AccountID: "12_4", Name: "break-glass", SafeName: "Operations", Status: "skipped", Message: "automatic management is disabled: Managed by hand"},
+		{AccountID: "12_1", Name: "db-admin", SafeName: "Finance", Status: "rotated", Message: "secret changed and verified", Attempts: 1, Verified: true},
+		{AccountID: "12_2", Name: "web-admin", SafeName: "Finance", Status: "rotated", Message: "secret changed and verified", Attempts: 2, Verified: true},
+		{AccountID: "12_5", Name: "app-admin", SafeName: "Finance", Status: "failed", Message: "CPM verify failed: Invalid current password, then CPM reconcile failed: Invalid current password", Attempts: 1},
+	}, report.Accounts)
+	require.NotContains(t, cpm.triggered, "12_4/change", "accounts CPM does not manage are never changed")
+}
+
+func TestRotate_timeout(t *testing.T) {
+	t.Parallel()
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, newFakeCPM(nil))
+	defer cleanup()
+	var polls int32
+	svc := newTestPCloudSecretRotationService(parts, func(time.Duration) { atomic.AddInt32(&polls, 1) })
+
+	report, err := svc.Rotate(&secretrotationmodels.IdsecPCloudRotateSecrets{
+		AccountIDs:          []string{"12_1"},
+		PollIntervalSeconds: 10,
+		TimeoutSeconds:      30,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Failed)
+	require.Equal(t, "timed out after 30s waiting for CPM to change the secret", report.Accounts[0].Message)
+	require.Equal(t, int32(3), atomic.LoadInt32(&polls))
+
+	_, err = svc.Rotate(&secretrotationmodels.IdsecPCloudRotateSecrets{})
+	require.ErrorContains(t, err, "at least one of account ids, search or safe name is required")
+}
+
+func TestRotate_sameSecondResults(t *testing.T) {
+	t.Parallel()
+	cpm := newFakeCPM(map[string][]bool{
+		"12_1/change":    {false},
+		"12_1/reconcile": {true},
+	})
+	cpm.date = 1600000000
+	cpm.sameSecond = true
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, cpm)
+	defer cleanup()
+	svc := newTestPCloudSecretRotationService(parts, func(time.Duration) {})
+
+	report, err := svc.Rotate(&secretrotationmodels.IdsecPCloudRotateSecrets{
+		AccountIDs:         []string{"12_1"},
+		TimeoutSeconds:     30,
+		ReconcileOnFailure: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []secretrotationmodels.IdsecPCloudSecretRotationAccountReport{
+		{AccountID: "12_1", Name: "db-admin", SafeName: "Finance", Status: "reconciled", Message: "CPM change failed: Invalid current password, secret reconciled", Attempts: 1, Reconciled: true},
+	}, report.Accounts, "results recorded in the same second as earlier activities are found")
+}
+
+func TestRotate_secretManagementTimes(t *testing.T) {
+	t.Parallel()
+	cpm := newFakeCPM(map[string][]bool{
+		"12_1/change": {true},
+		"12_1/verify": {true},
+		"12_2/change": {false},
+	})
+	cpm.noActivity = true
+	parts, cleanup := pcloudint.SetupMockISPServiceParts(t, cpm)
+	defer cleanup()
+	svc := newTestPCloudSecretRotationService(parts, func(time.Duration) {})
+
+	report, err := svc.Rotate(&secretrotationmodels.IdsecPCloudRotateSecrets{
+		AccountIDs:     []string{"12_1", "12_2"},
+		Verify:         true,
+		TimeoutSeconds: 30,
+	})
+	require.NoError(t, err)
+	require.Equal(t, secretrotationmodels.RotationAccountStatusRotated, report.Accounts[0].Status, "a success is read from the secret management of the account")
+	require.True(t, report.Accounts[0].Verified)
+	require.Equal(t, secretrotationmodels.RotationAccountStatusFailed, report.Accounts[1].Status, "a failure does not move the secret management time")
+}
@@ -0,0 +1,48 @@
+package models
+
+// Possible statuses of an account during a secret rotation.
+const (
+	RotationAccountStatusRotated    = "rotated"
+	RotationAccountStatusReconciled = "reconciled"
+	RotationAccountStatusFailed     = "failed"
+	RotationAccountStatusSkipped    = "skipped"
+)
+
+// IdsecPCloudRotateSecrets defines the structure for an orchestrated secret rotation of a set of accounts.
+//
+// The accounts are either given by ID or selected by search and safe name. At least one of
+// them is required, so an empty request never rotates every account of the tenant.
+type IdsecPCloudRotateSecrets struct {
+	AccountIDs          []string `json:"account_ids,omitempty" mapstructure:"account_ids,omitempty" flag:"account-ids" desc:"The IDs of the accounts to rotate."`
+	Search              string   `json:"search,omitempty" mapstructure:"search,omitempty" flag:"search" desc:"Rotate the accounts matching these keywords, separated by a space."`
+	SafeName            string   `json:"safe_name,omitempty" mapstructure:"safe_name,omitempty" flag:"safe-name" desc:"Rotate the accounts of this safe."`
+	Concurrency         int      `json:"concurrency,omitempty" mapstructure:"concurrency,omitempty" flag:"concurrency" desc:"The maximum number of accounts rotated concurrently by CPM." default:"5"`
+	PollIntervalSeconds int      `json:"poll_interval_seconds,omitempty" mapstructure:"poll_interval_seconds,omitempty" flag:"poll-interval-seconds" desc:"The interval (in seconds) between checks of the account and its activities for the CPM result." default:"10"`
+	TimeoutSeconds      int      `json:"timeout_seconds,omitempty" mapstructure:"timeout_seconds,omitempty" flag:"timeout-seconds" desc:"How long (in seconds) to wait for CPM to complete each operation." default:"600"`
+	RetryCount          int      `json:"retry_count,omitempty" mapstructure:"retry_count,omitempty" flag:"retry-count" desc:"The number of times to retry a failed change." default:"0"`
+	Verify              bool     `json:"verify,omitempty" mapstructure:"verify,omitempty" flag:"verify" desc:"Have CPM verify the new secret after it was changed." default:"false"`
+	ReconcileOnFailure  bool     `json:"reconcile_on_failure,omitempty" mapstructure:"reconcile_on_failure,omitempty" flag:"reconcile-on-failure" desc:"Have CPM reconcile the secret of accounts whose change or verification failed." default:"false"`
+}
+
+// IdsecPCloudSecretRotationAccountReport represents the rotation outcome of a single account.
+type IdsecPCloudSecretRotationAccountReport struct {
+	AccountID  string `json:"account_id" mapstructure:"account_id" desc:"The ID of the account."`
+	Name       string `json:"name,omitempty" mapstructure:"name,omitempty" desc:"The name of the account."`
+	SafeName   string `json:"safe_name,omitempty" mapstructure:"safe_name,omitempty" desc:"The safe of the account."`
+	Status     string `json:"status" mapstructure:"status" desc:"The rotation status of the account." choices:"rotated,reconciled,failed,skipped"`
+	Message    string `json:"message,omitempty" mapstructure:"message,omitempty" desc:"Details about the last CPM operation on the account."`
+	Attempts   int    `json:"attempts" mapstructure:"attempts" desc:"The number of changes triggered on the account."`
+	Verified   bool   `json:"verified" mapstructure:"verified" desc:"Whether CPM verified the secret after the rotation."`
+	Reconciled bool   `json:"reconciled" mapstructure:"reconciled" desc:"Whether CPM reconciled the secret after a failure."`
+}
+
+// IdsecPCloudSecretRotationReport represents the report of an orchestrated secret rotation.
+type IdsecPCloudSecretRotationReport struct {
+	StartedAt   string                                   `json:"started_at" mapstructure:"started_at" desc:"The time the rotation started (RFC3339)."`
+	CompletedAt string                                   `json:"completed_at" mapstructure:"completed_at" desc:"The time the rotation completed (RFC3339)."`
+	Rotated     int                                      `json:"rotated" mapstructure:"rotated" desc:"The number of accounts rotated."`
+	Reconciled  int                                      `json:"reconciled" mapstructure:"reconciled" desc:"The number of accounts reconciled after a failure."`
+	Failed      int                                      `json:"failed" mapstructure:"failed" desc:"The number of accounts that failed."`
+	Skipped     int                                      `json:"skipped" mapstructure:"skipped" desc:"The number of accounts skipped because CPM does not manage them."`
+	Accounts    []IdsecPCloudSecretRotationAccountReport `json:"accounts" mapstructure:"accounts" desc:"The per-account report."`
+}
@@ -1,6 +1,7 @@
 package targetplatforms
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
@@ -451,7 +452,24 @@ func (s *IdsecPCloudTargetPlatformsService) Import(importPlatform *targetplatfor
 		return nil, fmt.Errorf("given path [%s] does not exist or is invalid", importPlatform.PlatformZipPath)
 	}
 
-	zipData, err := os.ReadFile(filePath)
+	zipFile, err := os.Open(filePath) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("failed to read platform zip file: %v", err)
+	}
+	defer func(zipFile *os.File) {
+		err := zipFile.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing platform zip file")
+		}
+	}(zipFile)
+	return s.ImportFrom(zipFile)
+}
+
+// ImportFrom imports a target platform from zip data read from platformZip.
+//
+// Same as Import, without requiring the zip to be stored on disk.
+func (s *IdsecPCloudTargetPlatformsService) ImportFrom(platformZip io.Reader) (*targetplatformsmodels.IdsecPCloudTargetPlatform, error) {
+	zipData, err := io.ReadAll(platformZip)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read platform zip file: %v", err)
 	}
@@ -524,7 +542,23 @@ func (s *IdsecPCloudTargetPlatformsService) Export(exportPlatform *targetplatfor
 		return fmt.Errorf("failed to create output folder: %v", err)
 	}
 
-	response, err := s.postOperation()(context.Background(), fmt.Sprintf(exportTargetPlatformURL, *platID), nil)
+	var data bytes.Buffer
+	if err := s.ExportTo(*platID, &data); err != nil {
+		return err
+	}
+	outputPath := filepath.Join(exportPlatform.OutputFolder, targetPlatform.PlatformID)
+	if err := os.WriteFile(fmt.Sprintf("%s.zip", outputPath), data.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write export file: %v", err)
+	}
+
+	return nil
+}
+
+// ExportTo exports a target platform by id and writes its zip data to w.
+//
+// Same as Export, without storing the zip on disk.
+func (s *IdsecPCloudTargetPlatformsService) ExportTo(targetPlatformID int, w io.Writer) error {
+	response, err := s.postOperation()(context.Background(), fmt.Sprintf(exportTargetPlatformURL, targetPlatformID), nil)
 	if err != nil {
 		return err
 	}
@@ -539,15 +573,9 @@ func (s *IdsecPCloudTargetPlatformsService) Export(exportPlatform *targetplatfor
 		return fmt.Errorf("failed to export target platform - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
 	}
 
-	data, err := io.ReadAll(response.Body)
-	if err != nil {
+	if _, err := io.Copy(w, response.Body); err != nil {
 		return fmt.Errorf("failed to read export data: %v", err)
 	}
-	outputPath := filepath.Join(exportPlatform.OutputFolder, targetPlatform.PlatformID)
-	if err := os.WriteFile(fmt.Sprintf("%s.zip", outputPath), data, 0644); err != nil {
-		return fmt.Errorf("failed to write export file: %v", err)
-	}
-
 	return nil
 }
 
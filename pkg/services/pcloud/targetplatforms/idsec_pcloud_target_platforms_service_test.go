@@ -3,10 +3,12 @@ package targetplatforms
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/cyberark/idsec-sdk-golang/pkg/common"
@@ -1008,3 +1010,57 @@ func TestExport(t *testing.T) {
 		})
 	}
 }
+
+func TestImportFrom(t *testing.T) {
+	t.Parallel()
+
+	service := createTestService()
+	var importFile interface{}
+	service.doPost = func(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+		importFile = body.(map[string]interface{})["ImportFile"]
+		return NewMockResponse(http.StatusCreated, `{"platform_id": "imported-platform"}`), nil
+	}
+	service.doGet = func(ctx context.Context, path string, params interface{}) (*http.Response, error) {
+		return NewMockResponse(http.StatusOK, `{"platforms": [{"id": 123, "platform_id": "imported-platform"}]}`), nil
+	}
+
+	targetPlatform, err := service.ImportFrom(strings.NewReader("fake zip content"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if targetPlatform.PlatformID != "imported-platform" {
+		t.Errorf("Expected imported-platform, got %s", targetPlatform.PlatformID)
+	}
+	if importFile != base64.StdEncoding.EncodeToString([]byte("fake zip content")) {
+		t.Errorf("Expected base64 encoded zip content, got %v", importFile)
+	}
+}
+
+func TestExportTo(t *testing.T) {
+	t.Parallel()
+
+	service := createTestService()
+	var exportPath string
+	service.doPost = func(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+		exportPath = path
+		return NewMockResponse(http.StatusOK, "fake zip content"), nil
+	}
+
+	var buf bytes.Buffer
+	if err := service.ExportTo(123, &buf); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if buf.String() != "fake zip content" {
+		t.Errorf("Expected exported zip content, got %q", buf.String())
+	}
+	if exportPath != "api/platforms/targets/123/export" {
+		t.Errorf("Expected export path, got %s", exportPath)
+	}
+
+	service.doPost = func(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+		return NewMockResponse(http.StatusNotFound, `{"error": "platform not found"}`), nil
+	}
+	if err := service.ExportTo(999, &bytes.Buffer{}); err == nil || !strings.HasPrefix(err.Error(), "failed to export target platform") {
+		t.Errorf("Expected export error, got %v", err)
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 // ActionToSchemaMap maps cloudaccess action names to their input schema structs.
 var ActionToSchemaMap = map[string]interface{}{
-	"list-targets": &scamodels.IdsecSCAListTargetsRequest{},
-	"elevate":      &cloudaccessmodels.IdsecSCACloudAccessElevateActionRequest{},
+	"list-targets":           &scamodels.IdsecSCAListTargetsRequest{},
+	"elevate":                &cloudaccessmodels.IdsecSCACloudAccessElevateActionRequest{},
+	"aws-credential-process": &cloudaccessmodels.IdsecSCACloudAccessAWSCredentialsRequest{},
+	"write-aws-profile":      &cloudaccessmodels.IdsecSCACloudAccessWriteAWSProfileRequest{},
 }
@@ -0,0 +1,401 @@
+package cloudaccess
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	authmodels "github.com/cyberark/idsec-sdk-golang/pkg/models/auth"
+	commonmodels "github.com/cyberark/idsec-sdk-golang/pkg/models/common"
+	"github.com/cyberark/idsec-sdk-golang/pkg/profiles"
+	cloudaccessmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/cloudaccess/models"
+	scamodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/models"
+)
+
+const (
+	// DefaultAWSCredentialsFile is the default AWS shared credentials file.
+	DefaultAWSCredentialsFile = "~/.aws/credentials"
+
+	// DefaultAWSConfigFile is the default AWS shared config file.
+	DefaultAWSConfigFile = "~/.aws/config"
+
+	// defaultAWSFallbackLifetime is the lifetime conservatively assumed for elevated AWS
+	// credentials when the elevate response has no session expiry and the request does
+	// not specify a fallback lifetime.
+	defaultAWSFallbackLifetime = 15 * time.Minute
+
+	// awsSessionRefreshWindow is how long before its expiry a cached session
+	// is considered stale and a new elevation is performed.
+	awsSessionRefreshWindow = 5 * time.Minute
+
+	// awsCredentialProcessVersion is the credential_process payload version expected by the AWS CLI.
+	awsCredentialProcessVersion = 1
+)
+
+// AwsCredentialProcess elevates to an AWS account and returns the short-lived credentials
+// in the AWS CLI credential_process format.
+//
+// Sessions are cached in the keyring per tenant, user, workspace and role. A cached session
+// is reused until it is within awsSessionRefreshWindow of its expiry, after which
+// the account is elevated again. ForceRefresh bypasses the cache.
+//
+// The returned payload is meant to be printed as JSON by a credential_process command:
+//
+//	[profile prod]
+//	credential_process = idsec exec sca cloudaccess aws-credential-process --workspace-id 123456789012 --role-id ReadOnly
+func (s *IdsecSCACloudAccessService) AwsCredentialProcess(req *cloudaccessmodels.IdsecSCACloudAccessAWSCredentialsRequest) (*cloudaccessmodels.IdsecSCACloudAccessAWSCredentialProcessOutput, error) {
+	if req == nil {
+		return nil, fmt.Errorf("aws credentials request cannot be nil")
+	}
+	if strings.TrimSpace(req.WorkspaceID) == "" {
+		return nil, fmt.Errorf("workspaceId cannot be empty")
+	}
+	if strings.TrimSpace(req.RoleID) == "" {
+		return nil, fmt.Errorf("roleId cannot be empty")
+	}
+	if req.FallbackLifetime < 0 {
+		return nil, fmt.Errorf("fallback lifetime cannot be negative")
+	}
+	if s == nil || s.IdsecISPBaseService == nil || s.ISPClient() == nil {
+		return nil, fmt.Errorf("sca cloudaccess service not initialized")
+	}
+
+	cacheKey := s.awsSessionCacheKey(req.WorkspaceID, req.RoleID)
+	if !req.ForceRefresh {
+		if cached := s.loadAWSSessionFromCache(cacheKey); cached != nil {
+			return cached, nil
+		}
+	}
+
+	output, err := s.elevateAWSCredentials(req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.saveAWSSessionToCache(cacheKey, output); err != nil {
+		s.Logger.Warning("Failed to cache elevated AWS session: %v", err)
+	}
+	return output, nil
+}
+
+// WriteAwsProfile writes a named AWS profile for an elevated AWS account.
+//
+// Without CredentialProcess, the account is elevated (reusing a cached session when valid)
+// and the static credentials are written to the credentials file, while the region is
+// written to the config file. With CredentialProcess, no elevation takes place; the command
+// is written to the config file and any static keys of the profile are removed from the
+// credentials file, since they would take precedence over the credential_process entry.
+//
+// Other profiles and unrelated keys of the written profile are preserved.
+func (s *IdsecSCACloudAccessService) WriteAwsProfile(req *cloudaccessmodels.IdsecSCACloudAccessWriteAWSProfileRequest) (*cloudaccessmodels.IdsecSCACloudAccessAWSProfile, error) {
+	if req == nil {
+		return nil, fmt.Errorf("write aws profile request cannot be nil")
+	}
+	profileName := strings.TrimSpace(req.ProfileName)
+	if profileName == "" {
+		return nil, fmt.Errorf("profile name cannot be empty")
+	}
+	if strings.ContainsAny(profileName, "[]\n") {
+		return nil, fmt.Errorf("invalid profile name [%s]", req.ProfileName)
+	}
+	credentialsFile := expandFilePath(req.CredentialsFile, DefaultAWSCredentialsFile)
+	configFile := expandFilePath(req.ConfigFile, DefaultAWSConfigFile)
+	configSection := "profile " + profileName
+	if profileName == "default" {
+		configSection = profileName
+	}
+	awsKeys := []string{"aws_access_key_id", "aws_secret_access_key", "aws_session_token"}
+
+	configValues := map[string]string{}
+	if req.Region != "" {
+		configValues["region"] = req.Region
+	}
+
+	if req.CredentialProcess != "" {
+		configValues["credential_process"] = req.CredentialProcess
+		if err := updateINISection(configFile, configSection, configValues, nil); err != nil {
+			return nil, err
+		}
+		if err := updateINISection(credentialsFile, profileName, nil, awsKeys); err != nil {
+			return nil, err
+		}
+		s.Logger.Info("Wrote credential_process AWS profile [%s] to [%s]", profileName, configFile)
+		return &cloudaccessmodels.IdsecSCACloudAccessAWSProfile{
+			ProfileName: profileName,
+			ConfigFile:  configFile,
+		}, nil
+	}
+
+	output, err := s.AwsCredentialProcess(&req.IdsecSCACloudAccessAWSCredentialsRequest)
+	if err != nil {
+		return nil, err
+	}
+	credentialValues := map[string]string{
+		"aws_access_key_id":     output.AccessKeyID,
+		"aws_secret_access_key": output.SecretAccessKey,
+	}
+	var unset []string
+	if output.SessionToken != "" {
+		credentialValues["aws_session_token"] = output.SessionToken
+	} else {
+		unset = append(unset, "aws_session_token")
+	}
+	if err := updateINISection(credentialsFile, profileName, credentialValues, unset); err != nil {
+		return nil, err
+	}
+	if err := updateINISection(configFile, configSection, configValues, []string{"credential_process"}); err != nil {
+		return nil, err
+	}
+	s.Logger.Info("Wrote AWS profile [%s] to [%s]", profileName, credentialsFile)
+	return &cloudaccessmodels.IdsecSCACloudAccessAWSProfile{
+		ProfileName:     profileName,
+		CredentialsFile: credentialsFile,
+		ConfigFile:      configFile,
+		Expiration:      output.Expiration,
+	}, nil
+}
+
+func (s *IdsecSCACloudAccessService) elevateAWSCredentials(req *cloudaccessmodels.IdsecSCACloudAccessAWSCredentialsRequest) (*cloudaccessmodels.IdsecSCACloudAccessAWSCredentialProcessOutput, error) {
+	elevatedAt := time.Now()
+	resp, err := s.Elevate(&cloudaccessmodels.IdsecSCACloudAccessElevateActionRequest{
+		CSP:            scamodels.CSPAWS,
+		WorkspaceID:    req.WorkspaceID,
+		RoleIDs:        req.RoleID,
+		OrganizationID: req.OrganizationID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Response.Results) == 0 {
+		return nil, fmt.Errorf("elevate response contains no results for workspace [%s]", req.WorkspaceID)
+	}
+	result := resp.Response.Results[0]
+	if result.ErrorInfo != nil {
+		return nil, fmt.Errorf("failed to elevate to workspace [%s] with role [%s] - [%s] - [%s]", req.WorkspaceID, req.RoleID, result.ErrorInfo.Code, result.ErrorInfo.Message)
+	}
+	if result.AccessCredentials == "" {
+		return nil, fmt.Errorf("accessCredentials is empty for workspace [%s]", req.WorkspaceID)
+	}
+	var awsCreds cloudaccessmodels.IdsecSCACloudAccessAWSAccessCredentials
+	if err := json.Unmarshal([]byte(result.AccessCredentials), &awsCreds); err != nil {
+		return nil, fmt.Errorf("failed to parse AWS access credentials: %w", err)
+	}
+	if awsCreds.AWSAccessKey == "" || awsCreds.AWSSecretAccessKey == "" {
+		return nil, fmt.Errorf("AWS access key or secret key is missing in access credentials")
+	}
+	expiration, err := parseElevateSessionExpTime(result.SessionExpTime)
+	if err != nil {
+		fallbackLifetime := defaultAWSFallbackLifetime
+		if req.FallbackLifetime > 0 {
+			fallbackLifetime = time.Duration(req.FallbackLifetime) * time.Minute
+		}
+		s.Logger.Info("Elevate response has no usable session expiry (%v), assuming the credentials expire in %s", err, fallbackLifetime)
+		expiration = elevatedAt.Add(fallbackLifetime)
+	}
+	return &cloudaccessmodels.IdsecSCACloudAccessAWSCredentialProcessOutput{
+		Version:         awsCredentialProcessVersion,
+		AccessKeyID:     awsCreds.AWSAccessKey,
+		SecretAccessKey: awsCreds.AWSSecretAccessKey,
+		SessionToken:    awsCreds.AWSSessionToken,
+		Expiration:      expiration.UTC().Format(time.RFC3339),
+	}, nil
+}
+
+// parseElevateSessionExpTime parses the sessionExpTime of an elevate result, accepting
+// the RFC3339 variants and the zone-less UTC form returned by some tenants.
+func parseElevateSessionExpTime(raw string) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return time.Time{}, fmt.Errorf("empty sessionExpTime")
+	}
+	for _, layout := range []string{time.RFC3339Nano, time.RFC3339} {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	if t, err := time.ParseInLocation("2006-01-02T15:04:05.999999999", raw, time.UTC); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("unrecognized sessionExpTime format [%s]", raw)
+}
+
+// awsSessionCacheKey returns the keyring postfix of a cached session, or an empty string
+// when the current token does not identify a tenant and user.
+func (s *IdsecSCACloudAccessService) awsSessionCacheKey(workspaceID string, roleID string) string {
+	parsedToken, _, err := new(jwt.Parser).ParseUnverified(s.ISPClient().GetToken(), jwt.MapClaims{})
+	if err != nil {
+		return ""
+	}
+	claims := parsedToken.Claims.(jwt.MapClaims)
+	return fmt.Sprintf("%s_%s_sca_cloudaccess_aws_%s_%s", claims["tenant_id"], claims["unique_name"], workspaceID, roleID)
+}
+
+func (s *IdsecSCACloudAccessService) loadAWSSessionFromCache(cacheKey string) *cloudaccessmodels.IdsecSCACloudAccessAWSCredentialProcessOutput {
+	if s.cacheKeyring == nil || cacheKey == "" {
+		return nil
+	}
+	defaultProfile, err := (*profiles.DefaultProfilesLoader()).LoadDefaultProfile()
+	if err != nil {
+		return nil
+	}
+	token, err := s.cacheKeyring.LoadToken(defaultProfile, cacheKey, false)
+	if err != nil || token == nil {
+		return nil
+	}
+	var output cloudaccessmodels.IdsecSCACloudAccessAWSCredentialProcessOutput
+	if err := json.Unmarshal([]byte(token.Token), &output); err != nil {
+		return nil
+	}
+	expiration, err := time.Parse(time.RFC3339, output.Expiration)
+	if err != nil || time.Until(expiration) < awsSessionRefreshWindow {
+		s.Logger.Info("Cached AWS session is near expiry, elevating again")
+		return nil
+	}
+	return &output
+}
+
+func (s *IdsecSCACloudAccessService) saveAWSSessionToCache(cacheKey string, output *cloudaccessmodels.IdsecSCACloudAccessAWSCredentialProcessOutput) error {
+	if s.cacheKeyring == nil || cacheKey == "" {
+		return nil
+	}
+	defaultProfile, err := (*profiles.DefaultProfilesLoader()).LoadDefaultProfile()
+	if err != nil {
+		return err
+	}
+	expiration, err := time.Parse(time.RFC3339, output.Expiration)
+	if err != nil {
+		return err
+	}
+	marshaledOutput, err := json.Marshal(output)
+	if err != nil {
+		return err
+	}
+	return s.cacheKeyring.SaveToken(
+		defaultProfile,
+		&authmodels.IdsecToken{
+			Token:     string(marshaledOutput),
+			TokenType: authmodels.Token,
+			ExpiresIn: commonmodels.IdsecRFC3339Time(expiration),
+		},
+		cacheKey,
+		false,
+	)
+}
+
+// expandFilePath expands environment variables and the home directory of a file path,
+// falling back to defaultPath when path is empty.
+func expandFilePath(path string, defaultPath string) string {
+	if path == "" {
+		path = defaultPath
+	}
+	return filepath.Join(common.ExpandFolder(filepath.Dir(path)), filepath.Base(path))
+}
+
+// updateINISection sets and unsets keys of a single section in an AWS shared INI file,
+// preserving every other line. The section is appended when missing and removed when
+// it ends up without any keys. The file and its folder are created as needed.
+func updateINISection(path string, section string, set map[string]string, unset []string) error {
+	content, err := os.ReadFile(path) // #nosec G304
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read [%s]: %w", path, err)
+	}
+	if len(content) == 0 && len(set) == 0 {
+		return nil
+	}
+	pending := make(map[string]string, len(set))
+	for key, value := range set {
+		pending[key] = value
+	}
+	removed := make(map[string]bool, len(unset))
+	for _, key := range unset {
+		removed[key] = true
+	}
+	appendPending := func(lines []string) []string {
+		keys := make([]string, 0, len(pending))
+		for key := range pending {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			lines = append(lines, fmt.Sprintf("%s = %s", key, pending[key]))
+			delete(pending, key)
+		}
+		return lines
+	}
+
+	var lines []string
+	var sectionLines []string
+	inSection, found := false, false
+	flushSection := func() {
+		sectionLines = appendPending(sectionLines)
+		hasKeys := false
+		for _, line := range sectionLines[1:] {
+			if trimmed := strings.TrimSpace(line); trimmed != "" && !strings.HasPrefix(trimmed, "#") && !strings.HasPrefix(trimmed, ";") {
+				hasKeys = true
+				break
+			}
+		}
+		if hasKeys {
+			lines = append(lines, sectionLines...)
+		}
+		sectionLines = nil
+	}
+	if len(content) > 0 {
+		for _, line := range strings.Split(strings.TrimRight(string(content), "\n"), "\n") {
+			trimmed := strings.TrimSpace(line)
+			if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+				if inSection {
+					flushSection()
+				}
+				inSection = strings.TrimSpace(trimmed[1:len(trimmed)-1]) == section
+				if inSection {
+					found = true
+					sectionLines = []string{line}
+					continue
+				}
+			}
+			if !inSection {
+				lines = append(lines, line)
+				continue
+			}
+			if key, _, ok := strings.Cut(trimmed, "="); ok {
+				key = strings.TrimSpace(key)
+				if removed[key] {
+					continue
+				}
+				if value, ok := pending[key]; ok {
+					sectionLines = append(sectionLines, fmt.Sprintf("%s = %s", key, value))
+					delete(pending, key)
+					continue
+				}
+			}
+			sectionLines = append(sectionLines, line)
+		}
+		if inSection {
+			flushSection()
+		}
+	}
+	if !found && len(pending) > 0 {
+		if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+			lines = append(lines, "")
+		}
+		lines = appendPending(append(lines, fmt.Sprintf("[%s]", section)))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create folder for [%s]: %w", path, err)
+	}
+	output := strings.TrimRight(strings.Join(lines, "\n"), "\n")
+	if output != "" {
+		output += "\n"
+	}
+	if err := os.WriteFile(path, []byte(output), 0o600); err != nil {
+		return fmt.Errorf("failed to write [%s]: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package cloudaccess
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
+	"github.com/cyberark/idsec-sdk-golang/pkg/models"
+	authmodels "github.com/cyberark/idsec-sdk-golang/pkg/models/auth"
+	cloudaccessmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/cloudaccess/models"
+	scainternal "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/internal"
+)
+
+const awsElevateResponseJSON = "{\"response\":{\"organizationId\":\"test-org\",\"csp\":\"AWS\",\"results\":[{\"workspaceId\":\"123456789012\",\"roleId\":\"ReadOnly\",\"sessionId\":\"test-session-id\",\"accessCredentials\":\"{\\\"aws_access_key\\\":\\\"test-access-key\\\",\\\"aws_secret_access_key\\\":\\\"test-secret-key\\\",\\\"aws_session_token\\\":\\\"test-session-token\\\"}\"}]}}"
+
+// memoryKeyring is an in-memory keyring used to observe session caching.
+type memoryKeyring struct {
+	tokens map[string]*authmodels.IdsecToken
+}
+
+func (m *memoryKeyring) SaveToken(_ *models.IdsecProfile, token *authmodels.IdsecToken, postfix string, _ bool) error {
+	m.tokens[postfix] = token
+	return nil
+}
+
+func (m *memoryKeyring) LoadToken(_ *models.IdsecProfile, postfix string, _ bool) (*authmodels.IdsecToken, error) {
+	return m.tokens[postfix], nil
+}
+
+// setupAWSCredentialsService creates a cloudaccess service with a signed-in user token and an
+// in-memory keyring, and counts the elevate calls it makes.
+func setupAWSCredentialsService(t *testing.T) (*IdsecSCACloudAccessService, *memoryKeyring, *int) {
+	t.Helper()
+	t.Setenv("IDSEC_PROFILES_FOLDER", t.TempDir())
+	elevateCalls := 0
+	client, cleanup := scainternal.SetupMockSCAService(t, []scainternal.MockEndpointConfig{
+		{
+			Matcher:      func(r *http.Request) bool { return r.Method == http.MethodPost && r.URL.Path == "/api/access/elevate" },
+			StatusCode:   http.StatusOK,
+			ResponseBody: awsElevateResponseJSON,
+			OnRequest:    func(r *http.Request) { elevateCalls++ },
+		},
+	})
+	t.Cleanup(cleanup)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"tenant_id":   "tenant",
+		"unique_name": "user@example.com",
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+	client.UpdateToken(token, "Bearer")
+
+	cache := &memoryKeyring{tokens: map[string]*authmodels.IdsecToken{}}
+	svc := setupCloudAccessService(client)
+	svc.cacheKeyring = cache
+	return svc, cache, &elevateCalls
+}
+
+func TestAwsCredentialProcess_ReturnsCredentialProcessPayload(t *testing.T) {
+	svc, _, _ := setupAWSCredentialsService(t)
+
+	before := time.Now()
+	output, err := svc.AwsCredentialProcess(&cloudaccessmodels.IdsecSCACloudAccessAWSCredentialsRequest{
+		WorkspaceID: "123456789012",
+		RoleID:      "ReadOnly",
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, 1, output.Version)
+	require.Equal(t, "test-access-key", output.AccessKeyID)
+	require.Equal(t, "test-secret-key", output.SecretAccessKey)
+	require.Equal(t, "test-session-token", output.SessionToken)
+	expiration, err := time.Parse(time.RFC3339, output.Expiration)
+	require.NoError(t, err)
+	require.WithinDuration(t, before.Add(defaultAWSFallbackLifetime), expiration, 5*time.Second)
+}
+
+func TestAwsCredentialProcess_SessionExpTime(t *testing.T) {
+	t.Setenv("IDSEC_PROFILES_FOLDER", t.TempDir())
+	tests := []struct {
+		name           string
+		sessionExpTime string
+		expected       string
+	}{
+		{name: "rfc3339", sessionExpTime: "2030-01-02T03:04:05Z", expected: "2030-01-02T03:04:05Z"},
+		{name: "rfc3339_with_offset", sessionExpTime: "2030-01-02T05:04:05.123+02:00", expected: "2030-01-02T03:04:05Z"},
+		{name: "zone_less_utc", sessionExpTime: "2030-01-02T03:04:05.123456", expected: "2030-01-02T03:04:05Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, cleanup := scainternal.SetupMockSCAService(t, []scainternal.MockEndpointConfig{
+				{
+					Matcher:      func(r *http.Request) bool { return r.Method == http.MethodPost && r.URL.Path == "/api/access/elevate" },
+					StatusCode:   http.StatusOK,
+					ResponseBody: strings.Replace(awsElevateResponseJSON, `"sessionId":"test-session-id"`, `"sessionId":"test-session-id","sessionExpTime":"`+tt.sessionExpTime+`"`, 1),
+				},
+			})
+			defer cleanup()
+
+			svc := setupCloudAccessService(client)
+			output, err := svc.AwsCredentialProcess(&cloudaccessmodels.IdsecSCACloudAccessAWSCredentialsRequest{
+				WorkspaceID:      "123456789012",
+				RoleID:           "ReadOnly",
+				FallbackLifetime: 1,
+			})
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, output.Expiration)
+		})
+	}
+}
+
+func TestAwsCredentialProcess_Caching(t *testing.T) {
+	tests := []struct {
+		name                 string
+		fallbackLifetime     int
+		forceRefresh         bool
+		expectedElevateCalls int
+	}{
+		{name: "reuses_cached_session", fallbackLifetime: 60, expectedElevateCalls: 1},
+		{name: "force_refresh_elevates_again", fallbackLifetime: 60, forceRefresh: true, expectedElevateCalls: 2},
+		{name: "near_expiry_elevates_again", fallbackLifetime: 1, expectedElevateCalls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, cache, elevateCalls := setupAWSCredentialsService(t)
+			req := &cloudaccessmodels.IdsecSCACloudAccessAWSCredentialsRequest{
+				WorkspaceID:      "123456789012",
+				RoleID:           "ReadOnly",
+				FallbackLifetime: tt.fallbackLifetime,
+			}
+			_, err := svc.AwsCredentialProcess(req)
+			require.NoError(t, err)
+			require.Contains(t, cache.tokens, "tenant_user@example.com_sca_cloudaccess_aws_123456789012_ReadOnly")
+
+			req.ForceRefresh = tt.forceRefresh
+			output, err := svc.AwsCredentialProcess(req)
+			require.NoError(t, err)
+			require.Equal(t, "test-access-key", output.AccessKeyID)
+			require.Equal(t, tt.expectedElevateCalls, *elevateCalls)
+		})
+	}
+}
+
+func TestAwsCredentialProcess_NotEligible(t *testing.T) {
+	client, cleanup := scainternal.SetupMockSCAService(t, []scainternal.MockEndpointConfig{
+		{
+			Matcher:      func(r *http.Request) bool { return true },
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{"response":{"csp":"AWS","results":[{"workspaceId":"123456789012","roleId":"ReadOnly","errorInfo":{"code":"NOT_ELIGIBLE","message":"User is not eligible"}}]}}`,
+		},
+	})
+	defer cleanup()
+
+	svc := setupCloudAccessService(client)
+	_, err := svc.AwsCredentialProcess(&cloudaccessmodels.IdsecSCACloudAccessAWSCredentialsRequest{
+		WorkspaceID: "123456789012",
+		RoleID:      "ReadOnly",
+	})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "NOT_ELIGIBLE")
+}
+
+func TestAwsCredentialProcess_Validation(t *testing.T) {
+	svc := &IdsecSCACloudAccessService{}
+	tests := []struct {
+		name string
+		req  *cloudaccessmodels.IdsecSCACloudAccessAWSCredentialsRequest
+	}{
+		{name: "nil_request", req: nil},
+		{name: "missing_workspace_id", req: &cloudaccessmodels.IdsecSCACloudAccessAWSCredentialsRequest{RoleID: "ReadOnly"}},
+		{name: "missing_role_id", req: &cloudaccessmodels.IdsecSCACloudAccessAWSCredentialsRequest{WorkspaceID: "123456789012"}},
+		{name: "negative_fallback_lifetime", req: &cloudaccessmodels.IdsecSCACloudAccessAWSCredentialsRequest{WorkspaceID: "123456789012", RoleID: "ReadOnly", FallbackLifetime: -1}},
+		{name: "uninitialized_service", req: &cloudaccessmodels.IdsecSCACloudAccessAWSCredentialsRequest{WorkspaceID: "123456789012", RoleID: "ReadOnly"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.AwsCredentialProcess(tt.req)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestWriteAwsProfile_StaticCredentials(t *testing.T) {
+	svc, _, _ := setupAWSCredentialsService(t)
+	folder := t.TempDir()
+	credentialsFile := filepath.Join(folder, "credentials")
+	configFile := filepath.Join(folder, "config")
+	require.NoError(t, os.WriteFile(credentialsFile, []byte("[other]\naws_access_key_id = other-key\n\n[prod]\naws_access_key_id = old-key\naws_secret_access_key = old-secret\n"), 0o600))
+	require.NoError(t, os.WriteFile(configFile, []byte("[profile prod]\noutput = json\ncredential_process = old-command\n"), 0o600))
+
+	profile, err := svc.WriteAwsProfile(&cloudaccessmodels.IdsecSCACloudAccessWriteAWSProfileRequest{
+		IdsecSCACloudAccessAWSCredentialsRequest: cloudaccessmodels.IdsecSCACloudAccessAWSCredentialsRequest{
+			WorkspaceID: "123456789012",
+			RoleID:      "ReadOnly",
+		},
+		ProfileName:     "prod",
+		Region:          "us-east-1",
+		CredentialsFile: credentialsFile,
+		ConfigFile:      configFile,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "prod", profile.ProfileName)
+	require.NotEmpty(t, profile.Expiration)
+	credentials, err := os.ReadFile(credentialsFile)
+	require.NoError(t, err)
+	require.Equal(t, "[other]\naws_access_key_id = other-key\n\n[prod]\naws_access_key_id = test-access-key\naws_secret_access_key = test-secret-key\naws_session_token = test-session-token\n", string(credentials))
+	config, err := os.ReadFile(configFile)
+	require.NoError(t, err)
+	require.Equal(t, "[profile prod]\noutput = json\nregion = us-east-1\n", string(config))
+}
+
+func TestWriteAwsProfile_CredentialProcess(t *testing.T) {
+	svc, _, elevateCalls := setupAWSCredentialsService(t)
+	folder := t.TempDir()
+	credentialsFile := filepath.Join(folder, "credentials")
+	configFile := filepath.Join(folder, "aws", "config")
+	require.NoError(t, os.WriteFile(credentialsFile, []byte("[default]\naws_access_key_id = default-key\n\n[prod]\naws_access_key_id = old-key\naws_secret_access_key = old-secret\n"), 0o600))
+
+	profile, err := svc.WriteAwsProfile(&cloudaccessmodels.IdsecSCACloudAccessWriteAWSProfileRequest{
+		ProfileName:       "prod",
+		CredentialsFile:   credentialsFile,
+		ConfigFile:        configFile,
+		CredentialProcess: "idsec exec sca cloudaccess aws-credential-process --workspace-id 123456789012 --role-id ReadOnly",
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, configFile, profile.ConfigFile)
+	require.Zero(t, *elevateCalls)
+	credentials, err := os.ReadFile(credentialsFile)
+	require.NoError(t, err)
+	require.Equal(t, "[default]\naws_access_key_id = default-key\n", string(credentials))
+	config, err := os.ReadFile(configFile)
+	require.NoError(t, err)
+	require.Equal(t, "[profile prod]\ncredential_process = idsec exec sca cloudaccess aws-credential-process --workspace-id 123456789012 --role-id ReadOnly\n", string(config))
+}
+
+func TestWriteAwsProfile_Validation(t *testing.T) {
+	svc := setupCloudAccessService(&isp.IdsecISPServiceClient{})
+	_, err := svc.WriteAwsProfile(nil)
+	require.Error(t, err)
+	_, err = svc.WriteAwsProfile(&cloudaccessmodels.IdsecSCACloudAccessWriteAWSProfileRequest{})
+	require.Error(t, err)
+	_, err = svc.WriteAwsProfile(&cloudaccessmodels.IdsecSCACloudAccessWriteAWSProfileRequest{ProfileName: "bad]name"})
+	require.Error(t, err)
+}
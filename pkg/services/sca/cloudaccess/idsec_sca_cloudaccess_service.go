@@ -12,6 +12,7 @@ import (
 	"github.com/cyberark/idsec-sdk-golang/pkg/auth"
 	"github.com/cyberark/idsec-sdk-golang/pkg/common"
 	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/keyring"
 	"github.com/cyberark/idsec-sdk-golang/pkg/services"
 	"github.com/cyberark/idsec-sdk-golang/pkg/services/sca"
 	cloudaccessmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/cloudaccess/models"
@@ -21,7 +22,7 @@ import (
 
 const (
 	// eligibilityURLFmt is the endpoint for listing eligible cloudaccess targets.
-	// The CSP (AWS | AZURE) is a path parameter.
+	// The CSP (AWS | AZURE | GCP) is a path parameter.
 	eligibilityURLFmt = "/api/access/%s/eligibility"
 
 	// elevateRelURL is the endpoint for obtaining short-lived cloud credentials.
@@ -37,7 +38,7 @@ const (
 // IdsecSCACloudAccessService provides SCA cloudaccess eligibility operations.
 //
 // It is an independent service that hits GET /api/access/{csp}/eligibility
-// and supports AWS and AZURE as valid CSP values.
+// and supports AWS, AZURE and GCP as valid CSP values.
 //
 // Initialization requires a valid "isp" authenticator. Token refresh is delegated
 // to refreshAuth.
@@ -52,12 +53,15 @@ const (
 type IdsecSCACloudAccessService struct {
 	*services.IdsecBaseService
 	*services.IdsecISPBaseService
+	cacheKeyring keyring.IdsecKeyringInterface
 }
 
 // NewIdsecSCACloudAccessService creates a new IdsecSCACloudAccessService instance
 // using the provided authenticators. An "isp" authenticator is required.
 func NewIdsecSCACloudAccessService(authenticators ...auth.IdsecAuth) (*IdsecSCACloudAccessService, error) {
-	svc := &IdsecSCACloudAccessService{}
+	svc := &IdsecSCACloudAccessService{
+		cacheKeyring: keyring.NewIdsecKeyring(ServiceConfig.ServiceName),
+	}
 	base, err := services.NewIdsecBaseService(svc, authenticators...)
 	if err != nil {
 		return nil, err
@@ -94,7 +98,7 @@ func (s *IdsecSCACloudAccessService) refreshAuth(client *common.IdsecClient) err
 //
 // Returns *IdsecSCAListTargetsResponse with Response, Total and NextToken on success, or error when:
 //   - req is nil
-//   - CSP is not one of AWS / AZURE
+//   - CSP is not one of AWS / AZURE / GCP
 //   - the service is not initialized
 //   - the network call fails
 //   - the response status is not 200
@@ -110,8 +114,8 @@ func (s *IdsecSCACloudAccessService) ListTargets(req *scamodels.IdsecSCAListTarg
 		return nil, fmt.Errorf("list targets request cannot be nil")
 	}
 	cspUpper := strings.ToUpper(strings.TrimSpace(req.CSP))
-	if cspUpper != "" && !scacommon.IsSupportedCSP(cspUpper, scamodels.CSPAWS, scamodels.CSPAzure) {
-		return nil, scacommon.ErrUnsupportedCSP(req.CSP, scamodels.CSPAWS, scamodels.CSPAzure)
+	if cspUpper != "" && !scacommon.IsSupportedCSP(cspUpper, scamodels.CSPAWS, scamodels.CSPAzure, scamodels.CSPGCP) {
+		return nil, scacommon.ErrUnsupportedCSP(req.CSP, scamodels.CSPAWS, scamodels.CSPAzure, scamodels.CSPGCP)
 	}
 	if req.All && cspUpper != "" {
 		return nil, scacommon.ErrCSPAllConflict()
@@ -224,7 +228,7 @@ func (s *IdsecSCACloudAccessService) listTargetsForCSP(req *scamodels.IdsecSCALi
 //
 // Returns *IdsecSCACloudAccessElevateResponse on success or an error when:
 //   - req is nil, CSP is empty, WorkspaceID is empty, or RoleIDs is empty
-//   - CSP is not one of AWS / AZURE
+//   - CSP is not one of AWS / AZURE / GCP
 //   - the network call fails or the response status is not 200
 //   - JSON decoding fails
 func (s *IdsecSCACloudAccessService) Elevate(req *cloudaccessmodels.IdsecSCACloudAccessElevateActionRequest) (*cloudaccessmodels.IdsecSCACloudAccessElevateResponse, error) { //nolint:revive
@@ -233,10 +237,10 @@ func (s *IdsecSCACloudAccessService) Elevate(req *cloudaccessmodels.IdsecSCAClou
 	}
 	cspUpper := strings.ToUpper(strings.TrimSpace(req.CSP))
 	if cspUpper == "" {
-		return nil, scacommon.ErrCSPEmpty(scamodels.CSPAWS, scamodels.CSPAzure)
+		return nil, scacommon.ErrCSPEmpty(scamodels.CSPAWS, scamodels.CSPAzure, scamodels.CSPGCP)
 	}
-	if !scacommon.IsSupportedCSP(cspUpper, scamodels.CSPAWS, scamodels.CSPAzure) {
-		return nil, scacommon.ErrUnsupportedCSP(req.CSP, scamodels.CSPAWS, scamodels.CSPAzure)
+	if !scacommon.IsSupportedCSP(cspUpper, scamodels.CSPAWS, scamodels.CSPAzure, scamodels.CSPGCP) {
+		return nil, scacommon.ErrUnsupportedCSP(req.CSP, scamodels.CSPAWS, scamodels.CSPAzure, scamodels.CSPGCP)
 	}
 	if strings.TrimSpace(req.WorkspaceID) == "" {
 		return nil, fmt.Errorf("workspaceId cannot be empty")
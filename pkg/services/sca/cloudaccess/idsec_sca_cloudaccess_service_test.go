@@ -165,7 +165,7 @@ func TestListTargets_EmptyResponse(t *testing.T) {
 	require.Empty(t, resp.Response)
 }
 
-func TestListTargets_AllFlag_AggregatesAWSAndAzure(t *testing.T) {
+func TestListTargets_AllFlag_AggregatesAllCSPs(t *testing.T) {
 	var capturedPaths []string
 	var capturedQueries []string
 	client, cleanup := scainternal.SetupMockSCAService(t, []scainternal.MockEndpointConfig{
@@ -213,6 +213,11 @@ func TestListTargets_AllFlag_AggregatesAWSAndAzure(t *testing.T) {
 				capturedQueries = append(capturedQueries, r.URL.RawQuery)
 			},
 		},
+		{
+			Matcher:      func(r *http.Request) bool { return r.URL.Path == "/api/access/GCP/eligibility" },
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{"response": [{"workspaceId": "gcp-001", "workspaceName": "GCP Project", "workspaceType": "PROJECT"}], "total": 1}`,
+		},
 		{
 			Matcher: func(r *http.Request) bool {
 				return r.URL.Path == "/api/access/AZURE/eligibility" && r.URL.Query().Get("nextToken") == "azure-page-2"
@@ -235,9 +240,10 @@ func TestListTargets_AllFlag_AggregatesAWSAndAzure(t *testing.T) {
 
 	require.NoError(t, err)
 	require.NotNil(t, resp)
-	require.Equal(t, 4, resp.Total)
+	require.Equal(t, 5, resp.Total)
 	require.Empty(t, resp.Response)
-	require.Len(t, resp.Responses, 2)
+	require.Len(t, resp.Responses, 3)
+	require.Len(t, resp.Responses["gcp"].Response, 1)
 	require.Len(t, resp.Responses["aws"].Response, 2)
 	require.Equal(t, 2, resp.Responses["aws"].Total)
 	require.Len(t, resp.Responses["azure"].Response, 2)
@@ -309,6 +315,11 @@ func TestListTargets_EmptyCSP_PartialSuccessReturnsErrorsInResponse(t *testing.T
 					StatusCode:   tt.azureStatus,
 					ResponseBody: tt.azureResponseBody,
 				},
+				{
+					Matcher:      func(r *http.Request) bool { return r.URL.Path == "/api/access/GCP/eligibility" },
+					StatusCode:   http.StatusOK,
+					ResponseBody: `{"response": [{"workspaceId": "gcp-001", "workspaceName": "GCP Project", "workspaceType": "PROJECT"}], "total": 1}`,
+				},
 			})
 			defer cleanup()
 
@@ -317,9 +328,10 @@ func TestListTargets_EmptyCSP_PartialSuccessReturnsErrorsInResponse(t *testing.T
 
 			require.NoError(t, err)
 			require.NotNil(t, resp)
-			require.Equal(t, 1, resp.Total)
+			require.Equal(t, 2, resp.Total)
 			require.Empty(t, resp.Response)
-			require.Len(t, resp.Responses, 1)
+			require.Len(t, resp.Responses, 2)
+			require.Len(t, resp.Responses["gcp"].Response, 1)
 			require.Len(t, resp.Responses[tt.expectedSuccessCSP].Response, 1)
 			require.Equal(t, tt.expectedWorkspaceID, resp.Responses[tt.expectedSuccessCSP].Response[0].WorkspaceID)
 			require.Len(t, resp.Errors, 1)
@@ -354,9 +366,11 @@ func TestListTargets_EmptyCSP_AllCSPsFailReturnsErrorsInResponse(t *testing.T) {
 	require.Empty(t, resp.Response)
 	require.Empty(t, resp.Responses)
 	require.Equal(t, 0, resp.Total)
-	require.Len(t, resp.Errors, 2)
+	require.Len(t, resp.Errors, 3)
 	require.Contains(t, resp.Errors["aws"], "API call failed: ")
 	require.Contains(t, resp.Errors["aws"], "500")
+	require.Contains(t, resp.Errors["gcp"], "API call failed: ")
+	require.Contains(t, resp.Errors["gcp"], "500")
 	require.Contains(t, resp.Errors["azure"], "API call failed: ")
 	require.Contains(t, resp.Errors["azure"], "500")
 }
@@ -643,14 +657,14 @@ func TestElevate_validation_empty_csp(t *testing.T) {
 
 func TestElevate_validation_unsupported_csp(t *testing.T) {
 	svc := &IdsecSCACloudAccessService{}
-	for _, csp := range []string{"GCP", "ibm", "oracle"} {
+	for _, csp := range []string{"ibm", "oracle"} {
 		_, err := svc.Elevate(&cloudaccessmodels.IdsecSCACloudAccessElevateActionRequest{
 			CSP:         csp,
 			WorkspaceID: "ws-1",
 			RoleIDs:     "role-1",
 		})
 		require.Error(t, err)
-		require.Contains(t, err.Error(), "Supported providers are: AWS, AZURE")
+		require.Contains(t, err.Error(), "Supported providers are: AWS, AZURE, GCP")
 	}
 }
 
@@ -989,6 +1003,53 @@ func TestElevate_ExceedsMaxRoleIDs_AZURE(t *testing.T) {
 	require.Contains(t, err.Error(), "maximum 5 role IDs allowed for AZURE")
 }
 
+func TestElevate_Success_GCP(t *testing.T) {
+	var capturedPath string
+	var capturedBody []byte
+	client, cleanup := scainternal.SetupMockSCAService(t, []scainternal.MockEndpointConfig{
+		{
+			Matcher:      func(r *http.Request) bool { return r.Method == http.MethodPost },
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{"response":{"organizationId":"123456789","csp":"GCP","results":[{"workspaceId":"my-project","roleId":"roles/viewer","sessionId":"gcp-session"}]}}`,
+			OnRequest: func(r *http.Request) {
+				capturedPath = r.URL.Path
+				capturedBody = make([]byte, r.ContentLength)
+				_, _ = r.Body.Read(capturedBody)
+			},
+		},
+	})
+	defer cleanup()
+
+	svc := setupCloudAccessService(client)
+	resp, err := svc.Elevate(&cloudaccessmodels.IdsecSCACloudAccessElevateActionRequest{
+		CSP:            "gcp",
+		WorkspaceID:    "my-project",
+		RoleIDs:        "roles/viewer,roles/storage.admin",
+		OrganizationID: "123456789",
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "GCP", resp.Response.CSP)
+	require.Len(t, resp.Response.Results, 1)
+	require.Equal(t, "gcp-session", resp.Response.Results[0].SessionID)
+	require.Equal(t, "/api/access/elevate", capturedPath)
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(capturedBody, &body))
+	require.Equal(t, "GCP", body["csp"])
+	require.Len(t, body["targets"], 2)
+}
+
+func TestElevate_ExceedsMaxRoleIDs_GCP(t *testing.T) {
+	svc := &IdsecSCACloudAccessService{}
+	_, err := svc.Elevate(&cloudaccessmodels.IdsecSCACloudAccessElevateActionRequest{
+		CSP:         "GCP",
+		WorkspaceID: "my-project",
+		RoleIDs:     "r1,r2,r3,r4,r5,r6",
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "maximum 5 role IDs allowed for GCP")
+}
+
 func TestElevate_RequestBody_WithOrganizationID(t *testing.T) {
 	var capturedBody []byte
 	client, cleanup := scainternal.SetupMockSCAService(t, []scainternal.MockEndpointConfig{
@@ -0,0 +1,59 @@
+package models
+
+// IdsecSCACloudAccessAWSAccessCredentials holds the short-lived AWS STS credentials
+// returned inside the JSON-encoded AccessCredentials string of an elevate result.
+type IdsecSCACloudAccessAWSAccessCredentials struct {
+	AWSAccessKey       string `json:"aws_access_key" mapstructure:"aws_access_key"`
+	AWSSecretAccessKey string `json:"aws_secret_access_key" mapstructure:"aws_secret_access_key"`
+	AWSSessionToken    string `json:"aws_session_token" mapstructure:"aws_session_token"`
+}
+
+// IdsecSCACloudAccessAWSCredentialsRequest is the CLI schema for
+// `idsec exec sca cloudaccess aws-credential-process`.
+//
+// The Expiration of the credential_process payload and the TTL of the cached session are
+// taken from the session expiry returned by the Elevate API. FallbackLifetime is the
+// lifetime, in minutes, assumed for the credentials only when the response has no expiry.
+// It does not change the duration of the elevated session.
+type IdsecSCACloudAccessAWSCredentialsRequest struct {
+	WorkspaceID     string `json:"workspace_id" mapstructure:"workspace_id" validate:"required" flag:"workspace-id" desc:"The ID of the AWS account to elevate to"`
+	RoleID          string `json:"role_id" mapstructure:"role_id" validate:"required" flag:"role-id" desc:"The ID of the role to elevate with"`
+	OrganizationID  string `json:"organization_id,omitempty" mapstructure:"organization_id" flag:"organization-id" desc:"The ID of the AWS organization. Required for AWS org accounts."`
+	FallbackLifetime int    `json:"fallback_lifetime,omitempty" mapstructure:"fallback_lifetime" flag:"fallback-lifetime" desc:"The lifetime in minutes assumed for the credentials when the elevate response has no session expiry" default:"15"`
+	ForceRefresh     bool   `json:"force_refresh,omitempty" mapstructure:"force_refresh" flag:"force-refresh" desc:"Elevate again even if a cached session is still valid"`
+}
+
+// IdsecSCACloudAccessAWSCredentialProcessOutput is the payload the AWS CLI and SDKs expect
+// on stdout from a `credential_process` command.
+//
+// See https://docs.aws.amazon.com/cli/latest/userguide/cli-configure-sourcing-external.html
+type IdsecSCACloudAccessAWSCredentialProcessOutput struct {
+	Version         int    `json:"Version" mapstructure:"Version"`
+	AccessKeyID     string `json:"AccessKeyId" mapstructure:"AccessKeyId"`
+	SecretAccessKey string `json:"SecretAccessKey" mapstructure:"SecretAccessKey"`
+	SessionToken    string `json:"SessionToken,omitempty" mapstructure:"SessionToken"`
+	Expiration      string `json:"Expiration,omitempty" mapstructure:"Expiration"`
+}
+
+// IdsecSCACloudAccessWriteAWSProfileRequest is the CLI schema for
+// `idsec exec sca cloudaccess write-aws-profile`.
+//
+// By default the elevated credentials are written as static keys to the credentials
+// file. When CredentialProcess is set, the profile is instead written to the config
+// file with a credential_process entry, so the AWS CLI re-elevates on demand.
+type IdsecSCACloudAccessWriteAWSProfileRequest struct {
+	IdsecSCACloudAccessAWSCredentialsRequest `mapstructure:",squash"`
+	ProfileName                              string `json:"profile_name" mapstructure:"profile_name" validate:"required" flag:"profile-name" desc:"The name of the AWS profile to write"`
+	Region                                   string `json:"region,omitempty" mapstructure:"region" flag:"region" desc:"The default AWS region to set on the profile"`
+	CredentialsFile                          string `json:"credentials_file,omitempty" mapstructure:"credentials_file" flag:"credentials-file" desc:"The AWS shared credentials file to update" default:"~/.aws/credentials"`
+	ConfigFile                               string `json:"config_file,omitempty" mapstructure:"config_file" flag:"config-file" desc:"The AWS shared config file to update" default:"~/.aws/config"`
+	CredentialProcess                        string `json:"credential_process,omitempty" mapstructure:"credential_process" flag:"credential-process" desc:"A credential_process command to write instead of static credentials"`
+}
+
+// IdsecSCACloudAccessAWSProfile describes an AWS profile written by write-aws-profile.
+type IdsecSCACloudAccessAWSProfile struct {
+	ProfileName     string `json:"profile_name" mapstructure:"profile_name" desc:"The name of the written AWS profile"`
+	CredentialsFile string `json:"credentials_file,omitempty" mapstructure:"credentials_file" desc:"The credentials file holding the profile keys"`
+	ConfigFile      string `json:"config_file" mapstructure:"config_file" desc:"The config file holding the profile settings"`
+	Expiration      string `json:"expiration,omitempty" mapstructure:"expiration" desc:"The expiration of the written credentials"`
+}
@@ -15,6 +15,8 @@ import (
 	"github.com/cyberark/idsec-sdk-golang/pkg/common"
 	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
 	discoverymodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/discovery/models"
+	scacommon "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/internal/common"
+	scamodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/models"
 )
 
 const (
@@ -280,8 +282,7 @@ func (s *IdsecSCADiscoveryService) Discovery(req *discoverymodels.IdsecSCADiscov
 	if req.CSP == "" {
 		return nil, fmt.Errorf("csp cannot be empty")
 	}
-	supported := map[string]struct{}{"AWS": {}, "AZURE": {}, "GCP": {}}
-	if _, ok := supported[strings.ToUpper(req.CSP)]; !ok {
+	if !scacommon.IsSupportedCSP(req.CSP, scamodels.CSPAWS, scamodels.CSPAzure, scamodels.CSPGCP) {
 		return nil, fmt.Errorf("unsupported csp '%s'", req.CSP)
 	}
 	if req.OrganizationID == "" {
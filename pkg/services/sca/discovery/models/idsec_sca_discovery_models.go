@@ -20,7 +20,7 @@ type IdsecSCADiscoveryResponse struct {
 //   - ID: Workspace / Account identifier (required).
 //   - NewAccount: Flag indicating new account onboarding.
 type IdsecSCADiscoveryAccountInfo struct {
-	ID         string `json:"id" mapstructure:"id" validate:"required" flag:"id" desc:"The ID of the workspace to discover (AWS - AWS account ID | Azure - Management group, subscription, or resource group ID | GCP - Google Cloud project ID )"`
+	ID         string `json:"id" mapstructure:"id" validate:"required" flag:"id" desc:"The ID of the workspace to discover (AWS - AWS account ID | Azure - Management group, subscription, or resource group ID | GCP - Google Cloud organization, folder or project ID)"`
 	NewAccount bool   `json:"new_account" mapstructure:"new_account" flag:"new-account" default:"false" desc:"Indicates whether the account is new to an already onboarded organization and needs to be discovered (e.g., a new AWS account in an already onboarded AWS organization; a new GCP project in an already onboarded Google Cloud organization; a new management group/subscription in an already onboarded Microsoft Entra ID tenant). Defaults to false."`
 }
 
@@ -501,6 +501,13 @@ func TestGetTokenProvider_AZURE(t *testing.T) {
 	require.Equal(t, "AZURE", p.CSP())
 }
 
+func TestGetTokenProvider_GCP(t *testing.T) {
+	p, err := GetTokenProvider("gcp")
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	require.Equal(t, "GCP", p.CSP())
+}
+
 func TestGetTokenProvider_Unsupported(t *testing.T) {
 	_, err := GetTokenProvider("ibm")
 	require.Error(t, err)
@@ -0,0 +1,197 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	k8smodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/k8s/models"
+)
+
+const (
+	gkeExecCredAPI = "client.authentication.k8s.io/v1beta1"
+
+	acquireGKETokenTimeout   = 30 * time.Second
+	gkeExecCredRefreshBuffer = 60 * time.Second // subtract from token_expiry for ExecCredential.expirationTimestamp
+)
+
+// gcloudConfigHelperOutput is the subset of `gcloud config config-helper --format=json` used for GKE tokens.
+type gcloudConfigHelperOutput struct {
+	Configuration struct {
+		Properties struct {
+			Core struct {
+				Account string `json:"account"`
+				Project string `json:"project"`
+			} `json:"core"`
+		} `json:"properties"`
+	} `json:"configuration"`
+	Credential struct {
+		AccessToken string `json:"access_token"`
+		TokenExpiry string `json:"token_expiry"`
+	} `json:"credential"`
+}
+
+// gcloudAccessToken is a GKE access token obtained from the local gcloud session.
+type gcloudAccessToken struct {
+	Token   string
+	Expiry  time.Time
+	Account string
+}
+
+// runGcloudConfigHelper and runGcloudLogin are package vars so tests can stub the gcloud CLI.
+var (
+	runGcloudConfigHelper = defaultRunGcloudConfigHelper
+	runGcloudLogin        = defaultRunGcloudLogin
+)
+
+// GCPTokenProvider: GKE token via local gcloud session; validates gcloud account vs idsec Elevate JWT (claim match only).
+type GCPTokenProvider struct{}
+
+func (p *GCPTokenProvider) CSP() string { return k8smodels.CSPGCP }
+
+// GenerateToken returns a GKE ExecCredential after EnsureGcloudSession (diagnostics gated by ctx.Diagnostics).
+func (p *GCPTokenProvider) GenerateToken(
+	result *k8smodels.IdsecSCAK8sElevateResult,
+	ctx *IdsecSCAK8sClusterContext,
+) (*k8smodels.IdsecSCAK8sExecCredential, error) {
+	if result == nil {
+		return nil, fmt.Errorf("elevate result cannot be nil")
+	}
+	if ctx == nil {
+		return nil, fmt.Errorf("cluster context cannot be nil")
+	}
+
+	token, err := EnsureGcloudSession(ctx.ElevateToken, ctx.Diagnostics)
+	if err != nil {
+		return nil, err
+	}
+	return BuildGCPExecCredential(token.Token, token.Expiry), nil
+}
+
+// EnsureGcloudSession obtains a GKE access token via gcloud; runs gcloud auth login when no usable session exists.
+// When elevateToken is set, validates the gcloud account vs idsec JWT. diagnostics gates stderr logs.
+func EnsureGcloudSession(elevateToken string, diagnostics bool) (*gcloudAccessToken, error) {
+	token, err := acquireGKEToken()
+	if err != nil {
+		if diagnostics {
+			KubectlLoginLog(KubectlLoginLogLevelInfo, "No usable gcloud session found. Running 'gcloud auth login'...")
+		}
+		if loginErr := runGcloudLogin(); loginErr != nil {
+			return nil, fmt.Errorf("gcloud auth login failed: %w", loginErr)
+		}
+		token, err = acquireGKEToken()
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire GKE token after gcloud auth login: %w", err)
+		}
+	}
+
+	if strings.TrimSpace(elevateToken) != "" {
+		if err := validateGcloudIdentity(elevateToken, token.Account); err != nil {
+			return nil, err
+		}
+	}
+	return token, nil
+}
+
+// BuildGCPExecCredential builds client.authentication.k8s.io/v1beta1 ExecCredential; expirationTimestamp = expiry − gkeExecCredRefreshBuffer when expiry is set.
+func BuildGCPExecCredential(accessToken string, expiry time.Time) *k8smodels.IdsecSCAK8sExecCredential {
+	cred := &k8smodels.IdsecSCAK8sExecCredential{
+		APIVersion: gkeExecCredAPI,
+		Kind:       "ExecCredential",
+		Status: k8smodels.IdsecSCAK8sExecCredentialStatus{
+			Token: accessToken,
+		},
+	}
+	if !expiry.IsZero() {
+		cred.Status.ExpirationTimestamp = expiry.Add(-gkeExecCredRefreshBuffer).UTC().Format(time.RFC3339)
+	}
+	return cred
+}
+
+// GKEClusterFromTargetID splits an Elevate targetId of the form
+// "[//container.googleapis.com/]projects/<project>/locations/<location>/clusters/<cluster>".
+func GKEClusterFromTargetID(targetID string) (project, location, cluster string, err error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(targetID), "//container.googleapis.com/")
+	parts := strings.Split(strings.Trim(trimmed, "/"), "/")
+	if len(parts) != 6 || parts[0] != "projects" || parts[2] != "locations" || parts[4] != "clusters" {
+		return "", "", "", fmt.Errorf("invalid GKE cluster target id %q", targetID)
+	}
+	if parts[1] == "" || parts[3] == "" || parts[5] == "" {
+		return "", "", "", fmt.Errorf("invalid GKE cluster target id %q", targetID)
+	}
+	return parts[1], parts[3], parts[5], nil
+}
+
+// acquireGKEToken reads the current gcloud access token (refreshed by gcloud when needed).
+func acquireGKEToken() (*gcloudAccessToken, error) {
+	out, err := runGcloudConfigHelper()
+	if err != nil {
+		return nil, fmt.Errorf("get GKE token via gcloud: %w", err)
+	}
+	var helper gcloudConfigHelperOutput
+	if err := json.Unmarshal(out, &helper); err != nil {
+		return nil, fmt.Errorf("failed to parse gcloud config-helper output: %w", err)
+	}
+	if strings.TrimSpace(helper.Credential.AccessToken) == "" {
+		return nil, fmt.Errorf("gcloud config-helper returned no access token")
+	}
+	token := &gcloudAccessToken{
+		Token:   helper.Credential.AccessToken,
+		Account: strings.TrimSpace(helper.Configuration.Properties.Core.Account),
+	}
+	if helper.Credential.TokenExpiry != "" {
+		if expiry, err := time.Parse(time.RFC3339, helper.Credential.TokenExpiry); err == nil {
+			token.Expiry = expiry.UTC()
+		}
+	}
+	return token, nil
+}
+
+func defaultRunGcloudConfigHelper() ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), acquireGKETokenTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "gcloud", "config", "config-helper", "--format=json")
+	cmd.Stderr = io.Discard
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("'gcloud config config-helper' exited with error: %w", err)
+	}
+	return out, nil
+}
+
+// defaultRunGcloudLogin: stdout discarded so parent stdout stays clean for kubectl ExecCredential JSON.
+func defaultRunGcloudLogin() error {
+	cmd := exec.Command("gcloud", "auth", "login")
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = io.Discard
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("'gcloud auth login' exited with error: %w", err)
+	}
+	return nil
+}
+
+// validateGcloudIdentity: unverified JWT claim match of the idsec user against the active gcloud account.
+func validateGcloudIdentity(elevateToken, gcloudAccount string) error {
+	if gcloudAccount == "" {
+		return fmt.Errorf("gcloud session has no active account; run 'gcloud auth login'")
+	}
+	claims, err := parseJWTMapClaims(elevateToken)
+	if err != nil {
+		return fmt.Errorf("failed to extract identity from Elevate API token: %w", err)
+	}
+	for _, key := range []string{"email", "preferred_username", "unique_name", "upn"} {
+		if value := firstStringClaim(claims, key); value != "" && strings.EqualFold(value, gcloudAccount) {
+			return nil
+		}
+	}
+	return fmt.Errorf(
+		"gcloud account does not match the idsec elevated user; " +
+			"run 'gcloud auth login' with the same account you used for 'idsec login'",
+	)
+}
@@ -0,0 +1,118 @@
+package k8s
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	k8smodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/k8s/models"
+)
+
+// stubGcloud replaces the gcloud CLI runners for the duration of the test.
+func stubGcloud(t *testing.T, configHelper func() ([]byte, error), login func() error) {
+	t.Helper()
+	origHelper, origLogin := runGcloudConfigHelper, runGcloudLogin
+	runGcloudConfigHelper, runGcloudLogin = configHelper, login
+	t.Cleanup(func() {
+		runGcloudConfigHelper, runGcloudLogin = origHelper, origLogin
+	})
+}
+
+const testGcloudConfigHelper = `{
+  "configuration": {"properties": {"core": {"account": "alice@example.com", "project": "my-project"}}},
+  "credential": {"access_token": "ya29.token", "token_expiry": "2099-01-01T00:00:00Z"}
+}`
+
+func TestGCPTokenProvider_GenerateToken_Success(t *testing.T) {
+	stubGcloud(t, func() ([]byte, error) { return []byte(testGcloudConfigHelper), nil }, func() error {
+		t.Fatal("gcloud auth login should not run with an active session")
+		return nil
+	})
+
+	p := &GCPTokenProvider{}
+	cred, err := p.GenerateToken(&k8smodels.IdsecSCAK8sElevateResult{}, &IdsecSCAK8sClusterContext{
+		CSP:          "GCP",
+		ElevateToken: testUnsignedJWT(map[string]string{"email": "Alice@Example.com"}),
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, gkeExecCredAPI, cred.APIVersion)
+	require.Equal(t, "ExecCredential", cred.Kind)
+	require.Equal(t, "ya29.token", cred.Status.Token)
+	require.Equal(t, "2098-12-31T23:59:00Z", cred.Status.ExpirationTimestamp)
+}
+
+func TestGCPTokenProvider_GenerateToken_LogsInWhenNoSession(t *testing.T) {
+	loggedIn := false
+	stubGcloud(t, func() ([]byte, error) {
+		if !loggedIn {
+			return nil, fmt.Errorf("no credentialed accounts")
+		}
+		return []byte(testGcloudConfigHelper), nil
+	}, func() error {
+		loggedIn = true
+		return nil
+	})
+
+	p := &GCPTokenProvider{}
+	cred, err := p.GenerateToken(&k8smodels.IdsecSCAK8sElevateResult{}, &IdsecSCAK8sClusterContext{CSP: "GCP"})
+
+	require.NoError(t, err)
+	require.True(t, loggedIn)
+	require.Equal(t, "ya29.token", cred.Status.Token)
+}
+
+func TestGCPTokenProvider_GenerateToken_AccountMismatch(t *testing.T) {
+	stubGcloud(t, func() ([]byte, error) { return []byte(testGcloudConfigHelper), nil }, func() error { return nil })
+
+	p := &GCPTokenProvider{}
+	_, err := p.GenerateToken(&k8smodels.IdsecSCAK8sElevateResult{}, &IdsecSCAK8sClusterContext{
+		CSP:          "GCP",
+		ElevateToken: testUnsignedJWT(map[string]string{"unique_name": "bob@example.com"}),
+	})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "gcloud account does not match")
+}
+
+func TestGCPTokenProvider_GenerateToken_NilInputs(t *testing.T) {
+	p := &GCPTokenProvider{}
+	_, err := p.GenerateToken(nil, &IdsecSCAK8sClusterContext{CSP: "GCP"})
+	require.ErrorContains(t, err, "elevate result cannot be nil")
+	_, err = p.GenerateToken(&k8smodels.IdsecSCAK8sElevateResult{}, nil)
+	require.ErrorContains(t, err, "cluster context cannot be nil")
+}
+
+func TestBuildGCPExecCredential_NoExpiry(t *testing.T) {
+	cred := BuildGCPExecCredential("token", time.Time{})
+	require.Equal(t, "token", cred.Status.Token)
+	require.Empty(t, cred.Status.ExpirationTimestamp)
+}
+
+func TestGKEClusterFromTargetID(t *testing.T) {
+	tests := []struct {
+		name      string
+		targetID  string
+		expectErr bool
+	}{
+		{name: "relative", targetID: "projects/my-project/locations/us-central1/clusters/prod"},
+		{name: "full_resource_name", targetID: "//container.googleapis.com/projects/my-project/locations/us-central1/clusters/prod"},
+		{name: "empty", targetID: "", expectErr: true},
+		{name: "missing_cluster", targetID: "projects/my-project/locations/us-central1", expectErr: true},
+		{name: "aws_arn", targetID: "arn:aws:eks:us-east-1:123:cluster/prod", expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project, location, cluster, err := GKEClusterFromTargetID(tt.targetID)
+			if tt.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "my-project", project)
+			require.Equal(t, "us-central1", location)
+			require.Equal(t, "prod", cluster)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	jose "github.com/go-jose/go-jose/v4"
+	commonk8s "github.com/cyberark/idsec-sdk-golang/pkg/common/k8s"
 	k8smodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/k8s/models"
 )
 
@@ -175,30 +176,12 @@ func parseDpaSsoExpiresAt(meta *k8smodels.IdsecSCAK8sDpaSsoAcquireMetadata) (tim
 // proxyExecCredRefreshBuffer before passing the DPA metadata.expires_at in, and the cache
 // fast path replays the already-buffered value it stored.
 func BuildProxyExecCredential(certPEM, keyPEM string, expirationTimestamp time.Time) *k8smodels.IdsecSCAK8sExecCredential {
-	cred := &k8smodels.IdsecSCAK8sExecCredential{
-		APIVersion: proxyExecCredAPI,
-		Kind:       "ExecCredential",
-		Status: k8smodels.IdsecSCAK8sExecCredentialStatus{
-			ClientCertificateData: certPEM,
-			ClientKeyData:         keyPEM,
-		},
-	}
-	if !expirationTimestamp.IsZero() {
-		cred.Status.ExpirationTimestamp = expirationTimestamp.UTC().Format(time.RFC3339)
-	}
-	return cred
+	return commonk8s.BuildClientCertificateExecCredential(proxyExecCredAPI, certPEM, keyPEM, expirationTimestamp)
 }
 
 // ProxyExecCredentialExpiresAt parses status.expirationTimestamp (written by
 // BuildProxyExecCredential) back into a time.Time. It is the exact inverse of the
 // formatting above — no buffer arithmetic is applied.
 func ProxyExecCredentialExpiresAt(cred *k8smodels.IdsecSCAK8sExecCredential) (time.Time, error) {
-	if cred == nil || strings.TrimSpace(cred.Status.ExpirationTimestamp) == "" {
-		return time.Time{}, fmt.Errorf("ExecCredential missing expirationTimestamp")
-	}
-	t, err := time.Parse(time.RFC3339, cred.Status.ExpirationTimestamp)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("failed to parse expirationTimestamp: %w", err)
-	}
-	return t.UTC(), nil
+	return commonk8s.ExecCredentialExpiresAt(cred)
 }
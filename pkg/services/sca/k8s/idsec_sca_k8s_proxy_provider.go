@@ -40,12 +40,16 @@ func GetProxyProvider(csp string) (IdsecSCAK8sProxyProvider, error) {
 		// Azure AKS proxy encrypts ctx.K8sToken (AKS token) and ctx.RootCA
 		// as JWE (k8s_token + root_ca) for DPA proxy→cluster mTLS.
 		return &dpaProxyProvider{csp: k8smodels.CSPAzure, requireJWE: true}, nil
+	case k8smodels.CSPGCP:
+		// GCP GKE proxy encrypts ctx.K8sToken (gcloud access token) and ctx.RootCA
+		// as JWE (k8s_token + root_ca), same as Azure AKS.
+		return &dpaProxyProvider{csp: k8smodels.CSPGCP, requireJWE: true}, nil
 	default:
 		return nil, fmt.Errorf("unsupported CSP for kubectl-login proxy flow: %q", csp)
 	}
 }
 
-// dpaProxyProvider implements IdsecSCAK8sProxyProvider for AWS EKS and Azure AKS
+// dpaProxyProvider implements IdsecSCAK8sProxyProvider for AWS EKS, Azure AKS and GCP GKE
 // clusters reached via the DPA proxy connection method.
 type dpaProxyProvider struct {
 	csp        string
@@ -17,6 +17,7 @@ func TestGetProxyProvider(t *testing.T) {
 		{name: "success_aws_lower", csp: "aws", expectedCSP: "AWS"},
 		{name: "success_aws_padded", csp: "  aws  ", expectedCSP: "AWS"},
 		{name: "success_azure", csp: "azure", expectedCSP: "AZURE"},
+		{name: "success_gcp", csp: "gcp", expectedCSP: "GCP"},
 		{name: "error_empty", csp: "", expectErr: true},
 		{name: "error_unknown", csp: "ibm", expectErr: true},
 	}
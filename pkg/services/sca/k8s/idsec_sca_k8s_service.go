@@ -48,6 +48,7 @@ const (
 var SupportedCSPs = []string{
 	strings.ToLower(k8smodels.CSPAWS),
 	strings.ToLower(k8smodels.CSPAzure),
+	strings.ToLower(k8smodels.CSPGCP),
 }
 
 // IdsecSCAK8sService provides SCA Kubernetes cluster discovery capabilities.
@@ -113,7 +114,7 @@ func (s *IdsecSCAK8sService) refreshScaAuth(client *common.IdsecClient) error {
 }
 
 // ListTargets lists clusters eligible for SCA discovery via the eligibility API.
-// req accepts optional CSP (aws/azure, any case); when omitted, AWS and AZURE are queried.
+// req accepts optional CSP (aws/azure/gcp, any case); when omitted, AWS, AZURE and GCP are queried.
 // workspaceId, limit (1-50), and nextToken are optional.
 func (s *IdsecSCAK8sService) ListTargets(req *k8smodels.IdsecSCAk8sListClustersRequest) (*k8smodels.IdsecSCAk8sListClustersResponse, error) {
 	if req == nil {
@@ -125,8 +126,8 @@ func (s *IdsecSCAK8sService) ListTargets(req *k8smodels.IdsecSCAk8sListClustersR
 
 	csp := strings.TrimSpace(req.CSP)
 	cspLower := strings.ToLower(csp)
-	if cspLower != "" && !scacommon.IsSupportedCSP(csp, k8smodels.CSPAWS, k8smodels.CSPAzure) {
-		return nil, scacommon.ErrUnsupportedCSP(csp, k8smodels.CSPAWS, k8smodels.CSPAzure)
+	if cspLower != "" && !scacommon.IsSupportedCSP(csp, k8smodels.CSPAWS, k8smodels.CSPAzure, k8smodels.CSPGCP) {
+		return nil, scacommon.ErrUnsupportedCSP(csp, k8smodels.CSPAWS, k8smodels.CSPAzure, k8smodels.CSPGCP)
 	}
 	if req.All && cspLower != "" {
 		return nil, scacommon.ErrCSPAllConflict()
@@ -243,7 +244,7 @@ func (s *IdsecSCAK8sService) listTargetsForCSP(req *k8smodels.IdsecSCAk8sListClu
 // Parameters:
 //   - req: *IdsecSCAK8sEvaluateRequest with at least one target. Each target must
 //     provide either FQDN or Name.
-//   - csp: Cloud service provider (AWS, AZURE). Case-insensitive.
+//   - csp: Cloud service provider (AWS, AZURE, GCP). Case-insensitive.
 //
 // Returns *IdsecSCAK8sEvaluateResponse on success or an error when:
 //   - req is nil, csp is empty, or targets have neither FQDN nor Name
@@ -260,6 +261,7 @@ func (s *IdsecSCAK8sService) EvaluateEligibility(req *k8smodels.IdsecSCAK8sEvalu
 	supported := map[string]struct{}{
 		strings.ToLower(k8smodels.CSPAWS):   {},
 		strings.ToLower(k8smodels.CSPAzure): {},
+		strings.ToLower(k8smodels.CSPGCP):   {},
 	}
 	if _, ok := supported[strings.ToLower(csp)]; !ok {
 		return nil, fmt.Errorf("unsupported csp '%s'", csp)
@@ -381,7 +383,7 @@ func (s *IdsecSCAK8sService) Elevate(req *k8smodels.IdsecSCAK8sElevateKubectlReq
 // connection method, dispatching to the CSP-specific proxy provider.
 //
 // Parameters:
-//   - csp: Cloud service provider (AWS, AZURE). Case-insensitive.
+//   - csp: Cloud service provider (AWS, AZURE, GCP). Case-insensitive.
 //   - ctx: Optional cluster context (CSP, FQDN, role identifiers, region, etc.)
 //     for providers that need cluster-specific inputs. May be nil for CSPs that
 //     do not need it (currently AWS).
@@ -729,8 +731,8 @@ func (s *IdsecSCAK8sService) parseGenerateKubeconfigBody(bodyBytes []byte, csp s
 
 // validateSupportedCSP returns an error if csp is not one of the supported CSP names (case-insensitive).
 func validateSupportedCSP(csp string) error {
-	if !scacommon.IsSupportedCSP(csp, k8smodels.CSPAWS, k8smodels.CSPAzure) {
-		return scacommon.ErrUnsupportedCSP(csp, k8smodels.CSPAWS, k8smodels.CSPAzure)
+	if !scacommon.IsSupportedCSP(csp, k8smodels.CSPAWS, k8smodels.CSPAzure, k8smodels.CSPGCP) {
+		return scacommon.ErrUnsupportedCSP(csp, k8smodels.CSPAWS, k8smodels.CSPAzure, k8smodels.CSPGCP)
 	}
 	return nil
 }
@@ -44,7 +44,7 @@ func setupK8sListTargetsService(client *isp.IdsecISPServiceClient) *IdsecSCAK8sS
 }
 
 func TestSupportedCSPsForKubeconfigGeneration(t *testing.T) {
-	require.ElementsMatch(t, []string{"aws", "azure"}, SupportedCSPs)
+	require.ElementsMatch(t, []string{"aws", "azure", "gcp"}, SupportedCSPs)
 }
 
 func TestDPAGenerateKubeconfigCSPSegment(t *testing.T) {
@@ -210,7 +210,7 @@ func TestListTargetsResponse_EmptyResponse(t *testing.T) {
 	require.Equal(t, 0, result.Total)
 }
 
-func TestListTargets_AllFlag_AggregatesAWSAndAzure(t *testing.T) {
+func TestListTargets_AllFlag_AggregatesAllCSPs(t *testing.T) {
 	var capturedPaths []string
 	var capturedQueries []string
 	client, cleanup := scainternal.SetupMockSCAService(t, []scainternal.MockEndpointConfig{
@@ -258,6 +258,11 @@ func TestListTargets_AllFlag_AggregatesAWSAndAzure(t *testing.T) {
 				capturedQueries = append(capturedQueries, r.URL.RawQuery)
 			},
 		},
+		{
+			Matcher:      func(r *http.Request) bool { return r.URL.Path == "/access/GCP/eligibility/clusters" },
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{"response": [{"workspaceId": "gcp-001", "workspaceName": "GCP Project", "workspaceType": "project"}], "total": 1}`,
+		},
 		{
 			Matcher: func(r *http.Request) bool {
 				return r.URL.Path == "/access/AZURE/eligibility/clusters" && r.URL.Query().Get("nextToken") == "azure-page-2"
@@ -280,9 +285,10 @@ func TestListTargets_AllFlag_AggregatesAWSAndAzure(t *testing.T) {
 
 	require.NoError(t, err)
 	require.NotNil(t, resp)
-	require.Equal(t, 4, resp.Total)
+	require.Equal(t, 5, resp.Total)
 	require.Empty(t, resp.Response)
-	require.Len(t, resp.Responses, 2)
+	require.Len(t, resp.Responses, 3)
+	require.Len(t, resp.Responses["gcp"].Response, 1)
 	require.Len(t, resp.Responses["aws"].Response, 2)
 	require.Equal(t, 2, resp.Responses["aws"].Total)
 	require.Len(t, resp.Responses["azure"].Response, 2)
@@ -354,6 +360,11 @@ func TestListTargets_EmptyCSP_PartialSuccessReturnsErrorsInResponse(t *testing.T
 					StatusCode:   tt.azureStatus,
 					ResponseBody: tt.azureResponseBody,
 				},
+				{
+					Matcher:      func(r *http.Request) bool { return r.URL.Path == "/access/GCP/eligibility/clusters" },
+					StatusCode:   http.StatusOK,
+					ResponseBody: `{"response": [{"workspaceId": "gcp-001", "workspaceName": "GCP Project", "workspaceType": "project"}], "total": 1}`,
+				},
 			})
 			defer cleanup()
 
@@ -362,9 +373,10 @@ func TestListTargets_EmptyCSP_PartialSuccessReturnsErrorsInResponse(t *testing.T
 
 			require.NoError(t, err)
 			require.NotNil(t, resp)
-			require.Equal(t, 1, resp.Total)
+			require.Equal(t, 2, resp.Total)
 			require.Empty(t, resp.Response)
-			require.Len(t, resp.Responses, 1)
+			require.Len(t, resp.Responses, 2)
+			require.Contains(t, resp.Responses, "gcp")
 			require.Len(t, resp.Responses[tt.expectedSuccessCSP].Response, 1)
 			require.Equal(t, tt.expectedWorkspaceID, resp.Responses[tt.expectedSuccessCSP].Response[0].WorkspaceID)
 			require.Len(t, resp.Errors, 1)
@@ -399,11 +411,13 @@ func TestListTargets_EmptyCSP_AllCSPsFailReturnsErrorsInResponse(t *testing.T) {
 	require.Empty(t, resp.Response)
 	require.Empty(t, resp.Responses)
 	require.Equal(t, 0, resp.Total)
-	require.Len(t, resp.Errors, 2)
+	require.Len(t, resp.Errors, 3)
 	require.Contains(t, resp.Errors["aws"], "API call failed: ")
 	require.Contains(t, resp.Errors["aws"], "500")
 	require.Contains(t, resp.Errors["azure"], "API call failed: ")
 	require.Contains(t, resp.Errors["azure"], "500")
+	require.Contains(t, resp.Errors["gcp"], "API call failed: ")
+	require.Contains(t, resp.Errors["gcp"], "500")
 }
 
 // --- Positive test: response with multiple items decodes correctly ---
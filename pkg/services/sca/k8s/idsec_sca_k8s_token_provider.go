@@ -11,7 +11,7 @@ import (
 // provider needs. All fields are sourced from CLI flags and/or derived from the
 // Elevate API response — no kubeconfig file I/O is performed.
 type IdsecSCAK8sClusterContext struct {
-	// CSP is the cloud service provider identifier (e.g. "AWS", "AZURE").
+	// CSP is the cloud service provider identifier (e.g. "AWS", "AZURE", "GCP").
 	CSP string
 
 	// ClusterID is the EKS cluster name, parsed from the targetId ARN in the
@@ -80,6 +80,8 @@ func GetTokenProvider(csp string) (IdsecSCAK8sTokenProvider, error) {
 		return &AWSTokenProvider{}, nil
 	case k8smodels.CSPAzure:
 		return &AzureTokenProvider{}, nil
+	case k8smodels.CSPGCP:
+		return &GCPTokenProvider{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported CSP for kubectl-login: %q", csp)
 	}
@@ -11,6 +11,7 @@ import (
 const (
 	CSPAWS   = "AWS"
 	CSPAzure = "AZURE"
+	CSPGCP   = "GCP"
 )
 
 // IdsecSCAK8sDpaSsoAcquireResponse is the JSON body from POST /api/adb/sso/acquire
@@ -49,8 +50,8 @@ type IdsecSCAK8sDpaSsoAcquireMetadata struct {
 
 // IdsecSCAk8sListClustersRequest is the request schema for the list-clusters CLI action.
 type IdsecSCAk8sListClustersRequest struct {
-	CSP         string `json:"csp" mapstructure:"csp" flag:"csp" desc:"The cloud provider that hosts the workspace to discover (AWS | AZURE). Omit to list AWS and AZURE targets."`
-	All         bool   `json:"all,omitempty" mapstructure:"all,omitempty" flag:"all" desc:"List targets for all default CSPs (AWS and AZURE)."`
+	CSP         string `json:"csp" mapstructure:"csp" flag:"csp" desc:"The cloud provider that hosts the workspace to discover (AWS | AZURE | GCP). Omit to list AWS, AZURE and GCP targets."`
+	All         bool   `json:"all,omitempty" mapstructure:"all,omitempty" flag:"all" desc:"List targets for all default CSPs (AWS, AZURE and GCP)."`
 	WorkspaceID string `json:"workspace_id,omitempty" mapstructure:"workspace_id,omitempty" flag:"workspace-id" desc:"The ID of the workspace to discover (AWS - The AWS organization ID | AZURE: Microsoft Entra ID Directory (Tenant) ID)"`
 	Limit       int    `json:"limit,omitempty" mapstructure:"limit,omitempty" flag:"limit" desc:"Limit the number of clusters to list"`
 	NextToken   string `json:"next_token,omitempty" mapstructure:"next_token,omitempty" flag:"next-token" desc:"The token to use to get the next page of clusters"`
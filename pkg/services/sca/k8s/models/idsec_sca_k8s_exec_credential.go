@@ -1,6 +1,8 @@
 // Package models provides data structures for the SCA k8s service.
 package models
 
+import k8scommonmodels "github.com/cyberark/idsec-sdk-golang/pkg/models/common/k8s"
+
 // IdsecSCAK8sExecCredentialStatus holds the authentication credentials injected
 // into kubectl. For direct connections a bearer token is provided; for proxy
 // connections a client certificate and key are provided instead.
@@ -11,19 +13,10 @@ package models
 // and rely on reactive 401-driven refresh.
 //
 // Reference: https://kubernetes.io/docs/reference/config-api/client-authentication.v1beta1/
-type IdsecSCAK8sExecCredentialStatus struct {
-	Token                 string `json:"token,omitempty"`
-	ExpirationTimestamp   string `json:"expirationTimestamp,omitempty"`
-	ClientCertificateData string `json:"clientCertificateData,omitempty"`
-	ClientKeyData         string `json:"clientKeyData,omitempty"`
-}
+type IdsecSCAK8sExecCredentialStatus = k8scommonmodels.IdsecK8sExecCredentialStatus
 
 // IdsecSCAK8sExecCredential is the JSON object written to stdout for kubectl's
 // exec credential plugin protocol.
 //
 // Reference: https://kubernetes.io/docs/reference/config-api/client-authentication.v1beta1/
-type IdsecSCAK8sExecCredential struct {
-	APIVersion string                          `json:"apiVersion"`
-	Kind       string                          `json:"kind"`
-	Status     IdsecSCAK8sExecCredentialStatus `json:"status"`
-}
+type IdsecSCAK8sExecCredential = k8scommonmodels.IdsecK8sExecCredential
@@ -0,0 +1,12 @@
+package actions
+
+import (
+	sessionsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/sessions/models"
+)
+
+// ActionToSchemaMap maps sessions action names to their input schema structs.
+var ActionToSchemaMap = map[string]interface{}{
+	"list-sessions":  &sessionsmodels.IdsecSCAListSessionsRequest{},
+	"session":        &sessionsmodels.IdsecSCAGetSessionRequest{},
+	"revoke-session": &sessionsmodels.IdsecSCARevokeSessionRequest{},
+}
@@ -0,0 +1,168 @@
+package sessions
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	cloudaccessmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/cloudaccess/models"
+	groupaccessmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/groupaccess/models"
+	sessionsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/sessions/models"
+)
+
+// IdsecSCASessionRevoker revokes SCA sessions. It is implemented by IdsecSCASessionsService.
+type IdsecSCASessionRevoker interface {
+	RevokeSession(req *sessionsmodels.IdsecSCARevokeSessionRequest) error
+}
+
+// IdsecSCASessionRegistry keeps track of the sessions started by the current process,
+// so long-running tools can end them once their work is done instead of leaving the
+// elevated access in place until it expires.
+//
+// The registry is safe for concurrent use.
+//
+// Example:
+//
+//	registry := sessions.NewIdsecSCASessionRegistry(sessionsService)
+//	defer func() { _ = registry.RevokeAll() }()
+//	resp, err := cloudAccessService.Elevate(req)
+//	if err != nil { /* handle */ }
+//	registry.TrackCloudAccessElevation(resp)
+type IdsecSCASessionRegistry struct {
+	mutex    sync.Mutex
+	revoker  IdsecSCASessionRevoker
+	order    []string
+	sessions map[string]sessionsmodels.IdsecSCASession
+}
+
+// NewIdsecSCASessionRegistry creates an empty session registry which revokes sessions
+// using the given revoker.
+func NewIdsecSCASessionRegistry(revoker IdsecSCASessionRevoker) *IdsecSCASessionRegistry {
+	return &IdsecSCASessionRegistry{
+		revoker:  revoker,
+		sessions: map[string]sessionsmodels.IdsecSCASession{},
+	}
+}
+
+// Track records a session started by this process. Sessions without an ID are ignored,
+// and tracking an already tracked session replaces its details.
+func (r *IdsecSCASessionRegistry) Track(session sessionsmodels.IdsecSCASession) {
+	if session.SessionID == "" {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.sessions[session.SessionID]; !ok {
+		r.order = append(r.order, session.SessionID)
+	}
+	r.sessions[session.SessionID] = session
+}
+
+// TrackCloudAccessElevation records every successful result of a cloudaccess elevate
+// response and returns the IDs of the tracked sessions.
+func (r *IdsecSCASessionRegistry) TrackCloudAccessElevation(resp *cloudaccessmodels.IdsecSCACloudAccessElevateResponse) []string {
+	if resp == nil {
+		return nil
+	}
+	startTime := time.Now().UTC().Format(time.RFC3339)
+	var sessionIDs []string
+	for _, result := range resp.Response.Results {
+		if result.SessionID == "" || result.ErrorInfo != nil {
+			continue
+		}
+		r.Track(sessionsmodels.IdsecSCASession{
+			SessionID:      result.SessionID,
+			CSP:            resp.Response.CSP,
+			OrganizationID: resp.Response.OrganizationID,
+			WorkspaceID:    result.WorkspaceID,
+			RoleID:         result.RoleID,
+			Status:         sessionsmodels.SessionStatusActive,
+			StartTime:      startTime,
+		})
+		sessionIDs = append(sessionIDs, result.SessionID)
+	}
+	return sessionIDs
+}
+
+// TrackGroupAccessElevation records every successful result of a groupaccess elevate
+// response and returns the IDs of the tracked sessions.
+func (r *IdsecSCASessionRegistry) TrackGroupAccessElevation(resp *groupaccessmodels.IdsecSCAGroupAccessElevateResponse) []string {
+	if resp == nil {
+		return nil
+	}
+	startTime := time.Now().UTC().Format(time.RFC3339)
+	var sessionIDs []string
+	for _, result := range resp.Response.Results {
+		if result.SessionID == "" || result.ErrorInfo != nil {
+			continue
+		}
+		r.Track(sessionsmodels.IdsecSCASession{
+			SessionID:      result.SessionID,
+			CSP:            resp.Response.CSP,
+			OrganizationID: resp.Response.DirectoryID,
+			GroupID:        result.GroupID,
+			Status:         sessionsmodels.SessionStatusActive,
+			StartTime:      startTime,
+		})
+		sessionIDs = append(sessionIDs, result.SessionID)
+	}
+	return sessionIDs
+}
+
+// Untrack stops tracking a session without revoking it.
+func (r *IdsecSCASessionRegistry) Untrack(sessionID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, ok := r.sessions[sessionID]; !ok {
+		return
+	}
+	delete(r.sessions, sessionID)
+	for i, id := range r.order {
+		if id == sessionID {
+			r.order = append(r.order[:i], r.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// Sessions returns the tracked sessions in the order they were tracked.
+func (r *IdsecSCASessionRegistry) Sessions() []sessionsmodels.IdsecSCASession {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	sessions := make([]sessionsmodels.IdsecSCASession, 0, len(r.order))
+	for _, id := range r.order {
+		sessions = append(sessions, r.sessions[id])
+	}
+	return sessions
+}
+
+// Revoke revokes a tracked session and stops tracking it once revoked.
+func (r *IdsecSCASessionRegistry) Revoke(sessionID string) error {
+	r.mutex.Lock()
+	_, ok := r.sessions[sessionID]
+	r.mutex.Unlock()
+	if !ok {
+		return fmt.Errorf("session [%s] is not tracked", sessionID)
+	}
+	if r.revoker == nil {
+		return fmt.Errorf("session registry has no revoker")
+	}
+	if err := r.revoker.RevokeSession(&sessionsmodels.IdsecSCARevokeSessionRequest{SessionID: sessionID}); err != nil {
+		return fmt.Errorf("failed to revoke session [%s]: %w", sessionID, err)
+	}
+	r.Untrack(sessionID)
+	return nil
+}
+
+// RevokeAll revokes every tracked session. Sessions that could not be revoked remain
+// tracked, and their errors are joined into the returned error.
+func (r *IdsecSCASessionRegistry) RevokeAll() error {
+	var errs []error
+	for _, session := range r.Sessions() {
+		if err := r.Revoke(session.SessionID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,83 @@
+package sessions
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	cloudaccessmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/cloudaccess/models"
+	groupaccessmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/groupaccess/models"
+	scamodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/models"
+	sessionsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/sessions/models"
+)
+
+// fakeRevoker records revoked sessions and fails for the configured session IDs.
+type fakeRevoker struct {
+	revoked []string
+	failing map[string]bool
+}
+
+func (f *fakeRevoker) RevokeSession(req *sessionsmodels.IdsecSCARevokeSessionRequest) error {
+	if f.failing[req.SessionID] {
+		return fmt.Errorf("revoke failed")
+	}
+	f.revoked = append(f.revoked, req.SessionID)
+	return nil
+}
+
+func TestSessionRegistry_TrackElevations(t *testing.T) {
+	registry := NewIdsecSCASessionRegistry(&fakeRevoker{})
+
+	cloudIDs := registry.TrackCloudAccessElevation(&cloudaccessmodels.IdsecSCACloudAccessElevateResponse{
+		Response: cloudaccessmodels.IdsecSCACloudAccessElevateResponseBody{
+			OrganizationID: "org",
+			CSP:            "AZURE",
+			Results: []cloudaccessmodels.IdsecSCACloudAccessElevateResult{
+				{WorkspaceID: "sub-1", RoleID: "Reader", SessionID: "cloud-1"},
+				{WorkspaceID: "sub-1", RoleID: "Owner", ErrorInfo: &scamodels.IdsecSCAElevateErrorInfo{Code: "NOT_ELIGIBLE"}},
+			},
+		},
+	})
+	groupIDs := registry.TrackGroupAccessElevation(&groupaccessmodels.IdsecSCAGroupAccessElevateResponse{
+		Response: groupaccessmodels.IdsecSCAGroupAccessElevateResponseData{
+			DirectoryID: "tenant",
+			CSP:         "AZURE",
+			Results:     []groupaccessmodels.IdsecSCAGroupAccessElevateResult{{GroupID: "group-1", SessionID: "group-1-session"}},
+		},
+	})
+
+	require.Equal(t, []string{"cloud-1"}, cloudIDs)
+	require.Equal(t, []string{"group-1-session"}, groupIDs)
+	tracked := registry.Sessions()
+	require.Len(t, tracked, 2)
+	require.Equal(t, "Reader", tracked[0].RoleID)
+	require.Equal(t, "org", tracked[0].OrganizationID)
+	require.Equal(t, "group-1", tracked[1].GroupID)
+	require.Equal(t, sessionsmodels.SessionStatusActive, tracked[1].Status)
+
+	registry.Untrack("cloud-1")
+	require.Len(t, registry.Sessions(), 1)
+}
+
+func TestSessionRegistry_RevokeAll(t *testing.T) {
+	revoker := &fakeRevoker{failing: map[string]bool{"s2": true}}
+	registry := NewIdsecSCASessionRegistry(revoker)
+	for _, id := range []string{"s1", "s2", "s3"} {
+		registry.Track(sessionsmodels.IdsecSCASession{SessionID: id})
+	}
+
+	err := registry.RevokeAll()
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to revoke session [s2]")
+	require.Equal(t, []string{"s1", "s3"}, revoker.revoked)
+	remaining := registry.Sessions()
+	require.Len(t, remaining, 1)
+	require.Equal(t, "s2", remaining[0].SessionID)
+}
+
+func TestSessionRegistry_RevokeUntracked(t *testing.T) {
+	registry := NewIdsecSCASessionRegistry(&fakeRevoker{})
+	require.Error(t, registry.Revoke("unknown"))
+	require.NoError(t, registry.RevokeAll())
+}
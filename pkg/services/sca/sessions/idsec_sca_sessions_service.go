@@ -0,0 +1,216 @@
+package sessions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/cyberark/idsec-sdk-golang/pkg/auth"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services"
+	scacommon "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/internal/common"
+	scamodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/models"
+	sessionsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/sessions/models"
+)
+
+const (
+	sessionsURL   = "/api/access/sessions"
+	sessionURLFmt = "/api/access/sessions/%s"
+)
+
+// IdsecSCASessionsService provides SCA elevation session operations.
+//
+// Sessions are created by the cloudaccess and groupaccess elevate calls. This service
+// lists active and past sessions, retrieves the details of a session and revokes a
+// session before it expires.
+//
+// Example:
+//
+//	svc, err := NewIdsecSCASessionsService(ispAuth)
+//	if err != nil { /* handle */ }
+//	resp, err := svc.ListSessions(&sessionsmodels.IdsecSCAListSessionsRequest{Status: sessionsmodels.SessionStatusActive})
+//	if err != nil { /* handle */ }
+//	for _, session := range resp.Response { fmt.Println(session.SessionID, session.Remaining()) }
+type IdsecSCASessionsService struct {
+	*services.IdsecBaseService
+	*services.IdsecISPBaseService
+}
+
+// NewIdsecSCASessionsService creates a new service instance. Requires an "isp" authenticator.
+func NewIdsecSCASessionsService(authenticators ...auth.IdsecAuth) (*IdsecSCASessionsService, error) {
+	svc := &IdsecSCASessionsService{}
+	base, err := services.NewIdsecBaseService(svc, authenticators...)
+	if err != nil {
+		return nil, err
+	}
+	ispBaseAuth, err := base.Authenticator("isp")
+	if err != nil {
+		return nil, err
+	}
+	ispAuth := ispBaseAuth.(*auth.IdsecISPAuth)
+
+	ispBaseService, err := services.NewIdsecISPBaseService(ispAuth, "sca", ".", "", svc.refreshAuth)
+	if err != nil {
+		return nil, err
+	}
+	ispBaseService.ISPClient().SetHeader("X-API-Version", "2.0")
+
+	svc.IdsecBaseService = base
+	svc.IdsecISPBaseService = ispBaseService
+	return svc, nil
+}
+
+func (s *IdsecSCASessionsService) refreshAuth(client *common.IdsecClient) error {
+	return isp.RefreshClient(client, s.ISPAuth())
+}
+
+// ListSessions retrieves the elevation sessions of the authenticated user via
+// GET /api/access/sessions, following NextToken until all pages are read.
+//
+// Sessions can be filtered by CSP, WorkspaceID, RoleID and Status. When NextToken is set,
+// listing starts from that page.
+func (s *IdsecSCASessionsService) ListSessions(req *sessionsmodels.IdsecSCAListSessionsRequest) (*sessionsmodels.IdsecSCAListSessionsResponse, error) { //nolint:revive
+	if req == nil {
+		return nil, fmt.Errorf("list sessions request cannot be nil")
+	}
+	cspUpper := strings.ToUpper(strings.TrimSpace(req.CSP))
+	if cspUpper != "" && !scacommon.IsSupportedCSP(cspUpper, scamodels.CSPAWS, scamodels.CSPAzure, scamodels.CSPGCP) {
+		return nil, scacommon.ErrUnsupportedCSP(req.CSP, scamodels.CSPAWS, scamodels.CSPAzure, scamodels.CSPGCP)
+	}
+	if s == nil || s.IdsecISPBaseService == nil || s.ISPClient() == nil {
+		return nil, fmt.Errorf("sca sessions service not initialized")
+	}
+
+	all := &sessionsmodels.IdsecSCAListSessionsResponse{}
+	nextToken := req.NextToken
+	totalSet := false
+	for {
+		params := map[string]string{}
+		if cspUpper != "" {
+			params["csp"] = cspUpper
+		}
+		if req.WorkspaceID != "" {
+			params["workspaceId"] = req.WorkspaceID
+		}
+		if req.RoleID != "" {
+			params["roleId"] = req.RoleID
+		}
+		if req.Status != "" {
+			params["status"] = strings.ToUpper(req.Status)
+		}
+		if req.Limit > 0 {
+			params["limit"] = fmt.Sprintf("%d", req.Limit)
+		}
+		if nextToken != "" {
+			params["nextToken"] = nextToken
+		}
+		page, err := s.listSessionsPage(params)
+		if err != nil {
+			return nil, err
+		}
+		all.Response = append(all.Response, page.Response...)
+		if !totalSet {
+			all.Total = page.Total
+			totalSet = true
+		}
+		nextToken = strings.TrimSpace(page.NextToken)
+		if nextToken == "" {
+			if all.Total == 0 {
+				all.Total = len(all.Response)
+			}
+			return all, nil
+		}
+	}
+}
+
+func (s *IdsecSCASessionsService) listSessionsPage(params map[string]string) (*sessionsmodels.IdsecSCAListSessionsResponse, error) {
+	s.Logger.Info("Listing SCA sessions")
+	resp, err := s.ISPClient().Get(context.Background(), sessionsURL, params)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list sessions - [%d] - [%s]", resp.StatusCode, common.SerializeResponseToJSON(resp.Body))
+	}
+	decoded, err := common.DeserializeJSONCamel(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	dataMap, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected response format from sessions API")
+	}
+	var response sessionsmodels.IdsecSCAListSessionsResponse
+	if err = mapstructure.Decode(dataMap, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Session retrieves the details of a single session via GET /api/access/sessions/{sessionId}.
+func (s *IdsecSCASessionsService) Session(req *sessionsmodels.IdsecSCAGetSessionRequest) (*sessionsmodels.IdsecSCASession, error) { //nolint:revive
+	if req == nil {
+		return nil, fmt.Errorf("get session request cannot be nil")
+	}
+	if strings.TrimSpace(req.SessionID) == "" {
+		return nil, fmt.Errorf("sessionId cannot be empty")
+	}
+	if s == nil || s.IdsecISPBaseService == nil || s.ISPClient() == nil {
+		return nil, fmt.Errorf("sca sessions service not initialized")
+	}
+	s.Logger.Info("Retrieving SCA session [%s]", req.SessionID)
+	resp, err := s.ISPClient().Get(context.Background(), fmt.Sprintf(sessionURLFmt, req.SessionID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get session - [%d] - [%s]", resp.StatusCode, common.SerializeResponseToJSON(resp.Body))
+	}
+	decoded, err := common.DeserializeJSONCamel(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	dataMap, ok := decoded.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected response format from sessions API")
+	}
+	var session sessionsmodels.IdsecSCASession
+	if err = mapstructure.Decode(dataMap, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// RevokeSession ends a session before it expires via DELETE /api/access/sessions/{sessionId}.
+// The access granted by the session is removed from the cloud provider.
+func (s *IdsecSCASessionsService) RevokeSession(req *sessionsmodels.IdsecSCARevokeSessionRequest) error { //nolint:revive
+	if req == nil {
+		return fmt.Errorf("revoke session request cannot be nil")
+	}
+	if strings.TrimSpace(req.SessionID) == "" {
+		return fmt.Errorf("sessionId cannot be empty")
+	}
+	if s == nil || s.IdsecISPBaseService == nil || s.ISPClient() == nil {
+		return fmt.Errorf("sca sessions service not initialized")
+	}
+	s.Logger.Info("Revoking SCA session [%s]", req.SessionID)
+	resp, err := s.ISPClient().Delete(context.Background(), fmt.Sprintf(sessionURLFmt, req.SessionID), nil, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to revoke session - [%d] - [%s]", resp.StatusCode, common.SerializeResponseToJSON(resp.Body))
+	}
+	return nil
+}
+
+// ServiceConfig returns the service configuration (implements services.IdsecService).
+func (s *IdsecSCASessionsService) ServiceConfig() services.IdsecServiceConfig { //nolint:revive
+	return ServiceConfig
+}
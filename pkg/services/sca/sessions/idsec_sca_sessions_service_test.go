@@ -0,0 +1,184 @@
+package sessions
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services"
+	scainternal "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/internal"
+	sessionsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sca/sessions/models"
+)
+
+// setupSessionsService creates an IdsecSCASessionsService with the given mock ISP client injected.
+func setupSessionsService(client *isp.IdsecISPServiceClient) *IdsecSCASessionsService {
+	ispBase := &services.IdsecISPBaseService{}
+	scainternal.InjectISPClient(ispBase, client)
+
+	return &IdsecSCASessionsService{
+		IdsecBaseService: &services.IdsecBaseService{
+			Logger: common.GlobalLogger,
+		},
+		IdsecISPBaseService: ispBase,
+	}
+}
+
+func TestListSessions_validation_table(t *testing.T) {
+	svc := &IdsecSCASessionsService{}
+	tests := []struct {
+		name string
+		req  *sessionsmodels.IdsecSCAListSessionsRequest
+	}{
+		{name: "nil_request", req: nil},
+		{name: "unsupported_csp", req: &sessionsmodels.IdsecSCAListSessionsRequest{CSP: "IBM"}},
+		{name: "uninitialized_service", req: &sessionsmodels.IdsecSCAListSessionsRequest{CSP: "AWS"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := svc.ListSessions(tt.req)
+			require.Error(t, err)
+			require.Nil(t, resp)
+		})
+	}
+}
+
+func TestListSessions_FiltersAndPagination(t *testing.T) {
+	var queries []map[string]string
+	client, cleanup := scainternal.SetupMockSCAService(t, []scainternal.MockEndpointConfig{
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == http.MethodGet && r.URL.Path == "/api/access/sessions" && r.URL.Query().Get("nextToken") == ""
+			},
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{"response":[{"sessionId":"s1","csp":"AWS","workspaceId":"123456789012","roleId":"ReadOnly","status":"ACTIVE","expiresAt":"2099-01-01T00:00:00Z"}],"total":2,"nextToken":"page-2"}`,
+			OnRequest: func(r *http.Request) {
+				queries = append(queries, map[string]string{"csp": r.URL.Query().Get("csp"), "workspaceId": r.URL.Query().Get("workspaceId"), "status": r.URL.Query().Get("status")})
+			},
+		},
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == http.MethodGet && r.URL.Path == "/api/access/sessions" && r.URL.Query().Get("nextToken") == "page-2"
+			},
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{"response":[{"sessionId":"s2","csp":"AWS","workspaceId":"123456789012","roleId":"Admin","status":"ACTIVE"}],"total":2}`,
+		},
+	})
+	defer cleanup()
+
+	svc := setupSessionsService(client)
+	resp, err := svc.ListSessions(&sessionsmodels.IdsecSCAListSessionsRequest{
+		CSP:         "aws",
+		WorkspaceID: "123456789012",
+		Status:      "active",
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, 2, resp.Total)
+	require.Len(t, resp.Response, 2)
+	require.Equal(t, "s1", resp.Response[0].SessionID)
+	require.Equal(t, "s2", resp.Response[1].SessionID)
+	require.Positive(t, resp.Response[0].Remaining())
+	require.Equal(t, []map[string]string{{"csp": "AWS", "workspaceId": "123456789012", "status": "ACTIVE"}}, queries)
+}
+
+func TestListSessions_500InternalServerError(t *testing.T) {
+	client, cleanup := scainternal.SetupMockSCAService(t, []scainternal.MockEndpointConfig{
+		{
+			Matcher:      func(r *http.Request) bool { return true },
+			StatusCode:   http.StatusInternalServerError,
+			ResponseBody: `{"error":"internal"}`,
+		},
+	})
+	defer cleanup()
+
+	svc := setupSessionsService(client)
+	resp, err := svc.ListSessions(&sessionsmodels.IdsecSCAListSessionsRequest{})
+
+	require.Error(t, err)
+	require.Nil(t, resp)
+	require.Contains(t, err.Error(), "failed to list sessions")
+}
+
+func TestSession_Success(t *testing.T) {
+	client, cleanup := scainternal.SetupMockSCAService(t, []scainternal.MockEndpointConfig{
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == http.MethodGet && r.URL.Path == "/api/access/sessions/s1"
+			},
+			StatusCode:   http.StatusOK,
+			ResponseBody: `{"sessionId":"s1","csp":"AZURE","organizationId":"tenant","groupId":"group-1","status":"EXPIRED","expiresAt":"2020-01-01T00:00:00Z"}`,
+		},
+	})
+	defer cleanup()
+
+	svc := setupSessionsService(client)
+	session, err := svc.Session(&sessionsmodels.IdsecSCAGetSessionRequest{SessionID: "s1"})
+
+	require.NoError(t, err)
+	require.Equal(t, "AZURE", session.CSP)
+	require.Equal(t, "group-1", session.GroupID)
+	require.Equal(t, sessionsmodels.SessionStatusExpired, session.Status)
+	require.Zero(t, session.Remaining())
+}
+
+func TestSession_validation(t *testing.T) {
+	svc := &IdsecSCASessionsService{}
+	_, err := svc.Session(nil)
+	require.Error(t, err)
+	_, err = svc.Session(&sessionsmodels.IdsecSCAGetSessionRequest{})
+	require.Error(t, err)
+}
+
+func TestRevokeSession_Success(t *testing.T) {
+	revoked := false
+	client, cleanup := scainternal.SetupMockSCAService(t, []scainternal.MockEndpointConfig{
+		{
+			Matcher: func(r *http.Request) bool {
+				return r.Method == http.MethodDelete && r.URL.Path == "/api/access/sessions/s1"
+			},
+			StatusCode: http.StatusNoContent,
+			OnRequest:  func(r *http.Request) { revoked = true },
+		},
+	})
+	defer cleanup()
+
+	svc := setupSessionsService(client)
+	err := svc.RevokeSession(&sessionsmodels.IdsecSCARevokeSessionRequest{SessionID: "s1"})
+
+	require.NoError(t, err)
+	require.True(t, revoked)
+}
+
+func TestRevokeSession_404NotFound(t *testing.T) {
+	client, cleanup := scainternal.SetupMockSCAService(t, []scainternal.MockEndpointConfig{
+		{
+			Matcher:      func(r *http.Request) bool { return true },
+			StatusCode:   http.StatusNotFound,
+			ResponseBody: `{"error":"not found"}`,
+		},
+	})
+	defer cleanup()
+
+	svc := setupSessionsService(client)
+	err := svc.RevokeSession(&sessionsmodels.IdsecSCARevokeSessionRequest{SessionID: "missing"})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to revoke session - [404]")
+}
+
+func TestSessionsMethods_ErrorPropagation(t *testing.T) {
+	scainternal.TestServiceErrorPropagation(t, func(client *isp.IdsecISPServiceClient) error {
+		_, err := setupSessionsService(client).ListSessions(&sessionsmodels.IdsecSCAListSessionsRequest{})
+		return err
+	})
+	scainternal.TestServiceErrorPropagation(t, func(client *isp.IdsecISPServiceClient) error {
+		_, err := setupSessionsService(client).Session(&sessionsmodels.IdsecSCAGetSessionRequest{SessionID: "s1"})
+		return err
+	})
+	scainternal.TestServiceErrorPropagation(t, func(client *isp.IdsecISPServiceClient) error {
+		return setupSessionsService(client).RevokeSession(&sessionsmodels.IdsecSCARevokeSessionRequest{SessionID: "s1"})
+	})
+}
@@ -0,0 +1,72 @@
+// Package models provides data structures for SCA elevation session operations.
+package models
+
+import "time"
+
+// Possible statuses of an SCA elevation session.
+const (
+	SessionStatusActive  = "ACTIVE"
+	SessionStatusExpired = "EXPIRED"
+	SessionStatusRevoked = "REVOKED"
+)
+
+// IdsecSCASession represents a single SCA elevation session.
+//
+// A session is created by a successful cloudaccess or groupaccess elevate call and is
+// identified by the SessionID returned in the elevate result. RoleID is set for
+// cloudaccess sessions and GroupID for groupaccess sessions.
+type IdsecSCASession struct {
+	SessionID      string `json:"sessionId" mapstructure:"sessionId" desc:"The ID of the session"`
+	CSP            string `json:"csp" mapstructure:"csp" desc:"The cloud provider of the session (AWS | AZURE | GCP)"`
+	OrganizationID string `json:"organizationId,omitempty" mapstructure:"organizationId" desc:"The ID of the organization or tenant that contains the workspace"`
+	WorkspaceID    string `json:"workspaceId,omitempty" mapstructure:"workspaceId" desc:"The ID of the workspace the session grants access to"`
+	WorkspaceName  string `json:"workspaceName,omitempty" mapstructure:"workspaceName" desc:"The display name of the workspace"`
+	RoleID         string `json:"roleId,omitempty" mapstructure:"roleId" desc:"The ID of the role the session was elevated with"`
+	RoleName       string `json:"roleName,omitempty" mapstructure:"roleName" desc:"The name of the role the session was elevated with"`
+	GroupID        string `json:"groupId,omitempty" mapstructure:"groupId" desc:"The ID of the Entra group the session grants membership to"`
+	Status         string `json:"status" mapstructure:"status" desc:"The status of the session" choices:"ACTIVE,EXPIRED,REVOKED"`
+	StartTime      string `json:"startTime,omitempty" mapstructure:"startTime" desc:"The time the session started (RFC3339)"`
+	ExpiresAt      string `json:"expiresAt,omitempty" mapstructure:"expiresAt" desc:"The time the session expires (RFC3339)"`
+}
+
+// Remaining returns the time left until the session expires, or zero when the session
+// is not active, already expired or has no known expiry.
+func (s *IdsecSCASession) Remaining() time.Duration {
+	if s.Status != SessionStatusActive || s.ExpiresAt == "" {
+		return 0
+	}
+	expiresAt, err := time.Parse(time.RFC3339, s.ExpiresAt)
+	if err != nil {
+		return 0
+	}
+	return max(time.Until(expiresAt), 0)
+}
+
+// IdsecSCAListSessionsRequest is the input for listing elevation sessions.
+//
+// All filters are optional. Use Limit and NextToken for paginating through results.
+type IdsecSCAListSessionsRequest struct {
+	CSP         string `json:"csp,omitempty" mapstructure:"csp,omitempty" flag:"csp" desc:"Filter sessions by cloud provider (AWS | AZURE | GCP)"`
+	WorkspaceID string `json:"workspace_id,omitempty" mapstructure:"workspace_id,omitempty" flag:"workspace-id" desc:"Filter sessions by workspace ID"`
+	RoleID      string `json:"role_id,omitempty" mapstructure:"role_id,omitempty" flag:"role-id" desc:"Filter sessions by role ID"`
+	Status      string `json:"status,omitempty" mapstructure:"status,omitempty" flag:"status" desc:"Filter sessions by status" choices:"ACTIVE,EXPIRED,REVOKED"`
+	Limit       int    `json:"limit,omitempty" mapstructure:"limit,omitempty" flag:"limit" desc:"The maximum number of sessions to return per page"`
+	NextToken   string `json:"next_token,omitempty" mapstructure:"next_token,omitempty" flag:"next-token" desc:"The pagination token from the previous API response"`
+}
+
+// IdsecSCAListSessionsResponse is the response from GET /api/access/sessions.
+type IdsecSCAListSessionsResponse struct {
+	Response  []IdsecSCASession `json:"response" mapstructure:"response" desc:"The list of sessions"`
+	Total     int               `json:"total" mapstructure:"total" desc:"The total number of sessions matching the filters"`
+	NextToken string            `json:"nextToken,omitempty" mapstructure:"nextToken" desc:"The token for retrieving the next page of results"`
+}
+
+// IdsecSCAGetSessionRequest is the input for retrieving a single session.
+type IdsecSCAGetSessionRequest struct {
+	SessionID string `json:"session_id" mapstructure:"session_id" validate:"required" flag:"session-id" desc:"The ID of the session to retrieve"`
+}
+
+// IdsecSCARevokeSessionRequest is the input for ending a session before it expires.
+type IdsecSCARevokeSessionRequest struct {
+	SessionID string `json:"session_id" mapstructure:"session_id" validate:"required" flag:"session-id" desc:"The ID of the session to revoke"`
+}
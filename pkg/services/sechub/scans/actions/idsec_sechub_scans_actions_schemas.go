@@ -4,7 +4,8 @@ import sechubscans "github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/sca
 
 // ActionToSchemaMap is a map that defines the mapping between Sec Hub scans action names and their corresponding schema types.
 var ActionToSchemaMap = map[string]interface{}{
-	"get":     nil,
-	"stats":   nil,
-	"trigger": &sechubscans.IdsecSecHubTriggerScans{},
+	"get":              nil,
+	"stats":            nil,
+	"trigger":          &sechubscans.IdsecSecHubTriggerScans{},
+	"trigger-and-wait": &sechubscans.IdsecSecHubTriggerAndWaitScans{},
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
 	"github.com/cyberark/idsec-sdk-golang/pkg/services"
 	scansmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/scans/models"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/secrets"
 )
 
 const (
@@ -26,6 +27,7 @@ type IdsecSecHubScansPage = common.IdsecPage[scansmodels.IdsecSecHubScan]
 type IdsecSecHubScansService struct {
 	*services.IdsecBaseService
 	*services.IdsecISPBaseService
+	secretsService *secrets.IdsecSecHubSecretsService
 }
 
 // NewIdsecSecHubScansService creates a new instance of IdsecSecHubscansService.
@@ -50,6 +52,11 @@ func NewIdsecSecHubScansService(authenticators ...auth.IdsecAuth) (*IdsecSecHubS
 		"Accept": "application/x.secretshub.beta+json",
 	})
 
+	scansService.secretsService, err = secrets.NewIdsecSecHubSecretsService(ispAuth)
+	if err != nil {
+		return nil, err
+	}
+
 	scansService.IdsecBaseService = baseService
 	scansService.IdsecISPBaseService = ispBaseService
 	return scansService, nil
@@ -63,6 +70,52 @@ func (s *IdsecSecHubScansService) refreshSecHubAuth(client *common.IdsecClient)
 	return nil
 }
 
+// listScans retrieves all scans from the Secrets Hub service in a single call.
+func (s *IdsecSecHubScansService) listScans(ctx context.Context) ([]*scansmodels.IdsecSecHubScan, error) {
+	response, err := s.ISPClient().Get(ctx, sechubURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list Secret Store Scans - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	}
+	result, err := common.DeserializeJSONSnake(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("failed to list Secret Store scans, unexpected result")
+	}
+	var scansJSON []interface{}
+	if scans, ok := resultMap["scans"]; ok {
+		scansJSON, ok = scans.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("failed to list Secret Store scans, unexpected result")
+		}
+	} else {
+		return nil, fmt.Errorf("failed to list Secret Store scans, unexpected result")
+	}
+	for i, scansMember := range scansJSON {
+		if scansMemberMap, ok := scansMember.(map[string]interface{}); ok {
+			if ID, ok := scansMemberMap["id"]; ok {
+				scansJSON[i].(map[string]interface{})["id"] = ID
+			}
+		}
+	}
+	var scans []*scansmodels.IdsecSecHubScan
+	if err := mapstructure.Decode(scansJSON, &scans); err != nil {
+		return nil, fmt.Errorf("failed to validate Secret Store scans: %v", err)
+	}
+	return scans, nil
+}
+
 // Get retrieves the scans info from the Secrets Hub service.
 // https://api-docs.cyberark.com/docs/secretshub-api/78cprz38emhrb-get-scans
 func (s *IdsecSecHubScansService) Get() (<-chan *IdsecSecHubScansPage, error) {
@@ -71,47 +124,11 @@ func (s *IdsecSecHubScansService) Get() (<-chan *IdsecSecHubScansPage, error) {
 	results := make(chan *IdsecSecHubScansPage)
 	go func() {
 		defer close(results)
-		response, err := s.ISPClient().Get(context.Background(), sechubURL, nil)
-		if err != nil {
-			s.Logger.Error("Failed to list filters: %v", err)
-			return
-		}
-		defer func(Body io.ReadCloser) {
-			err := Body.Close()
-			if err != nil {
-				common.GlobalLogger.Warning("Error closing response body")
-			}
-		}(response.Body)
-		if response.StatusCode != http.StatusOK {
-			s.Logger.Error("Failed to list Secret Store Scans - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
-			return
-		}
-		result, err := common.DeserializeJSONSnake(response.Body)
+		scans, err := s.listScans(context.Background())
 		if err != nil {
-			s.Logger.Error("Failed to decode response: %v", err)
-			return
-		}
-		resultMap := result.(map[string]interface{})
-		var scansJSON []interface{}
-		if scans, ok := resultMap["scans"]; ok {
-			scansJSON = scans.([]interface{})
-		} else {
-			s.Logger.Error("Failed to list Secret Store scans, unexpected result")
-			return
-		}
-		for i, scansMember := range scansJSON {
-			if scansMemberMap, ok := scansMember.(map[string]interface{}); ok {
-				if ID, ok := scansMemberMap["id"]; ok {
-					scansJSON[i].(map[string]interface{})["id"] = ID
-				}
-			}
-		}
-		var scans []*scansmodels.IdsecSecHubScan
-		if err := mapstructure.Decode(scansJSON, &scans); err != nil {
-			s.Logger.Error("Failed to validate Secret Store scans: %v", err)
+			s.Logger.Error("Failed to list scans: %v", err)
 			return
 		}
-
 		results <- &IdsecSecHubScansPage{Items: scans}
 	}()
 	return results, nil
@@ -120,6 +137,11 @@ func (s *IdsecSecHubScansService) Get() (<-chan *IdsecSecHubScansPage, error) {
 // Trigger triggers scans in the Secrets Hub service.
 // https://api-docs.cyberark.com/docs/secretshub-api/kyc9azwliw2xa-trigger-scan
 func (s *IdsecSecHubScansService) Trigger(triggerScan *scansmodels.IdsecSecHubTriggerScans) (*scansmodels.IdsecSecHubScanIDs, error) {
+	return s.TriggerContext(context.Background(), triggerScan)
+}
+
+// TriggerContext is like Trigger but accepts a context.Context.
+func (s *IdsecSecHubScansService) TriggerContext(ctx context.Context, triggerScan *scansmodels.IdsecSecHubTriggerScans) (*scansmodels.IdsecSecHubScanIDs, error) {
 	bodyMap := scansmodels.IdsecSecHubScanMap{
 		Scope: scansmodels.IdsecSecHubSecretStoreIds{
 			SecretStoresIds: triggerScan.SecretStoresIds,
@@ -130,7 +152,7 @@ func (s *IdsecSecHubScansService) Trigger(triggerScan *scansmodels.IdsecSecHubTr
 		return nil, err
 	}
 	s.Logger.Info("Triggering scan. Scan ID %s", triggerScan.ID)
-	response, err := s.ISPClient().Post(context.Background(), fmt.Sprintf(triggerURL, triggerScan.Type, triggerScan.ID), bodyMapJSON)
+	response, err := s.ISPClient().Post(ctx, fmt.Sprintf(triggerURL, triggerScan.Type, triggerScan.ID), bodyMapJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,255 @@
+package scans
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	scansmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/scans/models"
+	secretsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/secrets/models"
+)
+
+const (
+	defaultPollIntervalSeconds    = 5
+	defaultMaxPollIntervalSeconds = 60
+	defaultBackoffMultiplier      = 1.5
+	defaultWaitTimeoutSeconds     = 1800
+	secretsByStoreFilter          = "storeId EQ %s"
+)
+
+// IdsecSecHubScansWaitOptions configures how progress is reported while waiting for triggered scans.
+//
+// Both OnProgress and Progress are optional and may be combined. Progress updates are only
+// emitted when the status of a secret store scan changes. Sends on Progress block until the
+// update is received or the wait context is done; the channel is never closed by the service.
+type IdsecSecHubScansWaitOptions struct {
+	OnProgress func(progress *scansmodels.IdsecSecHubScanStoreProgress)
+	Progress   chan<- *scansmodels.IdsecSecHubScanStoreProgress
+}
+
+// scanWaitState tracks the last known state of every triggered scan.
+type scanWaitState struct {
+	scans map[string]*scansmodels.IdsecSecHubScan
+}
+
+func isScanFinished(status string) bool {
+	return status == scansmodels.ScanStatusSuccess || status == scansmodels.ScanStatusFailed
+}
+
+// applyWaitDefaults fills in the polling parameters that were left empty.
+func applyWaitDefaults(req *scansmodels.IdsecSecHubTriggerAndWaitScans) scansmodels.IdsecSecHubTriggerAndWaitScans {
+	params := *req
+	if params.PollIntervalSeconds <= 0 {
+		params.PollIntervalSeconds = defaultPollIntervalSeconds
+	}
+	if params.MaxPollIntervalSeconds <= 0 {
+		params.MaxPollIntervalSeconds = defaultMaxPollIntervalSeconds
+	}
+	if params.MaxPollIntervalSeconds < params.PollIntervalSeconds {
+		params.MaxPollIntervalSeconds = params.PollIntervalSeconds
+	}
+	if params.BackoffMultiplier < 1 {
+		params.BackoffMultiplier = defaultBackoffMultiplier
+	}
+	if params.TimeoutSeconds <= 0 {
+		params.TimeoutSeconds = defaultWaitTimeoutSeconds
+	}
+	return params
+}
+
+// nextPollInterval returns the interval to wait before the next poll, applying backoff and the configured cap.
+func nextPollInterval(current time.Duration, multiplier float64, maxInterval time.Duration) time.Duration {
+	next := time.Duration(float64(current) * multiplier)
+	if next > maxInterval {
+		return maxInterval
+	}
+	return next
+}
+
+func (s *IdsecSecHubScansService) reportProgress(ctx context.Context, opts *IdsecSecHubScansWaitOptions, progress *scansmodels.IdsecSecHubScanStoreProgress) {
+	s.Logger.Info("Scan [%s] of store [%s] status [%s]", progress.ScanID, progress.StoreID, progress.Status)
+	if opts == nil {
+		return
+	}
+	if opts.OnProgress != nil {
+		opts.OnProgress(progress)
+	}
+	if opts.Progress != nil {
+		select {
+		case opts.Progress <- progress:
+		case <-ctx.Done():
+		}
+	}
+}
+
+// pollScans retrieves the current scans once, updates the wait state and reports status changes.
+// Returns true when all the triggered scans reached a final status.
+func (s *IdsecSecHubScansService) pollScans(ctx context.Context, scanIDs []string, state *scanWaitState, opts *IdsecSecHubScansWaitOptions) (bool, error) {
+	scans, err := s.listScans(ctx)
+	if err != nil {
+		return false, err
+	}
+	scansByID := make(map[string]*scansmodels.IdsecSecHubScan, len(scans))
+	for _, scan := range scans {
+		scansByID[scan.ID] = scan
+	}
+	allFinished := true
+	for _, scanID := range scanIDs {
+		scan, ok := scansByID[scanID]
+		if !ok {
+			allFinished = false
+			continue
+		}
+		previous, seen := state.scans[scanID]
+		if !seen || previous.Status != scan.Status {
+			progress := &scansmodels.IdsecSecHubScanStoreProgress{
+				ScanID:   scan.ID,
+				StoreID:  scan.Metadata.StoreID,
+				Status:   scan.Status,
+				Message:  scan.Message,
+				Finished: isScanFinished(scan.Status),
+			}
+			if seen {
+				progress.PreviousStatus = previous.Status
+			}
+			s.reportProgress(ctx, opts, progress)
+		}
+		state.scans[scanID] = scan
+		if !isScanFinished(scan.Status) {
+			allFinished = false
+		}
+	}
+	return allFinished, nil
+}
+
+// countScanSecrets returns the number of secrets of the scanned secret store that the scan found,
+// that is the secrets last scanned since the scan started. The secrets of the store that the scan
+// no longer found keep an older scan time and are not counted.
+func (s *IdsecSecHubScansService) countScanSecrets(ctx context.Context, scan *scansmodels.IdsecSecHubScan) (int, error) {
+	scanStartedAt, err := time.Parse(time.RFC3339Nano, scan.StartedAt)
+	if err != nil {
+		return 0, fmt.Errorf("scan [%s] has no valid start time [%s]: %v", scan.ID, scan.StartedAt, err)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	secretsPages, err := s.secretsService.ListByContext(ctx, &secretsmodels.IdsecSecHubSecretsFilter{
+		Filter: fmt.Sprintf(secretsByStoreFilter, scan.Metadata.StoreID),
+	})
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for page := range secretsPages {
+		if page.Err != nil {
+			return 0, page.Err
+		}
+		for _, secret := range page.Items {
+			lastScannedAt, err := time.Parse(time.RFC3339Nano, secret.LastScannedAt)
+			if err != nil || lastScannedAt.Before(scanStartedAt) {
+				continue
+			}
+			count++
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// buildScansSummary builds the final summary out of the wait state.
+func (s *IdsecSecHubScansService) buildScansSummary(ctx context.Context, scanIDs []string, state *scanWaitState, countSecrets bool, started time.Time) *scansmodels.IdsecSecHubScansSummary {
+	summary := &scansmodels.IdsecSecHubScansSummary{
+		ScanIDs:       scanIDs,
+		Stores:        make([]scansmodels.IdsecSecHubScanStoreResult, 0, len(scanIDs)),
+		ErrorsByStore: make(map[string]string),
+	}
+	for _, scanID := range scanIDs {
+		scan := state.scans[scanID]
+		result := scansmodels.IdsecSecHubScanStoreResult{
+			ScanID:     scan.ID,
+			StoreID:    scan.Metadata.StoreID,
+			Status:     scan.Status,
+			Message:    scan.Message,
+			StartedAt:  scan.StartedAt,
+			FinishedAt: scan.FinishedAt,
+		}
+		if scan.Status == scansmodels.ScanStatusSuccess {
+			summary.SucceededCount++
+			if countSecrets && s.secretsService != nil && result.StoreID != "" {
+				count, err := s.countScanSecrets(ctx, scan)
+				if err != nil {
+					// The store is left out of the total rather than counted as having no secrets
+					s.Logger.Warning("Failed to count secrets found by scan [%s] of store [%s]: %v", scan.ID, result.StoreID, err)
+					result.SecretsCountError = err.Error()
+				} else {
+					result.SecretsFound = count
+					summary.SecretsFound += count
+				}
+			}
+		} else {
+			summary.FailedCount++
+			summary.ErrorsByStore[result.StoreID] = scan.Message
+		}
+		summary.Stores = append(summary.Stores, result)
+	}
+	sort.Slice(summary.Stores, func(i, j int) bool {
+		return summary.Stores[i].StoreID < summary.Stores[j].StoreID
+	})
+	summary.DurationSeconds = time.Since(started).Seconds()
+	return summary
+}
+
+// TriggerAndWait triggers scans in the Secrets Hub service and waits until all of them finish.
+// Progress is logged for every secret store scan status change.
+func (s *IdsecSecHubScansService) TriggerAndWait(triggerAndWait *scansmodels.IdsecSecHubTriggerAndWaitScans) (*scansmodels.IdsecSecHubScansSummary, error) {
+	return s.TriggerAndWaitContext(context.Background(), triggerAndWait, nil)
+}
+
+// TriggerAndWaitContext is like TriggerAndWait but accepts a context.Context and optional progress reporting options.
+//
+// The scans are polled starting at PollIntervalSeconds, multiplying the interval by BackoffMultiplier after every
+// poll up to MaxPollIntervalSeconds. Waiting stops with an error when the context is cancelled or TimeoutSeconds
+// elapse. A scan that ends in FAILED status does not fail the call; it is reported in the summary's ErrorsByStore.
+func (s *IdsecSecHubScansService) TriggerAndWaitContext(ctx context.Context, triggerAndWait *scansmodels.IdsecSecHubTriggerAndWaitScans, opts *IdsecSecHubScansWaitOptions) (*scansmodels.IdsecSecHubScansSummary, error) {
+	if triggerAndWait == nil {
+		return nil, fmt.Errorf("trigger and wait request cannot be nil")
+	}
+	params := applyWaitDefaults(triggerAndWait)
+	started := time.Now()
+	scanIDs, err := s.TriggerContext(ctx, &params.IdsecSecHubTriggerScans)
+	if err != nil {
+		return nil, err
+	}
+	if len(scanIDs.ScanIDs) == 0 {
+		return nil, fmt.Errorf("no scans were triggered")
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(params.TimeoutSeconds)*time.Second)
+	defer cancel()
+
+	state := &scanWaitState{scans: make(map[string]*scansmodels.IdsecSecHubScan)}
+	interval := time.Duration(params.PollIntervalSeconds) * time.Second
+	maxInterval := time.Duration(params.MaxPollIntervalSeconds) * time.Second
+	for {
+		finished, err := s.pollScans(ctx, scanIDs.ScanIDs, state, opts)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("waiting for scans canceled: %v", ctx.Err())
+			}
+			return nil, err
+		}
+		if finished {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for scans canceled: %v", ctx.Err())
+		case <-time.After(interval):
+		}
+		interval = nextPollInterval(interval, params.BackoffMultiplier, maxInterval)
+	}
+	summary := s.buildScansSummary(ctx, scanIDs.ScanIDs, state, !params.SkipSecretsCount, started)
+	s.Logger.Info("Scans finished, [%d] succeeded, [%d] failed, [%d] secrets found", summary.SucceededCount, summary.FailedCount, summary.SecretsFound)
+	return summary, nil
+}
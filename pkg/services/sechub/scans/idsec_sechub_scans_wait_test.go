@@ -0,0 +1,285 @@
+package scans
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services"
+	scansmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/scans/models"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/secrets"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockISPBaseService creates an IdsecISPBaseService wired to the given test server.
+func newMockISPBaseService(t *testing.T, serverURL string) *services.IdsecISPBaseService {
+	t.Helper()
+
+	client := common.NewIdsecClient("", "", "", "Authorization", nil, nil, "", false)
+	client.BaseURL = serverURL
+
+	ispClient := &isp.IdsecISPServiceClient{
+		IdsecClient: client,
+	}
+
+	ispBase := &services.IdsecISPBaseService{}
+	v := reflect.ValueOf(ispBase).Elem()
+	clientField := v.FieldByName("client")
+	clientField = reflect.NewAt(clientField.Type(), unsafe.Pointer(clientField.UnsafeAddr())).Elem()
+	clientField.Set(reflect.ValueOf(ispClient))
+	return ispBase
+}
+
+// newMockScansService creates an IdsecSecHubScansService and its secrets service wired to the given test server.
+func newMockScansService(t *testing.T, serverURL string) *IdsecSecHubScansService {
+	t.Helper()
+	baseService := &services.IdsecBaseService{
+		Logger: common.GlobalLogger,
+	}
+	return &IdsecSecHubScansService{
+		IdsecBaseService:    baseService,
+		IdsecISPBaseService: newMockISPBaseService(t, serverURL),
+		secretsService: &secrets.IdsecSecHubSecretsService{
+			IdsecBaseService:    baseService,
+			IdsecISPBaseService: newMockISPBaseService(t, serverURL),
+		},
+	}
+}
+
+func TestTriggerAndWaitContext(t *testing.T) {
+	tests := []struct {
+		name             string
+		scansResponses   []string
+		timeoutSeconds   int
+		expectedError    bool
+		expectedErrorMsg string
+		validateFunc     func(t *testing.T, summary *scansmodels.IdsecSecHubScansSummary, progress []*scansmodels.IdsecSecHubScanStoreProgress)
+	}{
+		{
+			name: "success_all_scans_finish",
+			scansResponses: []string{
+				`{"scans": [
+					{"id": "scan-1", "metadata": {"store_id": "store-a"}, "status": "IN_PROGRESS"},
+					{"id": "scan-2", "metadata": {"store_id": "store-b"}, "status": "IN_PROGRESS"}
+				]}`,
+				`{"scans": [
+					{"id": "scan-1", "metadata": {"store_id": "store-a"}, "status": "SUCCESS", "started_at": "2026-01-01T10:00:00+00:00"},
+					{"id": "scan-2", "metadata": {"store_id": "store-b"}, "status": "FAILED", "message": "access denied"},
+					{"id": "scan-old", "metadata": {"store_id": "store-c"}, "status": "SUCCESS"}
+				]}`,
+			},
+			validateFunc: func(t *testing.T, summary *scansmodels.IdsecSecHubScansSummary, progress []*scansmodels.IdsecSecHubScanStoreProgress) {
+				require.Equal(t, 1, summary.SucceededCount)
+				require.Equal(t, 1, summary.FailedCount)
+				require.Equal(t, 2, summary.SecretsFound)
+				require.Equal(t, map[string]string{"store-b": "access denied"}, summary.ErrorsByStore)
+				require.Len(t, summary.Stores, 2)
+				require.Equal(t, "store-a", summary.Stores[0].StoreID)
+				require.Equal(t, 2, summary.Stores[0].SecretsFound)
+				require.Len(t, progress, 4)
+				require.Empty(t, progress[0].PreviousStatus)
+				require.Equal(t, scansmodels.ScanStatusInProgress, progress[0].Status)
+				require.Equal(t, scansmodels.ScanStatusInProgress, progress[2].PreviousStatus)
+				require.True(t, progress[3].Finished)
+			},
+		},
+		{
+			name: "success_scans_appear_after_first_poll",
+			scansResponses: []string{
+				`{"scans": []}`,
+				`{"scans": [
+					{"id": "scan-1", "metadata": {"store_id": "store-a"}, "status": "SUCCESS", "started_at": "2026-01-01T10:00:00+00:00"},
+					{"id": "scan-2", "metadata": {"store_id": "store-b"}, "status": "SUCCESS", "started_at": "2026-01-01T10:00:00.500000+00:00"}
+				]}`,
+			},
+			validateFunc: func(t *testing.T, summary *scansmodels.IdsecSecHubScansSummary, progress []*scansmodels.IdsecSecHubScanStoreProgress) {
+				require.Equal(t, 2, summary.SucceededCount)
+				require.Equal(t, 4, summary.SecretsFound)
+				require.Empty(t, summary.ErrorsByStore)
+				require.Len(t, progress, 2)
+			},
+		},
+		{
+			name: "success_secrets_count_fails_for_one_store",
+			scansResponses: []string{
+				`{"scans": [
+					{"id": "scan-1", "metadata": {"store_id": "store-a"}, "status": "SUCCESS", "started_at": "2026-01-01T10:00:00+00:00"},
+					{"id": "scan-2", "metadata": {"store_id": "store-forbidden"}, "status": "SUCCESS", "started_at": "2026-01-01T10:00:00+00:00"}
+				]}`,
+			},
+			validateFunc: func(t *testing.T, summary *scansmodels.IdsecSecHubScansSummary, progress []*scansmodels.IdsecSecHubScanStoreProgress) {
+				require.Equal(t, 2, summary.SucceededCount)
+				require.Equal(t, 2, summary.SecretsFound, "the store that could not be counted is left out of the total")
+				require.Empty(t, summary.Stores[0].SecretsCountError)
+				require.Equal(t, "store-forbidden", summary.Stores[1].StoreID)
+				require.Equal(t, 0, summary.Stores[1].SecretsFound)
+				require.Contains(t, summary.Stores[1].SecretsCountError, "failed to list Secrets - [403]")
+			},
+		},
+		{
+			name: "error_timeout_while_scans_in_progress",
+			scansResponses: []string{
+				`{"scans": [
+					{"id": "scan-1", "metadata": {"store_id": "store-a"}, "status": "IN_PROGRESS"},
+					{"id": "scan-2", "metadata": {"store_id": "store-b"}, "status": "IN_PROGRESS"}
+				]}`,
+			},
+			timeoutSeconds:   1,
+			expectedError:    true,
+			expectedErrorMsg: "waiting for scans canceled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var scansCallCount int
+			var mu sync.Mutex
+			testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/scan"):
+					w.WriteHeader(http.StatusAccepted)
+					_, _ = w.Write([]byte(`{"scan_ids": ["scan-1", "scan-2"]}`))
+				case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/api/scans"):
+					mu.Lock()
+					index := scansCallCount
+					if index >= len(tt.scansResponses) {
+						index = len(tt.scansResponses) - 1
+					}
+					scansCallCount++
+					mu.Unlock()
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(tt.scansResponses[index]))
+				case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/api/secrets"):
+					if !strings.HasPrefix(r.URL.Query().Get("filter"), "storeId EQ store-") {
+						w.WriteHeader(http.StatusBadRequest)
+						return
+					}
+					if strings.HasSuffix(r.URL.Query().Get("filter"), "store-forbidden") {
+						w.WriteHeader(http.StatusForbidden)
+						_, _ = w.Write([]byte(`{"error": "forbidden"}`))
+						return
+					}
+					// Only the secrets scanned since the scan started were found by it
+					w.WriteHeader(http.StatusOK)
+					_, _ = w.Write([]byte(`{"secrets": [
+						{"id": "secret-1", "last_scanned_at": "2026-01-01T10:05:00.103000+00:00"},
+						{"id": "secret-2", "last_scanned_at": "2026-01-01T10:00:00.500000+00:00"},
+						{"id": "secret-3", "last_scanned_at": "2025-12-01T10:00:00+00:00"},
+						{"id": "secret-4"}
+					]}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer testServer.Close()
+
+			svc := newMockScansService(t, testServer.URL)
+			var progress []*scansmodels.IdsecSecHubScanStoreProgress
+			summary, err := svc.TriggerAndWaitContext(
+				context.Background(),
+				&scansmodels.IdsecSecHubTriggerAndWaitScans{
+					IdsecSecHubTriggerScans: scansmodels.IdsecSecHubTriggerScans{
+						ID:              "default",
+						Type:            "secret-store",
+						SecretStoresIds: []string{"store-a", "store-b"},
+					},
+					PollIntervalSeconds: 1,
+					TimeoutSeconds:      tt.timeoutSeconds,
+				},
+				&IdsecSecHubScansWaitOptions{
+					OnProgress: func(p *scansmodels.IdsecSecHubScanStoreProgress) {
+						progress = append(progress, p)
+					},
+				},
+			)
+			if tt.expectedError {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectedErrorMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []string{"scan-1", "scan-2"}, summary.ScanIDs)
+			if tt.validateFunc != nil {
+				tt.validateFunc(t, summary, progress)
+			}
+		})
+	}
+}
+
+func TestTriggerAndWaitContext_ProgressChannel(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"scan_ids": ["scan-1"]}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"scans": [{"id": "scan-1", "metadata": {"store_id": "store-a"}, "status": "SUCCESS"}]}`))
+	}))
+	defer testServer.Close()
+
+	svc := newMockScansService(t, testServer.URL)
+	progressChan := make(chan *scansmodels.IdsecSecHubScanStoreProgress, 1)
+	summary, err := svc.TriggerAndWaitContext(
+		context.Background(),
+		&scansmodels.IdsecSecHubTriggerAndWaitScans{SkipSecretsCount: true},
+		&IdsecSecHubScansWaitOptions{Progress: progressChan},
+	)
+	require.NoError(t, err)
+	require.Equal(t, 1, summary.SucceededCount)
+	require.Equal(t, 0, summary.SecretsFound)
+	update := <-progressChan
+	require.Equal(t, "store-a", update.StoreID)
+	require.True(t, update.Finished)
+}
+
+func TestTriggerAndWaitContext_CanceledContext(t *testing.T) {
+	var triggered bool
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		triggered = true
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"scan_ids": ["scan-1"]}`))
+	}))
+	defer testServer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	svc := newMockScansService(t, testServer.URL)
+	_, err := svc.TriggerAndWaitContext(ctx, &scansmodels.IdsecSecHubTriggerAndWaitScans{
+		IdsecSecHubTriggerScans: scansmodels.IdsecSecHubTriggerScans{ID: "default", Type: "secret-store"},
+	}, nil)
+	require.ErrorIs(t, err, context.Canceled)
+	require.False(t, triggered)
+}
+
+func TestTriggerAndWaitContext_NilRequest(t *testing.T) {
+	svc := &IdsecSecHubScansService{}
+	_, err := svc.TriggerAndWaitContext(context.Background(), nil, nil)
+	require.Error(t, err)
+}
+
+func TestNextPollInterval(t *testing.T) {
+	require.Equal(t, 3*time.Second, nextPollInterval(2*time.Second, 1.5, time.Minute))
+	require.Equal(t, 10*time.Second, nextPollInterval(8*time.Second, 2, 10*time.Second))
+	require.Equal(t, 5*time.Second, nextPollInterval(5*time.Second, 1, time.Minute))
+}
+
+func TestApplyWaitDefaults(t *testing.T) {
+	params := applyWaitDefaults(&scansmodels.IdsecSecHubTriggerAndWaitScans{MaxPollIntervalSeconds: 1, PollIntervalSeconds: 10})
+	require.Equal(t, 10, params.PollIntervalSeconds)
+	require.Equal(t, 10, params.MaxPollIntervalSeconds)
+	require.Equal(t, defaultBackoffMultiplier, params.BackoffMultiplier)
+	require.Equal(t, defaultWaitTimeoutSeconds, params.TimeoutSeconds)
+}
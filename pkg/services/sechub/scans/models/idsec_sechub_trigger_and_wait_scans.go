@@ -0,0 +1,51 @@
+package models
+
+// Possible scan statuses reported by the Secrets Hub scans API.
+const (
+	ScanStatusInProgress = "IN_PROGRESS"
+	ScanStatusSuccess    = "SUCCESS"
+	ScanStatusFailed     = "FAILED"
+)
+
+// IdsecSecHubTriggerAndWaitScans represents the request structure for triggering scans and waiting for them to finish.
+type IdsecSecHubTriggerAndWaitScans struct {
+	IdsecSecHubTriggerScans `mapstructure:",squash"`
+	PollIntervalSeconds     int     `json:"poll_interval_seconds,omitempty" mapstructure:"poll_interval_seconds,omitempty" flag:"poll-interval-seconds" desc:"Initial interval in seconds between scan status polls, defaulted to 5" default:"5" validate:"omitempty,min=1"`
+	MaxPollIntervalSeconds  int     `json:"max_poll_interval_seconds,omitempty" mapstructure:"max_poll_interval_seconds,omitempty" flag:"max-poll-interval-seconds" desc:"Maximal interval in seconds between scan status polls, defaulted to 60" default:"60" validate:"omitempty,min=1"`
+	BackoffMultiplier       float64 `json:"backoff_multiplier,omitempty" mapstructure:"backoff_multiplier,omitempty" flag:"backoff-multiplier" desc:"Multiplier applied to the poll interval after every poll, defaulted to 1.5" default:"1.5" validate:"omitempty,min=1"`
+	TimeoutSeconds          int     `json:"timeout_seconds,omitempty" mapstructure:"timeout_seconds,omitempty" flag:"timeout-seconds" desc:"Overall time in seconds to wait for the scans to finish, defaulted to 1800" default:"1800" validate:"omitempty,min=1"`
+	SkipSecretsCount        bool    `json:"skip_secrets_count,omitempty" mapstructure:"skip_secrets_count,omitempty" flag:"skip-secrets-count" desc:"Whether to skip counting the secrets found by every secret store scan once the scans finish" default:"false"`
+}
+
+// IdsecSecHubScanStoreProgress represents a status change of a single secret store scan while waiting for scans to finish.
+type IdsecSecHubScanStoreProgress struct {
+	ScanID         string `json:"scan_id" mapstructure:"scan_id" desc:"Scan ID"`
+	StoreID        string `json:"store_id" mapstructure:"store_id" desc:"Store ID associated with the scan"`
+	PreviousStatus string `json:"previous_status,omitempty" mapstructure:"previous_status,omitempty" desc:"Scan status before this update, empty when the scan is first observed"`
+	Status         string `json:"status" mapstructure:"status" desc:"Current scan status"`
+	Message        string `json:"message,omitempty" mapstructure:"message,omitempty" desc:"Scan message"`
+	Finished       bool   `json:"finished" mapstructure:"finished" desc:"Whether the scan reached a final status"`
+}
+
+// IdsecSecHubScanStoreResult represents the final result of a single secret store scan.
+type IdsecSecHubScanStoreResult struct {
+	ScanID            string `json:"scan_id" mapstructure:"scan_id" desc:"Scan ID"`
+	StoreID           string `json:"store_id" mapstructure:"store_id" desc:"Store ID associated with the scan"`
+	Status            string `json:"status" mapstructure:"status" desc:"Final scan status"`
+	Message           string `json:"message,omitempty" mapstructure:"message,omitempty" desc:"Scan message"`
+	StartedAt         string `json:"started_at,omitempty" mapstructure:"started_at,omitempty" desc:"Scan start time"`
+	FinishedAt        string `json:"finished_at,omitempty" mapstructure:"finished_at,omitempty" desc:"Scan finish time"`
+	SecretsFound      int    `json:"secrets_found" mapstructure:"secrets_found" desc:"Number of secrets the scan found in the secret store"`
+	SecretsCountError string `json:"secrets_count_error,omitempty" mapstructure:"secrets_count_error,omitempty" desc:"Why the secrets found by the scan could not be counted"`
+}
+
+// IdsecSecHubScansSummary represents the final summary of triggered scans once they all finished.
+type IdsecSecHubScansSummary struct {
+	ScanIDs         []string                     `json:"scan_ids" mapstructure:"scan_ids" desc:"List of scan IDs that were triggered"`
+	Stores          []IdsecSecHubScanStoreResult `json:"stores" mapstructure:"stores" desc:"Final result per secret store"`
+	SucceededCount  int                          `json:"succeeded_count" mapstructure:"succeeded_count" desc:"Number of secret store scans that succeeded"`
+	FailedCount     int                          `json:"failed_count" mapstructure:"failed_count" desc:"Number of secret store scans that failed"`
+	SecretsFound    int                          `json:"secrets_found" mapstructure:"secrets_found" desc:"Overall number of secrets found in the scanned secret stores whose secrets could be counted"`
+	ErrorsByStore   map[string]string            `json:"errors_by_store" mapstructure:"errors_by_store" desc:"Scan error messages by store ID"`
+	DurationSeconds float64                      `json:"duration_seconds" mapstructure:"duration_seconds" desc:"Time in seconds it took for the scans to finish"`
+}
@@ -7,4 +7,5 @@ var ActionToSchemaMap = map[string]interface{}{
 	"get":     nil,
 	"list-by": &sechubsecrets.IdsecSecHubSecretsFilter{},
 	"stats":   nil,
+	"report":  &sechubsecrets.IdsecSecHubSecretsReportRequest{},
 }
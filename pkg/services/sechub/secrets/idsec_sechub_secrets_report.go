@@ -0,0 +1,299 @@
+package secrets
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	secretsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/secrets/models"
+)
+
+const (
+	defaultReportStaleDays = 90
+	extendedProjection     = "EXTEND"
+	unknownGroupKey        = "unknown"
+)
+
+// secretTimeLayouts are the layouts Secrets Hub and the cloud vendors use for secret timestamps.
+var secretTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05.999999-07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+var reportCSVHeader = []string{
+	"id", "name", "store_id", "store_name", "vendor_type", "vendor_sub_type", "region",
+	"last_rotated_at", "days_since_rotation", "unmanaged", "stale", "duplicated", "unsynced",
+}
+
+var reportHTMLTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Secrets Hub Inventory Risk Report</title>
+<style>
+body { font-family: Arial, Helvetica, sans-serif; margin: 24px; color: #222; }
+h1 { font-size: 22px; }
+h2 { font-size: 18px; margin-top: 28px; }
+table { border-collapse: collapse; margin-top: 8px; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; font-size: 13px; }
+th { background: #f0f0f0; }
+td.flag { color: #b00020; font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>Secrets Hub Inventory Risk Report</h1>
+<p>Generated at {{.GeneratedAt}}, stale threshold {{.StaleDays}} days.</p>
+<table>
+<tr><th>Secrets</th><th>Unmanaged</th><th>Stale</th><th>Duplicated</th><th>Unsynced</th></tr>
+<tr><td>{{.Totals.SecretsCount}}</td><td>{{.Totals.UnmanagedCount}}</td><td>{{.Totals.StaleCount}}</td><td>{{.Totals.DuplicatedCount}}</td><td>{{.Totals.UnsyncedCount}}</td></tr>
+</table>
+{{define "groups"}}<table>
+<tr><th>Group</th><th>Secrets</th><th>Unmanaged</th><th>Stale</th><th>Duplicated</th><th>Unsynced</th></tr>
+{{range .}}<tr><td>{{.Key}}</td><td>{{.SecretsCount}}</td><td>{{.UnmanagedCount}}</td><td>{{.StaleCount}}</td><td>{{.DuplicatedCount}}</td><td>{{.UnsyncedCount}}</td></tr>
+{{end}}</table>{{end}}
+<h2>By Store</h2>
+{{template "groups" .ByStore}}
+<h2>By Vendor</h2>
+{{template "groups" .ByVendor}}
+<h2>By Region</h2>
+{{template "groups" .ByRegion}}
+<h2>Secrets</h2>
+<table>
+<tr><th>Name</th><th>Store</th><th>Vendor</th><th>Region</th><th>Last Rotated</th><th>Days</th><th>Unmanaged</th><th>Stale</th><th>Duplicated</th><th>Unsynced</th></tr>
+{{range .Secrets}}<tr><td>{{.Name}}</td><td>{{.StoreName}}</td><td>{{.VendorType}} {{.VendorSubType}}</td><td>{{.Region}}</td><td>{{.LastRotatedAt}}</td><td>{{.DaysSinceRotation}}</td>{{if .Unmanaged}}<td class="flag">yes</td>{{else}}<td>no</td>{{end}}{{if .Stale}}<td class="flag">yes</td>{{else}}<td>no</td>{{end}}{{if .Duplicated}}<td class="flag">yes</td>{{else}}<td>no</td>{{end}}{{if .Unsynced}}<td class="flag">yes</td>{{else}}<td>no</td>{{end}}</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// parseSecretTime parses a secret timestamp in any of the known layouts.
+func parseSecretTime(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	for _, layout := range secretTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// groupKey returns the key to group by, falling back to unknownGroupKey for empty values.
+func groupKey(parts ...string) string {
+	nonEmpty := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return unknownGroupKey
+	}
+	return strings.Join(nonEmpty, "/")
+}
+
+// addToGroup adds the flags of the report entry to the group counts.
+func addToGroup(group *secretsmodels.IdsecSecHubSecretsReportGroup, entry *secretsmodels.IdsecSecHubSecretReportEntry) {
+	group.SecretsCount++
+	if entry.Unmanaged {
+		group.UnmanagedCount++
+	}
+	if entry.Stale {
+		group.StaleCount++
+	}
+	if entry.Duplicated {
+		group.DuplicatedCount++
+	}
+	if entry.Unsynced {
+		group.UnsyncedCount++
+	}
+}
+
+// addToKeyedGroup adds the flags of the report entry to the group of the given key, creating it when missing.
+func addToKeyedGroup(groups map[string]*secretsmodels.IdsecSecHubSecretsReportGroup, key string, entry *secretsmodels.IdsecSecHubSecretReportEntry) {
+	if groups[key] == nil {
+		groups[key] = &secretsmodels.IdsecSecHubSecretsReportGroup{Key: key}
+	}
+	addToGroup(groups[key], entry)
+}
+
+// sortedGroups returns the groups sorted by key.
+func sortedGroups(groups map[string]*secretsmodels.IdsecSecHubSecretsReportGroup) []secretsmodels.IdsecSecHubSecretsReportGroup {
+	result := make([]secretsmodels.IdsecSecHubSecretsReportGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, *group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// buildSecretsReport builds the risk report out of the given secrets.
+func buildSecretsReport(secrets []*secretsmodels.IdsecSecHubSecret, staleDays int, now time.Time) *secretsmodels.IdsecSecHubSecretsReport {
+	storesByName := make(map[string]map[string]bool)
+	for _, secret := range secrets {
+		name := strings.ToLower(secret.Name)
+		if storesByName[name] == nil {
+			storesByName[name] = make(map[string]bool)
+		}
+		storesByName[name][secret.StoreID] = true
+	}
+	report := &secretsmodels.IdsecSecHubSecretsReport{
+		GeneratedAt: now.UTC().Format(time.RFC3339),
+		StaleDays:   staleDays,
+		Totals:      secretsmodels.IdsecSecHubSecretsReportGroup{Key: "total"},
+		Secrets:     make([]secretsmodels.IdsecSecHubSecretReportEntry, 0, len(secrets)),
+	}
+	byStore := make(map[string]*secretsmodels.IdsecSecHubSecretsReportGroup)
+	byVendor := make(map[string]*secretsmodels.IdsecSecHubSecretsReportGroup)
+	byRegion := make(map[string]*secretsmodels.IdsecSecHubSecretsReportGroup)
+	for _, secret := range secrets {
+		entry := secretsmodels.IdsecSecHubSecretReportEntry{
+			ID:                secret.ID,
+			Name:              secret.Name,
+			StoreID:           secret.StoreID,
+			StoreName:         secret.StoreName,
+			VendorType:        secret.VendorType,
+			VendorSubType:     secret.VendorSubType,
+			Region:            secret.VendorData.Region,
+			DaysSinceRotation: -1,
+			Unmanaged:         !secret.Onboarded,
+			Duplicated:        len(storesByName[strings.ToLower(secret.Name)]) > 1,
+			Unsynced:          secret.Onboarded && !secret.SyncedByCyberArk,
+		}
+		entry.LastRotatedAt = secret.VendorData.UpdatedAt
+		if entry.LastRotatedAt == "" {
+			entry.LastRotatedAt = secret.VendorData.CreatedAt
+		}
+		if rotatedAt, ok := parseSecretTime(entry.LastRotatedAt); ok {
+			entry.DaysSinceRotation = int(now.Sub(rotatedAt).Hours() / 24)
+			entry.Stale = entry.DaysSinceRotation > staleDays
+		}
+		addToKeyedGroup(byStore, groupKey(secret.StoreName, secret.StoreID), &entry)
+		addToKeyedGroup(byVendor, groupKey(secret.VendorType, secret.VendorSubType), &entry)
+		addToKeyedGroup(byRegion, groupKey(secret.VendorData.Region), &entry)
+		addToGroup(&report.Totals, &entry)
+		report.Secrets = append(report.Secrets, entry)
+	}
+	sort.Slice(report.Secrets, func(i, j int) bool {
+		if report.Secrets[i].StoreName != report.Secrets[j].StoreName {
+			return report.Secrets[i].StoreName < report.Secrets[j].StoreName
+		}
+		return report.Secrets[i].Name < report.Secrets[j].Name
+	})
+	report.ByStore = sortedGroups(byStore)
+	report.ByVendor = sortedGroups(byVendor)
+	report.ByRegion = sortedGroups(byRegion)
+	return report
+}
+
+// writeReportCSV writes a row per secret of the report as CSV.
+func writeReportCSV(w io.Writer, report *secretsmodels.IdsecSecHubSecretsReport) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(reportCSVHeader); err != nil {
+		return err
+	}
+	for _, entry := range report.Secrets {
+		if err := writer.Write([]string{
+			entry.ID,
+			entry.Name,
+			entry.StoreID,
+			entry.StoreName,
+			entry.VendorType,
+			entry.VendorSubType,
+			entry.Region,
+			entry.LastRotatedAt,
+			strconv.Itoa(entry.DaysSinceRotation),
+			strconv.FormatBool(entry.Unmanaged),
+			strconv.FormatBool(entry.Stale),
+			strconv.FormatBool(entry.Duplicated),
+			strconv.FormatBool(entry.Unsynced),
+		}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeSecretsReport renders the report in the given format to w.
+func writeSecretsReport(w io.Writer, report *secretsmodels.IdsecSecHubSecretsReport, format string) error {
+	switch format {
+	case "", secretsmodels.SecretsReportFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case secretsmodels.SecretsReportFormatCSV:
+		return writeReportCSV(w, report)
+	case secretsmodels.SecretsReportFormatHTML:
+		return reportHTMLTemplate.Execute(w, report)
+	default:
+		return fmt.Errorf("unsupported report format [%s]", format)
+	}
+}
+
+// Report generates an inventory risk report of the secrets known to Secrets Hub.
+// Secrets are grouped by store, vendor and region, and flagged as unmanaged when not onboarded to PAM,
+// stale when not updated within StaleDays, duplicated when a secret with the same name exists in another
+// store and unsynced when not synced by Idira. When OutputFile is set, the report is also rendered to it
+// in the requested format.
+func (s *IdsecSecHubSecretsService) Report(reportRequest *secretsmodels.IdsecSecHubSecretsReportRequest) (*secretsmodels.IdsecSecHubSecretsReport, error) {
+	if reportRequest == nil {
+		reportRequest = &secretsmodels.IdsecSecHubSecretsReportRequest{}
+	}
+	switch reportRequest.Format {
+	case "", secretsmodels.SecretsReportFormatJSON, secretsmodels.SecretsReportFormatCSV, secretsmodels.SecretsReportFormatHTML:
+	default:
+		return nil, fmt.Errorf("unsupported report format [%s]", reportRequest.Format)
+	}
+	staleDays := reportRequest.StaleDays
+	if staleDays <= 0 {
+		staleDays = defaultReportStaleDays
+	}
+	s.Logger.Info("Generating secrets report")
+	secretsChan, err := s.ListBy(&secretsmodels.IdsecSecHubSecretsFilter{
+		Projection: extendedProjection,
+		Filter:     reportRequest.Filter,
+	})
+	if err != nil {
+		return nil, err
+	}
+	secrets := make([]*secretsmodels.IdsecSecHubSecret, 0)
+	for page := range secretsChan {
+		if page.Err != nil {
+			return nil, page.Err
+		}
+		secrets = append(secrets, page.Items...)
+	}
+	report := buildSecretsReport(secrets, staleDays, time.Now())
+	if reportRequest.OutputFile != "" {
+		file, err := os.Create(reportRequest.OutputFile)
+		if err != nil {
+			return nil, err
+		}
+		defer func(file *os.File) {
+			err := file.Close()
+			if err != nil {
+				s.Logger.Warning("Error closing output file")
+			}
+		}(file)
+		if err := writeSecretsReport(file, report, reportRequest.Format); err != nil {
+			return nil, err
+		}
+	}
+	s.Logger.Info("Secrets report generated, [%d] secrets, [%d] stale, [%d] unmanaged", report.Totals.SecretsCount, report.Totals.StaleCount, report.Totals.UnmanagedCount)
+	return report, nil
+}
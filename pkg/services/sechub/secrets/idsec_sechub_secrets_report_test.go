@@ -0,0 +1,218 @@
+package secrets
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services"
+	secretsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/secrets/models"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockSecretsService creates an IdsecSecHubSecretsService wired to the given test server.
+func newMockSecretsService(t *testing.T, serverURL string) *IdsecSecHubSecretsService {
+	t.Helper()
+
+	client := common.NewIdsecClient("", "", "", "Authorization", nil, nil, "", false)
+	client.BaseURL = serverURL
+
+	ispClient := &isp.IdsecISPServiceClient{
+		IdsecClient: client,
+	}
+
+	ispBase := &services.IdsecISPBaseService{}
+	v := reflect.ValueOf(ispBase).Elem()
+	clientField := v.FieldByName("client")
+	clientField = reflect.NewAt(clientField.Type(), unsafe.Pointer(clientField.UnsafeAddr())).Elem()
+	clientField.Set(reflect.ValueOf(ispClient))
+
+	return &IdsecSecHubSecretsService{
+		IdsecBaseService: &services.IdsecBaseService{
+			Logger: common.GlobalLogger,
+		},
+		IdsecISPBaseService: ispBase,
+	}
+}
+
+const (
+	reportSecretsFirstPage = `{"secrets": [
+		{"id": "s-1", "name": "db-admin", "store_id": "store-a", "store_name": "aws-prod", "vendor_type": "AWS", "vendor_sub_type": "ASM",
+			"onboarded": true, "synced_by_cyberark": true, "vendor_data": {"region": "us-east-1", "updated_at": "2020-01-01T00:00:00+00:00"}},
+		{"id": "s-2", "name": "api-key", "store_id": "store-a", "store_name": "aws-prod", "vendor_type": "AWS", "vendor_sub_type": "ASM",
+			"vendor_data": {"region": "us-east-1", "created_at": "RECENT"}}
+	], "nextLink": "/api/secrets?projection=EXTEND&offset=2"}`
+	reportSecretsSecondPage = `{"secrets": [
+		{"id": "s-3", "name": "DB-Admin", "store_id": "store-b", "store_name": "akv-prod", "vendor_type": "AZURE", "vendor_sub_type": "AKV",
+			"onboarded": true, "synced_by_cyberark": true},
+		{"id": "s-4", "name": "web-token", "store_id": "store-b", "store_name": "akv-prod", "vendor_type": "AZURE", "vendor_sub_type": "AKV",
+			"onboarded": true, "synced_by_cyberark": false}
+	]}`
+)
+
+func newReportTestServer(t *testing.T) (*httptest.Server, *[]string) {
+	t.Helper()
+	recent := time.Now().Add(-48 * time.Hour).UTC().Format("2006-01-02T15:04:05.000000")
+	var queries []string
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("offset") == "2" {
+			_, _ = w.Write([]byte(reportSecretsSecondPage))
+			return
+		}
+		_, _ = w.Write([]byte(strings.Replace(reportSecretsFirstPage, "RECENT", recent, 1)))
+	}))
+	return server, &queries
+}
+
+func TestReport(t *testing.T) {
+	tests := []struct {
+		name             string
+		request          *secretsmodels.IdsecSecHubSecretsReportRequest
+		expectedError    bool
+		expectedErrorMsg string
+		validateFunc     func(t *testing.T, report *secretsmodels.IdsecSecHubSecretsReport, outputFile string)
+	}{
+		{
+			name:    "success_flags_and_groups",
+			request: &secretsmodels.IdsecSecHubSecretsReportRequest{},
+			validateFunc: func(t *testing.T, report *secretsmodels.IdsecSecHubSecretsReport, outputFile string) {
+				require.Equal(t, 90, report.StaleDays)
+				require.Equal(t, 4, report.Totals.SecretsCount)
+				require.Equal(t, 1, report.Totals.UnmanagedCount)
+				require.Equal(t, 1, report.Totals.StaleCount)
+				require.Equal(t, 2, report.Totals.DuplicatedCount)
+				require.Equal(t, 1, report.Totals.UnsyncedCount)
+				byID := make(map[string]secretsmodels.IdsecSecHubSecretReportEntry)
+				for _, entry := range report.Secrets {
+					byID[entry.ID] = entry
+				}
+				require.True(t, byID["s-1"].Stale)
+				require.True(t, byID["s-1"].Duplicated)
+				require.False(t, byID["s-2"].Stale)
+				require.Equal(t, 2, byID["s-2"].DaysSinceRotation)
+				require.True(t, byID["s-2"].Unmanaged)
+				require.False(t, byID["s-2"].Unsynced, "a secret that is not onboarded is only reported as unmanaged")
+				require.True(t, byID["s-4"].Unsynced)
+				require.False(t, byID["s-4"].Unmanaged)
+				require.Equal(t, -1, byID["s-3"].DaysSinceRotation)
+				require.Equal(t, []secretsmodels.IdsecSecHubSecretsReportGroup{
+					{Key: "akv-prod/store-b", SecretsCount: 2, DuplicatedCount: 1, UnsyncedCount: 1},
+					{Key: "aws-prod/store-a", SecretsCount: 2, UnmanagedCount: 1, StaleCount: 1, DuplicatedCount: 1},
+				}, report.ByStore)
+				require.Equal(t, "unknown", report.ByRegion[0].Key)
+				require.Equal(t, "AWS/ASM", report.ByVendor[0].Key)
+			},
+		},
+		{
+			name:    "success_csv_output",
+			request: &secretsmodels.IdsecSecHubSecretsReportRequest{Format: "csv", StaleDays: 1},
+			validateFunc: func(t *testing.T, report *secretsmodels.IdsecSecHubSecretsReport, outputFile string) {
+				require.Equal(t, 2, report.Totals.StaleCount)
+				content, err := os.ReadFile(outputFile)
+				require.NoError(t, err)
+				lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+				require.Len(t, lines, 5)
+				require.True(t, strings.HasPrefix(lines[0], "id,name,store_id"))
+			},
+		},
+		{
+			name:    "success_html_output",
+			request: &secretsmodels.IdsecSecHubSecretsReportRequest{Format: "html"},
+			validateFunc: func(t *testing.T, report *secretsmodels.IdsecSecHubSecretsReport, outputFile string) {
+				content, err := os.ReadFile(outputFile)
+				require.NoError(t, err)
+				require.Contains(t, string(content), "<!DOCTYPE html>")
+				require.Contains(t, string(content), "aws-prod/store-a")
+			},
+		},
+		{
+			name:             "error_unsupported_format",
+			request:          &secretsmodels.IdsecSecHubSecretsReportRequest{Format: "xml"},
+			expectedError:    true,
+			expectedErrorMsg: "unsupported report format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			testServer, queries := newReportTestServer(t)
+			defer testServer.Close()
+
+			outputFile := ""
+			if tt.request.Format != "" {
+				outputFile = filepath.Join(t.TempDir(), "report."+tt.request.Format)
+				tt.request.OutputFile = outputFile
+			}
+			svc := newMockSecretsService(t, testServer.URL)
+			report, err := svc.Report(tt.request)
+			if tt.expectedError {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectedErrorMsg)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, *queries, 2)
+			require.Contains(t, (*queries)[0], "projection=EXTEND")
+			tt.validateFunc(t, report, outputFile)
+		})
+	}
+}
+
+func TestReport_PaginationError(t *testing.T) {
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("offset") == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error": "internal"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(reportSecretsFirstPage))
+	}))
+	defer testServer.Close()
+
+	svc := newMockSecretsService(t, testServer.URL)
+	_, err := svc.Report(nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to list Secrets")
+}
+
+func TestWriteSecretsReport_JSON(t *testing.T) {
+	report := buildSecretsReport(nil, 30, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	var buf bytes.Buffer
+	require.NoError(t, writeSecretsReport(&buf, report, "json"))
+	require.Contains(t, buf.String(), `"generated_at": "2024-01-01T00:00:00Z"`)
+	require.Contains(t, buf.String(), `"stale_days": 30`)
+}
+
+func TestParseSecretTime(t *testing.T) {
+	for _, value := range []string{
+		"2023-07-06T15:43:48.103000+00:00",
+		"2023-07-06T15:43:48.103000",
+		"2023-07-06T15:43:48Z",
+		"2023-07-06",
+	} {
+		parsed, ok := parseSecretTime(value)
+		require.True(t, ok, value)
+		require.Equal(t, 2023, parsed.Year())
+	}
+	_, ok := parseSecretTime("not a date")
+	require.False(t, ok)
+}
@@ -64,7 +64,55 @@ func (s *IdsecSecHubSecretsService) refreshSecHubAuth(client *common.IdsecClient
 	return nil
 }
 
+// fetchSecretsPage retrieves a single page of secrets and the link to the next page, empty when there is none.
+func (s *IdsecSecHubSecretsService) fetchSecretsPage(ctx context.Context, query map[string]string) ([]*secretsmodels.IdsecSecHubSecret, string, error) {
+	response, err := s.ISPClient().Get(ctx, sechubURL, query)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			common.GlobalLogger.Warning("Error closing response body")
+		}
+	}(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("failed to list Secrets - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	}
+	result, err := common.DeserializeJSONSnake(response.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode response: %v", err)
+	}
+	resultMap, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, "", fmt.Errorf("failed to list Secrets, unexpected result")
+	}
+	secretsJSON, ok := resultMap["secrets"].([]interface{})
+	if !ok {
+		return nil, "", fmt.Errorf("failed to list Secrets, unexpected result")
+	}
+	for i, secrets := range secretsJSON {
+		if secretsMap, ok := secrets.(map[string]interface{}); ok {
+			if secretStoreID, ok := secretsMap["id"]; ok {
+				secretsJSON[i].(map[string]interface{})["id"] = secretStoreID
+			}
+		}
+	}
+	var secrets []*secretsmodels.IdsecSecHubSecret
+	if err := mapstructure.Decode(secretsJSON, &secrets); err != nil {
+		return nil, "", fmt.Errorf("failed to validate Secrets: %v", err)
+	}
+	// Response keys are snake cased on deserialization, the camel case key is kept for safety
+	for _, key := range []string{"next_link", "nextLink"} {
+		if nextLink, ok := resultMap[key].(string); ok && nextLink != "" {
+			return secrets, nextLink, nil
+		}
+	}
+	return secrets, "", nil
+}
+
 func (s *IdsecSecHubSecretsService) getSecretsWithFilters(
+	ctx context.Context,
 	projection string,
 	filter string,
 	limit int,
@@ -88,61 +136,40 @@ func (s *IdsecSecHubSecretsService) getSecretsWithFilters(
 		query["sort"] = sort
 	}
 	results := make(chan *IdsecSecHubSecretsPage)
+	send := func(page *IdsecSecHubSecretsPage) bool {
+		select {
+		case results <- page:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
 	go func() {
 		defer close(results)
 		for {
-			response, err := s.ISPClient().Get(context.Background(), sechubURL, query)
-			if err != nil {
-				s.Logger.Error("Failed to list Secrets %v", err)
-				return
-			}
-			defer func(Body io.ReadCloser) {
-				err := Body.Close()
-				if err != nil {
-					common.GlobalLogger.Warning("Error closing response body")
-				}
-			}(response.Body)
-			if response.StatusCode != http.StatusOK {
-				s.Logger.Error("Failed to list Secrets - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
-				return
-			}
-			result, err := common.DeserializeJSONSnake(response.Body)
+			secrets, nextLink, err := s.fetchSecretsPage(ctx, query)
 			if err != nil {
-				s.Logger.Error("Failed to decode response: %v", err)
+				s.Logger.Error("Failed to list Secrets: %v", err)
+				send(&IdsecSecHubSecretsPage{Err: err})
 				return
 			}
-			resultMap := result.(map[string]interface{})
-			var secretsJSON []interface{}
-			if secrets, ok := resultMap["secrets"]; ok {
-				secretsJSON = secrets.([]interface{})
-			} else {
-				s.Logger.Error("Failed to list Secrets, unexpected result")
+			if !send(&IdsecSecHubSecretsPage{Items: secrets}) {
 				return
 			}
-			for i, secrets := range secretsJSON {
-				if secretsMap, ok := secrets.(map[string]interface{}); ok {
-					if secretStoreID, ok := secretsMap["id"]; ok {
-						secretsJSON[i].(map[string]interface{})["id"] = secretStoreID
-					}
-				}
+			if nextLink == "" {
+				break
 			}
-			var secrets []*secretsmodels.IdsecSecHubSecret
-			if err := mapstructure.Decode(secretsJSON, &secrets); err != nil {
-				s.Logger.Error("Failed to validate Secrets: %v", err)
+			nextQuery, err := url.Parse(nextLink)
+			if err != nil {
+				s.Logger.Error("Failed to parse Secrets next link: %v", err)
+				send(&IdsecSecHubSecretsPage{Err: err})
 				return
 			}
-			results <- &IdsecSecHubSecretsPage{Items: secrets}
-			if nextLink, ok := resultMap["nextLink"].(string); ok {
-				nextQuery, _ := url.Parse(nextLink)
-				queryValues := nextQuery.Query()
-				query = make(map[string]string)
-				for key, values := range queryValues {
-					if len(values) > 0 {
-						query[key] = values[0]
-					}
+			query = make(map[string]string)
+			for key, values := range nextQuery.Query() {
+				if len(values) > 0 {
+					query[key] = values[0]
 				}
-			} else {
-				break
 			}
 		}
 	}()
@@ -150,9 +177,11 @@ func (s *IdsecSecHubSecretsService) getSecretsWithFilters(
 }
 
 // Get returns a channel of IdsecSecHubSecretsPage containing all Secret Stores.
+// On failure during pagination, the channel emits a final page with Err set; otherwise Err is nil on every page.
 // https://api-docs.cyberark.com/docs/secretshub-api/kdyou8dae9r8m-get-secrets
 func (s *IdsecSecHubSecretsService) Get() (<-chan *IdsecSecHubSecretsPage, error) {
 	return s.getSecretsWithFilters(
+		context.Background(),
 		"",
 		"",
 		0,
@@ -162,8 +191,17 @@ func (s *IdsecSecHubSecretsService) Get() (<-chan *IdsecSecHubSecretsPage, error
 }
 
 // ListBy returns a channel of IdsecSecHubSecretsPage containing secrets filtered by the given filters.
+// On failure during pagination, the channel emits a final page with Err set; otherwise Err is nil on every page.
 func (s *IdsecSecHubSecretsService) ListBy(secretsFilters *secretsmodels.IdsecSecHubSecretsFilter) (<-chan *IdsecSecHubSecretsPage, error) {
+	return s.ListByContext(context.Background(), secretsFilters)
+}
+
+// ListByContext is like ListBy but accepts a context.Context. Callers that stop iterating the
+// returned channel early must cancel the context to release the producer goroutine and any
+// in-flight request.
+func (s *IdsecSecHubSecretsService) ListByContext(ctx context.Context, secretsFilters *secretsmodels.IdsecSecHubSecretsFilter) (<-chan *IdsecSecHubSecretsPage, error) {
 	return s.getSecretsWithFilters(
+		ctx,
 		secretsFilters.Projection,
 		secretsFilters.Filter,
 		secretsFilters.Limit,
@@ -181,6 +219,9 @@ func (s *IdsecSecHubSecretsService) Stats() (*secretsmodels.IdsecSecHubSecretsSt
 	}
 	secrets := make([]*secretsmodels.IdsecSecHubSecret, 0)
 	for page := range secretsChan {
+		if page.Err != nil {
+			return nil, page.Err
+		}
 		secrets = append(secrets, page.Items...)
 	}
 	var secretsStats secretsmodels.IdsecSecHubSecretsStats
@@ -0,0 +1,54 @@
+package models
+
+// Possible formats of a secrets report.
+const (
+	SecretsReportFormatJSON = "json"
+	SecretsReportFormatCSV  = "csv"
+	SecretsReportFormatHTML = "html"
+)
+
+// IdsecSecHubSecretsReportRequest represents the request for generating a secrets inventory risk report.
+type IdsecSecHubSecretsReportRequest struct {
+	Filter     string `json:"filter,omitempty" mapstructure:"filter,omitempty" desc:"Filter to apply on the secrets included in the report" flag:"filter"`
+	StaleDays  int    `json:"stale_days,omitempty" mapstructure:"stale_days,omitempty" desc:"Number of days since the last rotation after which a secret is considered stale" flag:"stale-days" default:"90" validate:"min=0"`
+	Format     string `json:"format,omitempty" mapstructure:"format,omitempty" desc:"Format of the report written to the output file (json,csv,html)" flag:"format" default:"json" choices:"json,csv,html"`
+	OutputFile string `json:"output_file,omitempty" mapstructure:"output_file,omitempty" desc:"The path to the file where the rendered report will be saved" flag:"output-file"`
+}
+
+// IdsecSecHubSecretReportEntry represents a single secret in the report along with its risk flags.
+type IdsecSecHubSecretReportEntry struct {
+	ID                string `json:"id" mapstructure:"id" desc:"The unique identifier of the secret in Secrets Hub"`
+	Name              string `json:"name" mapstructure:"name" desc:"The name of the secret as defined in the secret store"`
+	StoreID           string `json:"store_id" mapstructure:"store_id" desc:"The unique identifier of the secret store"`
+	StoreName         string `json:"store_name" mapstructure:"store_name" desc:"Name of the secret store"`
+	VendorType        string `json:"vendor_type" mapstructure:"vendor_type" desc:"The vendor type of the store where the secret was found"`
+	VendorSubType     string `json:"vendor_sub_type" mapstructure:"vendor_sub_type" desc:"The subtype of the secret store where the secret was found"`
+	Region            string `json:"region" mapstructure:"region" desc:"Cloud Service Provider Region"`
+	LastRotatedAt     string `json:"last_rotated_at" mapstructure:"last_rotated_at" desc:"The date and time the secret was last updated in the secret store"`
+	DaysSinceRotation int    `json:"days_since_rotation" mapstructure:"days_since_rotation" desc:"Number of days since the secret was last updated, -1 if unknown"`
+	Unmanaged         bool   `json:"unmanaged" mapstructure:"unmanaged" desc:"Whether the secret is not onboarded to PAM"`
+	Stale             bool   `json:"stale" mapstructure:"stale" desc:"Whether the secret was not rotated within the stale days threshold"`
+	Duplicated        bool   `json:"duplicated" mapstructure:"duplicated" desc:"Whether a secret with the same name exists in another secret store"`
+	Unsynced          bool   `json:"unsynced" mapstructure:"unsynced" desc:"Whether the secret is onboarded to PAM but not synced by Idira"`
+}
+
+// IdsecSecHubSecretsReportGroup represents the risk counts of a group of secrets.
+type IdsecSecHubSecretsReportGroup struct {
+	Key             string `json:"key" mapstructure:"key" desc:"The value the secrets are grouped by"`
+	SecretsCount    int    `json:"secrets_count" mapstructure:"secrets_count" desc:"Number of secrets in the group"`
+	UnmanagedCount  int    `json:"unmanaged_count" mapstructure:"unmanaged_count" desc:"Number of unmanaged secrets in the group"`
+	StaleCount      int    `json:"stale_count" mapstructure:"stale_count" desc:"Number of stale secrets in the group"`
+	DuplicatedCount int    `json:"duplicated_count" mapstructure:"duplicated_count" desc:"Number of duplicated secrets in the group"`
+	UnsyncedCount   int    `json:"unsynced_count" mapstructure:"unsynced_count" desc:"Number of unsynced secrets in the group"`
+}
+
+// IdsecSecHubSecretsReport represents a secrets inventory risk report.
+type IdsecSecHubSecretsReport struct {
+	GeneratedAt string                          `json:"generated_at" mapstructure:"generated_at" desc:"The date and time the report was generated"`
+	StaleDays   int                             `json:"stale_days" mapstructure:"stale_days" desc:"The stale days threshold used for the report"`
+	Totals      IdsecSecHubSecretsReportGroup   `json:"totals" mapstructure:"totals" desc:"Risk counts of all the secrets"`
+	ByStore     []IdsecSecHubSecretsReportGroup `json:"by_store" mapstructure:"by_store" desc:"Risk counts grouped by secret store"`
+	ByVendor    []IdsecSecHubSecretsReportGroup `json:"by_vendor" mapstructure:"by_vendor" desc:"Risk counts grouped by vendor type and subtype"`
+	ByRegion    []IdsecSecHubSecretsReportGroup `json:"by_region" mapstructure:"by_region" desc:"Risk counts grouped by region"`
+	Secrets     []IdsecSecHubSecretReportEntry  `json:"secrets" mapstructure:"secrets" desc:"The secrets included in the report"`
+}
@@ -9,6 +9,7 @@ var ActionToSchemaMap = map[string]interface{}{
 	"get":       &policiesmodels.IdsecSecHubGetSyncPolicy{},
 	"list":      &policiesmodels.IdsecSecHubGetSyncPolicies{},
 	"list-by":   &policiesmodels.IdsecSecHubSyncPoliciesFilters{},
+	"preview":   &policiesmodels.IdsecSecHubPreviewSyncPolicy{},
 	"set-state": &policiesmodels.IdsecSecHubSetSyncPolicyState{},
 	"stats":     nil,
 	"update":    &policiesmodels.IdsecSecHubUpdateSyncPolicy{},
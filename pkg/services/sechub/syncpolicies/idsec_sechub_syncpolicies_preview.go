@@ -0,0 +1,244 @@
+package syncpolicies
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	filtersmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/filters/models"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/secrets"
+	secretsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/secrets/models"
+	secretstoresmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/secretstores/models"
+	syncpoliciesmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/syncpolicies/models"
+)
+
+const (
+	secretsByStoreFilter = "storeId EQ %s"
+	// safeTagName is the tag Secrets Hub applies to synced secrets with the name of their origin safe,
+	// normalized with normalizeTagName as tag keys are snake cased when responses are deserialized.
+	safeTagName = "cyberarksafe"
+	// supportedPredefinedTransformation is the only predefined transformation Secrets Hub supports.
+	supportedPredefinedTransformation = "password_only_plain_text"
+)
+
+// targetNamingRule describes how Secrets Hub names secrets in a target store type.
+type targetNamingRule struct {
+	separator    string
+	allowedChars *regexp.Regexp
+	maxLength    int
+}
+
+// targetNamingRules maps a target secret store type to its naming rule.
+var targetNamingRules = map[string]targetNamingRule{
+	"AWS_ASM":         {separator: "/", allowedChars: regexp.MustCompile(`^[A-Za-z0-9/_+=.@-]$`), maxLength: 512},
+	"AZURE_AKV":       {separator: "-", allowedChars: regexp.MustCompile(`^[A-Za-z0-9-]$`), maxLength: 127},
+	"GCP_GSM":         {separator: "-", allowedChars: regexp.MustCompile(`^[A-Za-z0-9_-]$`), maxLength: 255},
+	"HASHICORP_VAULT": {separator: "/", allowedChars: regexp.MustCompile(`^[A-Za-z0-9/_.@-]$`), maxLength: 512},
+}
+
+var nonAlphanumericRegex = regexp.MustCompile(`[^a-z0-9]`)
+
+// normalizeTagName lowercases a tag key and strips everything but letters and digits.
+func normalizeTagName(tagName string) string {
+	return nonAlphanumericRegex.ReplaceAllString(strings.ToLower(tagName), "")
+}
+
+// secretSafeName returns the safe a source secret belongs to, either from its safe tag or from its name path.
+func secretSafeName(secret *secretsmodels.IdsecSecHubSecret) string {
+	for tagName, tagValue := range secret.VendorData.Tags {
+		if normalizeTagName(tagName) == safeTagName {
+			return tagValue
+		}
+	}
+	if idx := strings.Index(secret.Name, "/"); idx > 0 {
+		return secret.Name[:idx]
+	}
+	return ""
+}
+
+// secretAccountName returns the source secret name without its safe prefix.
+func secretAccountName(secret *secretsmodels.IdsecSecHubSecret, safeName string) string {
+	return strings.TrimPrefix(secret.Name, safeName+"/")
+}
+
+// unsupportedCharacters returns the distinct characters of name that the rule does not allow, in order of appearance.
+func (r targetNamingRule) unsupportedCharacters(name string) []string {
+	seen := make(map[rune]bool)
+	var unsupported []string
+	for _, c := range name {
+		if seen[c] || r.allowedChars.MatchString(string(c)) {
+			continue
+		}
+		seen[c] = true
+		unsupported = append(unsupported, string(c))
+	}
+	return unsupported
+}
+
+// targetNameKey normalizes a target secret name for collision checks. Cloud secret stores treat
+// names differing only by case as the same secret, so sources and existing targets compare lowercase.
+func targetNameKey(name string) string {
+	return strings.ToLower(name)
+}
+
+// listStoreSecrets returns all the secrets known to Secrets Hub for the given store.
+func listStoreSecrets(secretsService *secrets.IdsecSecHubSecretsService, storeID string) ([]*secretsmodels.IdsecSecHubSecret, error) {
+	secretsPages, err := secretsService.ListBy(&secretsmodels.IdsecSecHubSecretsFilter{
+		Projection: "EXTEND",
+		Filter:     fmt.Sprintf(secretsByStoreFilter, storeID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	storeSecrets := make([]*secretsmodels.IdsecSecHubSecret, 0)
+	for page := range secretsPages {
+		if page.Err != nil {
+			return nil, page.Err
+		}
+		storeSecrets = append(storeSecrets, page.Items...)
+	}
+	return storeSecrets, nil
+}
+
+// resolvePreviewDefinition returns the policy definition to preview, loading it from an existing policy when requested.
+func (s *IdsecSecHubSyncPoliciesService) resolvePreviewDefinition(previewSyncPolicy *syncpoliciesmodels.IdsecSecHubPreviewSyncPolicy) (*syncpoliciesmodels.IdsecSecHubPreviewSyncPolicy, error) {
+	if previewSyncPolicy.PolicyID == "" {
+		return previewSyncPolicy, nil
+	}
+	policy, err := s.Get(&syncpoliciesmodels.IdsecSecHubGetSyncPolicy{
+		PolicyID:       previewSyncPolicy.PolicyID,
+		Transformation: previewSyncPolicy.Transformation,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &syncpoliciesmodels.IdsecSecHubPreviewSyncPolicy{
+		PolicyID:       policy.ID,
+		Source:         policy.Source,
+		Target:         policy.Target,
+		Filter:         policy.Filter,
+		Transformation: policy.Transformation,
+	}, nil
+}
+
+// resolveFilterSafeName returns the safe name of the policy filter, fetching the filter by ID when its data is not given.
+func (s *IdsecSecHubSyncPoliciesService) resolveFilterSafeName(sourceStoreID string, filter syncpoliciesmodels.IdsecSecHubPolicyFilter) (string, error) {
+	if filter.Data.SafeName != "" {
+		return filter.Data.SafeName, nil
+	}
+	if filter.ID == "" {
+		return "", fmt.Errorf("filter must have either an id or a safe name")
+	}
+	existingFilter, err := s.filtersService.Get(&filtersmodels.IdsecSecHubGetFilter{
+		StoreID:  sourceStoreID,
+		FilterID: filter.ID,
+	})
+	if err != nil {
+		return "", err
+	}
+	if existingFilter == nil || existingFilter.Data.SafeName == "" {
+		return "", fmt.Errorf("failed to resolve safe name of filter [%s]", filter.ID)
+	}
+	return existingFilter.Data.SafeName, nil
+}
+
+// Preview evaluates a sync policy against the secrets of its source store without creating the policy.
+// It reports, for every source secret matched by the policy filter, the name it would get in the target store,
+// and whether that name collides with another synced secret or an existing target secret, contains characters
+// the target store does not support, or exceeds the target store name length limit.
+func (s *IdsecSecHubSyncPoliciesService) Preview(previewSyncPolicy *syncpoliciesmodels.IdsecSecHubPreviewSyncPolicy) (*syncpoliciesmodels.IdsecSecHubSyncPolicyPreview, error) {
+	if previewSyncPolicy == nil {
+		return nil, fmt.Errorf("preview request cannot be nil")
+	}
+	definition, err := s.resolvePreviewDefinition(previewSyncPolicy)
+	if err != nil {
+		return nil, err
+	}
+	if definition.Source.ID == "" || definition.Target.ID == "" {
+		return nil, fmt.Errorf("source and target store ids are required")
+	}
+	if p := definition.Transformation.Predefined; p != "" && p != supportedPredefinedTransformation {
+		return nil, fmt.Errorf("unsupported predefined transformation [%s]", p)
+	}
+	s.Logger.Info("Previewing sync policy from store [%s] to store [%s]", definition.Source.ID, definition.Target.ID)
+	safeName, err := s.resolveFilterSafeName(definition.Source.ID, definition.Filter)
+	if err != nil {
+		return nil, err
+	}
+	targetStore, err := s.secretStoresService.Get(&secretstoresmodels.IdsecSecHubGetSecretStore{ID: definition.Target.ID})
+	if err != nil {
+		return nil, err
+	}
+	rule, ok := targetNamingRules[targetStore.Type]
+	if !ok {
+		return nil, fmt.Errorf("secret store [%s] of type [%s] is not a supported sync target", targetStore.ID, targetStore.Type)
+	}
+	sourceSecrets, err := listStoreSecrets(s.secretsService, definition.Source.ID)
+	if err != nil {
+		return nil, err
+	}
+	targetSecrets, err := listStoreSecrets(s.secretsService, definition.Target.ID)
+	if err != nil {
+		return nil, err
+	}
+	existingTargets := make(map[string][]string)
+	for _, secret := range targetSecrets {
+		if secret.SyncedByCyberArk {
+			continue
+		}
+		existingTargets[targetNameKey(secret.Name)] = append(existingTargets[targetNameKey(secret.Name)], secret.ID)
+	}
+
+	preview := &syncpoliciesmodels.IdsecSecHubSyncPolicyPreview{
+		SourceStoreID:   definition.Source.ID,
+		TargetStoreID:   targetStore.ID,
+		TargetStoreType: targetStore.Type,
+		SafeName:        safeName,
+		Transformation:  definition.Transformation.Predefined,
+		Secrets:         make([]syncpoliciesmodels.IdsecSecHubSyncPolicyPreviewSecret, 0),
+	}
+	sourcesByTarget := make(map[string][]string)
+	for _, secret := range sourceSecrets {
+		if !strings.EqualFold(secretSafeName(secret), safeName) {
+			continue
+		}
+		targetName := safeName + rule.separator + secretAccountName(secret, safeName)
+		preview.Secrets = append(preview.Secrets, syncpoliciesmodels.IdsecSecHubSyncPolicyPreviewSecret{
+			SourceSecretID: secret.ID,
+			SourceName:     secret.Name,
+			TargetName:     targetName,
+		})
+		sourcesByTarget[targetNameKey(targetName)] = append(sourcesByTarget[targetNameKey(targetName)], secret.ID)
+	}
+	for i := range preview.Secrets {
+		previewSecret := &preview.Secrets[i]
+		for _, other := range sourcesByTarget[targetNameKey(previewSecret.TargetName)] {
+			if other != previewSecret.SourceSecretID {
+				previewSecret.CollidesWith = append(previewSecret.CollidesWith, other)
+			}
+		}
+		previewSecret.CollidesWith = append(previewSecret.CollidesWith, existingTargets[targetNameKey(previewSecret.TargetName)]...)
+		previewSecret.UnsupportedCharacters = rule.unsupportedCharacters(previewSecret.TargetName)
+		switch {
+		case len(previewSecret.CollidesWith) > 0:
+			previewSecret.Status = syncpoliciesmodels.PreviewSecretStatusCollision
+			preview.CollisionsCount++
+		case len(previewSecret.UnsupportedCharacters) > 0:
+			previewSecret.Status = syncpoliciesmodels.PreviewSecretStatusUnsupportedCharacters
+			preview.UnsupportedCharactersCount++
+		case len(previewSecret.TargetName) > rule.maxLength:
+			previewSecret.Status = syncpoliciesmodels.PreviewSecretStatusNameTooLong
+			preview.NameTooLongCount++
+		default:
+			previewSecret.Status = syncpoliciesmodels.PreviewSecretStatusWillSync
+			preview.WillSyncCount++
+		}
+	}
+	sort.Slice(preview.Secrets, func(i, j int) bool {
+		return preview.Secrets[i].TargetName < preview.Secrets[j].TargetName
+	})
+	preview.SecretsCount = len(preview.Secrets)
+	s.Logger.Info("Sync policy preview done, [%d] secrets matched, [%d] would sync", preview.SecretsCount, preview.WillSyncCount)
+	return preview, nil
+}
@@ -0,0 +1,180 @@
+package syncpolicies
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/filters"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/secrets"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/secretstores"
+	syncpoliciesmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/syncpolicies/models"
+	"github.com/stretchr/testify/require"
+)
+
+// newMockPreviewService creates an IdsecSecHubSyncPoliciesService with its dependent services wired to the given test server.
+func newMockPreviewService(t *testing.T, serverURL string) *IdsecSecHubSyncPoliciesService {
+	t.Helper()
+	svc := newMockService(t, serverURL)
+	base := newMockService(t, serverURL)
+	svc.filtersService = &filters.IdsecSecHubFiltersService{
+		IdsecBaseService:    base.IdsecBaseService,
+		IdsecISPBaseService: base.IdsecISPBaseService,
+	}
+	svc.secretsService = &secrets.IdsecSecHubSecretsService{
+		IdsecBaseService:    base.IdsecBaseService,
+		IdsecISPBaseService: base.IdsecISPBaseService,
+	}
+	svc.secretStoresService = &secretstores.IdsecSecHubSecretStoresService{
+		IdsecBaseService:    base.IdsecBaseService,
+		IdsecISPBaseService: base.IdsecISPBaseService,
+	}
+	return svc
+}
+
+const (
+	previewSourceSecrets = `{"secrets": [
+		{"id": "s-1", "name": "finance/db-admin", "store_id": "store-source"},
+		{"id": "s-2", "name": "finance/db admin", "store_id": "store-source"},
+		{"id": "s-3", "name": "finance/DB-Admin", "store_id": "store-source"},
+		{"id": "s-4", "name": "app-token", "store_id": "store-source", "vendor_data": {"tags": {"CyberArk Safe": "finance"}}},
+		{"id": "s-5", "name": "hr/payroll", "store_id": "store-source"},
+		{"id": "s-6", "name": "finance/existing", "store_id": "store-source"}
+	]}`
+	previewTargetSecrets = `{"secrets": [
+		{"id": "t-1", "name": "Finance/Existing", "store_id": "store-target", "synced_by_cyberark": false},
+		{"id": "t-2", "name": "finance/app-token", "store_id": "store-target", "synced_by_cyberark": true}
+	]}`
+)
+
+func newPreviewTestServer(t *testing.T, targetType string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "/api/secret-stores/store-source/filters/filter-1"):
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": "filter-1", "type": "PAM_SAFE", "data": {"safe_name": "finance"}}`))
+		case strings.HasSuffix(r.URL.Path, "/api/secret-stores/store-target"):
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"id": "store-target", "type": "` + targetType + `", "name": "target"}`))
+		case strings.HasSuffix(r.URL.Path, "/api/secrets"):
+			w.WriteHeader(http.StatusOK)
+			if strings.Contains(r.URL.Query().Get("filter"), "store-target") {
+				_, _ = w.Write([]byte(previewTargetSecrets))
+				return
+			}
+			_, _ = w.Write([]byte(previewSourceSecrets))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": "not found"}`))
+		}
+	}))
+}
+
+func TestPreview(t *testing.T) {
+	tests := []struct {
+		name             string
+		targetType       string
+		request          *syncpoliciesmodels.IdsecSecHubPreviewSyncPolicy
+		expectedError    bool
+		expectedErrorMsg string
+		validateFunc     func(t *testing.T, preview *syncpoliciesmodels.IdsecSecHubSyncPolicyPreview)
+	}{
+		{
+			name:       "success_aws_target_with_filter_id",
+			targetType: "AWS_ASM",
+			request: &syncpoliciesmodels.IdsecSecHubPreviewSyncPolicy{
+				Source: syncpoliciesmodels.IdsecSecHubPolicyStore{ID: "store-source"},
+				Target: syncpoliciesmodels.IdsecSecHubPolicyStore{ID: "store-target"},
+				Filter: syncpoliciesmodels.IdsecSecHubPolicyFilter{ID: "filter-1"},
+			},
+			validateFunc: func(t *testing.T, preview *syncpoliciesmodels.IdsecSecHubSyncPolicyPreview) {
+				require.Equal(t, "finance", preview.SafeName)
+				require.Equal(t, 5, preview.SecretsCount)
+				byID := make(map[string]syncpoliciesmodels.IdsecSecHubSyncPolicyPreviewSecret)
+				for _, secret := range preview.Secrets {
+					byID[secret.SourceSecretID] = secret
+				}
+				require.Equal(t, "finance/db-admin", byID["s-1"].TargetName)
+				require.Equal(t, syncpoliciesmodels.PreviewSecretStatusCollision, byID["s-1"].Status)
+				require.Equal(t, []string{"s-3"}, byID["s-1"].CollidesWith)
+				require.Equal(t, syncpoliciesmodels.PreviewSecretStatusUnsupportedCharacters, byID["s-2"].Status)
+				require.Equal(t, []string{" "}, byID["s-2"].UnsupportedCharacters)
+				require.Equal(t, "finance/app-token", byID["s-4"].TargetName)
+				require.Equal(t, syncpoliciesmodels.PreviewSecretStatusWillSync, byID["s-4"].Status)
+				require.Equal(t, []string{"t-1"}, byID["s-6"].CollidesWith)
+				require.Equal(t, 3, preview.CollisionsCount)
+				require.Equal(t, 1, preview.UnsupportedCharactersCount)
+				require.Equal(t, 1, preview.WillSyncCount)
+			},
+		},
+		{
+			name:       "success_azure_target_separator_and_characters",
+			targetType: "AZURE_AKV",
+			request: &syncpoliciesmodels.IdsecSecHubPreviewSyncPolicy{
+				Source: syncpoliciesmodels.IdsecSecHubPolicyStore{ID: "store-source"},
+				Target: syncpoliciesmodels.IdsecSecHubPolicyStore{ID: "store-target"},
+				Filter: syncpoliciesmodels.IdsecSecHubPolicyFilter{
+					Data: syncpoliciesmodels.IdsecSechubSyncPolicyFilterData{SafeName: "hr"},
+				},
+				Transformation: syncpoliciesmodels.IdsecSecHubPolicyTransformation{Predefined: "password_only_plain_text"},
+			},
+			validateFunc: func(t *testing.T, preview *syncpoliciesmodels.IdsecSecHubSyncPolicyPreview) {
+				require.Equal(t, 1, preview.SecretsCount)
+				require.Equal(t, "hr-payroll", preview.Secrets[0].TargetName)
+				require.Equal(t, syncpoliciesmodels.PreviewSecretStatusWillSync, preview.Secrets[0].Status)
+				require.Equal(t, "password_only_plain_text", preview.Transformation)
+			},
+		},
+		{
+			name:       "error_unsupported_target_type",
+			targetType: "PAM_PCLOUD",
+			request: &syncpoliciesmodels.IdsecSecHubPreviewSyncPolicy{
+				Source: syncpoliciesmodels.IdsecSecHubPolicyStore{ID: "store-source"},
+				Target: syncpoliciesmodels.IdsecSecHubPolicyStore{ID: "store-target"},
+				Filter: syncpoliciesmodels.IdsecSecHubPolicyFilter{ID: "filter-1"},
+			},
+			expectedError:    true,
+			expectedErrorMsg: "is not a supported sync target",
+		},
+		{
+			name:       "error_unsupported_transformation",
+			targetType: "AWS_ASM",
+			request: &syncpoliciesmodels.IdsecSecHubPreviewSyncPolicy{
+				Source:         syncpoliciesmodels.IdsecSecHubPolicyStore{ID: "store-source"},
+				Target:         syncpoliciesmodels.IdsecSecHubPolicyStore{ID: "store-target"},
+				Filter:         syncpoliciesmodels.IdsecSecHubPolicyFilter{ID: "filter-1"},
+				Transformation: syncpoliciesmodels.IdsecSecHubPolicyTransformation{Predefined: "custom"},
+			},
+			expectedError:    true,
+			expectedErrorMsg: "unsupported predefined transformation",
+		},
+		{
+			name:             "error_missing_stores",
+			targetType:       "AWS_ASM",
+			request:          &syncpoliciesmodels.IdsecSecHubPreviewSyncPolicy{},
+			expectedError:    true,
+			expectedErrorMsg: "source and target store ids are required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			testServer := newPreviewTestServer(t, tt.targetType)
+			defer testServer.Close()
+
+			svc := newMockPreviewService(t, testServer.URL)
+			preview, err := svc.Preview(tt.request)
+			if tt.expectedError {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectedErrorMsg)
+				return
+			}
+			require.NoError(t, err)
+			tt.validateFunc(t, preview)
+		})
+	}
+}
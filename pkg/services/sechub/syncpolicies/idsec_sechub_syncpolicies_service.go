@@ -13,6 +13,9 @@ import (
 	"github.com/cyberark/idsec-sdk-golang/pkg/common"
 	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
 	"github.com/cyberark/idsec-sdk-golang/pkg/services"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/filters"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/secrets"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/secretstores"
 	syncpoliciesmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sechub/syncpolicies/models"
 )
 
@@ -43,6 +46,9 @@ type IdsecSecHubSyncPoliciesPage = common.IdsecPage[syncpoliciesmodels.IdsecSecH
 type IdsecSecHubSyncPoliciesService struct {
 	*services.IdsecBaseService
 	*services.IdsecISPBaseService
+	filtersService      *filters.IdsecSecHubFiltersService
+	secretsService      *secrets.IdsecSecHubSecretsService
+	secretStoresService *secretstores.IdsecSecHubSecretStoresService
 }
 
 // NewIdsecSecHubSyncPoliciesService creates a new instance of IdsecSecHubSyncPoliciesService.
@@ -64,6 +70,19 @@ func NewIdsecSecHubSyncPoliciesService(authenticators ...auth.IdsecAuth) (*Idsec
 		return nil, err
 	}
 
+	syncPoliciesService.filtersService, err = filters.NewIdsecSecHubFiltersService(ispAuth)
+	if err != nil {
+		return nil, err
+	}
+	syncPoliciesService.secretsService, err = secrets.NewIdsecSecHubSecretsService(ispAuth)
+	if err != nil {
+		return nil, err
+	}
+	syncPoliciesService.secretStoresService, err = secretstores.NewIdsecSecHubSecretStoresService(ispAuth)
+	if err != nil {
+		return nil, err
+	}
+
 	syncPoliciesService.IdsecBaseService = baseService
 	syncPoliciesService.IdsecISPBaseService = ispBaseService
 	return syncPoliciesService, nil
@@ -0,0 +1,44 @@
+package models
+
+// Possible statuses of a secret in a sync policy preview.
+const (
+	PreviewSecretStatusWillSync              = "WILL_SYNC"
+	PreviewSecretStatusCollision             = "COLLISION"
+	PreviewSecretStatusUnsupportedCharacters = "UNSUPPORTED_CHARACTERS"
+	PreviewSecretStatusNameTooLong           = "NAME_TOO_LONG"
+)
+
+// IdsecSecHubPreviewSyncPolicy represents a sync policy to evaluate without creating it.
+// When PolicyID is given, the source, target, filter and transformation of the existing policy are used instead.
+type IdsecSecHubPreviewSyncPolicy struct {
+	PolicyID       string                          `json:"policy_id,omitempty" mapstructure:"policy_id,omitempty" desc:"Existing sync policy to preview instead of the given definition" flag:"policy-id"`
+	Source         IdsecSecHubPolicyStore          `json:"source,omitzero" mapstructure:"source,omitempty" desc:"Source store reference" flag:"source"`
+	Target         IdsecSecHubPolicyStore          `json:"target,omitzero" mapstructure:"target,omitempty" desc:"Target store reference" flag:"target"`
+	Filter         IdsecSecHubPolicyFilter         `json:"filter,omitzero" mapstructure:"filter,omitempty" desc:"Filter reference" flag:"filter"`
+	Transformation IdsecSecHubPolicyTransformation `json:"transformation,omitzero" mapstructure:"transformation,omitempty" desc:"Transformation reference" flag:"transformation"`
+}
+
+// IdsecSecHubSyncPolicyPreviewSecret represents the would-be outcome of syncing a single source secret.
+type IdsecSecHubSyncPolicyPreviewSecret struct {
+	SourceSecretID        string   `json:"source_secret_id" mapstructure:"source_secret_id" desc:"The unique identifier of the source secret in Secrets Hub"`
+	SourceName            string   `json:"source_name" mapstructure:"source_name" desc:"The name of the secret in the source store"`
+	TargetName            string   `json:"target_name" mapstructure:"target_name" desc:"The name the secret would get in the target store"`
+	Status                string   `json:"status" mapstructure:"status" desc:"The preview status of the secret (WILL_SYNC,COLLISION,UNSUPPORTED_CHARACTERS,NAME_TOO_LONG)"`
+	CollidesWith          []string `json:"collides_with,omitempty" mapstructure:"collides_with,omitempty" desc:"Source secret IDs or existing target secret IDs that map to the same target name"`
+	UnsupportedCharacters []string `json:"unsupported_characters,omitempty" mapstructure:"unsupported_characters,omitempty" desc:"Characters of the target name that the target store does not support"`
+}
+
+// IdsecSecHubSyncPolicyPreview represents the impact preview of a sync policy.
+type IdsecSecHubSyncPolicyPreview struct {
+	SourceStoreID              string                               `json:"source_store_id" mapstructure:"source_store_id" desc:"The source secret store ID"`
+	TargetStoreID              string                               `json:"target_store_id" mapstructure:"target_store_id" desc:"The target secret store ID"`
+	TargetStoreType            string                               `json:"target_store_type" mapstructure:"target_store_type" desc:"The target secret store type"`
+	SafeName                   string                               `json:"safe_name" mapstructure:"safe_name" desc:"The safe name the policy filter resolves to"`
+	Transformation             string                               `json:"transformation,omitempty" mapstructure:"transformation,omitempty" desc:"The predefined transformation applied to the synced secrets"`
+	Secrets                    []IdsecSecHubSyncPolicyPreviewSecret `json:"secrets" mapstructure:"secrets" desc:"The would-be outcome per source secret"`
+	SecretsCount               int                                  `json:"secrets_count" mapstructure:"secrets_count" desc:"Number of source secrets matched by the filter"`
+	WillSyncCount              int                                  `json:"will_sync_count" mapstructure:"will_sync_count" desc:"Number of secrets that would be synced without issues"`
+	CollisionsCount            int                                  `json:"collisions_count" mapstructure:"collisions_count" desc:"Number of secrets whose target name collides"`
+	UnsupportedCharactersCount int                                  `json:"unsupported_characters_count" mapstructure:"unsupported_characters_count" desc:"Number of secrets whose target name has unsupported characters"`
+	NameTooLongCount           int                                  `json:"name_too_long_count" mapstructure:"name_too_long_count" desc:"Number of secrets whose target name exceeds the target store limit"`
+}
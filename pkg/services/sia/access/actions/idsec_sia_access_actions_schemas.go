@@ -19,4 +19,7 @@ var ActionToSchemaMap = map[string]interface{}{
 	"rotate-relay":                      &accessmodels.IdsecSIARotateHTTPSRelay{},
 	"relay-setup-script":                &accessmodels.IdsecSIAHTTPSRelaySetupScriptRequest{},
 	"install-relay":                     &accessmodels.IdsecSIAInstallRelay{},
+	"fleet-health":                      &accessmodels.IdsecSIAFleetHealth{},
+	"rolling-rotate-connectors":         &accessmodels.IdsecSIARollingRotateConnectors{},
+	"rolling-update-relays":             &accessmodels.IdsecSIARollingUpdateRelays{},
 }
@@ -0,0 +1,548 @@
+package access
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	accessmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/access/models"
+)
+
+const (
+	connectorStatusActive         = "ACTIVE"
+	reachabilityStatusSuccess     = "SUCCESS"
+	jobStatusSucceeded            = "SUCCEEDED"
+	defaultFleetHealthConcurrency = 5
+)
+
+// jobFailedStatuses are the terminal job statuses of a failed connector or HTTPS relay job.
+var jobFailedStatuses = map[string]bool{
+	"FAILED":    true,
+	"TIMED_OUT": true,
+	"REJECTED":  true,
+}
+
+// FleetHealth builds a health report across all connectors and HTTPS relays, including the
+// connectors and HTTPS relays that drifted from the latest version.
+//
+// Parameters:
+//   - fleetHealth: The health request. With CheckReachability, the reachability of every active connector is tested as well.
+//
+// Returns the health report or an error if the connectors or HTTPS relays could not be listed.
+func (s *IdsecSIAAccessService) FleetHealth(fleetHealth *accessmodels.IdsecSIAFleetHealth) (*accessmodels.IdsecSIAFleetHealthReport, error) {
+	s.Logger.Info("Building connectors and HTTPS relays health report")
+	connectors, err := s.ListConnectors()
+	if err != nil {
+		return nil, err
+	}
+	relays, err := s.listAllRelays()
+	if err != nil {
+		return nil, err
+	}
+	report := &accessmodels.IdsecSIAFleetHealthReport{
+		Healthy:            true,
+		Connectors:         make([]accessmodels.IdsecSIAConnectorHealth, len(connectors.Items)),
+		Relays:             make([]accessmodels.IdsecSIAHTTPSRelayHealth, 0, len(relays)),
+		ConnectorVersions:  map[string][]string{},
+		RelayVersions:      map[string][]string{},
+		OutdatedConnectors: []string{},
+		OutdatedRelays:     []string{},
+	}
+	for i, connector := range connectors.Items {
+		report.Connectors[i] = connectorHealth(connector)
+	}
+	if fleetHealth.CheckReachability {
+		s.checkConnectorsReachability(fleetHealth, report.Connectors)
+	}
+	for _, connector := range report.Connectors {
+		report.Healthy = report.Healthy && connector.Healthy
+		report.ConnectorVersions[connector.Version] = append(report.ConnectorVersions[connector.Version], connector.ConnectorID)
+		if !connector.IsLatestVersion {
+			report.OutdatedConnectors = append(report.OutdatedConnectors, connector.ConnectorID)
+		}
+	}
+	for _, relay := range relays {
+		health := relayHealth(relay)
+		report.Healthy = report.Healthy && health.Healthy
+		report.Relays = append(report.Relays, health)
+		report.RelayVersions[health.Version] = append(report.RelayVersions[health.Version], health.HTTPSRelayID)
+		if !health.IsLatestVersion {
+			report.OutdatedRelays = append(report.OutdatedRelays, health.HTTPSRelayID)
+		}
+	}
+	return report, nil
+}
+
+// RollingRotateConnectors rotates the certificates of connectors without taking a whole pool down.
+// Pools are processed concurrently, but within a pool a single connector at a time is put into
+// maintenance mode, rotated and waited on until it is healthy again before it leaves maintenance
+// mode and the next connector of the pool is processed. Connectors that are not active are skipped.
+//
+// A connector that does not become healthy is left in maintenance mode so no new sessions reach it,
+// and unless ContinueOnFailure is set, the remaining connectors of its pool are skipped.
+//
+// Parameters:
+//   - rollingRotate: The rolling rotation request.
+//
+// Returns the outcome per connector, and an error if any connector failed.
+func (s *IdsecSIAAccessService) RollingRotateConnectors(rollingRotate *accessmodels.IdsecSIARollingRotateConnectors) (*accessmodels.IdsecSIARollingOperationResult, error) {
+	return s.RollingRotateConnectorsContext(context.Background(), rollingRotate)
+}
+
+// RollingRotateConnectorsContext is like RollingRotateConnectors but accepts a context.Context.
+// Once the context is done, health waits stop, the connector being waited on is left in maintenance
+// mode and the connectors that were not processed yet are skipped.
+func (s *IdsecSIAAccessService) RollingRotateConnectorsContext(ctx context.Context, rollingRotate *accessmodels.IdsecSIARollingRotateConnectors) (*accessmodels.IdsecSIARollingOperationResult, error) {
+	connectors, err := s.ListConnectors()
+	if err != nil {
+		return nil, err
+	}
+	selected, err := selectConnectors(connectors.Items, rollingRotate.ConnectorIDs, rollingRotate.ConnectorPoolIDs)
+	if err != nil {
+		return nil, err
+	}
+	pools := map[string][]accessmodels.IdsecSIAConnector{}
+	for _, connector := range selected {
+		pools[connector.ConnectorPoolID] = append(pools[connector.ConnectorPoolID], connector)
+	}
+	poolIDs := make([]string, 0, len(pools))
+	for poolID := range pools {
+		poolIDs = append(poolIDs, poolID)
+	}
+	sort.Strings(poolIDs)
+	s.Logger.Info("Rotating [%d] connectors across [%d] pools", len(selected), len(poolIDs))
+
+	poolItems := make([][]accessmodels.IdsecSIARollingOperationItem, len(poolIDs))
+	var wg sync.WaitGroup
+	for i, poolID := range poolIDs {
+		wg.Add(1)
+		go func(i int, poolConnectors []accessmodels.IdsecSIAConnector) {
+			defer wg.Done()
+			poolItems[i] = s.rotatePoolConnectors(ctx, rollingRotate, poolConnectors)
+		}(i, pools[poolID])
+	}
+	wg.Wait()
+
+	result := &accessmodels.IdsecSIARollingOperationResult{
+		Operation: accessmodels.RollingOperationRotate,
+		Items:     []accessmodels.IdsecSIARollingOperationItem{},
+	}
+	for _, items := range poolItems {
+		for _, item := range items {
+			addRollingItem(result, item)
+		}
+	}
+	return result, rollingResultError(result, "connectors")
+}
+
+// RollingUpdateRelays upgrades or rotates the certificates of HTTPS relays one HTTPS relay at a time,
+// waiting for each HTTPS relay to be healthy again before proceeding to the next one.
+// HTTPS relays that are not active are skipped, and when upgrading, so are the HTTPS relays that
+// are already on the latest version.
+//
+// Parameters:
+//   - rollingUpdate: The rolling update request.
+//
+// Returns the outcome per HTTPS relay, and an error if any HTTPS relay failed or the HTTPS relays could not be listed.
+func (s *IdsecSIAAccessService) RollingUpdateRelays(rollingUpdate *accessmodels.IdsecSIARollingUpdateRelays) (*accessmodels.IdsecSIARollingOperationResult, error) {
+	return s.RollingUpdateRelaysContext(context.Background(), rollingUpdate)
+}
+
+// RollingUpdateRelaysContext is like RollingUpdateRelays but accepts a context.Context.
+// Once the context is done, health waits stop and the HTTPS relays that were not processed yet are skipped.
+func (s *IdsecSIAAccessService) RollingUpdateRelaysContext(ctx context.Context, rollingUpdate *accessmodels.IdsecSIARollingUpdateRelays) (*accessmodels.IdsecSIARollingOperationResult, error) {
+	if rollingUpdate.Operation != accessmodels.RollingOperationUpgrade && rollingUpdate.Operation != accessmodels.RollingOperationRotate {
+		return nil, fmt.Errorf("unsupported rolling operation [%s]", rollingUpdate.Operation)
+	}
+	relays, err := s.listAllRelays()
+	if err != nil {
+		return nil, err
+	}
+	selected, err := selectRelays(relays, rollingUpdate.HTTPSRelayIDs)
+	if err != nil {
+		return nil, err
+	}
+	s.Logger.Info("Running [%s] on [%d] HTTPS relays", rollingUpdate.Operation, len(selected))
+	result := &accessmodels.IdsecSIARollingOperationResult{
+		Operation: rollingUpdate.Operation,
+		Items:     []accessmodels.IdsecSIARollingOperationItem{},
+	}
+	stoppedBy := ""
+	for _, relay := range selected {
+		item := accessmodels.IdsecSIARollingOperationItem{ID: relay.HTTPSRelayID}
+		switch {
+		case stoppedBy != "":
+			item.Status = accessmodels.RollingItemSkipped
+			item.Error = fmt.Sprintf("stopped after HTTPS relay [%s] failed", stoppedBy)
+		case ctx.Err() != nil:
+			item.Status = accessmodels.RollingItemSkipped
+			item.Error = fmt.Sprintf("canceled: %v", ctx.Err())
+		case relay.StatusCode != accessmodels.HTTPSRelayStatusActive:
+			item.Status = accessmodels.RollingItemSkipped
+			item.Error = fmt.Sprintf("HTTPS relay is not active, status is [%s]", relay.Status)
+		case rollingUpdate.Operation == accessmodels.RollingOperationUpgrade && relay.IsLatestVersion:
+			item.Status = accessmodels.RollingItemSkipped
+			item.Error = "HTTPS relay is already on the latest version"
+		default:
+			if err := s.updateRelay(ctx, rollingUpdate, relay); err != nil {
+				s.Logger.Warning("Failed to [%s] HTTPS relay [%s]: %v", rollingUpdate.Operation, relay.HTTPSRelayID, err)
+				item.Status = accessmodels.RollingItemFailed
+				item.Error = err.Error()
+				if !rollingUpdate.ContinueOnFailure {
+					stoppedBy = relay.HTTPSRelayID
+				}
+			} else {
+				item.Status = accessmodels.RollingItemSucceeded
+			}
+		}
+		addRollingItem(result, item)
+	}
+	return result, rollingResultError(result, "HTTPS relays")
+}
+
+// rotatePoolConnectors rotates the connectors of a single pool one after the other.
+func (s *IdsecSIAAccessService) rotatePoolConnectors(ctx context.Context, rollingRotate *accessmodels.IdsecSIARollingRotateConnectors, connectors []accessmodels.IdsecSIAConnector) []accessmodels.IdsecSIARollingOperationItem {
+	items := make([]accessmodels.IdsecSIARollingOperationItem, 0, len(connectors))
+	stoppedBy := ""
+	for _, connector := range connectors {
+		item := accessmodels.IdsecSIARollingOperationItem{
+			ID:              connector.ID,
+			ConnectorPoolID: connector.ConnectorPoolID,
+		}
+		switch {
+		case stoppedBy != "":
+			item.Status = accessmodels.RollingItemSkipped
+			item.Error = fmt.Sprintf("stopped after connector [%s] failed", stoppedBy)
+		case ctx.Err() != nil:
+			item.Status = accessmodels.RollingItemSkipped
+			item.Error = fmt.Sprintf("canceled: %v", ctx.Err())
+		case !strings.EqualFold(connector.Status, connectorStatusActive):
+			item.Status = accessmodels.RollingItemSkipped
+			item.Error = fmt.Sprintf("connector is not active, status is [%s]", connector.Status)
+		default:
+			leftInMaintenance, err := s.rotatePoolConnector(ctx, rollingRotate, connector)
+			item.LeftInMaintenance = leftInMaintenance
+			if err != nil {
+				s.Logger.Warning("Failed to rotate connector [%s]: %v", connector.ID, err)
+				item.Status = accessmodels.RollingItemFailed
+				item.Error = err.Error()
+				if !rollingRotate.ContinueOnFailure {
+					stoppedBy = connector.ID
+				}
+			} else {
+				item.Status = accessmodels.RollingItemSucceeded
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// rotatePoolConnector rotates a single connector while it is in maintenance mode.
+// It returns whether the connector was left in maintenance mode.
+func (s *IdsecSIAAccessService) rotatePoolConnector(ctx context.Context, rollingRotate *accessmodels.IdsecSIARollingRotateConnectors, connector accessmodels.IdsecSIAConnector) (bool, error) {
+	if err := s.setConnectorMaintenance(rollingRotate, connector.ID, true); err != nil {
+		return false, fmt.Errorf("failed to enter maintenance mode: %w", err)
+	}
+	if err := s.RotateConnector(&accessmodels.IdsecSIARotateConnector{ConnectorID: connector.ID}); err != nil {
+		if exitErr := s.setConnectorMaintenance(rollingRotate, connector.ID, false); exitErr != nil {
+			return true, fmt.Errorf("%w, and failed to exit maintenance mode: %v", err, exitErr)
+		}
+		return false, err
+	}
+	previousJobUpdate := connector.LastRotationJobInfoUpdateDate
+	err := s.waitForHealth(ctx, rollingRotate.HealthTimeout, rollingRotate.HealthPollInterval, func() (bool, error) {
+		current, err := s.findConnector(connector.ID)
+		if err != nil {
+			return false, err
+		}
+		finished, err := jobFinished(current.LastRotationJobStatus, current.LastRotationJobInfoUpdateDate, previousJobUpdate, current.LastRotationJobStatusDescription)
+		if err != nil {
+			return false, err
+		}
+		return finished && strings.EqualFold(current.Status, connectorStatusActive), nil
+	})
+	if err != nil {
+		return true, err
+	}
+	if err := s.setConnectorMaintenance(rollingRotate, connector.ID, false); err != nil {
+		return true, fmt.Errorf("failed to exit maintenance mode: %w", err)
+	}
+	return false, nil
+}
+
+func (s *IdsecSIAAccessService) setConnectorMaintenance(rollingRotate *accessmodels.IdsecSIARollingRotateConnectors, connectorID string, maintenance bool) error {
+	_, err := s.UpdateConnectorMaintenanceMode(&accessmodels.IdsecSIAMaintenanceConnector{
+		ConnectorID: connectorID,
+		Maintenance: maintenance,
+		RetryCount:  rollingRotate.MaintenanceRetryCount,
+		RetryDelay:  rollingRotate.MaintenanceRetryDelay,
+	})
+	return err
+}
+
+// updateRelay upgrades or rotates a single HTTPS relay and waits for it to be healthy again.
+func (s *IdsecSIAAccessService) updateRelay(ctx context.Context, rollingUpdate *accessmodels.IdsecSIARollingUpdateRelays, relay *accessmodels.IdsecSIAHTTPSRelay) error {
+	var err error
+	if rollingUpdate.Operation == accessmodels.RollingOperationUpgrade {
+		err = s.UpgradeRelay(&accessmodels.IdsecSIAUpgradeHTTPSRelay{HTTPSRelayID: relay.HTTPSRelayID})
+	} else {
+		err = s.RotateRelay(&accessmodels.IdsecSIARotateHTTPSRelay{HTTPSRelayID: relay.HTTPSRelayID})
+	}
+	if err != nil {
+		return err
+	}
+	return s.waitForHealth(ctx, rollingUpdate.HealthTimeout, rollingUpdate.HealthPollInterval, func() (bool, error) {
+		current, err := s.GetRelay(&accessmodels.IdsecSIAGetHTTPSRelay{HTTPSRelayID: relay.HTTPSRelayID})
+		if err != nil {
+			return false, err
+		}
+		var finished bool
+		if rollingUpdate.Operation == accessmodels.RollingOperationUpgrade {
+			finished, err = jobFinished(current.LastJobStatus, current.LastJobInfoUpdateDate, relay.LastJobInfoUpdateDate, current.LastJobStatusDescription)
+		} else {
+			finished, err = jobFinished(current.LastRotationJobStatus, current.LastRotationJobInfoUpdateDate, relay.LastRotationJobInfoUpdateDate, current.LastRotationJobStatusDescription)
+		}
+		if err != nil {
+			return false, err
+		}
+		return finished && current.StatusCode == accessmodels.HTTPSRelayStatusActive, nil
+	})
+}
+
+// waitForHealth polls check until it reports healthy, fails, the timeout in seconds elapses or the
+// context is done. The first check runs right away.
+func (s *IdsecSIAAccessService) waitForHealth(ctx context.Context, timeout int, pollInterval int, check func() (bool, error)) error {
+	waitCtx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+	for {
+		healthy, err := check()
+		if err != nil {
+			return err
+		}
+		if healthy {
+			return nil
+		}
+		select {
+		case <-waitCtx.Done():
+			if ctx.Err() != nil {
+				return fmt.Errorf("waiting for health canceled: %v", ctx.Err())
+			}
+			return fmt.Errorf("not healthy after [%d] seconds", timeout)
+		case <-time.After(time.Duration(pollInterval) * time.Second):
+		}
+	}
+}
+
+func (s *IdsecSIAAccessService) findConnector(connectorID string) (*accessmodels.IdsecSIAConnector, error) {
+	connectors, err := s.ListConnectors()
+	if err != nil {
+		return nil, err
+	}
+	for i := range connectors.Items {
+		if connectors.Items[i].ID == connectorID {
+			return &connectors.Items[i], nil
+		}
+	}
+	return nil, fmt.Errorf("connector [%s] not found", connectorID)
+}
+
+// checkConnectorsReachability tests the reachability of the active connectors concurrently and updates their health.
+func (s *IdsecSIAAccessService) checkConnectorsReachability(fleetHealth *accessmodels.IdsecSIAFleetHealth, connectors []accessmodels.IdsecSIAConnectorHealth) {
+	concurrency := fleetHealth.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultFleetHealthConcurrency
+	}
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i := range connectors {
+		health := &connectors[i]
+		if !strings.EqualFold(health.Status, connectorStatusActive) {
+			continue
+		}
+		wg.Add(1)
+		go func(health *accessmodels.IdsecSIAConnectorHealth) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			reachable := true
+			response, err := s.TestConnectorReachability(&accessmodels.IdsecSIATestConnectorReachability{
+				ConnectorID:           health.ConnectorID,
+				TargetHostname:        fleetHealth.TargetHostname,
+				TargetPort:            fleetHealth.TargetPort,
+				CheckBackendEndpoints: fleetHealth.CheckBackendEndpoints,
+			})
+			if err != nil {
+				reachable = false
+				health.Issues = append(health.Issues, fmt.Sprintf("reachability test failed: %v", err))
+			} else {
+				for _, target := range response.Targets {
+					if !strings.EqualFold(target.Status, reachabilityStatusSuccess) {
+						reachable = false
+						health.Issues = append(health.Issues, fmt.Sprintf("target [%s:%d] is not reachable: %s", target.TargetIP, target.TargetPort, target.Description))
+					}
+				}
+				for _, backend := range response.Backends {
+					if !strings.EqualFold(backend.Status, reachabilityStatusSuccess) {
+						reachable = false
+						health.Issues = append(health.Issues, fmt.Sprintf("backend [%s] is not reachable: %s", backend.BackendConnectorAddress, backend.Description))
+					}
+				}
+			}
+			health.Reachable = &reachable
+			health.Healthy = health.Healthy && reachable
+		}(health)
+	}
+	wg.Wait()
+}
+
+func connectorHealth(connector accessmodels.IdsecSIAConnector) accessmodels.IdsecSIAConnectorHealth {
+	health := accessmodels.IdsecSIAConnectorHealth{
+		ConnectorID:           connector.ID,
+		ConnectorPoolID:       connector.ConnectorPoolID,
+		HostName:              connector.HostName,
+		Version:               connector.Version,
+		IsLatestVersion:       connector.IsLatestVersion,
+		Status:                connector.Status,
+		LastRotationJobStatus: connector.LastRotationJobStatus,
+	}
+	if !strings.EqualFold(connector.Status, connectorStatusActive) {
+		health.Issues = append(health.Issues, fmt.Sprintf("status is [%s]", connector.Status))
+	}
+	if jobFailedStatuses[strings.ToUpper(connector.LastRotationJobStatus)] {
+		health.Issues = append(health.Issues, fmt.Sprintf("last certificate rotation job is [%s]", connector.LastRotationJobStatus))
+	}
+	health.Healthy = len(health.Issues) == 0
+	return health
+}
+
+func relayHealth(relay *accessmodels.IdsecSIAHTTPSRelay) accessmodels.IdsecSIAHTTPSRelayHealth {
+	health := accessmodels.IdsecSIAHTTPSRelayHealth{
+		HTTPSRelayID:          relay.HTTPSRelayID,
+		HostName:              relay.HostName,
+		Version:               relay.Version,
+		IsLatestVersion:       relay.IsLatestVersion,
+		Status:                relay.Status,
+		LastJobStatus:         relay.LastJobStatus,
+		LastRotationJobStatus: relay.LastRotationJobStatus,
+	}
+	if relay.StatusCode != accessmodels.HTTPSRelayStatusActive {
+		health.Issues = append(health.Issues, fmt.Sprintf("status is [%s]", relay.Status))
+	}
+	if jobFailedStatuses[strings.ToUpper(relay.LastJobStatus)] {
+		health.Issues = append(health.Issues, fmt.Sprintf("last job is [%s]", relay.LastJobStatus))
+	}
+	if jobFailedStatuses[strings.ToUpper(relay.LastRotationJobStatus)] {
+		health.Issues = append(health.Issues, fmt.Sprintf("last certificate rotation job is [%s]", relay.LastRotationJobStatus))
+	}
+	health.Healthy = len(health.Issues) == 0
+	return health
+}
+
+// jobFinished reports whether the job updated after previousUpdate has succeeded, and fails
+// once that job ended in a failed status.
+func jobFinished(status string, updatedAt string, previousUpdate string, description string) (bool, error) {
+	if updatedAt == previousUpdate {
+		return false, nil
+	}
+	if jobFailedStatuses[strings.ToUpper(status)] {
+		return false, fmt.Errorf("job ended with status [%s]: %s", status, description)
+	}
+	return strings.EqualFold(status, jobStatusSucceeded), nil
+}
+
+// selectConnectors filters connectors by ID and pool, sorted by pool and ID so rolling operations are predictable.
+func selectConnectors(connectors []accessmodels.IdsecSIAConnector, connectorIDs []string, poolIDs []string) ([]accessmodels.IdsecSIAConnector, error) {
+	wantedIDs := stringSet(connectorIDs)
+	wantedPools := stringSet(poolIDs)
+	found := map[string]bool{}
+	selected := make([]accessmodels.IdsecSIAConnector, 0, len(connectors))
+	for _, connector := range connectors {
+		if len(wantedIDs) > 0 && !wantedIDs[connector.ID] {
+			continue
+		}
+		if len(wantedPools) > 0 && !wantedPools[connector.ConnectorPoolID] {
+			continue
+		}
+		found[connector.ID] = true
+		selected = append(selected, connector)
+	}
+	if missing := missingIDs(connectorIDs, found); len(missing) > 0 {
+		return nil, fmt.Errorf("connectors [%s] not found", strings.Join(missing, ", "))
+	}
+	sort.SliceStable(selected, func(i, j int) bool {
+		if selected[i].ConnectorPoolID != selected[j].ConnectorPoolID {
+			return selected[i].ConnectorPoolID < selected[j].ConnectorPoolID
+		}
+		return selected[i].ID < selected[j].ID
+	})
+	return selected, nil
+}
+
+func selectRelays(relays []*accessmodels.IdsecSIAHTTPSRelay, relayIDs []string) ([]*accessmodels.IdsecSIAHTTPSRelay, error) {
+	wantedIDs := stringSet(relayIDs)
+	found := map[string]bool{}
+	selected := make([]*accessmodels.IdsecSIAHTTPSRelay, 0, len(relays))
+	for _, relay := range relays {
+		if len(wantedIDs) > 0 && !wantedIDs[relay.HTTPSRelayID] {
+			continue
+		}
+		found[relay.HTTPSRelayID] = true
+		selected = append(selected, relay)
+	}
+	if missing := missingIDs(relayIDs, found); len(missing) > 0 {
+		return nil, fmt.Errorf("HTTPS relays [%s] not found", strings.Join(missing, ", "))
+	}
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].HTTPSRelayID < selected[j].HTTPSRelayID
+	})
+	return selected, nil
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+func missingIDs(ids []string, found map[string]bool) []string {
+	var missing []string
+	for _, id := range ids {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+func addRollingItem(result *accessmodels.IdsecSIARollingOperationResult, item accessmodels.IdsecSIARollingOperationItem) {
+	result.Items = append(result.Items, item)
+	switch item.Status {
+	case accessmodels.RollingItemSucceeded:
+		result.Succeeded++
+	case accessmodels.RollingItemFailed:
+		result.Failed++
+	case accessmodels.RollingItemSkipped:
+		result.Skipped++
+	}
+}
+
+func rollingResultError(result *accessmodels.IdsecSIARollingOperationResult, kind string) error {
+	var failed []string
+	for _, item := range result.Items {
+		if item.Status == accessmodels.RollingItemFailed {
+			failed = append(failed, item.ID)
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("rolling %s failed on %s [%s]", result.Operation, kind, strings.Join(failed, ", "))
+}
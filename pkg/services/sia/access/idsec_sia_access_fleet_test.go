@@ -0,0 +1,386 @@
+package access
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	accessmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/access/models"
+)
+
+// fakeFleet is an in-memory SIA connector management API serving connectors and HTTPS relays.
+type fakeFleet struct {
+	mu                 sync.Mutex
+	connectors         []map[string]interface{}
+	relays             []map[string]interface{}
+	rotationJobStatus  map[string]string
+	reachabilityStatus string
+	relaysUnavailable  bool
+	maintenance        map[string]bool
+	maxPoolMaintenance map[string]int
+	events             []string
+	jobCounter         int
+}
+
+func newFakeFleet(connectors []map[string]interface{}, relays []map[string]interface{}) *fakeFleet {
+	return &fakeFleet{
+		connectors:         connectors,
+		relays:             relays,
+		rotationJobStatus:  map[string]string{},
+		reachabilityStatus: "SUCCESS",
+		maintenance:        map[string]bool{},
+		maxPoolMaintenance: map[string]int{},
+	}
+}
+
+func (f *fakeFleet) connector(id string) map[string]interface{} {
+	for _, connector := range f.connectors {
+		if connector["id"] == id {
+			return connector
+		}
+	}
+	return nil
+}
+
+func (f *fakeFleet) relay(id string) map[string]interface{} {
+	for _, relay := range f.relays {
+		if relay["id"] == id {
+			return relay
+		}
+	}
+	return nil
+}
+
+func (f *fakeFleet) nextJobDate() string {
+	f.jobCounter++
+	return fmt.Sprintf("2026-01-01T00:00:%02dZ", f.jobCounter)
+}
+
+func (f *fakeFleet) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == connectorsURL:
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": len(f.connectors), "items": f.connectors})
+	case r.Method == http.MethodGet && r.URL.Path == httpsRelaysURL && f.relaysUnavailable:
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"error":"unavailable"}`))
+	case r.Method == http.MethodGet && r.URL.Path == httpsRelaysURL:
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"items": f.relays, "continuationToken": ""})
+	case r.Method == http.MethodPut && len(parts) == 4 && parts[3] == "maintenance":
+		var body map[string]bool
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		id := parts[2]
+		f.maintenance[id] = body["maintenance"]
+		f.events = append(f.events, fmt.Sprintf("%s:maintenance=%t", id, body["maintenance"]))
+		pool := f.connector(id)["connectorPoolId"]
+		inMaintenance := 0
+		for _, connector := range f.connectors {
+			if connector["connectorPoolId"] == pool && f.maintenance[connector["id"].(string)] {
+				inMaintenance++
+			}
+		}
+		if inMaintenance > f.maxPoolMaintenance[pool.(string)] {
+			f.maxPoolMaintenance[pool.(string)] = inMaintenance
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"connector_id": id, "maintenance": body["maintenance"]})
+	case r.Method == http.MethodPost && len(parts) == 4 && parts[1] == "connectors" && parts[3] == "rotate":
+		id := parts[2]
+		f.events = append(f.events, id+":rotate")
+		status := f.rotationJobStatus[id]
+		if status == "" {
+			status = "SUCCEEDED"
+		}
+		connector := f.connector(id)
+		connector["last_rotation_job_status"] = status
+		connector["last_rotation_job_info_update_date"] = f.nextJobDate()
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{}`))
+	case r.Method == http.MethodPost && len(parts) == 4 && parts[3] == "reachability":
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"targets":  []map[string]interface{}{{"target_ip": "10.0.0.1", "target_port": 22, "status": "SUCCESS"}},
+			"backends": []map[string]interface{}{{"backend_connector_endpoint": "backend", "status": f.reachabilityStatus, "description": "timeout"}},
+		})
+	case r.Method == http.MethodPost && len(parts) == 4 && parts[1] == "https-relays":
+		id := parts[2]
+		f.events = append(f.events, id+":"+parts[3])
+		relay := f.relay(id)
+		if parts[3] == "upgrade" {
+			relay["last_job_status"] = "SUCCEEDED"
+			relay["last_job_info_update_date"] = f.nextJobDate()
+			relay["is_latest_version"] = true
+		} else {
+			relay["last_rotation_job_status"] = "SUCCEEDED"
+			relay["last_rotation_job_info_update_date"] = f.nextJobDate()
+		}
+		_, _ = w.Write([]byte(`{}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func fleetConnector(id string, pool string, status string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":              id,
+		"connectorPoolId": pool,
+		"status":          status,
+		"version":         "1.0",
+		"isLatestVersion": true,
+	}
+}
+
+func fleetRelay(id string, statusCode int, latest bool) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                id,
+		"status":            "ACTIVE",
+		"status_code":       statusCode,
+		"version":           "2.0",
+		"is_latest_version": latest,
+	}
+}
+
+func newFleetService(t *testing.T, fleet *fakeFleet) *IdsecSIAAccessService {
+	t.Helper()
+	server := httptest.NewServer(fleet)
+	t.Cleanup(server.Close)
+	return newServiceWithHTTPTest(t, server.URL)
+}
+
+func TestFleetHealth(t *testing.T) {
+	outdated := fleetConnector("c-2", "pool-a", "DISCONNECTED")
+	outdated["isLatestVersion"] = false
+	outdated["version"] = "0.9"
+	fleet := newFakeFleet(
+		[]map[string]interface{}{fleetConnector("c-1", "pool-a", "ACTIVE"), outdated},
+		[]map[string]interface{}{fleetRelay("r-1", 1, false)},
+	)
+	fleet.reachabilityStatus = "FAILED"
+	svc := newFleetService(t, fleet)
+
+	report, err := svc.FleetHealth(&accessmodels.IdsecSIAFleetHealth{
+		CheckReachability:     true,
+		TargetHostname:        "10.0.0.1",
+		TargetPort:            22,
+		CheckBackendEndpoints: true,
+	})
+	require.NoError(t, err)
+	require.False(t, report.Healthy)
+	require.Len(t, report.Connectors, 2)
+
+	active := report.Connectors[0]
+	require.Equal(t, "c-1", active.ConnectorID)
+	require.Equal(t, "pool-a", active.ConnectorPoolID)
+	require.NotNil(t, active.Reachable)
+	require.False(t, *active.Reachable)
+	require.False(t, active.Healthy)
+	require.Len(t, active.Issues, 1)
+	require.Contains(t, active.Issues[0], "backend [backend] is not reachable")
+
+	disconnected := report.Connectors[1]
+	require.Nil(t, disconnected.Reachable, "inactive connectors are not tested")
+	require.Equal(t, []string{"status is [DISCONNECTED]"}, disconnected.Issues)
+
+	require.Equal(t, map[string][]string{"1.0": {"c-1"}, "0.9": {"c-2"}}, report.ConnectorVersions)
+	require.Equal(t, []string{"c-2"}, report.OutdatedConnectors)
+	require.Len(t, report.Relays, 1)
+	require.True(t, report.Relays[0].Healthy)
+	require.Equal(t, []string{"r-1"}, report.OutdatedRelays)
+}
+
+func TestRollingRotateConnectors(t *testing.T) {
+	tests := []struct {
+		name              string
+		rotationJobStatus map[string]string
+		continueOnFailure bool
+		expectedStatuses  map[string]string
+		expectedError     string
+		maxInMaintenance  int
+	}{
+		{
+			name: "all_succeed",
+			expectedStatuses: map[string]string{
+				"c-1": accessmodels.RollingItemSucceeded,
+				"c-2": accessmodels.RollingItemSucceeded,
+				"c-3": accessmodels.RollingItemSucceeded,
+				"c-4": accessmodels.RollingItemSkipped,
+			},
+			maxInMaintenance: 1,
+		},
+		{
+			name:              "failure_stops_pool",
+			rotationJobStatus: map[string]string{"c-1": "FAILED"},
+			expectedStatuses: map[string]string{
+				"c-1": accessmodels.RollingItemFailed,
+				"c-2": accessmodels.RollingItemSkipped,
+				"c-3": accessmodels.RollingItemSucceeded,
+				"c-4": accessmodels.RollingItemSkipped,
+			},
+			expectedError:    "rolling rotate failed on connectors [c-1]",
+			maxInMaintenance: 1,
+		},
+		{
+			name:              "failure_continues",
+			rotationJobStatus: map[string]string{"c-1": "FAILED"},
+			continueOnFailure: true,
+			expectedStatuses: map[string]string{
+				"c-1": accessmodels.RollingItemFailed,
+				"c-2": accessmodels.RollingItemSucceeded,
+				"c-3": accessmodels.RollingItemSucceeded,
+				"c-4": accessmodels.RollingItemSkipped,
+			},
+			expectedError: "rolling rotate failed on connectors [c-1]",
+			// The failed connector stays in maintenance mode while the next one is rotated
+			maxInMaintenance: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fleet := newFakeFleet([]map[string]interface{}{
+				fleetConnector("c-2", "pool-a", "ACTIVE"),
+				fleetConnector("c-1", "pool-a", "ACTIVE"),
+				fleetConnector("c-3", "pool-b", "ACTIVE"),
+				fleetConnector("c-4", "pool-b", "DISCONNECTED"),
+			}, nil)
+			for id, status := range tt.rotationJobStatus {
+				fleet.rotationJobStatus[id] = status
+			}
+			svc := newFleetService(t, fleet)
+
+			result, err := svc.RollingRotateConnectors(&accessmodels.IdsecSIARollingRotateConnectors{
+				HealthTimeout:     5,
+				ContinueOnFailure: tt.continueOnFailure,
+			})
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+			require.NotNil(t, result)
+
+			statuses := map[string]string{}
+			for _, item := range result.Items {
+				statuses[item.ID] = item.Status
+				require.Equal(t, item.Status == accessmodels.RollingItemFailed, item.LeftInMaintenance)
+			}
+			require.Equal(t, tt.expectedStatuses, statuses)
+			require.Equal(t, []string{"c-1", "c-2", "c-3", "c-4"}, []string{result.Items[0].ID, result.Items[1].ID, result.Items[2].ID, result.Items[3].ID})
+			require.Equal(t, tt.maxInMaintenance, fleet.maxPoolMaintenance["pool-a"])
+			require.False(t, fleet.maintenance["c-3"])
+		})
+	}
+}
+
+func TestRollingRotateConnectors_Filters(t *testing.T) {
+	fleet := newFakeFleet([]map[string]interface{}{
+		fleetConnector("c-1", "pool-a", "ACTIVE"),
+		fleetConnector("c-2", "pool-b", "ACTIVE"),
+	}, nil)
+	svc := newFleetService(t, fleet)
+
+	result, err := svc.RollingRotateConnectors(&accessmodels.IdsecSIARollingRotateConnectors{
+		ConnectorPoolIDs: []string{"pool-b"},
+		HealthTimeout:    5,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	require.Equal(t, "c-2", result.Items[0].ID)
+	require.Equal(t, []string{"c-2:maintenance=true", "c-2:rotate", "c-2:maintenance=false"}, fleet.events)
+
+	_, err = svc.RollingRotateConnectors(&accessmodels.IdsecSIARollingRotateConnectors{ConnectorIDs: []string{"c-9"}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "connectors [c-9] not found")
+}
+
+func TestRollingUpdateRelays(t *testing.T) {
+	fleet := newFakeFleet(nil, []map[string]interface{}{
+		fleetRelay("r-2", 1, true),
+		fleetRelay("r-1", 1, false),
+		fleetRelay("r-3", 0, false),
+	})
+	svc := newFleetService(t, fleet)
+
+	result, err := svc.RollingUpdateRelays(&accessmodels.IdsecSIARollingUpdateRelays{
+		Operation:     accessmodels.RollingOperationUpgrade,
+		HealthTimeout: 5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Succeeded)
+	require.Equal(t, 2, result.Skipped)
+	require.Equal(t, "r-1", result.Items[0].ID)
+	require.Equal(t, accessmodels.RollingItemSucceeded, result.Items[0].Status)
+	require.Equal(t, []string{"r-1:upgrade"}, fleet.events)
+
+	_, err = svc.RollingUpdateRelays(&accessmodels.IdsecSIARollingUpdateRelays{Operation: "restart"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unsupported rolling operation")
+}
+
+func TestFleetOperations_FailWhenRelaysCannotBeListed(t *testing.T) {
+	fleet := newFakeFleet([]map[string]interface{}{fleetConnector("c-1", "pool-a", "ACTIVE")}, nil)
+	fleet.relaysUnavailable = true
+	svc := newFleetService(t, fleet)
+
+	_, err := svc.FleetHealth(&accessmodels.IdsecSIAFleetHealth{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to list HTTPS relays - [503]")
+
+	_, err = svc.RollingUpdateRelays(&accessmodels.IdsecSIARollingUpdateRelays{Operation: accessmodels.RollingOperationRotate})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to list HTTPS relays - [503]")
+	require.Empty(t, fleet.events)
+}
+
+func TestRollingUpdateRelaysContext_CanceledSkipsRemaining(t *testing.T) {
+	fleet := newFakeFleet(nil, []map[string]interface{}{fleetRelay("r-1", 1, false), fleetRelay("r-2", 1, false)})
+	svc := newFleetService(t, fleet)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := svc.RollingUpdateRelaysContext(ctx, &accessmodels.IdsecSIARollingUpdateRelays{
+		Operation:     accessmodels.RollingOperationUpgrade,
+		HealthTimeout: 5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, result.Skipped)
+	require.Contains(t, result.Items[0].Error, "canceled")
+	require.Empty(t, fleet.events)
+}
+
+func TestWaitForHealth(t *testing.T) {
+	svc := newFleetService(t, newFakeFleet(nil, nil))
+	unhealthy := func() (bool, error) { return false, nil }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	err := svc.waitForHealth(ctx, 60, 60, unhealthy)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "canceled")
+	require.Less(t, time.Since(started), 5*time.Second)
+
+	err = svc.waitForHealth(context.Background(), 0, 60, unhealthy)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not healthy after [0] seconds")
+
+	checks := 0
+	err = svc.waitForHealth(context.Background(), 5, 0, func() (bool, error) {
+		checks++
+		return checks == 2, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, checks)
+}
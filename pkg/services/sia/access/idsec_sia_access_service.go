@@ -637,7 +637,7 @@ func (s *IdsecSIAAccessService) UpdateConnectorMaintenanceMode(maintenanceConnec
 // ListRelays returns HTTPS relays page-by-page via a channel.
 // Each page contains up to 500 items (server-enforced maximum). The channel is
 // closed when all pages have been delivered or an error occurs during pagination;
-// errors are logged and cause the channel to be closed early.
+// an error is delivered as a final page with Err set, after which the channel is closed.
 //
 // Example:
 //
@@ -646,6 +646,9 @@ func (s *IdsecSIAAccessService) UpdateConnectorMaintenanceMode(maintenanceConnec
 //	    return err
 //	}
 //	for page := range pages {
+//	    if page.Err != nil {
+//	        return page.Err
+//	    }
 //	    for _, relay := range page.Items {
 //	        fmt.Printf("Relay: %s\n", relay.HTTPSRelayID)
 //	    }
@@ -662,12 +665,15 @@ func (s *IdsecSIAAccessService) ListRelays() (<-chan *IdsecSIAHTTPSRelayPage, er
 			response, err := s.ISPClient().Get(context.Background(), httpsRelaysURL, queryParams)
 			if err != nil {
 				s.Logger.Error("Failed to list HTTPS relays: %v", err)
+				output <- &IdsecSIAHTTPSRelayPage{Err: fmt.Errorf("failed to list HTTPS relays: %w", err)}
 				return
 			}
 
 			if response.StatusCode != http.StatusOK {
-				s.Logger.Error("Failed to list HTTPS relays - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+				err = fmt.Errorf("failed to list HTTPS relays - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+				s.Logger.Error("%v", err)
 				_ = response.Body.Close()
+				output <- &IdsecSIAHTTPSRelayPage{Err: err}
 				return
 			}
 
@@ -675,6 +681,7 @@ func (s *IdsecSIAAccessService) ListRelays() (<-chan *IdsecSIAHTTPSRelayPage, er
 			_ = response.Body.Close()
 			if err != nil {
 				s.Logger.Error("Failed to decode HTTPS relays response: %v", err)
+				output <- &IdsecSIAHTTPSRelayPage{Err: fmt.Errorf("failed to decode HTTPS relays response: %w", err)}
 				return
 			}
 
@@ -698,6 +705,7 @@ func (s *IdsecSIAAccessService) ListRelays() (<-chan *IdsecSIAHTTPSRelayPage, er
 			var page IdsecSIAHTTPSRelayPage
 			if err = mapstructure.Decode(result, &page); err != nil {
 				s.Logger.Error("Failed to decode HTTPS relay items: %v", err)
+				output <- &IdsecSIAHTTPSRelayPage{Err: fmt.Errorf("failed to decode HTTPS relay items: %w", err)}
 				return
 			}
 
@@ -721,20 +729,39 @@ func (s *IdsecSIAAccessService) GetRelay(getRelay *accessmodels.IdsecSIAGetHTTPS
 		return nil, fmt.Errorf("HTTPS relay ID is required")
 	}
 	s.Logger.Info("Retrieving HTTPS relay [%s]", getRelay.HTTPSRelayID)
-	pages, err := s.ListRelays()
+	relays, err := s.listAllRelays()
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve HTTPS relay: %w", err)
 	}
-	for page := range pages {
-		for _, relay := range page.Items {
-			if relay.HTTPSRelayID == getRelay.HTTPSRelayID {
-				return relay, nil
-			}
+	for _, relay := range relays {
+		if relay.HTTPSRelayID == getRelay.HTTPSRelayID {
+			return relay, nil
 		}
 	}
 	return nil, fmt.Errorf("HTTPS relay [%s] not found", getRelay.HTTPSRelayID)
 }
 
+// listAllRelays drains ListRelays and returns every HTTPS relay, or the error that stopped the listing.
+func (s *IdsecSIAAccessService) listAllRelays() ([]*accessmodels.IdsecSIAHTTPSRelay, error) {
+	pages, err := s.ListRelays()
+	if err != nil {
+		return nil, err
+	}
+	var relays []*accessmodels.IdsecSIAHTTPSRelay
+	var listErr error
+	for page := range pages {
+		if page.Err != nil {
+			listErr = page.Err
+			continue
+		}
+		relays = append(relays, page.Items...)
+	}
+	if listErr != nil {
+		return nil, listErr
+	}
+	return relays, nil
+}
+
 // DeleteRelay deletes an existing HTTPS relay.
 func (s *IdsecSIAAccessService) DeleteRelay(deleteRelay *accessmodels.IdsecSIADeleteHTTPSRelay) error {
 	if deleteRelay.HTTPSRelayID == "" {
@@ -304,12 +304,18 @@ func TestListRelays_Non200Status(t *testing.T) {
 	pages, err := svc.ListRelays()
 	require.NoError(t, err)
 
-	// The channel should be closed without delivering any items.
+	// The channel should deliver a terminal error page without any items.
 	var all []*accessmodels.IdsecSIAHTTPSRelay
+	var listErr error
 	for page := range pages {
 		all = append(all, page.Items...)
+		if page.Err != nil {
+			listErr = page.Err
+		}
 	}
 	require.Empty(t, all)
+	require.Error(t, listErr)
+	require.Contains(t, listErr.Error(), "[403]")
 }
 
 func TestListRelays_Empty(t *testing.T) {
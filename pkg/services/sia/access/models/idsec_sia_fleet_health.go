@@ -0,0 +1,49 @@
+// Package models provides request and response model types for the SIA access service.
+package models
+
+// IdsecSIAFleetHealth represents the request to build a health report across all connectors and HTTPS relays.
+type IdsecSIAFleetHealth struct {
+	CheckReachability     bool   `json:"check_reachability" mapstructure:"check_reachability" flag:"check-reachability" desc:"Whether to test the reachability of every connector." default:"false"`
+	TargetHostname        string `json:"target_hostname,omitempty" mapstructure:"target_hostname,omitempty" flag:"target-hostname" desc:"The target hostname used to test the reachability of the connectors."`
+	TargetPort            int    `json:"target_port,omitempty" mapstructure:"target_port,omitempty" flag:"target-port" desc:"The target port used to test the reachability of the connectors." default:"22"`
+	CheckBackendEndpoints bool   `json:"check_backend_endpoints" mapstructure:"check_backend_endpoints" flag:"check-backend-endpoints" desc:"Whether the reachability test also checks the backend endpoints." default:"true"`
+	Concurrency           int    `json:"concurrency,omitempty" mapstructure:"concurrency,omitempty" flag:"concurrency" desc:"The maximum number of reachability tests run concurrently." default:"5"`
+}
+
+// IdsecSIAConnectorHealth represents the health of a single connector.
+type IdsecSIAConnectorHealth struct {
+	ConnectorID           string   `json:"connector_id" mapstructure:"connector_id" desc:"The connector ID."`
+	ConnectorPoolID       string   `json:"connector_pool_id,omitempty" mapstructure:"connector_pool_id,omitempty" desc:"The connector pool that the connector is part of."`
+	HostName              string   `json:"host_name,omitempty" mapstructure:"host_name,omitempty" desc:"The host name of the connector."`
+	Version               string   `json:"version,omitempty" mapstructure:"version,omitempty" desc:"The connector version."`
+	IsLatestVersion       bool     `json:"is_latest_version" mapstructure:"is_latest_version" desc:"Whether the connector is on the latest version."`
+	Status                string   `json:"status,omitempty" mapstructure:"status,omitempty" desc:"The connector status."`
+	LastRotationJobStatus string   `json:"last_rotation_job_status,omitempty" mapstructure:"last_rotation_job_status,omitempty" desc:"The status of the last certificate rotation job."`
+	Reachable             *bool    `json:"reachable,omitempty" mapstructure:"reachable,omitempty" desc:"Whether the reachability test passed, set only when reachability is checked."`
+	Healthy               bool     `json:"healthy" mapstructure:"healthy" desc:"Whether the connector is healthy."`
+	Issues                []string `json:"issues,omitempty" mapstructure:"issues,omitempty" desc:"The issues found on the connector."`
+}
+
+// IdsecSIAHTTPSRelayHealth represents the health of a single HTTPS relay.
+type IdsecSIAHTTPSRelayHealth struct {
+	HTTPSRelayID          string   `json:"https_relay_id" mapstructure:"https_relay_id" desc:"The ID of the HTTPS relay."`
+	HostName              string   `json:"host_name,omitempty" mapstructure:"host_name,omitempty" desc:"The host name of the HTTPS relay."`
+	Version               string   `json:"version,omitempty" mapstructure:"version,omitempty" desc:"The HTTPS relay version."`
+	IsLatestVersion       bool     `json:"is_latest_version" mapstructure:"is_latest_version" desc:"Whether the HTTPS relay is on the latest version."`
+	Status                string   `json:"status,omitempty" mapstructure:"status,omitempty" desc:"The HTTPS relay status."`
+	LastJobStatus         string   `json:"last_job_status,omitempty" mapstructure:"last_job_status,omitempty" desc:"The status of the last executed job."`
+	LastRotationJobStatus string   `json:"last_rotation_job_status,omitempty" mapstructure:"last_rotation_job_status,omitempty" desc:"The status of the last certificate rotation job."`
+	Healthy               bool     `json:"healthy" mapstructure:"healthy" desc:"Whether the HTTPS relay is healthy."`
+	Issues                []string `json:"issues,omitempty" mapstructure:"issues,omitempty" desc:"The issues found on the HTTPS relay."`
+}
+
+// IdsecSIAFleetHealthReport represents the health of all connectors and HTTPS relays, including version drift.
+type IdsecSIAFleetHealthReport struct {
+	Healthy            bool                       `json:"healthy" mapstructure:"healthy" desc:"Whether every connector and HTTPS relay is healthy."`
+	Connectors         []IdsecSIAConnectorHealth  `json:"connectors" mapstructure:"connectors" desc:"The health of every connector."`
+	Relays             []IdsecSIAHTTPSRelayHealth `json:"relays" mapstructure:"relays" desc:"The health of every HTTPS relay."`
+	ConnectorVersions  map[string][]string        `json:"connector_versions" mapstructure:"connector_versions" desc:"The connector IDs per connector version."`
+	RelayVersions      map[string][]string        `json:"relay_versions" mapstructure:"relay_versions" desc:"The HTTPS relay IDs per HTTPS relay version."`
+	OutdatedConnectors []string                   `json:"outdated_connectors" mapstructure:"outdated_connectors" desc:"The IDs of the connectors that are not on the latest version."`
+	OutdatedRelays     []string                   `json:"outdated_relays" mapstructure:"outdated_relays" desc:"The IDs of the HTTPS relays that are not on the latest version."`
+}
@@ -14,6 +14,7 @@ type IdsecSIAConnector struct {
 	HostType                         string `json:"hostType" mapstructure:"host_type" description:"The platform type of the host. Valid values: AWS, AZURE or ON-PREMISE."`                    //nolint:tagliatelle
 	HostNetwork                      string `json:"hostNetwork" mapstructure:"host_network" description:"The virtual network of the host. For AWS, it is the VPC. For AZURE, it is the Vnet."` //nolint:tagliatelle
 	HostSubnet                       string `json:"hostSubnet" mapstructure:"host_subnet" description:"The subnet of the host."`                                                               //nolint:tagliatelle
+	ConnectorPoolID                  string `json:"connectorPoolId" mapstructure:"connector_pool_id" description:"The connector pool that the connector is part of."`                          //nolint:tagliatelle
 	Version                          string `json:"version" mapstructure:"version" description:"The connector version."`
 	ActiveSessionsCount              int    `json:"activeSessionsCount" mapstructure:"active_sessions_count" description:"The number of active sessions currently running on the connector." ge:"0"` //nolint:tagliatelle
 	Status                           string `json:"status" mapstructure:"status" description:"The connector's current status."`
@@ -0,0 +1,53 @@
+// Package models provides request and response model types for the SIA access service.
+package models
+
+// Possible rolling operations on HTTPS relays.
+const (
+	RollingOperationUpgrade = "upgrade"
+	RollingOperationRotate  = "rotate"
+)
+
+// Possible outcomes of a rolling operation on a single connector or HTTPS relay.
+const (
+	RollingItemSucceeded = "succeeded"
+	RollingItemFailed    = "failed"
+	RollingItemSkipped   = "skipped"
+)
+
+// IdsecSIARollingRotateConnectors represents the request to rotate the certificates of connectors one connector per pool at a time.
+type IdsecSIARollingRotateConnectors struct {
+	ConnectorIDs          []string `json:"connector_ids,omitempty" mapstructure:"connector_ids,omitempty" flag:"connector-ids" desc:"The IDs of the connectors to rotate. All connectors are rotated when not set."`
+	ConnectorPoolIDs      []string `json:"connector_pool_ids,omitempty" mapstructure:"connector_pool_ids,omitempty" flag:"connector-pool-ids" desc:"The connector pools to rotate. All pools are rotated when not set."`
+	HealthTimeout         int      `json:"health_timeout" mapstructure:"health_timeout" flag:"health-timeout" desc:"The number of seconds to wait for a connector to be healthy after its rotation." default:"600"`
+	HealthPollInterval    int      `json:"health_poll_interval" mapstructure:"health_poll_interval" flag:"health-poll-interval" desc:"The number of seconds to wait between health checks." default:"15"`
+	MaintenanceRetryCount int      `json:"maintenance_retry_count" mapstructure:"maintenance_retry_count" flag:"maintenance-retry-count" desc:"The number of times to retry updating the maintenance mode of a connector, if it fails." default:"10"`
+	MaintenanceRetryDelay int      `json:"maintenance_retry_delay" mapstructure:"maintenance_retry_delay" flag:"maintenance-retry-delay" desc:"The number of seconds to wait between maintenance mode retries." default:"5"`
+	ContinueOnFailure     bool     `json:"continue_on_failure" mapstructure:"continue_on_failure" flag:"continue-on-failure" desc:"Whether to continue with the next connector of a pool after a connector failed. The failed connector stays in maintenance mode meanwhile." default:"false"`
+}
+
+// IdsecSIARollingUpdateRelays represents the request to upgrade or rotate HTTPS relays one HTTPS relay at a time.
+type IdsecSIARollingUpdateRelays struct {
+	Operation          string   `json:"operation" mapstructure:"operation" flag:"operation" desc:"The operation to run on every HTTPS relay (upgrade, rotate)." validate:"required" choices:"upgrade,rotate"`
+	HTTPSRelayIDs      []string `json:"https_relay_ids,omitempty" mapstructure:"https_relay_ids,omitempty" flag:"https-relay-ids" desc:"The IDs of the HTTPS relays to update. All HTTPS relays are updated when not set."`
+	HealthTimeout      int      `json:"health_timeout" mapstructure:"health_timeout" flag:"health-timeout" desc:"The number of seconds to wait for an HTTPS relay to be healthy after its update." default:"600"`
+	HealthPollInterval int      `json:"health_poll_interval" mapstructure:"health_poll_interval" flag:"health-poll-interval" desc:"The number of seconds to wait between health checks." default:"15"`
+	ContinueOnFailure  bool     `json:"continue_on_failure" mapstructure:"continue_on_failure" flag:"continue-on-failure" desc:"Whether to continue with the next HTTPS relay after an HTTPS relay failed." default:"false"`
+}
+
+// IdsecSIARollingOperationItem represents the outcome of a rolling operation on a single connector or HTTPS relay.
+type IdsecSIARollingOperationItem struct {
+	ID                string `json:"id" mapstructure:"id" desc:"The ID of the connector or HTTPS relay."`
+	ConnectorPoolID   string `json:"connector_pool_id,omitempty" mapstructure:"connector_pool_id,omitempty" desc:"The connector pool of the connector."`
+	Status            string `json:"status" mapstructure:"status" desc:"The outcome of the operation (succeeded, failed, skipped)."`
+	Error             string `json:"error,omitempty" mapstructure:"error,omitempty" desc:"The reason the operation failed or was skipped."`
+	LeftInMaintenance bool   `json:"left_in_maintenance,omitempty" mapstructure:"left_in_maintenance,omitempty" desc:"Whether the connector was left in maintenance mode because it did not become healthy."`
+}
+
+// IdsecSIARollingOperationResult represents the outcome of a rolling operation.
+type IdsecSIARollingOperationResult struct {
+	Operation string                         `json:"operation" mapstructure:"operation" desc:"The operation that was run."`
+	Items     []IdsecSIARollingOperationItem `json:"items" mapstructure:"items" desc:"The outcome per connector or HTTPS relay, in processing order."`
+	Succeeded int                            `json:"succeeded" mapstructure:"succeeded" desc:"The number of connectors or HTTPS relays that were updated."`
+	Failed    int                            `json:"failed" mapstructure:"failed" desc:"The number of connectors or HTTPS relays that failed."`
+	Skipped   int                            `json:"skipped" mapstructure:"skipped" desc:"The number of connectors or HTTPS relays that were skipped."`
+}
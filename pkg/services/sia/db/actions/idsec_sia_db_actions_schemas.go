@@ -7,6 +7,10 @@ var ActionToSchemaMap = map[string]interface{}{
 	"psql":                      &dbmodels.IdsecSIADBPsqlExecution{},
 	"mysql":                     &dbmodels.IdsecSIADBMysqlExecution{},
 	"sqlcmd":                    &dbmodels.IdsecSIADBSqlcmdExecution{},
+	"mongosh":                   &dbmodels.IdsecSIADBMongoshExecution{},
+	"db2":                       &dbmodels.IdsecSIADBDb2Execution{},
+	"sqlplus":                   &dbmodels.IdsecSIADBSqlplusExecution{},
+	"tunnel":                    &dbmodels.IdsecSIADBTunnelExecution{},
 	"generate-oracle-tnsnames":  &dbmodels.IdsecSIADBOracleGenerateAssets{},
 	"generate-proxy-full-chain": &dbmodels.IdsecSIADBProxyFullChainGenerateAssets{},
 }
@@ -5,13 +5,23 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	jwt "github.com/golang-jwt/jwt/v5"
 	"github.com/cyberark/idsec-sdk-golang/pkg/auth"
@@ -25,8 +35,18 @@ import (
 )
 
 const (
-	assetsURL            = "api/adb/guidance/generate"
-	defaultSqlcmdTimeout = 60
+	assetsURL              = "api/adb/guidance/generate"
+	defaultSqlcmdTimeout   = 60
+	defaultMongoProxyPort  = 27017
+	defaultDb2ProxyPort    = 50002
+	defaultOracleProxyPort = 2484
+)
+
+var (
+	// db2IdentifierPattern matches DB2 database names, which are at most 8 characters.
+	db2IdentifierPattern = regexp.MustCompile(`^[A-Za-z@#$][A-Za-z0-9@#$_]{0,7}$`)
+	// oracleServiceNamePattern matches Oracle service names, which are embedded in a connect descriptor.
+	oracleServiceNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._$#-]*$`)
 )
 
 // IdsecSIADBService is a struct that implements the IdsecService interface and provides functionality for DB service of SIA.
@@ -178,6 +198,122 @@ func (s *IdsecSIADBService) createMyLoginCnf(username string, address string, pa
 	return tempFile.Name(), nil
 }
 
+func (s *IdsecSIADBService) createTempCredentialsFile(pattern string, content string) (string, error) {
+	tempFile, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	defer tempFile.Close() //nolint:errcheck
+	if err := os.Chmod(tempFile.Name(), 0600); err != nil {
+		_ = os.Remove(tempFile.Name())
+		return "", err
+	}
+	if _, err := tempFile.Write([]byte(content)); err != nil {
+		_ = os.Remove(tempFile.Name())
+		return "", err
+	}
+	return tempFile.Name(), nil
+}
+
+func (s *IdsecSIADBService) removeTempCredentialsFile(path string) {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		s.Logger.Warning("Error removing temporary credentials file: %v", err)
+	}
+}
+
+// mongoshConnectScript builds a mongosh script which connects to the SIA proxy with the
+// short-lived password, so the password never appears on the command line.
+func mongoshConnectScript(username string, address string, port int, password string, database string) string {
+	uri := url.URL{
+		Scheme:   "mongodb",
+		User:     url.UserPassword(username, password),
+		Host:     net.JoinHostPort(address, strconv.Itoa(port)),
+		Path:     "/" + database,
+		RawQuery: "tls=true&authMechanism=PLAIN&authSource=%24external",
+	}
+	return fmt.Sprintf("db = connect(%s);\n", strconv.Quote(uri.String()))
+}
+
+// db2AliasSuffix derives a short deterministic suffix for the DB2 node and database
+// aliases, which are limited to 8 characters.
+func db2AliasSuffix(address string, database string) string {
+	sum := sha256.Sum256([]byte(address + "/" + database))
+	return strings.ToUpper(hex.EncodeToString(sum[:])[:5])
+}
+
+// db2Quote quotes a value as a DB2 CLP delimited string, doubling embedded quotes.
+// Line breaks and the ';' statement terminator used by `db2 -t` cannot be escaped and are rejected.
+func db2Quote(name string, value string) (string, error) {
+	if strings.ContainsAny(value, ";\r\n") {
+		return "", fmt.Errorf("%s contains characters unsupported by the db2 command line processor", name)
+	}
+	return `"` + strings.ReplaceAll(value, `"`, `""`) + `"`, nil
+}
+
+// db2ConnectScript builds the DB2 CLP script which catalogs the SIA proxy and connects to it.
+func db2ConnectScript(nodeName string, alias string, username string, address string, port int, password string, database string) (string, error) {
+	quotedUsername, err := db2Quote("username", username)
+	if err != nil {
+		return "", err
+	}
+	quotedPassword, err := db2Quote("password", password)
+	if err != nil {
+		return "", err
+	}
+	if !db2IdentifierPattern.MatchString(database) {
+		return "", fmt.Errorf("invalid db2 database name [%s]", database)
+	}
+	return fmt.Sprintf(
+		"CATALOG TCPIP NODE %s REMOTE %s SERVER %d SECURITY SSL;\nCATALOG DATABASE %s AS %s AT NODE %s;\nCONNECT TO %s USER %s USING %s;\n",
+		nodeName, address, port, database, alias, nodeName, alias, quotedUsername, quotedPassword,
+	), nil
+}
+
+// db2UncatalogScript builds the DB2 CLP script which removes the catalog entries added by db2ConnectScript.
+func db2UncatalogScript(nodeName string, alias string) string {
+	return fmt.Sprintf("UNCATALOG DATABASE %s;\nUNCATALOG NODE %s;\nTERMINATE;\n", alias, nodeName)
+}
+
+// sqlplusQuote quotes a value for a SQL*Plus CONNECT command. Oracle quoted
+// identifiers and passwords cannot contain double quotes, so those and line breaks are rejected.
+func sqlplusQuote(name string, value string) (string, error) {
+	if strings.ContainsAny(value, "\"\r\n") {
+		return "", fmt.Errorf("%s contains characters unsupported by sqlplus", name)
+	}
+	return `"` + value + `"`, nil
+}
+
+// sqlplusConnectScript builds the SQL*Plus script which connects to the SIA proxy over TCPS.
+func sqlplusConnectScript(username string, address string, port int, password string, serviceName string) (string, error) {
+	if !oracleServiceNamePattern.MatchString(serviceName) {
+		return "", fmt.Errorf("invalid oracle service name [%s]", serviceName)
+	}
+	quotedUsername, err := sqlplusQuote("username", username)
+	if err != nil {
+		return "", err
+	}
+	quotedPassword, err := sqlplusQuote("password", password)
+	if err != nil {
+		return "", err
+	}
+	descriptor := fmt.Sprintf(
+		"(DESCRIPTION=(ADDRESS=(PROTOCOL=TCPS)(HOST=%s)(PORT=%d))(CONNECT_DATA=(SERVICE_NAME=%s)))",
+		address, port, serviceName,
+	)
+	return fmt.Sprintf("CONNECT %s/%s@\"%s\"\n", quotedUsername, quotedPassword, descriptor), nil
+}
+
+// proxyPortOrDefault returns the configured SIA proxy port, or the default port of the database family.
+func proxyPortOrDefault(port int, defaultPort int) (int, error) {
+	if port == 0 {
+		return defaultPort, nil
+	}
+	if port < 0 || port > 65535 {
+		return 0, fmt.Errorf("invalid proxy port [%d]", port)
+	}
+	return port, nil
+}
+
 func (s *IdsecSIADBService) generateAssets(
 	assetType string,
 	connectionMethod string,
@@ -305,8 +441,301 @@ func (s *IdsecSIADBService) Sqlcmd(sqlcmdExecution *dbmodels.IdsecSIADBSqlcmdExe
 	return common.ExecuteCommand(executionAction)
 }
 
+// Mongosh executes a mongosh command using the provided execution parameters.
+// The short-lived password is staged in a temporary connect script that is removed once mongosh exits.
+func (s *IdsecSIADBService) Mongosh(mongoshExecution *dbmodels.IdsecSIADBMongoshExecution) error {
+	proxyPort, err := proxyPortOrDefault(mongoshExecution.ProxyPort, defaultMongoProxyPort)
+	if err != nil {
+		return err
+	}
+	proxyAddress, err := s.proxyAddress("mongo")
+	if err != nil {
+		return err
+	}
+	connectionString, err := s.connectionString(mongoshExecution.TargetAddress, mongoshExecution.TargetUsername, mongoshExecution.NetworkName)
+	if err != nil {
+		return err
+	}
+	password, err := s.ssoService.ShortLivedPassword(&ssomodels.IdsecSIASSOGetShortLivedPassword{
+		Service: "DPA-DB",
+	})
+	if err != nil {
+		return err
+	}
+	scriptPath, err := s.createTempCredentialsFile("mongosh_connect_*.js", mongoshConnectScript(connectionString, proxyAddress, proxyPort, password, mongoshExecution.Database))
+	if err != nil {
+		return err
+	}
+	defer s.removeTempCredentialsFile(scriptPath)
+	return common.ExecuteCommandArgs(mongoshExecution.MongoshPath, "--nodb", "--shell", scriptPath)
+}
+
+// Db2 executes a DB2 command line processor session using the provided execution parameters.
+// The SIA proxy is cataloged and connected by a temporary CLP script, and the catalog entries
+// and script are removed once the session exits.
+func (s *IdsecSIADBService) Db2(db2Execution *dbmodels.IdsecSIADBDb2Execution) error {
+	if db2Execution.Database == "" {
+		return fmt.Errorf("database is required for db2 connections")
+	}
+	proxyPort, err := proxyPortOrDefault(db2Execution.ProxyPort, defaultDb2ProxyPort)
+	if err != nil {
+		return err
+	}
+	proxyAddress, err := s.proxyAddress("db2")
+	if err != nil {
+		return err
+	}
+	connectionString, err := s.connectionString(db2Execution.TargetAddress, db2Execution.TargetUsername, db2Execution.NetworkName)
+	if err != nil {
+		return err
+	}
+	password, err := s.ssoService.ShortLivedPassword(&ssomodels.IdsecSIASSOGetShortLivedPassword{
+		Service: "DPA-DB",
+	})
+	if err != nil {
+		return err
+	}
+	suffix := db2AliasSuffix(proxyAddress, db2Execution.Database)
+	nodeName, alias := "SIN"+suffix, "SIA"+suffix
+	connectScript, err := db2ConnectScript(nodeName, alias, connectionString, proxyAddress, proxyPort, password, db2Execution.Database)
+	if err != nil {
+		return err
+	}
+	scriptPath, err := s.createTempCredentialsFile("db2_connect_*.clp", connectScript)
+	if err != nil {
+		return err
+	}
+	defer s.removeTempCredentialsFile(scriptPath)
+	defer func() {
+		uncatalogPath, err := s.createTempCredentialsFile("db2_uncatalog_*.clp", db2UncatalogScript(nodeName, alias))
+		if err != nil {
+			s.Logger.Warning("Error creating db2 uncatalog script: %v", err)
+			return
+		}
+		defer s.removeTempCredentialsFile(uncatalogPath)
+		if err := common.ExecuteCommand(fmt.Sprintf("%s -tf %s", db2Execution.Db2Path, uncatalogPath)); err != nil {
+			s.Logger.Warning("Error removing db2 catalog entries: %v", err)
+		}
+	}()
+	// Both CLP invocations share the parent shell, so the connection made by the script
+	// carries over to the interactive session.
+	executionAction := fmt.Sprintf("%s -tf %s && %s", db2Execution.Db2Path, scriptPath, db2Execution.Db2Path)
+	return common.ExecuteCommand(executionAction)
+}
+
+// Sqlplus executes a SQL*Plus command using the provided execution parameters.
+// The short-lived password is staged in a temporary connect script that is removed once SQL*Plus exits.
+func (s *IdsecSIADBService) Sqlplus(sqlplusExecution *dbmodels.IdsecSIADBSqlplusExecution) error {
+	if sqlplusExecution.ServiceName == "" {
+		return fmt.Errorf("service name is required for sqlplus connections")
+	}
+	proxyPort, err := proxyPortOrDefault(sqlplusExecution.ProxyPort, defaultOracleProxyPort)
+	if err != nil {
+		return err
+	}
+	proxyAddress, err := s.proxyAddress("oracle")
+	if err != nil {
+		return err
+	}
+	connectionString, err := s.connectionString(sqlplusExecution.TargetAddress, sqlplusExecution.TargetUsername, sqlplusExecution.NetworkName)
+	if err != nil {
+		return err
+	}
+	password, err := s.ssoService.ShortLivedPassword(&ssomodels.IdsecSIASSOGetShortLivedPassword{
+		Service: "DPA-DB",
+	})
+	if err != nil {
+		return err
+	}
+	connectScript, err := sqlplusConnectScript(connectionString, proxyAddress, proxyPort, password, sqlplusExecution.ServiceName)
+	if err != nil {
+		return err
+	}
+	scriptPath, err := s.createTempCredentialsFile("sqlplus_connect_*.sql", connectScript)
+	if err != nil {
+		return err
+	}
+	defer s.removeTempCredentialsFile(scriptPath)
+	var env []string
+	if sqlplusExecution.TNSAdmin != "" {
+		env = append(env, "TNS_ADMIN="+sqlplusExecution.TNSAdmin)
+	}
+	return common.ExecuteCommandArgsWithEnv(env, sqlplusExecution.SqlplusPath, "/nolog", "@"+scriptPath)
+}
+
+// StartTunnel starts a local tunnel to the SIA DB proxy that any database client can connect to.
+// The tunnel keeps running in the background until it is closed, and with the certificate auth method
+// the short-lived client certificate is renewed transparently for the whole lifetime of the tunnel.
+// With the password auth method, tunnel.Password returns a short-lived password that is renewed
+// before it expires.
+//
+// The tunnel negotiates TLS with the proxy the way each database client does, which is supported
+// for Postgres, Oracle, MySQL, MariaDB and Mongo. MSSQL and Db2 negotiate TLS inside their own
+// wire protocol and are rejected. The tunnel only listens on loopback addresses.
+//
+// Example:
+//
+//	tunnel, err := dbService.StartTunnel(&dbmodels.IdsecSIADBTunnelExecution{
+//		IdsecSIADBBaseExecution: dbmodels.IdsecSIADBBaseExecution{TargetAddress: "mydb.example.com", TargetUsername: "admin"},
+//		DatabaseFamily:          "Postgres",
+//		LocalPort:               15432,
+//	})
+//	if err != nil { /* handle */ }
+//	defer tunnel.Close()
+//	fmt.Println(tunnel.Info().LocalAddress, tunnel.Info().Username)
+func (s *IdsecSIADBService) StartTunnel(tunnelExecution *dbmodels.IdsecSIADBTunnelExecution) (*IdsecSIADBTunnel, error) {
+	protocol, err := tunnelProtocolFor(tunnelExecution.DatabaseFamily)
+	if err != nil {
+		return nil, err
+	}
+	listenAddress, err := tunnelListenAddress(tunnelExecution.LocalAddress, tunnelExecution.LocalPort)
+	if err != nil {
+		return nil, err
+	}
+	proxyPort, err := tunnelProxyPort(tunnelExecution.DatabaseFamily, tunnelExecution.ProxyPort)
+	if err != nil {
+		return nil, err
+	}
+	proxyAddress, err := s.proxyAddress(protocol.proxyDBType)
+	if err != nil {
+		return nil, err
+	}
+	connectionString, err := s.connectionString(tunnelExecution.TargetAddress, tunnelExecution.TargetUsername, tunnelExecution.NetworkName)
+	if err != nil {
+		return nil, err
+	}
+	authMethod := tunnelExecution.AuthMethod
+	if authMethod == "" {
+		authMethod = dbmodels.TunnelAuthMethodCertificate
+	}
+	info := dbmodels.IdsecSIADBTunnelInfo{
+		Username:   connectionString,
+		AuthMethod: authMethod,
+	}
+	var fetchCertificate clientCertificateFetcher
+	var fetchPassword passwordFetcher
+	switch authMethod {
+	case dbmodels.TunnelAuthMethodCertificate:
+		fetchCertificate = s.tunnelClientCertificate
+		// Fetch the first certificate eagerly so authentication problems surface before clients connect
+		if _, _, err := fetchCertificate(); err != nil {
+			return nil, err
+		}
+	case dbmodels.TunnelAuthMethodPassword:
+		fetchPassword = s.tunnelPassword
+		// Fetch the first password eagerly so authentication problems surface before clients connect
+		if _, _, err := fetchPassword(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported tunnel auth method [%s]", authMethod)
+	}
+	s.Logger.Info("Starting SIA DB tunnel to %s via %s", tunnelExecution.TargetAddress, proxyAddress)
+	return newDBTunnel(
+		listenAddress,
+		net.JoinHostPort(proxyAddress, strconv.Itoa(proxyPort)),
+		protocol.negotiate,
+		nil,
+		fetchCertificate,
+		fetchPassword,
+		info,
+		s.Logger,
+	)
+}
+
+// Tunnel runs a local tunnel to the SIA DB proxy until interrupted, logging the details clients need to connect.
+func (s *IdsecSIADBService) Tunnel(tunnelExecution *dbmodels.IdsecSIADBTunnelExecution) error {
+	tunnel, err := s.StartTunnel(tunnelExecution)
+	if err != nil {
+		return err
+	}
+	info := tunnel.Info()
+	s.Logger.Info("Listening on [%s], forwarding to [%s]", info.LocalAddress, info.ProxyAddress)
+	s.Logger.Info("Username: [%s]", info.Username)
+	if info.AuthMethod == dbmodels.TunnelAuthMethodPassword {
+		s.Logger.Info("Authenticate with a short-lived password generated by the SIA SSO short-lived-password action.")
+	}
+	s.Logger.Info("Connect to the local address with TLS disabled, the tunnel negotiates TLS with the proxy. Press Ctrl+C to stop.")
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	select {
+	case <-ctx.Done():
+	case <-tunnel.Done():
+	}
+	return tunnel.Close()
+}
+
+func (s *IdsecSIADBService) tunnelClientCertificate() (*tls.Certificate, time.Time, error) {
+	shortLivedCertificate, err := s.ssoService.ShortLivedClientCertificateContent(&ssomodels.IdsecSIASSOGetShortLivedClientCertificateContent{
+		Service: "DPA-DB",
+	})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return parseTunnelClientCertificate(shortLivedCertificate)
+}
+
+func (s *IdsecSIADBService) tunnelPassword() (string, time.Time, error) {
+	shortLivedPassword, err := s.ssoService.ShortLivedPasswordContent(&ssomodels.IdsecSIASSOGetShortLivedPassword{
+		Service: "DPA-DB",
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	var expiresAt time.Time
+	if shortLivedPassword.ExpiresAt != "" {
+		// An unknown expiry leaves the zero time, so the tunnel generates a new password on every request
+		expiresAt, _ = time.Parse(time.RFC3339, shortLivedPassword.ExpiresAt)
+	}
+	return shortLivedPassword.Password, expiresAt, nil
+}
+
+func parseTunnelClientCertificate(shortLivedCertificate *ssomodels.IdsecSIASSOShortLivedClientCertificate) (*tls.Certificate, time.Time, error) {
+	certificate, err := tls.X509KeyPair([]byte(shortLivedCertificate.ClientCertificate), []byte(shortLivedCertificate.PrivateKey))
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to parse short-lived client certificate: %w", err)
+	}
+	if shortLivedCertificate.ExpiresAt != "" {
+		if expiresAt, err := time.Parse(time.RFC3339, shortLivedCertificate.ExpiresAt); err == nil {
+			return &certificate, expiresAt, nil
+		}
+	}
+	if certificate.Leaf == nil {
+		return nil, time.Time{}, fmt.Errorf("failed to determine short-lived client certificate expiry")
+	}
+	return &certificate, certificate.Leaf.NotAfter, nil
+}
+
 // GenerateOracleTnsnames generates Oracle TNS names and writes them to the specified folder.
 func (s *IdsecSIADBService) GenerateOracleTnsnames(generateOracleAssets *dbmodels.IdsecSIADBOracleGenerateAssets) error {
+	oracleAssets, err := s.GenerateOracleTnsnamesContent(generateOracleAssets)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(generateOracleAssets.Folder); os.IsNotExist(err) {
+		if err := os.MkdirAll(generateOracleAssets.Folder, 0755); err != nil {
+			return err
+		}
+	}
+	if !generateOracleAssets.Unzip {
+		filePath := filepath.Join(generateOracleAssets.Folder, "oracle_assets.zip")
+		return os.WriteFile(filePath, oracleAssets.Zip, 0644)
+	}
+	for name, content := range oracleAssets.Files {
+		filePath := filepath.Join(generateOracleAssets.Folder, name)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filePath, content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GenerateOracleTnsnamesContent generates Oracle TNS names and returns them in memory instead of writing them to a folder.
+// The folder is still passed to the generation as the location the wallet is expected in, and Unzip is ignored.
+func (s *IdsecSIADBService) GenerateOracleTnsnamesContent(generateOracleAssets *dbmodels.IdsecSIADBOracleGenerateAssets) (*dbmodels.IdsecSIADBOracleAssets, error) {
 	s.Logger.Info("Generating Oracle TNS names")
 	assetsData, err := s.generateAssets(
 		dbmodels.AssetTypeOracleTNSAssets,
@@ -317,55 +746,47 @@ func (s *IdsecSIADBService) GenerateOracleTnsnames(generateOracleAssets *dbmodel
 		workspacesdbmodels.FamilyTypeOracle,
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if assetsDataMap, ok := assetsData.(map[string]interface{}); ok {
 		assetsData = assetsDataMap["generated_assets"]
 	}
-	decodedAssets, err := base64.StdEncoding.DecodeString(assetsData.(string))
+	encodedAssets, ok := assetsData.(string)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse generated oracle assets")
+	}
+	decodedAssets, err := base64.StdEncoding.DecodeString(encodedAssets)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if _, err := os.Stat(generateOracleAssets.Folder); os.IsNotExist(err) {
-		if err := os.MkdirAll(generateOracleAssets.Folder, 0755); err != nil {
-			return err
-		}
+	return readOracleAssets(decodedAssets)
+}
+
+func readOracleAssets(assetsZip []byte) (*dbmodels.IdsecSIADBOracleAssets, error) {
+	zipReader, err := zip.NewReader(bytes.NewReader(assetsZip), int64(len(assetsZip)))
+	if err != nil {
+		return nil, err
 	}
-	if !generateOracleAssets.Unzip {
-		filePath := filepath.Join(generateOracleAssets.Folder, "oracle_assets.zip")
-		if err := os.WriteFile(filePath, decodedAssets, 0644); err != nil {
-			return err
+	oracleAssets := &dbmodels.IdsecSIADBOracleAssets{
+		Zip:   assetsZip,
+		Files: make(map[string][]byte),
+	}
+	for _, file := range zipReader.File {
+		if file.FileInfo().IsDir() {
+			continue
 		}
-	} else {
-		zipReader, err := zip.NewReader(bytes.NewReader(decodedAssets), int64(len(decodedAssets)))
+		rc, err := file.Open()
 		if err != nil {
-			return err
+			return nil, err
 		}
-		for _, file := range zipReader.File {
-			filePath := filepath.Join(generateOracleAssets.Folder, file.Name)
-			if file.FileInfo().IsDir() {
-				if err := os.MkdirAll(filePath, 0755); err != nil {
-					return err
-				}
-				continue
-			}
-			outFile, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.Mode())
-			if err != nil {
-				return err
-			}
-			defer outFile.Close() //nolint:errcheck
-			rc, err := file.Open()
-			if err != nil {
-				return err
-			}
-			defer rc.Close() //nolint:errcheck
-			_, err = io.Copy(outFile, rc)
-			if err != nil {
-				return err
-			}
+		content, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, err
 		}
+		oracleAssets.Files[file.Name] = content
 	}
-	return nil
+	return oracleAssets, nil
 }
 
 // GenerateProxyFullChain generates a proxy full chain asset and writes it to the specified folder.
@@ -0,0 +1,308 @@
+package db
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	dbmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/db/models"
+	workspacesdbmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/workspacesdb/models"
+)
+
+const (
+	defaultTunnelLocalAddress     = "127.0.0.1"
+	tunnelDialTimeout             = 30 * time.Second
+	tunnelCredentialRefreshBuffer = 2 * time.Minute
+)
+
+// tunnelProtocol describes how the tunnel reaches the SIA DB proxy for a database family.
+type tunnelProtocol struct {
+	proxyDBType string
+	negotiate   tunnelNegotiator
+}
+
+// tunnelProtocols maps a database family to the SIA DB proxy host prefix serving it and the way
+// its connections are upgraded to TLS.
+var tunnelProtocols = map[string]tunnelProtocol{
+	workspacesdbmodels.FamilyTypePostgres: {proxyDBType: "postgres", negotiate: negotiatePostgresTLS},
+	workspacesdbmodels.FamilyTypeOracle:   {proxyDBType: "oracle", negotiate: negotiateRawTLS},
+	workspacesdbmodels.FamilyTypeMySQL:    {proxyDBType: "mysql", negotiate: negotiateMySQLTLS},
+	workspacesdbmodels.FamilyTypeMariaDB:  {proxyDBType: "mysql", negotiate: negotiateMySQLTLS},
+	workspacesdbmodels.FamilyTypeMongo:    {proxyDBType: "mongo", negotiate: negotiateRawTLS},
+}
+
+// clientCertificateFetcher returns a fresh client certificate and the time it expires.
+type clientCertificateFetcher func() (*tls.Certificate, time.Time, error)
+
+// passwordFetcher returns a fresh short-lived password and the time it expires, or the zero time when unknown.
+type passwordFetcher func() (string, time.Time, error)
+
+// IdsecSIADBTunnel is a local TCP listener that forwards every accepted connection to the
+// SIA DB proxy over TLS. It is created by IdsecSIADBService.StartTunnel and runs until Close
+// is called.
+//
+// Every connection is upgraded to TLS the way the database client would do it, so clients
+// connect to the local address with TLS disabled. When the tunnel authenticates with a client
+// certificate, the certificate is fetched lazily and renewed shortly before it expires, so new
+// connections keep working for the whole lifetime of the tunnel without any action from the
+// client. With the password auth method, Password returns a short-lived password that is
+// renewed the same way.
+type IdsecSIADBTunnel struct {
+	listener         net.Listener
+	proxyAddress     string
+	negotiate        tunnelNegotiator
+	tlsConfig        *tls.Config
+	info             dbmodels.IdsecSIADBTunnelInfo
+	fetchCertificate clientCertificateFetcher
+	fetchPassword    passwordFetcher
+	logger           *common.IdsecLogger
+
+	certificateLock   sync.Mutex
+	certificate       *tls.Certificate
+	certificateExpiry time.Time
+
+	passwordLock   sync.Mutex
+	password       string
+	passwordExpiry time.Time
+
+	connectionsLock sync.Mutex
+	connections     map[net.Conn]struct{}
+	closing         bool
+	connectionsWait sync.WaitGroup
+	closeOnce       sync.Once
+	done            chan struct{}
+}
+
+func newDBTunnel(
+	listenAddress string,
+	proxyAddress string,
+	negotiate tunnelNegotiator,
+	tlsConfig *tls.Config,
+	fetchCertificate clientCertificateFetcher,
+	fetchPassword passwordFetcher,
+	info dbmodels.IdsecSIADBTunnelInfo,
+	logger *common.IdsecLogger,
+) (*IdsecSIADBTunnel, error) {
+	proxyHost, _, err := net.SplitHostPort(proxyAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy address [%s]: %w", proxyAddress, err)
+	}
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on [%s]: %w", listenAddress, err)
+	}
+	tunnel := &IdsecSIADBTunnel{
+		listener:         listener,
+		proxyAddress:     proxyAddress,
+		negotiate:        negotiate,
+		fetchCertificate: fetchCertificate,
+		fetchPassword:    fetchPassword,
+		logger:           logger,
+		connections:      map[net.Conn]struct{}{},
+		done:             make(chan struct{}),
+	}
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+	tunnel.tlsConfig = tlsConfig.Clone()
+	if tunnel.tlsConfig.ServerName == "" {
+		tunnel.tlsConfig.ServerName = proxyHost
+	}
+	if tunnel.tlsConfig.MinVersion == 0 {
+		tunnel.tlsConfig.MinVersion = tls.VersionTLS12
+	}
+	if fetchCertificate != nil {
+		tunnel.tlsConfig.GetClientCertificate = tunnel.clientCertificate
+	}
+	info.LocalAddress = listener.Addr().String()
+	info.ProxyAddress = proxyAddress
+	tunnel.info = info
+	go tunnel.serve()
+	return tunnel, nil
+}
+
+// Addr returns the local address the tunnel listens on.
+func (t *IdsecSIADBTunnel) Addr() net.Addr {
+	return t.listener.Addr()
+}
+
+// Info returns the details clients need to connect through the tunnel.
+func (t *IdsecSIADBTunnel) Info() *dbmodels.IdsecSIADBTunnelInfo {
+	info := t.info
+	return &info
+}
+
+// Password returns the short-lived password clients should authenticate with when the tunnel uses
+// the password auth method, generating a new one when the current password is about to expire.
+func (t *IdsecSIADBTunnel) Password() (string, error) {
+	if t.fetchPassword == nil {
+		return "", errors.New("the tunnel does not use the password auth method")
+	}
+	t.passwordLock.Lock()
+	defer t.passwordLock.Unlock()
+	if t.password != "" && time.Now().Add(tunnelCredentialRefreshBuffer).Before(t.passwordExpiry) {
+		return t.password, nil
+	}
+	t.logger.Info("Refreshing SIA DB tunnel short-lived password")
+	password, expiry, err := t.fetchPassword()
+	if err != nil {
+		return "", fmt.Errorf("failed to refresh short-lived password: %w", err)
+	}
+	t.password = password
+	t.passwordExpiry = expiry
+	return password, nil
+}
+
+// Done returns a channel that is closed once the tunnel stops accepting connections.
+func (t *IdsecSIADBTunnel) Done() <-chan struct{} {
+	return t.done
+}
+
+// Close stops accepting connections, closes every forwarded connection and waits for them to finish.
+func (t *IdsecSIADBTunnel) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		err = t.listener.Close()
+		t.connectionsLock.Lock()
+		t.closing = true
+		for connection := range t.connections {
+			_ = connection.Close()
+		}
+		t.connectionsLock.Unlock()
+	})
+	<-t.done
+	t.connectionsWait.Wait()
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+func (t *IdsecSIADBTunnel) serve() {
+	defer close(t.done)
+	for {
+		accepted, err := t.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				t.logger.Warning("Stopped accepting tunnel connections on [%s]: %v", t.listener.Addr(), err)
+			}
+			return
+		}
+		if !t.track(accepted) {
+			_ = accepted.Close()
+			continue
+		}
+		go t.handle(accepted)
+	}
+}
+
+func (t *IdsecSIADBTunnel) handle(accepted net.Conn) {
+	defer t.connectionsWait.Done()
+	defer t.untrack(accepted)
+	dialer := &net.Dialer{Timeout: tunnelDialTimeout}
+	proxy, err := dialer.Dial("tcp", t.proxyAddress)
+	if err != nil {
+		t.logger.Warning("Failed to connect to SIA DB proxy [%s]: %v", t.proxyAddress, err)
+		_ = accepted.Close()
+		return
+	}
+	if !t.track(proxy) {
+		_ = proxy.Close()
+		_ = accepted.Close()
+		return
+	}
+	defer t.connectionsWait.Done()
+	defer t.untrack(proxy)
+	deadline := time.Now().Add(tunnelDialTimeout)
+	_ = accepted.SetDeadline(deadline)
+	_ = proxy.SetDeadline(deadline)
+	secured, err := t.negotiate(accepted, proxy, t.tlsConfig)
+	if err != nil {
+		t.logger.Warning("Failed to negotiate TLS with SIA DB proxy [%s]: %v", t.proxyAddress, err)
+		_ = proxy.Close()
+		_ = accepted.Close()
+		return
+	}
+	_ = accepted.SetDeadline(time.Time{})
+	_ = proxy.SetDeadline(time.Time{})
+	t.logger.Debug("Forwarding tunnel connection from [%s] to [%s]", accepted.RemoteAddr(), t.proxyAddress)
+	common.PipeConnections(accepted, secured)
+}
+
+// track registers a connection so Close can tear it down. It returns false once the tunnel is closing.
+func (t *IdsecSIADBTunnel) track(connection net.Conn) bool {
+	t.connectionsLock.Lock()
+	defer t.connectionsLock.Unlock()
+	if t.closing {
+		return false
+	}
+	t.connections[connection] = struct{}{}
+	t.connectionsWait.Add(1)
+	return true
+}
+
+func (t *IdsecSIADBTunnel) untrack(connection net.Conn) {
+	t.connectionsLock.Lock()
+	defer t.connectionsLock.Unlock()
+	delete(t.connections, connection)
+}
+
+// clientCertificate returns the cached client certificate, renewing it when it is about to expire.
+func (t *IdsecSIADBTunnel) clientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	t.certificateLock.Lock()
+	defer t.certificateLock.Unlock()
+	if t.certificate != nil && time.Now().Add(tunnelCredentialRefreshBuffer).Before(t.certificateExpiry) {
+		return t.certificate, nil
+	}
+	t.logger.Info("Refreshing SIA DB tunnel client certificate")
+	certificate, expiry, err := t.fetchCertificate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh client certificate: %w", err)
+	}
+	t.certificate = certificate
+	t.certificateExpiry = expiry
+	return certificate, nil
+}
+
+func tunnelProxyPort(databaseFamily string, proxyPort int) (int, error) {
+	if proxyPort > 0 {
+		return proxyPort, nil
+	}
+	port, ok := workspacesdbmodels.DatabaseFamiliesDefaultPorts[databaseFamily]
+	if !ok {
+		return 0, fmt.Errorf("unsupported database family [%s]", databaseFamily)
+	}
+	return port, nil
+}
+
+// tunnelProtocolFor returns the tunnel protocol of a database family.
+func tunnelProtocolFor(databaseFamily string) (tunnelProtocol, error) {
+	if protocol, ok := tunnelProtocols[databaseFamily]; ok {
+		return protocol, nil
+	}
+	switch databaseFamily {
+	case workspacesdbmodels.FamilyTypeMSSQL, workspacesdbmodels.FamilyTypeDB2:
+		return tunnelProtocol{}, fmt.Errorf("database family [%s] negotiates TLS inside its own wire protocol and cannot be tunneled, connect to the SIA DB proxy with the database client directly", databaseFamily)
+	}
+	return tunnelProtocol{}, fmt.Errorf("unsupported database family [%s]", databaseFamily)
+}
+
+// tunnelListenAddress returns the address the tunnel listens on. Clients talk to the tunnel in
+// plain text, so only loopback addresses are accepted.
+func tunnelListenAddress(localAddress string, localPort int) (string, error) {
+	if localAddress == "" {
+		localAddress = defaultTunnelLocalAddress
+	}
+	if localAddress != "localhost" {
+		ip := net.ParseIP(localAddress)
+		if ip == nil || !ip.IsLoopback() {
+			return "", fmt.Errorf("local address [%s] is not a loopback address, the tunnel only listens on loopback", localAddress)
+		}
+	}
+	return net.JoinHostPort(localAddress, strconv.Itoa(localPort)), nil
+}
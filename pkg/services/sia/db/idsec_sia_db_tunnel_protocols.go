@@ -0,0 +1,235 @@
+package db
+
+import (
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+)
+
+// tunnelNegotiator upgrades a plain connection to the SIA DB proxy to TLS the way the database
+// client would, and returns the connection the rest of the traffic is forwarded over.
+//
+// Some database protocols start in plain text and only switch to TLS after a protocol specific
+// request, so the negotiator may have to read from and answer the local client before the
+// upgrade. The local client always sees a server that does not offer TLS.
+type tunnelNegotiator func(client net.Conn, proxy net.Conn, tlsConfig *tls.Config) (net.Conn, error)
+
+// Postgres startup request codes, see https://www.postgresql.org/docs/current/protocol-message-formats.html
+const (
+	postgresSSLRequestCode     uint32 = 80877103
+	postgresGSSENCRequestCode  uint32 = 80877104
+	postgresMaxStartupLength   uint32 = 10000
+	postgresStartupHeaderBytes        = 8
+)
+
+// MySQL capability flags and packet markers, see https://dev.mysql.com/doc/dev/mysql-server/latest/page_protocol_basic_packets.html
+const (
+	mysqlClientProtocol41      uint32 = 0x00000200
+	mysqlClientSSL             uint32 = 0x00000800
+	mysqlHandshakeV10          byte   = 0x0a
+	mysqlOKPacket              byte   = 0x00
+	mysqlErrPacket             byte   = 0xff
+	mysqlAuthMoreData          byte   = 0x01
+	mysqlFastAuthSuccess       byte   = 0x03
+	mysqlSSLRequestLength             = 32
+	mysqlMaxHandshakePacketLen        = 1 << 16
+)
+
+// negotiateRawTLS starts the TLS handshake right away, for protocols that run entirely over TLS
+// such as Oracle TCPS and MongoDB.
+func negotiateRawTLS(_ net.Conn, proxy net.Conn, tlsConfig *tls.Config) (net.Conn, error) {
+	return handshakeTunnelTLS(proxy, tlsConfig)
+}
+
+// negotiatePostgresTLS answers the client's own SSL or GSS encryption requests with "N", sends an
+// SSLRequest to the proxy, upgrades the proxy connection to TLS and forwards the client's startup
+// message over it.
+func negotiatePostgresTLS(client net.Conn, proxy net.Conn, tlsConfig *tls.Config) (net.Conn, error) {
+	var startup []byte
+	for {
+		message, err := readPostgresStartupMessage(client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read postgres startup message: %w", err)
+		}
+		code := binary.BigEndian.Uint32(message[4:8])
+		if len(message) == postgresStartupHeaderBytes && (code == postgresSSLRequestCode || code == postgresGSSENCRequestCode) {
+			if _, err := client.Write([]byte{'N'}); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		startup = message
+		break
+	}
+	sslRequest := make([]byte, postgresStartupHeaderBytes)
+	binary.BigEndian.PutUint32(sslRequest[0:4], postgresStartupHeaderBytes)
+	binary.BigEndian.PutUint32(sslRequest[4:8], postgresSSLRequestCode)
+	if _, err := proxy.Write(sslRequest); err != nil {
+		return nil, err
+	}
+	answer := make([]byte, 1)
+	if _, err := io.ReadFull(proxy, answer); err != nil {
+		return nil, fmt.Errorf("failed to read postgres SSL response: %w", err)
+	}
+	if answer[0] != 'S' {
+		return nil, fmt.Errorf("SIA DB proxy refused the postgres SSL request with [%q]", answer[0])
+	}
+	secured, err := handshakeTunnelTLS(proxy, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := secured.Write(startup); err != nil {
+		_ = secured.Close()
+		return nil, err
+	}
+	return secured, nil
+}
+
+func readPostgresStartupMessage(client net.Conn) ([]byte, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(client, header); err != nil {
+		return nil, err
+	}
+	length := binary.BigEndian.Uint32(header)
+	if length < postgresStartupHeaderBytes || length > postgresMaxStartupLength {
+		return nil, fmt.Errorf("invalid startup message length [%d]", length)
+	}
+	message := make([]byte, length)
+	copy(message, header)
+	if _, err := io.ReadFull(client, message[4:]); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// negotiateMySQLTLS hides the proxy's SSL capability from the client, sends the proxy an SSL request
+// on the client's behalf and relays the authentication exchange over TLS. The tunnel inserts one
+// packet into the exchange, so sequence ids are shifted by one in each direction until the proxy
+// reports the authentication result. After that every command restarts at sequence id zero and the
+// traffic is forwarded untouched.
+func negotiateMySQLTLS(client net.Conn, proxy net.Conn, tlsConfig *tls.Config) (net.Conn, error) {
+	greeting, _, err := readMySQLPacket(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mysql greeting: %w", err)
+	}
+	capabilitiesOffset, err := mysqlGreetingCapabilitiesOffset(greeting)
+	if err != nil {
+		return nil, err
+	}
+	serverCapabilities := binary.LittleEndian.Uint16(greeting[capabilitiesOffset:])
+	if uint32(serverCapabilities)&mysqlClientSSL == 0 {
+		return nil, errors.New("SIA DB proxy does not offer mysql SSL")
+	}
+	binary.LittleEndian.PutUint16(greeting[capabilitiesOffset:], serverCapabilities&^uint16(mysqlClientSSL))
+	if err := writeMySQLPacket(client, 0, greeting); err != nil {
+		return nil, err
+	}
+	response, sequence, err := readMySQLPacket(client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mysql handshake response: %w", err)
+	}
+	if len(response) < mysqlSSLRequestLength {
+		return nil, fmt.Errorf("invalid mysql handshake response length [%d]", len(response))
+	}
+	clientCapabilities := binary.LittleEndian.Uint32(response[0:4])
+	if clientCapabilities&mysqlClientProtocol41 == 0 {
+		return nil, errors.New("mysql clients without protocol 4.1 support are not supported")
+	}
+	binary.LittleEndian.PutUint32(response[0:4], clientCapabilities|mysqlClientSSL)
+	if err := writeMySQLPacket(proxy, sequence, response[:mysqlSSLRequestLength]); err != nil {
+		return nil, err
+	}
+	secured, err := handshakeTunnelTLS(proxy, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err := relayMySQLAuthentication(client, secured, sequence+1, response); err != nil {
+		_ = secured.Close()
+		return nil, err
+	}
+	return secured, nil
+}
+
+func relayMySQLAuthentication(client net.Conn, secured net.Conn, sequence byte, response []byte) error {
+	if err := writeMySQLPacket(secured, sequence, response); err != nil {
+		return err
+	}
+	for {
+		packet, serverSequence, err := readMySQLPacket(secured)
+		if err != nil {
+			return fmt.Errorf("failed to read mysql authentication packet: %w", err)
+		}
+		if err := writeMySQLPacket(client, serverSequence-1, packet); err != nil {
+			return err
+		}
+		if len(packet) == 0 || packet[0] == mysqlOKPacket || packet[0] == mysqlErrPacket {
+			return nil
+		}
+		if len(packet) == 2 && packet[0] == mysqlAuthMoreData && packet[1] == mysqlFastAuthSuccess {
+			// The proxy follows a fast authentication success with an OK packet without waiting for the client
+			continue
+		}
+		packet, clientSequence, err := readMySQLPacket(client)
+		if err != nil {
+			return fmt.Errorf("failed to read mysql authentication packet: %w", err)
+		}
+		if err := writeMySQLPacket(secured, clientSequence+1, packet); err != nil {
+			return err
+		}
+	}
+}
+
+// mysqlGreetingCapabilitiesOffset returns the offset of the lower capability flags in a HandshakeV10 packet.
+func mysqlGreetingCapabilitiesOffset(greeting []byte) (int, error) {
+	if len(greeting) == 0 || greeting[0] != mysqlHandshakeV10 {
+		return 0, errors.New("unsupported mysql greeting from SIA DB proxy")
+	}
+	offset := 1
+	for offset < len(greeting) && greeting[offset] != 0 {
+		offset++
+	}
+	// Skip the server version terminator, the connection id, the first auth data part and the filler
+	offset += 1 + 4 + 8 + 1
+	if offset+2 > len(greeting) {
+		return 0, errors.New("truncated mysql greeting from SIA DB proxy")
+	}
+	return offset, nil
+}
+
+func readMySQLPacket(connection net.Conn) ([]byte, byte, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(connection, header); err != nil {
+		return nil, 0, err
+	}
+	length := int(header[0]) | int(header[1])<<8 | int(header[2])<<16
+	if length > mysqlMaxHandshakePacketLen {
+		return nil, 0, fmt.Errorf("mysql handshake packet too large [%d]", length)
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(connection, payload); err != nil {
+		return nil, 0, err
+	}
+	return payload, header[3], nil
+}
+
+func writeMySQLPacket(connection net.Conn, sequence byte, payload []byte) error {
+	packet := make([]byte, 4+len(payload))
+	packet[0] = byte(len(payload))
+	packet[1] = byte(len(payload) >> 8)
+	packet[2] = byte(len(payload) >> 16)
+	packet[3] = sequence
+	copy(packet[4:], payload)
+	_, err := connection.Write(packet)
+	return err
+}
+
+func handshakeTunnelTLS(proxy net.Conn, tlsConfig *tls.Config) (net.Conn, error) {
+	secured := tls.Client(proxy, tlsConfig)
+	if err := secured.Handshake(); err != nil {
+		return nil, fmt.Errorf("TLS handshake with SIA DB proxy failed: %w", err)
+	}
+	return secured, nil
+}
@@ -0,0 +1,483 @@
+package db
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	dbmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/db/models"
+	ssomodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/sso/models"
+)
+
+type testCertificateAuthority struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+	pool        *x509.CertPool
+}
+
+func newTestCertificateAuthority(t *testing.T) *testCertificateAuthority {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(certificate)
+	return &testCertificateAuthority{certificate: certificate, key: key, pool: pool}
+}
+
+func (ca *testCertificateAuthority) issue(t *testing.T, commonName string, notAfter time.Time, server bool) (certPEM string, keyPEM string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if server {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+		template.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.certificate, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func testProxyTLSConfig(t *testing.T, ca *testCertificateAuthority, requireClientCertificate bool) *tls.Config {
+	t.Helper()
+	certPEM, keyPEM := ca.issue(t, "proxy", time.Now().Add(time.Hour), true)
+	serverCertificate, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+	require.NoError(t, err)
+	config := &tls.Config{Certificates: []tls.Certificate{serverCertificate}, MinVersion: tls.VersionTLS12}
+	if requireClientCertificate {
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+		config.ClientCAs = ca.pool
+	}
+	return config
+}
+
+// startTestProxy runs a TLS echo server that prefixes every line with the client certificate common name.
+func startTestProxy(t *testing.T, ca *testCertificateAuthority, requireClientCertificate bool) string {
+	t.Helper()
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", testProxyTLSConfig(t, ca, requireClientCertificate))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			connection, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = connection.Close() }()
+				tlsConnection := connection.(*tls.Conn)
+				if err := tlsConnection.Handshake(); err != nil {
+					return
+				}
+				commonName := "anonymous"
+				if peers := tlsConnection.ConnectionState().PeerCertificates; len(peers) > 0 {
+					commonName = peers[0].Subject.CommonName
+				}
+				scanner := bufio.NewScanner(connection)
+				for scanner.Scan() {
+					if _, err := connection.Write([]byte(commonName + ":" + scanner.Text() + "\n")); err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+// startTestPlainProxy runs a plain TCP server that hands the first accepted connection to handle
+// and reports the handler result.
+func startTestPlainProxy(t *testing.T, handle func(connection net.Conn) error) (string, <-chan error) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	result := make(chan error, 1)
+	go func() {
+		connection, err := listener.Accept()
+		if err != nil {
+			result <- err
+			return
+		}
+		defer func() { _ = connection.Close() }()
+		_ = connection.SetDeadline(time.Now().Add(5 * time.Second))
+		result <- handle(connection)
+	}()
+	return listener.Addr().String(), result
+}
+
+func decodeProtocolBytes(t *testing.T, recorded string) []byte {
+	t.Helper()
+	decoded, err := hex.DecodeString(strings.ReplaceAll(recorded, " ", ""))
+	require.NoError(t, err)
+	return decoded
+}
+
+func expectProtocolBytes(connection io.Reader, expected []byte) error {
+	received := make([]byte, len(expected))
+	if _, err := io.ReadFull(connection, received); err != nil {
+		return err
+	}
+	if string(received) != string(expected) {
+		return fmt.Errorf("unexpected bytes %x, expected %x", received, expected)
+	}
+	return nil
+}
+
+func sendTunnelLine(t *testing.T, address string, line string) string {
+	t.Helper()
+	connection, err := net.Dial("tcp", address)
+	require.NoError(t, err)
+	defer func() { _ = connection.Close() }()
+	require.NoError(t, connection.SetDeadline(time.Now().Add(5*time.Second)))
+	_, err = connection.Write([]byte(line + "\n"))
+	require.NoError(t, err)
+	response, err := bufio.NewReader(connection).ReadString('\n')
+	require.NoError(t, err)
+	return response
+}
+
+func TestTunnel_ForwardsWithClientCertificate(t *testing.T) {
+	ca := newTestCertificateAuthority(t)
+	proxyAddress := startTestProxy(t, ca, true)
+	var fetches atomic.Int32
+	fetch := func() (*tls.Certificate, time.Time, error) {
+		fetches.Add(1)
+		expiry := time.Now().Add(time.Hour)
+		certPEM, keyPEM := ca.issue(t, "user#acme", expiry, false)
+		certificate, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+		return &certificate, expiry, err
+	}
+	tunnel, err := newDBTunnel("127.0.0.1:0", proxyAddress, negotiateRawTLS, &tls.Config{RootCAs: ca.pool}, fetch, nil,
+		dbmodels.IdsecSIADBTunnelInfo{Username: "user#acme@db", AuthMethod: dbmodels.TunnelAuthMethodCertificate}, common.GlobalLogger)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tunnel.Close()) }()
+
+	require.Equal(t, tunnel.Addr().String(), tunnel.Info().LocalAddress)
+	require.Equal(t, proxyAddress, tunnel.Info().ProxyAddress)
+	require.Equal(t, "user#acme:hello\n", sendTunnelLine(t, tunnel.Addr().String(), "hello"))
+	require.Equal(t, "user#acme:again\n", sendTunnelLine(t, tunnel.Addr().String(), "again"))
+	require.Equal(t, int32(1), fetches.Load())
+}
+
+func TestTunnel_RefreshesExpiringClientCertificate(t *testing.T) {
+	ca := newTestCertificateAuthority(t)
+	proxyAddress := startTestProxy(t, ca, true)
+	var fetches atomic.Int32
+	fetch := func() (*tls.Certificate, time.Time, error) {
+		fetches.Add(1)
+		// Within the refresh buffer, so every new connection renews the certificate
+		expiry := time.Now().Add(tunnelCredentialRefreshBuffer / 2)
+		certPEM, keyPEM := ca.issue(t, "user#acme", expiry, false)
+		certificate, err := tls.X509KeyPair([]byte(certPEM), []byte(keyPEM))
+		return &certificate, expiry, err
+	}
+	tunnel, err := newDBTunnel("127.0.0.1:0", proxyAddress, negotiateRawTLS, &tls.Config{RootCAs: ca.pool}, fetch, nil,
+		dbmodels.IdsecSIADBTunnelInfo{}, common.GlobalLogger)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tunnel.Close()) }()
+
+	require.Equal(t, "user#acme:one\n", sendTunnelLine(t, tunnel.Addr().String(), "one"))
+	require.Equal(t, "user#acme:two\n", sendTunnelLine(t, tunnel.Addr().String(), "two"))
+	require.Equal(t, int32(2), fetches.Load())
+}
+
+func TestTunnel_ForwardsWithoutClientCertificate(t *testing.T) {
+	ca := newTestCertificateAuthority(t)
+	proxyAddress := startTestProxy(t, ca, false)
+	var fetches atomic.Int32
+	fetchPassword := func() (string, time.Time, error) {
+		fetches.Add(1)
+		return "secret", time.Now().Add(time.Hour), nil
+	}
+	tunnel, err := newDBTunnel("127.0.0.1:0", proxyAddress, negotiateRawTLS, &tls.Config{RootCAs: ca.pool}, nil, fetchPassword,
+		dbmodels.IdsecSIADBTunnelInfo{AuthMethod: dbmodels.TunnelAuthMethodPassword}, common.GlobalLogger)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tunnel.Close()) }()
+
+	require.Equal(t, "anonymous:hello\n", sendTunnelLine(t, tunnel.Addr().String(), "hello"))
+	password, err := tunnel.Password()
+	require.NoError(t, err)
+	require.Equal(t, "secret", password)
+	_, err = tunnel.Password()
+	require.NoError(t, err)
+	require.Equal(t, int32(1), fetches.Load())
+}
+
+func TestTunnel_RefreshesExpiringPassword(t *testing.T) {
+	var fetches atomic.Int32
+	fetchPassword := func() (string, time.Time, error) {
+		count := fetches.Add(1)
+		return fmt.Sprintf("secret-%d", count), time.Now().Add(tunnelCredentialRefreshBuffer / 2), nil
+	}
+	tunnel, err := newDBTunnel("127.0.0.1:0", "127.0.0.1:1", negotiateRawTLS, nil, nil, fetchPassword,
+		dbmodels.IdsecSIADBTunnelInfo{AuthMethod: dbmodels.TunnelAuthMethodPassword}, common.GlobalLogger)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tunnel.Close()) }()
+
+	password, err := tunnel.Password()
+	require.NoError(t, err)
+	require.Equal(t, "secret-1", password)
+	password, err = tunnel.Password()
+	require.NoError(t, err)
+	require.Equal(t, "secret-2", password)
+}
+
+func TestTunnel_PasswordRequiresPasswordAuthMethod(t *testing.T) {
+	tunnel, err := newDBTunnel("127.0.0.1:0", "127.0.0.1:1", negotiateRawTLS, nil, nil, nil,
+		dbmodels.IdsecSIADBTunnelInfo{}, common.GlobalLogger)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tunnel.Close()) }()
+
+	_, err = tunnel.Password()
+	require.Error(t, err)
+}
+
+func TestTunnel_NegotiatesPostgresSSL(t *testing.T) {
+	ca := newTestCertificateAuthority(t)
+	serverConfig := testProxyTLSConfig(t, ca, false)
+	// Protocol bytes as sent by psql with sslmode=prefer: SSLRequest, then the protocol 3.0 startup message
+	sslRequest := decodeProtocolBytes(t, "00 00 00 08 04 d2 16 2f")
+	startup := decodeProtocolBytes(t, "00 00 00 2d 00 03 00 00 75 73 65 72 00 75 73 65 72 23 61 63 6d 65 40 64 62 00 "+
+		"64 61 74 61 62 61 73 65 00 70 6f 73 74 67 72 65 73 00 00")
+	authenticationOk := decodeProtocolBytes(t, "52 00 00 00 08 00 00 00 00")
+	proxyAddress, proxyResult := startTestPlainProxy(t, func(connection net.Conn) error {
+		if err := expectProtocolBytes(connection, sslRequest); err != nil {
+			return err
+		}
+		if _, err := connection.Write([]byte{'S'}); err != nil {
+			return err
+		}
+		secured := tls.Server(connection, serverConfig)
+		if err := expectProtocolBytes(secured, startup); err != nil {
+			return err
+		}
+		_, err := secured.Write(authenticationOk)
+		return err
+	})
+	tunnel, err := newDBTunnel("127.0.0.1:0", proxyAddress, negotiatePostgresTLS, &tls.Config{RootCAs: ca.pool}, nil, nil,
+		dbmodels.IdsecSIADBTunnelInfo{}, common.GlobalLogger)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tunnel.Close()) }()
+
+	connection, err := net.Dial("tcp", tunnel.Addr().String())
+	require.NoError(t, err)
+	defer func() { _ = connection.Close() }()
+	require.NoError(t, connection.SetDeadline(time.Now().Add(5*time.Second)))
+	_, err = connection.Write(sslRequest)
+	require.NoError(t, err)
+	require.NoError(t, expectProtocolBytes(connection, []byte{'N'}))
+	_, err = connection.Write(startup)
+	require.NoError(t, err)
+	require.NoError(t, expectProtocolBytes(connection, authenticationOk))
+	require.NoError(t, <-proxyResult)
+}
+
+func TestTunnel_NegotiatesMySQLSSL(t *testing.T) {
+	ca := newTestCertificateAuthority(t)
+	serverConfig := testProxyTLSConfig(t, ca, false)
+	// A MySQL 8.0 server greeting with CLIENT_SSL set in the lower capability flags
+	greeting := decodeProtocolBytes(t, "4a 00 00 00 0a 38 2e 30 2e 33 36 00 0b 00 00 00 3a 23 3d 4b 43 4a 2e 43 00 ff ff ff 02 00 ff df 15 "+
+		"00 00 00 00 00 00 00 00 00 00 62 7b 1a 5d 45 2f 14 62 7d 60 0e 58 00 "+
+		"63 61 63 68 69 6e 67 5f 73 68 61 32 5f 70 61 73 73 77 6f 72 64 00")
+	// The same greeting as the client sees it, with CLIENT_SSL cleared
+	clientGreeting := append([]byte{}, greeting...)
+	clientGreeting[26] = 0xf7
+	// The handshake response of the mysql 8.0 client with --ssl-mode=DISABLED
+	handshakeResponse := decodeProtocolBytes(t, "5c 00 00 01 85 a6 ff 01 00 00 00 01 ff "+
+		"00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 72 6f 6f 74 00 20 "+
+		"6c 1d 43 0a 5e 7f 2b 19 33 48 27 61 0c 55 3e 70 14 29 4f 06 7b 36 52 1f 48 0d 3a 65 21 59 16 44 "+
+		"63 61 63 68 69 6e 67 5f 73 68 61 32 5f 70 61 73 73 77 6f 72 64 00")
+	// The SSLRequest and handshake response the proxy expects, with CLIENT_SSL set and shifted sequence ids
+	sslRequest := decodeProtocolBytes(t, "20 00 00 01 85 ae ff 01 00 00 00 01 ff "+
+		"00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00 00")
+	proxyHandshakeResponse := append([]byte{}, handshakeResponse...)
+	proxyHandshakeResponse[3] = 0x02
+	proxyHandshakeResponse[5] = 0xae
+	fastAuthSuccess := decodeProtocolBytes(t, "02 00 00 03 01 03")
+	okPacket := decodeProtocolBytes(t, "07 00 00 04 00 00 00 02 00 00 00")
+	ping := decodeProtocolBytes(t, "01 00 00 00 0e")
+	pingOk := decodeProtocolBytes(t, "07 00 00 01 00 00 00 02 00 00 00")
+	proxyAddress, proxyResult := startTestPlainProxy(t, func(connection net.Conn) error {
+		if _, err := connection.Write(greeting); err != nil {
+			return err
+		}
+		if err := expectProtocolBytes(connection, sslRequest); err != nil {
+			return err
+		}
+		secured := tls.Server(connection, serverConfig)
+		if err := expectProtocolBytes(secured, proxyHandshakeResponse); err != nil {
+			return err
+		}
+		if _, err := secured.Write(append(append([]byte{}, fastAuthSuccess...), okPacket...)); err != nil {
+			return err
+		}
+		if err := expectProtocolBytes(secured, ping); err != nil {
+			return err
+		}
+		_, err := secured.Write(pingOk)
+		return err
+	})
+	tunnel, err := newDBTunnel("127.0.0.1:0", proxyAddress, negotiateMySQLTLS, &tls.Config{RootCAs: ca.pool}, nil, nil,
+		dbmodels.IdsecSIADBTunnelInfo{}, common.GlobalLogger)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tunnel.Close()) }()
+
+	connection, err := net.Dial("tcp", tunnel.Addr().String())
+	require.NoError(t, err)
+	defer func() { _ = connection.Close() }()
+	require.NoError(t, connection.SetDeadline(time.Now().Add(5*time.Second)))
+	require.NoError(t, expectProtocolBytes(connection, clientGreeting))
+	_, err = connection.Write(handshakeResponse)
+	require.NoError(t, err)
+	require.NoError(t, expectProtocolBytes(connection, decodeProtocolBytes(t, "02 00 00 02 01 03")))
+	require.NoError(t, expectProtocolBytes(connection, decodeProtocolBytes(t, "07 00 00 03 00 00 00 02 00 00 00")))
+	_, err = connection.Write(ping)
+	require.NoError(t, err)
+	require.NoError(t, expectProtocolBytes(connection, pingOk))
+	require.NoError(t, <-proxyResult)
+}
+
+func TestTunnel_MySQLRequiresProxySSL(t *testing.T) {
+	ca := newTestCertificateAuthority(t)
+	greeting := decodeProtocolBytes(t, "4a 00 00 00 0a 38 2e 30 2e 33 36 00 0b 00 00 00 3a 23 3d 4b 43 4a 2e 43 00 ff f7 ff 02 00 ff df 15 "+
+		"00 00 00 00 00 00 00 00 00 00 62 7b 1a 5d 45 2f 14 62 7d 60 0e 58 00 "+
+		"63 61 63 68 69 6e 67 5f 73 68 61 32 5f 70 61 73 73 77 6f 72 64 00")
+	proxyAddress, _ := startTestPlainProxy(t, func(connection net.Conn) error {
+		_, err := connection.Write(greeting)
+		return err
+	})
+	tunnel, err := newDBTunnel("127.0.0.1:0", proxyAddress, negotiateMySQLTLS, &tls.Config{RootCAs: ca.pool}, nil, nil,
+		dbmodels.IdsecSIADBTunnelInfo{}, common.GlobalLogger)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, tunnel.Close()) }()
+
+	connection, err := net.Dial("tcp", tunnel.Addr().String())
+	require.NoError(t, err)
+	defer func() { _ = connection.Close() }()
+	require.NoError(t, connection.SetDeadline(time.Now().Add(5*time.Second)))
+	_, err = connection.Read(make([]byte, 1))
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestTunnel_CloseStopsListening(t *testing.T) {
+	ca := newTestCertificateAuthority(t)
+	proxyAddress := startTestProxy(t, ca, false)
+	tunnel, err := newDBTunnel("127.0.0.1:0", proxyAddress, negotiateRawTLS, &tls.Config{RootCAs: ca.pool}, nil, nil,
+		dbmodels.IdsecSIADBTunnelInfo{}, common.GlobalLogger)
+	require.NoError(t, err)
+
+	connection, err := net.Dial("tcp", tunnel.Addr().String())
+	require.NoError(t, err)
+	defer func() { _ = connection.Close() }()
+	require.NoError(t, tunnel.Close())
+	<-tunnel.Done()
+	_, err = net.Dial("tcp", tunnel.Addr().String())
+	require.Error(t, err)
+}
+
+func TestParseTunnelClientCertificate(t *testing.T) {
+	ca := newTestCertificateAuthority(t)
+	notAfter := time.Now().Add(30 * time.Minute).Truncate(time.Second)
+	certPEM, keyPEM := ca.issue(t, "user", notAfter, false)
+
+	_, expiry, err := parseTunnelClientCertificate(&ssomodels.IdsecSIASSOShortLivedClientCertificate{
+		ClientCertificate: certPEM,
+		PrivateKey:        keyPEM,
+		ExpiresAt:         "2030-01-02T03:04:05Z",
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC), expiry.UTC())
+
+	_, expiry, err = parseTunnelClientCertificate(&ssomodels.IdsecSIASSOShortLivedClientCertificate{
+		ClientCertificate: certPEM,
+		PrivateKey:        keyPEM,
+	})
+	require.NoError(t, err)
+	require.True(t, notAfter.Equal(expiry))
+
+	_, _, err = parseTunnelClientCertificate(&ssomodels.IdsecSIASSOShortLivedClientCertificate{ClientCertificate: "bad", PrivateKey: "bad"})
+	require.Error(t, err)
+}
+
+func TestTunnelProxyPortAndListenAddress(t *testing.T) {
+	port, err := tunnelProxyPort("Postgres", 0)
+	require.NoError(t, err)
+	require.Equal(t, 5432, port)
+	port, err = tunnelProxyPort("Postgres", 15432)
+	require.NoError(t, err)
+	require.Equal(t, 15432, port)
+	_, err = tunnelProxyPort("Unknown", 0)
+	require.Error(t, err)
+	for localAddress, expected := range map[string]string{
+		"":          "127.0.0.1:0",
+		"localhost": "localhost:6000",
+		"127.0.0.2": "127.0.0.2:6000",
+		"::1":       "[::1]:6000",
+	} {
+		port := 6000
+		if localAddress == "" {
+			port = 0
+		}
+		address, err := tunnelListenAddress(localAddress, port)
+		require.NoError(t, err)
+		require.Equal(t, expected, address)
+	}
+	for _, localAddress := range []string{"0.0.0.0", "::", "10.0.0.5", "db.example.com"} {
+		_, err := tunnelListenAddress(localAddress, 6000)
+		require.Error(t, err, localAddress)
+	}
+}
+
+func TestTunnelProtocolFor(t *testing.T) {
+	for _, family := range []string{"Postgres", "Oracle", "MySQL", "MariaDB", "Mongo"} {
+		protocol, err := tunnelProtocolFor(family)
+		require.NoError(t, err, family)
+		require.NotNil(t, protocol.negotiate, family)
+	}
+	for _, family := range []string{"MSSQL", "DB2"} {
+		_, err := tunnelProtocolFor(family)
+		require.ErrorContains(t, err, "negotiates TLS inside its own wire protocol")
+	}
+	_, err := tunnelProtocolFor("Unknown")
+	require.Error(t, err)
+}
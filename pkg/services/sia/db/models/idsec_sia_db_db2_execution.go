@@ -0,0 +1,9 @@
+package models
+
+// IdsecSIADBDb2Execution defines the structure for executing DB2 command line processor commands in the IdsecDBA context.
+type IdsecSIADBDb2Execution struct {
+	IdsecSIADBBaseExecution `mapstructure:",squash"`
+	Db2Path                 string `json:"db2_path" mapstructure:"db2_path" flag:"db2-path" desc:"The path to the DB2 command line processor executable file." default:"db2"`
+	Database                string `json:"database" mapstructure:"database" flag:"database" desc:"The name of the DB2 database to connect to." validate:"required"`
+	ProxyPort               int    `json:"proxy_port,omitempty" mapstructure:"proxy_port,omitempty" flag:"proxy-port" desc:"The SIA DB proxy port to connect to." default:"50002"`
+}
@@ -0,0 +1,9 @@
+package models
+
+// IdsecSIADBMongoshExecution defines the structure for executing mongosh commands in the IdsecDBA context.
+type IdsecSIADBMongoshExecution struct {
+	IdsecSIADBBaseExecution `mapstructure:",squash"`
+	MongoshPath             string `json:"mongosh_path" mapstructure:"mongosh_path" flag:"mongosh-path" desc:"The path to the mongosh executable file." default:"mongosh"`
+	Database                string `json:"database,omitempty" mapstructure:"database,omitempty" flag:"database" desc:"The database to use once connected. Defaults to the server default database."`
+	ProxyPort               int    `json:"proxy_port,omitempty" mapstructure:"proxy_port,omitempty" flag:"proxy-port" desc:"The SIA DB proxy port to connect to." default:"27017"`
+}
@@ -6,3 +6,9 @@ type IdsecSIADBOracleGenerateAssets struct {
 	Unzip                        bool `json:"unzip" mapstructure:"unzip" flag:"unzip" desc:"Indicates whether to save the file as a ZIP file." default:"true"`
 	IncludeSSO                   bool `json:"include_sso" mapstructure:"include_sso" flag:"include-sso" desc:"Indicates whether to generate the asset with SSO details." default:"true"`
 }
+
+// IdsecSIADBOracleAssets represents generated Oracle assets held in memory.
+type IdsecSIADBOracleAssets struct {
+	Zip   []byte            `json:"zip" mapstructure:"zip" desc:"The generated assets as a ZIP file."`
+	Files map[string][]byte `json:"files" mapstructure:"files" desc:"The files of the ZIP, such as tnsnames.ora and sqlnet.ora, by their path in the ZIP."`
+}
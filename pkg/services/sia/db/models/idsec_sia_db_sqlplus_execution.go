@@ -0,0 +1,10 @@
+package models
+
+// IdsecSIADBSqlplusExecution defines the structure for executing SQL*Plus commands in the IdsecDBA context.
+type IdsecSIADBSqlplusExecution struct {
+	IdsecSIADBBaseExecution `mapstructure:",squash"`
+	SqlplusPath             string `json:"sqlplus_path" mapstructure:"sqlplus_path" flag:"sqlplus-path" desc:"The path to the SQL*Plus executable file." default:"sqlplus"`
+	ServiceName             string `json:"service_name" mapstructure:"service_name" flag:"service-name" desc:"The Oracle service name to connect to." validate:"required"`
+	TNSAdmin                string `json:"tns_admin,omitempty" mapstructure:"tns_admin,omitempty" flag:"tns-admin" desc:"The folder holding the Oracle wallet and sqlnet.ora generated by generate-oracle-tnsnames, used as TNS_ADMIN."`
+	ProxyPort               int    `json:"proxy_port,omitempty" mapstructure:"proxy_port,omitempty" flag:"proxy-port" desc:"The SIA DB proxy port to connect to." default:"2484"`
+}
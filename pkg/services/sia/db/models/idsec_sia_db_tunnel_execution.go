@@ -0,0 +1,33 @@
+package models
+
+// Possible authentication methods for a local SIA DB tunnel.
+const (
+	TunnelAuthMethodCertificate string = "certificate"
+	TunnelAuthMethodPassword    string = "password"
+)
+
+// IdsecSIADBTunnelExecution defines the structure for running a local tunnel to the SIA DB proxy.
+//
+// The tunnel listens on the loopback address LocalAddress:LocalPort and forwards every accepted
+// connection to the SIA DB proxy, negotiating TLS the way the database client would, so any
+// database client can connect to the local address with TLS disabled. MSSQL and Db2 negotiate
+// TLS inside their own wire protocol and cannot be tunneled. With the certificate auth method
+// the tunnel presents a short-lived client certificate and transparently renews it before it
+// expires. With the password auth method the tunnel only secures the traffic, and the client
+// authenticates with a short-lived password.
+type IdsecSIADBTunnelExecution struct {
+	IdsecSIADBBaseExecution `mapstructure:",squash"`
+	DatabaseFamily          string `json:"database_family" mapstructure:"database_family" flag:"database-family" desc:"The database family of the target." choices:"Postgres,Oracle,MySQL,MariaDB,Mongo"`
+	LocalAddress            string `json:"local_address,omitempty" mapstructure:"local_address,omitempty" flag:"local-address" desc:"The local loopback address to listen on." default:"127.0.0.1"`
+	LocalPort               int    `json:"local_port,omitempty" mapstructure:"local_port,omitempty" flag:"local-port" desc:"The local port to listen on. Leave empty to pick a free port."`
+	ProxyPort               int    `json:"proxy_port,omitempty" mapstructure:"proxy_port,omitempty" flag:"proxy-port" desc:"The SIA DB proxy port to connect to. Defaults to the database family default port."`
+	AuthMethod              string `json:"auth_method,omitempty" mapstructure:"auth_method,omitempty" flag:"auth-method" desc:"The authentication method used against the SIA DB proxy." default:"certificate" choices:"certificate,password"`
+}
+
+// IdsecSIADBTunnelInfo describes a running local SIA DB tunnel.
+type IdsecSIADBTunnelInfo struct {
+	LocalAddress string `json:"local_address" mapstructure:"local_address" desc:"The local address clients should connect to."`
+	ProxyAddress string `json:"proxy_address" mapstructure:"proxy_address" desc:"The SIA DB proxy address the tunnel forwards to."`
+	Username     string `json:"username" mapstructure:"username" desc:"The username clients should use for the connection."`
+	AuthMethod   string `json:"auth_method" mapstructure:"auth_method" desc:"The authentication method used against the SIA DB proxy."`
+}
@@ -4,5 +4,7 @@ import siak8s "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/k8s/models"
 
 // ActionToSchemaMap is a map that defines the mapping between K8S action names and their corresponding schema types.
 var ActionToSchemaMap = map[string]interface{}{
-	"generate-kubeconfig": &siak8s.IdsecSIAK8SGenerateKubeconfig{},
+	"generate-kubeconfig":      &siak8s.IdsecSIAK8SGenerateKubeconfig{},
+	"generate-exec-kubeconfig": &siak8s.IdsecSIAK8SGenerateExecKubeconfig{},
+	"exec-credential":          &siak8s.IdsecSIAK8SGetExecCredential{},
 }
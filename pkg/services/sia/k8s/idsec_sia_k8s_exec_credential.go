@@ -0,0 +1,274 @@
+package k8s
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+	"gopkg.in/yaml.v3"
+	commonk8s "github.com/cyberark/idsec-sdk-golang/pkg/common/k8s"
+	authmodels "github.com/cyberark/idsec-sdk-golang/pkg/models/auth"
+	commonmodels "github.com/cyberark/idsec-sdk-golang/pkg/models/common"
+	"github.com/cyberark/idsec-sdk-golang/pkg/profiles"
+	k8smodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/k8s/models"
+	ssomodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/sso/models"
+)
+
+const (
+	execCredentialRefreshBuffer = 60 * time.Second
+	k8sSSOService               = "DPA-K8S"
+)
+
+// defaultExecArgs are the arguments of the exec command that runs the exec-credential action.
+var defaultExecArgs = []string{"exec", "sia", "k8s", "exec-credential"}
+
+// kubeconfigUserCredentialKeys are the static credential keys of a kubeconfig user, replaced by the exec plugin.
+var kubeconfigUserCredentialKeys = map[string]bool{
+	"client-certificate":      true,
+	"client-certificate-data": true,
+	"client-key":              true,
+	"client-key-data":         true,
+	"token":                   true,
+	"tokenFile":               true,
+	"username":                true,
+	"password":                true,
+	"exec":                    true,
+}
+
+// kubeconfigExec is the exec section of a kubeconfig user.
+type kubeconfigExec struct {
+	APIVersion         string   `yaml:"apiVersion"`
+	Command            string   `yaml:"command"`
+	Args               []string `yaml:"args,omitempty"`
+	InteractiveMode    string   `yaml:"interactiveMode"`
+	ProvideClusterInfo bool     `yaml:"provideClusterInfo"`
+}
+
+// GenerateExecKubeconfig generates a kubeconfig file for the SIA K8S service whose users authenticate
+// through an exec plugin instead of an embedded client certificate, and saves it to the specified folder.
+//
+// kubectl runs the exec command whenever it needs a credential, by default the exec-credential action:
+//
+//	idsec exec sia k8s exec-credential
+//
+// so sessions keep working after the short-lived client certificate expires.
+func (s *IdsecSIAK8SService) GenerateExecKubeconfig(generateExecKubeconfig *k8smodels.IdsecSIAK8SGenerateExecKubeconfig) (string, error) {
+	execKubeconfig, err := s.getExecKubeconfig(generateExecKubeconfig)
+	if err != nil {
+		return "", err
+	}
+	return writeKubeconfig(generateExecKubeconfig.Folder, execKubeconfig)
+}
+
+// GenerateExecKubeconfigTo generates an exec plugin kubeconfig like GenerateExecKubeconfig and writes it
+// to w instead of a folder. The Folder of generateExecKubeconfig is ignored.
+func (s *IdsecSIAK8SService) GenerateExecKubeconfigTo(generateExecKubeconfig *k8smodels.IdsecSIAK8SGenerateExecKubeconfig, w io.Writer) error {
+	execKubeconfig, err := s.getExecKubeconfig(generateExecKubeconfig)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(execKubeconfig)
+	return err
+}
+
+func (s *IdsecSIAK8SService) getExecKubeconfig(generateExecKubeconfig *k8smodels.IdsecSIAK8SGenerateExecKubeconfig) ([]byte, error) {
+	kubeconfig, err := s.getKubeconfig()
+	if err != nil {
+		return nil, err
+	}
+	command := generateExecKubeconfig.ExecCommand
+	if command == "" {
+		command = "idsec"
+	}
+	args := generateExecKubeconfig.ExecArgs
+	if len(args) == 0 {
+		args = defaultExecArgs
+	}
+	return kubeconfigWithExecPlugin(kubeconfig, kubeconfigExec{
+		APIVersion:      k8smodels.IdsecSIAK8SExecCredentialAPIVersion,
+		Command:         command,
+		Args:            args,
+		InteractiveMode: "Never",
+	})
+}
+
+// ExecCredential returns a kubectl ExecCredential holding a short-lived SIA client certificate.
+//
+// The credential is cached in the keyring per tenant and user, and reused until it is within
+// execCredentialRefreshBuffer of the certificate expiry. That same early expiry is written as
+// status.expirationTimestamp, so kubectl runs the exec plugin again before the certificate
+// actually expires. ForceRefresh bypasses the cache.
+func (s *IdsecSIAK8SService) ExecCredential(getExecCredential *k8smodels.IdsecSIAK8SGetExecCredential) (*k8smodels.IdsecSIAK8SExecCredential, error) {
+	cacheKey := s.execCredentialCacheKey()
+	if !getExecCredential.ForceRefresh {
+		if cached := s.loadExecCredentialFromCache(cacheKey); cached != nil {
+			return cached, nil
+		}
+	}
+	s.Logger.Info("Generating SIA K8S exec credential")
+	fetchCertificate := s.fetchCertificate
+	if fetchCertificate == nil {
+		fetchCertificate = func() (*ssomodels.IdsecSIASSOShortLivedClientCertificate, error) {
+			// The SSO service caches client certificates regardless of their service, so its cache is bypassed
+			return s.ssoService.ShortLivedClientCertificateContent(&ssomodels.IdsecSIASSOGetShortLivedClientCertificateContent{
+				Service: k8sSSOService,
+			})
+		}
+	}
+	certificate, err := fetchCertificate()
+	if err != nil {
+		return nil, err
+	}
+	expiresAt, err := clientCertificateExpiresAt(certificate)
+	if err != nil {
+		return nil, err
+	}
+	credential := commonk8s.BuildClientCertificateExecCredential(k8smodels.IdsecSIAK8SExecCredentialAPIVersion, certificate.ClientCertificate, certificate.PrivateKey, expiresAt.Add(-execCredentialRefreshBuffer))
+	if err := s.saveExecCredentialToCache(cacheKey, credential); err != nil {
+		s.Logger.Warning("Failed to cache SIA K8S exec credential: %v", err)
+	}
+	return credential, nil
+}
+
+// clientCertificateExpiresAt returns the expiry reported by SIA SSO, falling back to the certificate validity.
+func clientCertificateExpiresAt(certificate *ssomodels.IdsecSIASSOShortLivedClientCertificate) (time.Time, error) {
+	if certificate.ExpiresAt != "" {
+		if expiresAt, err := time.Parse(time.RFC3339, certificate.ExpiresAt); err == nil {
+			return expiresAt, nil
+		}
+	}
+	keyPair, err := tls.X509KeyPair([]byte(certificate.ClientCertificate), []byte(certificate.PrivateKey))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse short-lived client certificate: %w", err)
+	}
+	if keyPair.Leaf == nil {
+		return time.Time{}, fmt.Errorf("failed to determine short-lived client certificate expiry")
+	}
+	return keyPair.Leaf.NotAfter, nil
+}
+
+// execCredentialCacheKey returns the keyring postfix of a cached exec credential, or an empty string
+// when the current token does not identify a tenant and user.
+func (s *IdsecSIAK8SService) execCredentialCacheKey() string {
+	parsedToken, _, err := new(jwt.Parser).ParseUnverified(s.ISPClient().GetToken(), jwt.MapClaims{})
+	if err != nil {
+		return ""
+	}
+	claims := parsedToken.Claims.(jwt.MapClaims)
+	return fmt.Sprintf("%s_%s_sia_k8s_exec_credential", claims["tenant_id"], claims["unique_name"])
+}
+
+func (s *IdsecSIAK8SService) loadExecCredentialFromCache(cacheKey string) *k8smodels.IdsecSIAK8SExecCredential {
+	if s.cacheKeyring == nil || cacheKey == "" {
+		return nil
+	}
+	defaultProfile, err := (*profiles.DefaultProfilesLoader()).LoadDefaultProfile()
+	if err != nil {
+		return nil
+	}
+	token, err := s.cacheKeyring.LoadToken(defaultProfile, cacheKey, false)
+	if err != nil || token == nil {
+		return nil
+	}
+	var credential k8smodels.IdsecSIAK8SExecCredential
+	if err := json.Unmarshal([]byte(token.Token), &credential); err != nil {
+		return nil
+	}
+	expiresAt, err := commonk8s.ExecCredentialExpiresAt(&credential)
+	if err != nil || !time.Now().Before(expiresAt) {
+		s.Logger.Info("Cached SIA K8S exec credential expired, generating a new one")
+		return nil
+	}
+	return &credential
+}
+
+func (s *IdsecSIAK8SService) saveExecCredentialToCache(cacheKey string, credential *k8smodels.IdsecSIAK8SExecCredential) error {
+	if s.cacheKeyring == nil || cacheKey == "" {
+		return nil
+	}
+	defaultProfile, err := (*profiles.DefaultProfilesLoader()).LoadDefaultProfile()
+	if err != nil {
+		return err
+	}
+	expiresAt, err := commonk8s.ExecCredentialExpiresAt(credential)
+	if err != nil {
+		return err
+	}
+	marshaledCredential, err := json.Marshal(credential)
+	if err != nil {
+		return err
+	}
+	return s.cacheKeyring.SaveToken(
+		defaultProfile,
+		&authmodels.IdsecToken{
+			Token:     string(marshaledCredential),
+			TokenType: authmodels.Token,
+			ExpiresIn: commonmodels.IdsecRFC3339Time(expiresAt),
+		},
+		cacheKey,
+		false,
+	)
+}
+
+// kubeconfigWithExecPlugin replaces the static credentials of every kubeconfig user with the exec plugin,
+// keeping the rest of the document and its key order unchanged.
+func kubeconfigWithExecPlugin(kubeconfig []byte, exec kubeconfigExec) ([]byte, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(kubeconfig, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("failed to parse kubeconfig: not a YAML mapping")
+	}
+	users := mappingValue(document.Content[0], "users")
+	if users == nil || users.Kind != yaml.SequenceNode || len(users.Content) == 0 {
+		return nil, fmt.Errorf("kubeconfig has no users")
+	}
+	var execNode yaml.Node
+	if err := execNode.Encode(exec); err != nil {
+		return nil, err
+	}
+	for _, namedUser := range users.Content {
+		user := mappingValue(namedUser, "user")
+		if user == nil || user.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("kubeconfig user is missing its user section")
+		}
+		content := make([]*yaml.Node, 0, len(user.Content)+2)
+		for i := 0; i+1 < len(user.Content); i += 2 {
+			if !kubeconfigUserCredentialKeys[user.Content[i].Value] {
+				content = append(content, user.Content[i], user.Content[i+1])
+			}
+		}
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "exec"}
+		valueNode := execNode
+		user.Content = append(content, keyNode, &valueNode)
+		user.Style = 0
+	}
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// mappingValue returns the value of a key of a YAML mapping node, or nil when it is missing.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
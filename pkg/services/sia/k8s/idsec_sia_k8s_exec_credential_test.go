@@ -0,0 +1,210 @@
+package k8s
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unsafe"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
+	commonk8s "github.com/cyberark/idsec-sdk-golang/pkg/common/k8s"
+	"github.com/cyberark/idsec-sdk-golang/pkg/models"
+	authmodels "github.com/cyberark/idsec-sdk-golang/pkg/models/auth"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services"
+	k8smodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/k8s/models"
+	ssomodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/sso/models"
+)
+
+const siaKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+  - name: sia
+    cluster:
+      server: https://tenant.k8s.cyberark.cloud
+contexts:
+  - name: sia
+    context:
+      cluster: sia
+      user: sia-user
+current-context: sia
+users:
+  - name: sia-user
+    user:
+      client-certificate-data: Q0VSVA==
+      client-key-data: S0VZ
+`
+
+// memoryKeyring is an in-memory keyring used to observe exec credential caching.
+type memoryKeyring struct {
+	tokens map[string]*authmodels.IdsecToken
+}
+
+func (m *memoryKeyring) SaveToken(_ *models.IdsecProfile, token *authmodels.IdsecToken, postfix string, _ bool) error {
+	m.tokens[postfix] = token
+	return nil
+}
+
+func (m *memoryKeyring) LoadToken(_ *models.IdsecProfile, postfix string, _ bool) (*authmodels.IdsecToken, error) {
+	return m.tokens[postfix], nil
+}
+
+// newTestK8SService creates an IdsecSIAK8SService wired to the given server URL, signed in as a tenant user.
+// It uses reflection to inject the ISP client because the client field is unexported.
+func newTestK8SService(t *testing.T, serverURL string) *IdsecSIAK8SService {
+	t.Helper()
+	t.Setenv("IDSEC_PROFILES_FOLDER", t.TempDir())
+	client := common.NewIdsecClient("", "", "", "Authorization", nil, nil, "", false)
+	client.BaseURL = serverURL
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"tenant_id":   "tenant",
+		"unique_name": "user@example.com",
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+	client.UpdateToken(token, "Bearer")
+	ispBase := &services.IdsecISPBaseService{}
+	field := reflect.ValueOf(ispBase).Elem().FieldByName("client")
+	field = reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+	field.Set(reflect.ValueOf(&isp.IdsecISPServiceClient{IdsecClient: client}))
+	return &IdsecSIAK8SService{
+		IdsecBaseService:    &services.IdsecBaseService{Logger: common.GlobalLogger},
+		IdsecISPBaseService: ispBase,
+		cacheKeyring:        &memoryKeyring{tokens: map[string]*authmodels.IdsecToken{}},
+	}
+}
+
+func TestExecCredential_CachesUntilExpiry(t *testing.T) {
+	svc := newTestK8SService(t, "http://unused")
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	fetches := 0
+	svc.fetchCertificate = func() (*ssomodels.IdsecSIASSOShortLivedClientCertificate, error) {
+		fetches++
+		return &ssomodels.IdsecSIASSOShortLivedClientCertificate{
+			ClientCertificate: "CERT",
+			PrivateKey:        "KEY",
+			ExpiresAt:         expiresAt.Format(time.RFC3339),
+		}, nil
+	}
+
+	credential, err := svc.ExecCredential(&k8smodels.IdsecSIAK8SGetExecCredential{})
+	require.NoError(t, err)
+	require.Equal(t, "client.authentication.k8s.io/v1", credential.APIVersion)
+	require.Equal(t, "ExecCredential", credential.Kind)
+	require.Equal(t, "CERT", credential.Status.ClientCertificateData)
+	require.Equal(t, "KEY", credential.Status.ClientKeyData)
+	credentialExpiresAt, err := commonk8s.ExecCredentialExpiresAt(credential)
+	require.NoError(t, err)
+	require.Equal(t, expiresAt.Add(-execCredentialRefreshBuffer), credentialExpiresAt)
+
+	cached, err := svc.ExecCredential(&k8smodels.IdsecSIAK8SGetExecCredential{})
+	require.NoError(t, err)
+	require.Equal(t, credential, cached)
+	require.Equal(t, 1, fetches)
+
+	_, err = svc.ExecCredential(&k8smodels.IdsecSIAK8SGetExecCredential{ForceRefresh: true})
+	require.NoError(t, err)
+	require.Equal(t, 2, fetches)
+}
+
+func TestExecCredential_ExpiredCacheIsRefreshed(t *testing.T) {
+	svc := newTestK8SService(t, "http://unused")
+	fetches := 0
+	svc.fetchCertificate = func() (*ssomodels.IdsecSIASSOShortLivedClientCertificate, error) {
+		fetches++
+		// Within the refresh buffer, so the credential is already expired for kubectl
+		return &ssomodels.IdsecSIASSOShortLivedClientCertificate{
+			ClientCertificate: "CERT",
+			PrivateKey:        "KEY",
+			ExpiresAt:         time.Now().Add(30 * time.Second).UTC().Format(time.RFC3339),
+		}, nil
+	}
+
+	_, err := svc.ExecCredential(&k8smodels.IdsecSIAK8SGetExecCredential{})
+	require.NoError(t, err)
+	_, err = svc.ExecCredential(&k8smodels.IdsecSIAK8SGetExecCredential{})
+	require.NoError(t, err)
+	require.Equal(t, 2, fetches)
+}
+
+func TestGenerateExecKubeconfig(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, kubeConfigGenerationURL, r.URL.Path)
+		_, _ = w.Write([]byte(siaKubeconfig))
+	}))
+	defer server.Close()
+	svc := newTestK8SService(t, server.URL)
+	folder := t.TempDir()
+
+	path, err := svc.GenerateExecKubeconfig(&k8smodels.IdsecSIAK8SGenerateExecKubeconfig{Folder: folder})
+	require.NoError(t, err)
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(content), "client-certificate-data")
+	require.NotContains(t, string(content), "client-key-data")
+	require.True(t, strings.Index(string(content), "clusters:") < strings.Index(string(content), "users:"), "key order is preserved")
+
+	var kubeconfig struct {
+		CurrentContext string `yaml:"current-context"`
+		Users          []struct {
+			Name string `yaml:"name"`
+			User struct {
+				Exec kubeconfigExec `yaml:"exec"`
+			} `yaml:"user"`
+		} `yaml:"users"`
+	}
+	require.NoError(t, yaml.Unmarshal(content, &kubeconfig))
+	require.Equal(t, "sia", kubeconfig.CurrentContext)
+	require.Len(t, kubeconfig.Users, 1)
+	require.Equal(t, kubeconfigExec{
+		APIVersion:      "client.authentication.k8s.io/v1",
+		Command:         "idsec",
+		Args:            []string{"exec", "sia", "k8s", "exec-credential"},
+		InteractiveMode: "Never",
+	}, kubeconfig.Users[0].User.Exec)
+}
+
+func TestGenerateKubeconfigTo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, kubeConfigGenerationURL, r.URL.Path)
+		_, _ = w.Write([]byte(siaKubeconfig))
+	}))
+	defer server.Close()
+	svc := newTestK8SService(t, server.URL)
+
+	var kubeconfig bytes.Buffer
+	require.NoError(t, svc.GenerateKubeconfigTo(&kubeconfig))
+	require.Equal(t, siaKubeconfig, kubeconfig.String())
+
+	var execKubeconfig bytes.Buffer
+	require.NoError(t, svc.GenerateExecKubeconfigTo(&k8smodels.IdsecSIAK8SGenerateExecKubeconfig{ExecCommand: "/usr/local/bin/idsec"}, &execKubeconfig))
+	require.NotContains(t, execKubeconfig.String(), "client-key-data")
+	require.Contains(t, execKubeconfig.String(), "command: /usr/local/bin/idsec")
+}
+
+func TestKubeconfigWithExecPlugin_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		kubeconfig    string
+		expectedError string
+	}{
+		{name: "not_yaml", kubeconfig: "users: [", expectedError: "failed to parse kubeconfig"},
+		{name: "not_mapping", kubeconfig: "- a\n- b\n", expectedError: "not a YAML mapping"},
+		{name: "no_users", kubeconfig: "apiVersion: v1\nkind: Config\n", expectedError: "kubeconfig has no users"},
+		{name: "missing_user", kubeconfig: "users:\n  - name: sia-user\n", expectedError: "missing its user section"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := kubeconfigWithExecPlugin([]byte(tt.kubeconfig), kubeconfigExec{Command: "idsec"})
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
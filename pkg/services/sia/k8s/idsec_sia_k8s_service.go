@@ -8,8 +8,11 @@ import (
 	"github.com/cyberark/idsec-sdk-golang/pkg/auth"
 	"github.com/cyberark/idsec-sdk-golang/pkg/common"
 	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/keyring"
 	"github.com/cyberark/idsec-sdk-golang/pkg/services"
 	k8smodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/k8s/models"
+	"github.com/cyberark/idsec-sdk-golang/pkg/services/sia/sso"
+	ssomodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/sso/models"
 
 	"io"
 	"net/http"
@@ -30,11 +33,17 @@ const (
 type IdsecSIAK8SService struct {
 	*services.IdsecBaseService
 	*services.IdsecISPBaseService
+	ssoService   *sso.IdsecSIASSOService
+	cacheKeyring keyring.IdsecKeyringInterface
+
+	fetchCertificate func() (*ssomodels.IdsecSIASSOShortLivedClientCertificate, error)
 }
 
 // NewIdsecSIAK8SService creates a new instance of IdsecSIAK8SService with the provided authenticators.
 func NewIdsecSIAK8SService(authenticators ...auth.IdsecAuth) (*IdsecSIAK8SService, error) {
-	k8sService := &IdsecSIAK8SService{}
+	k8sService := &IdsecSIAK8SService{
+		cacheKeyring: keyring.NewIdsecKeyring(ServiceConfig.ServiceName),
+	}
 	var k8sServiceInterface services.IdsecService = k8sService
 	baseService, err := services.NewIdsecBaseService(k8sServiceInterface, authenticators...)
 	if err != nil {
@@ -53,6 +62,10 @@ func NewIdsecSIAK8SService(authenticators ...auth.IdsecAuth) (*IdsecSIAK8SServic
 
 	k8sService.IdsecBaseService = baseService
 	k8sService.IdsecISPBaseService = ispBaseService
+	k8sService.ssoService, err = sso.NewIdsecSIASSOService(ispBaseService)
+	if err != nil {
+		return nil, err
+	}
 	return k8sService, nil
 }
 
@@ -66,10 +79,28 @@ func (s *IdsecSIAK8SService) refreshSIAAuth(client *common.IdsecClient) error {
 
 // GenerateKubeconfig generates a kubeconfig file for the SIA K8S service and saves it to the specified folder.
 func (s *IdsecSIAK8SService) GenerateKubeconfig(generateKubeConfig *k8smodels.IdsecSIAK8SGenerateKubeconfig) (string, error) {
+	kubeconfig, err := s.getKubeconfig()
+	if err != nil {
+		return "", err
+	}
+	return writeKubeconfig(generateKubeConfig.Folder, kubeconfig)
+}
+
+// GenerateKubeconfigTo generates a kubeconfig for the SIA K8S service and writes it to w instead of a folder.
+func (s *IdsecSIAK8SService) GenerateKubeconfigTo(w io.Writer) error {
+	kubeconfig, err := s.getKubeconfig()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(kubeconfig)
+	return err
+}
+
+func (s *IdsecSIAK8SService) getKubeconfig() ([]byte, error) {
 	s.Logger.Info("Getting kubeconfig")
 	response, err := s.ISPClient().Get(context.Background(), kubeConfigGenerationURL, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
@@ -78,9 +109,13 @@ func (s *IdsecSIAK8SService) GenerateKubeconfig(generateKubeConfig *k8smodels.Id
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to get kubeconfig - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, fmt.Errorf("failed to get kubeconfig - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
 	}
-	folderPath := generateKubeConfig.Folder
+	return io.ReadAll(response.Body)
+}
+
+// writeKubeconfig writes a kubeconfig as the config file of the given folder and returns its path.
+func writeKubeconfig(folderPath string, kubeconfig []byte) (string, error) {
 	if folderPath == "" {
 		folderPath = DefaultKubeConfigFolderPath
 	}
@@ -96,11 +131,7 @@ func (s *IdsecSIAK8SService) GenerateKubeconfig(generateKubeConfig *k8smodels.Id
 	}
 	baseName := "config"
 	fullPath := filepath.Join(folderPath, baseName)
-	resp, err := io.ReadAll(response.Body)
-	if err != nil {
-		return "", err
-	}
-	err = os.WriteFile(fullPath, resp, 0644)
+	err := os.WriteFile(fullPath, kubeconfig, 0644)
 	if err != nil {
 		return "", err
 	}
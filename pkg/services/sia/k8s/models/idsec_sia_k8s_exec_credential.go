@@ -0,0 +1,29 @@
+package models
+
+import k8scommonmodels "github.com/cyberark/idsec-sdk-golang/pkg/models/common/k8s"
+
+// IdsecSIAK8SExecCredentialAPIVersion is the client authentication API version of the exec credentials written and emitted by the Idsec SIA K8S service.
+const IdsecSIAK8SExecCredentialAPIVersion = "client.authentication.k8s.io/v1"
+
+// IdsecSIAK8SGetExecCredential is a struct that represents the request for getting a kubectl exec credential from the Idsec SIA K8S service.
+type IdsecSIAK8SGetExecCredential struct {
+	ForceRefresh bool `json:"force_refresh" mapstructure:"force_refresh" flag:"force-refresh" desc:"Whether to ignore the cached client certificate and generate a new one." default:"false"`
+}
+
+// IdsecSIAK8SGenerateExecKubeconfig is a struct that represents the request for generating a kubeconfig file that authenticates through an exec plugin.
+type IdsecSIAK8SGenerateExecKubeconfig struct {
+	Folder      string   `json:"folder" mapstructure:"folder" flag:"folder" desc:"The output folder in which the kubeconfig is written." default:"~/.kube"`
+	ExecCommand string   `json:"exec_command" mapstructure:"exec_command" flag:"exec-command" desc:"The command kubectl runs to get a SIA exec credential." default:"idsec"`
+	ExecArgs    []string `json:"exec_args,omitempty" mapstructure:"exec_args,omitempty" flag:"exec-args" desc:"The arguments of the exec command. Defaults to the SIA K8S exec-credential action."`
+}
+
+// IdsecSIAK8SExecCredentialStatus holds the short-lived client certificate handed to kubectl.
+//
+// ExpirationTimestamp (RFC3339) lets kubectl reuse the credential in-process and run the
+// exec plugin again only once it passed.
+type IdsecSIAK8SExecCredentialStatus = k8scommonmodels.IdsecK8sExecCredentialStatus
+
+// IdsecSIAK8SExecCredential is the JSON object written to stdout for kubectl's exec credential plugin protocol.
+//
+// Reference: https://kubernetes.io/docs/reference/config-api/client-authentication.v1/
+type IdsecSIAK8SExecCredential = k8scommonmodels.IdsecK8sExecCredential
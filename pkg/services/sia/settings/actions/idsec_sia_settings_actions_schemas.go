@@ -6,6 +6,9 @@ import settingsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/set
 var ActionToSchemaMap = map[string]interface{}{
 	"list-settings":                                  nil,
 	"set-settings":                                   &settingsmodels.IdsecSIASettings{},
+	"export-settings-baseline":                       &settingsmodels.IdsecSIASettingsExportBaseline{},
+	"diff-settings-baseline":                         &settingsmodels.IdsecSIASettingsDiffBaseline{},
+	"apply-settings-baseline":                        &settingsmodels.IdsecSIASettingsApplyBaseline{},
 	"adb-mfa-caching":                                nil,
 	"set-adb-mfa-caching":                            &settingsmodels.IdsecSIASettingsAdbMfaCaching{},
 	"certificate-validation":                         nil,
@@ -0,0 +1,304 @@
+package settings
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	settingsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/settings/models"
+)
+
+// ExportSettingsBaseline exports the live SIA settings as a versioned baseline document.
+//
+// Parameters:
+//   - exportBaseline: The export request. When OutputPath is set, the baseline is also written to it as JSON or YAML.
+//
+// Returns the exported baseline or an error if the export fails.
+func (s *IdsecSIASettingsService) ExportSettingsBaseline(exportBaseline *settingsmodels.IdsecSIASettingsExportBaseline) (*settingsmodels.IdsecSIASettingsBaseline, error) {
+	settings, err := s.ListSettings()
+	if err != nil {
+		return nil, err
+	}
+	baseline := &settingsmodels.IdsecSIASettingsBaseline{
+		Version:    settingsmodels.IdsecSIASettingsBaselineVersion,
+		ExportedAt: time.Now().UTC().Format(time.RFC3339),
+		Settings:   *settings,
+	}
+	if exportBaseline.OutputPath == "" {
+		return baseline, nil
+	}
+	s.Logger.Info("Exporting settings baseline to [%s]", exportBaseline.OutputPath)
+	if err := WriteSettingsBaseline(exportBaseline.OutputPath, exportBaseline.Format, baseline); err != nil {
+		return nil, err
+	}
+	return baseline, nil
+}
+
+// DiffSettingsBaseline compares a baseline file to the live SIA settings, per feature and per field.
+//
+// Parameters:
+//   - diffBaseline: The diff request. With FailOnDrift, an error is returned when drift is found.
+//
+// Returns the drift between the baseline and the live settings or an error if the comparison fails.
+func (s *IdsecSIASettingsService) DiffSettingsBaseline(diffBaseline *settingsmodels.IdsecSIASettingsDiffBaseline) (*settingsmodels.IdsecSIASettingsDrift, error) {
+	baseline, err := LoadSettingsBaseline(diffBaseline.BaselinePath)
+	if err != nil {
+		return nil, err
+	}
+	live, err := s.ListSettings()
+	if err != nil {
+		return nil, err
+	}
+	drift, err := DiffSettings(&baseline.Settings, live)
+	if err != nil {
+		return nil, err
+	}
+	if diffBaseline.FailOnDrift && !drift.InSync {
+		return drift, fmt.Errorf("settings drifted from baseline in features [%s]", strings.Join(driftedFeatures(drift), ", "))
+	}
+	return drift, nil
+}
+
+// ApplySettingsBaseline patches the live SIA settings so they match a baseline file.
+// Only the features that drifted from the baseline are patched.
+//
+// Parameters:
+//   - applyBaseline: The apply request. With DryRun, the drift and the features that would be patched are returned without changing the tenant.
+//
+// Returns the outcome of the apply or an error if it fails.
+func (s *IdsecSIASettingsService) ApplySettingsBaseline(applyBaseline *settingsmodels.IdsecSIASettingsApplyBaseline) (*settingsmodels.IdsecSIASettingsApplyBaselineResult, error) {
+	baseline, err := LoadSettingsBaseline(applyBaseline.BaselinePath)
+	if err != nil {
+		return nil, err
+	}
+	live, err := s.ListSettings()
+	if err != nil {
+		return nil, err
+	}
+	drift, err := DiffSettings(&baseline.Settings, live)
+	if err != nil {
+		return nil, err
+	}
+	result := &settingsmodels.IdsecSIASettingsApplyBaselineResult{
+		DryRun:          applyBaseline.DryRun,
+		Drift:           *drift,
+		PatchedFeatures: driftedFeatures(drift),
+	}
+	if drift.InSync || applyBaseline.DryRun {
+		return result, nil
+	}
+	patch := settingsPatch(&baseline.Settings, result.PatchedFeatures)
+	s.Logger.Info("Applying settings baseline to features [%s]", strings.Join(result.PatchedFeatures, ", "))
+	if _, err := s.SetSettings(patch); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// LoadSettingsBaseline reads a JSON or YAML SIA settings baseline file.
+func LoadSettingsBaseline(path string) (*settingsmodels.IdsecSIASettingsBaseline, error) {
+	content, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings baseline [%s]: %w", path, err)
+	}
+	// YAML is a superset of JSON, so a single decoder handles both formats
+	var document interface{}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse settings baseline [%s]: %w", path, err)
+	}
+	documentJSON, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse settings baseline [%s]: %w", path, err)
+	}
+	var baseline settingsmodels.IdsecSIASettingsBaseline
+	decoder := json.NewDecoder(strings.NewReader(string(documentJSON)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse settings baseline [%s]: %w", path, err)
+	}
+	if baseline.Version != settingsmodels.IdsecSIASettingsBaselineVersion {
+		return nil, fmt.Errorf("unsupported settings baseline version [%d], expected [%d]", baseline.Version, settingsmodels.IdsecSIASettingsBaselineVersion)
+	}
+	return &baseline, nil
+}
+
+// WriteSettingsBaseline writes a SIA settings baseline file as JSON or YAML.
+// When format is empty, it is inferred from the path extension and defaults to YAML.
+func WriteSettingsBaseline(path string, format string, baseline *settingsmodels.IdsecSIASettingsBaseline) error {
+	if format == "" {
+		format = settingsmodels.IdsecSIASettingsBaselineFormatYAML
+		if strings.EqualFold(filepath.Ext(path), ".json") {
+			format = settingsmodels.IdsecSIASettingsBaselineFormatJSON
+		}
+	}
+	baselineJSON, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return err
+	}
+	var content []byte
+	switch format {
+	case settingsmodels.IdsecSIASettingsBaselineFormatJSON:
+		content = append(baselineJSON, '\n')
+	case settingsmodels.IdsecSIASettingsBaselineFormatYAML:
+		// Round-trip through JSON so the YAML keys match the snake_case JSON tags
+		var document yaml.Node
+		if err := yaml.Unmarshal(baselineJSON, &document); err != nil {
+			return err
+		}
+		resetYAMLStyle(&document)
+		var buffer bytes.Buffer
+		encoder := yaml.NewEncoder(&buffer)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&document); err != nil {
+			return err
+		}
+		if err := encoder.Close(); err != nil {
+			return err
+		}
+		content = buffer.Bytes()
+	default:
+		return fmt.Errorf("unsupported settings baseline format [%s]", format)
+	}
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		return fmt.Errorf("failed to write settings baseline [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// DiffSettings compares baseline settings to live settings. Only the features and fields set
+// in the baseline are compared, features and fields left out of it are not managed by it.
+func DiffSettings(baseline *settingsmodels.IdsecSIASettings, live *settingsmodels.IdsecSIASettings) (*settingsmodels.IdsecSIASettingsDrift, error) {
+	baselineFeatures, err := settingsFeatures(baseline)
+	if err != nil {
+		return nil, err
+	}
+	liveFeatures, err := settingsFeatures(live)
+	if err != nil {
+		return nil, err
+	}
+	drift := &settingsmodels.IdsecSIASettingsDrift{
+		InSync:   true,
+		Features: []settingsmodels.IdsecSIASettingsFeatureDrift{},
+	}
+	for _, feature := range settingsFeatureNames() {
+		baselineFeature, ok := baselineFeatures[feature]
+		if !ok {
+			continue
+		}
+		baselineFields := flattenSettingsFields("", baselineFeature)
+		liveFields := flattenSettingsFields("", liveFeatures[feature])
+		fields := make([]string, 0, len(baselineFields))
+		for field := range baselineFields {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		featureDrift := settingsmodels.IdsecSIASettingsFeatureDrift{Feature: feature}
+		for _, field := range fields {
+			if reflect.DeepEqual(baselineFields[field], liveFields[field]) {
+				continue
+			}
+			featureDrift.Fields = append(featureDrift.Fields, settingsmodels.IdsecSIASettingsFieldDrift{
+				Field:    field,
+				Baseline: baselineFields[field],
+				Live:     liveFields[field],
+			})
+		}
+		if len(featureDrift.Fields) > 0 {
+			drift.InSync = false
+			drift.Features = append(drift.Features, featureDrift)
+		}
+	}
+	return drift, nil
+}
+
+// settingsFeatureNames returns the settings document feature names in declaration order.
+func settingsFeatureNames() []string {
+	settingsType := reflect.TypeOf(settingsmodels.IdsecSIASettings{})
+	names := make([]string, 0, settingsType.NumField())
+	for i := 0; i < settingsType.NumField(); i++ {
+		names = append(names, settingsJSONName(settingsType.Field(i)))
+	}
+	return names
+}
+
+func settingsJSONName(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("json"), ",")[0]
+}
+
+// settingsFeatures converts the set features of a settings document to generic maps keyed by feature name.
+func settingsFeatures(settings *settingsmodels.IdsecSIASettings) (map[string]interface{}, error) {
+	features := map[string]interface{}{}
+	if settings == nil {
+		return features, nil
+	}
+	settingsJSON, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(settingsJSON, &features); err != nil {
+		return nil, err
+	}
+	return features, nil
+}
+
+// flattenSettingsFields flattens nested objects into dotted field names, other values are compared as a whole.
+func flattenSettingsFields(prefix string, value interface{}) map[string]interface{} {
+	fields := map[string]interface{}{}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		if prefix != "" {
+			fields[prefix] = value
+		}
+		return fields
+	}
+	for key, nested := range object {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+		for field, fieldValue := range flattenSettingsFields(name, nested) {
+			fields[field] = fieldValue
+		}
+	}
+	return fields
+}
+
+func driftedFeatures(drift *settingsmodels.IdsecSIASettingsDrift) []string {
+	features := make([]string, 0, len(drift.Features))
+	for _, feature := range drift.Features {
+		features = append(features, feature.Feature)
+	}
+	return features
+}
+
+// settingsPatch builds a settings document holding only the given features of the baseline.
+func settingsPatch(baseline *settingsmodels.IdsecSIASettings, features []string) *settingsmodels.IdsecSIASettings {
+	patched := map[string]bool{}
+	for _, feature := range features {
+		patched[feature] = true
+	}
+	patch := &settingsmodels.IdsecSIASettings{}
+	baselineValue := reflect.ValueOf(baseline).Elem()
+	patchValue := reflect.ValueOf(patch).Elem()
+	for i := 0; i < baselineValue.NumField(); i++ {
+		if patched[settingsJSONName(baselineValue.Type().Field(i))] {
+			patchValue.Field(i).Set(baselineValue.Field(i))
+		}
+	}
+	return patch
+}
+
+// resetYAMLStyle drops the flow and quoting styles inherited from the JSON source so the output is plain block YAML.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
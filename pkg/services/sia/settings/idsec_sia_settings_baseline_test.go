@@ -0,0 +1,337 @@
+package settings
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	settingsmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/settings/models"
+)
+
+const (
+	BaselineYAML = `version: 1
+settings:
+  adb_mfa_caching:
+    is_mfa_caching_enabled: true
+    key_expiration_time_sec: 600
+  certificate_validation:
+    enabled: true
+  rdp_recording:
+    enabled: true
+`
+
+	BaselineJSON = `{
+		"version": 1,
+		"settings": {
+			"certificate_validation": {"enabled": true},
+			"logon_sequence": {"always_use_sia": true}
+		}
+	}`
+)
+
+func writeBaselineFile(t *testing.T, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write baseline file: %v", err)
+	}
+	return path
+}
+
+func TestDiffSettings(t *testing.T) {
+	baseline := &settingsmodels.IdsecSIASettings{
+		AdbMfaCaching: &settingsmodels.IdsecSIASettingsAdbMfaCaching{
+			IsMfaCachingEnabled:  common.Ptr(true),
+			KeyExpirationTimeSec: common.Ptr(600),
+		},
+		CertificateValidation: &settingsmodels.IdsecSIASettingsCertificateValidation{
+			Enabled: common.Ptr(true),
+		},
+		RdpRecording: &settingsmodels.IdsecSIASettingsRdpRecording{
+			Enabled: common.Ptr(true),
+		},
+	}
+	live := &settingsmodels.IdsecSIASettings{
+		AdbMfaCaching: &settingsmodels.IdsecSIASettingsAdbMfaCaching{
+			IsMfaCachingEnabled:  common.Ptr(true),
+			KeyExpirationTimeSec: common.Ptr(300),
+			ClientIPEnforced:     common.Ptr(false),
+		},
+		CertificateValidation: &settingsmodels.IdsecSIASettingsCertificateValidation{
+			Enabled: common.Ptr(true),
+		},
+		LogonSequence: &settingsmodels.IdsecSIASettingsLogonSequence{
+			AlwaysUseSia: common.Ptr(true),
+		},
+	}
+
+	drift, err := DiffSettings(baseline, live)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := &settingsmodels.IdsecSIASettingsDrift{
+		InSync: false,
+		Features: []settingsmodels.IdsecSIASettingsFeatureDrift{
+			{
+				Feature: "adb_mfa_caching",
+				Fields: []settingsmodels.IdsecSIASettingsFieldDrift{
+					{Field: "key_expiration_time_sec", Baseline: float64(600), Live: float64(300)},
+				},
+			},
+			{
+				Feature: "rdp_recording",
+				Fields: []settingsmodels.IdsecSIASettingsFieldDrift{
+					{Field: "enabled", Baseline: true, Live: nil},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(drift, expected) {
+		t.Errorf("Expected drift %+v, got %+v", expected, drift)
+	}
+
+	drift, err = DiffSettings(&settingsmodels.IdsecSIASettings{CertificateValidation: live.CertificateValidation}, live)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !drift.InSync || len(drift.Features) != 0 {
+		t.Errorf("Expected settings in sync, got %+v", drift)
+	}
+}
+
+func TestLoadSettingsBaseline(t *testing.T) {
+	tests := []struct {
+		name          string
+		fileName      string
+		content       string
+		expected      *settingsmodels.IdsecSIASettings
+		expectedError bool
+	}{
+		{
+			name:     "success_yaml",
+			fileName: "baseline.yaml",
+			content:  BaselineYAML,
+			expected: &settingsmodels.IdsecSIASettings{
+				AdbMfaCaching: &settingsmodels.IdsecSIASettingsAdbMfaCaching{
+					IsMfaCachingEnabled:  common.Ptr(true),
+					KeyExpirationTimeSec: common.Ptr(600),
+				},
+				CertificateValidation: &settingsmodels.IdsecSIASettingsCertificateValidation{Enabled: common.Ptr(true)},
+				RdpRecording:          &settingsmodels.IdsecSIASettingsRdpRecording{Enabled: common.Ptr(true)},
+			},
+		},
+		{
+			name:     "success_json",
+			fileName: "baseline.json",
+			content:  BaselineJSON,
+			expected: &settingsmodels.IdsecSIASettings{
+				CertificateValidation: &settingsmodels.IdsecSIASettingsCertificateValidation{Enabled: common.Ptr(true)},
+				LogonSequence:         &settingsmodels.IdsecSIASettingsLogonSequence{AlwaysUseSia: common.Ptr(true)},
+			},
+		},
+		{
+			name:          "error_unsupported_version",
+			fileName:      "baseline.yaml",
+			content:       "version: 2\nsettings: {}\n",
+			expectedError: true,
+		},
+		{
+			name:          "error_unknown_feature",
+			fileName:      "baseline.yaml",
+			content:       "version: 1\nsettings:\n  rdp_recordings:\n    enabled: true\n",
+			expectedError: true,
+		},
+		{
+			name:          "error_invalid_content",
+			fileName:      "baseline.yaml",
+			content:       "version: [",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			baseline, err := LoadSettingsBaseline(writeBaselineFile(t, tt.fileName, tt.content))
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(&baseline.Settings, tt.expected) {
+				t.Errorf("Expected settings %+v, got %+v", tt.expected, baseline.Settings)
+			}
+		})
+	}
+}
+
+func TestExportSettingsBaseline(t *testing.T) {
+	for _, fileName := range []string{"baseline.yaml", "baseline.json"} {
+		t.Run(fileName, func(t *testing.T) {
+			service, err := NewIdsecSIASettingsService(MockISPAuth())
+			if err != nil {
+				t.Fatalf("Failed to create IdsecSIASettingsService: %v", err)
+			}
+			service.doGet = MockGetFunc(MockHTTPResponse(http.StatusOK, SettingsResponseJSON), nil)
+			path := filepath.Join(t.TempDir(), fileName)
+
+			exported, err := service.ExportSettingsBaseline(&settingsmodels.IdsecSIASettingsExportBaseline{OutputPath: path})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if exported.Version != settingsmodels.IdsecSIASettingsBaselineVersion {
+				t.Errorf("Expected version %d, got %d", settingsmodels.IdsecSIASettingsBaselineVersion, exported.Version)
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read exported baseline: %v", err)
+			}
+			if strings.HasSuffix(fileName, ".yaml") && !strings.Contains(string(content), "adb_mfa_caching:\n") {
+				t.Errorf("Expected block style YAML with snake_case keys, got %s", content)
+			}
+			loaded, err := LoadSettingsBaseline(path)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(loaded.Settings, exported.Settings) {
+				t.Errorf("Expected round-tripped settings %+v, got %+v", exported.Settings, loaded.Settings)
+			}
+		})
+	}
+}
+
+func TestDiffSettingsBaseline(t *testing.T) {
+	tests := []struct {
+		name          string
+		baseline      string
+		failOnDrift   bool
+		expectedSync  bool
+		expectedError bool
+	}{
+		{
+			name:         "success_drift_reported",
+			baseline:     BaselineYAML,
+			expectedSync: false,
+		},
+		{
+			name:          "error_fail_on_drift",
+			baseline:      BaselineYAML,
+			failOnDrift:   true,
+			expectedError: true,
+		},
+		{
+			name:         "success_in_sync",
+			baseline:     BaselineJSON,
+			failOnDrift:  true,
+			expectedSync: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service, err := NewIdsecSIASettingsService(MockISPAuth())
+			if err != nil {
+				t.Fatalf("Failed to create IdsecSIASettingsService: %v", err)
+			}
+			service.doGet = MockGetFunc(MockHTTPResponse(http.StatusOK, SettingsResponseJSON), nil)
+			drift, err := service.DiffSettingsBaseline(&settingsmodels.IdsecSIASettingsDiffBaseline{
+				BaselinePath: writeBaselineFile(t, "baseline.yaml", tt.baseline),
+				FailOnDrift:  tt.failOnDrift,
+			})
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if drift.InSync != tt.expectedSync {
+				t.Errorf("Expected in sync %v, got %+v", tt.expectedSync, drift)
+			}
+		})
+	}
+}
+
+func TestApplySettingsBaseline(t *testing.T) {
+	tests := []struct {
+		name            string
+		baseline        string
+		dryRun          bool
+		expectedPatched []string
+		expectedPatch   map[string]interface{}
+	}{
+		{
+			name:            "success_patches_drifted_features_only",
+			baseline:        BaselineYAML,
+			expectedPatched: []string{"adb_mfa_caching", "rdp_recording"},
+			expectedPatch: map[string]interface{}{
+				"adbMfaCaching": map[string]interface{}{"isMfaCachingEnabled": true, "keyExpirationTimeSec": float64(600)},
+				"rdpRecording":  map[string]interface{}{"enabled": true},
+			},
+		},
+		{
+			name:            "success_dry_run",
+			baseline:        BaselineYAML,
+			dryRun:          true,
+			expectedPatched: []string{"adb_mfa_caching", "rdp_recording"},
+		},
+		{
+			name:            "success_in_sync",
+			baseline:        BaselineJSON,
+			expectedPatched: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service, err := NewIdsecSIASettingsService(MockISPAuth())
+			if err != nil {
+				t.Fatalf("Failed to create IdsecSIASettingsService: %v", err)
+			}
+			service.doGet = func(ctx context.Context, path string, params interface{}) (*http.Response, error) {
+				return MockHTTPResponse(http.StatusOK, SettingsResponseJSON), nil
+			}
+			var patchBody interface{}
+			service.doPatch = func(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+				patchBody = body
+				return MockHTTPResponse(http.StatusOK, EmptyResponseJSON), nil
+			}
+			result, err := service.ApplySettingsBaseline(&settingsmodels.IdsecSIASettingsApplyBaseline{
+				BaselinePath: writeBaselineFile(t, "baseline.yaml", tt.baseline),
+				DryRun:       tt.dryRun,
+			})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !reflect.DeepEqual(result.PatchedFeatures, tt.expectedPatched) {
+				t.Errorf("Expected patched features %v, got %v", tt.expectedPatched, result.PatchedFeatures)
+			}
+			if tt.expectedPatch == nil {
+				if patchBody != nil {
+					t.Errorf("Expected no patch, got %+v", patchBody)
+				}
+				return
+			}
+			patchMap, ok := patchBody.(map[string]interface{})
+			if !ok || !reflect.DeepEqual(patchMap, tt.expectedPatch) {
+				t.Errorf("Expected patch %+v, got %+v", tt.expectedPatch, patchBody)
+			}
+		})
+	}
+}
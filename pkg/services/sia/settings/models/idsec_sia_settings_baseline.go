@@ -0,0 +1,64 @@
+package models
+
+// IdsecSIASettingsBaselineVersion is the current version of the SIA settings baseline document format.
+const IdsecSIASettingsBaselineVersion = 1
+
+// Possible formats of an exported SIA settings baseline.
+const (
+	IdsecSIASettingsBaselineFormatJSON = "json"
+	IdsecSIASettingsBaselineFormatYAML = "yaml"
+)
+
+// IdsecSIASettingsBaseline represents a versioned SIA settings baseline document.
+//
+// A baseline is the desired state of the tenant settings. Features and fields left out of
+// the baseline are not managed by it, and are ignored when computing drift or applying it.
+type IdsecSIASettingsBaseline struct {
+	Version    int              `json:"version" mapstructure:"version" desc:"The version of the baseline document format."`
+	ExportedAt string           `json:"exported_at,omitempty" mapstructure:"exported_at,omitempty" desc:"The time the baseline was exported (RFC3339)."`
+	Settings   IdsecSIASettings `json:"settings" mapstructure:"settings" desc:"The desired SIA settings."`
+}
+
+// IdsecSIASettingsExportBaseline represents the request for exporting the live SIA settings as a baseline.
+type IdsecSIASettingsExportBaseline struct {
+	OutputPath string `json:"output_path,omitempty" mapstructure:"output_path,omitempty" flag:"output-path" desc:"The file to write the baseline to. When empty, the baseline is only returned."`
+	Format     string `json:"format,omitempty" mapstructure:"format,omitempty" flag:"format" desc:"The format of the baseline file. Defaults to the output path extension, or yaml." choices:"json,yaml"`
+}
+
+// IdsecSIASettingsDiffBaseline represents the request for comparing a baseline file to the live SIA settings.
+type IdsecSIASettingsDiffBaseline struct {
+	BaselinePath string `json:"baseline_path" mapstructure:"baseline_path" flag:"baseline-path" desc:"The JSON or YAML baseline file to compare against the live settings." validate:"required"`
+	FailOnDrift  bool   `json:"fail_on_drift,omitempty" mapstructure:"fail_on_drift,omitempty" flag:"fail-on-drift" desc:"Return an error when the live settings drifted from the baseline, for use as a CI gate." default:"false"`
+}
+
+// IdsecSIASettingsApplyBaseline represents the request for applying a baseline file to the live SIA settings.
+type IdsecSIASettingsApplyBaseline struct {
+	BaselinePath string `json:"baseline_path" mapstructure:"baseline_path" flag:"baseline-path" desc:"The JSON or YAML baseline file to apply." validate:"required"`
+	DryRun       bool   `json:"dry_run,omitempty" mapstructure:"dry_run,omitempty" flag:"dry-run" desc:"Only report the features that would be patched, without changing the tenant." default:"false"`
+}
+
+// IdsecSIASettingsFieldDrift represents a single field whose live value differs from the baseline.
+type IdsecSIASettingsFieldDrift struct {
+	Field    string      `json:"field" mapstructure:"field" desc:"The field name, dotted for nested fields."`
+	Baseline interface{} `json:"baseline" mapstructure:"baseline" desc:"The value in the baseline."`
+	Live     interface{} `json:"live" mapstructure:"live" desc:"The value in the live settings, null when not set."`
+}
+
+// IdsecSIASettingsFeatureDrift represents the drifted fields of a single settings feature.
+type IdsecSIASettingsFeatureDrift struct {
+	Feature string                       `json:"feature" mapstructure:"feature" desc:"The settings feature, as named in the settings document."`
+	Fields  []IdsecSIASettingsFieldDrift `json:"fields" mapstructure:"fields" desc:"The drifted fields of the feature."`
+}
+
+// IdsecSIASettingsDrift represents the differences between a baseline and the live SIA settings.
+type IdsecSIASettingsDrift struct {
+	InSync   bool                           `json:"in_sync" mapstructure:"in_sync" desc:"Whether the live settings match the baseline."`
+	Features []IdsecSIASettingsFeatureDrift `json:"features" mapstructure:"features" desc:"The drifted features."`
+}
+
+// IdsecSIASettingsApplyBaselineResult represents the outcome of applying a baseline to the live SIA settings.
+type IdsecSIASettingsApplyBaselineResult struct {
+	DryRun          bool                  `json:"dry_run" mapstructure:"dry_run" desc:"Whether the apply was a dry run."`
+	Drift           IdsecSIASettingsDrift `json:"drift" mapstructure:"drift" desc:"The drift found before applying the baseline."`
+	PatchedFeatures []string              `json:"patched_features" mapstructure:"patched_features" desc:"The features patched, or that would be patched in a dry run."`
+}
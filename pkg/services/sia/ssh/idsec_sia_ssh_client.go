@@ -0,0 +1,329 @@
+package ssh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/common"
+	sshmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/ssh/models"
+	ssomodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/sso/models"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	sshGatewayPort        = 22
+	defaultClientTimeout  = 30 * time.Second
+	defaultPTYTerm        = "xterm-256color"
+	defaultPTYWidth       = 80
+	defaultPTYHeight      = 24
+	defaultKnownHostsFile = "~/.ssh/known_hosts"
+)
+
+// IdsecSIASSHClient is an in-process SSH connection to a target host through the
+// SIA SSH gateway. It is created by IdsecSIASSHService.NewClient and must be closed
+// with Close once no longer needed.
+//
+// A single client can run several commands, shells and port forwards concurrently,
+// each over its own SSH channel.
+type IdsecSIASSHClient struct {
+	client *gossh.Client
+	logger *common.IdsecLogger
+}
+
+// NewClient opens an in-process SSH connection to the target through the SIA SSH
+// gateway, authenticating with the short-lived SSH key issued by the SSO service.
+// The key is parsed in memory and never written to disk.
+//
+// Example:
+//
+//	client, err := sshService.NewClient(&sshmodels.IdsecSIASSHClientOptions{TargetAddress: "10.0.0.1", TargetUsername: "ec2-user"})
+//	if err != nil { /* handle */ }
+//	defer client.Close()
+//	out, err := client.CombinedOutput("uname -a")
+func (s *IdsecSIASSHService) NewClient(options *sshmodels.IdsecSIASSHClientOptions) (*IdsecSIASSHClient, error) {
+	if options == nil {
+		return nil, fmt.Errorf("client options are required")
+	}
+	if options.TargetAddress == "" {
+		return nil, fmt.Errorf("target address is required")
+	}
+	gateway, err := s.proxyAddress()
+	if err != nil {
+		return nil, err
+	}
+	connectionString, err := s.connectionString(options.TargetAddress, options.TargetUsername, options.TargetPort, options.NetworkName)
+	if err != nil {
+		return nil, err
+	}
+	keyContent, err := s.shortLivedSshKeyFn()(&ssomodels.IdsecSIASSOGetSSHKey{
+		Format:       ssomodels.OpenSSH,
+		OutputFormat: ssomodels.SSHKeyOutputFormatRaw,
+		AllowCaching: options.AllowCaching,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if keyContent == "" {
+		return nil, fmt.Errorf("sso service returned an empty ssh key")
+	}
+	signer, err := gossh.ParsePrivateKey([]byte(keyContent))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse short-lived ssh key: %w", err)
+	}
+	hostKeyCallback, err := s.hostKeyCallback(options)
+	if err != nil {
+		return nil, err
+	}
+	timeout := defaultClientTimeout
+	if options.TimeoutSeconds > 0 {
+		timeout = time.Duration(options.TimeoutSeconds) * time.Second
+	}
+	config := &gossh.ClientConfig{
+		User:            connectionString,
+		Auth:            []gossh.AuthMethod{gossh.PublicKeys(signer)},
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         timeout,
+	}
+	address := net.JoinHostPort(gateway, strconv.Itoa(sshGatewayPort))
+	s.Logger.Info("Opening native ssh connection to %s via %s", options.TargetAddress, gateway)
+	client, err := s.dialFn()("tcp", address, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to ssh gateway [%s]: %w", gateway, err)
+	}
+	return &IdsecSIASSHClient{client: client, logger: s.Logger}, nil
+}
+
+func (s *IdsecSIASSHService) dialFn() func(string, string, *gossh.ClientConfig) (*gossh.Client, error) {
+	if s.dialSSH != nil {
+		return s.dialSSH
+	}
+	return gossh.Dial
+}
+
+// hostKeyCallback verifies the gateway host key against the pinned fingerprint when
+// one is given, otherwise against the configured known_hosts file, the same trust
+// store used by the system ssh client.
+func (s *IdsecSIASSHService) hostKeyCallback(options *sshmodels.IdsecSIASSHClientOptions) (gossh.HostKeyCallback, error) {
+	if options.InsecureIgnoreHostKey {
+		s.Logger.Warning("SIA ssh gateway host key verification is disabled")
+		return gossh.InsecureIgnoreHostKey(), nil // #nosec G106
+	}
+	if options.HostKeyFingerprint != "" {
+		return pinnedHostKeyCallback(options.HostKeyFingerprint), nil
+	}
+	knownHostsFile := options.KnownHostsFile
+	if knownHostsFile == "" {
+		knownHostsFile = defaultKnownHostsFile
+	}
+	knownHostsFile = strings.TrimSuffix(common.ExpandFolder(knownHostsFile), "/")
+	if knownHostsFile == "" {
+		return nil, fmt.Errorf("failed to resolve known hosts file [%s]", options.KnownHostsFile)
+	}
+	callback, err := loadKnownHosts(knownHostsFile)
+	if err != nil {
+		return nil, err
+	}
+	if !options.TrustOnFirstUse {
+		return callback, nil
+	}
+	return func(hostname string, remote net.Addr, key gossh.PublicKey) error {
+		err := callback(hostname, remote, key)
+		var keyErr *knownhosts.KeyError
+		if !errors.As(err, &keyErr) || len(keyErr.Want) > 0 {
+			// Either the key is known, or the host is known with a different key
+			return err
+		}
+		s.Logger.Warning("Trusting new SIA ssh gateway host key %s for [%s] on first use", gossh.FingerprintSHA256(key), hostname)
+		return appendKnownHost(knownHostsFile, hostname, key)
+	}, nil
+}
+
+// loadKnownHosts parses the known_hosts file, treating a missing file as empty.
+func loadKnownHosts(knownHostsFile string) (gossh.HostKeyCallback, error) {
+	files := []string{knownHostsFile}
+	if _, err := os.Stat(knownHostsFile); errors.Is(err, os.ErrNotExist) {
+		files = nil
+	}
+	callback, err := knownhosts.New(files...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load known hosts file [%s]: %w", knownHostsFile, err)
+	}
+	return callback, nil
+}
+
+// appendKnownHost records the host key in the known_hosts file, creating it if needed.
+func appendKnownHost(knownHostsFile string, hostname string, key gossh.PublicKey) error {
+	if err := os.MkdirAll(filepath.Dir(knownHostsFile), 0700); err != nil {
+		return fmt.Errorf("failed to create known hosts folder: %w", err)
+	}
+	file, err := os.OpenFile(knownHostsFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600) // #nosec G304
+	if err != nil {
+		return fmt.Errorf("failed to open known hosts file [%s]: %w", knownHostsFile, err)
+	}
+	defer func() { _ = file.Close() }()
+	if _, err := fmt.Fprintln(file, knownhosts.Line([]string{hostname}, key)); err != nil {
+		return fmt.Errorf("failed to write known hosts file [%s]: %w", knownHostsFile, err)
+	}
+	return nil
+}
+
+// pinnedHostKeyCallback accepts only the host key whose SHA256 fingerprint matches.
+func pinnedHostKeyCallback(fingerprint string) gossh.HostKeyCallback {
+	if !strings.HasPrefix(fingerprint, "SHA256:") {
+		fingerprint = "SHA256:" + fingerprint
+	}
+	return func(hostname string, _ net.Addr, key gossh.PublicKey) error {
+		if actual := gossh.FingerprintSHA256(key); actual != fingerprint {
+			return fmt.Errorf("ssh gateway [%s] host key %s does not match pinned fingerprint %s", hostname, actual, fingerprint)
+		}
+		return nil
+	}
+}
+
+// Close closes the underlying SSH connection and every session and forward opened on it.
+func (c *IdsecSIASSHClient) Close() error {
+	return c.client.Close()
+}
+
+// NewSession opens a raw SSH session for callers that need full control over the
+// channel (environment variables, signals, custom requests).
+func (c *IdsecSIASSHClient) NewSession() (*gossh.Session, error) {
+	session, err := c.client.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open ssh session: %w", err)
+	}
+	return session, nil
+}
+
+// Run executes a single command on the target, streaming stdin to the remote process
+// and its stdout/stderr to the given writers. Any of the streams may be nil.
+// A non-zero remote exit status is returned as a *gossh.ExitError.
+func (c *IdsecSIASSHClient) Run(command string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	session, err := c.NewSession()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = session.Close() }()
+	session.Stdin = stdin
+	session.Stdout = stdout
+	session.Stderr = stderr
+	return session.Run(command)
+}
+
+// CombinedOutput executes a single command on the target and returns its combined
+// stdout and stderr.
+func (c *IdsecSIASSHClient) CombinedOutput(command string) ([]byte, error) {
+	session, err := c.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = session.Close() }()
+	return session.CombinedOutput(command)
+}
+
+// Shell opens an interactive shell on the target with a pseudo-terminal and wires
+// it to the given streams until the remote shell exits. When command is non-empty
+// it is executed with the pseudo-terminal instead of the login shell (the
+// equivalent of `ssh -t host command`).
+func (c *IdsecSIASSHClient) Shell(pty *sshmodels.IdsecSIASSHPTY, command string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	session, err := c.NewSession()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = session.Close() }()
+	term, width, height := defaultPTYTerm, defaultPTYWidth, defaultPTYHeight
+	if pty != nil {
+		if pty.Term != "" {
+			term = pty.Term
+		}
+		if pty.Width > 0 {
+			width = pty.Width
+		}
+		if pty.Height > 0 {
+			height = pty.Height
+		}
+	}
+	modes := gossh.TerminalModes{
+		gossh.ECHO:          1,
+		gossh.TTY_OP_ISPEED: 14400,
+		gossh.TTY_OP_OSPEED: 14400,
+	}
+	if err := session.RequestPty(term, height, width, modes); err != nil {
+		return fmt.Errorf("failed to request pty: %w", err)
+	}
+	stopResize := watchTerminalResize(stdin, session.WindowChange)
+	defer stopResize()
+	session.Stdin = stdin
+	session.Stdout = stdout
+	session.Stderr = stderr
+	if command != "" {
+		return session.Run(command)
+	}
+	if err := session.Shell(); err != nil {
+		return fmt.Errorf("failed to start remote shell: %w", err)
+	}
+	return session.Wait()
+}
+
+// ForwardLocal listens on localAddress and forwards every accepted connection to
+// remoteAddress as seen from the target (the equivalent of `ssh -L`).
+// Forwarding stops when ctx is done or the returned listener is closed; use
+// listener.Addr() to discover the bound port when localAddress uses port 0.
+func (c *IdsecSIASSHClient) ForwardLocal(ctx context.Context, localAddress string, remoteAddress string) (net.Listener, error) {
+	listener, err := net.Listen("tcp", localAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on [%s]: %w", localAddress, err)
+	}
+	go c.forward(ctx, listener, func() (net.Conn, error) {
+		return c.client.Dial("tcp", remoteAddress)
+	})
+	return listener, nil
+}
+
+// ForwardRemote listens on remoteAddress on the target and forwards every accepted
+// connection to localAddress as seen from the current host (the equivalent of
+// `ssh -R`). Forwarding stops when ctx is done or the returned listener is closed.
+func (c *IdsecSIASSHClient) ForwardRemote(ctx context.Context, remoteAddress string, localAddress string) (net.Listener, error) {
+	listener, err := c.client.Listen("tcp", remoteAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on remote [%s]: %w", remoteAddress, err)
+	}
+	go c.forward(ctx, listener, func() (net.Conn, error) {
+		return net.Dial("tcp", localAddress)
+	})
+	return listener, nil
+}
+
+// forward accepts connections on listener and pipes each one to a connection
+// obtained from dial until the listener is closed or ctx is done.
+func (c *IdsecSIASSHClient) forward(ctx context.Context, listener net.Listener, dial func() (net.Conn, error)) {
+	stop := context.AfterFunc(ctx, func() { _ = listener.Close() })
+	defer stop()
+	for {
+		accepted, err := listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) && ctx.Err() == nil {
+				c.logger.Debug("Stopped forwarding on [%s]: %v", listener.Addr(), err)
+			}
+			return
+		}
+		go func() {
+			target, err := dial()
+			if err != nil {
+				c.logger.Warning("Failed to open forwarded connection from [%s]: %v", listener.Addr(), err)
+				_ = accepted.Close()
+				return
+			}
+			common.PipeConnections(accepted, target)
+		}()
+	}
+}
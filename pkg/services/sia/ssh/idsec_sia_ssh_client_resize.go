@@ -0,0 +1,41 @@
+//go:build !windows
+
+package ssh
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"golang.org/x/term"
+)
+
+// watchTerminalResize propagates local terminal size changes (SIGWINCH) to the remote
+// pseudo-terminal when stdin is a terminal. The returned function stops watching.
+func watchTerminalResize(stdin io.Reader, resize func(height int, width int) error) func() {
+	file, ok := stdin.(*os.File)
+	if !ok || !term.IsTerminal(int(file.Fd())) { // #nosec G115
+		return func() {}
+	}
+	fd := int(file.Fd()) // #nosec G115
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGWINCH)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-signals:
+				if width, height, err := term.GetSize(fd); err == nil {
+					_ = resize(height, width)
+				}
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
@@ -0,0 +1,9 @@
+package ssh
+
+import "io"
+
+// watchTerminalResize is a no-op on Windows, which has no SIGWINCH; the remote
+// pseudo-terminal keeps the size it was opened with.
+func watchTerminalResize(io.Reader, func(height int, width int) error) func() {
+	return func() {}
+}
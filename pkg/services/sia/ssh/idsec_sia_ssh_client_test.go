@@ -0,0 +1,420 @@
+package ssh
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	sshmodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/ssh/models"
+	ssomodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/sso/models"
+)
+
+// testSSHServer is a minimal in-process SSH server standing in for the SIA gateway.
+// It supports exec (echoes the command, "cat" echoes stdin, "fail" exits 3),
+// pty-req + shell (writes the requested terminal type) and direct-tcpip.
+type testSSHServer struct {
+	address string
+	hostKey gossh.PublicKey
+
+	mutex sync.Mutex
+	users []string
+}
+
+func (s *testSSHServer) lastUser() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if len(s.users) == 0 {
+		return ""
+	}
+	return s.users[len(s.users)-1]
+}
+
+func newTestSSHServer(t *testing.T, authorizedKey gossh.PublicKey) *testSSHServer {
+	t.Helper()
+	_, hostPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostSigner, err := gossh.NewSignerFromKey(hostPrivateKey)
+	require.NoError(t, err)
+
+	server := &testSSHServer{hostKey: hostSigner.PublicKey()}
+	config := &gossh.ServerConfig{
+		PublicKeyCallback: func(conn gossh.ConnMetadata, key gossh.PublicKey) (*gossh.Permissions, error) {
+			if !bytes.Equal(key.Marshal(), authorizedKey.Marshal()) {
+				return nil, fmt.Errorf("unauthorized key")
+			}
+			server.mutex.Lock()
+			server.users = append(server.users, conn.User())
+			server.mutex.Unlock()
+			return nil, nil
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	server.address = listener.Addr().String()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestSSHConn(conn, config)
+		}
+	}()
+	return server
+}
+
+func serveTestSSHConn(conn net.Conn, config *gossh.ServerConfig) {
+	serverConn, channels, requests, err := gossh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	defer func() { _ = serverConn.Close() }()
+	go gossh.DiscardRequests(requests)
+	for newChannel := range channels {
+		switch newChannel.ChannelType() {
+		case "session":
+			channel, channelRequests, err := newChannel.Accept()
+			if err != nil {
+				continue
+			}
+			go serveTestSSHSession(channel, channelRequests)
+		case "direct-tcpip":
+			var payload struct {
+				Host       string
+				Port       uint32
+				OriginHost string
+				OriginPort uint32
+			}
+			if err := gossh.Unmarshal(newChannel.ExtraData(), &payload); err != nil {
+				_ = newChannel.Reject(gossh.ConnectionFailed, err.Error())
+				continue
+			}
+			target, err := net.Dial("tcp", net.JoinHostPort(payload.Host, strconv.Itoa(int(payload.Port))))
+			if err != nil {
+				_ = newChannel.Reject(gossh.ConnectionFailed, err.Error())
+				continue
+			}
+			channel, channelRequests, err := newChannel.Accept()
+			if err != nil {
+				_ = target.Close()
+				continue
+			}
+			go gossh.DiscardRequests(channelRequests)
+			go func() {
+				go func() { _, _ = io.Copy(target, channel) }()
+				_, _ = io.Copy(channel, target)
+				_ = channel.Close()
+				_ = target.Close()
+			}()
+		default:
+			_ = newChannel.Reject(gossh.UnknownChannelType, "unsupported channel type")
+		}
+	}
+}
+
+func serveTestSSHSession(channel gossh.Channel, requests <-chan *gossh.Request) {
+	defer func() { _ = channel.Close() }()
+	exit := func(status uint32) {
+		_, _ = channel.SendRequest("exit-status", false, gossh.Marshal(struct{ Status uint32 }{status}))
+	}
+	ptyTerm := ""
+	for req := range requests {
+		switch req.Type {
+		case "pty-req":
+			var payload struct {
+				Term     string
+				Columns  uint32
+				Rows     uint32
+				Width    uint32
+				Height   uint32
+				Modelist string
+			}
+			_ = gossh.Unmarshal(req.Payload, &payload)
+			ptyTerm = fmt.Sprintf("%s %dx%d", payload.Term, payload.Columns, payload.Rows)
+			_ = req.Reply(true, nil)
+		case "shell":
+			_ = req.Reply(true, nil)
+			_, _ = fmt.Fprintf(channel, "shell: %s", ptyTerm)
+			exit(0)
+			return
+		case "exec":
+			var payload struct{ Command string }
+			_ = gossh.Unmarshal(req.Payload, &payload)
+			_ = req.Reply(true, nil)
+			switch payload.Command {
+			case "cat":
+				_, _ = io.Copy(channel, channel)
+				exit(0)
+			case "fail":
+				_, _ = fmt.Fprint(channel.Stderr(), "failed")
+				exit(3)
+			default:
+				if ptyTerm != "" {
+					_, _ = fmt.Fprintf(channel, "tty %s: ", ptyTerm)
+				}
+				_, _ = fmt.Fprintf(channel, "ran: %s", payload.Command)
+				exit(0)
+			}
+			return
+		default:
+			_ = req.Reply(false, nil)
+		}
+	}
+}
+
+// newNativeTestService returns a test service whose SSO key and dialer point at an
+// in-process SSH server.
+func newNativeTestService(t *testing.T) (*IdsecSIASSHService, *testSSHServer, *[]string) {
+	t.Helper()
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pemBlock, err := gossh.MarshalPrivateKey(privateKey, "")
+	require.NoError(t, err)
+	sshPublicKey, err := gossh.NewPublicKey(publicKey)
+	require.NoError(t, err)
+
+	server := newTestSSHServer(t, sshPublicKey)
+	var dialed []string
+	svc := newTestService()
+	svc.shortLivedSshKey = func(_ *ssomodels.IdsecSIASSOGetSSHKey) (string, error) {
+		return string(pem.EncodeToMemory(pemBlock)), nil
+	}
+	svc.dialSSH = func(network, addr string, config *gossh.ClientConfig) (*gossh.Client, error) {
+		dialed = append(dialed, addr)
+		return gossh.Dial(network, server.address, config)
+	}
+	return svc, server, &dialed
+}
+
+func newNativeTestClient(t *testing.T) (*IdsecSIASSHClient, *testSSHServer) {
+	t.Helper()
+	svc, server, _ := newNativeTestService(t)
+	client, err := svc.NewClient(&sshmodels.IdsecSIASSHClientOptions{
+		TargetAddress:         "10.0.0.1",
+		TargetUsername:        "ec2-user",
+		InsecureIgnoreHostKey: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client, server
+}
+
+func TestNewClient_ConnectsThroughGateway(t *testing.T) {
+	t.Parallel()
+	svc, server, dialed := newNativeTestService(t)
+
+	client, err := svc.NewClient(&sshmodels.IdsecSIASSHClientOptions{
+		TargetAddress:         "10.0.0.1",
+		TargetUsername:        "ec2-user",
+		TargetPort:            2222,
+		NetworkName:           "prod-network",
+		InsecureIgnoreHostKey: true,
+	})
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	require.Equal(t, []string{testGateway + ":22"}, *dialed)
+	require.Equal(t, testTenantPrefix+"@ec2-user@10.0.0.1:2222#prod-network", server.lastUser())
+}
+
+func TestNewClient_KnownHosts(t *testing.T) {
+	t.Parallel()
+	svc, server, _ := newNativeTestService(t)
+	knownHostsFile := filepath.Join(t.TempDir(), "known_hosts")
+	options := &sshmodels.IdsecSIASSHClientOptions{TargetAddress: "10.0.0.1", KnownHostsFile: knownHostsFile}
+
+	require.NoError(t, os.WriteFile(knownHostsFile, nil, 0600))
+	_, err := svc.NewClient(options)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to connect to ssh gateway")
+
+	// The test dialer connects to the server address, so that is the host being verified.
+	line := knownhosts.Line([]string{server.address}, server.hostKey)
+	require.NoError(t, os.WriteFile(knownHostsFile, []byte(line+"\n"), 0600))
+	client, err := svc.NewClient(options)
+	require.NoError(t, err)
+	_ = client.Close()
+}
+
+func TestNewClient_TrustOnFirstUse(t *testing.T) {
+	t.Parallel()
+	svc, server, _ := newNativeTestService(t)
+	knownHostsFile := filepath.Join(t.TempDir(), "ssh", "known_hosts")
+	options := &sshmodels.IdsecSIASSHClientOptions{TargetAddress: "10.0.0.1", KnownHostsFile: knownHostsFile}
+
+	// A missing file is treated as empty, so the unknown host is rejected without TOFU
+	_, err := svc.NewClient(options)
+	require.ErrorContains(t, err, "failed to connect to ssh gateway")
+	require.NoFileExists(t, knownHostsFile)
+
+	options.TrustOnFirstUse = true
+	client, err := svc.NewClient(options)
+	require.NoError(t, err)
+	_ = client.Close()
+	content, err := os.ReadFile(knownHostsFile)
+	require.NoError(t, err)
+	require.Equal(t, knownhosts.Line([]string{server.address}, server.hostKey)+"\n", string(content))
+
+	// The recorded key is now trusted without TOFU, and a different key is still rejected
+	options.TrustOnFirstUse = false
+	client, err = svc.NewClient(options)
+	require.NoError(t, err)
+	_ = client.Close()
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherSigner, err := gossh.NewSignerFromKey(otherKey)
+	require.NoError(t, err)
+	line := knownhosts.Line([]string{server.address}, otherSigner.PublicKey())
+	require.NoError(t, os.WriteFile(knownHostsFile, []byte(line+"\n"), 0600))
+	options.TrustOnFirstUse = true
+	_, err = svc.NewClient(options)
+	require.ErrorContains(t, err, "failed to connect to ssh gateway")
+}
+
+func TestNewClient_PinnedHostKey(t *testing.T) {
+	t.Parallel()
+	svc, server, _ := newNativeTestService(t)
+
+	client, err := svc.NewClient(&sshmodels.IdsecSIASSHClientOptions{
+		TargetAddress:      "10.0.0.1",
+		HostKeyFingerprint: gossh.FingerprintSHA256(server.hostKey),
+		KnownHostsFile:     filepath.Join(t.TempDir(), "missing"),
+	})
+	require.NoError(t, err)
+	_ = client.Close()
+
+	_, err = svc.NewClient(&sshmodels.IdsecSIASSHClientOptions{
+		TargetAddress:      "10.0.0.1",
+		HostKeyFingerprint: "SHA256:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+	})
+	require.ErrorContains(t, err, "does not match pinned fingerprint")
+}
+
+func TestNewClient_Validation(t *testing.T) {
+	t.Parallel()
+	svc := newTestService()
+
+	_, err := svc.NewClient(nil)
+	require.Error(t, err)
+	_, err = svc.NewClient(&sshmodels.IdsecSIASSHClientOptions{})
+	require.Error(t, err)
+
+	svc.shortLivedSshKey = func(_ *ssomodels.IdsecSIASSOGetSSHKey) (string, error) { return "", nil }
+	_, err = svc.NewClient(&sshmodels.IdsecSIASSHClientOptions{TargetAddress: "10.0.0.1"})
+	require.ErrorContains(t, err, "empty ssh key")
+
+	svc.shortLivedSshKey = func(_ *ssomodels.IdsecSIASSOGetSSHKey) (string, error) { return "not-a-key", nil }
+	_, err = svc.NewClient(&sshmodels.IdsecSIASSHClientOptions{TargetAddress: "10.0.0.1"})
+	require.ErrorContains(t, err, "failed to parse short-lived ssh key")
+
+	svc.shortLivedSshKey = func(_ *ssomodels.IdsecSIASSOGetSSHKey) (string, error) { return "", errors.New("sso down") }
+	_, err = svc.NewClient(&sshmodels.IdsecSIASSHClientOptions{TargetAddress: "10.0.0.1"})
+	require.ErrorContains(t, err, "sso down")
+}
+
+func TestClient_RunAndCombinedOutput(t *testing.T) {
+	t.Parallel()
+	client, _ := newNativeTestClient(t)
+
+	out, err := client.CombinedOutput("uname -a")
+	require.NoError(t, err)
+	require.Equal(t, "ran: uname -a", string(out))
+
+	var stdout bytes.Buffer
+	require.NoError(t, client.Run("cat", strings.NewReader("streamed input"), &stdout, nil))
+	require.Equal(t, "streamed input", stdout.String())
+
+	var stderr bytes.Buffer
+	err = client.Run("fail", nil, nil, &stderr)
+	var exitErr *gossh.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	require.Equal(t, 3, exitErr.ExitStatus())
+	require.Equal(t, "failed", stderr.String())
+}
+
+func TestClient_Shell(t *testing.T) {
+	t.Parallel()
+	client, _ := newNativeTestClient(t)
+
+	var stdout bytes.Buffer
+	require.NoError(t, client.Shell(&sshmodels.IdsecSIASSHPTY{Term: "vt100", Width: 120, Height: 40}, "", nil, &stdout, nil))
+	require.Equal(t, "shell: vt100 120x40", stdout.String())
+
+	stdout.Reset()
+	require.NoError(t, client.Shell(nil, "sudo id", nil, &stdout, nil))
+	require.Equal(t, "tty xterm-256color 80x24: ran: sudo id", stdout.String())
+}
+
+func TestClient_ForwardLocal(t *testing.T) {
+	t.Parallel()
+	client, _ := newNativeTestClient(t)
+
+	echo, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = echo.Close() }()
+	go func() {
+		for {
+			conn, err := echo.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_, _ = io.Copy(conn, conn)
+				_ = conn.Close()
+			}()
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	listener, err := client.ForwardLocal(ctx, "127.0.0.1:0", echo.Addr().String())
+	require.NoError(t, err)
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("ping"))
+	require.NoError(t, err)
+	reply := make([]byte, 4)
+	_, err = io.ReadFull(conn, reply)
+	require.NoError(t, err)
+	require.Equal(t, "ping", string(reply))
+	_ = conn.Close()
+
+	cancel()
+	require.Eventually(t, func() bool {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		if err == nil {
+			_ = conn.Close()
+		}
+		return err != nil
+	}, 2*time.Second, 20*time.Millisecond)
+}
+
+func TestConnect_NativeClientRejectsExtraArgs(t *testing.T) {
+	t.Parallel()
+	svc := newTestService()
+	err := svc.Connect(&sshmodels.IdsecSIASSHConnectExecution{
+		IdsecSIASSHBaseExecution: sshmodels.IdsecSIASSHBaseExecution{TargetAddress: "10.0.0.1"},
+		NativeClient:             true,
+		ExtraArgs:                []string{"-L", "1234:host:22"},
+	})
+	require.ErrorContains(t, err, "not supported by the native client")
+}
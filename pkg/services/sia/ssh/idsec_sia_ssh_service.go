@@ -5,6 +5,8 @@ import (
 	"os"
 
 	jwt "github.com/golang-jwt/jwt/v5"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/term"
 	"github.com/cyberark/idsec-sdk-golang/pkg/auth"
 	"github.com/cyberark/idsec-sdk-golang/pkg/common"
 	"github.com/cyberark/idsec-sdk-golang/pkg/common/isp"
@@ -23,12 +25,13 @@ const (
 // IdsecSIASSHService is a struct that implements the IdsecService interface and
 // provides functionality for the SSH service of SIA. It mirrors the structure
 // of IdsecSIADBService, reusing the SSO service to obtain short-lived
-// credentials and spawning a local SSH client as a child process.
+// credentials and either spawning a local SSH client as a child process or
+// connecting in-process (see NewClient).
 //
 // The unexported function fields are test seams (same pattern used by
 // IdsecSIASSHCAService): production code uses the defaults wired in
 // NewIdsecSIASSHService while tests inject deterministic fakes via the
-// helper accessors below (claimsFn / shortLivedSshKeyFn / executeFn).
+// helper accessors below (claimsFn / shortLivedSshKeyFn / executeFn / dialFn).
 type IdsecSIASSHService struct {
 	*services.IdsecBaseService
 	*services.IdsecISPBaseService
@@ -37,6 +40,7 @@ type IdsecSIASSHService struct {
 	parseClaims      func() (jwt.MapClaims, error)
 	shortLivedSshKey func(*ssomodels.IdsecSIASSOGetSSHKey) (string, error)
 	executeCommand   func(name string, args ...string) error
+	dialSSH          func(network, addr string, config *gossh.ClientConfig) (*gossh.Client, error)
 }
 
 // NewIdsecSIASSHService creates a new instance of IdsecSIASSHService with the provided authenticators.
@@ -228,11 +232,15 @@ func (s *IdsecSIASSHService) buildSSHArgs(keyPath string, userAtGateway string,
 //
 // The short-lived SSH key issued by the SSO service is staged into a temp
 // file with 0600 permissions for the lifetime of the spawned SSH process and
-// removed once it exits.
+// removed once it exits. Set NativeClient to connect in-process instead, which
+// needs no SSH executable and keeps the key in memory.
 func (s *IdsecSIASSHService) Connect(execution *sshmodels.IdsecSIASSHConnectExecution) error {
 	if execution == nil {
 		return fmt.Errorf("execution parameters are required")
 	}
+	if execution.NativeClient {
+		return s.connectNative(execution)
+	}
 	gateway, err := s.proxyAddress()
 	if err != nil {
 		return err
@@ -261,6 +269,46 @@ func (s *IdsecSIASSHService) Connect(execution *sshmodels.IdsecSIASSHConnectExec
 	return s.executeFn()(sshPath, args...)
 }
 
+// connectNative implements Connect on top of the in-process SSH client, wiring
+// the remote session to the current process' stdin/stdout/stderr.
+func (s *IdsecSIASSHService) connectNative(execution *sshmodels.IdsecSIASSHConnectExecution) error {
+	if len(execution.ExtraArgs) > 0 {
+		return fmt.Errorf("extra ssh arguments are not supported by the native client")
+	}
+	client, err := s.NewClient(&sshmodels.IdsecSIASSHClientOptions{
+		TargetAddress:         execution.TargetAddress,
+		TargetUsername:        execution.TargetUsername,
+		TargetPort:            execution.TargetPort,
+		NetworkName:           execution.NetworkName,
+		AllowCaching:          execution.AllowCaching,
+		KnownHostsFile:        execution.KnownHostsFile,
+		HostKeyFingerprint:    execution.HostKeyFingerprint,
+		TrustOnFirstUse:       execution.TrustOnFirstUse,
+		InsecureIgnoreHostKey: execution.InsecureIgnoreHostKey,
+	})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	if execution.Command != "" && !execution.ForceTTY {
+		return client.Run(execution.Command, os.Stdin, os.Stdout, os.Stderr)
+	}
+	pty := &sshmodels.IdsecSIASSHPTY{Term: os.Getenv("TERM")}
+	stdinFd := int(os.Stdin.Fd()) // #nosec G115
+	if term.IsTerminal(stdinFd) {
+		if width, height, err := term.GetSize(stdinFd); err == nil {
+			pty.Width, pty.Height = width, height
+		}
+		oldState, err := term.MakeRaw(stdinFd)
+		if err != nil {
+			return fmt.Errorf("failed to put terminal into raw mode: %w", err)
+		}
+		defer func() { _ = term.Restore(stdinFd, oldState) }()
+	}
+	return client.Shell(pty, execution.Command, os.Stdin, os.Stdout, os.Stderr)
+}
+
 // ServiceConfig returns the service configuration for the IdsecSIASSHService.
 func (s *IdsecSIASSHService) ServiceConfig() services.IdsecServiceConfig {
 	return ServiceConfig
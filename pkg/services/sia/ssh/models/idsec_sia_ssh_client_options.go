@@ -0,0 +1,30 @@
+package models
+
+// IdsecSIASSHClientOptions defines the parameters used to open an in-process SSH
+// client connection to a target host through the SIA SSH gateway.
+//
+// Unlike IdsecSIASSHConnectExecution, no local SSH executable is involved: the
+// short-lived SSH key issued by the SSO service is parsed in memory and never
+// written to disk. The gateway host key is verified against HostKeyFingerprint
+// when set, otherwise against KnownHostsFile (defaulting to ~/.ssh/known_hosts),
+// unless InsecureIgnoreHostKey is set. A missing known_hosts file is treated as
+// empty; with TrustOnFirstUse an unknown gateway key is accepted and recorded in it.
+type IdsecSIASSHClientOptions struct {
+	TargetAddress         string `json:"target_address" mapstructure:"target_address" flag:"target-address" desc:"The target address of the connection."`
+	TargetUsername        string `json:"target_username,omitempty" mapstructure:"target_username,omitempty" flag:"target-username" desc:"The target username account to use for the connection."`
+	TargetPort            int    `json:"target_port,omitempty" mapstructure:"target_port,omitempty" flag:"target-port" desc:"Optional port on the target machine to connect to. Leave empty to use the SIA gateway default."`
+	NetworkName           string `json:"network_name,omitempty" mapstructure:"network_name,omitempty" flag:"network-name" desc:"The network name to use for the connection, if applicable."`
+	AllowCaching          bool   `json:"allow_caching,omitempty" mapstructure:"allow_caching,omitempty" flag:"allow-caching" desc:"Reuse a cached short-lived SSH key from the SSO keyring (when present and not expired) instead of fetching a fresh one." default:"false"`
+	KnownHostsFile        string `json:"known_hosts_file,omitempty" mapstructure:"known_hosts_file,omitempty" flag:"known-hosts-file" desc:"The known_hosts file used to verify the SIA gateway host key. Defaults to ~/.ssh/known_hosts."`
+	HostKeyFingerprint    string `json:"host_key_fingerprint,omitempty" mapstructure:"host_key_fingerprint,omitempty" flag:"host-key-fingerprint" desc:"The SHA256 fingerprint (as printed by ssh-keygen -l) the SIA gateway host key must match. Takes precedence over the known_hosts file."`
+	TrustOnFirstUse       bool   `json:"trust_on_first_use,omitempty" mapstructure:"trust_on_first_use,omitempty" flag:"trust-on-first-use" desc:"Accept the SIA gateway host key when it is not in the known_hosts file yet and record it there. Changed keys are still rejected." default:"false"`
+	InsecureIgnoreHostKey bool   `json:"insecure_ignore_host_key,omitempty" mapstructure:"insecure_ignore_host_key,omitempty" flag:"insecure-ignore-host-key" desc:"Skip verification of the SIA gateway host key. Not recommended outside of testing." default:"false"`
+	TimeoutSeconds        int    `json:"timeout_seconds,omitempty" mapstructure:"timeout_seconds,omitempty" flag:"timeout-seconds" desc:"Timeout in seconds for establishing the connection to the SIA gateway." default:"30"`
+}
+
+// IdsecSIASSHPTY defines the pseudo-terminal requested for an interactive SSH shell.
+type IdsecSIASSHPTY struct {
+	Term   string `json:"term,omitempty" mapstructure:"term,omitempty" desc:"The terminal type to request (e.g. xterm-256color)." default:"xterm-256color"`
+	Width  int    `json:"width,omitempty" mapstructure:"width,omitempty" desc:"The terminal width in columns." default:"80"`
+	Height int    `json:"height,omitempty" mapstructure:"height,omitempty" desc:"The terminal height in rows." default:"24"`
+}
@@ -9,12 +9,17 @@ package models
 //
 // The short-lived SSH key issued by the SSO service is never written to a
 // user-visible folder; it lives only for the duration of the spawned SSH
-// child process. Enable AllowCaching to reuse a previously-issued key from
+// child process, or only in memory when NativeClient is set. Enable AllowCaching to reuse a previously-issued key from
 // the SSO keyring cache rather than fetching a fresh one on every call.
 type IdsecSIASSHConnectExecution struct {
 	IdsecSIASSHBaseExecution `mapstructure:",squash"`
 	Command                  string   `json:"command,omitempty" mapstructure:"command,omitempty" flag:"command" desc:"Optional single command to execute on the remote host. If empty, an interactive terminal session is opened."`
 	ForceTTY                 bool     `json:"force_tty,omitempty" mapstructure:"force_tty,omitempty" flag:"force-tty" desc:"Force pseudo-terminal allocation (adds -t to the SSH client). Useful for single commands that require a TTY (e.g. sudo with password prompt)." default:"false"`
 	AllowCaching             bool     `json:"allow_caching,omitempty" mapstructure:"allow_caching,omitempty" flag:"allow-caching" desc:"Reuse a cached short-lived SSH key from the SSO keyring (when present and not expired) instead of fetching a fresh one." default:"false"`
-	ExtraArgs                []string `json:"extra_args,omitempty" mapstructure:"extra_args,omitempty" flag:"extra-args" desc:"Additional arguments passed through to the SSH client (e.g. -L, -o BatchMode=yes)."`
+	ExtraArgs                []string `json:"extra_args,omitempty" mapstructure:"extra_args,omitempty" flag:"extra-args" desc:"Additional arguments passed through to the SSH client (e.g. -L, -o BatchMode=yes). Not supported with native-client."`
+	NativeClient             bool     `json:"native_client,omitempty" mapstructure:"native_client,omitempty" flag:"native-client" desc:"Connect with the built-in SSH client instead of spawning the SSH executable." default:"false"`
+	KnownHostsFile           string   `json:"known_hosts_file,omitempty" mapstructure:"known_hosts_file,omitempty" flag:"known-hosts-file" desc:"The known_hosts file used by the native client to verify the SIA gateway host key. Defaults to ~/.ssh/known_hosts."`
+	HostKeyFingerprint       string   `json:"host_key_fingerprint,omitempty" mapstructure:"host_key_fingerprint,omitempty" flag:"host-key-fingerprint" desc:"The SHA256 fingerprint the SIA gateway host key must match with the native client. Takes precedence over the known_hosts file."`
+	TrustOnFirstUse          bool     `json:"trust_on_first_use,omitempty" mapstructure:"trust_on_first_use,omitempty" flag:"trust-on-first-use" desc:"Accept and record an unknown SIA gateway host key in the known_hosts file with the native client. Changed keys are still rejected." default:"false"`
+	InsecureIgnoreHostKey    bool     `json:"insecure_ignore_host_key,omitempty" mapstructure:"insecure_ignore_host_key,omitempty" flag:"insecure-ignore-host-key" desc:"Skip verification of the SIA gateway host key with the native client. Not recommended outside of testing." default:"false"`
 }
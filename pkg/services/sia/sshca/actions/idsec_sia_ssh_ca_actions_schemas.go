@@ -12,4 +12,7 @@ var ActionToSchemaMap = map[string]interface{}{
 	"install-public-key":      &siasshca.IdsecSIAInstallSSHPublicKey{},
 	"uninstall-public-key":    &siasshca.IdsecSIAUninstallSSHPublicKey{},
 	"is-public-key-installed": &siasshca.IdsecSIAIsSSHPublicKeyInstalled{},
+	"rotate-ca":               &siasshca.IdsecSIASSHCARotateCa{},
+	"rotation-status":         &siasshca.IdsecSIASSHCARotationStatus{},
+	"rollback-rotation":       &siasshca.IdsecSIASSHCARollbackRotation{},
 }
@@ -0,0 +1,497 @@
+package sshca
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	sshcamodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/sshca/models"
+)
+
+const (
+	defaultRotationConcurrency = 10
+)
+
+// RotateCa rotates the SSH CA across a fleet of hosts.
+//
+// The rotation generates a new CA key version, installs its public key on every host of the
+// inventory with bounded concurrency and verifies the installation. Only once every host is
+// verified is the previous CA deactivated, so hosts never stop trusting SIA in the middle of a
+// rotation. Progress is written to the state file after every host, and running the rotation
+// again with the same state file resumes it, retrying only the hosts that are not verified yet.
+// The generated CA is recorded in the state file as soon as it exists, so resuming an interrupted
+// rotation never generates a second CA. Host credentials are read from the inventory and never
+// written to the state file.
+func (s *IdsecSIASSHCAService) RotateCa(rotateCa *sshcamodels.IdsecSIASSHCARotateCa) (*sshcamodels.IdsecSIASSHCARotationState, error) {
+	inventory, err := loadRotationInventory(rotateCa.InventoryFile)
+	if err != nil {
+		return nil, err
+	}
+	state, err := loadOrCreateRotationState(rotateCa.StateFile)
+	if err != nil {
+		return nil, err
+	}
+	switch state.Phase {
+	case sshcamodels.RotationPhaseRollingBack, sshcamodels.RotationPhaseRolledBack:
+		return nil, fmt.Errorf("rotation in state file [%s] was rolled back, use a new state file to start a new rotation", rotateCa.StateFile)
+	case sshcamodels.RotationPhasePreviousCaDeactivated:
+		s.Logger.Info("Rotation in state file [%s] is already complete", rotateCa.StateFile)
+		return state, nil
+	}
+	hosts := mergeRotationHosts(state, inventory)
+	if err := saveRotationState(rotateCa.StateFile, state); err != nil {
+		return nil, err
+	}
+
+	if state.Phase == sshcamodels.RotationPhasePending || state.Phase == sshcamodels.RotationPhaseGeneratingNewCa {
+		if err := s.generateRotationCa(rotateCa.StateFile, state); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.rotateHosts(rotateCa, state, hosts); err != nil {
+		return nil, err
+	}
+	if state.Failed > 0 || state.Pending > 0 {
+		// Hosts added to the inventory after the new CA was installed everywhere reopen the install phase
+		state.Phase = sshcamodels.RotationPhaseNewCaGenerated
+		if err := saveRotationState(rotateCa.StateFile, state); err != nil {
+			return nil, err
+		}
+		if !rotateCa.DeactivateOnFailures {
+			s.Logger.Warning("Previous CA kept active, [%d] hosts failed and [%d] are pending, rerun the rotation to resume", state.Failed, state.Pending)
+			return state, nil
+		}
+		s.Logger.Warning("Deactivating previous CA although [%d] hosts failed and [%d] are pending", state.Failed, state.Pending)
+	} else {
+		state.Phase = sshcamodels.RotationPhaseInstalled
+		if err := saveRotationState(rotateCa.StateFile, state); err != nil {
+			return nil, err
+		}
+	}
+	if rotateCa.SkipDeactivate {
+		s.Logger.Info("New CA installed, skipping deactivation of the previous CA")
+		return state, nil
+	}
+	if err := s.DeactivatePreviousCa(); err != nil {
+		return nil, err
+	}
+	state.Phase = sshcamodels.RotationPhasePreviousCaDeactivated
+	if err := saveRotationState(rotateCa.StateFile, state); err != nil {
+		return nil, err
+	}
+	s.Logger.Info("SSH CA rotation completed on [%d] hosts", state.Verified)
+	return state, nil
+}
+
+// RotationStatus returns the per-host report of an SSH CA rotation from its state file.
+func (s *IdsecSIASSHCAService) RotationStatus(rotationStatus *sshcamodels.IdsecSIASSHCARotationStatus) (*sshcamodels.IdsecSIASSHCARotationState, error) {
+	return loadRotationState(rotationStatus.StateFile)
+}
+
+// RollbackRotation rolls back an SSH CA rotation at any phase. When the previous CA was already
+// deactivated, it is reactivated so hosts that still only trust it accept SIA connections again.
+// The new CA public key is then removed from every host the rotation attempted to install it on.
+// Hosts that cannot be reached keep the rollback open, and running it again with the same state
+// file retries only them. Once every host is cleaned up, the state file is marked as rolled back
+// and can no longer be resumed.
+func (s *IdsecSIASSHCAService) RollbackRotation(rollbackRotation *sshcamodels.IdsecSIASSHCARollbackRotation) (*sshcamodels.IdsecSIASSHCARotationState, error) {
+	state, err := loadRotationState(rollbackRotation.StateFile)
+	if err != nil {
+		return nil, err
+	}
+	switch state.Phase {
+	case sshcamodels.RotationPhaseRolledBack:
+		return state, nil
+	case sshcamodels.RotationPhasePreviousCaDeactivated:
+		if err := s.ReactivatePreviousCa(); err != nil {
+			return nil, err
+		}
+	case sshcamodels.RotationPhaseGeneratingNewCa:
+		// The rotation was interrupted while generating, find out whether the new CA exists
+		publicKey, err := s.PublicKey(nil)
+		if err != nil {
+			return nil, err
+		}
+		if publicKey != state.PreviousCaPublicKey {
+			state.NewCaPublicKey = publicKey
+		}
+	case sshcamodels.RotationPhaseRollingBack:
+		s.Logger.Info("Resuming rollback of rotation in state file [%s]", rollbackRotation.StateFile)
+	default:
+		s.Logger.Info("Previous CA was not deactivated, nothing to reactivate")
+	}
+	state.Phase = sshcamodels.RotationPhaseRollingBack
+	if err := saveRotationState(rollbackRotation.StateFile, state); err != nil {
+		return nil, err
+	}
+
+	if err := s.removeRotationCa(rollbackRotation, state); err != nil {
+		return nil, err
+	}
+	if state.Failed > 0 || state.Verified > 0 {
+		s.Logger.Warning("New CA could not be removed from [%d] hosts, rerun the rollback to retry", state.Failed+state.Verified)
+		return state, nil
+	}
+	state.Phase = sshcamodels.RotationPhaseRolledBack
+	if err := saveRotationState(rollbackRotation.StateFile, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// generateRotationCa generates the new CA of a rotation exactly once. The CA public key served
+// before generating is recorded first, so a rotation interrupted right after generating detects
+// the new CA on resume and records it instead of generating another one.
+func (s *IdsecSIASSHCAService) generateRotationCa(stateFile string, state *sshcamodels.IdsecSIASSHCARotationState) error {
+	publicKey, err := s.PublicKey(nil)
+	if err != nil {
+		return err
+	}
+	if state.Phase == sshcamodels.RotationPhasePending {
+		state.PreviousCaPublicKey = publicKey
+		state.Phase = sshcamodels.RotationPhaseGeneratingNewCa
+		if err := saveRotationState(stateFile, state); err != nil {
+			return err
+		}
+	} else if publicKey != state.PreviousCaPublicKey {
+		s.Logger.Info("New CA was already generated before the rotation was interrupted")
+		return recordRotationCa(stateFile, state, publicKey)
+	}
+	if err := s.GenerateNewCa(); err != nil {
+		return err
+	}
+	publicKey, err = s.PublicKey(nil)
+	if err != nil {
+		return err
+	}
+	if publicKey == state.PreviousCaPublicKey {
+		return errors.New("the CA public key did not change after generating a new CA")
+	}
+	return recordRotationCa(stateFile, state, publicKey)
+}
+
+func recordRotationCa(stateFile string, state *sshcamodels.IdsecSIASSHCARotationState, publicKey string) error {
+	state.NewCaPublicKey = publicKey
+	state.Phase = sshcamodels.RotationPhaseNewCaGenerated
+	return saveRotationState(stateFile, state)
+}
+
+// removeRotationCa removes the new CA public key from every host the rotation attempted to install it on.
+func (s *IdsecSIASSHCAService) removeRotationCa(rollbackRotation *sshcamodels.IdsecSIASSHCARollbackRotation, state *sshcamodels.IdsecSIASSHCARotationState) error {
+	touched := func(report *sshcamodels.IdsecSIASSHCARotationHostReport) bool {
+		return report.Attempts > 0 && report.Status != sshcamodels.RotationHostStatusRemoved
+	}
+	remaining := 0
+	for i := range state.Hosts {
+		if touched(&state.Hosts[i]) {
+			remaining++
+		}
+	}
+	if remaining == 0 {
+		return nil
+	}
+	if state.NewCaPublicKey == "" {
+		return fmt.Errorf("rotation state file [%s] does not record the new CA public key, remove it from the hosts manually", rollbackRotation.StateFile)
+	}
+	if rollbackRotation.InventoryFile == "" {
+		return fmt.Errorf("an inventory file is required to remove the new CA from [%d] hosts", remaining)
+	}
+	inventory, err := loadRotationInventory(rollbackRotation.InventoryFile)
+	if err != nil {
+		return err
+	}
+	hosts := map[string]sshcamodels.IdsecSIASSHCARotationHost{}
+	for _, host := range inventory.Hosts {
+		hosts[host.TargetMachine] = host
+	}
+	return s.forEachRotationHost(rollbackRotation.StateFile, state, hosts, rollbackRotation.Concurrency, false, touched,
+		func(host sshcamodels.IdsecSIASSHCARotationHost) (string, string) {
+			return s.removeRotationCaFromHost(&rollbackRotation.IdsecSIASSHCARotationHostDefaults, host, state.NewCaPublicKey)
+		})
+}
+
+// rotateHosts installs and verifies the new CA public key on every host that is not verified yet.
+func (s *IdsecSIASSHCAService) rotateHosts(rotateCa *sshcamodels.IdsecSIASSHCARotateCa, state *sshcamodels.IdsecSIASSHCARotationState, hosts map[string]sshcamodels.IdsecSIASSHCARotationHost) error {
+	pending := func(report *sshcamodels.IdsecSIASSHCARotationHostReport) bool {
+		return report.Status != sshcamodels.RotationHostStatusVerified
+	}
+	return s.forEachRotationHost(rotateCa.StateFile, state, hosts, rotateCa.Concurrency, true, pending,
+		func(host sshcamodels.IdsecSIASSHCARotationHost) (string, string) {
+			return s.rotateHost(&rotateCa.IdsecSIASSHCARotationHostDefaults, host)
+		})
+}
+
+// forEachRotationHost runs the operation with bounded concurrency on every host of the state selected
+// by the filter, recording the outcome of each host in the state file as soon as it is known.
+// Rotation attempts are counted per host, rollback operations are not.
+func (s *IdsecSIASSHCAService) forEachRotationHost(
+	stateFile string,
+	state *sshcamodels.IdsecSIASSHCARotationState,
+	hosts map[string]sshcamodels.IdsecSIASSHCARotationHost,
+	concurrency int,
+	countAttempts bool,
+	filter func(report *sshcamodels.IdsecSIASSHCARotationHostReport) bool,
+	operation func(host sshcamodels.IdsecSIASSHCARotationHost) (string, string),
+) error {
+	if concurrency <= 0 {
+		concurrency = defaultRotationConcurrency
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+	var errOnce sync.Once
+
+	sem := make(chan struct{}, concurrency)
+
+	for i := range state.Hosts {
+		report := &state.Hosts[i]
+		if !filter(report) {
+			continue
+		}
+		host, ok := hosts[report.TargetMachine]
+		if !ok {
+			s.Logger.Warning("Host [%s] is in the state file but not in the inventory, skipping it", report.TargetMachine)
+			continue
+		}
+		wg.Add(1)
+		go func(report *sshcamodels.IdsecSIASSHCARotationHostReport, host sshcamodels.IdsecSIASSHCARotationHost) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			status, message := operation(host)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if countAttempts {
+				report.Attempts++
+			}
+			report.Status = status
+			report.Message = message
+			report.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+			if err := saveRotationState(stateFile, state); err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+				})
+			}
+		}(report, host)
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+// rotationHostConnection resolves the connection parameters of a host, falling back to the defaults of the request.
+func rotationHostConnection(defaults *sshcamodels.IdsecSIASSHCARotationHostDefaults, host sshcamodels.IdsecSIASSHCARotationHost) (string, string, string, string) {
+	username, password, privateKeyPath, shell := host.Username, host.Password, host.PrivateKeyPath, host.Shell
+	if username == "" {
+		username = defaults.Username
+	}
+	if password == "" && host.PrivateKeyContents == "" && privateKeyPath == "" {
+		password, privateKeyPath = defaults.Password, defaults.PrivateKeyPath
+	}
+	if shell == "" {
+		shell = defaults.Shell
+	}
+	if shell == "" {
+		shell = defaultShellType
+	}
+	return username, password, privateKeyPath, shell
+}
+
+func (s *IdsecSIASSHCAService) removeRotationCaFromHost(defaults *sshcamodels.IdsecSIASSHCARotationHostDefaults, host sshcamodels.IdsecSIASSHCARotationHost, publicKey string) (string, string) {
+	username, password, privateKeyPath, shell := rotationHostConnection(defaults, host)
+	result, err := s.uninstallPublicKeyContent(&sshcamodels.IdsecSIAUninstallSSHPublicKey{
+		TargetMachine:      host.TargetMachine,
+		Username:           username,
+		Password:           password,
+		PrivateKeyPath:     privateKeyPath,
+		PrivateKeyContents: host.PrivateKeyContents,
+		Shell:              shell,
+		RetryCount:         defaults.RetryCount,
+		RetryDelay:         defaults.RetryDelay,
+	}, publicKey)
+	if err != nil {
+		s.Logger.Warning("Failed to remove new CA public key from [%s]: %v", host.TargetMachine, err)
+		return sshcamodels.RotationHostStatusFailed, fmt.Sprintf("rollback failed: %v", err)
+	}
+	return sshcamodels.RotationHostStatusRemoved, result.Message
+}
+
+func (s *IdsecSIASSHCAService) rotateHost(defaults *sshcamodels.IdsecSIASSHCARotationHostDefaults, host sshcamodels.IdsecSIASSHCARotationHost) (string, string) {
+	username, password, privateKeyPath, shell := rotationHostConnection(defaults, host)
+	_, err := s.InstallPublicKey(&sshcamodels.IdsecSIAInstallSSHPublicKey{
+		TargetMachine:      host.TargetMachine,
+		Username:           username,
+		Password:           password,
+		PrivateKeyPath:     privateKeyPath,
+		PrivateKeyContents: host.PrivateKeyContents,
+		Shell:              shell,
+		RetryCount:         defaults.RetryCount,
+		RetryDelay:         defaults.RetryDelay,
+	})
+	if err != nil {
+		s.Logger.Warning("Failed to install public key on [%s]: %v", host.TargetMachine, err)
+		return sshcamodels.RotationHostStatusFailed, fmt.Sprintf("install failed: %v", err)
+	}
+	verification, err := s.IsPublicKeyInstalled(&sshcamodels.IdsecSIAIsSSHPublicKeyInstalled{
+		TargetMachine:      host.TargetMachine,
+		Username:           username,
+		Password:           password,
+		PrivateKeyPath:     privateKeyPath,
+		PrivateKeyContents: host.PrivateKeyContents,
+		Shell:              shell,
+		RetryCount:         defaults.RetryCount,
+		RetryDelay:         defaults.RetryDelay,
+	})
+	if err != nil {
+		s.Logger.Warning("Failed to verify public key on [%s]: %v", host.TargetMachine, err)
+		return sshcamodels.RotationHostStatusFailed, fmt.Sprintf("verification failed: %v", err)
+	}
+	if !verification.Result {
+		return sshcamodels.RotationHostStatusFailed, "verification failed: " + verification.Message
+	}
+	return sshcamodels.RotationHostStatusVerified, verification.Message
+}
+
+// mergeRotationHosts adds the inventory hosts missing from the state as pending, refreshes the
+// state counters and returns the inventory hosts by target machine.
+func mergeRotationHosts(state *sshcamodels.IdsecSIASSHCARotationState, inventory *sshcamodels.IdsecSIASSHCARotationInventory) map[string]sshcamodels.IdsecSIASSHCARotationHost {
+	known := map[string]bool{}
+	for _, report := range state.Hosts {
+		known[report.TargetMachine] = true
+	}
+	hosts := map[string]sshcamodels.IdsecSIASSHCARotationHost{}
+	for _, host := range inventory.Hosts {
+		hosts[host.TargetMachine] = host
+		if known[host.TargetMachine] {
+			continue
+		}
+		known[host.TargetMachine] = true
+		state.Hosts = append(state.Hosts, sshcamodels.IdsecSIASSHCARotationHostReport{
+			TargetMachine: host.TargetMachine,
+			Status:        sshcamodels.RotationHostStatusPending,
+		})
+	}
+	countRotationHosts(state)
+	return hosts
+}
+
+func countRotationHosts(state *sshcamodels.IdsecSIASSHCARotationState) {
+	state.Verified, state.Failed, state.Pending, state.Removed = 0, 0, 0, 0
+	for _, report := range state.Hosts {
+		switch report.Status {
+		case sshcamodels.RotationHostStatusVerified:
+			state.Verified++
+		case sshcamodels.RotationHostStatusFailed:
+			state.Failed++
+		case sshcamodels.RotationHostStatusRemoved:
+			state.Removed++
+		default:
+			state.Pending++
+		}
+	}
+}
+
+func loadRotationInventory(path string) (*sshcamodels.IdsecSIASSHCARotationInventory, error) {
+	content, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inventory [%s]: %w", path, err)
+	}
+	// YAML is a superset of JSON, so a single decoder handles both formats
+	var document interface{}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse inventory [%s]: %w", path, err)
+	}
+	documentJSON, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse inventory [%s]: %w", path, err)
+	}
+	var inventory sshcamodels.IdsecSIASSHCARotationInventory
+	if err := json.Unmarshal(documentJSON, &inventory); err != nil {
+		return nil, fmt.Errorf("failed to parse inventory [%s]: %w", path, err)
+	}
+	if len(inventory.Hosts) == 0 {
+		return nil, fmt.Errorf("inventory [%s] has no hosts", path)
+	}
+	seen := map[string]bool{}
+	for _, host := range inventory.Hosts {
+		if strings.TrimSpace(host.TargetMachine) == "" {
+			return nil, fmt.Errorf("inventory [%s] has a host without a target machine", path)
+		}
+		if seen[host.TargetMachine] {
+			return nil, fmt.Errorf("inventory [%s] lists host [%s] more than once", path, host.TargetMachine)
+		}
+		seen[host.TargetMachine] = true
+	}
+	return &inventory, nil
+}
+
+func loadRotationState(path string) (*sshcamodels.IdsecSIASSHCARotationState, error) {
+	content, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rotation state [%s]: %w", path, err)
+	}
+	var state sshcamodels.IdsecSIASSHCARotationState
+	if err := json.Unmarshal(content, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse rotation state [%s]: %w", path, err)
+	}
+	if state.Version != sshcamodels.IdsecSIASSHCARotationStateVersion {
+		return nil, fmt.Errorf("unsupported rotation state version [%d], expected [%d]", state.Version, sshcamodels.IdsecSIASSHCARotationStateVersion)
+	}
+	return &state, nil
+}
+
+func loadOrCreateRotationState(path string) (*sshcamodels.IdsecSIASSHCARotationState, error) {
+	state, err := loadRotationState(path)
+	if err == nil {
+		return state, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	return &sshcamodels.IdsecSIASSHCARotationState{
+		Version:   sshcamodels.IdsecSIASSHCARotationStateVersion,
+		Phase:     sshcamodels.RotationPhasePending,
+		StartedAt: now,
+		UpdatedAt: now,
+		Hosts:     []sshcamodels.IdsecSIASSHCARotationHostReport{},
+	}, nil
+}
+
+// saveRotationState writes the state file atomically so an interrupted rotation always leaves a readable state.
+func saveRotationState(path string, state *sshcamodels.IdsecSIASSHCARotationState) error {
+	countRotationHosts(state)
+	state.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tempFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write rotation state [%s]: %w", path, err)
+	}
+	defer func() {
+		_ = os.Remove(tempFile.Name())
+	}()
+	if _, err := tempFile.Write(content); err != nil {
+		_ = tempFile.Close()
+		return fmt.Errorf("failed to write rotation state [%s]: %w", path, err)
+	}
+	if err := tempFile.Close(); err != nil {
+		return fmt.Errorf("failed to write rotation state [%s]: %w", path, err)
+	}
+	if err := os.Rename(tempFile.Name(), path); err != nil {
+		return fmt.Errorf("failed to write rotation state [%s]: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,379 @@
+package sshca
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cyberark/idsec-sdk-golang/pkg/common/connections"
+	connectionsmodels "github.com/cyberark/idsec-sdk-golang/pkg/models/common/connections"
+	sshcamodels "github.com/cyberark/idsec-sdk-golang/pkg/services/sia/sshca/models"
+)
+
+const rotationInventoryYAML = `hosts:
+  - target_machine: host-1
+  - target_machine: host-2
+  - target_machine: host-3
+    username: root
+    shell: kornShell
+`
+
+const (
+	rotationPreviousCaPublicKey = "ssh-rsa PREVIOUS"
+	rotationNewCaPublicKey      = "ssh-rsa NEW"
+)
+
+// rotationTestService creates a test service whose hosts in failingHosts refuse SSH connections,
+// and records the CA rotation API calls made. The served CA public key changes once a new CA is
+// generated, or right away when caGenerated is set.
+func rotationTestService(failingHosts map[string]bool, caGenerated bool) (*IdsecSIASSHCAService, *[]string, *[]string) {
+	service, apiCalls, connected, _ := rotationTestServiceWithCommands(failingHosts, caGenerated)
+	return service, apiCalls, connected
+}
+
+// rotationTestServiceWithCommands is rotationTestService that also records the commands run on the hosts.
+func rotationTestServiceWithCommands(failingHosts map[string]bool, caGenerated bool) (*IdsecSIASSHCAService, *[]string, *[]string, *[]string) {
+	var mu sync.Mutex
+	var apiCalls []string
+	var connected []string
+	var commands []string
+	service := createTestService()
+	service.doGet = func(ctx context.Context, path string, params interface{}) (*http.Response, error) {
+		if path == publicKeyScriptURL {
+			scriptB64 := base64.StdEncoding.EncodeToString([]byte("#!/bin/bash\necho 'install script'"))
+			return NewMockResponse(http.StatusOK, fmt.Sprintf(`{"base64_cmd": "%s"}`, scriptB64)), nil
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if caGenerated {
+			return NewMockResponse(http.StatusOK, rotationNewCaPublicKey), nil
+		}
+		return NewMockResponse(http.StatusOK, rotationPreviousCaPublicKey), nil
+	}
+	service.doPost = func(ctx context.Context, path string, body interface{}) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		apiCalls = append(apiCalls, path)
+		if path == generateNewCAKeyURL {
+			caGenerated = true
+			return NewMockResponse(http.StatusCreated, ""), nil
+		}
+		return NewMockResponse(http.StatusOK, ""), nil
+	}
+	service.newConnection = func() connections.IdsecConnection {
+		return &MockSSHConnection{
+			ConnectFunc: func(details *connectionsmodels.IdsecConnectionDetails) error {
+				mu.Lock()
+				defer mu.Unlock()
+				connected = append(connected, fmt.Sprintf("%s:%s", details.Address, details.Credentials.User))
+				if failingHosts[details.Address] {
+					return fmt.Errorf("connection refused")
+				}
+				return nil
+			},
+			RunCommandFunc: func(cmd *connectionsmodels.IdsecConnectionCommand) (*connectionsmodels.IdsecConnectionResult, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				commands = append(commands, cmd.Command)
+				return &connectionsmodels.IdsecConnectionResult{RC: 0}, nil
+			},
+		}
+	}
+	return service, &apiCalls, &connected, &commands
+}
+
+func writeRotationInventory(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	inventoryFile := filepath.Join(dir, "inventory.yaml")
+	if err := os.WriteFile(inventoryFile, []byte(rotationInventoryYAML), 0600); err != nil {
+		t.Fatalf("Failed to write inventory: %v", err)
+	}
+	return inventoryFile, filepath.Join(dir, "rotation.json")
+}
+
+func TestRotateCa_Success(t *testing.T) {
+	inventoryFile, stateFile := writeRotationInventory(t)
+	service, apiCalls, connected := rotationTestService(nil, false)
+
+	state, err := service.RotateCa(&sshcamodels.IdsecSIASSHCARotateCa{
+		InventoryFile: inventoryFile,
+		StateFile:     stateFile,
+		IdsecSIASSHCARotationHostDefaults: sshcamodels.IdsecSIASSHCARotationHostDefaults{
+			Concurrency: 2,
+			Username:    "admin",
+			Password:    "secret",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if state.Phase != sshcamodels.RotationPhasePreviousCaDeactivated {
+		t.Errorf("Expected phase %s, got %s", sshcamodels.RotationPhasePreviousCaDeactivated, state.Phase)
+	}
+	if state.Verified != 3 || state.Failed != 0 || state.Pending != 0 {
+		t.Errorf("Expected 3 verified hosts, got %+v", state)
+	}
+	if state.PreviousCaPublicKey != rotationPreviousCaPublicKey || state.NewCaPublicKey != rotationNewCaPublicKey {
+		t.Errorf("Expected the previous and new CA public keys to be recorded, got %+v", state)
+	}
+	expectedCalls := []string{generateNewCAKeyURL, deactivatePreviousCAKeyURL}
+	if !reflect.DeepEqual(*apiCalls, expectedCalls) {
+		t.Errorf("Expected API calls %v, got %v", expectedCalls, *apiCalls)
+	}
+	// Each host is connected twice, once to install and once to verify
+	if len(*connected) != 6 {
+		t.Errorf("Expected 6 connections, got %v", *connected)
+	}
+	for _, connection := range *connected {
+		if connection == "host-3:admin" {
+			t.Errorf("Expected host-3 to use its own username, got %s", connection)
+		}
+	}
+	saved, err := service.RotationStatus(&sshcamodels.IdsecSIASSHCARotationStatus{StateFile: stateFile})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if saved.Phase != state.Phase || len(saved.Hosts) != 3 {
+		t.Errorf("Expected saved state to match, got %+v", saved)
+	}
+	content, err := os.ReadFile(stateFile)
+	if err != nil {
+		t.Fatalf("Failed to read state file: %v", err)
+	}
+	if strings.Contains(string(content), "secret") {
+		t.Errorf("Expected state file without credentials, got %s", content)
+	}
+}
+
+func TestRotateCa_FailedHostKeepsPreviousCaAndResumes(t *testing.T) {
+	inventoryFile, stateFile := writeRotationInventory(t)
+	service, apiCalls, _ := rotationTestService(map[string]bool{"host-2": true}, false)
+	rotateCa := &sshcamodels.IdsecSIASSHCARotateCa{
+		InventoryFile:                     inventoryFile,
+		StateFile:                         stateFile,
+		IdsecSIASSHCARotationHostDefaults: sshcamodels.IdsecSIASSHCARotationHostDefaults{Username: "admin"},
+	}
+
+	state, err := service.RotateCa(rotateCa)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if state.Phase != sshcamodels.RotationPhaseNewCaGenerated {
+		t.Errorf("Expected phase %s, got %s", sshcamodels.RotationPhaseNewCaGenerated, state.Phase)
+	}
+	if state.Verified != 2 || state.Failed != 1 {
+		t.Errorf("Expected 2 verified and 1 failed hosts, got %+v", state)
+	}
+	if !reflect.DeepEqual(*apiCalls, []string{generateNewCAKeyURL}) {
+		t.Errorf("Expected previous CA to stay active, got API calls %v", *apiCalls)
+	}
+
+	// Resume once the host is reachable, only the failed host is retried
+	resumed, resumedCalls, connected := rotationTestService(nil, true)
+	state, err = resumed.RotateCa(rotateCa)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if state.Phase != sshcamodels.RotationPhasePreviousCaDeactivated || state.Verified != 3 {
+		t.Errorf("Expected completed rotation, got %+v", state)
+	}
+	if !reflect.DeepEqual(*resumedCalls, []string{deactivatePreviousCAKeyURL}) {
+		t.Errorf("Expected only deactivation on resume, got API calls %v", *resumedCalls)
+	}
+	if !reflect.DeepEqual(*connected, []string{"host-2:admin", "host-2:admin"}) {
+		t.Errorf("Expected only host-2 to be retried, got %v", *connected)
+	}
+	for _, host := range state.Hosts {
+		if host.TargetMachine == "host-2" && host.Attempts != 2 {
+			t.Errorf("Expected 2 attempts on host-2, got %d", host.Attempts)
+		}
+	}
+}
+
+func TestRotateCa_SkipDeactivate(t *testing.T) {
+	inventoryFile, stateFile := writeRotationInventory(t)
+	service, apiCalls, _ := rotationTestService(nil, false)
+
+	state, err := service.RotateCa(&sshcamodels.IdsecSIASSHCARotateCa{
+		InventoryFile:  inventoryFile,
+		StateFile:      stateFile,
+		SkipDeactivate: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if state.Phase != sshcamodels.RotationPhaseInstalled {
+		t.Errorf("Expected phase %s, got %s", sshcamodels.RotationPhaseInstalled, state.Phase)
+	}
+	if !reflect.DeepEqual(*apiCalls, []string{generateNewCAKeyURL}) {
+		t.Errorf("Expected no deactivation, got API calls %v", *apiCalls)
+	}
+}
+
+func TestRollbackRotation(t *testing.T) {
+	inventoryFile, stateFile := writeRotationInventory(t)
+	service, apiCalls, _, commands := rotationTestServiceWithCommands(nil, false)
+	rotateCa := &sshcamodels.IdsecSIASSHCARotateCa{InventoryFile: inventoryFile, StateFile: stateFile}
+	if _, err := service.RotateCa(rotateCa); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	*commands = nil
+
+	state, err := service.RollbackRotation(&sshcamodels.IdsecSIASSHCARollbackRotation{StateFile: stateFile, InventoryFile: inventoryFile})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if state.Phase != sshcamodels.RotationPhaseRolledBack {
+		t.Errorf("Expected phase %s, got %s", sshcamodels.RotationPhaseRolledBack, state.Phase)
+	}
+	if state.Removed != 3 {
+		t.Errorf("Expected the new CA to be removed from 3 hosts, got %+v", state)
+	}
+	expectedCalls := []string{generateNewCAKeyURL, deactivatePreviousCAKeyURL, reactivatePreviousCAKeyURL}
+	if !reflect.DeepEqual(*apiCalls, expectedCalls) {
+		t.Errorf("Expected API calls %v, got %v", expectedCalls, *apiCalls)
+	}
+	for _, command := range *commands {
+		if strings.Contains(command, rotationPreviousCaPublicKey) {
+			t.Errorf("Expected only the new CA public key to be removed, got %s", command)
+		}
+	}
+	if _, err := service.RotateCa(rotateCa); err == nil {
+		t.Errorf("Expected error resuming a rolled back rotation, got nil")
+	}
+}
+
+func TestRollbackRotation_RemovesNewCaBeforeDeactivation(t *testing.T) {
+	inventoryFile, stateFile := writeRotationInventory(t)
+	service, apiCalls, _ := rotationTestService(map[string]bool{"host-2": true}, false)
+	rotateCa := &sshcamodels.IdsecSIASSHCARotateCa{InventoryFile: inventoryFile, StateFile: stateFile}
+	if _, err := service.RotateCa(rotateCa); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	rollbackRotation := &sshcamodels.IdsecSIASSHCARollbackRotation{StateFile: stateFile, InventoryFile: inventoryFile}
+
+	// host-2 is still unreachable, so the rollback stays open
+	state, err := service.RollbackRotation(rollbackRotation)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if state.Phase != sshcamodels.RotationPhaseRollingBack || state.Removed != 2 || state.Failed != 1 {
+		t.Errorf("Expected an open rollback with 2 removed and 1 failed hosts, got %+v", state)
+	}
+	if !reflect.DeepEqual(*apiCalls, []string{generateNewCAKeyURL}) {
+		t.Errorf("Expected the previous CA to stay untouched, got API calls %v", *apiCalls)
+	}
+
+	// Rerunning once the host is reachable only retries it
+	resumed, _, connected := rotationTestService(nil, true)
+	state, err = resumed.RollbackRotation(rollbackRotation)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if state.Phase != sshcamodels.RotationPhaseRolledBack || state.Removed != 3 {
+		t.Errorf("Expected a completed rollback, got %+v", state)
+	}
+	if !reflect.DeepEqual(*connected, []string{"host-2:"}) {
+		t.Errorf("Expected only host-2 to be retried, got %v", *connected)
+	}
+}
+
+func TestRollbackRotation_RequiresInventoryOnceInstalled(t *testing.T) {
+	inventoryFile, stateFile := writeRotationInventory(t)
+	service, _, _ := rotationTestService(nil, false)
+	if _, err := service.RotateCa(&sshcamodels.IdsecSIASSHCARotateCa{InventoryFile: inventoryFile, StateFile: stateFile, SkipDeactivate: true}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.RollbackRotation(&sshcamodels.IdsecSIASSHCARollbackRotation{StateFile: stateFile}); err == nil {
+		t.Errorf("Expected error rolling back without an inventory, got nil")
+	}
+}
+
+func TestRotateCa_ResumesInterruptedGenerationWithoutGeneratingAgain(t *testing.T) {
+	inventoryFile, stateFile := writeRotationInventory(t)
+	state, err := loadOrCreateRotationState(stateFile)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// The previous run recorded the previous CA and was interrupted right after generating
+	state.Phase = sshcamodels.RotationPhaseGeneratingNewCa
+	state.PreviousCaPublicKey = rotationPreviousCaPublicKey
+	if err := saveRotationState(stateFile, state); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	service, apiCalls, _ := rotationTestService(nil, true)
+
+	state, err = service.RotateCa(&sshcamodels.IdsecSIASSHCARotateCa{InventoryFile: inventoryFile, StateFile: stateFile})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if state.NewCaPublicKey != rotationNewCaPublicKey || state.Phase != sshcamodels.RotationPhasePreviousCaDeactivated {
+		t.Errorf("Expected the existing new CA to be recorded and the rotation completed, got %+v", state)
+	}
+	if !reflect.DeepEqual(*apiCalls, []string{deactivatePreviousCAKeyURL}) {
+		t.Errorf("Expected no second CA generation, got API calls %v", *apiCalls)
+	}
+}
+
+func TestRotateCa_RecordsNewCaRightAfterGeneration(t *testing.T) {
+	inventoryFile, stateFile := writeRotationInventory(t)
+	service, _, _ := rotationTestService(map[string]bool{"host-1": true, "host-2": true, "host-3": true}, false)
+
+	if _, err := service.RotateCa(&sshcamodels.IdsecSIASSHCARotateCa{InventoryFile: inventoryFile, StateFile: stateFile}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	saved, err := loadRotationState(stateFile)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if saved.Phase != sshcamodels.RotationPhaseNewCaGenerated || saved.NewCaPublicKey != rotationNewCaPublicKey {
+		t.Errorf("Expected the generated CA to be saved, got %+v", saved)
+	}
+}
+
+func TestLoadRotationInventory(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expectedHosts int
+		expectedError bool
+	}{
+		{name: "success_yaml", content: rotationInventoryYAML, expectedHosts: 3},
+		{name: "success_json", content: `{"hosts": [{"target_machine": "host-1"}]}`, expectedHosts: 1},
+		{name: "error_no_hosts", content: `hosts: []`, expectedError: true},
+		{name: "error_missing_target", content: "hosts:\n  - username: admin\n", expectedError: true},
+		{name: "error_duplicate_host", content: "hosts:\n  - target_machine: a\n  - target_machine: a\n", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "inventory.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatalf("Failed to write inventory: %v", err)
+			}
+			inventory, err := loadRotationInventory(path)
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("Expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(inventory.Hosts) != tt.expectedHosts {
+				t.Errorf("Expected %d hosts, got %d", tt.expectedHosts, len(inventory.Hosts))
+			}
+		})
+	}
+}
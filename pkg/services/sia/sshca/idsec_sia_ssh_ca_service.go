@@ -683,7 +683,11 @@ func (s *IdsecSIASSHCAService) UninstallPublicKey(uninstallPublicKey *sshcamodel
 	if err != nil {
 		return nil, err
 	}
+	return s.uninstallPublicKeyContent(uninstallPublicKey, publicKey)
+}
 
+// uninstallPublicKeyContent uninstalls the given public key from the target machine.
+func (s *IdsecSIASSHCAService) uninstallPublicKeyContent(uninstallPublicKey *sshcamodels.IdsecSIAUninstallSSHPublicKey, publicKey string) (*sshcamodels.IdsecSIASSHPublicKeyOperationResult, error) {
 	// Choose uninstall script based on shell type
 	var uninstallScript string
 	if uninstallPublicKey.Shell == "kornShell" {
@@ -0,0 +1,97 @@
+package models
+
+// IdsecSIASSHCARotationStateVersion is the current version of the SSH CA rotation state file format.
+const IdsecSIASSHCARotationStateVersion = 1
+
+// Possible phases of an SSH CA rotation.
+const (
+	RotationPhasePending               = "pending"
+	RotationPhaseGeneratingNewCa       = "generating_new_ca"
+	RotationPhaseNewCaGenerated        = "new_ca_generated"
+	RotationPhaseInstalled             = "installed"
+	RotationPhasePreviousCaDeactivated = "previous_ca_deactivated"
+	RotationPhaseRollingBack           = "rolling_back"
+	RotationPhaseRolledBack            = "rolled_back"
+)
+
+// Possible statuses of a host during an SSH CA rotation.
+const (
+	RotationHostStatusPending  = "pending"
+	RotationHostStatusVerified = "verified"
+	RotationHostStatusFailed   = "failed"
+	RotationHostStatusRemoved  = "removed"
+)
+
+// IdsecSIASSHCARotationHost is a struct that represents a single host of an SSH CA rotation inventory.
+// Empty connection fields fall back to the defaults of the rotation request.
+type IdsecSIASSHCARotationHost struct {
+	TargetMachine      string `json:"target_machine" mapstructure:"target_machine" desc:"The target machine on which to install the SSH public key."`
+	Username           string `json:"username,omitempty" mapstructure:"username,omitempty" desc:"The username to use to connect to the target machine via SSH."`
+	Password           string `json:"password,omitempty" mapstructure:"password,omitempty" desc:"The password to use to connect to the target machine via SSH."`
+	PrivateKeyPath     string `json:"private_key_path,omitempty" mapstructure:"private_key_path,omitempty" desc:"The private key file path to use to connect to the target machine via SSH."`
+	PrivateKeyContents string `json:"private_key_contents,omitempty" mapstructure:"private_key_contents,omitempty" desc:"The private key contents to use to connect to the target machine via SSH."`
+	Shell              string `json:"shell,omitempty" mapstructure:"shell,omitempty" desc:"The shell to use on the target machine (bash, kornShell)." choices:"bash,kornShell"`
+}
+
+// IdsecSIASSHCARotationInventory is a struct that represents the inventory of hosts of an SSH CA rotation.
+type IdsecSIASSHCARotationInventory struct {
+	Hosts []IdsecSIASSHCARotationHost `json:"hosts" mapstructure:"hosts" desc:"The hosts that must trust the new SSH CA."`
+}
+
+// IdsecSIASSHCARotationHostDefaults is a struct that represents the defaults used to connect to the hosts of an SSH CA rotation.
+type IdsecSIASSHCARotationHostDefaults struct {
+	Concurrency    int    `json:"concurrency,omitempty" mapstructure:"concurrency,omitempty" flag:"concurrency" desc:"The maximum number of hosts updated concurrently." default:"10"`
+	Username       string `json:"username,omitempty" mapstructure:"username,omitempty" flag:"username" desc:"The default username to use to connect to the hosts via SSH."`
+	Password       string `json:"password,omitempty" mapstructure:"password,omitempty" flag:"password" desc:"The default password to use to connect to the hosts via SSH."`
+	PrivateKeyPath string `json:"private_key_path,omitempty" mapstructure:"private_key_path,omitempty" flag:"private-key-path" desc:"The default private key file path to use to connect to the hosts via SSH."`
+	Shell          string `json:"shell,omitempty" mapstructure:"shell,omitempty" flag:"shell" desc:"The default shell to use on the hosts (bash, kornShell)." default:"bash" choices:"bash,kornShell"`
+	RetryCount     int    `json:"retry_count,omitempty" mapstructure:"retry_count,omitempty" flag:"retry-count" desc:"The number of times to retry to connect to a host, if it fails." default:"3"`
+	RetryDelay     int    `json:"retry_delay,omitempty" mapstructure:"retry_delay,omitempty" flag:"retry-delay" desc:"The delay (in seconds) between retries." default:"5"`
+}
+
+// IdsecSIASSHCARotateCa is a struct that represents the parameters of an orchestrated SSH CA rotation across a host fleet.
+type IdsecSIASSHCARotateCa struct {
+	InventoryFile                     string `json:"inventory_file" mapstructure:"inventory_file" flag:"inventory-file" desc:"The JSON or YAML inventory file listing the hosts to rotate." validate:"required"`
+	StateFile                         string `json:"state_file" mapstructure:"state_file" flag:"state-file" desc:"The rotation state file. An existing state file resumes the rotation where it stopped." validate:"required"`
+	IdsecSIASSHCARotationHostDefaults `mapstructure:",squash"`
+	SkipDeactivate                    bool `json:"skip_deactivate,omitempty" mapstructure:"skip_deactivate,omitempty" flag:"skip-deactivate" desc:"Install and verify the new CA on all hosts without deactivating the previous CA." default:"false"`
+	DeactivateOnFailures              bool `json:"deactivate_on_failures,omitempty" mapstructure:"deactivate_on_failures,omitempty" flag:"deactivate-on-failures" desc:"Deactivate the previous CA even if some hosts failed verification. Failed hosts will reject SIA connections." default:"false"`
+}
+
+// IdsecSIASSHCARotationStatus is a struct that represents the request for the status of an SSH CA rotation.
+type IdsecSIASSHCARotationStatus struct {
+	StateFile string `json:"state_file" mapstructure:"state_file" flag:"state-file" desc:"The rotation state file." validate:"required"`
+}
+
+// IdsecSIASSHCARollbackRotation is a struct that represents the request for rolling back an SSH CA rotation.
+// The inventory is required to remove the new CA from the hosts the rotation already touched.
+type IdsecSIASSHCARollbackRotation struct {
+	StateFile                         string `json:"state_file" mapstructure:"state_file" flag:"state-file" desc:"The rotation state file." validate:"required"`
+	InventoryFile                     string `json:"inventory_file,omitempty" mapstructure:"inventory_file,omitempty" flag:"inventory-file" desc:"The JSON or YAML inventory file listing the hosts of the rotation. Required once the new CA was installed on any host."`
+	IdsecSIASSHCARotationHostDefaults `mapstructure:",squash"`
+}
+
+// IdsecSIASSHCARotationHostReport is a struct that represents the rotation outcome of a single host.
+type IdsecSIASSHCARotationHostReport struct {
+	TargetMachine string `json:"target_machine" mapstructure:"target_machine" desc:"The target machine."`
+	Status        string `json:"status" mapstructure:"status" desc:"The rotation status of the host." choices:"pending,verified,failed,removed"`
+	Message       string `json:"message,omitempty" mapstructure:"message,omitempty" desc:"Details about the last operation on the host."`
+	Attempts      int    `json:"attempts" mapstructure:"attempts" desc:"The number of rotation attempts made on the host."`
+	UpdatedAt     string `json:"updated_at,omitempty" mapstructure:"updated_at,omitempty" desc:"The time the host status last changed (RFC3339)."`
+}
+
+// IdsecSIASSHCARotationState is a struct that represents the resumable state and report of an SSH CA rotation.
+// It never holds host credentials.
+type IdsecSIASSHCARotationState struct {
+	Version             int                               `json:"version" mapstructure:"version" desc:"The version of the state file format."`
+	Phase               string                            `json:"phase" mapstructure:"phase" desc:"The rotation phase." choices:"pending,generating_new_ca,new_ca_generated,installed,previous_ca_deactivated,rolling_back,rolled_back"`
+	PreviousCaPublicKey string                            `json:"previous_ca_public_key,omitempty" mapstructure:"previous_ca_public_key,omitempty" desc:"The CA public key served before the new CA was generated."`
+	NewCaPublicKey      string                            `json:"new_ca_public_key,omitempty" mapstructure:"new_ca_public_key,omitempty" desc:"The public key of the CA generated by the rotation, identifying it on resume and rollback."`
+	StartedAt           string                            `json:"started_at" mapstructure:"started_at" desc:"The time the rotation started (RFC3339)."`
+	UpdatedAt           string                            `json:"updated_at" mapstructure:"updated_at" desc:"The time the state last changed (RFC3339)."`
+	Verified            int                               `json:"verified" mapstructure:"verified" desc:"The number of hosts verified to trust the new CA."`
+	Failed              int                               `json:"failed" mapstructure:"failed" desc:"The number of hosts that failed."`
+	Pending             int                               `json:"pending" mapstructure:"pending" desc:"The number of hosts not processed yet."`
+	Removed             int                               `json:"removed,omitempty" mapstructure:"removed,omitempty" desc:"The number of hosts the new CA was removed from by a rollback."`
+	Hosts               []IdsecSIASSHCARotationHostReport `json:"hosts" mapstructure:"hosts" desc:"The per-host report."`
+}
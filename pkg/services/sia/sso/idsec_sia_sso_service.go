@@ -142,16 +142,26 @@ func (s *IdsecSIASSOService) saveToCache(result *ssomodels.IdsecSIASSOAcquireTok
 	)
 }
 
+// userBaseName returns the user part of the authenticated username, used to name generated files.
+func (s *IdsecSIASSOService) userBaseName() (string, error) {
+	parsedToken, _, err := new(jwt.Parser).ParseUnverified(s.ISPClient().GetToken(), jwt.MapClaims{})
+	if err != nil {
+		return "", err
+	}
+	claims := parsedToken.Claims.(jwt.MapClaims)
+	return strings.Split(claims["unique_name"].(string), "@")[0], nil
+}
+
 func (s *IdsecSIASSOService) outputClientCertificate(folder string, outputFormat string, result *ssomodels.IdsecSIASSOAcquireTokenResponse) error {
 	folderPath := common.ExpandFolder(folder)
-	parsedToken, _, err := new(jwt.Parser).ParseUnverified(s.ISPClient().GetToken(), jwt.MapClaims{})
+	baseName, err := s.userBaseName()
 	if err != nil {
 		return err
 	}
-	claims := parsedToken.Claims.(jwt.MapClaims)
-	baseName := strings.Split(claims["unique_name"].(string), "@")[0]
-	clientCertificate := result.Token["client_certificate"].(string)
-	privateKey := result.Token["private_key"].(string)
+	clientCertificate, privateKey, ok := clientCertificateFromToken(result)
+	if !ok {
+		return errors.New("short lived client certificate response is missing the certificate or private key")
+	}
 
 	switch outputFormat {
 	case ssomodels.Raw:
@@ -211,12 +221,10 @@ func (s *IdsecSIASSOService) saveOracleSSOWallet(folder string, unzipWallet bool
 		}
 	}
 	if !unzipWallet {
-		parsedToken, _, err := new(jwt.Parser).ParseUnverified(s.ISPClient().GetToken(), jwt.MapClaims{})
+		baseName, err := s.userBaseName()
 		if err != nil {
 			return err
 		}
-		claims := parsedToken.Claims.(jwt.MapClaims)
-		baseName := strings.Split(claims["unique_name"].(string), "@")[0]
 		err = os.WriteFile(filepath.Join(folderPath, baseName+"_wallet.zip"), wallet, 0644)
 		if err != nil {
 			return err
@@ -261,12 +269,10 @@ func (s *IdsecSIASSOService) saveOracleSSOWallet(folder string, unzipWallet bool
 
 func (s *IdsecSIASSOService) saveOraclePEMWallet(folder string, result *ssomodels.IdsecSIASSOAcquireTokenResponse) error {
 	folderPath := common.ExpandFolder(folder)
-	parsedToken, _, err := new(jwt.Parser).ParseUnverified(s.ISPClient().GetToken(), jwt.MapClaims{})
+	baseName, err := s.userBaseName()
 	if err != nil {
 		return err
 	}
-	claims := parsedToken.Claims.(jwt.MapClaims)
-	baseName := strings.Split(claims["unique_name"].(string), "@")[0]
 	pemWallet, err := base64.StdEncoding.DecodeString(result.Token["pem_wallet"].(string))
 	if err != nil {
 		return err
@@ -284,36 +290,59 @@ func (s *IdsecSIASSOService) saveOraclePEMWallet(folder string, result *ssomodel
 	return nil
 }
 
-func (s *IdsecSIASSOService) saveRDPFile(getShortLivedRDPFile *ssomodels.IdsecSIASSOGetShortLivedRDPFile, result *ssomodels.IdsecSIASSOAcquireTokenResponse) error {
-	folderPath := common.ExpandFolder(getShortLivedRDPFile.Folder)
+func (s *IdsecSIASSOService) saveRDPFile(folder string, rdpFile *ssomodels.IdsecSIASSOShortLivedRDPFile) error {
+	folderPath := common.ExpandFolder(folder)
 	if _, err := os.Stat(folderPath); os.IsNotExist(err) {
 		err := os.MkdirAll(folderPath, os.ModePerm)
 		if err != nil {
 			return err
 		}
 	}
-	filename := fmt.Sprintf("sia _a %s", getShortLivedRDPFile.TargetAddress)
-	if getShortLivedRDPFile.TargetDomain != "" {
-		filename += fmt.Sprintf(" _d %s", getShortLivedRDPFile.TargetDomain)
-	}
-	return os.WriteFile(filepath.Join(folderPath, filename+".rdp"), []byte(result.Token["text"].(string)), 0644)
+	return os.WriteFile(filepath.Join(folderPath, rdpFile.FileName), []byte(rdpFile.Content), 0644)
 }
 
 // ShortLivedPassword generates a short-lived password token for the user to connect.
 func (s *IdsecSIASSOService) ShortLivedPassword(getShortLivedPassword *ssomodels.IdsecSIASSOGetShortLivedPassword) (string, error) {
 	s.Logger.Info("Generating short lived password token")
-	if getShortLivedPassword.AllowCaching {
+	result, err := s.acquirePassword(getShortLivedPassword.Service, getShortLivedPassword.AllowCaching)
+	if err != nil {
+		return "", err
+	}
+	return result.Token["key"].(string), nil
+}
+
+// ShortLivedPasswordContent generates a short-lived password token and returns it together with
+// the time it expires, so callers holding on to it know when to generate a new one.
+func (s *IdsecSIASSOService) ShortLivedPasswordContent(getShortLivedPassword *ssomodels.IdsecSIASSOGetShortLivedPassword) (*ssomodels.IdsecSIASSOShortLivedPassword, error) {
+	s.Logger.Info("Generating short lived password token")
+	result, err := s.acquirePassword(getShortLivedPassword.Service, getShortLivedPassword.AllowCaching)
+	if err != nil {
+		return nil, err
+	}
+	password := &ssomodels.IdsecSIASSOShortLivedPassword{
+		Password: result.Token["key"].(string),
+	}
+	if expiresAt, ok := result.Metadata["expires_at"].(string); ok {
+		password.ExpiresAt = expiresAt
+	}
+	return password, nil
+}
+
+func (s *IdsecSIASSOService) acquirePassword(service string, allowCaching bool) (*ssomodels.IdsecSIASSOAcquireTokenResponse, error) {
+	if allowCaching {
 		result, err := s.loadFromCache("password")
 		if err == nil && result != nil {
-			return result.Token["key"].(string), nil
+			if _, ok := result.Token["key"].(string); ok {
+				return result, nil
+			}
 		}
 	}
 	response, err := s.ISPClient().Post(context.Background(), acquireSsoTokenURL, map[string]interface{}{
 		"token_type": "password",
-		"service":    getShortLivedPassword.Service,
+		"service":    service,
 	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
@@ -322,37 +351,69 @@ func (s *IdsecSIASSOService) ShortLivedPassword(getShortLivedPassword *ssomodels
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return "", fmt.Errorf("failed to generate short lived password - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, fmt.Errorf("failed to generate short lived password - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
 	}
 	var result ssomodels.IdsecSIASSOAcquireTokenResponse
 	err = json.NewDecoder(response.Body).Decode(&result)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	if key, ok := result.Token["key"].(string); ok {
-		if getShortLivedPassword.AllowCaching {
+	if _, ok := result.Token["key"].(string); ok {
+		if allowCaching {
 			_ = s.saveToCache(&result, "password")
 		}
-		return key, nil
+		return &result, nil
 	}
-	return "", fmt.Errorf("failed to generate short lived password - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	return nil, fmt.Errorf("failed to generate short lived password - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
 }
 
 // ShortLivedClientCertificate generates a short-lived client certificate for the user to connect.
 func (s *IdsecSIASSOService) ShortLivedClientCertificate(getShortLivedClientCertificate *ssomodels.IdsecSIASSOGetShortLivedClientCertificate) error {
 	s.Logger.Info("Generating short lived client certificate")
-	if getShortLivedClientCertificate.AllowCaching {
+	result, err := s.acquireClientCertificate(getShortLivedClientCertificate.Service, getShortLivedClientCertificate.AllowCaching)
+	if err != nil {
+		return err
+	}
+	return s.outputClientCertificate(getShortLivedClientCertificate.Folder, getShortLivedClientCertificate.OutputFormat, result)
+}
+
+// ShortLivedClientCertificateContent generates a short-lived client certificate and returns it
+// in memory instead of printing it or writing it to a folder.
+func (s *IdsecSIASSOService) ShortLivedClientCertificateContent(getShortLivedClientCertificate *ssomodels.IdsecSIASSOGetShortLivedClientCertificateContent) (*ssomodels.IdsecSIASSOShortLivedClientCertificate, error) {
+	s.Logger.Info("Generating short lived client certificate")
+	result, err := s.acquireClientCertificate(getShortLivedClientCertificate.Service, getShortLivedClientCertificate.AllowCaching)
+	if err != nil {
+		return nil, err
+	}
+	clientCertificate, privateKey, ok := clientCertificateFromToken(result)
+	if !ok {
+		return nil, errors.New("short lived client certificate response is missing the certificate or private key")
+	}
+	certificate := &ssomodels.IdsecSIASSOShortLivedClientCertificate{
+		ClientCertificate: clientCertificate,
+		PrivateKey:        privateKey,
+	}
+	if expiresAt, ok := result.Metadata["expires_at"].(string); ok {
+		certificate.ExpiresAt = expiresAt
+	}
+	return certificate, nil
+}
+
+func (s *IdsecSIASSOService) acquireClientCertificate(service string, allowCaching bool) (*ssomodels.IdsecSIASSOAcquireTokenResponse, error) {
+	if allowCaching {
 		result, err := s.loadFromCache("client_certificate")
 		if err == nil && result != nil {
-			return s.outputClientCertificate(getShortLivedClientCertificate.Folder, getShortLivedClientCertificate.OutputFormat, result)
+			if _, _, ok := clientCertificateFromToken(result); ok {
+				return result, nil
+			}
 		}
 	}
 	response, err := s.ISPClient().Post(context.Background(), acquireSsoTokenURL, map[string]interface{}{
 		"token_type": "client_certificate",
-		"service":    getShortLivedClientCertificate.Service,
+		"service":    service,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
@@ -361,35 +422,88 @@ func (s *IdsecSIASSOService) ShortLivedClientCertificate(getShortLivedClientCert
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf("failed to generate short lived client certificate - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, fmt.Errorf("failed to generate short lived client certificate - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
 	}
 	var result ssomodels.IdsecSIASSOAcquireTokenResponse
 	err = json.NewDecoder(response.Body).Decode(&result)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if _, ok := result.Token["client_certificate"].(string); ok {
-		if _, ok := result.Token["private_key"].(string); ok {
-			if getShortLivedClientCertificate.AllowCaching {
-				_ = s.saveToCache(&result, "client_certificate")
-			}
-			return s.outputClientCertificate(getShortLivedClientCertificate.Folder, getShortLivedClientCertificate.OutputFormat, &result)
+	if _, _, ok := clientCertificateFromToken(&result); ok {
+		if allowCaching {
+			_ = s.saveToCache(&result, "client_certificate")
 		}
+		return &result, nil
+	}
+	return nil, fmt.Errorf("failed to generate short lived client certificate - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+}
+
+// clientCertificateFromToken extracts the client certificate and private key of an acquired token,
+// reporting false when either of them is missing.
+func clientCertificateFromToken(result *ssomodels.IdsecSIASSOAcquireTokenResponse) (string, string, bool) {
+	clientCertificate, ok := result.Token["client_certificate"].(string)
+	if !ok {
+		return "", "", false
+	}
+	privateKey, ok := result.Token["private_key"].(string)
+	if !ok {
+		return "", "", false
 	}
-	return fmt.Errorf("failed to generate short lived client certificate - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	return clientCertificate, privateKey, true
 }
 
 // ShortLivedOracleWallet generates a short-lived oracle wallet for the user to connect to oracle databases.
 func (s *IdsecSIASSOService) ShortLivedOracleWallet(getShortLivedOracleWallet *ssomodels.IdsecSIASSOGetShortLivedOracleWallet) error {
 	s.Logger.Info("Generating short lived oracle wallet")
-	if getShortLivedOracleWallet.AllowCaching {
+	result, err := s.acquireOracleWallet(getShortLivedOracleWallet.WalletType, getShortLivedOracleWallet.AllowCaching)
+	if err != nil {
+		return err
+	}
+	if getShortLivedOracleWallet.WalletType == ssomodels.PEM {
+		return s.saveOraclePEMWallet(getShortLivedOracleWallet.Folder, result)
+	}
+	return s.saveOracleSSOWallet(getShortLivedOracleWallet.Folder, getShortLivedOracleWallet.UnzipWallet, result)
+}
+
+// ShortLivedOracleWalletContent generates a short-lived oracle wallet and returns it
+// in memory instead of writing it to a folder.
+func (s *IdsecSIASSOService) ShortLivedOracleWalletContent(getShortLivedOracleWallet *ssomodels.IdsecSIASSOGetShortLivedOracleWalletContent) (*ssomodels.IdsecSIASSOShortLivedOracleWallet, error) {
+	s.Logger.Info("Generating short lived oracle wallet")
+	result, err := s.acquireOracleWallet(getShortLivedOracleWallet.WalletType, getShortLivedOracleWallet.AllowCaching)
+	if err != nil {
+		return nil, err
+	}
+	baseName, err := s.userBaseName()
+	if err != nil {
+		return nil, err
+	}
+	tokenKey, fileName := "wallet", baseName+"_wallet.zip"
+	if getShortLivedOracleWallet.WalletType == ssomodels.PEM {
+		tokenKey, fileName = "pem_wallet", baseName+"_ewallet.pem"
+	}
+	wallet, err := base64.StdEncoding.DecodeString(result.Token[tokenKey].(string))
+	if err != nil {
+		return nil, err
+	}
+	return &ssomodels.IdsecSIASSOShortLivedOracleWallet{
+		WalletType: getShortLivedOracleWallet.WalletType,
+		FileName:   fileName,
+		Wallet:     wallet,
+	}, nil
+}
+
+func (s *IdsecSIASSOService) acquireOracleWallet(walletType string, allowCaching bool) (*ssomodels.IdsecSIASSOAcquireTokenResponse, error) {
+	tokenKey := "wallet"
+	if walletType == ssomodels.PEM {
+		tokenKey = "pem_wallet"
+	} else if walletType != ssomodels.SSO {
+		return nil, fmt.Errorf("unknown wallet type [%v]", walletType)
+	}
+	if allowCaching {
 		result, err := s.loadFromCache("oracle_wallet")
 		if err == nil && result != nil {
-			if getShortLivedOracleWallet.WalletType == ssomodels.SSO {
-				return s.saveOracleSSOWallet(getShortLivedOracleWallet.Folder, getShortLivedOracleWallet.UnzipWallet, result)
-			}
-			if getShortLivedOracleWallet.WalletType == ssomodels.PEM {
-				return s.saveOraclePEMWallet(getShortLivedOracleWallet.Folder, result)
+			if _, ok := result.Token[tokenKey].(string); ok {
+				return result, nil
 			}
 		}
 	}
@@ -397,11 +511,11 @@ func (s *IdsecSIASSOService) ShortLivedOracleWallet(getShortLivedOracleWallet *s
 		"token_type": "oracle_wallet",
 		"service":    "DPA-DB",
 		"token_parameters": map[string]interface{}{
-			"walletType": getShortLivedOracleWallet.WalletType,
+			"walletType": walletType,
 		},
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
@@ -410,35 +524,57 @@ func (s *IdsecSIASSOService) ShortLivedOracleWallet(getShortLivedOracleWallet *s
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf("failed to generate short lived oracle wallet - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, fmt.Errorf("failed to generate short lived oracle wallet - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
 	}
 	var result ssomodels.IdsecSIASSOAcquireTokenResponse
 	err = json.NewDecoder(response.Body).Decode(&result)
 	if err != nil {
-		return err
-	}
-	if _, ok := result.Token["wallet"].(string); ok && getShortLivedOracleWallet.WalletType == ssomodels.SSO {
-		if getShortLivedOracleWallet.AllowCaching {
-			_ = s.saveToCache(&result, "oracle_wallet")
-		}
-		return s.saveOracleSSOWallet(getShortLivedOracleWallet.Folder, getShortLivedOracleWallet.UnzipWallet, &result)
+		return nil, err
 	}
-	if _, ok := result.Token["pem_wallet"].(string); ok && getShortLivedOracleWallet.WalletType == ssomodels.PEM {
-		if getShortLivedOracleWallet.AllowCaching {
+	if _, ok := result.Token[tokenKey].(string); ok {
+		if allowCaching {
 			_ = s.saveToCache(&result, "oracle_wallet")
 		}
-		return s.saveOraclePEMWallet(getShortLivedOracleWallet.Folder, &result)
+		return &result, nil
 	}
-	return fmt.Errorf("failed to generate short lived oracle wallet - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	return nil, fmt.Errorf("failed to generate short lived oracle wallet - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
 }
 
 // ShortLivedRdpFile generates a short-lived RDP file for the user to connect to remote desktops.
 func (s *IdsecSIASSOService) ShortLivedRdpFile(getShortLivedRDPFile *ssomodels.IdsecSIASSOGetShortLivedRDPFile) error {
 	s.Logger.Info("Generating short lived rdp file")
+	rdpFile, err := s.acquireRDPFile(&ssomodels.IdsecSIASSOGetShortLivedRDPFileContent{
+		AllowCaching:       getShortLivedRDPFile.AllowCaching,
+		TargetAddress:      getShortLivedRDPFile.TargetAddress,
+		TargetDomain:       getShortLivedRDPFile.TargetDomain,
+		TargetUser:         getShortLivedRDPFile.TargetUser,
+		ElevatedPrivileges: getShortLivedRDPFile.ElevatedPrivileges,
+	})
+	if err != nil {
+		return err
+	}
+	return s.saveRDPFile(getShortLivedRDPFile.Folder, rdpFile)
+}
+
+// ShortLivedRdpFileContent generates a short-lived RDP file and returns it
+// in memory instead of writing it to a folder.
+func (s *IdsecSIASSOService) ShortLivedRdpFileContent(getShortLivedRDPFile *ssomodels.IdsecSIASSOGetShortLivedRDPFileContent) (*ssomodels.IdsecSIASSOShortLivedRDPFile, error) {
+	s.Logger.Info("Generating short lived rdp file")
+	return s.acquireRDPFile(getShortLivedRDPFile)
+}
+
+func (s *IdsecSIASSOService) acquireRDPFile(getShortLivedRDPFile *ssomodels.IdsecSIASSOGetShortLivedRDPFileContent) (*ssomodels.IdsecSIASSOShortLivedRDPFile, error) {
+	fileName := fmt.Sprintf("sia _a %s", getShortLivedRDPFile.TargetAddress)
+	if getShortLivedRDPFile.TargetDomain != "" {
+		fileName += fmt.Sprintf(" _d %s", getShortLivedRDPFile.TargetDomain)
+	}
+	fileName += ".rdp"
 	if getShortLivedRDPFile.AllowCaching {
 		result, err := s.loadFromCache("rdp_file")
 		if err == nil && result != nil {
-			return s.saveRDPFile(getShortLivedRDPFile, result)
+			if text, ok := result.Token["text"].(string); ok {
+				return &ssomodels.IdsecSIASSOShortLivedRDPFile{FileName: fileName, Content: text}, nil
+			}
 		}
 	}
 	tokenParameters := map[string]interface{}{
@@ -459,7 +595,7 @@ func (s *IdsecSIASSOService) ShortLivedRdpFile(getShortLivedRDPFile *ssomodels.I
 		"token_response_format": "extended",
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
@@ -468,20 +604,20 @@ func (s *IdsecSIASSOService) ShortLivedRdpFile(getShortLivedRDPFile *ssomodels.I
 		}
 	}(response.Body)
 	if response.StatusCode != http.StatusCreated {
-		return fmt.Errorf("failed to generate short lived rdp file - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+		return nil, fmt.Errorf("failed to generate short lived rdp file - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
 	}
 	var result ssomodels.IdsecSIASSOAcquireTokenResponse
 	err = json.NewDecoder(response.Body).Decode(&result)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if _, ok := result.Token["text"].(string); ok {
+	if text, ok := result.Token["text"].(string); ok {
 		if getShortLivedRDPFile.AllowCaching {
 			_ = s.saveToCache(&result, "rdp_file")
 		}
-		return s.saveRDPFile(getShortLivedRDPFile, &result)
+		return &ssomodels.IdsecSIASSOShortLivedRDPFile{FileName: fileName, Content: text}, nil
 	}
-	return fmt.Errorf("failed to generate short rdp file - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
+	return nil, fmt.Errorf("failed to generate short rdp file - [%d] - [%s]", response.StatusCode, common.SerializeResponseToJSON(response.Body))
 }
 
 // ShortLivedSshKey generates a short-lived SSH key for the user to connect to remote servers.
@@ -15,3 +15,16 @@ type IdsecSIASSOGetShortLivedClientCertificate struct {
 	OutputFormat string `json:"output_format" mapstructure:"output_format" flag:"output-format" desc:"The output format of the key / ' 'certificate. For example, File, Raw, or Base64." default:"file" choices:"file,single_file,raw,base64"`
 	Service      string `json:"service" validate:"required" mapstructure:"service" flag:"service" desc:"The service for which to generate the short-lived certificate - DPA-DB, DPA-K8S." choices:"DPA-DB,DPA-K8S"`
 }
+
+// IdsecSIASSOGetShortLivedClientCertificateContent is a struct that represents the request for getting a short-lived client certificate in memory from the Idsec SIA SSO service.
+type IdsecSIASSOGetShortLivedClientCertificateContent struct {
+	AllowCaching bool   `json:"allow_caching" mapstructure:"allow_caching" flag:"allow-caching" desc:"Indicates whether to allow short-lived token caching." default:"false"`
+	Service      string `json:"service" validate:"required" mapstructure:"service" flag:"service" desc:"The service for which to generate the short-lived certificate - DPA-DB, DPA-K8S." choices:"DPA-DB,DPA-K8S"`
+}
+
+// IdsecSIASSOShortLivedClientCertificate is a struct that represents a short-lived client certificate and its private key.
+type IdsecSIASSOShortLivedClientCertificate struct {
+	ClientCertificate string `json:"client_certificate" mapstructure:"client_certificate" desc:"The PEM-encoded short-lived client certificate."`
+	PrivateKey        string `json:"private_key" mapstructure:"private_key" desc:"The PEM-encoded private key of the client certificate."`
+	ExpiresAt         string `json:"expires_at,omitempty" mapstructure:"expires_at,omitempty" desc:"The time the client certificate expires (RFC3339)."`
+}
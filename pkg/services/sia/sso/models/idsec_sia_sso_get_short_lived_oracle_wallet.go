@@ -13,3 +13,16 @@ type IdsecSIASSOGetShortLivedOracleWallet struct {
 	Folder       string `json:"folder" validate:"required" mapstructure:"folder" flag:"folder" desc:"The output folder to which the wallet is written."`
 	WalletType   string `json:"wallet_type" mapstructure:"wallet_type" flag:"wallet-type" desc:"The type of wallet to generate. If PEM, no zip will be generated, only an ewallet.pem file." default:"SSO" choices:"PEM,SSO"`
 }
+
+// IdsecSIASSOGetShortLivedOracleWalletContent is a struct that represents the request for getting a short-lived Oracle wallet in memory from the Idsec SIA SSO service.
+type IdsecSIASSOGetShortLivedOracleWalletContent struct {
+	AllowCaching bool   `json:"allow_caching" mapstructure:"allow_caching" flag:"allow-caching" desc:"Indicates whether to allow short-lived token caching." default:"false"`
+	WalletType   string `json:"wallet_type" mapstructure:"wallet_type" flag:"wallet-type" desc:"The type of wallet to generate. If PEM, the wallet is an ewallet.pem file, otherwise a zip." default:"SSO" choices:"PEM,SSO"`
+}
+
+// IdsecSIASSOShortLivedOracleWallet is a struct that represents a short-lived Oracle wallet.
+type IdsecSIASSOShortLivedOracleWallet struct {
+	WalletType string `json:"wallet_type" mapstructure:"wallet_type" desc:"The type of the wallet, PEM or SSO."`
+	FileName   string `json:"file_name" mapstructure:"file_name" desc:"The suggested file name of the wallet."`
+	Wallet     []byte `json:"wallet" mapstructure:"wallet" desc:"The wallet content, a zip for SSO wallets or an ewallet.pem for PEM wallets."`
+}
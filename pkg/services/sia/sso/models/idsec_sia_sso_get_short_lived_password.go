@@ -5,3 +5,9 @@ type IdsecSIASSOGetShortLivedPassword struct {
 	AllowCaching bool   `json:"allow_caching" mapstructure:"allow_caching" flag:"allow-caching" desc:"Indicates whether to allow short-lived token caching." default:"false"`
 	Service      string `json:"service" mapstructure:"service" flag:"service" desc:"The service for which to get the token info." choices:"DPA-DB,DPA-RDP" default:"DPA-DB"`
 }
+
+// IdsecSIASSOShortLivedPassword is a struct that represents a short-lived password and the time it expires.
+type IdsecSIASSOShortLivedPassword struct {
+	Password  string `json:"password" mapstructure:"password" desc:"The short-lived password."`
+	ExpiresAt string `json:"expires_at,omitempty" mapstructure:"expires_at,omitempty" desc:"The time the password expires (RFC3339)."`
+}
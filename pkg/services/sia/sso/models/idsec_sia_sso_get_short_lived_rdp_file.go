@@ -9,3 +9,18 @@ type IdsecSIASSOGetShortLivedRDPFile struct {
 	TargetUser         string `json:"target_user,omitempty" mapstructure:"target_user,omitempty" flag:"target-user" desc:"The target user to use for the RDP file."`
 	ElevatedPrivileges bool   `json:"elevated_privileges" mapstructure:"elevated_privileges" flag:"elevated-privileges" desc:"Indicates whether to use elevated privileges."`
 }
+
+// IdsecSIASSOGetShortLivedRDPFileContent is a struct that represents the request for getting a short-lived RDP file in memory from the Idsec SIA SSO service.
+type IdsecSIASSOGetShortLivedRDPFileContent struct {
+	AllowCaching       bool   `json:"allow_caching" mapstructure:"allow_caching" flag:"allow-caching" desc:"Indicates whether to allow short-lived token caching." default:"false"`
+	TargetAddress      string `json:"target_address" validate:"required" mapstructure:"target_address"`
+	TargetDomain       string `json:"target_domain,omitempty" mapstructure:"target_domain,omitempty" flag:"target-domain" desc:"The target domain to use for the RDP file."`
+	TargetUser         string `json:"target_user,omitempty" mapstructure:"target_user,omitempty" flag:"target-user" desc:"The target user to use for the RDP file."`
+	ElevatedPrivileges bool   `json:"elevated_privileges" mapstructure:"elevated_privileges" flag:"elevated-privileges" desc:"Indicates whether to use elevated privileges."`
+}
+
+// IdsecSIASSOShortLivedRDPFile is a struct that represents a short-lived RDP file.
+type IdsecSIASSOShortLivedRDPFile struct {
+	FileName string `json:"file_name" mapstructure:"file_name" desc:"The suggested file name of the RDP file."`
+	Content  string `json:"content" mapstructure:"content" desc:"The content of the RDP file."`
+}